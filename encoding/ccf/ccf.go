@@ -0,0 +1,239 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ccf implements the Cadence Compact Format (CCF),
+// a compact, deterministic, CBOR-based encoding of Cadence values.
+//
+// In contrast to JSON-Cadence, types are not repeated for every value:
+// The definitions of all composite and interface types are written once per message,
+// and are referred to by their index in the message.
+// Values are encoded bare, i.e. without their type,
+// if their type is exactly the static type of the position they are encoded at,
+// e.g. the element type of an array, or the type of a field.
+// Otherwise, they are encoded together with their type.
+//
+// A message has the following structure (CDDL):
+//
+//   message = #6.128([
+//       ; the kinds and IDs of the type definitions,
+//       ; so definitions can refer to each other, and to themselves
+//       type-definition-headers: [* type-definition-header],
+//       type-definition-bodies: [* type-definition-body],
+//       value: self-describing-value,
+//   ])
+//
+//   type-definition-header = #6.152-159(type-id: tstr)
+//
+//   type-definition-body = [
+//       fields: [* [identifier: tstr, type: type]] / null,
+//       ; for event types, the parameters of the single initializer
+//       initializers: [* [* parameter] / null] / [* parameter] / null,
+//       ; for enum types, the raw type
+//       raw-type: type,
+//   ]
+//
+//   self-describing-value =
+//       #6.129([type: type, value: bare-value])
+//     / #6.130([* self-describing-value])                              ; array without type
+//     / #6.131([* (key: self-describing-value, value: self-describing-value)]) ; dictionary without type
+//     / #6.132([target-path: [domain: tstr, identifier: tstr], borrow-type: tstr]) ; link
+//
+//   type = null
+//     / #6.136(simple-type-code: uint)
+//     / #6.137(type)                                     ; optional
+//     / #6.138(type)                                     ; variable-sized array
+//     / #6.139([size: uint, type])                       ; constant-sized array
+//     / #6.140([key-type: type, element-type: type])     ; dictionary
+//     / #6.141([authorized: bool, type])                 ; reference
+//     / #6.142([type-id: tstr, type, [* type] / null])   ; restricted
+//     / #6.143(type)                                     ; capability
+//     / #6.144([type-id: tstr, [* parameter] / null, return-type: type]) ; function
+//     / #6.145(type-definition-index: uint)              ; composite or interface type
+//
+//   parameter = [label: tstr, identifier: tstr, type: type]
+//
+// Bare values are encoded as CBOR data items depending on their type,
+// e.g. integers as CBOR integers or bignums, strings as text strings,
+// arrays as arrays of their elements, and composites as arrays of their field values.
+// Dictionaries are encoded as arrays of their keys and values,
+// with the pairs in canonical order, i.e. sorted by the encoding of their keys,
+// so the encoding does not depend on the insertion order of the pairs.
+//
+package ccf
+
+import (
+	"math"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/onflow/cadence"
+)
+
+// !!! *WARNING* !!!
+//
+// Only add new tags before the warning at the end of each section.
+// DO *NOT* REPLACE EXISTING TAGS!
+// DO *NOT* REMOVE EXISTING TAGS!
+// DO *NOT* INSERT NEW TAGS IN BETWEEN EXISTING TAGS!
+//
+// This is an encoding, so existing encoded data would become undecodable.
+//
+const (
+	// Message
+	cborTagMessage = 128 + iota
+
+	// Self-describing values
+	cborTagTypeAndValue
+	cborTagUntypedArray
+	cborTagUntypedDictionary
+	cborTagLink
+	_
+	_
+	_
+
+	// Types
+	cborTagSimpleType
+	cborTagOptionalType
+	cborTagVariableSizedArrayType
+	cborTagConstantSizedArrayType
+	cborTagDictionaryType
+	cborTagReferenceType
+	cborTagRestrictedType
+	cborTagCapabilityType
+	cborTagFunctionType
+	cborTagTypeDefinitionReference
+	_
+	_
+	_
+	_
+	_
+	_
+
+	// Type definitions
+	cborTagStructType
+	cborTagResourceType
+	cborTagEventType
+	cborTagContractType
+	cborTagEnumType
+	cborTagStructInterfaceType
+	cborTagResourceInterfaceType
+	cborTagContractInterfaceType
+)
+
+// isSelfDescribingValueTag returns true if the given tag number
+// is the tag of a self-describing value
+//
+func isSelfDescribingValueTag(number uint64) bool {
+	return number >= cborTagTypeAndValue && number <= cborTagLink
+}
+
+// simpleTypes are the types which are encoded as a simple type code,
+// which is the index of the type in this list.
+//
+// !!! *WARNING* !!!
+// Only append new types. DO *NOT* REORDER OR REMOVE EXISTING TYPES!
+//
+var simpleTypes = []cadence.Type{
+	cadence.AnyType{},
+	cadence.AnyStructType{},
+	cadence.AnyResourceType{},
+	cadence.MetaType{},
+	cadence.VoidType{},
+	cadence.NeverType{},
+	cadence.BoolType{},
+	cadence.StringType{},
+	cadence.CharacterType{},
+	cadence.BytesType{},
+	cadence.AddressType{},
+	cadence.NumberType{},
+	cadence.SignedNumberType{},
+	cadence.IntegerType{},
+	cadence.SignedIntegerType{},
+	cadence.FixedPointType{},
+	cadence.SignedFixedPointType{},
+	cadence.IntType{},
+	cadence.Int8Type{},
+	cadence.Int16Type{},
+	cadence.Int32Type{},
+	cadence.Int64Type{},
+	cadence.Int128Type{},
+	cadence.Int256Type{},
+	cadence.UIntType{},
+	cadence.UInt8Type{},
+	cadence.UInt16Type{},
+	cadence.UInt32Type{},
+	cadence.UInt64Type{},
+	cadence.UInt128Type{},
+	cadence.UInt256Type{},
+	cadence.Word8Type{},
+	cadence.Word16Type{},
+	cadence.Word32Type{},
+	cadence.Word64Type{},
+	cadence.Fix64Type{},
+	cadence.UFix64Type{},
+	cadence.BlockType{},
+	cadence.PathType{},
+	cadence.CapabilityPathType{},
+	cadence.StoragePathType{},
+	cadence.PublicPathType{},
+	cadence.PrivatePathType{},
+	cadence.AuthAccountType{},
+	cadence.PublicAccountType{},
+	cadence.AuthAccountKeysType{},
+	cadence.PublicAccountKeysType{},
+	cadence.AuthAccountContractsType{},
+	cadence.PublicAccountContractsType{},
+	cadence.DeployedContractType{},
+	cadence.AccountKeyType{},
+}
+
+var simpleTypeCodes = func() map[cadence.Type]uint64 {
+	codes := make(map[cadence.Type]uint64, len(simpleTypes))
+	for code, simpleType := range simpleTypes {
+		codes[simpleType] = uint64(code)
+	}
+	return codes
+}()
+
+// CBOREncMode
+//
+// See https://github.com/fxamacker/cbor:
+// "For best performance, reuse EncMode and DecMode after creating them."
+//
+var CBOREncMode = func() cbor.EncMode {
+	options := cbor.CanonicalEncOptions()
+	options.BigIntConvert = cbor.BigIntConvertNone
+	encMode, err := options.EncMode()
+	if err != nil {
+		panic(err)
+	}
+	return encMode
+}()
+
+var CBORDecMode = func() cbor.DecMode {
+	decMode, err := cbor.DecOptions{
+		IntDec:           cbor.IntDecConvertNone,
+		MaxArrayElements: math.MaxInt64,
+		MaxMapPairs:      math.MaxInt64,
+		MaxNestedLevels:  math.MaxInt16,
+	}.DecMode()
+	if err != nil {
+		panic(err)
+	}
+	return decMode
+}()
@@ -0,0 +1,1282 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ccf
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// A Decoder decodes CCF-encoded representations of Cadence values.
+type Decoder struct {
+	dec *cbor.StreamDecoder
+}
+
+// Decode returns a Cadence value decoded from its CCF-encoded representation.
+//
+// This function returns an error if the bytes represent CBOR that is malformed
+// or does not conform to the CCF specification.
+func Decode(b []byte) (cadence.Value, error) {
+	dec := &Decoder{
+		dec: CBORDecMode.NewByteStreamDecoder(b),
+	}
+
+	v, err := dec.Decode()
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// NewDecoder initializes a Decoder that will decode CCF-encoded bytes from the
+// given io.Reader.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{
+		dec: CBORDecMode.NewStreamDecoder(r),
+	}
+}
+
+// Decode reads CCF-encoded bytes from the io.Reader and decodes them to a
+// Cadence value.
+//
+// This function returns an error if the bytes represent CBOR that is malformed
+// or does not conform to the CCF specification.
+func (d *Decoder) Decode() (cadence.Value, error) {
+	dec := &decoder{
+		dec: d.dec,
+	}
+
+	value, err := dec.decodeMessage()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode value: %w", err)
+	}
+
+	return value, nil
+}
+
+var ErrInvalidCCF = errors.New("invalid CCF structure")
+
+func invalidCCFError(format string, a ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidCCF, fmt.Sprintf(format, a...))
+}
+
+// decoder decodes a message from a CBOR stream
+//
+type decoder struct {
+	dec         *cbor.StreamDecoder
+	definitions []cadence.Type
+}
+
+func (d *decoder) decodeMessage() (cadence.Value, error) {
+	number, err := d.dec.DecodeTagNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	if number != cborTagMessage {
+		return nil, invalidCCFError("invalid message tag: %d", number)
+	}
+
+	err = d.decodeArrayHead(3)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode the type definition headers first,
+	// so the type definition bodies can refer to all type definitions
+
+	err = d.decodeTypeDefinitionHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.decodeTypeDefinitionBodies()
+	if err != nil {
+		return nil, err
+	}
+
+	return d.decodeSelfDescribingValue()
+}
+
+func (d *decoder) decodeArrayHead(expectedLength uint64) error {
+	length, err := d.dec.DecodeArrayHead()
+	if err != nil {
+		return err
+	}
+
+	if length != expectedLength {
+		return invalidCCFError("invalid array length: expected %d, got %d", expectedLength, length)
+	}
+
+	return nil
+}
+
+// decodeNil decodes a CBOR nil, if the next data item is nil,
+// and returns true if it did
+//
+func (d *decoder) decodeNil() (bool, error) {
+	nextType, err := d.dec.NextType()
+	if err != nil {
+		return false, err
+	}
+
+	if nextType != cbor.NilType {
+		return false, nil
+	}
+
+	return true, d.dec.DecodeNil()
+}
+
+// decodeOptionalTagNumber decodes the tag number of a tag,
+// if the next data item is a tag
+//
+func (d *decoder) decodeOptionalTagNumber() (*uint64, error) {
+	nextType, err := d.dec.NextType()
+	if err != nil {
+		return nil, err
+	}
+
+	if nextType != cbor.TagType {
+		return nil, nil
+	}
+
+	number, err := d.dec.DecodeTagNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	return &number, nil
+}
+
+func (d *decoder) decodeTypeDefinitionHeaders() error {
+	count, err := d.dec.DecodeArrayHead()
+	if err != nil {
+		return err
+	}
+
+	d.definitions = make([]cadence.Type, count)
+
+	for i := range d.definitions {
+		d.definitions[i], err = d.decodeTypeDefinitionHeader()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *decoder) decodeTypeDefinitionHeader() (cadence.Type, error) {
+	number, err := d.dec.DecodeTagNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	typeID, err := d.dec.DecodeString()
+	if err != nil {
+		return nil, err
+	}
+
+	location, qualifiedIdentifier, err := common.DecodeTypeID(typeID)
+	if err != nil {
+		return nil, invalidCCFError("invalid type ID: `%s`", typeID)
+	}
+
+	switch number {
+	case cborTagStructType:
+		return &cadence.StructType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}, nil
+
+	case cborTagResourceType:
+		return &cadence.ResourceType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}, nil
+
+	case cborTagEventType:
+		return &cadence.EventType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}, nil
+
+	case cborTagContractType:
+		return &cadence.ContractType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}, nil
+
+	case cborTagEnumType:
+		return &cadence.EnumType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}, nil
+
+	case cborTagStructInterfaceType:
+		return &cadence.StructInterfaceType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}, nil
+
+	case cborTagResourceInterfaceType:
+		return &cadence.ResourceInterfaceType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}, nil
+
+	case cborTagContractInterfaceType:
+		return &cadence.ContractInterfaceType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}, nil
+
+	default:
+		return nil, invalidCCFError("invalid type definition tag: %d", number)
+	}
+}
+
+func (d *decoder) decodeTypeDefinitionBodies() error {
+	err := d.decodeArrayHead(uint64(len(d.definitions)))
+	if err != nil {
+		return err
+	}
+
+	for _, t := range d.definitions {
+		err = d.decodeTypeDefinitionBody(t)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *decoder) decodeTypeDefinitionBody(t cadence.Type) error {
+	err := d.decodeArrayHead(3)
+	if err != nil {
+		return err
+	}
+
+	fields, err := d.decodeFields()
+	if err != nil {
+		return err
+	}
+
+	// Events have a single initializer, which is encoded directly
+
+	var initializer []cadence.Parameter
+	var initializers [][]cadence.Parameter
+
+	if _, ok := t.(*cadence.EventType); ok {
+		initializer, err = d.decodeParameters()
+	} else {
+		initializers, err = d.decodeInitializers()
+	}
+	if err != nil {
+		return err
+	}
+
+	rawType, err := d.decodeType()
+	if err != nil {
+		return err
+	}
+
+	switch t := t.(type) {
+	case *cadence.StructType:
+		t.Fields = fields
+		t.Initializers = initializers
+
+	case *cadence.ResourceType:
+		t.Fields = fields
+		t.Initializers = initializers
+
+	case *cadence.EventType:
+		t.Fields = fields
+		t.Initializer = initializer
+
+	case *cadence.ContractType:
+		t.Fields = fields
+		t.Initializers = initializers
+
+	case *cadence.EnumType:
+		t.Fields = fields
+		t.Initializers = initializers
+		t.RawType = rawType
+
+	case *cadence.StructInterfaceType:
+		t.Fields = fields
+		t.Initializers = initializers
+
+	case *cadence.ResourceInterfaceType:
+		t.Fields = fields
+		t.Initializers = initializers
+
+	case *cadence.ContractInterfaceType:
+		t.Fields = fields
+		t.Initializers = initializers
+	}
+
+	return nil
+}
+
+func (d *decoder) decodeFields() ([]cadence.Field, error) {
+	isNil, err := d.decodeNil()
+	if err != nil || isNil {
+		return nil, err
+	}
+
+	count, err := d.dec.DecodeArrayHead()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]cadence.Field, count)
+
+	for i := range fields {
+		err = d.decodeArrayHead(2)
+		if err != nil {
+			return nil, err
+		}
+
+		fields[i].Identifier, err = d.dec.DecodeString()
+		if err != nil {
+			return nil, err
+		}
+
+		fields[i].Type, err = d.decodeType()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return fields, nil
+}
+
+func (d *decoder) decodeInitializers() ([][]cadence.Parameter, error) {
+	isNil, err := d.decodeNil()
+	if err != nil || isNil {
+		return nil, err
+	}
+
+	count, err := d.dec.DecodeArrayHead()
+	if err != nil {
+		return nil, err
+	}
+
+	initializers := make([][]cadence.Parameter, count)
+
+	for i := range initializers {
+		initializers[i], err = d.decodeParameters()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return initializers, nil
+}
+
+func (d *decoder) decodeParameters() ([]cadence.Parameter, error) {
+	isNil, err := d.decodeNil()
+	if err != nil || isNil {
+		return nil, err
+	}
+
+	count, err := d.dec.DecodeArrayHead()
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := make([]cadence.Parameter, count)
+
+	for i := range parameters {
+		err = d.decodeArrayHead(3)
+		if err != nil {
+			return nil, err
+		}
+
+		parameters[i].Label, err = d.dec.DecodeString()
+		if err != nil {
+			return nil, err
+		}
+
+		parameters[i].Identifier, err = d.dec.DecodeString()
+		if err != nil {
+			return nil, err
+		}
+
+		parameters[i].Type, err = d.decodeType()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return parameters, nil
+}
+
+func (d *decoder) decodeTypes() ([]cadence.Type, error) {
+	isNil, err := d.decodeNil()
+	if err != nil || isNil {
+		return nil, err
+	}
+
+	count, err := d.dec.DecodeArrayHead()
+	if err != nil {
+		return nil, err
+	}
+
+	types := make([]cadence.Type, count)
+
+	for i := range types {
+		types[i], err = d.decodeType()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return types, nil
+}
+
+func (d *decoder) decodeType() (cadence.Type, error) {
+	isNil, err := d.decodeNil()
+	if err != nil || isNil {
+		return nil, err
+	}
+
+	number, err := d.dec.DecodeTagNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	return d.decodeTaggedType(number)
+}
+
+// decodeTaggedType decodes the content of a type with the given tag number
+//
+func (d *decoder) decodeTaggedType(number uint64) (cadence.Type, error) {
+	switch number {
+	case cborTagSimpleType:
+		code, err := d.dec.DecodeUint64()
+		if err != nil {
+			return nil, err
+		}
+
+		if code >= uint64(len(simpleTypes)) {
+			return nil, invalidCCFError("invalid simple type code: %d", code)
+		}
+
+		return simpleTypes[code], nil
+
+	case cborTagOptionalType:
+		innerType, err := d.decodeType()
+		if err != nil {
+			return nil, err
+		}
+
+		return cadence.OptionalType{
+			Type: innerType,
+		}, nil
+
+	case cborTagVariableSizedArrayType:
+		elementType, err := d.decodeType()
+		if err != nil {
+			return nil, err
+		}
+
+		return cadence.VariableSizedArrayType{
+			ElementType: elementType,
+		}, nil
+
+	case cborTagConstantSizedArrayType:
+		err := d.decodeArrayHead(2)
+		if err != nil {
+			return nil, err
+		}
+
+		size, err := d.dec.DecodeUint64()
+		if err != nil {
+			return nil, err
+		}
+
+		elementType, err := d.decodeType()
+		if err != nil {
+			return nil, err
+		}
+
+		return cadence.ConstantSizedArrayType{
+			Size:        uint(size),
+			ElementType: elementType,
+		}, nil
+
+	case cborTagDictionaryType:
+		err := d.decodeArrayHead(2)
+		if err != nil {
+			return nil, err
+		}
+
+		keyType, err := d.decodeType()
+		if err != nil {
+			return nil, err
+		}
+
+		elementType, err := d.decodeType()
+		if err != nil {
+			return nil, err
+		}
+
+		return cadence.DictionaryType{
+			KeyType:     keyType,
+			ElementType: elementType,
+		}, nil
+
+	case cborTagReferenceType:
+		err := d.decodeArrayHead(2)
+		if err != nil {
+			return nil, err
+		}
+
+		authorized, err := d.dec.DecodeBool()
+		if err != nil {
+			return nil, err
+		}
+
+		referencedType, err := d.decodeType()
+		if err != nil {
+			return nil, err
+		}
+
+		return cadence.ReferenceType{
+			Authorized: authorized,
+			Type:       referencedType,
+		}, nil
+
+	case cborTagRestrictedType:
+		err := d.decodeArrayHead(3)
+		if err != nil {
+			return nil, err
+		}
+
+		typeID, err := d.dec.DecodeString()
+		if err != nil {
+			return nil, err
+		}
+
+		restrictedType, err := d.decodeType()
+		if err != nil {
+			return nil, err
+		}
+
+		restrictions, err := d.decodeTypes()
+		if err != nil {
+			return nil, err
+		}
+
+		return cadence.RestrictedType{
+			Type:         restrictedType,
+			Restrictions: restrictions,
+		}.WithID(typeID), nil
+
+	case cborTagCapabilityType:
+		borrowType, err := d.decodeType()
+		if err != nil {
+			return nil, err
+		}
+
+		return cadence.CapabilityType{
+			BorrowType: borrowType,
+		}, nil
+
+	case cborTagFunctionType:
+		err := d.decodeArrayHead(3)
+		if err != nil {
+			return nil, err
+		}
+
+		typeID, err := d.dec.DecodeString()
+		if err != nil {
+			return nil, err
+		}
+
+		parameters, err := d.decodeParameters()
+		if err != nil {
+			return nil, err
+		}
+
+		returnType, err := d.decodeType()
+		if err != nil {
+			return nil, err
+		}
+
+		return cadence.FunctionType{
+			Parameters: parameters,
+			ReturnType: returnType,
+		}.WithID(typeID), nil
+
+	case cborTagTypeDefinitionReference:
+		index, err := d.dec.DecodeUint64()
+		if err != nil {
+			return nil, err
+		}
+
+		if index >= uint64(len(d.definitions)) {
+			return nil, invalidCCFError("invalid type definition index: %d", index)
+		}
+
+		return d.definitions[index], nil
+
+	default:
+		return nil, invalidCCFError("invalid type tag: %d", number)
+	}
+}
+
+// decodeValue decodes a value at a position where a value of the given static type is expected.
+//
+// The value is either encoded bare, if it has exactly the expected type,
+// or it is self-describing, i.e. its encoding starts with the tag of a self-describing value
+//
+func (d *decoder) decodeValue(expectedType cadence.Type) (cadence.Value, error) {
+	tagNumber, err := d.decodeOptionalTagNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	return d.decodeValueWithTagNumber(expectedType, tagNumber)
+}
+
+// decodeValueWithTagNumber is like decodeValue,
+// but the tag number of the value, if any, was already decoded
+//
+func (d *decoder) decodeValueWithTagNumber(expectedType cadence.Type, tagNumber *uint64) (cadence.Value, error) {
+	if tagNumber != nil && isSelfDescribingValueTag(*tagNumber) {
+		return d.decodeSelfDescribingValueContent(*tagNumber)
+	}
+
+	if expectedType == nil {
+		return nil, invalidCCFError("missing type of value")
+	}
+
+	return d.decodeBareValue(expectedType, tagNumber)
+}
+
+func (d *decoder) decodeSelfDescribingValue() (cadence.Value, error) {
+	number, err := d.dec.DecodeTagNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	if !isSelfDescribingValueTag(number) {
+		return nil, invalidCCFError("invalid value tag: %d", number)
+	}
+
+	return d.decodeSelfDescribingValueContent(number)
+}
+
+func (d *decoder) decodeSelfDescribingValueContent(number uint64) (cadence.Value, error) {
+	switch number {
+	case cborTagTypeAndValue:
+		err := d.decodeArrayHead(2)
+		if err != nil {
+			return nil, err
+		}
+
+		valueType, err := d.decodeType()
+		if err != nil {
+			return nil, err
+		}
+
+		if valueType == nil {
+			return nil, invalidCCFError("missing type of value")
+		}
+
+		tagNumber, err := d.decodeOptionalTagNumber()
+		if err != nil {
+			return nil, err
+		}
+
+		return d.decodeBareValue(valueType, tagNumber)
+
+	case cborTagUntypedArray:
+		values, err := d.decodeArrayElements(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return cadence.NewArray(values), nil
+
+	case cborTagUntypedDictionary:
+		pairs, err := d.decodeDictionaryPairs(nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return cadence.NewDictionary(pairs), nil
+
+	case cborTagLink:
+		err := d.decodeArrayHead(2)
+		if err != nil {
+			return nil, err
+		}
+
+		targetPath, err := d.decodePath()
+		if err != nil {
+			return nil, err
+		}
+
+		borrowType, err := d.dec.DecodeString()
+		if err != nil {
+			return nil, err
+		}
+
+		return cadence.NewLink(targetPath, borrowType), nil
+
+	default:
+		return nil, invalidCCFError("invalid value tag: %d", number)
+	}
+}
+
+// decodeBareValue decodes a value of the given type, which was encoded without its type.
+//
+// The tag number of the value, if any, was already decoded.
+// Only optionals, type values, and big integers may be encoded as a tag
+//
+func (d *decoder) decodeBareValue(valueType cadence.Type, tagNumber *uint64) (cadence.Value, error) {
+	switch valueType := valueType.(type) {
+	case cadence.OptionalType:
+		return d.decodeOptional(valueType, tagNumber)
+
+	case cadence.MetaType:
+		if tagNumber == nil {
+			err := d.dec.DecodeNil()
+			if err != nil {
+				return nil, err
+			}
+			return cadence.NewTypeValue(nil), nil
+		}
+
+		staticType, err := d.decodeTaggedType(*tagNumber)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewTypeValue(staticType), nil
+
+	case cadence.IntType:
+		value, err := d.decodeBigInt(tagNumber)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewIntFromBig(value), nil
+
+	case cadence.Int128Type:
+		value, err := d.decodeBigInt(tagNumber)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewInt128FromBig(value)
+
+	case cadence.Int256Type:
+		value, err := d.decodeBigInt(tagNumber)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewInt256FromBig(value)
+
+	case cadence.UIntType:
+		value, err := d.decodeBigInt(tagNumber)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewUIntFromBig(value)
+
+	case cadence.UInt128Type:
+		value, err := d.decodeBigInt(tagNumber)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewUInt128FromBig(value)
+
+	case cadence.UInt256Type:
+		value, err := d.decodeBigInt(tagNumber)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewUInt256FromBig(value)
+	}
+
+	if tagNumber != nil {
+		return nil, invalidCCFError("unexpected tag %d for value of type %s", *tagNumber, valueType.ID())
+	}
+
+	switch valueType := valueType.(type) {
+	case cadence.VoidType:
+		err := d.dec.DecodeNil()
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewVoid(), nil
+
+	case cadence.BoolType:
+		value, err := d.dec.DecodeBool()
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewBool(value), nil
+
+	case cadence.StringType:
+		value, err := d.dec.DecodeString()
+		if err != nil {
+			return nil, err
+		}
+		return cadence.String(value), nil
+
+	case cadence.CharacterType:
+		value, err := d.dec.DecodeString()
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewCharacter(value)
+
+	case cadence.BytesType:
+		value, err := d.dec.DecodeBytes()
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			value = []byte{}
+		}
+		return cadence.NewBytes(value), nil
+
+	case cadence.AddressType:
+		return d.decodeAddress()
+
+	case cadence.Int8Type:
+		value, err := d.decodeInt64(math.MinInt8, math.MaxInt8)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewInt8(int8(value)), nil
+
+	case cadence.Int16Type:
+		value, err := d.decodeInt64(math.MinInt16, math.MaxInt16)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewInt16(int16(value)), nil
+
+	case cadence.Int32Type:
+		value, err := d.decodeInt64(math.MinInt32, math.MaxInt32)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewInt32(int32(value)), nil
+
+	case cadence.Int64Type:
+		value, err := d.decodeInt64(math.MinInt64, math.MaxInt64)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewInt64(value), nil
+
+	case cadence.UInt8Type:
+		value, err := d.decodeUint64(math.MaxUint8)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewUInt8(uint8(value)), nil
+
+	case cadence.UInt16Type:
+		value, err := d.decodeUint64(math.MaxUint16)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewUInt16(uint16(value)), nil
+
+	case cadence.UInt32Type:
+		value, err := d.decodeUint64(math.MaxUint32)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewUInt32(uint32(value)), nil
+
+	case cadence.UInt64Type:
+		value, err := d.decodeUint64(math.MaxUint64)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewUInt64(value), nil
+
+	case cadence.Word8Type:
+		value, err := d.decodeUint64(math.MaxUint8)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewWord8(uint8(value)), nil
+
+	case cadence.Word16Type:
+		value, err := d.decodeUint64(math.MaxUint16)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewWord16(uint16(value)), nil
+
+	case cadence.Word32Type:
+		value, err := d.decodeUint64(math.MaxUint32)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewWord32(uint32(value)), nil
+
+	case cadence.Word64Type:
+		value, err := d.decodeUint64(math.MaxUint64)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewWord64(value), nil
+
+	case cadence.Fix64Type:
+		value, err := d.decodeInt64(math.MinInt64, math.MaxInt64)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.Fix64(value), nil
+
+	case cadence.UFix64Type:
+		value, err := d.decodeUint64(math.MaxUint64)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.UFix64(value), nil
+
+	case cadence.VariableSizedArrayType:
+		values, err := d.decodeArrayElements(valueType.ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewArray(values).WithType(valueType), nil
+
+	case cadence.ConstantSizedArrayType:
+		values, err := d.decodeArrayElements(valueType.ElementType)
+		if err != nil {
+			return nil, err
+		}
+		if uint(len(values)) != valueType.Size {
+			return nil, invalidCCFError(
+				"invalid constant-sized array length: expected %d, got %d",
+				valueType.Size,
+				len(values),
+			)
+		}
+		return cadence.NewArray(values).WithType(valueType), nil
+
+	case cadence.DictionaryType:
+		pairs, err := d.decodeDictionaryPairs(valueType.KeyType, valueType.ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewDictionary(pairs).WithType(valueType), nil
+
+	case *cadence.StructType:
+		fields, err := d.decodeCompositeFields(valueType.Fields)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewStruct(fields).WithType(valueType), nil
+
+	case *cadence.ResourceType:
+		fields, err := d.decodeCompositeFields(valueType.Fields)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewResource(fields).WithType(valueType), nil
+
+	case *cadence.EventType:
+		fields, err := d.decodeCompositeFields(valueType.Fields)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewEvent(fields).WithType(valueType), nil
+
+	case *cadence.ContractType:
+		fields, err := d.decodeCompositeFields(valueType.Fields)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewContract(fields).WithType(valueType), nil
+
+	case *cadence.EnumType:
+		fields, err := d.decodeCompositeFields(valueType.Fields)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewEnum(fields).WithType(valueType), nil
+
+	case cadence.PathType:
+		return d.decodePath()
+
+	case cadence.CapabilityType:
+		return d.decodeCapability()
+
+	default:
+		return nil, invalidCCFError("cannot decode value of type %s", valueType.ID())
+	}
+}
+
+func (d *decoder) decodeOptional(optionalType cadence.OptionalType, tagNumber *uint64) (cadence.Value, error) {
+	innerType := optionalType.Type
+
+	if tagNumber == nil {
+		isNil, err := d.decodeNil()
+		if err != nil {
+			return nil, err
+		}
+
+		if isNil {
+			return cadence.NewOptional(nil), nil
+		}
+
+		// Values of some types may be encoded as nil,
+		// so the value of a non-nil optional is wrapped in an array
+
+		if isNilEncoded(innerType) {
+			err = d.decodeArrayHead(1)
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := d.decodeValue(innerType)
+			if err != nil {
+				return nil, err
+			}
+
+			return cadence.NewOptional(value), nil
+		}
+	}
+
+	value, err := d.decodeValueWithTagNumber(innerType, tagNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return cadence.NewOptional(value), nil
+}
+
+// CBOR bignum tag numbers, see RFC 8949, section 3.4.3
+//
+const (
+	cborTagPositiveBignum = 2
+	cborTagNegativeBignum = 3
+)
+
+var bigOne = big.NewInt(1)
+
+// decodeBigInt decodes a big integer.
+//
+// The tag number of the bignum, if any, was already decoded
+//
+func (d *decoder) decodeBigInt(tagNumber *uint64) (*big.Int, error) {
+	if tagNumber == nil {
+		return d.dec.DecodeBigInt()
+	}
+
+	content, err := d.dec.DecodeBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	value := new(big.Int).SetBytes(content)
+
+	switch *tagNumber {
+	case cborTagPositiveBignum:
+		return value, nil
+
+	case cborTagNegativeBignum:
+		// The content n encodes the value -1 - n
+		value.Add(value, bigOne)
+		return value.Neg(value), nil
+
+	default:
+		return nil, invalidCCFError("invalid bignum tag: %d", *tagNumber)
+	}
+}
+
+func (d *decoder) decodeInt64(min, max int64) (int64, error) {
+	value, err := d.dec.DecodeInt64()
+	if err != nil {
+		return 0, err
+	}
+
+	if value < min || value > max {
+		return 0, invalidCCFError("integer out of range: %d", value)
+	}
+
+	return value, nil
+}
+
+func (d *decoder) decodeUint64(max uint64) (uint64, error) {
+	value, err := d.dec.DecodeUint64()
+	if err != nil {
+		return 0, err
+	}
+
+	if value > max {
+		return 0, invalidCCFError("integer out of range: %d", value)
+	}
+
+	return value, nil
+}
+
+func (d *decoder) decodeAddress() (cadence.Address, error) {
+	content, err := d.dec.DecodeBytes()
+	if err != nil {
+		return cadence.Address{}, err
+	}
+
+	if len(content) != cadence.AddressLength {
+		return cadence.Address{}, invalidCCFError("invalid address length: %d", len(content))
+	}
+
+	return cadence.BytesToAddress(content), nil
+}
+
+func (d *decoder) decodeArrayElements(elementType cadence.Type) ([]cadence.Value, error) {
+	count, err := d.dec.DecodeArrayHead()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]cadence.Value, count)
+
+	for i := range values {
+		values[i], err = d.decodeValue(elementType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+func (d *decoder) decodeDictionaryPairs(keyType, elementType cadence.Type) ([]cadence.KeyValuePair, error) {
+	count, err := d.dec.DecodeArrayHead()
+	if err != nil {
+		return nil, err
+	}
+
+	if count%2 != 0 {
+		return nil, invalidCCFError("invalid dictionary length: %d", count)
+	}
+
+	pairs := make([]cadence.KeyValuePair, count/2)
+
+	for i := range pairs {
+		pairs[i].Key, err = d.decodeValue(keyType)
+		if err != nil {
+			return nil, err
+		}
+
+		pairs[i].Value, err = d.decodeValue(elementType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pairs, nil
+}
+
+func (d *decoder) decodeCompositeFields(fields []cadence.Field) ([]cadence.Value, error) {
+	err := d.decodeArrayHead(uint64(len(fields)))
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]cadence.Value, len(fields))
+
+	for i := range values {
+		values[i], err = d.decodeValue(fields[i].Type)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+func (d *decoder) decodePath() (cadence.Path, error) {
+	err := d.decodeArrayHead(2)
+	if err != nil {
+		return cadence.Path{}, err
+	}
+
+	domain, err := d.dec.DecodeString()
+	if err != nil {
+		return cadence.Path{}, err
+	}
+
+	identifier, err := d.dec.DecodeString()
+	if err != nil {
+		return cadence.Path{}, err
+	}
+
+	return cadence.Path{
+		Domain:     domain,
+		Identifier: identifier,
+	}, nil
+}
+
+func (d *decoder) decodeCapability() (cadence.Capability, error) {
+	err := d.decodeArrayHead(4)
+	if err != nil {
+		return cadence.Capability{}, err
+	}
+
+	path, err := d.decodePath()
+	if err != nil {
+		return cadence.Capability{}, err
+	}
+
+	address, err := d.decodeAddress()
+	if err != nil {
+		return cadence.Capability{}, err
+	}
+
+	borrowType, err := d.decodeType()
+	if err != nil {
+		return cadence.Capability{}, err
+	}
+
+	id, err := d.dec.DecodeUint64()
+	if err != nil {
+		return cadence.Capability{}, err
+	}
+
+	return cadence.Capability{
+		Path:       path,
+		Address:    address,
+		BorrowType: borrowType,
+		ID:         id,
+	}, nil
+}
@@ -0,0 +1,1007 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ccf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/onflow/cadence"
+)
+
+// An Encoder converts Cadence values into CCF-encoded bytes.
+type Encoder struct {
+	w io.Writer
+}
+
+// Encode returns the CCF-encoded representation of the given value.
+//
+// This function returns an error if the Cadence value cannot be represented in CCF.
+func Encode(value cadence.Value) ([]byte, error) {
+	var w bytes.Buffer
+	enc := NewEncoder(&w)
+
+	err := enc.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.Bytes(), nil
+}
+
+// MustEncode returns the CCF-encoded representation of the given value, or panics
+// if the value cannot be represented in CCF.
+func MustEncode(value cadence.Value) []byte {
+	b, err := Encode(value)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// NewEncoder initializes an Encoder that will write CCF-encoded bytes to the
+// given io.Writer.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes the CCF-encoded representation of the given value to this
+// encoder's io.Writer.
+//
+// This function returns an error if the given value's type is not supported
+// by this encoder.
+func (e *Encoder) Encode(value cadence.Value) error {
+	message, err := encodeMessage(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode value: %w", err)
+	}
+
+	_, err = e.w.Write(message)
+	return err
+}
+
+// typeDefinitions are the composite and interface types which are defined in a message,
+// in the order in which they were first referred to
+//
+type typeDefinitions struct {
+	types   []cadence.Type
+	indices map[cadence.Type]uint64
+}
+
+func (d *typeDefinitions) index(t cadence.Type) uint64 {
+	index, ok := d.indices[t]
+	if !ok {
+		index = uint64(len(d.types))
+		d.types = append(d.types, t)
+		d.indices[t] = index
+	}
+	return index
+}
+
+// encoder encodes values and types into a CBOR stream,
+// and collects the type definitions they refer to
+//
+type encoder struct {
+	enc         *cbor.StreamEncoder
+	definitions *typeDefinitions
+}
+
+func encodeMessage(value cadence.Value) ([]byte, error) {
+	definitions := &typeDefinitions{
+		indices: map[cadence.Type]uint64{},
+	}
+
+	// Encode the value first, to determine which types it refers to
+
+	var valueBuffer bytes.Buffer
+	valueEncoder := encoder{
+		enc:         CBOREncMode.NewStreamEncoder(&valueBuffer),
+		definitions: definitions,
+	}
+
+	err := valueEncoder.encodeSelfDescribingValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	err = valueEncoder.enc.Flush()
+	if err != nil {
+		return nil, err
+	}
+
+	// Encode the bodies of the type definitions.
+	// Type definitions may refer to further types,
+	// which are appended to the definitions, and are also encoded
+
+	var bodiesBuffer bytes.Buffer
+	bodiesEncoder := encoder{
+		enc:         CBOREncMode.NewStreamEncoder(&bodiesBuffer),
+		definitions: definitions,
+	}
+
+	for i := 0; i < len(definitions.types); i++ {
+		err = bodiesEncoder.encodeTypeDefinitionBody(definitions.types[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = bodiesEncoder.enc.Flush()
+	if err != nil {
+		return nil, err
+	}
+
+	// Encode the message
+
+	var messageBuffer bytes.Buffer
+	messageEncoder := encoder{
+		enc:         CBOREncMode.NewStreamEncoder(&messageBuffer),
+		definitions: definitions,
+	}
+
+	err = messageEncoder.encodeMessage(bodiesBuffer.Bytes(), valueBuffer.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	err = messageEncoder.enc.Flush()
+	if err != nil {
+		return nil, err
+	}
+
+	return messageBuffer.Bytes(), nil
+}
+
+func (e *encoder) encodeMessage(encodedBodies, encodedValue []byte) error {
+	err := e.encodeTagHead(cborTagMessage)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeArrayHead(3)
+	if err != nil {
+		return err
+	}
+
+	// Encode type definition headers
+
+	types := e.definitions.types
+
+	err = e.enc.EncodeArrayHead(uint64(len(types)))
+	if err != nil {
+		return err
+	}
+
+	for _, t := range types {
+		err = e.encodeTypeDefinitionHeader(t)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Encode type definition bodies
+
+	err = e.enc.EncodeArrayHead(uint64(len(types)))
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeRawBytes(encodedBodies)
+	if err != nil {
+		return err
+	}
+
+	// Encode value
+
+	return e.enc.EncodeRawBytes(encodedValue)
+}
+
+// encodeTagHead encodes the head of a tag. The tag number must be in [24, 255]
+//
+func (e *encoder) encodeTagHead(number uint8) error {
+	return e.enc.EncodeRawBytes([]byte{
+		// tag number
+		0xd8, number,
+	})
+}
+
+func (e *encoder) encodeTypeDefinitionHeader(t cadence.Type) error {
+	var tagNumber uint8
+
+	switch t.(type) {
+	case *cadence.StructType:
+		tagNumber = cborTagStructType
+	case *cadence.ResourceType:
+		tagNumber = cborTagResourceType
+	case *cadence.EventType:
+		tagNumber = cborTagEventType
+	case *cadence.ContractType:
+		tagNumber = cborTagContractType
+	case *cadence.EnumType:
+		tagNumber = cborTagEnumType
+	case *cadence.StructInterfaceType:
+		tagNumber = cborTagStructInterfaceType
+	case *cadence.ResourceInterfaceType:
+		tagNumber = cborTagResourceInterfaceType
+	case *cadence.ContractInterfaceType:
+		tagNumber = cborTagContractInterfaceType
+	default:
+		return fmt.Errorf("unsupported type definition: %T", t)
+	}
+
+	err := e.encodeTagHead(tagNumber)
+	if err != nil {
+		return err
+	}
+
+	return e.enc.EncodeString(t.ID())
+}
+
+func (e *encoder) encodeTypeDefinitionBody(t cadence.Type) error {
+	var fields []cadence.Field
+	var initializers [][]cadence.Parameter
+	var rawType cadence.Type
+
+	switch t := t.(type) {
+	case cadence.CompositeType:
+		fields = t.CompositeFields()
+
+		switch t := t.(type) {
+		case *cadence.EventType:
+			// Events have a single initializer, which is encoded directly below
+
+		case *cadence.EnumType:
+			initializers = t.Initializers
+			rawType = t.RawType
+
+		default:
+			initializers = t.CompositeInitializers()
+		}
+
+	case cadence.InterfaceType:
+		fields = t.InterfaceFields()
+		initializers = t.InterfaceInitializers()
+
+	default:
+		return fmt.Errorf("unsupported type definition: %T", t)
+	}
+
+	err := e.enc.EncodeArrayHead(3)
+	if err != nil {
+		return err
+	}
+
+	err = e.encodeFields(fields)
+	if err != nil {
+		return err
+	}
+
+	if eventType, ok := t.(*cadence.EventType); ok {
+		err = e.encodeParameters(eventType.Initializer)
+	} else {
+		err = e.encodeInitializers(initializers)
+	}
+	if err != nil {
+		return err
+	}
+
+	return e.encodeType(rawType)
+}
+
+func (e *encoder) encodeFields(fields []cadence.Field) error {
+	if fields == nil {
+		return e.enc.EncodeNil()
+	}
+
+	err := e.enc.EncodeArrayHead(uint64(len(fields)))
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		err = e.enc.EncodeArrayHead(2)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeString(field.Identifier)
+		if err != nil {
+			return err
+		}
+
+		err = e.encodeType(field.Type)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *encoder) encodeInitializers(initializers [][]cadence.Parameter) error {
+	if initializers == nil {
+		return e.enc.EncodeNil()
+	}
+
+	err := e.enc.EncodeArrayHead(uint64(len(initializers)))
+	if err != nil {
+		return err
+	}
+
+	for _, parameters := range initializers {
+		err = e.encodeParameters(parameters)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *encoder) encodeParameters(parameters []cadence.Parameter) error {
+	if parameters == nil {
+		return e.enc.EncodeNil()
+	}
+
+	err := e.enc.EncodeArrayHead(uint64(len(parameters)))
+	if err != nil {
+		return err
+	}
+
+	for _, parameter := range parameters {
+		err = e.enc.EncodeArrayHead(3)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeString(parameter.Label)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeString(parameter.Identifier)
+		if err != nil {
+			return err
+		}
+
+		err = e.encodeType(parameter.Type)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *encoder) encodeTypes(types []cadence.Type) error {
+	if types == nil {
+		return e.enc.EncodeNil()
+	}
+
+	err := e.enc.EncodeArrayHead(uint64(len(types)))
+	if err != nil {
+		return err
+	}
+
+	for _, t := range types {
+		err = e.encodeType(t)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encodeType encodes the given type inline.
+// Composite and interface types are encoded as a reference to their type definition
+//
+func (e *encoder) encodeType(t cadence.Type) error {
+	switch t := t.(type) {
+	case nil:
+		return e.enc.EncodeNil()
+
+	case cadence.OptionalType:
+		err := e.encodeTagHead(cborTagOptionalType)
+		if err != nil {
+			return err
+		}
+		return e.encodeType(t.Type)
+
+	case cadence.VariableSizedArrayType:
+		err := e.encodeTagHead(cborTagVariableSizedArrayType)
+		if err != nil {
+			return err
+		}
+		return e.encodeType(t.ElementType)
+
+	case cadence.ConstantSizedArrayType:
+		err := e.encodeTagHead(cborTagConstantSizedArrayType)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeArrayHead(2)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeUint64(uint64(t.Size))
+		if err != nil {
+			return err
+		}
+
+		return e.encodeType(t.ElementType)
+
+	case cadence.DictionaryType:
+		err := e.encodeTagHead(cborTagDictionaryType)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeArrayHead(2)
+		if err != nil {
+			return err
+		}
+
+		err = e.encodeType(t.KeyType)
+		if err != nil {
+			return err
+		}
+
+		return e.encodeType(t.ElementType)
+
+	case cadence.ReferenceType:
+		err := e.encodeTagHead(cborTagReferenceType)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeArrayHead(2)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeBool(t.Authorized)
+		if err != nil {
+			return err
+		}
+
+		return e.encodeType(t.Type)
+
+	case cadence.RestrictedType:
+		err := e.encodeTagHead(cborTagRestrictedType)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeArrayHead(3)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeString(t.ID())
+		if err != nil {
+			return err
+		}
+
+		err = e.encodeType(t.Type)
+		if err != nil {
+			return err
+		}
+
+		return e.encodeTypes(t.Restrictions)
+
+	case cadence.CapabilityType:
+		err := e.encodeTagHead(cborTagCapabilityType)
+		if err != nil {
+			return err
+		}
+		return e.encodeType(t.BorrowType)
+
+	case cadence.FunctionType:
+		err := e.encodeTagHead(cborTagFunctionType)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeArrayHead(3)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeString(t.ID())
+		if err != nil {
+			return err
+		}
+
+		err = e.encodeParameters(t.Parameters)
+		if err != nil {
+			return err
+		}
+
+		return e.encodeType(t.ReturnType)
+
+	case *cadence.StructType,
+		*cadence.ResourceType,
+		*cadence.EventType,
+		*cadence.ContractType,
+		*cadence.EnumType,
+		*cadence.StructInterfaceType,
+		*cadence.ResourceInterfaceType,
+		*cadence.ContractInterfaceType:
+
+		if reflect.ValueOf(t).IsNil() {
+			return fmt.Errorf("unsupported type: nil %T", t)
+		}
+
+		err := e.encodeTagHead(cborTagTypeDefinitionReference)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeUint64(e.definitions.index(t))
+
+	default:
+		code, ok := simpleTypeCodes[t]
+		if !ok {
+			return fmt.Errorf("unsupported type: %T, %v", t, t)
+		}
+
+		err := e.encodeTagHead(cborTagSimpleType)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeUint64(code)
+	}
+}
+
+// encodeValue encodes the given value at a position where a value of the given static type is expected.
+//
+// If the value has exactly the expected type, the value is encoded bare, i.e. without its type.
+// Otherwise, the value is encoded in a self-describing way
+//
+func (e *encoder) encodeValue(value cadence.Value, expectedType cadence.Type) error {
+	if value == nil {
+		return fmt.Errorf("unsupported value: nil")
+	}
+
+	if expectedType != nil && reflect.DeepEqual(expectedType, value.Type()) {
+		return e.encodeBareValue(value, expectedType)
+	}
+
+	return e.encodeSelfDescribingValue(value)
+}
+
+// encodeSelfDescribingValue encodes the given value together with its type.
+//
+// Arrays and dictionaries without a type, and links, which have no type,
+// are encoded as special self-describing values
+//
+func (e *encoder) encodeSelfDescribingValue(value cadence.Value) error {
+	switch value := value.(type) {
+	case nil:
+		return fmt.Errorf("unsupported value: nil")
+
+	case cadence.Array:
+		if value.ArrayType == nil {
+			err := e.encodeTagHead(cborTagUntypedArray)
+			if err != nil {
+				return err
+			}
+			return e.encodeArrayElements(value.Values, nil)
+		}
+
+	case cadence.Dictionary:
+		if value.DictionaryType == nil {
+			err := e.encodeTagHead(cborTagUntypedDictionary)
+			if err != nil {
+				return err
+			}
+			return e.encodeDictionaryPairs(value.Pairs, nil, nil)
+		}
+
+	case cadence.Link:
+		err := e.encodeTagHead(cborTagLink)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeArrayHead(2)
+		if err != nil {
+			return err
+		}
+
+		err = e.encodePath(value.TargetPath)
+		if err != nil {
+			return err
+		}
+
+		return e.enc.EncodeString(value.BorrowType)
+	}
+
+	valueType := value.Type()
+	if valueType == nil {
+		return fmt.Errorf("unsupported value: %T, %v", value, value)
+	}
+
+	err := e.encodeTagHead(cborTagTypeAndValue)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeArrayHead(2)
+	if err != nil {
+		return err
+	}
+
+	err = e.encodeType(valueType)
+	if err != nil {
+		return err
+	}
+
+	return e.encodeBareValue(value, valueType)
+}
+
+// isNilEncoded returns true if a value of the given type may be encoded as nil,
+// in which case a non-nil optional value with a value of this type
+// cannot be encoded as the inner value directly
+//
+func isNilEncoded(t cadence.Type) bool {
+	switch t.(type) {
+	case cadence.OptionalType, cadence.VoidType, cadence.MetaType:
+		return true
+	}
+	return false
+}
+
+// encodeBareValue encodes the given value, which has the given type, without its type
+//
+func (e *encoder) encodeBareValue(value cadence.Value, valueType cadence.Type) error {
+	switch value := value.(type) {
+	case cadence.Void:
+		return e.enc.EncodeNil()
+
+	case cadence.Optional:
+		if value.Value == nil {
+			return e.enc.EncodeNil()
+		}
+
+		innerType := valueType.(cadence.OptionalType).Type
+
+		if isNilEncoded(innerType) {
+			err := e.enc.EncodeArrayHead(1)
+			if err != nil {
+				return err
+			}
+		}
+
+		return e.encodeValue(value.Value, innerType)
+
+	case cadence.Bool:
+		return e.enc.EncodeBool(bool(value))
+
+	case cadence.String:
+		return e.encodeString(string(value))
+
+	case cadence.Character:
+		return e.encodeString(string(value))
+
+	case cadence.Bytes:
+		return e.enc.EncodeBytes(value)
+
+	case cadence.Address:
+		return e.enc.EncodeBytes(value.Bytes())
+
+	case cadence.Int:
+		return e.enc.EncodeBigInt(value.Value)
+
+	case cadence.Int8:
+		return e.enc.EncodeInt64(int64(value))
+
+	case cadence.Int16:
+		return e.enc.EncodeInt64(int64(value))
+
+	case cadence.Int32:
+		return e.enc.EncodeInt64(int64(value))
+
+	case cadence.Int64:
+		return e.enc.EncodeInt64(int64(value))
+
+	case cadence.Int128:
+		return e.enc.EncodeBigInt(value.Value)
+
+	case cadence.Int256:
+		return e.enc.EncodeBigInt(value.Value)
+
+	case cadence.UInt:
+		return e.enc.EncodeBigInt(value.Value)
+
+	case cadence.UInt8:
+		return e.enc.EncodeUint64(uint64(value))
+
+	case cadence.UInt16:
+		return e.enc.EncodeUint64(uint64(value))
+
+	case cadence.UInt32:
+		return e.enc.EncodeUint64(uint64(value))
+
+	case cadence.UInt64:
+		return e.enc.EncodeUint64(uint64(value))
+
+	case cadence.UInt128:
+		return e.enc.EncodeBigInt(value.Value)
+
+	case cadence.UInt256:
+		return e.enc.EncodeBigInt(value.Value)
+
+	case cadence.Word8:
+		return e.enc.EncodeUint64(uint64(value))
+
+	case cadence.Word16:
+		return e.enc.EncodeUint64(uint64(value))
+
+	case cadence.Word32:
+		return e.enc.EncodeUint64(uint64(value))
+
+	case cadence.Word64:
+		return e.enc.EncodeUint64(uint64(value))
+
+	case cadence.Fix64:
+		return e.enc.EncodeInt64(int64(value))
+
+	case cadence.UFix64:
+		return e.enc.EncodeUint64(uint64(value))
+
+	case cadence.Array:
+		arrayType, ok := valueType.(cadence.ArrayType)
+		if !ok {
+			return fmt.Errorf("invalid array type: %v", valueType)
+		}
+		if constantSizedArrayType, ok := arrayType.(cadence.ConstantSizedArrayType); ok &&
+			uint(len(value.Values)) != constantSizedArrayType.Size {
+
+			return fmt.Errorf(
+				"invalid constant-sized array: expected %d elements, got %d",
+				constantSizedArrayType.Size,
+				len(value.Values),
+			)
+		}
+		return e.encodeArrayElements(value.Values, arrayType.Element())
+
+	case cadence.Dictionary:
+		dictionaryType, ok := valueType.(cadence.DictionaryType)
+		if !ok {
+			return fmt.Errorf("invalid dictionary type: %v", valueType)
+		}
+		return e.encodeDictionaryPairs(
+			value.Pairs,
+			dictionaryType.KeyType,
+			dictionaryType.ElementType,
+		)
+
+	case cadence.Struct:
+		return e.encodeCompositeFields(value.Fields, value.StructType.Fields)
+
+	case cadence.Resource:
+		return e.encodeCompositeFields(value.Fields, value.ResourceType.Fields)
+
+	case cadence.Event:
+		return e.encodeCompositeFields(value.Fields, value.EventType.Fields)
+
+	case cadence.Contract:
+		return e.encodeCompositeFields(value.Fields, value.ContractType.Fields)
+
+	case cadence.Enum:
+		return e.encodeCompositeFields(value.Fields, value.EnumType.Fields)
+
+	case cadence.Path:
+		return e.encodePath(value)
+
+	case cadence.TypeValue:
+		return e.encodeType(value.StaticType)
+
+	case cadence.Capability:
+		return e.encodeCapability(value)
+
+	default:
+		return fmt.Errorf("unsupported value: %T, %v", value, value)
+	}
+}
+
+// encodeString encodes the given string as a CBOR text string.
+//
+// CBOR text strings must be valid UTF-8,
+// so invalid UTF-8 sequences are replaced with the Unicode replacement character
+//
+func (e *encoder) encodeString(s string) error {
+	if !utf8.ValidString(s) {
+		s = strings.ToValidUTF8(s, string(utf8.RuneError))
+	}
+	return e.enc.EncodeString(s)
+}
+
+func (e *encoder) encodeArrayElements(values []cadence.Value, elementType cadence.Type) error {
+	err := e.enc.EncodeArrayHead(uint64(len(values)))
+	if err != nil {
+		return err
+	}
+
+	for _, value := range values {
+		err = e.encodeValue(value, elementType)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *encoder) encodeDictionaryPairs(pairs []cadence.KeyValuePair, keyType, elementType cadence.Type) error {
+	pairs, err := sortedDictionaryPairs(pairs, keyType)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeArrayHead(uint64(len(pairs) * 2))
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range pairs {
+		err = e.encodeValue(pair.Key, keyType)
+		if err != nil {
+			return err
+		}
+
+		err = e.encodeValue(pair.Value, elementType)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sortedDictionaryPairs returns the given pairs in canonical order,
+// so the encoding of a dictionary does not depend on the insertion order of its pairs.
+//
+// Pairs are ordered by the encoding of their key on its own,
+// i.e. independent of the type definitions of the message,
+// and then by the type ID of their key
+//
+func sortedDictionaryPairs(pairs []cadence.KeyValuePair, keyType cadence.Type) ([]cadence.KeyValuePair, error) {
+	encodedKeys := make([][]byte, len(pairs))
+	keyTypeIDs := make([]string, len(pairs))
+
+	for i, pair := range pairs {
+		var keyBuffer bytes.Buffer
+		keyEncoder := encoder{
+			enc: CBOREncMode.NewStreamEncoder(&keyBuffer),
+			definitions: &typeDefinitions{
+				indices: map[cadence.Type]uint64{},
+			},
+		}
+
+		err := keyEncoder.encodeValue(pair.Key, keyType)
+		if err != nil {
+			return nil, err
+		}
+
+		err = keyEncoder.enc.Flush()
+		if err != nil {
+			return nil, err
+		}
+
+		encodedKeys[i] = keyBuffer.Bytes()
+		if pair.Key != nil && pair.Key.Type() != nil {
+			keyTypeIDs[i] = pair.Key.Type().ID()
+		}
+	}
+
+	indices := make([]int, len(pairs))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	sort.SliceStable(indices, func(i, j int) bool {
+		a := indices[i]
+		b := indices[j]
+		comparison := bytes.Compare(encodedKeys[a], encodedKeys[b])
+		if comparison != 0 {
+			return comparison < 0
+		}
+		return keyTypeIDs[a] < keyTypeIDs[b]
+	})
+
+	sortedPairs := make([]cadence.KeyValuePair, len(pairs))
+	for i, index := range indices {
+		sortedPairs[i] = pairs[index]
+	}
+
+	return sortedPairs, nil
+}
+
+func (e *encoder) encodeCompositeFields(values []cadence.Value, fields []cadence.Field) error {
+	if len(values) != len(fields) {
+		return fmt.Errorf(
+			"invalid composite: expected %d fields, got %d",
+			len(fields),
+			len(values),
+		)
+	}
+
+	err := e.enc.EncodeArrayHead(uint64(len(values)))
+	if err != nil {
+		return err
+	}
+
+	for i, value := range values {
+		err = e.encodeValue(value, fields[i].Type)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *encoder) encodePath(path cadence.Path) error {
+	err := e.enc.EncodeArrayHead(2)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeString(path.Domain)
+	if err != nil {
+		return err
+	}
+
+	return e.enc.EncodeString(path.Identifier)
+}
+
+func (e *encoder) encodeCapability(capability cadence.Capability) error {
+	err := e.enc.EncodeArrayHead(4)
+	if err != nil {
+		return err
+	}
+
+	err = e.encodePath(capability.Path)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeBytes(capability.Address.Bytes())
+	if err != nil {
+		return err
+	}
+
+	err = e.encodeType(capability.BorrowType)
+	if err != nil {
+		return err
+	}
+
+	return e.enc.EncodeUint64(capability.ID)
+}
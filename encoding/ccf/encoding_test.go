@@ -0,0 +1,1354 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ccf_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/tests/checker"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/ccf"
+	"github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+type encodeTest struct {
+	name string
+	val  cadence.Value
+}
+
+func TestEncodeVoid(t *testing.T) {
+
+	t.Parallel()
+
+	testEncodeAndDecodeBytes(
+		t,
+		cadence.NewVoid(),
+		// message: tag 128, array of 3
+		"d880"+"83"+
+			// no type definitions
+			"80"+"80"+
+			// type and value: tag 129, array of 2, simple type 4 (Void), nil
+			"d881"+"82"+"d888"+"04"+"f6",
+	)
+}
+
+func TestEncodeOptional(t *testing.T) {
+
+	t.Parallel()
+
+	testAllEncodeAndDecode(t, []encodeTest{
+		{
+			"Nil",
+			cadence.NewOptional(nil),
+		},
+		{
+			"Non-nil",
+			cadence.NewOptional(cadence.NewInt(42)),
+		},
+		{
+			"Nested nil",
+			cadence.NewOptional(cadence.NewOptional(nil)),
+		},
+		{
+			"Nested non-nil",
+			cadence.NewOptional(cadence.NewOptional(cadence.NewInt(42))),
+		},
+		{
+			"Void",
+			cadence.NewOptional(cadence.NewVoid()),
+		},
+		{
+			"Type",
+			cadence.NewOptional(cadence.NewTypeValue(cadence.IntType{})),
+		},
+		{
+			"Untyped array",
+			cadence.NewOptional(cadence.NewArray([]cadence.Value{})),
+		},
+	}...)
+}
+
+func TestEncodeBool(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("True", func(t *testing.T) {
+
+		t.Parallel()
+
+		testEncodeAndDecodeBytes(
+			t,
+			cadence.NewBool(true),
+			// message with no type definitions
+			"d880"+"83"+"80"+"80"+
+				// type and value: simple type 6 (Bool), true
+				"d881"+"82"+"d888"+"06"+"f5",
+		)
+	})
+
+	t.Run("False", func(t *testing.T) {
+
+		t.Parallel()
+
+		testEncodeAndDecodeBytes(
+			t,
+			cadence.NewBool(false),
+			// message with no type definitions
+			"d880"+"83"+"80"+"80"+
+				// type and value: simple type 6 (Bool), false
+				"d881"+"82"+"d888"+"06"+"f4",
+		)
+	})
+}
+
+func TestEncodeCharacter(t *testing.T) {
+
+	t.Parallel()
+
+	a, _ := cadence.NewCharacter("a")
+	b, _ := cadence.NewCharacter("é")
+
+	testAllEncodeAndDecode(t, []encodeTest{
+		{"a", a},
+		{"é", b},
+	}...)
+}
+
+func TestEncodeString(t *testing.T) {
+
+	t.Parallel()
+
+	testAllEncodeAndDecode(t, []encodeTest{
+		{
+			"Empty",
+			cadence.String(""),
+		},
+		{
+			"Non-empty",
+			cadence.String("foo"),
+		},
+		{
+			"Unicode",
+			cadence.String("é\U0001F600"),
+		},
+	}...)
+}
+
+func TestEncodeBytes(t *testing.T) {
+
+	t.Parallel()
+
+	testAllEncodeAndDecode(t, []encodeTest{
+		{
+			"Empty",
+			cadence.NewBytes([]byte{}),
+		},
+		{
+			"Non-empty",
+			cadence.NewBytes([]byte{1, 2, 3}),
+		},
+	}...)
+}
+
+func TestEncodeAddress(t *testing.T) {
+
+	t.Parallel()
+
+	testEncodeAndDecode(t, cadence.BytesToAddress([]byte{1, 2, 3, 4, 5}))
+}
+
+func TestEncodeInt(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("Positive", func(t *testing.T) {
+
+		t.Parallel()
+
+		testEncodeAndDecodeBytes(
+			t,
+			cadence.NewInt(42),
+			// message with no type definitions
+			"d880"+"83"+"80"+"80"+
+				// type and value: simple type 17 (Int), positive bignum 42
+				"d881"+"82"+"d888"+"11"+"c2"+"41"+"2a",
+		)
+	})
+
+	testAllEncodeAndDecode(t, []encodeTest{
+		{
+			"Negative",
+			cadence.NewInt(-42),
+		},
+		{
+			"Zero",
+			cadence.NewInt(0),
+		},
+		{
+			"SmallerThanMinInt256",
+			cadence.NewIntFromBig(new(big.Int).Sub(sema.Int256TypeMinIntBig, big.NewInt(10))),
+		},
+		{
+			"LargerThanMaxUInt256",
+			cadence.NewIntFromBig(new(big.Int).Add(sema.UInt256TypeMaxIntBig, big.NewInt(10))),
+		},
+	}...)
+}
+
+func TestEncodeFixedSizeIntegers(t *testing.T) {
+
+	t.Parallel()
+
+	testAllEncodeAndDecode(t, []encodeTest{
+		{"Int8 min", cadence.NewInt8(math.MinInt8)},
+		{"Int8 max", cadence.NewInt8(math.MaxInt8)},
+		{"Int16 min", cadence.NewInt16(math.MinInt16)},
+		{"Int16 max", cadence.NewInt16(math.MaxInt16)},
+		{"Int32 min", cadence.NewInt32(math.MinInt32)},
+		{"Int32 max", cadence.NewInt32(math.MaxInt32)},
+		{"Int64 min", cadence.NewInt64(math.MinInt64)},
+		{"Int64 max", cadence.NewInt64(math.MaxInt64)},
+		{"UInt8 max", cadence.NewUInt8(math.MaxUint8)},
+		{"UInt16 max", cadence.NewUInt16(math.MaxUint16)},
+		{"UInt32 max", cadence.NewUInt32(math.MaxUint32)},
+		{"UInt64 max", cadence.NewUInt64(math.MaxUint64)},
+		{"Word8 max", cadence.NewWord8(math.MaxUint8)},
+		{"Word16 max", cadence.NewWord16(math.MaxUint16)},
+		{"Word32 max", cadence.NewWord32(math.MaxUint32)},
+		{"Word64 max", cadence.NewWord64(math.MaxUint64)},
+	}...)
+}
+
+func TestEncodeBigIntegers(t *testing.T) {
+
+	t.Parallel()
+
+	int128Min, err := cadence.NewInt128FromBig(sema.Int128TypeMinIntBig)
+	require.NoError(t, err)
+
+	int128Max, err := cadence.NewInt128FromBig(sema.Int128TypeMaxIntBig)
+	require.NoError(t, err)
+
+	int256Min, err := cadence.NewInt256FromBig(sema.Int256TypeMinIntBig)
+	require.NoError(t, err)
+
+	int256Max, err := cadence.NewInt256FromBig(sema.Int256TypeMaxIntBig)
+	require.NoError(t, err)
+
+	uint128Max, err := cadence.NewUInt128FromBig(sema.UInt128TypeMaxIntBig)
+	require.NoError(t, err)
+
+	uint256Max, err := cadence.NewUInt256FromBig(sema.UInt256TypeMaxIntBig)
+	require.NoError(t, err)
+
+	testAllEncodeAndDecode(t, []encodeTest{
+		{"Int128 min", int128Min},
+		{"Int128 max", int128Max},
+		{"Int128 minus one", cadence.NewInt128(-1)},
+		{"Int256 min", int256Min},
+		{"Int256 max", int256Max},
+		{"UInt zero", cadence.NewUInt(0)},
+		{"UInt", cadence.NewUInt(42)},
+		{"UInt128 max", uint128Max},
+		{"UInt256 max", uint256Max},
+	}...)
+}
+
+func TestEncodeFixedPoints(t *testing.T) {
+
+	t.Parallel()
+
+	testAllEncodeAndDecode(t, []encodeTest{
+		{"Fix64 min", cadence.Fix64(math.MinInt64)},
+		{"Fix64 negative", cadence.Fix64(-12_300_000_000)},
+		{"Fix64 max", cadence.Fix64(math.MaxInt64)},
+		{"UFix64 zero", cadence.UFix64(0)},
+		{"UFix64 max", cadence.UFix64(math.MaxUint64)},
+	}...)
+}
+
+func TestEncodeArray(t *testing.T) {
+
+	t.Parallel()
+
+	testAllEncodeAndDecode(t, []encodeTest{
+		{
+			"Empty",
+			cadence.NewArray([]cadence.Value{}),
+		},
+		{
+			"Integers",
+			cadence.NewArray([]cadence.Value{
+				cadence.NewInt(1),
+				cadence.NewInt(2),
+				cadence.NewInt(3),
+			}),
+		},
+		{
+			"Typed integers",
+			cadence.NewArray([]cadence.Value{
+				cadence.NewInt(1),
+				cadence.NewInt(2),
+				cadence.NewInt(3),
+			}).WithType(cadence.VariableSizedArrayType{
+				ElementType: cadence.IntType{},
+			}),
+		},
+		{
+			"Constant-sized",
+			cadence.NewArray([]cadence.Value{
+				cadence.String("a"),
+				cadence.String("b"),
+			}).WithType(cadence.ConstantSizedArrayType{
+				Size:        2,
+				ElementType: cadence.StringType{},
+			}),
+		},
+		{
+			"Mixed",
+			cadence.NewArray([]cadence.Value{
+				cadence.NewInt(1),
+				cadence.String("a"),
+				cadence.NewOptional(nil),
+			}).WithType(cadence.VariableSizedArrayType{
+				ElementType: cadence.AnyStructType{},
+			}),
+		},
+		{
+			"Resources",
+			cadence.NewArray([]cadence.Value{
+				cadence.NewResource([]cadence.Value{
+					cadence.NewInt(1),
+				}).WithType(fooResourceType),
+				cadence.NewResource([]cadence.Value{
+					cadence.NewInt(2),
+				}).WithType(fooResourceType),
+				cadence.NewResource([]cadence.Value{
+					cadence.NewInt(3),
+				}).WithType(fooResourceType),
+			}).WithType(cadence.VariableSizedArrayType{
+				ElementType: fooResourceType,
+			}),
+		},
+	}...)
+}
+
+func TestEncodeDictionary(t *testing.T) {
+
+	t.Parallel()
+
+	simpleDict := encodeTest{
+		"Simple",
+		cadence.NewDictionary([]cadence.KeyValuePair{
+			{
+				Key:   cadence.String("a"),
+				Value: cadence.NewInt(1),
+			},
+			{
+				Key:   cadence.String("b"),
+				Value: cadence.NewInt(2),
+			},
+		}),
+	}
+
+	typedDict := encodeTest{
+		"Typed",
+		cadence.NewDictionary([]cadence.KeyValuePair{
+			{
+				Key:   cadence.String("a"),
+				Value: cadence.NewInt(1),
+			},
+			{
+				Key:   cadence.String("b"),
+				Value: cadence.NewInt(2),
+			},
+		}).WithType(cadence.DictionaryType{
+			KeyType:     cadence.StringType{},
+			ElementType: cadence.IntType{},
+		}),
+	}
+
+	nestedDict := encodeTest{
+		"Nested",
+		cadence.NewDictionary([]cadence.KeyValuePair{
+			{
+				Key: cadence.String("a"),
+				Value: cadence.NewDictionary([]cadence.KeyValuePair{
+					{
+						Key:   cadence.String("1"),
+						Value: cadence.NewInt(1),
+					},
+				}),
+			},
+		}),
+	}
+
+	resourceDict := encodeTest{
+		"Resources",
+		cadence.NewDictionary([]cadence.KeyValuePair{
+			{
+				Key: cadence.String("a"),
+				Value: cadence.NewResource([]cadence.Value{
+					cadence.NewInt(1),
+				}).WithType(fooResourceType),
+			},
+			{
+				Key: cadence.String("b"),
+				Value: cadence.NewResource([]cadence.Value{
+					cadence.NewInt(2),
+				}).WithType(fooResourceType),
+			},
+		}).WithType(cadence.DictionaryType{
+			KeyType:     cadence.StringType{},
+			ElementType: fooResourceType,
+		}),
+	}
+
+	testAllEncodeAndDecode(t,
+		simpleDict,
+		typedDict,
+		nestedDict,
+		resourceDict,
+	)
+}
+
+func exportFromScript(t *testing.T, code string) cadence.Value {
+	checker, err := checker.ParseAndCheck(t, code)
+	require.NoError(t, err)
+
+	var uuid uint64 = 0
+
+	inter, err := interpreter.NewInterpreter(
+		interpreter.ProgramFromChecker(checker),
+		checker.Location,
+		interpreter.WithUUIDHandler(
+			func() (uint64, error) {
+				uuid++
+				return uuid, nil
+			},
+		),
+		interpreter.WithAtreeStorageValidationEnabled(true),
+		interpreter.WithAtreeValueValidationEnabled(true),
+		interpreter.WithStorage(
+			interpreter.NewInMemoryStorage(),
+		),
+	)
+	require.NoError(t, err)
+
+	err = inter.Interpret()
+	require.NoError(t, err)
+
+	result, err := inter.Invoke("main")
+	require.NoError(t, err)
+
+	exported, err := runtime.ExportValue(result, inter)
+	require.NoError(t, err)
+
+	return exported
+}
+
+func TestEncodeResource(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("Simple", func(t *testing.T) {
+
+		t.Parallel()
+
+		actual := exportFromScript(t, `
+			resource Foo {
+				let bar: Int
+	
+				init(bar: Int) {
+					self.bar = bar
+				}
+			}
+	
+			fun main(): @Foo {
+				return <- create Foo(bar: 42)
+			}
+		`)
+
+		testEncodeAndDecode(t, actual)
+	})
+
+	t.Run("Nested resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		actual := exportFromScript(t, `
+			resource Bar {
+				let x: Int
+	
+				init(x: Int) {
+					self.x = x
+				}
+			}
+	
+			resource Foo {
+				let bar: @Bar
+	
+				init(bar: @Bar) {
+					self.bar <- bar
+				}
+	
+				destroy() {
+					destroy self.bar
+				}
+			}
+	
+			fun main(): @Foo {
+				return <- create Foo(bar: <- create Bar(x: 42))
+			}
+		`)
+
+		testEncodeAndDecode(t, actual)
+	})
+
+	t.Run("Array of resources", func(t *testing.T) {
+
+		t.Parallel()
+
+		actual := exportFromScript(t, `
+			resource Foo {
+				let bar: Int
+	
+				init(bar: Int) {
+					self.bar = bar
+				}
+			}
+	
+			fun main(): @[Foo] {
+				return <- [<- create Foo(bar: 1), <- create Foo(bar: 2)]
+			}
+		`)
+
+		testEncodeAndDecode(t, actual)
+	})
+}
+
+func TestEncodeStruct(t *testing.T) {
+
+	t.Parallel()
+
+	simpleStructType := &cadence.StructType{
+		Location:            utils.TestLocation,
+		QualifiedIdentifier: "FooStruct",
+		Fields: []cadence.Field{
+			{
+				Identifier: "a",
+				Type:       cadence.IntType{},
+			},
+			{
+				Identifier: "b",
+				Type:       cadence.StringType{},
+			},
+		},
+	}
+
+	t.Run("Simple", func(t *testing.T) {
+
+		t.Parallel()
+
+		testEncodeAndDecodeBytes(
+			t,
+			cadence.NewStruct(
+				[]cadence.Value{
+					cadence.NewInt(1),
+					cadence.String("foo"),
+				},
+			).WithType(simpleStructType),
+			// message: tag 128, array of 3
+			"d880"+"83"+
+				// type definition headers: struct type with ID "S.test.FooStruct"
+				"81"+"d898"+"70"+"532e746573742e466f6f537472756374"+
+				// type definition bodies
+				"81"+"83"+
+				// fields: [["a", Int], ["b", String]]
+				"82"+"82"+"6161"+"d888"+"11"+"82"+"6162"+"d888"+"07"+
+				// no initializers, no raw type
+				"f6"+"f6"+
+				// type and value: type definition 0, field values
+				"d881"+"82"+"d891"+"00"+"82"+"c2"+"41"+"01"+"63"+"666f6f",
+		)
+	})
+
+	resourceStructType := &cadence.StructType{
+		Location:            utils.TestLocation,
+		QualifiedIdentifier: "FooStruct",
+		Fields: []cadence.Field{
+			{
+				Identifier: "a",
+				Type:       cadence.StringType{},
+			},
+			{
+				Identifier: "b",
+				Type:       fooResourceType,
+			},
+		},
+	}
+
+	testAllEncodeAndDecode(t,
+		encodeTest{
+			"Resources",
+			cadence.NewStruct(
+				[]cadence.Value{
+					cadence.String("foo"),
+					cadence.NewResource(
+						[]cadence.Value{
+							cadence.NewInt(42),
+						},
+					).WithType(fooResourceType),
+				},
+			).WithType(resourceStructType),
+		},
+		encodeTest{
+			"Untyped field value",
+			cadence.NewStruct(
+				[]cadence.Value{
+					cadence.String("foo"),
+					cadence.NewArray([]cadence.Value{
+						cadence.NewInt(1),
+					}),
+				},
+			).WithType(&cadence.StructType{
+				Location:            utils.TestLocation,
+				QualifiedIdentifier: "FooStruct",
+				Fields: []cadence.Field{
+					{
+						Identifier: "a",
+						Type:       cadence.AnyStructType{},
+					},
+					{
+						Identifier: "b",
+						Type: cadence.VariableSizedArrayType{
+							ElementType: cadence.IntType{},
+						},
+					},
+				},
+			}),
+		},
+	)
+}
+
+func TestEncodeEvent(t *testing.T) {
+
+	t.Parallel()
+
+	simpleEventType := &cadence.EventType{
+		Location:            utils.TestLocation,
+		QualifiedIdentifier: "FooEvent",
+		Fields: []cadence.Field{
+			{
+				Identifier: "a",
+				Type:       cadence.IntType{},
+			},
+			{
+				Identifier: "b",
+				Type:       cadence.StringType{},
+			},
+		},
+		Initializer: []cadence.Parameter{
+			{
+				Label:      "a",
+				Identifier: "a",
+				Type:       cadence.IntType{},
+			},
+			{
+				Label:      "b",
+				Identifier: "b",
+				Type:       cadence.StringType{},
+			},
+		},
+	}
+
+	simpleEvent := encodeTest{
+		"Simple",
+		cadence.NewEvent(
+			[]cadence.Value{
+				cadence.NewInt(1),
+				cadence.String("foo"),
+			},
+		).WithType(simpleEventType),
+	}
+
+	resourceEventType := &cadence.EventType{
+		Location:            utils.TestLocation,
+		QualifiedIdentifier: "FooEvent",
+		Fields: []cadence.Field{
+			{
+				Identifier: "a",
+				Type:       cadence.StringType{},
+			},
+			{
+				Identifier: "b",
+				Type:       fooResourceType,
+			},
+		},
+	}
+
+	resourceEvent := encodeTest{
+		"Resources",
+		cadence.NewEvent(
+			[]cadence.Value{
+				cadence.String("foo"),
+				cadence.NewResource(
+					[]cadence.Value{
+						cadence.NewInt(42),
+					},
+				).WithType(fooResourceType),
+			},
+		).WithType(resourceEventType),
+	}
+
+	testAllEncodeAndDecode(t, simpleEvent, resourceEvent)
+}
+
+func TestEncodeContract(t *testing.T) {
+
+	t.Parallel()
+
+	simpleContractType := &cadence.ContractType{
+		Location:            utils.TestLocation,
+		QualifiedIdentifier: "FooContract",
+		Fields: []cadence.Field{
+			{
+				Identifier: "a",
+				Type:       cadence.IntType{},
+			},
+			{
+				Identifier: "b",
+				Type:       cadence.StringType{},
+			},
+		},
+	}
+
+	simpleContract := encodeTest{
+		"Simple",
+		cadence.NewContract(
+			[]cadence.Value{
+				cadence.NewInt(1),
+				cadence.String("foo"),
+			},
+		).WithType(simpleContractType),
+	}
+
+	testAllEncodeAndDecode(t, simpleContract)
+}
+
+func TestEncodeEnum(t *testing.T) {
+
+	t.Parallel()
+
+	actual := exportFromScript(t, `
+		enum Direction: UInt8 {
+			case up
+			case down
+		}
+
+		fun main(): Direction {
+			return Direction.down
+		}
+	`)
+
+	testEncodeAndDecode(t, actual)
+}
+
+func TestEncodeLink(t *testing.T) {
+
+	t.Parallel()
+
+	testEncodeAndDecode(
+		t,
+		cadence.NewLink(
+			cadence.Path{Domain: "storage", Identifier: "foo"},
+			"Bar",
+		),
+	)
+}
+
+func TestEncodePath(t *testing.T) {
+
+	t.Parallel()
+
+	testEncodeAndDecode(
+		t,
+		cadence.Path{Domain: "storage", Identifier: "foo"},
+	)
+}
+
+func TestEncodeSimpleTypes(t *testing.T) {
+
+	t.Parallel()
+
+	tests := []cadence.Type{
+		cadence.AnyType{},
+		cadence.AnyStructType{},
+		cadence.AnyResourceType{},
+		cadence.MetaType{},
+		cadence.VoidType{},
+		cadence.NeverType{},
+		cadence.BoolType{},
+		cadence.StringType{},
+		cadence.CharacterType{},
+		cadence.BytesType{},
+		cadence.AddressType{},
+		cadence.NumberType{},
+		cadence.SignedNumberType{},
+		cadence.IntegerType{},
+		cadence.SignedIntegerType{},
+		cadence.FixedPointType{},
+		cadence.SignedFixedPointType{},
+		cadence.IntType{},
+		cadence.Int8Type{},
+		cadence.Int16Type{},
+		cadence.Int32Type{},
+		cadence.Int64Type{},
+		cadence.Int128Type{},
+		cadence.Int256Type{},
+		cadence.UIntType{},
+		cadence.UInt8Type{},
+		cadence.UInt16Type{},
+		cadence.UInt32Type{},
+		cadence.UInt64Type{},
+		cadence.UInt128Type{},
+		cadence.UInt256Type{},
+		cadence.Word8Type{},
+		cadence.Word16Type{},
+		cadence.Word32Type{},
+		cadence.Word64Type{},
+		cadence.Fix64Type{},
+		cadence.UFix64Type{},
+		cadence.BlockType{},
+		cadence.PathType{},
+		cadence.CapabilityPathType{},
+		cadence.StoragePathType{},
+		cadence.PublicPathType{},
+		cadence.PrivatePathType{},
+		cadence.AccountKeyType{},
+		cadence.AuthAccountContractsType{},
+		cadence.AuthAccountKeysType{},
+		cadence.AuthAccountType{},
+		cadence.PublicAccountContractsType{},
+		cadence.PublicAccountKeysType{},
+		cadence.PublicAccountType{},
+		cadence.DeployedContractType{},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(fmt.Sprintf("with static %s", test.ID()), func(t *testing.T) {
+
+			t.Parallel()
+
+			testEncodeAndDecode(
+				t,
+				cadence.TypeValue{
+					StaticType: test,
+				},
+			)
+		})
+	}
+}
+
+func TestEncodeType(t *testing.T) {
+
+	t.Parallel()
+
+	structInterfaceType := &cadence.StructInterfaceType{
+		Location:            utils.TestLocation,
+		QualifiedIdentifier: "SI",
+		Fields: []cadence.Field{
+			{Identifier: "foo", Type: cadence.IntType{}},
+		},
+	}
+
+	resourceInterfaceType := &cadence.ResourceInterfaceType{
+		Location:            utils.TestLocation,
+		QualifiedIdentifier: "RI",
+	}
+
+	testAllEncodeAndDecode(t, []encodeTest{
+		{
+			"without static type",
+			cadence.TypeValue{},
+		},
+		{
+			"with static int?",
+			cadence.TypeValue{
+				StaticType: cadence.OptionalType{Type: cadence.IntType{}},
+			},
+		},
+		{
+			"with static [int]",
+			cadence.TypeValue{
+				StaticType: cadence.VariableSizedArrayType{ElementType: cadence.IntType{}},
+			},
+		},
+		{
+			"with static [int; 3]",
+			cadence.TypeValue{
+				StaticType: cadence.ConstantSizedArrayType{
+					ElementType: cadence.IntType{},
+					Size:        3,
+				},
+			},
+		},
+		{
+			"with static {int:string}",
+			cadence.TypeValue{
+				StaticType: cadence.DictionaryType{
+					ElementType: cadence.StringType{},
+					KeyType:     cadence.IntType{},
+				},
+			},
+		},
+		{
+			"with static struct",
+			cadence.TypeValue{
+				StaticType: &cadence.StructType{
+					Location:            utils.TestLocation,
+					QualifiedIdentifier: "S",
+					Fields: []cadence.Field{
+						{Identifier: "foo", Type: cadence.IntType{}},
+					},
+					Initializers: [][]cadence.Parameter{
+						{{Label: "foo", Identifier: "bar", Type: cadence.IntType{}}},
+						{{Label: "qux", Identifier: "baz", Type: cadence.StringType{}}},
+					},
+				},
+			},
+		},
+		{
+			"with static struct interface",
+			cadence.TypeValue{
+				StaticType: structInterfaceType,
+			},
+		},
+		{
+			"with static contract interface",
+			cadence.TypeValue{
+				StaticType: &cadence.ContractInterfaceType{
+					Location:            utils.TestLocation,
+					QualifiedIdentifier: "CI",
+					Initializers:        [][]cadence.Parameter{},
+				},
+			},
+		},
+		{
+			"with static enum",
+			cadence.TypeValue{
+				StaticType: &cadence.EnumType{
+					Location:            utils.TestLocation,
+					QualifiedIdentifier: "E",
+					RawType:             cadence.UInt8Type{},
+					Fields: []cadence.Field{
+						{Identifier: sema.EnumRawValueFieldName, Type: cadence.UInt8Type{}},
+					},
+				},
+			},
+		},
+		{
+			"with static &int",
+			cadence.TypeValue{
+				StaticType: cadence.ReferenceType{
+					Authorized: false,
+					Type:       cadence.IntType{},
+				},
+			},
+		},
+		{
+			"with static auth &int",
+			cadence.TypeValue{
+				StaticType: cadence.ReferenceType{
+					Authorized: true,
+					Type:       cadence.IntType{},
+				},
+			},
+		},
+		{
+			"with static function",
+			cadence.TypeValue{
+				StaticType: cadence.FunctionType{
+					Parameters: []cadence.Parameter{
+						{Label: "qux", Identifier: "baz", Type: cadence.StringType{}},
+					},
+					ReturnType: cadence.IntType{},
+				}.WithID("((String):Int)"),
+			},
+		},
+		{
+			"with static restricted type",
+			cadence.TypeValue{
+				StaticType: cadence.RestrictedType{
+					Restrictions: []cadence.Type{
+						resourceInterfaceType,
+					},
+					Type: cadence.AnyResourceType{},
+				}.WithID("AnyResource{S.test.RI}"),
+			},
+		},
+		{
+			"with static capability",
+			cadence.TypeValue{
+				StaticType: cadence.CapabilityType{
+					BorrowType: cadence.ReferenceType{
+						Type: structInterfaceType,
+					},
+				},
+			},
+		},
+	}...)
+}
+
+func TestEncodeCapability(t *testing.T) {
+
+	t.Parallel()
+
+	testEncodeAndDecode(
+		t,
+		cadence.Capability{
+			Path:       cadence.Path{Domain: "storage", Identifier: "foo"},
+			Address:    cadence.BytesToAddress([]byte{1, 2, 3, 4, 5}),
+			BorrowType: cadence.IntType{},
+		},
+	)
+}
+
+func TestEncodeControllerCapability(t *testing.T) {
+
+	t.Parallel()
+
+	testEncodeAndDecode(
+		t,
+		cadence.Capability{
+			Address:    cadence.BytesToAddress([]byte{1, 2, 3, 4, 5}),
+			BorrowType: cadence.IntType{},
+			ID:         42,
+		},
+	)
+}
+
+func TestEncodeRecursiveType(t *testing.T) {
+
+	t.Parallel()
+
+	ty := &cadence.ResourceType{
+		Location:            utils.TestLocation,
+		QualifiedIdentifier: "Foo",
+		Fields: []cadence.Field{
+			{
+				Identifier: "foo",
+			},
+		},
+	}
+
+	ty.Fields[0].Type = cadence.OptionalType{
+		Type: ty,
+	}
+
+	testAllEncodeAndDecode(t, []encodeTest{
+		{
+			"nil",
+			cadence.Resource{
+				Fields: []cadence.Value{
+					cadence.NewOptional(nil),
+				},
+			}.WithType(ty),
+		},
+		{
+			"non-nil",
+			cadence.Resource{
+				Fields: []cadence.Value{
+					cadence.NewOptional(
+						cadence.Resource{
+							Fields: []cadence.Value{
+								cadence.NewOptional(nil),
+							},
+						}.WithType(ty),
+					),
+				},
+			}.WithType(ty),
+		},
+	}...)
+}
+
+func TestEncodeTypeDefinitionsOnce(t *testing.T) {
+
+	t.Parallel()
+
+	events := make([]cadence.Value, 10)
+	for i := range events {
+		events[i] = cadence.NewResource([]cadence.Value{
+			cadence.NewInt(i),
+		}).WithType(fooResourceType)
+	}
+
+	value := cadence.NewArray(events).
+		WithType(cadence.VariableSizedArrayType{
+			ElementType: fooResourceType,
+		})
+
+	encoded := testEncodeAndDecode(t, value)
+
+	// The type is only defined once, and both the type and the field name are not repeated
+
+	assert.Equal(t, 1, bytes.Count(encoded, []byte("S.test.Foo")))
+	assert.Equal(t, 1, bytes.Count(encoded, []byte("bar")))
+
+	// The encoding is significantly smaller than the JSON-Cadence encoding
+
+	encodedJSON, err := json.Encode(value)
+	require.NoError(t, err)
+
+	assert.Less(t, len(encoded)*4, len(encodedJSON))
+}
+
+func TestEncodeDeterministic(t *testing.T) {
+
+	t.Parallel()
+
+	otherResourceType := &cadence.ResourceType{
+		Location:            utils.TestLocation,
+		QualifiedIdentifier: "Other",
+		Fields: []cadence.Field{
+			{
+				Identifier: "foo",
+				Type:       fooResourceType,
+			},
+		},
+	}
+
+	dictionaryType := cadence.DictionaryType{
+		KeyType:     cadence.StringType{},
+		ElementType: cadence.AnyResourceType{},
+	}
+
+	first := cadence.KeyValuePair{
+		Key: cadence.String("a"),
+		Value: cadence.NewResource([]cadence.Value{
+			cadence.NewResource([]cadence.Value{
+				cadence.NewInt(1),
+			}).WithType(fooResourceType),
+		}).WithType(otherResourceType),
+	}
+
+	second := cadence.KeyValuePair{
+		Key: cadence.String("b"),
+		Value: cadence.NewResource([]cadence.Value{
+			cadence.NewInt(2),
+		}).WithType(fooResourceType),
+	}
+
+	t.Run("typed dictionary", func(t *testing.T) {
+
+		t.Parallel()
+
+		expected, err := ccf.Encode(
+			cadence.NewDictionary([]cadence.KeyValuePair{first, second}).
+				WithType(dictionaryType),
+		)
+		require.NoError(t, err)
+
+		actual, err := ccf.Encode(
+			cadence.NewDictionary([]cadence.KeyValuePair{second, first}).
+				WithType(dictionaryType),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("untyped dictionary", func(t *testing.T) {
+
+		t.Parallel()
+
+		integerPair := cadence.KeyValuePair{
+			Key:   cadence.NewInt(1),
+			Value: cadence.String("c"),
+		}
+
+		expected, err := ccf.Encode(
+			cadence.NewDictionary([]cadence.KeyValuePair{first, second, integerPair}),
+		)
+		require.NoError(t, err)
+
+		actual, err := ccf.Encode(
+			cadence.NewDictionary([]cadence.KeyValuePair{integerPair, second, first}),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, actual)
+	})
+}
+
+func TestEncodeNonUTF8String(t *testing.T) {
+
+	t.Parallel()
+
+	nonUTF8String := "\xbd\xb2\x3d\xbc\x20\xe2"
+
+	// Make sure it is an invalid utf8 string
+	assert.False(t, utf8.ValidString(nonUTF8String))
+
+	// Avoid using the `NewString()` constructor to skip the validation
+	stringValue := cadence.String(nonUTF8String)
+
+	encodedValue, err := ccf.Encode(stringValue)
+	require.NoError(t, err)
+
+	decodedValue, err := ccf.Decode(encodedValue)
+	require.NoError(t, err)
+
+	// Decoded value must be a valid utf8 string
+	assert.IsType(t, cadence.String(""), decodedValue)
+	assert.True(t, utf8.ValidString(decodedValue.String()))
+}
+
+func TestEncodeUnsupportedValue(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ccf.Encode(cadence.NewStruct(nil))
+	require.Error(t, err)
+}
+
+func TestDecodeInvalid(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(name string, encoded string) {
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			data, err := hex.DecodeString(encoded)
+			require.NoError(t, err)
+
+			_, err = ccf.Decode(data)
+			require.Error(t, err)
+		})
+	}
+
+	test("empty", "")
+
+	test("not a message", "f6")
+
+	// message with a type and value, but without type definitions
+	test("missing type definitions", "d880"+"81"+"d881"+"82"+"d888"+"06"+"f5")
+
+	// Bool value encoded as an integer
+	test("mismatched value", "d880"+"83"+"80"+"80"+"d881"+"82"+"d888"+"06"+"01")
+
+	// simple type code 255 does not exist
+	test("invalid simple type", "d880"+"83"+"80"+"80"+"d881"+"82"+"d888"+"18ff"+"f6")
+
+	// reference to type definition 0, which does not exist
+	test("invalid type definition reference", "d880"+"83"+"80"+"80"+"d881"+"82"+"d891"+"00"+"80")
+
+	// Int8 value 128 is out of range
+	test("integer out of range", "d880"+"83"+"80"+"80"+"d881"+"82"+"d888"+"12"+"1880")
+
+	// value without type
+	test("missing type", "d880"+"83"+"80"+"80"+"d881"+"82"+"f6"+"f6")
+
+	// [Int; 2] value with three elements
+	test(
+		"constant-sized array length mismatch",
+		"d880"+"83"+"80"+"80"+"d881"+"82"+"d88b"+"82"+"02"+"d888"+"11"+"83"+"01"+"02"+"03",
+	)
+
+	// struct value with two field values, but the struct type only has one field
+	test(
+		"composite field count mismatch",
+		"d880"+"83"+
+			// type definition headers: S.test.Foo
+			"81"+"d898"+"6a"+hex.EncodeToString([]byte("S.test.Foo"))+
+			// type definition bodies: fields [a: Int], no initializers, no raw type
+			"81"+"83"+"81"+"82"+"61"+"61"+"d888"+"11"+"f6"+"f6"+
+			// value
+			"d881"+"82"+"d891"+"00"+"82"+"01"+"02",
+	)
+}
+
+func testAllEncodeAndDecode(t *testing.T, tests ...encodeTest) {
+
+	test := func(testCase encodeTest) {
+
+		t.Run(testCase.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			testEncodeAndDecode(t, testCase.val)
+		})
+	}
+
+	for _, testCase := range tests {
+		test(testCase)
+	}
+}
+
+func testEncodeAndDecode(t *testing.T, val cadence.Value) []byte {
+	encoded, err := ccf.Encode(val)
+	require.NoError(t, err)
+
+	testDecode(t, encoded, val)
+
+	return encoded
+}
+
+func testEncodeAndDecodeBytes(t *testing.T, val cadence.Value, expectedHex string) {
+	encoded := testEncodeAndDecode(t, val)
+
+	assert.Equal(t, expectedHex, hex.EncodeToString(encoded))
+}
+
+func testDecode(t *testing.T, encoded []byte, expectedVal cadence.Value) {
+	decodedVal, err := ccf.Decode(encoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedVal, decodedVal)
+}
+
+var fooResourceType = &cadence.ResourceType{
+	Location:            utils.TestLocation,
+	QualifiedIdentifier: "Foo",
+	Fields: []cadence.Field{
+		{
+			Identifier: "bar",
+			Type:       cadence.IntType{},
+		},
+	},
+}
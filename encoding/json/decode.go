@@ -546,16 +546,7 @@ type composite struct {
 func decodeComposite(valueJSON interface{}) composite {
 	obj := toObject(valueJSON)
 
-	typeID := obj.GetString(idKey)
-	location, qualifiedIdentifier, err := common.DecodeTypeID(typeID)
-
-	if err != nil ||
-		location == nil && sema.NativeCompositeTypes[typeID] == nil {
-
-		// If the location is nil, and there is no native composite type with this ID, then its an invalid type.
-		// Note: This is moved out from the common.DecodeTypeID() to avoid the circular dependency.
-		panic(fmt.Errorf("%s. invalid type ID: `%s`", ErrInvalidJSONCadence, typeID))
-	}
+	location, qualifiedIdentifier := decodeCompositeTypeID(obj.GetString(idKey))
 
 	fields := obj.GetSlice(fieldsKey)
 
@@ -577,6 +568,20 @@ func decodeComposite(valueJSON interface{}) composite {
 	}
 }
 
+func decodeCompositeTypeID(typeID string) (common.Location, string) {
+	location, qualifiedIdentifier, err := common.DecodeTypeID(typeID)
+
+	if err != nil ||
+		location == nil && sema.NativeCompositeTypes[typeID] == nil {
+
+		// If the location is nil, and there is no native composite type with this ID, then its an invalid type.
+		// Note: This is moved out from the common.DecodeTypeID() to avoid the circular dependency.
+		panic(fmt.Errorf("%s. invalid type ID: `%s`", ErrInvalidJSONCadence, typeID))
+	}
+
+	return location, qualifiedIdentifier
+}
+
 func decodeCompositeField(valueJSON interface{}) (cadence.Value, cadence.Field) {
 	obj := toObject(valueJSON)
 
@@ -592,8 +597,10 @@ func decodeCompositeField(valueJSON interface{}) (cadence.Value, cadence.Field)
 }
 
 func decodeStruct(valueJSON interface{}) cadence.Struct {
-	comp := decodeComposite(valueJSON)
+	return decodeComposite(valueJSON).structValue()
+}
 
+func (comp composite) structValue() cadence.Struct {
 	return cadence.NewStruct(comp.fieldValues).WithType(&cadence.StructType{
 		Location:            comp.location,
 		QualifiedIdentifier: comp.qualifiedIdentifier,
@@ -602,8 +609,10 @@ func decodeStruct(valueJSON interface{}) cadence.Struct {
 }
 
 func decodeResource(valueJSON interface{}) cadence.Resource {
-	comp := decodeComposite(valueJSON)
+	return decodeComposite(valueJSON).resourceValue()
+}
 
+func (comp composite) resourceValue() cadence.Resource {
 	return cadence.NewResource(comp.fieldValues).WithType(&cadence.ResourceType{
 		Location:            comp.location,
 		QualifiedIdentifier: comp.qualifiedIdentifier,
@@ -612,8 +621,10 @@ func decodeResource(valueJSON interface{}) cadence.Resource {
 }
 
 func decodeEvent(valueJSON interface{}) cadence.Event {
-	comp := decodeComposite(valueJSON)
+	return decodeComposite(valueJSON).eventValue()
+}
 
+func (comp composite) eventValue() cadence.Event {
 	return cadence.NewEvent(comp.fieldValues).WithType(&cadence.EventType{
 		Location:            comp.location,
 		QualifiedIdentifier: comp.qualifiedIdentifier,
@@ -622,8 +633,10 @@ func decodeEvent(valueJSON interface{}) cadence.Event {
 }
 
 func decodeContract(valueJSON interface{}) cadence.Contract {
-	comp := decodeComposite(valueJSON)
+	return decodeComposite(valueJSON).contractValue()
+}
 
+func (comp composite) contractValue() cadence.Contract {
 	return cadence.NewContract(comp.fieldValues).WithType(&cadence.ContractType{
 		Location:            comp.location,
 		QualifiedIdentifier: comp.qualifiedIdentifier,
@@ -632,8 +645,10 @@ func decodeContract(valueJSON interface{}) cadence.Contract {
 }
 
 func decodeEnum(valueJSON interface{}) cadence.Enum {
-	comp := decodeComposite(valueJSON)
+	return decodeComposite(valueJSON).enumValue()
+}
 
+func (comp composite) enumValue() cadence.Enum {
 	return cadence.NewEnum(comp.fieldValues).WithType(&cadence.EnumType{
 		Location:            comp.location,
 		QualifiedIdentifier: comp.qualifiedIdentifier,
@@ -970,16 +985,25 @@ func decodeTypeValue(valueJSON interface{}) cadence.TypeValue {
 func decodeCapability(valueJSON interface{}) cadence.Capability {
 	obj := toObject(valueJSON)
 
-	path, ok := decodeJSON(obj.Get(pathKey)).(cadence.Path)
-	if !ok {
-		// TODO: improve error message
-		panic(ErrInvalidJSONCadence)
+	// Capabilities issued through a capability controller have an ID, but no path
+
+	var id uint64
+	var path cadence.Path
+	if idJSON, ok := obj[idKey]; ok {
+		id = uint64(decodeUInt64(idJSON))
+	} else {
+		path, ok = decodeJSON(obj.Get(pathKey)).(cadence.Path)
+		if !ok {
+			// TODO: improve error message
+			panic(ErrInvalidJSONCadence)
+		}
 	}
 
 	return cadence.Capability{
 		Path:       path,
 		Address:    decodeAddress(obj.Get(addressKey)),
 		BorrowType: decodeType(obj.Get(borrowTypeKey)),
+		ID:         id,
 	}
 }
 
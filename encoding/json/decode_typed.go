@@ -0,0 +1,805 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// Limits are the limits enforced when decoding a value against an expected type.
+// A limit of zero means that the limit is not enforced.
+//
+type Limits struct {
+	// MaxBytes is the maximum size in bytes of the encoded value.
+	// It is checked before the value is unmarshalled
+	MaxBytes int
+	// MaxDepth is the maximum nesting depth of values, and of types in type values
+	MaxDepth int
+	// MaxElements is the maximum number of elements of an array,
+	// entries of a dictionary, or fields of a composite,
+	// and of fields, initializers, parameters, or restrictions of a type
+	MaxElements int
+	// MaxStringLength is the maximum length in bytes of a string, character,
+	// number, address, path identifier, capability ID, or type identifier
+	MaxStringLength int
+}
+
+// DefaultLimits are the limits suitable for decoding untrusted values,
+// such as transaction and script arguments
+//
+var DefaultLimits = Limits{
+	MaxBytes:        1 << 22,
+	MaxDepth:        64,
+	MaxElements:     10_000,
+	MaxStringLength: 1 << 20,
+}
+
+// ErrLimitExceeded is wrapped by the errors returned when a value exceeds a decoding limit
+//
+var ErrLimitExceeded = errors.New("decoding limit exceeded")
+
+// TypedDecodingError is returned when decoding a value against an expected type fails,
+// because the value does not conform to the type, or exceeds a decoding limit.
+//
+type TypedDecodingError struct {
+	// Path is the path of the invalid value, relative to the decoded value,
+	// for example `.items[3].id`
+	Path string
+	Err  error
+}
+
+func (e *TypedDecodingError) Unwrap() error {
+	return e.Err
+}
+
+func (e *TypedDecodingError) Error() string {
+	return e.ErrorWithRoot("value")
+}
+
+// ErrorWithRoot returns the error message,
+// with the path of the invalid value prefixed by the given root, for example `args[1]`
+//
+func (e *TypedDecodingError) ErrorWithRoot(root string) string {
+	return fmt.Sprintf("%s%s: %s", root, e.Path, e.Err.Error())
+}
+
+// DecodeWithType returns a Cadence value decoded from its JSON-encoded representation,
+// validated against the given expected type and limits while decoding.
+//
+// Unlike Decode, this function does not trust the types embedded in the encoding:
+// Values must have the expected type, and composites must have exactly the fields of the expected type.
+// Abstract expected types, like AnyStruct or interface types, only enforce the limits,
+// and must be validated against by the caller after decoding.
+//
+// This function returns a *TypedDecodingError if the value does not conform to the expected type,
+// or exceeds the limits.
+//
+func DecodeWithType(b []byte, expectedType cadence.Type, limits Limits) (cadence.Value, error) {
+	err := checkSize(len(b), limits)
+	if err != nil {
+		return nil, err
+	}
+
+	r := bytes.NewReader(b)
+	dec := NewDecoder(r)
+
+	v, err := dec.DecodeWithType(expectedType, limits)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// DecodeWithType reads JSON-encoded bytes from the io.Reader and decodes them to a
+// Cadence value, validated against the given expected type and limits while decoding.
+//
+// See the DecodeWithType function for details.
+//
+func (d *Decoder) DecodeWithType(expectedType cadence.Type, limits Limits) (value cadence.Value, err error) {
+	// Only determine the extent of the value first,
+	// so the size limit is checked before the value is unmarshalled
+
+	var rawJSON json.RawMessage
+
+	err = d.dec.Decode(&rawJSON)
+	if err != nil {
+		return nil, fmt.Errorf("json-cdc: failed to decode valid JSON structure: %w", err)
+	}
+
+	err = checkSize(len(rawJSON), limits)
+	if err != nil {
+		return nil, err
+	}
+
+	var valueJSON interface{}
+
+	err = json.Unmarshal(rawJSON, &valueJSON)
+	if err != nil {
+		return nil, fmt.Errorf("json-cdc: failed to decode valid JSON structure: %w", err)
+	}
+
+	// capture panics that occur during decoding
+	defer func() {
+		if r := recover(); r != nil {
+			decodingErr, ok := r.(*TypedDecodingError)
+			if !ok {
+				panic(r)
+			}
+
+			err = decodingErr
+		}
+	}()
+
+	decoder := typedDecoder{
+		limits: limits,
+	}
+
+	value = decoder.decode(valueJSON, expectedType, "", 0)
+	return value, nil
+}
+
+func checkSize(size int, limits Limits) error {
+	if limits.MaxBytes > 0 && size > limits.MaxBytes {
+		return &TypedDecodingError{
+			Err: limitExceededError("maximum size of %d bytes", limits.MaxBytes),
+		}
+	}
+
+	return nil
+}
+
+type typedDecoder struct {
+	limits Limits
+}
+
+// decode decodes the given JSON value, validating it against the given expected type.
+// The expected type is nil if the value is unconstrained
+//
+func (d typedDecoder) decode(
+	valueJSON interface{},
+	expectedType cadence.Type,
+	path string,
+	depth int,
+) cadence.Value {
+
+	// attribute errors of the untyped decoding functions to the value
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch err := r.(type) {
+			case *TypedDecodingError:
+				panic(err)
+			case error:
+				panic(&TypedDecodingError{
+					Path: path,
+					Err:  err,
+				})
+			default:
+				panic(r)
+			}
+		}
+	}()
+
+	if d.limits.MaxDepth > 0 && depth > d.limits.MaxDepth {
+		panic(limitExceededError("maximum depth of %d", d.limits.MaxDepth))
+	}
+
+	obj := toObject(valueJSON)
+
+	typeStr := obj.GetString(typeKey)
+
+	if optionalType, ok := expectedType.(cadence.OptionalType); ok && typeStr != optionalTypeStr {
+		// a non-optional value can be passed where an optional is expected
+		return d.decode(valueJSON, optionalType.Type, path, depth)
+	}
+
+	expectedTypeStr, ok := jsonTypeStr(expectedType)
+	if !ok {
+		// the expected type is abstract, e.g. AnyStruct,
+		// so the value is only constrained by the limits
+		expectedType = nil
+	} else if typeStr != expectedTypeStr {
+		panic(fmt.Errorf(
+			"%w: expected %s, got %s",
+			ErrInvalidJSONCadence,
+			expectedTypeStr,
+			typeStr,
+		))
+	}
+
+	// void is a special case, does not have "value" field
+	if typeStr == voidTypeStr {
+		return decodeVoid(obj)
+	}
+
+	// object should only contain two keys: "type", "value"
+	if len(obj) != 2 {
+		panic(fmt.Errorf("%w: unexpected keys in value", ErrInvalidJSONCadence))
+	}
+
+	innerJSON := obj.Get(valueKey)
+
+	switch typeStr {
+	case optionalTypeStr:
+		if innerJSON == nil {
+			return cadence.NewOptional(nil)
+		}
+
+		var innerType cadence.Type
+		if optionalType, ok := expectedType.(cadence.OptionalType); ok {
+			innerType = optionalType.Type
+		}
+
+		return cadence.NewOptional(d.decode(innerJSON, innerType, path, depth+1))
+
+	case arrayTypeStr:
+		return d.decodeArray(innerJSON, expectedType, path, depth)
+
+	case dictionaryTypeStr:
+		return d.decodeDictionary(innerJSON, expectedType, path, depth)
+
+	case structTypeStr,
+		resourceTypeStr,
+		eventTypeStr,
+		contractTypeStr,
+		enumTypeStr:
+
+		return d.decodeComposite(typeStr, innerJSON, expectedType, path, depth)
+
+	case pathTypeStr:
+		return d.decodePath(innerJSON, expectedType)
+
+	case linkTypeStr:
+		return d.decodeLink(innerJSON, path, depth)
+
+	case typeTypeStr:
+		return d.decodeTypeValue(innerJSON, depth)
+
+	case capabilityTypeStr:
+		return d.decodeCapability(innerJSON, expectedType, path, depth)
+
+	case stringTypeStr,
+		characterTypeStr,
+		addressTypeStr:
+
+		d.checkStringLength(innerJSON)
+
+	default:
+		// numbers are encoded as strings
+		if _, ok := innerJSON.(string); ok {
+			d.checkStringLength(innerJSON)
+		}
+	}
+
+	return decodeJSON(valueJSON)
+}
+
+func (d typedDecoder) decodeArray(
+	valueJSON interface{},
+	expectedType cadence.Type,
+	path string,
+	depth int,
+) cadence.Array {
+
+	elementsJSON := d.toSlice(valueJSON)
+
+	var elementType cadence.Type
+
+	switch expectedType := expectedType.(type) {
+	case cadence.VariableSizedArrayType:
+		elementType = expectedType.ElementType
+
+	case cadence.ConstantSizedArrayType:
+		elementType = expectedType.ElementType
+
+		if uint(len(elementsJSON)) != expectedType.Size {
+			panic(fmt.Errorf(
+				"%w: expected %d elements, got %d",
+				ErrInvalidJSONCadence,
+				expectedType.Size,
+				len(elementsJSON),
+			))
+		}
+	}
+
+	values := make([]cadence.Value, len(elementsJSON))
+
+	for i, elementJSON := range elementsJSON {
+		elementPath := fmt.Sprintf("%s[%d]", path, i)
+		values[i] = d.decode(elementJSON, elementType, elementPath, depth+1)
+	}
+
+	return cadence.NewArray(values)
+}
+
+func (d typedDecoder) decodeDictionary(
+	valueJSON interface{},
+	expectedType cadence.Type,
+	path string,
+	depth int,
+) cadence.Dictionary {
+
+	entriesJSON := d.toSlice(valueJSON)
+
+	var keyType, elementType cadence.Type
+
+	if dictionaryType, ok := expectedType.(cadence.DictionaryType); ok {
+		keyType = dictionaryType.KeyType
+		elementType = dictionaryType.ElementType
+	}
+
+	pairs := make([]cadence.KeyValuePair, len(entriesJSON))
+
+	for i, entryJSON := range entriesJSON {
+		entryPath := fmt.Sprintf("%s.keys[%d]", path, i)
+
+		entry := toObject(entryJSON)
+
+		// entry should only contain two keys: "key", "value"
+		if len(entry) != 2 {
+			panic(&TypedDecodingError{
+				Path: entryPath,
+				Err:  fmt.Errorf("%w: unexpected keys in dictionary entry", ErrInvalidJSONCadence),
+			})
+		}
+
+		key := d.decode(entry.Get(keyKey), keyType, entryPath, depth+1)
+
+		elementPath := fmt.Sprintf("%s[%s]", path, key.String())
+		value := d.decode(entry.Get(valueKey), elementType, elementPath, depth+1)
+
+		pairs[i] = cadence.KeyValuePair{
+			Key:   key,
+			Value: value,
+		}
+	}
+
+	return cadence.NewDictionary(pairs)
+}
+
+func (d typedDecoder) decodeComposite(
+	typeStr string,
+	valueJSON interface{},
+	expectedType cadence.Type,
+	path string,
+	depth int,
+) cadence.Value {
+
+	obj := toObject(valueJSON)
+
+	// object should only contain two keys: "id", "fields"
+	if len(obj) != 2 {
+		panic(fmt.Errorf("%w: unexpected keys in composite", ErrInvalidJSONCadence))
+	}
+
+	typeID := obj.GetString(idKey)
+
+	var expectedFields []cadence.Field
+
+	compositeType, isTyped := expectedType.(cadence.CompositeType)
+	if isTyped {
+		if typeID != compositeType.ID() {
+			panic(fmt.Errorf(
+				"%w: expected type %s, got %s",
+				ErrInvalidJSONCadence,
+				compositeType.ID(),
+				typeID,
+			))
+		}
+
+		expectedFields = compositeType.CompositeFields()
+	}
+
+	location, qualifiedIdentifier := decodeCompositeTypeID(typeID)
+
+	fieldsJSON := d.toSlice(obj.Get(fieldsKey))
+
+	fieldValues := make([]cadence.Value, len(fieldsJSON))
+	fieldTypes := make([]cadence.Field, len(fieldsJSON))
+
+	decodedFields := make(map[string]struct{}, len(fieldsJSON))
+
+	for i, fieldJSON := range fieldsJSON {
+		field := toObject(fieldJSON)
+
+		// field should only contain two keys: "name", "value"
+		if len(field) != 2 {
+			panic(fmt.Errorf("%w: unexpected keys in composite field", ErrInvalidJSONCadence))
+		}
+
+		name := field.GetString(nameKey)
+
+		if _, ok := decodedFields[name]; ok {
+			panic(fmt.Errorf("%w: duplicate field `%s`", ErrInvalidJSONCadence, name))
+		}
+		decodedFields[name] = struct{}{}
+
+		var fieldType cadence.Type
+
+		if isTyped {
+			var ok bool
+			fieldType, ok = compositeFieldType(expectedFields, name)
+			if !ok {
+				panic(fmt.Errorf("%w: unexpected field `%s`", ErrInvalidJSONCadence, name))
+			}
+		}
+
+		fieldPath := fmt.Sprintf("%s.%s", path, name)
+		value := d.decode(field.Get(valueKey), fieldType, fieldPath, depth+1)
+
+		fieldValues[i] = value
+		fieldTypes[i] = cadence.Field{
+			Identifier: name,
+			Type:       value.Type(),
+		}
+	}
+
+	if isTyped {
+		for _, expectedField := range expectedFields {
+			if _, ok := decodedFields[expectedField.Identifier]; !ok {
+				panic(fmt.Errorf(
+					"%w: missing field `%s`",
+					ErrInvalidJSONCadence,
+					expectedField.Identifier,
+				))
+			}
+		}
+	}
+
+	comp := composite{
+		location:            location,
+		qualifiedIdentifier: qualifiedIdentifier,
+		fieldValues:         fieldValues,
+		fieldTypes:          fieldTypes,
+	}
+
+	switch typeStr {
+	case structTypeStr:
+		return comp.structValue()
+	case resourceTypeStr:
+		return comp.resourceValue()
+	case eventTypeStr:
+		return comp.eventValue()
+	case contractTypeStr:
+		return comp.contractValue()
+	case enumTypeStr:
+		return comp.enumValue()
+	}
+
+	panic(ErrInvalidJSONCadence)
+}
+
+func (d typedDecoder) decodePath(valueJSON interface{}, expectedType cadence.Type) cadence.Path {
+	obj := toObject(valueJSON)
+
+	// object should only contain two keys: "domain", "identifier"
+	if len(obj) != 2 {
+		panic(fmt.Errorf("%w: unexpected keys in path", ErrInvalidJSONCadence))
+	}
+
+	d.checkStringLength(obj.Get(identifierKey))
+
+	path := decodePath(valueJSON)
+
+	var expectedDomains []common.PathDomain
+
+	switch expectedType.(type) {
+	case cadence.StoragePathType:
+		expectedDomains = []common.PathDomain{common.PathDomainStorage}
+	case cadence.PublicPathType:
+		expectedDomains = []common.PathDomain{common.PathDomainPublic}
+	case cadence.PrivatePathType:
+		expectedDomains = []common.PathDomain{common.PathDomainPrivate}
+	case cadence.CapabilityPathType:
+		expectedDomains = []common.PathDomain{common.PathDomainPublic, common.PathDomainPrivate}
+	default:
+		expectedDomains = common.AllPathDomains
+	}
+
+	for _, domain := range expectedDomains {
+		if path.Domain == domain.Identifier() {
+			return path
+		}
+	}
+
+	panic(fmt.Errorf(
+		"%w: unexpected path domain `%s`",
+		ErrInvalidJSONCadence,
+		path.Domain,
+	))
+}
+
+func (d typedDecoder) decodeLink(valueJSON interface{}, path string, depth int) cadence.Link {
+	obj := toObject(valueJSON)
+
+	// object should only contain two keys: "targetPath", "borrowType"
+	if len(obj) != 2 {
+		panic(fmt.Errorf("%w: unexpected keys in link", ErrInvalidJSONCadence))
+	}
+
+	targetPath := d.decode(obj.Get(targetPathKey), cadence.PathType{}, path, depth+1).(cadence.Path)
+
+	borrowTypeJSON := obj.Get(borrowTypeKey)
+	d.checkStringLength(borrowTypeJSON)
+
+	return cadence.NewLink(targetPath, toString(borrowTypeJSON))
+}
+
+func (d typedDecoder) decodeTypeValue(valueJSON interface{}, depth int) cadence.TypeValue {
+	obj := toObject(valueJSON)
+
+	// object should only contain one key: "staticType"
+	if len(obj) != 1 {
+		panic(fmt.Errorf("%w: unexpected keys in type value", ErrInvalidJSONCadence))
+	}
+
+	staticTypeJSON := obj.Get(staticTypeKey)
+	d.checkTypeLimits(staticTypeJSON, depth+1)
+
+	return cadence.TypeValue{
+		StaticType: decodeType(staticTypeJSON),
+	}
+}
+
+func (d typedDecoder) decodeCapability(
+	valueJSON interface{},
+	expectedType cadence.Type,
+	path string,
+	depth int,
+) cadence.Capability {
+
+	obj := toObject(valueJSON)
+
+	// object should only contain three keys:
+	// "id" or "path" (capabilities issued through a capability controller have an ID, but no path),
+	// "address", and "borrowType"
+	if len(obj) != 3 {
+		panic(fmt.Errorf("%w: unexpected keys in capability", ErrInvalidJSONCadence))
+	}
+
+	var id uint64
+	var capabilityPath cadence.Path
+	if idJSON, ok := obj[idKey]; ok {
+		d.checkStringLength(idJSON)
+		id = uint64(decodeUInt64(idJSON))
+	} else {
+		capabilityPath = d.decode(obj.Get(pathKey), cadence.PathType{}, path, depth+1).(cadence.Path)
+	}
+
+	addressJSON := obj.Get(addressKey)
+	d.checkStringLength(addressJSON)
+	address := decodeAddress(addressJSON)
+
+	borrowTypeJSON := obj.Get(borrowTypeKey)
+	d.checkTypeLimits(borrowTypeJSON, depth+1)
+	borrowType := decodeType(borrowTypeJSON)
+
+	if capabilityType, ok := expectedType.(cadence.CapabilityType); ok &&
+		capabilityType.BorrowType != nil {
+
+		if borrowType == nil || borrowType.ID() != capabilityType.BorrowType.ID() {
+			var borrowTypeID string
+			if borrowType != nil {
+				borrowTypeID = borrowType.ID()
+			}
+
+			panic(fmt.Errorf(
+				"%w: expected borrow type %s, got %s",
+				ErrInvalidJSONCadence,
+				capabilityType.BorrowType.ID(),
+				borrowTypeID,
+			))
+		}
+	}
+
+	return cadence.Capability{
+		Path:       capabilityPath,
+		Address:    address,
+		BorrowType: borrowType,
+		ID:         id,
+	}
+}
+
+// checkTypeLimits ensures the given JSON-encoded type is within the limits.
+// Each nested type counts as one level of depth
+//
+func (d typedDecoder) checkTypeLimits(typeJSON interface{}, depth int) {
+	if d.limits.MaxDepth > 0 && depth > d.limits.MaxDepth {
+		panic(limitExceededError("maximum depth of %d", d.limits.MaxDepth))
+	}
+
+	// the empty string encodes the absence of a type
+	if typeJSON == "" {
+		return
+	}
+
+	obj := toObject(typeJSON)
+
+	for key, valueJSON := range obj {
+		switch key {
+		case typeIDKey:
+			d.checkStringLength(valueJSON)
+
+		case typeKey, keyKey, valueKey, returnKey:
+			d.checkTypeLimits(valueJSON, depth+1)
+
+		case restrictionsKey:
+			for _, restrictionJSON := range d.toSlice(valueJSON) {
+				d.checkTypeLimits(restrictionJSON, depth+1)
+			}
+
+		case fieldsKey, parametersKey:
+			for _, memberJSON := range d.toSlice(valueJSON) {
+				d.checkMemberTypeLimits(memberJSON, depth+1)
+			}
+
+		case initializersKey:
+			for _, parametersJSON := range d.toSlice(valueJSON) {
+				for _, parameterJSON := range d.toSlice(parametersJSON) {
+					d.checkMemberTypeLimits(parameterJSON, depth+1)
+				}
+			}
+		}
+	}
+}
+
+// checkMemberTypeLimits ensures the given JSON-encoded field or parameter of a type is within the limits
+//
+func (d typedDecoder) checkMemberTypeLimits(memberJSON interface{}, depth int) {
+	obj := toObject(memberJSON)
+
+	for key, valueJSON := range obj {
+		switch key {
+		case labelKey, idKey:
+			d.checkStringLength(valueJSON)
+
+		case typeKey:
+			d.checkTypeLimits(valueJSON, depth)
+		}
+	}
+}
+
+// toSlice converts the given JSON value to a slice,
+// and ensures the number of elements is within the limits
+//
+func (d typedDecoder) toSlice(valueJSON interface{}) []interface{} {
+	v := toSlice(valueJSON)
+
+	if d.limits.MaxElements > 0 && len(v) > d.limits.MaxElements {
+		panic(limitExceededError("maximum number of %d elements", d.limits.MaxElements))
+	}
+
+	return v
+}
+
+func (d typedDecoder) checkStringLength(valueJSON interface{}) {
+	v := toString(valueJSON)
+
+	if d.limits.MaxStringLength > 0 && len(v) > d.limits.MaxStringLength {
+		panic(limitExceededError("maximum string length of %d", d.limits.MaxStringLength))
+	}
+}
+
+func limitExceededError(format string, limit int) error {
+	return fmt.Errorf("%w: "+format, ErrLimitExceeded, limit)
+}
+
+func compositeFieldType(fields []cadence.Field, name string) (cadence.Type, bool) {
+	for _, field := range fields {
+		if field.Identifier == name {
+			return field.Type, true
+		}
+	}
+
+	return nil, false
+}
+
+// jsonTypeStr returns the JSON-Cadence type string of values of the given type.
+// It returns false if the type is abstract, i.e. values of different kinds may have the type
+//
+func jsonTypeStr(t cadence.Type) (string, bool) {
+	switch t.(type) {
+	case cadence.VoidType:
+		return voidTypeStr, true
+	case cadence.OptionalType:
+		return optionalTypeStr, true
+	case cadence.BoolType:
+		return boolTypeStr, true
+	case cadence.CharacterType:
+		return characterTypeStr, true
+	case cadence.StringType:
+		return stringTypeStr, true
+	case cadence.AddressType:
+		return addressTypeStr, true
+	case cadence.IntType:
+		return intTypeStr, true
+	case cadence.Int8Type:
+		return int8TypeStr, true
+	case cadence.Int16Type:
+		return int16TypeStr, true
+	case cadence.Int32Type:
+		return int32TypeStr, true
+	case cadence.Int64Type:
+		return int64TypeStr, true
+	case cadence.Int128Type:
+		return int128TypeStr, true
+	case cadence.Int256Type:
+		return int256TypeStr, true
+	case cadence.UIntType:
+		return uintTypeStr, true
+	case cadence.UInt8Type:
+		return uint8TypeStr, true
+	case cadence.UInt16Type:
+		return uint16TypeStr, true
+	case cadence.UInt32Type:
+		return uint32TypeStr, true
+	case cadence.UInt64Type:
+		return uint64TypeStr, true
+	case cadence.UInt128Type:
+		return uint128TypeStr, true
+	case cadence.UInt256Type:
+		return uint256TypeStr, true
+	case cadence.Word8Type:
+		return word8TypeStr, true
+	case cadence.Word16Type:
+		return word16TypeStr, true
+	case cadence.Word32Type:
+		return word32TypeStr, true
+	case cadence.Word64Type:
+		return word64TypeStr, true
+	case cadence.Fix64Type:
+		return fix64TypeStr, true
+	case cadence.UFix64Type:
+		return ufix64TypeStr, true
+	case cadence.VariableSizedArrayType,
+		cadence.ConstantSizedArrayType:
+		return arrayTypeStr, true
+	case cadence.DictionaryType:
+		return dictionaryTypeStr, true
+	case *cadence.StructType:
+		return structTypeStr, true
+	case *cadence.ResourceType:
+		return resourceTypeStr, true
+	case *cadence.EventType:
+		return eventTypeStr, true
+	case *cadence.ContractType:
+		return contractTypeStr, true
+	case *cadence.EnumType:
+		return enumTypeStr, true
+	case cadence.PathType,
+		cadence.CapabilityPathType,
+		cadence.StoragePathType,
+		cadence.PublicPathType,
+		cadence.PrivatePathType:
+		return pathTypeStr, true
+	case cadence.MetaType:
+		return typeTypeStr, true
+	case cadence.CapabilityType:
+		return capabilityTypeStr, true
+	}
+
+	return "", false
+}
@@ -0,0 +1,548 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+var itemStructType = &cadence.StructType{
+	Location:            utils.TestLocation,
+	QualifiedIdentifier: "Item",
+	Fields: []cadence.Field{
+		{
+			Identifier: "id",
+			Type:       cadence.UInt64Type{},
+		},
+		{
+			Identifier: "name",
+			Type:       cadence.OptionalType{Type: cadence.StringType{}},
+		},
+	},
+}
+
+var orderStructType = &cadence.StructType{
+	Location:            utils.TestLocation,
+	QualifiedIdentifier: "Order",
+	Fields: []cadence.Field{
+		{
+			Identifier: "items",
+			Type: cadence.VariableSizedArrayType{
+				ElementType: itemStructType,
+			},
+		},
+		{
+			Identifier: "metadata",
+			Type: cadence.DictionaryType{
+				KeyType:     cadence.StringType{},
+				ElementType: cadence.AnyStructType{},
+			},
+		},
+	},
+}
+
+func newTestItem(id uint64) cadence.Struct {
+	return cadence.NewStruct([]cadence.Value{
+		cadence.NewUInt64(id),
+		cadence.NewOptional(cadence.String("foo")),
+	}).WithType(itemStructType)
+}
+
+func TestDecodeWithType(t *testing.T) {
+
+	t.Parallel()
+
+	type decodeTest struct {
+		name         string
+		val          cadence.Value
+		expectedType cadence.Type
+	}
+
+	tests := []decodeTest{
+		{
+			"Void",
+			cadence.NewVoid(),
+			cadence.VoidType{},
+		},
+		{
+			"Int",
+			cadence.NewInt(42),
+			cadence.IntType{},
+		},
+		{
+			"UFix64",
+			cadence.UFix64(1_00000000),
+			cadence.UFix64Type{},
+		},
+		{
+			"Optional nil",
+			cadence.NewOptional(nil),
+			cadence.OptionalType{Type: cadence.IntType{}},
+		},
+		{
+			"Optional non-nil",
+			cadence.NewOptional(cadence.NewInt(42)),
+			cadence.OptionalType{Type: cadence.IntType{}},
+		},
+		{
+			"Non-optional for optional",
+			cadence.NewInt(42),
+			cadence.OptionalType{Type: cadence.IntType{}},
+		},
+		{
+			"Constant-sized array",
+			cadence.NewArray([]cadence.Value{
+				cadence.NewInt(1),
+				cadence.NewInt(2),
+			}),
+			cadence.ConstantSizedArrayType{
+				ElementType: cadence.IntType{},
+				Size:        2,
+			},
+		},
+		{
+			"Struct",
+			cadence.NewStruct([]cadence.Value{
+				cadence.NewArray([]cadence.Value{
+					newTestItem(1),
+					newTestItem(2),
+				}),
+				cadence.NewDictionary([]cadence.KeyValuePair{
+					{
+						Key:   cadence.String("a"),
+						Value: cadence.NewBool(true),
+					},
+				}),
+			}).WithType(orderStructType),
+			orderStructType,
+		},
+		{
+			"AnyStruct",
+			newTestItem(1),
+			cadence.AnyStructType{},
+		},
+		{
+			"Storage path",
+			cadence.Path{Domain: "storage", Identifier: "foo"},
+			cadence.StoragePathType{},
+		},
+		{
+			"Capability path",
+			cadence.Path{Domain: "private", Identifier: "foo"},
+			cadence.CapabilityPathType{},
+		},
+		{
+			"Type",
+			cadence.NewTypeValue(cadence.OptionalType{
+				Type: cadence.ReferenceType{
+					Authorized: true,
+					Type:       itemStructType,
+				},
+			}),
+			cadence.MetaType{},
+		},
+		{
+			"Capability",
+			cadence.Capability{
+				Path:       cadence.Path{Domain: "public", Identifier: "foo"},
+				Address:    cadence.BytesToAddress([]byte{1, 2, 3, 4, 5}),
+				BorrowType: cadence.IntType{},
+			},
+			cadence.CapabilityType{
+				BorrowType: cadence.IntType{},
+			},
+		},
+		{
+			"Capability with ID",
+			cadence.Capability{
+				ID:         42,
+				Address:    cadence.BytesToAddress([]byte{1, 2, 3, 4, 5}),
+				BorrowType: cadence.IntType{},
+			},
+			cadence.CapabilityType{
+				BorrowType: cadence.IntType{},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			encoded, err := json.Encode(test.val)
+			require.NoError(t, err)
+
+			// Decoding a valid value against its type
+			// must produce the same result as decoding it without a type
+
+			expected, err := json.Decode(encoded)
+			require.NoError(t, err)
+
+			actual, err := json.DecodeWithType(encoded, test.expectedType, json.DefaultLimits)
+			require.NoError(t, err)
+
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestDecodeWithTypeInvalid(t *testing.T) {
+
+	t.Parallel()
+
+	type decodeTest struct {
+		name          string
+		encoded       string
+		expectedType  cadence.Type
+		expectedError string
+	}
+
+	tests := []decodeTest{
+		{
+			"Mismatched type",
+			`{"type":"Bool","value":true}`,
+			cadence.IntType{},
+			"value: invalid JSON Cadence structure: expected Int, got Bool",
+		},
+		{
+			"Extra key",
+			`{"type":"Int","value":"1","extra":true}`,
+			cadence.IntType{},
+			"value: invalid JSON Cadence structure: unexpected keys in value",
+		},
+		{
+			"Invalid number",
+			`{"type":"Int8","value":"128"}`,
+			cadence.Int8Type{},
+			"value: invalid JSON Cadence structure",
+		},
+		{
+			"Constant-sized array with wrong size",
+			`{"type":"Array","value":[{"type":"Int","value":"1"}]}`,
+			cadence.ConstantSizedArrayType{
+				ElementType: cadence.IntType{},
+				Size:        2,
+			},
+			"value: invalid JSON Cadence structure: expected 2 elements, got 1",
+		},
+		{
+			"Mismatched array element",
+			`{"type":"Array","value":[{"type":"Int","value":"1"},{"type":"String","value":"2"}]}`,
+			cadence.VariableSizedArrayType{
+				ElementType: cadence.IntType{},
+			},
+			"value[1]: invalid JSON Cadence structure: expected Int, got String",
+		},
+		{
+			"Mismatched dictionary key",
+			`{"type":"Dictionary","value":[{"key":{"type":"Int","value":"1"},"value":{"type":"Int","value":"1"}}]}`,
+			cadence.DictionaryType{
+				KeyType:     cadence.StringType{},
+				ElementType: cadence.IntType{},
+			},
+			"value.keys[0]: invalid JSON Cadence structure: expected String, got Int",
+		},
+		{
+			"Mismatched dictionary value",
+			`{"type":"Dictionary","value":[{"key":{"type":"String","value":"a"},"value":{"type":"Bool","value":true}}]}`,
+			cadence.DictionaryType{
+				KeyType:     cadence.StringType{},
+				ElementType: cadence.IntType{},
+			},
+			`value["a"]: invalid JSON Cadence structure: expected Int, got Bool`,
+		},
+		{
+			"Mismatched composite type",
+			`{"type":"Struct","value":{"id":"S.test.Order","fields":[]}}`,
+			itemStructType,
+			"value: invalid JSON Cadence structure: expected type S.test.Item, got S.test.Order",
+		},
+		{
+			"Mismatched composite kind",
+			`{"type":"Resource","value":{"id":"S.test.Item","fields":[]}}`,
+			itemStructType,
+			"value: invalid JSON Cadence structure: expected Struct, got Resource",
+		},
+		{
+			"Missing field",
+			`{"type":"Struct","value":{"id":"S.test.Item","fields":[
+			  {"name":"id","value":{"type":"UInt64","value":"1"}}
+			]}}`,
+			itemStructType,
+			"value: invalid JSON Cadence structure: missing field `name`",
+		},
+		{
+			"Extra field",
+			`{"type":"Struct","value":{"id":"S.test.Item","fields":[
+			  {"name":"id","value":{"type":"UInt64","value":"1"}},
+			  {"name":"name","value":{"type":"Optional","value":null}},
+			  {"name":"extra","value":{"type":"Bool","value":true}}
+			]}}`,
+			itemStructType,
+			"value: invalid JSON Cadence structure: unexpected field `extra`",
+		},
+		{
+			"Duplicate field",
+			`{"type":"Struct","value":{"id":"S.test.Item","fields":[
+			  {"name":"id","value":{"type":"UInt64","value":"1"}},
+			  {"name":"id","value":{"type":"UInt64","value":"2"}}
+			]}}`,
+			itemStructType,
+			"value: invalid JSON Cadence structure: duplicate field `id`",
+		},
+		{
+			"Nested mismatched field",
+			`{"type":"Struct","value":{"id":"S.test.Order","fields":[
+			  {"name":"items","value":{"type":"Array","value":[
+			    {"type":"Struct","value":{"id":"S.test.Item","fields":[
+			      {"name":"id","value":{"type":"UInt64","value":"1"}},
+			      {"name":"name","value":{"type":"Optional","value":null}}
+			    ]}},
+			    {"type":"Struct","value":{"id":"S.test.Item","fields":[
+			      {"name":"id","value":{"type":"String","value":"2"}},
+			      {"name":"name","value":{"type":"Optional","value":null}}
+			    ]}}
+			  ]}},
+			  {"name":"metadata","value":{"type":"Dictionary","value":[]}}
+			]}}`,
+			orderStructType,
+			"value.items[1].id: invalid JSON Cadence structure: expected UInt64, got String",
+		},
+		{
+			"Mismatched path domain",
+			`{"type":"Path","value":{"domain":"public","identifier":"foo"}}`,
+			cadence.StoragePathType{},
+			"value: invalid JSON Cadence structure: unexpected path domain `public`",
+		},
+		{
+			"Mismatched capability borrow type",
+			`{"type":"Capability","value":{"id":"42","address":"0x0000000102030405","borrowType":{"kind":"Int"}}}`,
+			cadence.CapabilityType{
+				BorrowType: cadence.StringType{},
+			},
+			"value: invalid JSON Cadence structure: expected borrow type String, got Int",
+		},
+		{
+			"Capability with ID and path",
+			`{"type":"Capability","value":{
+			  "id":"42",
+			  "path":{"type":"Path","value":{"domain":"public","identifier":"foo"}},
+			  "address":"0x0000000102030405",
+			  "borrowType":{"kind":"Int"}
+			}}`,
+			cadence.CapabilityType{
+				BorrowType: cadence.IntType{},
+			},
+			"value: invalid JSON Cadence structure: unexpected keys in capability",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			_, err := json.DecodeWithType([]byte(test.encoded), test.expectedType, json.DefaultLimits)
+			require.Error(t, err)
+
+			var decodingErr *json.TypedDecodingError
+			require.ErrorAs(t, err, &decodingErr)
+			require.ErrorIs(t, err, json.ErrInvalidJSONCadence)
+
+			assert.Contains(t, err.Error(), test.expectedError)
+		})
+	}
+}
+
+func TestDecodeWithTypeLimits(t *testing.T) {
+
+	t.Parallel()
+
+	limits := json.Limits{
+		MaxBytes:        1000,
+		MaxDepth:        3,
+		MaxElements:     2,
+		MaxStringLength: 5,
+	}
+
+	test := func(t *testing.T, value cadence.Value, expectedType cadence.Type, expectedError string) {
+		encoded, err := json.Encode(value)
+		require.NoError(t, err)
+
+		_, err = json.DecodeWithType(encoded, expectedType, limits)
+		require.Error(t, err)
+
+		require.ErrorIs(t, err, json.ErrLimitExceeded)
+
+		var decodingErr *json.TypedDecodingError
+		require.ErrorAs(t, err, &decodingErr)
+
+		assert.Equal(t, expectedError, decodingErr.ErrorWithRoot("args[1]"))
+	}
+
+	t.Run("depth", func(t *testing.T) {
+
+		t.Parallel()
+
+		var value cadence.Value = cadence.NewInt(1)
+		for i := 0; i < 4; i++ {
+			value = cadence.NewArray([]cadence.Value{value})
+		}
+
+		test(
+			t,
+			value,
+			cadence.AnyStructType{},
+			"args[1][0][0][0][0]: decoding limit exceeded: maximum depth of 3",
+		)
+	})
+
+	t.Run("elements", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(
+			t,
+			cadence.NewArray([]cadence.Value{
+				cadence.NewArray([]cadence.Value{
+					cadence.NewInt(1),
+					cadence.NewInt(2),
+					cadence.NewInt(3),
+				}),
+			}),
+			cadence.VariableSizedArrayType{
+				ElementType: cadence.VariableSizedArrayType{
+					ElementType: cadence.IntType{},
+				},
+			},
+			"args[1][0]: decoding limit exceeded: maximum number of 2 elements",
+		)
+	})
+
+	t.Run("string length", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(
+			t,
+			cadence.NewArray([]cadence.Value{
+				cadence.String(strings.Repeat("a", 6)),
+			}),
+			cadence.VariableSizedArrayType{
+				ElementType: cadence.StringType{},
+			},
+			"args[1][0]: decoding limit exceeded: maximum string length of 5",
+		)
+	})
+
+	t.Run("number length", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(
+			t,
+			cadence.NewInt(1_000_000),
+			cadence.IntType{},
+			"args[1]: decoding limit exceeded: maximum string length of 5",
+		)
+	})
+
+	t.Run("size", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(
+			t,
+			cadence.String(strings.Repeat("a", 1000)),
+			cadence.StringType{},
+			"args[1]: decoding limit exceeded: maximum size of 1000 bytes",
+		)
+	})
+
+	t.Run("type depth", func(t *testing.T) {
+
+		t.Parallel()
+
+		var staticType cadence.Type = cadence.IntType{}
+		for i := 0; i < 3; i++ {
+			staticType = cadence.OptionalType{Type: staticType}
+		}
+
+		test(
+			t,
+			cadence.NewArray([]cadence.Value{
+				cadence.NewTypeValue(staticType),
+			}),
+			cadence.AnyStructType{},
+			"args[1][0]: decoding limit exceeded: maximum depth of 3",
+		)
+	})
+
+	t.Run("type elements", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(
+			t,
+			cadence.NewTypeValue(cadence.RestrictedType{
+				Type: cadence.AnyStructType{},
+				Restrictions: []cadence.Type{
+					cadence.IntType{},
+					cadence.StringType{},
+					cadence.BoolType{},
+				},
+			}),
+			cadence.MetaType{},
+			"args[1]: decoding limit exceeded: maximum number of 2 elements",
+		)
+	})
+
+	t.Run("within limits", func(t *testing.T) {
+
+		t.Parallel()
+
+		value := cadence.NewArray([]cadence.Value{
+			cadence.NewArray([]cadence.Value{
+				cadence.String("abcde"),
+				cadence.String("fghij"),
+			}),
+		})
+
+		encoded, err := json.Encode(value)
+		require.NoError(t, err)
+
+		actual, err := json.DecodeWithType(encoded, cadence.AnyStructType{}, limits)
+		require.NoError(t, err)
+
+		assert.Equal(t, value, actual)
+	})
+}
@@ -200,9 +200,10 @@ type jsonTypeValue struct {
 }
 
 type jsonCapabilityValue struct {
-	Path       jsonValue `json:"path"`
+	Path       jsonValue `json:"path,omitempty"`
 	Address    string    `json:"address"`
 	BorrowType jsonValue `json:"borrowType"`
+	ID         string    `json:"id,omitempty"`
 }
 
 const (
@@ -846,12 +847,23 @@ func prepareTypeValue(typeValue cadence.TypeValue) jsonValue {
 }
 
 func prepareCapability(capability cadence.Capability) jsonValue {
+	// Capabilities issued through a capability controller have an ID, but no path
+
+	var id string
+	var path jsonValue
+	if capability.ID != 0 {
+		id = strconv.FormatUint(capability.ID, 10)
+	} else {
+		path = preparePath(capability.Path)
+	}
+
 	return jsonValueObject{
 		Type: capabilityTypeStr,
 		Value: jsonCapabilityValue{
-			Path:       preparePath(capability.Path),
+			Path:       path,
 			Address:    encodeBytes(capability.Address.Bytes()),
 			BorrowType: prepareType(capability.BorrowType),
+			ID:         id,
 		},
 	}
 }
@@ -1569,6 +1569,21 @@ func TestEncodeCapability(t *testing.T) {
 	)
 }
 
+func TestEncodeControllerCapability(t *testing.T) {
+
+	t.Parallel()
+
+	testEncodeAndDecode(
+		t,
+		cadence.Capability{
+			Address:    cadence.BytesToAddress([]byte{1, 2, 3, 4, 5}),
+			BorrowType: cadence.IntType{},
+			ID:         42,
+		},
+		`{"type":"Capability","value":{"address":"0x0000000102030405","borrowType":{"kind":"Int"},"id":"42"}}`,
+	)
+}
+
 func TestDecodeFixedPoints(t *testing.T) {
 
 	t.Parallel()
@@ -0,0 +1,1078 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cadence
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/onflow/cadence/fixedpoint"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// marshalTagName is the struct tag which gives the names of composite fields
+//
+const marshalTagName = "cadence"
+
+// MarshalError is returned by Marshal and Unmarshal
+// when the shapes of a Go value and a Cadence value or type do not match.
+//
+type MarshalError struct {
+	// Path is the path of the mismatching value, relative to the marshalled value,
+	// for example `.items[3].id`
+	Path    string
+	Message string
+}
+
+func (e *MarshalError) Error() string {
+	return fmt.Sprintf("value%s: %s", e.Path, e.Message)
+}
+
+func newMarshalError(path string, format string, args ...interface{}) *MarshalError {
+	return &MarshalError{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+var valueInterfaceType = reflect.TypeOf((*Value)(nil)).Elem()
+var bigIntType = reflect.TypeOf(big.Int{})
+var bigRatType = reflect.TypeOf(big.Rat{})
+var byteType = reflect.TypeOf(byte(0))
+
+var fix64FactorBig = big.NewInt(fixedpoint.Fix64Factor)
+
+// goStructField is a field of a Go struct, mapped to a composite field
+//
+type goStructField struct {
+	index int
+	name  string
+	// tagged is true if the composite field name is given explicitly by a struct tag
+	tagged bool
+}
+
+func goStructFields(structType reflect.Type) []goStructField {
+	fields := make([]goStructField, 0, structType.NumField())
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		// ignore unexported fields
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag, tagged := field.Tag.Lookup(marshalTagName)
+		if tag == "-" {
+			continue
+		}
+
+		name := tag
+		if name == "" {
+			tagged = false
+
+			r, size := utf8.DecodeRuneInString(field.Name)
+			name = string(unicode.ToLower(r)) + field.Name[size:]
+		}
+
+		fields = append(fields, goStructField{
+			index:  i,
+			name:   name,
+			tagged: tagged,
+		})
+	}
+
+	return fields
+}
+
+// Unmarshal
+
+// Unmarshal stores the given Cadence value in the Go value pointed to by target.
+//
+// Composite values are unmarshalled into Go structs.
+// The fields of a Go struct are mapped to the fields of a composite by name:
+// The name of the composite field is given by the `cadence` struct tag, e.g. `cadence:"fieldName"`,
+// or, if the Go field has no tag, is the name of the Go field with a lower-case first letter.
+// Go fields with the tag `cadence:"-"` and unexported Go fields are ignored.
+// A composite value may have fields which have no corresponding Go struct field,
+// but each Go struct field must have a corresponding composite field.
+//
+// Optionals are unmarshalled into pointers, arrays into slices and Go arrays,
+// and dictionaries into maps.
+// Fixed-point values are unmarshalled into decimal strings (e.g. "1.50000000") and big.Rat,
+// integers into Go integers and big.Int, and addresses into [8]byte, byte slices, and hex strings.
+//
+// Any Cadence value can also be unmarshalled into a Go value of the same Cadence value type,
+// e.g. UFix64, or of an interface type it implements, e.g. Value.
+//
+func Unmarshal(value Value, target interface{}) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr || targetValue.IsNil() {
+		return newMarshalError("", "cannot unmarshal into non-pointer Go value of type %T", target)
+	}
+
+	return unmarshal(value, targetValue.Elem(), "")
+}
+
+func unmarshal(value Value, target reflect.Value, path string) error {
+	targetType := target.Type()
+
+	// Cadence values can be unmarshalled into Go values of their own type,
+	// or of an interface type they implement
+
+	if value != nil && reflect.TypeOf(value).AssignableTo(targetType) {
+		target.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	optional, isOptional := value.(Optional)
+
+	// Optionals are unmarshalled into pointers
+
+	if targetType.Kind() == reflect.Ptr {
+		if isOptional {
+			if optional.Value == nil {
+				target.Set(reflect.Zero(targetType))
+				return nil
+			}
+
+			value = optional.Value
+		}
+
+		elem := reflect.New(targetType.Elem())
+
+		err := unmarshal(value, elem.Elem(), path)
+		if err != nil {
+			return err
+		}
+
+		target.Set(elem)
+		return nil
+	}
+
+	if isOptional {
+		if optional.Value != nil {
+			return unmarshal(optional.Value, target, path)
+		}
+
+		// nil can only be unmarshalled into Go values which can be nil
+
+		switch targetType.Kind() {
+		case reflect.Interface, reflect.Slice, reflect.Map:
+			target.Set(reflect.Zero(targetType))
+			return nil
+		}
+
+		return newMarshalError(path, "cannot unmarshal nil into Go value of type %s", targetType)
+	}
+
+	if value == nil {
+		return newMarshalError(path, "cannot unmarshal missing value into Go value of type %s", targetType)
+	}
+
+	if integer, ok := integerValueBig(value); ok {
+		return unmarshalInteger(integer, value, target, path)
+	}
+
+	switch value := value.(type) {
+	case Bool:
+		if targetType.Kind() == reflect.Bool {
+			target.SetBool(bool(value))
+			return nil
+		}
+
+	case String:
+		if targetType.Kind() == reflect.String {
+			target.SetString(string(value))
+			return nil
+		}
+
+	case Character:
+		if targetType.Kind() == reflect.String {
+			target.SetString(string(value))
+			return nil
+		}
+
+	case Address:
+		return unmarshalAddress(value, target, path)
+
+	case Fix64:
+		rat := new(big.Rat).SetFrac(big.NewInt(int64(value)), fix64FactorBig)
+		return unmarshalFixedPoint(rat, value, target, path)
+
+	case UFix64:
+		rat := new(big.Rat).SetFrac(new(big.Int).SetUint64(uint64(value)), fix64FactorBig)
+		return unmarshalFixedPoint(rat, value, target, path)
+
+	case Path:
+		if targetType.Kind() == reflect.String {
+			target.SetString(value.String())
+			return nil
+		}
+
+	case Array:
+		return unmarshalArray(value, target, path)
+
+	case Dictionary:
+		return unmarshalDictionary(value, target, path)
+
+	case Struct:
+		return unmarshalComposite(value.StructType, value.Fields, target, path)
+
+	case Resource:
+		return unmarshalComposite(value.ResourceType, value.Fields, target, path)
+
+	case Event:
+		return unmarshalComposite(value.EventType, value.Fields, target, path)
+
+	case Contract:
+		return unmarshalComposite(value.ContractType, value.Fields, target, path)
+
+	case Enum:
+		// enums can also be unmarshalled into integers, using the raw value
+		if isGoIntegerKind(targetType.Kind()) && value.EnumType != nil {
+			for i, field := range value.EnumType.Fields {
+				if field.Identifier == sema.EnumRawValueFieldName && i < len(value.Fields) {
+					return unmarshal(value.Fields[i], target, path)
+				}
+			}
+		}
+
+		return unmarshalComposite(value.EnumType, value.Fields, target, path)
+	}
+
+	return unmarshalTypeMismatchError(value, targetType, path)
+}
+
+func unmarshalTypeMismatchError(value Value, targetType reflect.Type, path string) error {
+	return newMarshalError(
+		path,
+		"cannot unmarshal %s into Go value of type %s",
+		reflect.TypeOf(value).Name(),
+		targetType,
+	)
+}
+
+func unmarshalInteger(integer *big.Int, value Value, target reflect.Value, path string) error {
+	targetType := target.Type()
+
+	switch targetType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !integer.IsInt64() || target.OverflowInt(integer.Int64()) {
+			return newMarshalError(
+				path,
+				"%s value %s overflows Go value of type %s",
+				reflect.TypeOf(value).Name(),
+				integer,
+				targetType,
+			)
+		}
+
+		target.SetInt(integer.Int64())
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if !integer.IsUint64() || target.OverflowUint(integer.Uint64()) {
+			return newMarshalError(
+				path,
+				"%s value %s overflows Go value of type %s",
+				reflect.TypeOf(value).Name(),
+				integer,
+				targetType,
+			)
+		}
+
+		target.SetUint(integer.Uint64())
+		return nil
+
+	case reflect.Struct:
+		if targetType == bigIntType {
+			target.Addr().Interface().(*big.Int).Set(integer)
+			return nil
+		}
+	}
+
+	return unmarshalTypeMismatchError(value, targetType, path)
+}
+
+func unmarshalFixedPoint(rat *big.Rat, value Value, target reflect.Value, path string) error {
+	targetType := target.Type()
+
+	switch targetType.Kind() {
+	case reflect.String:
+		target.SetString(value.String())
+		return nil
+
+	case reflect.Struct:
+		if targetType == bigRatType {
+			target.Addr().Interface().(*big.Rat).Set(rat)
+			return nil
+		}
+	}
+
+	return unmarshalTypeMismatchError(value, targetType, path)
+}
+
+func unmarshalAddress(address Address, target reflect.Value, path string) error {
+	targetType := target.Type()
+
+	switch targetType.Kind() {
+	case reflect.String:
+		target.SetString(address.String())
+		return nil
+
+	case reflect.Array:
+		if targetType.Elem() == byteType && targetType.Len() == AddressLength {
+			reflect.Copy(target, reflect.ValueOf(address[:]))
+			return nil
+		}
+
+	case reflect.Slice:
+		if targetType.Elem() == byteType {
+			bytes := make([]byte, AddressLength)
+			copy(bytes, address[:])
+			target.SetBytes(bytes)
+			return nil
+		}
+	}
+
+	return unmarshalTypeMismatchError(address, targetType, path)
+}
+
+func unmarshalArray(array Array, target reflect.Value, path string) error {
+	targetType := target.Type()
+
+	switch targetType.Kind() {
+	case reflect.Slice:
+		target.Set(reflect.MakeSlice(targetType, len(array.Values), len(array.Values)))
+
+	case reflect.Array:
+		if targetType.Len() != len(array.Values) {
+			return newMarshalError(
+				path,
+				"cannot unmarshal array of %d elements into Go value of type %s",
+				len(array.Values),
+				targetType,
+			)
+		}
+
+	default:
+		return unmarshalTypeMismatchError(array, targetType, path)
+	}
+
+	for i, element := range array.Values {
+		elementPath := fmt.Sprintf("%s[%d]", path, i)
+
+		err := unmarshal(element, target.Index(i), elementPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func unmarshalDictionary(dictionary Dictionary, target reflect.Value, path string) error {
+	targetType := target.Type()
+
+	if targetType.Kind() != reflect.Map {
+		return unmarshalTypeMismatchError(dictionary, targetType, path)
+	}
+
+	result := reflect.MakeMapWithSize(targetType, len(dictionary.Pairs))
+
+	for _, pair := range dictionary.Pairs {
+		elementPath := fmt.Sprintf("%s[%s]", path, pair.Key)
+
+		key := reflect.New(targetType.Key()).Elem()
+		err := unmarshal(pair.Key, key, elementPath)
+		if err != nil {
+			return err
+		}
+
+		element := reflect.New(targetType.Elem()).Elem()
+		err = unmarshal(pair.Value, element, elementPath)
+		if err != nil {
+			return err
+		}
+
+		result.SetMapIndex(key, element)
+	}
+
+	target.Set(result)
+
+	return nil
+}
+
+func unmarshalComposite(compositeType CompositeType, fieldValues []Value, target reflect.Value, path string) error {
+	targetType := target.Type()
+
+	if reflect.ValueOf(compositeType).IsNil() {
+		return newMarshalError(path, "cannot unmarshal composite value without type")
+	}
+
+	if targetType.Kind() != reflect.Struct {
+		return newMarshalError(
+			path,
+			"cannot unmarshal value of type %s into Go value of type %s",
+			compositeType.ID(),
+			targetType,
+		)
+	}
+
+	fields := compositeType.CompositeFields()
+	if len(fields) != len(fieldValues) {
+		return newMarshalError(
+			path,
+			"composite value has %d fields, but type %s has %d fields",
+			len(fieldValues),
+			compositeType.ID(),
+			len(fields),
+		)
+	}
+
+	fieldValuesByName := make(map[string]Value, len(fields))
+	for i, field := range fields {
+		fieldValuesByName[field.Identifier] = fieldValues[i]
+	}
+
+	for _, goField := range goStructFields(targetType) {
+		fieldValue, ok := fieldValuesByName[goField.name]
+		if !ok {
+			return newMarshalError(
+				path,
+				"type %s has no field `%s` for Go field %s.%s",
+				compositeType.ID(),
+				goField.name,
+				targetType,
+				targetType.Field(goField.index).Name,
+			)
+		}
+
+		fieldPath := fmt.Sprintf("%s.%s", path, goField.name)
+
+		err := unmarshal(fieldValue, target.Field(goField.index), fieldPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// integerValueBig returns the value of the given integer value as a big.Int.
+// It returns false if the value is not an integer value
+//
+func integerValueBig(value Value) (*big.Int, bool) {
+	switch value := value.(type) {
+	case Int:
+		return value.Big(), true
+	case Int8:
+		return big.NewInt(int64(value)), true
+	case Int16:
+		return big.NewInt(int64(value)), true
+	case Int32:
+		return big.NewInt(int64(value)), true
+	case Int64:
+		return big.NewInt(int64(value)), true
+	case Int128:
+		return value.Big(), true
+	case Int256:
+		return value.Big(), true
+	case UInt:
+		return value.Big(), true
+	case UInt8:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case UInt16:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case UInt32:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case UInt64:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case UInt128:
+		return value.Big(), true
+	case UInt256:
+		return value.Big(), true
+	case Word8:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case Word16:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case Word32:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case Word64:
+		return new(big.Int).SetUint64(uint64(value)), true
+	}
+
+	return nil, false
+}
+
+func isGoIntegerKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+
+	return false
+}
+
+// Marshal
+
+// Marshal returns the Cadence value of the given type for the given Go value.
+//
+// The Go value must have the shape of the type, as described for Unmarshal.
+// Each composite field must have a corresponding Go struct field,
+// and each Go struct field with a `cadence` tag must have a corresponding composite field.
+// In addition, paths can be marshalled from strings (e.g. "/storage/foo"),
+// and enums from integers, which are used as the raw value.
+// Dictionary entries are sorted by key, so the result is deterministic.
+//
+// Cadence values are returned as-is. Go values marshalled into abstract types,
+// e.g. AnyStruct, must be Cadence values, or basic Go values supported by NewValue.
+//
+func Marshal(value interface{}, t Type) (Value, error) {
+	if t == nil {
+		return nil, newMarshalError("", "cannot marshal without type")
+	}
+
+	return marshal(reflect.ValueOf(value), t, "")
+}
+
+func marshal(value reflect.Value, t Type, path string) (Value, error) {
+
+	if value.IsValid() && value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+
+	// Cadence values are marshalled as-is
+
+	if value.IsValid() &&
+		value.Kind() != reflect.Ptr &&
+		value.Type().Implements(valueInterfaceType) {
+
+		return value.Interface().(Value), nil
+	}
+
+	// Optionals are marshalled from pointers
+
+	if optionalType, ok := t.(OptionalType); ok {
+		if !value.IsValid() ||
+			value.Kind() == reflect.Ptr && value.IsNil() {
+
+			return NewOptional(nil), nil
+		}
+
+		if value.Kind() == reflect.Ptr {
+			value = value.Elem()
+		}
+
+		innerValue, err := marshal(value, optionalType.Type, path)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewOptional(innerValue), nil
+	}
+
+	if !value.IsValid() ||
+		value.Kind() == reflect.Ptr && value.IsNil() {
+
+		return nil, newMarshalError(path, "cannot marshal nil into non-optional type %s", t.ID())
+	}
+
+	if value.Kind() == reflect.Ptr {
+		return marshal(value.Elem(), t, path)
+	}
+
+	valueType := value.Type()
+
+	switch t := t.(type) {
+	case BoolType:
+		if value.Kind() == reflect.Bool {
+			return NewBool(value.Bool()), nil
+		}
+
+	case StringType:
+		if value.Kind() == reflect.String {
+			result, err := NewString(value.String())
+			if err != nil {
+				return nil, newMarshalError(path, "%s", err)
+			}
+			return result, nil
+		}
+
+	case CharacterType:
+		if value.Kind() == reflect.String {
+			result, err := NewCharacter(value.String())
+			if err != nil {
+				return nil, newMarshalError(path, "%s", err)
+			}
+			return result, nil
+		}
+
+	case AddressType:
+		return marshalAddress(value, path)
+
+	case IntType,
+		Int8Type, Int16Type, Int32Type, Int64Type, Int128Type, Int256Type,
+		UIntType,
+		UInt8Type, UInt16Type, UInt32Type, UInt64Type, UInt128Type, UInt256Type,
+		Word8Type, Word16Type, Word32Type, Word64Type:
+
+		integer, ok := goIntegerBig(value)
+		if !ok {
+			break
+		}
+
+		result, ok := newIntegerValue(t, integer)
+		if !ok {
+			return nil, newMarshalError(path, "value %s is out of range of type %s", integer, t.ID())
+		}
+
+		return result, nil
+
+	case Fix64Type, UFix64Type:
+		return marshalFixedPoint(value, t, path)
+
+	case PathType, CapabilityPathType, StoragePathType, PublicPathType, PrivatePathType:
+		if value.Kind() == reflect.String {
+			return marshalPath(value.String(), t, path)
+		}
+
+	case VariableSizedArrayType:
+		return marshalArray(value, t, t.ElementType, path)
+
+	case ConstantSizedArrayType:
+		if (value.Kind() == reflect.Slice || value.Kind() == reflect.Array) &&
+			uint(value.Len()) != t.Size {
+
+			return nil, newMarshalError(
+				path,
+				"cannot marshal Go value with %d elements into type %s",
+				value.Len(),
+				t.ID(),
+			)
+		}
+
+		return marshalArray(value, t, t.ElementType, path)
+
+	case DictionaryType:
+		return marshalDictionary(value, t, path)
+
+	case *EnumType:
+		// enums can also be marshalled from integers, which are used as the raw value
+		if isGoIntegerKind(value.Kind()) {
+			rawValue, err := marshal(value, t.RawType, path)
+			if err != nil {
+				return nil, err
+			}
+
+			return NewEnum([]Value{rawValue}).WithType(t), nil
+		}
+
+		return marshalComposite(value, t, path)
+
+	case CompositeType:
+		return marshalComposite(value, t, path)
+
+	default:
+		// abstract types, e.g. AnyStruct
+		result, err := NewValue(value.Interface())
+		if err == nil {
+			return result, nil
+		}
+	}
+
+	return nil, newMarshalError(
+		path,
+		"cannot marshal Go value of type %s into type %s",
+		valueType,
+		t.ID(),
+	)
+}
+
+func marshalAddress(value reflect.Value, path string) (Value, error) {
+	valueType := value.Type()
+
+	switch value.Kind() {
+	case reflect.String:
+		address, err := common.HexToAddress(value.String())
+		if err != nil {
+			return nil, newMarshalError(path, "invalid address: %s", err)
+		}
+		return Address(address), nil
+
+	case reflect.Array, reflect.Slice:
+		if valueType.Elem() != byteType {
+			break
+		}
+
+		if value.Len() != AddressLength {
+			return nil, newMarshalError(
+				path,
+				"invalid address length: expected %d bytes, got %d",
+				AddressLength,
+				value.Len(),
+			)
+		}
+
+		var address Address
+		reflect.Copy(reflect.ValueOf(address[:]), value)
+		return address, nil
+	}
+
+	return nil, newMarshalError(
+		path,
+		"cannot marshal Go value of type %s into type %s",
+		valueType,
+		AddressType{}.ID(),
+	)
+}
+
+func marshalFixedPoint(value reflect.Value, t Type, path string) (Value, error) {
+	var fixedPoint *big.Int
+
+	switch {
+	case value.Kind() == reflect.String:
+		var err error
+
+		if _, ok := t.(UFix64Type); ok {
+			fixedPoint, err = fixedpoint.ParseUFix64(value.String())
+		} else {
+			fixedPoint, err = fixedpoint.ParseFix64(value.String())
+		}
+
+		if err != nil {
+			return nil, newMarshalError(path, "invalid %s value: %s", t.ID(), err)
+		}
+
+	case value.Type() == bigRatType:
+		goRat := value.Interface().(big.Rat)
+
+		rat := new(big.Rat).Set(&goRat)
+		rat.Mul(rat, new(big.Rat).SetInt(fix64FactorBig))
+
+		if !rat.IsInt() {
+			return nil, newMarshalError(
+				path,
+				"value %s cannot be represented exactly by type %s",
+				goRat.RatString(),
+				t.ID(),
+			)
+		}
+
+		fixedPoint = rat.Num()
+
+	default:
+		return nil, newMarshalError(
+			path,
+			"cannot marshal Go value of type %s into type %s",
+			value.Type(),
+			t.ID(),
+		)
+	}
+
+	if _, ok := t.(UFix64Type); ok {
+		if fixedPoint.Sign() < 0 || !fixedPoint.IsUint64() {
+			return nil, newMarshalError(path, "value is out of range of type %s", t.ID())
+		}
+		return UFix64(fixedPoint.Uint64()), nil
+	}
+
+	if !fixedPoint.IsInt64() {
+		return nil, newMarshalError(path, "value is out of range of type %s", t.ID())
+	}
+	return Fix64(fixedPoint.Int64()), nil
+}
+
+func marshalPath(value string, t Type, path string) (Value, error) {
+	parts := strings.SplitN(value, "/", 3)
+	if len(parts) != 3 || parts[0] != "" {
+		return nil, newMarshalError(path, "invalid path `%s`", value)
+	}
+
+	domain, ok := common.AllPathDomainsByIdentifier[parts[1]]
+	if !ok {
+		return nil, newMarshalError(path, "invalid path domain `%s`", parts[1])
+	}
+
+	var valid bool
+
+	switch t.(type) {
+	case StoragePathType:
+		valid = domain == common.PathDomainStorage
+	case PublicPathType:
+		valid = domain == common.PathDomainPublic
+	case PrivatePathType:
+		valid = domain == common.PathDomainPrivate
+	case CapabilityPathType:
+		valid = domain == common.PathDomainPublic || domain == common.PathDomainPrivate
+	default:
+		valid = true
+	}
+
+	if !valid {
+		return nil, newMarshalError(path, "path `%s` is not of type %s", value, t.ID())
+	}
+
+	return Path{
+		Domain:     domain.Identifier(),
+		Identifier: parts[2],
+	}, nil
+}
+
+func marshalArray(value reflect.Value, t ArrayType, elementType Type, path string) (Value, error) {
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, newMarshalError(
+			path,
+			"cannot marshal Go value of type %s into type %s",
+			value.Type(),
+			t.ID(),
+		)
+	}
+
+	values := make([]Value, value.Len())
+
+	for i := 0; i < value.Len(); i++ {
+		elementPath := fmt.Sprintf("%s[%d]", path, i)
+
+		element, err := marshal(value.Index(i), elementType, elementPath)
+		if err != nil {
+			return nil, err
+		}
+
+		values[i] = element
+	}
+
+	return NewArray(values).WithType(t), nil
+}
+
+func marshalDictionary(value reflect.Value, t DictionaryType, path string) (Value, error) {
+	if value.Kind() != reflect.Map {
+		return nil, newMarshalError(
+			path,
+			"cannot marshal Go value of type %s into type %s",
+			value.Type(),
+			t.ID(),
+		)
+	}
+
+	pairs := make([]KeyValuePair, 0, value.Len())
+
+	iter := value.MapRange()
+	for iter.Next() {
+		keyPath := fmt.Sprintf("%s[%v]", path, iter.Key())
+
+		key, err := marshal(iter.Key(), t.KeyType, keyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		element, err := marshal(iter.Value(), t.ElementType, keyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		pairs = append(pairs, KeyValuePair{
+			Key:   key,
+			Value: element,
+		})
+	}
+
+	// Go maps are unordered, sort the pairs to produce a deterministic result
+
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].Key.String() < pairs[j].Key.String()
+	})
+
+	return NewDictionary(pairs).WithType(t), nil
+}
+
+func marshalComposite(value reflect.Value, t CompositeType, path string) (Value, error) {
+	valueType := value.Type()
+
+	if reflect.ValueOf(t).IsNil() {
+		return nil, newMarshalError(path, "cannot marshal into composite without type")
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil, newMarshalError(
+			path,
+			"cannot marshal Go value of type %s into type %s",
+			valueType,
+			t.ID(),
+		)
+	}
+
+	goFields := goStructFields(valueType)
+
+	goFieldsByName := make(map[string]goStructField, len(goFields))
+	for _, goField := range goFields {
+		goFieldsByName[goField.name] = goField
+	}
+
+	fields := t.CompositeFields()
+	fieldValues := make([]Value, len(fields))
+
+	fieldNames := make(map[string]struct{}, len(fields))
+
+	for i, field := range fields {
+		fieldNames[field.Identifier] = struct{}{}
+
+		goField, ok := goFieldsByName[field.Identifier]
+		if !ok {
+			return nil, newMarshalError(
+				path,
+				"Go type %s has no field for field `%s` of type %s",
+				valueType,
+				field.Identifier,
+				t.ID(),
+			)
+		}
+
+		fieldPath := fmt.Sprintf("%s.%s", path, field.Identifier)
+
+		fieldValue, err := marshal(value.Field(goField.index), field.Type, fieldPath)
+		if err != nil {
+			return nil, err
+		}
+
+		fieldValues[i] = fieldValue
+	}
+
+	for _, goField := range goFields {
+		if _, ok := fieldNames[goField.name]; !ok && goField.tagged {
+			return nil, newMarshalError(
+				path,
+				"type %s has no field `%s` for Go field %s.%s",
+				t.ID(),
+				goField.name,
+				valueType,
+				valueType.Field(goField.index).Name,
+			)
+		}
+	}
+
+	switch t := t.(type) {
+	case *StructType:
+		return NewStruct(fieldValues).WithType(t), nil
+	case *ResourceType:
+		return NewResource(fieldValues).WithType(t), nil
+	case *EventType:
+		return NewEvent(fieldValues).WithType(t), nil
+	case *ContractType:
+		return NewContract(fieldValues).WithType(t), nil
+	case *EnumType:
+		return NewEnum(fieldValues).WithType(t), nil
+	}
+
+	return nil, newMarshalError(path, "cannot marshal into composite type %s", t.ID())
+}
+
+// goIntegerBig returns the value of the given Go integer or big.Int as a big.Int.
+// It returns false if the value is not an integer
+//
+func goIntegerBig(value reflect.Value) (*big.Int, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(value.Int()), true
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(value.Uint()), true
+
+	case reflect.Struct:
+		if value.Type() == bigIntType {
+			bigInt := value.Interface().(big.Int)
+			return new(big.Int).Set(&bigInt), true
+		}
+	}
+
+	return nil, false
+}
+
+// newIntegerValue returns the integer value of the given type for the given integer.
+// It returns false if the integer is out of range of the type
+//
+func newIntegerValue(t Type, integer *big.Int) (Value, bool) {
+
+	inInt64Range := func(min, max int64) bool {
+		return integer.IsInt64() &&
+			integer.Int64() >= min &&
+			integer.Int64() <= max
+	}
+
+	inUint64Range := func(max uint64) bool {
+		return integer.Sign() >= 0 &&
+			integer.IsUint64() &&
+			integer.Uint64() <= max
+	}
+
+	switch t.(type) {
+	case IntType:
+		return NewIntFromBig(integer), true
+	case Int8Type:
+		return NewInt8(int8(integer.Int64())), inInt64Range(math.MinInt8, math.MaxInt8)
+	case Int16Type:
+		return NewInt16(int16(integer.Int64())), inInt64Range(math.MinInt16, math.MaxInt16)
+	case Int32Type:
+		return NewInt32(int32(integer.Int64())), inInt64Range(math.MinInt32, math.MaxInt32)
+	case Int64Type:
+		return NewInt64(integer.Int64()), inInt64Range(math.MinInt64, math.MaxInt64)
+	case Int128Type:
+		result, err := NewInt128FromBig(integer)
+		return result, err == nil
+	case Int256Type:
+		result, err := NewInt256FromBig(integer)
+		return result, err == nil
+	case UIntType:
+		result, err := NewUIntFromBig(integer)
+		return result, err == nil
+	case UInt8Type:
+		return NewUInt8(uint8(integer.Uint64())), inUint64Range(math.MaxUint8)
+	case UInt16Type:
+		return NewUInt16(uint16(integer.Uint64())), inUint64Range(math.MaxUint16)
+	case UInt32Type:
+		return NewUInt32(uint32(integer.Uint64())), inUint64Range(math.MaxUint32)
+	case UInt64Type:
+		return NewUInt64(integer.Uint64()), inUint64Range(math.MaxUint64)
+	case UInt128Type:
+		result, err := NewUInt128FromBig(integer)
+		return result, err == nil
+	case UInt256Type:
+		result, err := NewUInt256FromBig(integer)
+		return result, err == nil
+	case Word8Type:
+		return NewWord8(uint8(integer.Uint64())), inUint64Range(math.MaxUint8)
+	case Word16Type:
+		return NewWord16(uint16(integer.Uint64())), inUint64Range(math.MaxUint16)
+	case Word32Type:
+		return NewWord32(uint32(integer.Uint64())), inUint64Range(math.MaxUint32)
+	case Word64Type:
+		return NewWord64(integer.Uint64()), inUint64Range(math.MaxUint64)
+	}
+
+	return nil, false
+}
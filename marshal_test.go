@@ -0,0 +1,506 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cadence
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+type testMarshalItem struct {
+	ID   uint64  `cadence:"id"`
+	Name *string `cadence:"name"`
+}
+
+type testMarshalOrder struct {
+	Items    []testMarshalItem
+	Tags     map[string]uint8
+	Owner    string `cadence:"owner"`
+	Price    string `cadence:"price"`
+	Discount string `cadence:"discount"`
+	Ignored  int    `cadence:"-"`
+}
+
+var testMarshalItemType = &StructType{
+	Location:            utils.TestLocation,
+	QualifiedIdentifier: "Item",
+	Fields: []Field{
+		{
+			Identifier: "id",
+			Type:       UInt64Type{},
+		},
+		{
+			Identifier: "name",
+			Type:       OptionalType{Type: StringType{}},
+		},
+	},
+}
+
+var testMarshalOrderType = &StructType{
+	Location:            utils.TestLocation,
+	QualifiedIdentifier: "Order",
+	Fields: []Field{
+		{
+			Identifier: "items",
+			Type: VariableSizedArrayType{
+				ElementType: testMarshalItemType,
+			},
+		},
+		{
+			Identifier: "tags",
+			Type: DictionaryType{
+				KeyType:     StringType{},
+				ElementType: UInt8Type{},
+			},
+		},
+		{
+			Identifier: "owner",
+			Type:       AddressType{},
+		},
+		{
+			Identifier: "price",
+			Type:       UFix64Type{},
+		},
+		{
+			Identifier: "discount",
+			Type:       Fix64Type{},
+		},
+	},
+}
+
+func TestMarshalAndUnmarshal(t *testing.T) {
+
+	t.Parallel()
+
+	name := "foo"
+
+	order := testMarshalOrder{
+		Items: []testMarshalItem{
+			{ID: 1, Name: &name},
+			{ID: 2, Name: nil},
+		},
+		Tags: map[string]uint8{
+			"b": 2,
+			"a": 1,
+		},
+		Owner:    "0x0000000000000001",
+		Price:    "1.50000000",
+		Discount: "-0.25000000",
+	}
+
+	arrayType := testMarshalOrderType.Fields[0].Type.(VariableSizedArrayType)
+	dictionaryType := testMarshalOrderType.Fields[1].Type.(DictionaryType)
+
+	expected := NewStruct([]Value{
+		NewArray([]Value{
+			NewStruct([]Value{
+				NewUInt64(1),
+				NewOptional(String("foo")),
+			}).WithType(testMarshalItemType),
+			NewStruct([]Value{
+				NewUInt64(2),
+				NewOptional(nil),
+			}).WithType(testMarshalItemType),
+		}).WithType(arrayType),
+		NewDictionary([]KeyValuePair{
+			{
+				Key:   String("a"),
+				Value: NewUInt8(1),
+			},
+			{
+				Key:   String("b"),
+				Value: NewUInt8(2),
+			},
+		}).WithType(dictionaryType),
+		BytesToAddress([]byte{1}),
+		UFix64(1_50000000),
+		Fix64(-25000000),
+	}).WithType(testMarshalOrderType)
+
+	value, err := Marshal(order, testMarshalOrderType)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, value)
+
+	var unmarshalled testMarshalOrder
+	err = Unmarshal(value, &unmarshalled)
+	require.NoError(t, err)
+
+	assert.Equal(t, order, unmarshalled)
+}
+
+func TestUnmarshal(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("optional into pointer", func(t *testing.T) {
+
+		t.Parallel()
+
+		var result *int
+		err := Unmarshal(NewOptional(NewInt(42)), &result)
+		require.NoError(t, err)
+
+		require.NotNil(t, result)
+		assert.Equal(t, 42, *result)
+
+		err = Unmarshal(NewOptional(nil), &result)
+		require.NoError(t, err)
+
+		assert.Nil(t, result)
+	})
+
+	t.Run("nil into non-pointer", func(t *testing.T) {
+
+		t.Parallel()
+
+		var result int
+		err := Unmarshal(NewOptional(nil), &result)
+		require.EqualError(t, err, "value: cannot unmarshal nil into Go value of type int")
+	})
+
+	t.Run("Cadence value", func(t *testing.T) {
+
+		t.Parallel()
+
+		var result struct {
+			Price UFix64
+			Value Value
+		}
+
+		err := Unmarshal(
+			NewStruct([]Value{
+				UFix64(1_00000000),
+				NewInt(1),
+			}).WithType(&StructType{
+				Location:            utils.TestLocation,
+				QualifiedIdentifier: "Foo",
+				Fields: []Field{
+					{Identifier: "price", Type: UFix64Type{}},
+					{Identifier: "value", Type: IntType{}},
+				},
+			}),
+			&result,
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, UFix64(1_00000000), result.Price)
+		assert.Equal(t, NewInt(1), result.Value)
+	})
+
+	t.Run("fixed-point into big.Rat", func(t *testing.T) {
+
+		t.Parallel()
+
+		var result *big.Rat
+		err := Unmarshal(UFix64(1_50000000), &result)
+		require.NoError(t, err)
+
+		assert.Equal(t, "3/2", result.RatString())
+	})
+
+	t.Run("integer into big.Int", func(t *testing.T) {
+
+		t.Parallel()
+
+		expected, ok := new(big.Int).SetString("100000000000000000000000000000", 10)
+		require.True(t, ok)
+
+		var result big.Int
+		err := Unmarshal(NewIntFromBig(expected), &result)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, expected.Cmp(&result))
+	})
+
+	t.Run("address into bytes", func(t *testing.T) {
+
+		t.Parallel()
+
+		var result [8]byte
+		err := Unmarshal(BytesToAddress([]byte{1, 2}), &result)
+		require.NoError(t, err)
+
+		assert.Equal(t, [8]byte{0, 0, 0, 0, 0, 0, 1, 2}, result)
+	})
+
+	t.Run("enum into integer", func(t *testing.T) {
+
+		t.Parallel()
+
+		var result uint8
+		err := Unmarshal(
+			NewEnum([]Value{NewUInt8(2)}).WithType(&EnumType{
+				Location:            utils.TestLocation,
+				QualifiedIdentifier: "Direction",
+				RawType:             UInt8Type{},
+				Fields: []Field{
+					{Identifier: "rawValue", Type: UInt8Type{}},
+				},
+			}),
+			&result,
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint8(2), result)
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+
+		t.Parallel()
+
+		var result int8
+		err := Unmarshal(NewInt(128), &result)
+		require.EqualError(t, err, "value: Int value 128 overflows Go value of type int8")
+	})
+
+	t.Run("non-pointer", func(t *testing.T) {
+
+		t.Parallel()
+
+		var result int
+		err := Unmarshal(NewInt(1), result)
+		require.EqualError(t, err, "value: cannot unmarshal into non-pointer Go value of type int")
+	})
+
+	t.Run("mismatched field", func(t *testing.T) {
+
+		t.Parallel()
+
+		var result struct {
+			Items []struct {
+				ID string `cadence:"id"`
+			} `cadence:"items"`
+		}
+
+		value := NewStruct([]Value{
+			NewArray([]Value{
+				NewStruct([]Value{
+					NewUInt64(1),
+					NewOptional(nil),
+				}).WithType(testMarshalItemType),
+			}),
+			NewDictionary(nil),
+			BytesToAddress([]byte{1}),
+			UFix64(0),
+			Fix64(0),
+		}).WithType(testMarshalOrderType)
+
+		err := Unmarshal(value, &result)
+
+		var marshalErr *MarshalError
+		require.ErrorAs(t, err, &marshalErr)
+
+		assert.Equal(t, ".items[0].id", marshalErr.Path)
+		assert.EqualError(t, err, "value.items[0].id: cannot unmarshal UInt64 into Go value of type string")
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+
+		t.Parallel()
+
+		type quantity struct {
+			Quantity int `cadence:"quantity"`
+		}
+
+		var result quantity
+
+		err := Unmarshal(
+			NewStruct([]Value{
+				NewUInt64(1),
+				NewOptional(nil),
+			}).WithType(testMarshalItemType),
+			&result,
+		)
+		require.EqualError(
+			t,
+			err,
+			"value: type S.test.Item has no field `quantity` for Go field cadence.quantity.Quantity",
+		)
+	})
+}
+
+func TestMarshal(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("abstract type", func(t *testing.T) {
+
+		t.Parallel()
+
+		value, err := Marshal(uint8(1), AnyStructType{})
+		require.NoError(t, err)
+
+		assert.Equal(t, NewUInt8(1), value)
+	})
+
+	t.Run("Cadence value", func(t *testing.T) {
+
+		t.Parallel()
+
+		value, err := Marshal(NewInt(1), IntType{})
+		require.NoError(t, err)
+
+		assert.Equal(t, NewInt(1), value)
+	})
+
+	t.Run("non-optional for optional", func(t *testing.T) {
+
+		t.Parallel()
+
+		value, err := Marshal(1, OptionalType{Type: IntType{}})
+		require.NoError(t, err)
+
+		assert.Equal(t, NewOptional(NewInt(1)), value)
+	})
+
+	t.Run("big.Rat", func(t *testing.T) {
+
+		t.Parallel()
+
+		value, err := Marshal(big.NewRat(3, 2), UFix64Type{})
+		require.NoError(t, err)
+
+		assert.Equal(t, UFix64(1_50000000), value)
+
+		_, err = Marshal(big.NewRat(1, 3), UFix64Type{})
+		require.EqualError(t, err, "value: value 1/3 cannot be represented exactly by type UFix64")
+	})
+
+	t.Run("negative UFix64", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := Marshal(big.NewRat(-1, 2), UFix64Type{})
+		require.EqualError(t, err, "value: value is out of range of type UFix64")
+	})
+
+	t.Run("enum from integer", func(t *testing.T) {
+
+		t.Parallel()
+
+		enumType := &EnumType{
+			Location:            utils.TestLocation,
+			QualifiedIdentifier: "Direction",
+			RawType:             UInt8Type{},
+			Fields: []Field{
+				{Identifier: "rawValue", Type: UInt8Type{}},
+			},
+		}
+
+		value, err := Marshal(2, enumType)
+		require.NoError(t, err)
+
+		assert.Equal(t, NewEnum([]Value{NewUInt8(2)}).WithType(enumType), value)
+	})
+
+	t.Run("storage path", func(t *testing.T) {
+
+		t.Parallel()
+
+		value, err := Marshal("/storage/foo", StoragePathType{})
+		require.NoError(t, err)
+
+		assert.Equal(t, Path{Domain: "storage", Identifier: "foo"}, value)
+
+		_, err = Marshal("/public/foo", StoragePathType{})
+		require.EqualError(t, err, "value: path `/public/foo` is not of type StoragePath")
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := Marshal(
+			[]int{1, 256},
+			VariableSizedArrayType{
+				ElementType: UInt8Type{},
+			},
+		)
+		require.EqualError(t, err, "value[1]: value 256 is out of range of type UInt8")
+	})
+
+	t.Run("nil for non-optional", func(t *testing.T) {
+
+		t.Parallel()
+
+		var item *testMarshalItem
+
+		_, err := Marshal(item, testMarshalItemType)
+		require.EqualError(t, err, "value: cannot marshal nil into non-optional type S.test.Item")
+	})
+
+	t.Run("missing Go field", func(t *testing.T) {
+
+		t.Parallel()
+
+		type item struct {
+			ID uint64 `cadence:"id"`
+		}
+
+		_, err := Marshal(item{ID: 1}, testMarshalItemType)
+		require.EqualError(
+			t,
+			err,
+			"value: Go type cadence.item has no field for field `name` of type S.test.Item",
+		)
+	})
+
+	t.Run("extra Go field", func(t *testing.T) {
+
+		t.Parallel()
+
+		type item struct {
+			ID       uint64  `cadence:"id"`
+			Name     *string `cadence:"name"`
+			Quantity int     `cadence:"quantity"`
+		}
+
+		_, err := Marshal(item{}, testMarshalItemType)
+
+		var marshalErr *MarshalError
+		require.ErrorAs(t, err, &marshalErr)
+
+		assert.Equal(t, "", marshalErr.Path)
+		assert.Contains(t, err.Error(), "type S.test.Item has no field `quantity` for Go field")
+	})
+
+	t.Run("mismatched nested field", func(t *testing.T) {
+
+		t.Parallel()
+
+		type item struct {
+			ID   string  `cadence:"id"`
+			Name *string `cadence:"name"`
+		}
+
+		_, err := Marshal(
+			[]item{{ID: "1"}},
+			VariableSizedArrayType{
+				ElementType: testMarshalItemType,
+			},
+		)
+		require.EqualError(t, err, "value[0].id: cannot marshal Go value of type string into type UInt64")
+	})
+}
@@ -24,16 +24,21 @@ import (
 
 type ValueType generic.Type
 
+type TypeParameterType generic.Type
+
+type TypeArgumentType generic.Type
+
 // A ValueTypeActivation is a map of strings to values.
 // It can be used to represent an active scope in a program,
 // i.e. it can be used as a symbol table during semantic analysis,
 // or as an activation record during interpretation or compilation.
 //
 type ValueTypeActivation struct {
-	entries    map[string]ValueType
-	Depth      int
-	Parent     *ValueTypeActivation
-	isFunction bool
+	entries       map[string]ValueType
+	typeArguments map[TypeParameterType]TypeArgumentType
+	Depth         int
+	Parent        *ValueTypeActivation
+	isFunction    bool
 }
 
 func NewValueTypeActivation(parent *ValueTypeActivation) *ValueTypeActivation {
@@ -107,6 +112,58 @@ func (a *ValueTypeActivation) Set(name string, value ValueType) {
 	a.entries[name] = value
 }
 
+// SetTypeArgument binds the given type parameter to the given type argument in the activation.
+//
+func (a *ValueTypeActivation) SetTypeArgument(typeParameter TypeParameterType, typeArgument TypeArgumentType) {
+	if a.typeArguments == nil {
+		a.typeArguments = make(map[TypeParameterType]TypeArgumentType)
+	}
+
+	a.typeArguments[typeParameter] = typeArgument
+}
+
+// HasTypeArguments returns true if the activation or any of its parents
+// binds a type parameter to a type argument.
+//
+func (a *ValueTypeActivation) HasTypeArguments() bool {
+
+	current := a
+
+	for current != nil {
+
+		if len(current.typeArguments) > 0 {
+			return true
+		}
+
+		current = current.Parent
+	}
+
+	return false
+}
+
+// TypeArguments returns all type arguments bound in the activation and its parents.
+// Type arguments bound in nested activations take precedence.
+//
+func (a *ValueTypeActivation) TypeArguments() map[TypeParameterType]TypeArgumentType {
+
+	typeArguments := make(map[TypeParameterType]TypeArgumentType)
+
+	current := a
+
+	for current != nil {
+
+		for typeParameter, typeArgument := range current.typeArguments { //nolint:maprangecheck
+			if _, ok := typeArguments[typeParameter]; !ok {
+				typeArguments[typeParameter] = typeArgument
+			}
+		}
+
+		current = current.Parent
+	}
+
+	return typeArguments
+}
+
 // ValueTypeActivations is a stack of activation records.
 // Each entry represents a new activation record.
 //
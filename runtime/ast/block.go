@@ -106,6 +106,13 @@ func (b *FunctionBlock) IsEmpty() bool {
 			b.PostConditions.IsEmpty())
 }
 
+// HasStatements returns true if the function block has statements,
+// i.e. if it is an implementation, and not just a list of conditions.
+//
+func (b *FunctionBlock) HasStatements() bool {
+	return b != nil && !b.Block.IsEmpty()
+}
+
 func (b *FunctionBlock) Accept(visitor Visitor) Repr {
 	return visitor.VisitFunctionBlock(b)
 }
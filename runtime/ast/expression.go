@@ -1092,6 +1092,7 @@ func (e *BinaryExpression) MarshalJSON() ([]byte, error) {
 // FunctionExpression
 
 type FunctionExpression struct {
+	Purity               FunctionPurity `json:",omitempty"`
 	ParameterList        *ParameterList
 	ReturnTypeAnnotation *TypeAnnotation
 	FunctionBlock        *FunctionBlock
@@ -1124,6 +1125,7 @@ func (e *FunctionExpression) String() string {
 }
 
 var functionExpressionFunKeywordDoc prettier.Doc = prettier.Text("fun ")
+var functionExpressionViewKeywordDoc prettier.Doc = prettier.Text("view ")
 var functionExpressionParameterSeparatorDoc prettier.Doc = prettier.Concat{
 	prettier.Text(","),
 	prettier.Line{},
@@ -1146,12 +1148,19 @@ func (e *FunctionExpression) Doc() prettier.Doc {
 		}
 	}
 
-	doc := prettier.Concat{
+	var doc prettier.Concat
+
+	if e.Purity == FunctionPurityView {
+		doc = append(doc, functionExpressionViewKeywordDoc)
+	}
+
+	doc = append(
+		doc,
 		functionExpressionFunKeywordDoc,
 		prettier.Group{
 			Doc: signatureDoc,
 		},
-	}
+	)
 
 	if e.FunctionBlock.IsEmpty() {
 		return append(doc, functionExpressionEmptyBlockDoc)
@@ -1425,6 +1434,75 @@ func (e *DestroyExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// AttachExpression
+
+type AttachExpression struct {
+	Attachment *InvocationExpression
+	Base       Expression
+	StartPos   Position `json:"-"`
+}
+
+var _ Expression = &AttachExpression{}
+
+func (*AttachExpression) isExpression() {}
+
+func (*AttachExpression) isIfStatementTest() {}
+
+func (e *AttachExpression) Accept(visitor Visitor) Repr {
+	return e.AcceptExp(visitor)
+}
+
+func (e *AttachExpression) Walk(walkChild func(Element)) {
+	walkChild(e.Attachment)
+	walkChild(e.Base)
+}
+
+func (e *AttachExpression) AcceptExp(visitor ExpressionVisitor) Repr {
+	return visitor.VisitAttachExpression(e)
+}
+
+func (e *AttachExpression) String() string {
+	return fmt.Sprintf(
+		"(attach %s to %s)",
+		e.Attachment,
+		e.Base,
+	)
+}
+
+const attachExpressionKeywordDoc = prettier.Text("attach ")
+const attachExpressionToKeywordDoc = prettier.Text(" to ")
+
+func (e *AttachExpression) Doc() prettier.Doc {
+	return prettier.Concat{
+		attachExpressionKeywordDoc,
+		e.Attachment.Doc(),
+		attachExpressionToKeywordDoc,
+		// TODO: potentially parenthesize
+		e.Base.Doc(),
+	}
+}
+
+func (e *AttachExpression) StartPosition() Position {
+	return e.StartPos
+}
+
+func (e *AttachExpression) EndPosition() Position {
+	return e.Base.EndPosition()
+}
+
+func (e *AttachExpression) MarshalJSON() ([]byte, error) {
+	type Alias AttachExpression
+	return json.Marshal(&struct {
+		Type string
+		Range
+		*Alias
+	}{
+		Type:  "AttachExpression",
+		Range: NewRangeFromPositioned(e),
+		Alias: (*Alias)(e),
+	})
+}
+
 // ReferenceExpression
 
 type ReferenceExpression struct {
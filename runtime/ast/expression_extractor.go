@@ -96,6 +96,10 @@ type DestroyExtractor interface {
 	ExtractDestroy(extractor *ExpressionExtractor, expression *DestroyExpression) ExpressionExtraction
 }
 
+type AttachExtractor interface {
+	ExtractAttach(extractor *ExpressionExtractor, expression *AttachExpression) ExpressionExtraction
+}
+
 type ReferenceExtractor interface {
 	ExtractReference(extractor *ExpressionExtractor, expression *ReferenceExpression) ExpressionExtraction
 }
@@ -128,6 +132,7 @@ type ExpressionExtractor struct {
 	CastingExtractor     CastingExtractor
 	CreateExtractor      CreateExtractor
 	DestroyExtractor     DestroyExtractor
+	AttachExtractor      AttachExtractor
 	ReferenceExtractor   ReferenceExtractor
 	ForceExtractor       ForceExtractor
 	PathExtractor        PathExtractor
@@ -719,6 +724,61 @@ func (extractor *ExpressionExtractor) ExtractDestroy(expression *DestroyExpressi
 	}
 }
 
+func (extractor *ExpressionExtractor) VisitAttachExpression(expression *AttachExpression) Repr {
+	// delegate to child extractor, if any,
+	// or call default implementation
+
+	if extractor.AttachExtractor != nil {
+		return extractor.AttachExtractor.ExtractAttach(extractor, expression)
+	}
+	return extractor.ExtractAttach(expression)
+}
+
+func (extractor *ExpressionExtractor) ExtractAttach(expression *AttachExpression) ExpressionExtraction {
+	var extractedExpressions []ExtractedExpression
+
+	// copy the expression
+	newExpression := *expression
+
+	// rewrite the attachment invocation
+
+	attachmentResult := extractor.Extract(newExpression.Attachment)
+
+	invocationExpression, ok := attachmentResult.RewrittenExpression.(*InvocationExpression)
+	if !ok {
+		// Edge-case:
+		// The rewritten expression returned from the extractor may not be an InvocationExpression,
+		// but an expression of another type.
+		//
+		// Wrap the rewritten expression in an InvocationExpression.
+
+		invocationExpression = &InvocationExpression{
+			InvokedExpression: attachmentResult.RewrittenExpression,
+			EndPos:            attachmentResult.RewrittenExpression.EndPosition(),
+		}
+	}
+
+	newExpression.Attachment = invocationExpression
+	extractedExpressions = append(
+		extractedExpressions,
+		attachmentResult.ExtractedExpressions...,
+	)
+
+	// rewrite the base
+
+	baseResult := extractor.Extract(newExpression.Base)
+	newExpression.Base = baseResult.RewrittenExpression
+	extractedExpressions = append(
+		extractedExpressions,
+		baseResult.ExtractedExpressions...,
+	)
+
+	return ExpressionExtraction{
+		RewrittenExpression:  &newExpression,
+		ExtractedExpressions: extractedExpressions,
+	}
+}
+
 func (extractor *ExpressionExtractor) VisitReferenceExpression(expression *ReferenceExpression) Repr {
 	// delegate to child extractor, if any,
 	// or call default implementation
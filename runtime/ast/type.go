@@ -346,6 +346,7 @@ func (t *DictionaryType) CheckEqual(other Type, checker TypeEqualityChecker) err
 // FunctionType
 
 type FunctionType struct {
+	PurityAnnotation         FunctionPurity    `json:",omitempty"`
 	ParameterTypeAnnotations []*TypeAnnotation `json:",omitempty"`
 	ReturnTypeAnnotation     *TypeAnnotation
 	Range
@@ -364,13 +365,19 @@ func (t *FunctionType) String() string {
 		parameters.WriteString(parameterTypeAnnotation.String())
 	}
 
-	return fmt.Sprintf("((%s): %s)", parameters.String(), t.ReturnTypeAnnotation.String())
+	var purity string
+	if t.PurityAnnotation == FunctionPurityView {
+		purity = "view "
+	}
+
+	return fmt.Sprintf("(%s(%s): %s)", purity, parameters.String(), t.ReturnTypeAnnotation.String())
 }
 
 const functionTypeStartDoc = prettier.Text("(")
 const functionTypeEndDoc = prettier.Text(")")
 const functionTypeTypeSeparatorSpaceDoc = prettier.Text(": ")
 const functionTypeParameterSeparatorDoc = prettier.Text(",")
+const functionTypeViewKeywordSpaceDoc = prettier.Text("view ")
 
 func (t *FunctionType) Doc() prettier.Doc {
 	parametersDoc := prettier.Concat{
@@ -391,8 +398,16 @@ func (t *FunctionType) Doc() prettier.Doc {
 		)
 	}
 
-	return prettier.Concat{
+	doc := prettier.Concat{
 		functionTypeStartDoc,
+	}
+
+	if t.PurityAnnotation == FunctionPurityView {
+		doc = append(doc, functionTypeViewKeywordSpaceDoc)
+	}
+
+	return append(
+		doc,
 		prettier.Group{
 			Doc: prettier.Concat{
 				functionTypeStartDoc,
@@ -406,7 +421,7 @@ func (t *FunctionType) Doc() prettier.Doc {
 		functionTypeTypeSeparatorSpaceDoc,
 		t.ReturnTypeAnnotation.Doc(),
 		functionTypeEndDoc,
-	}
+	)
 }
 
 func (t *FunctionType) MarshalJSON() ([]byte, error) {
@@ -79,6 +79,7 @@ type ExpressionVisitor interface {
 	VisitCastingExpression(*CastingExpression) Repr
 	VisitCreateExpression(*CreateExpression) Repr
 	VisitDestroyExpression(*DestroyExpression) Repr
+	VisitAttachExpression(*AttachExpression) Repr
 	VisitReferenceExpression(*ReferenceExpression) Repr
 	VisitForceExpression(*ForceExpression) Repr
 	VisitPathExpression(*PathExpression) Repr
@@ -96,6 +97,7 @@ type Visitor interface {
 	VisitFieldDeclaration(*FieldDeclaration) Repr
 	VisitEnumCaseDeclaration(*EnumCaseDeclaration) Repr
 	VisitPragmaDeclaration(*PragmaDeclaration) Repr
+	VisitTypeAliasDeclaration(*TypeAliasDeclaration) Repr
 	VisitImportDeclaration(*ImportDeclaration) Repr
 	VisitTransactionDeclaration(*TransactionDeclaration) Repr
 }
@@ -0,0 +1,191 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+)
+
+var typeDeclarations = append(
+	stdlib.FlowBuiltInTypes,
+	stdlib.BuiltinTypes...,
+).ToTypeDeclarations()
+
+// loader parses and checks programs from files, including their imports,
+// and creates interpreters for them.
+//
+// Imported files are resolved relative to the importing file,
+// so locations are absolute paths, just like the paths of breakpoint sources.
+//
+type loader struct {
+	codes             map[common.LocationID]string
+	checkers          map[common.LocationID]*sema.Checker
+	valueDeclarations stdlib.StandardLibraryFunctions
+}
+
+func newLoader(log interpreter.HostFunction) *loader {
+	impls := stdlib.DefaultFlowBuiltinImpls()
+	impls.Log = log
+
+	return &loader{
+		codes:    map[common.LocationID]string{},
+		checkers: map[common.LocationID]*sema.Checker{},
+		valueDeclarations: append(
+			stdlib.FlowBuiltInFunctions(impls),
+			stdlib.BuiltinFunctions...,
+		),
+	}
+}
+
+func (l *loader) check(location common.StringLocation) (*sema.Checker, error) {
+
+	locationID := location.ID()
+
+	checker, ok := l.checkers[locationID]
+	if ok {
+		return checker, nil
+	}
+
+	code, err := ioutil.ReadFile(string(location))
+	if err != nil {
+		return nil, err
+	}
+	l.codes[locationID] = string(code)
+
+	program, err := parser2.ParseProgram(string(code), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	directory := filepath.Dir(string(location))
+
+	checker, err = sema.NewChecker(
+		program,
+		location,
+		sema.WithPredeclaredValues(l.valueDeclarations.ToSemaValueDeclarations()),
+		sema.WithPredeclaredTypes(typeDeclarations),
+		sema.WithLocationHandler(
+			func(identifiers []ast.Identifier, location common.Location) ([]sema.ResolvedLocation, error) {
+				if stringLocation, ok := location.(common.StringLocation); ok &&
+					!filepath.IsAbs(string(stringLocation)) {
+
+					location = common.StringLocation(
+						filepath.Join(directory, string(stringLocation)),
+					)
+				}
+
+				return []sema.ResolvedLocation{
+					{
+						Location:    location,
+						Identifiers: identifiers,
+					},
+				}, nil
+			},
+		),
+		sema.WithImportHandler(
+			func(_ *sema.Checker, importedLocation common.Location, _ ast.Range) (sema.Import, error) {
+				stringLocation, ok := importedLocation.(common.StringLocation)
+				if !ok {
+					return nil, fmt.Errorf(
+						"cannot import `%s`: only files are supported",
+						importedLocation,
+					)
+				}
+
+				importedChecker, err := l.check(stringLocation)
+				if err != nil {
+					return nil, err
+				}
+
+				return sema.ElaborationImport{
+					Elaboration: importedChecker.Elaboration,
+				}, nil
+			},
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	l.checkers[locationID] = checker
+
+	err = checker.Check()
+	if err != nil {
+		return nil, err
+	}
+
+	return checker, nil
+}
+
+// newInterpreter returns a new interpreter for the given checked program.
+// Imported programs must have been checked by the loader.
+//
+func (l *loader) newInterpreter(
+	ctx context.Context,
+	checker *sema.Checker,
+	debugger *interpreter.Debugger,
+) (
+	*interpreter.Interpreter,
+	error,
+) {
+	var uuid uint64
+
+	return interpreter.NewInterpreter(
+		interpreter.ProgramFromChecker(checker),
+		checker.Location,
+		interpreter.WithStorage(interpreter.NewInMemoryStorage()),
+		interpreter.WithPredeclaredValues(l.valueDeclarations.ToInterpreterValueDeclarations()),
+		interpreter.WithUUIDHandler(func() (uint64, error) {
+			defer func() { uuid++ }()
+			return uuid, nil
+		}),
+		interpreter.WithImportLocationHandler(
+			func(inter *interpreter.Interpreter, location common.Location) interpreter.Import {
+				importedChecker, ok := l.checkers[location.ID()]
+				if !ok {
+					panic(fmt.Errorf("cannot import `%s`: program was not checked", location))
+				}
+
+				subInterpreter, err := inter.NewSubInterpreter(
+					interpreter.ProgramFromChecker(importedChecker),
+					location,
+				)
+				if err != nil {
+					panic(err)
+				}
+
+				return interpreter.InterpreterImport{
+					Interpreter: subInterpreter,
+				}
+			},
+		),
+		interpreter.WithDebugger(debugger),
+		interpreter.WithContext(ctx),
+	)
+}
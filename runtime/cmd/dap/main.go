@@ -0,0 +1,42 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// The dap command is a debug adapter for Cadence programs.
+//
+// It implements the Debug Adapter Protocol (DAP) over standard input and output,
+// so editors like Visual Studio Code can debug programs using the interpreter's debugger.
+//
+// The `program` argument of the launch request is the path of the program.
+// The program is interpreted, and the global function `main` is invoked, if any.
+//
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/onflow/cadence/runtime/pretty"
+)
+
+func main() {
+	err := newServer(os.Stdin, os.Stdout).serve()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, pretty.FormatErrorMessage(err.Error(), true))
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,894 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/pretty"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// The interpreter executes the program on a single thread
+const threadID = 1
+
+const mainFunctionName = "main"
+
+const (
+	stopReasonEntry      = "entry"
+	stopReasonStep       = "step"
+	stopReasonPause      = "pause"
+	stopReasonBreakpoint = "breakpoint"
+)
+
+// server is a debug adapter, which handles requests of a client, e.g. an editor.
+//
+// Requests are handled sequentially. The program is executed concurrently,
+// and the server sends events when the execution stops or terminates.
+//
+type server struct {
+	reader *bufio.Reader
+	writer io.Writer
+
+	writeLock sync.Mutex
+	seq       int
+
+	debugger *interpreter.Debugger
+	loader   *loader
+
+	checker     *sema.Checker
+	noDebug     bool
+	stopOnEntry bool
+	configured  bool
+	started     bool
+	cancel      context.CancelFunc
+	terminated  chan struct{}
+
+	// The following fields describe the stopped execution,
+	// and are guarded by the lock
+
+	lock       sync.Mutex
+	stopped    bool
+	stopReason string
+	frames     []interpreter.StackFrame
+	references []func() []variable
+}
+
+func newServer(reader io.Reader, writer io.Writer) *server {
+	s := &server{
+		reader:     bufio.NewReader(reader),
+		writer:     writer,
+		debugger:   interpreter.NewDebugger(),
+		terminated: make(chan struct{}),
+	}
+
+	s.loader = newLoader(func(invocation interpreter.Invocation) interpreter.Value {
+		s.sendOutput("stdout", invocation.Arguments[0].String()+"\n")
+		return interpreter.VoidValue{}
+	})
+
+	return s
+}
+
+// serve handles requests until the client disconnects
+//
+func (s *server) serve() error {
+	for {
+		content, err := readMessage(s.reader)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var req request
+		err = json.Unmarshal(content, &req)
+		if err != nil {
+			return err
+		}
+
+		if req.Type != "request" {
+			continue
+		}
+
+		body, err := s.handleRequest(req)
+		if err != nil {
+			s.send(&response{
+				Type:       "response",
+				RequestSeq: req.Seq,
+				Command:    req.Command,
+				Success:    false,
+				Message:    err.Error(),
+			})
+			continue
+		}
+
+		s.send(&response{
+			Type:       "response",
+			RequestSeq: req.Seq,
+			Command:    req.Command,
+			Success:    true,
+			Body:       body,
+		})
+
+		switch req.Command {
+		case "initialize":
+			s.sendEvent("initialized", nil)
+
+		case "launch", "configurationDone":
+			s.startIfReady()
+
+		case "disconnect":
+			return nil
+		}
+	}
+}
+
+func (s *server) handleRequest(req request) (interface{}, error) {
+	switch req.Command {
+	case "initialize":
+		return capabilities{
+			SupportsConfigurationDoneRequest: true,
+			SupportsConditionalBreakpoints:   true,
+			SupportsEvaluateForHovers:        true,
+		}, nil
+
+	case "launch":
+		var arguments launchArguments
+		err := decodeArguments(req, &arguments)
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.launch(arguments)
+
+	case "setBreakpoints":
+		var arguments setBreakpointsArguments
+		err := decodeArguments(req, &arguments)
+		if err != nil {
+			return nil, err
+		}
+		return s.setBreakpoints(arguments), nil
+
+	case "configurationDone":
+		s.configured = true
+		return nil, nil
+
+	case "threads":
+		return threadsResponseBody{
+			Threads: []thread{
+				{
+					ID:   threadID,
+					Name: "main",
+				},
+			},
+		}, nil
+
+	case "stackTrace":
+		var arguments stackTraceArguments
+		err := decodeArguments(req, &arguments)
+		if err != nil {
+			return nil, err
+		}
+		return s.stackTrace(arguments)
+
+	case "scopes":
+		var arguments scopesArguments
+		err := decodeArguments(req, &arguments)
+		if err != nil {
+			return nil, err
+		}
+		return s.scopes(arguments)
+
+	case "variables":
+		var arguments variablesArguments
+		err := decodeArguments(req, &arguments)
+		if err != nil {
+			return nil, err
+		}
+		return s.variables(arguments)
+
+	case "evaluate":
+		var arguments evaluateArguments
+		err := decodeArguments(req, &arguments)
+		if err != nil {
+			return nil, err
+		}
+		return s.evaluate(arguments)
+
+	case "continue":
+		err := s.resume("", s.debugger.Continue)
+		if err != nil {
+			return nil, err
+		}
+		return continueResponseBody{
+			AllThreadsContinued: true,
+		}, nil
+
+	case "next":
+		return nil, s.resume(stopReasonStep, s.debugger.ContinueStepOver)
+
+	case "stepIn":
+		return nil, s.resume(stopReasonStep, s.debugger.ContinueStepIn)
+
+	case "stepOut":
+		return nil, s.resume(stopReasonStep, s.debugger.ContinueStepOut)
+
+	case "pause":
+		s.lock.Lock()
+		s.stopReason = stopReasonPause
+		s.lock.Unlock()
+
+		s.debugger.RequestPause()
+		return nil, nil
+
+	case "disconnect":
+		s.disconnect()
+		return nil, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported request: %s", req.Command)
+	}
+}
+
+func decodeArguments(req request, arguments interface{}) error {
+	if len(req.Arguments) == 0 {
+		return nil
+	}
+	return json.Unmarshal(req.Arguments, arguments)
+}
+
+// launch checks the program, which is executed once the client finished the configuration
+//
+func (s *server) launch(arguments launchArguments) error {
+	if s.checker != nil {
+		return errors.New("program is already launched")
+	}
+
+	if arguments.Program == "" {
+		return errors.New("missing program")
+	}
+
+	path, err := filepath.Abs(arguments.Program)
+	if err != nil {
+		return err
+	}
+
+	location := common.StringLocation(path)
+
+	checker, err := s.loader.check(location)
+	if err != nil {
+		return errors.New(s.formatError(err, location))
+	}
+
+	s.checker = checker
+	s.noDebug = arguments.NoDebug
+	s.stopOnEntry = arguments.StopOnEntry
+
+	return nil
+}
+
+func (s *server) startIfReady() {
+	if s.started || s.checker == nil || !s.configured {
+		return
+	}
+	s.started = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	var debugger *interpreter.Debugger
+	if !s.noDebug {
+		debugger = s.debugger
+
+		if s.stopOnEntry {
+			s.lock.Lock()
+			s.stopReason = stopReasonEntry
+			s.lock.Unlock()
+
+			debugger.RequestPause()
+		}
+
+		go s.handleStops()
+	}
+
+	go func() {
+		defer close(s.terminated)
+
+		exitCode := 0
+
+		err := s.run(ctx, debugger)
+		if err != nil {
+			exitCode = 1
+			s.sendOutput("stderr", s.formatError(err, s.checker.Location))
+		}
+
+		s.sendEvent("exited", exitedEventBody{
+			ExitCode: exitCode,
+		})
+		s.sendEvent("terminated", nil)
+	}()
+}
+
+// run interprets the program and invokes the main function, if any
+//
+func (s *server) run(ctx context.Context, debugger *interpreter.Debugger) error {
+	inter, err := s.loader.newInterpreter(ctx, s.checker, debugger)
+	if err != nil {
+		return err
+	}
+
+	err = inter.Interpret()
+	if err != nil {
+		return err
+	}
+
+	if !inter.Globals.Contains(mainFunctionName) {
+		return nil
+	}
+
+	_, err = inter.Invoke(mainFunctionName)
+	return err
+}
+
+func (s *server) formatError(err error, location common.Location) string {
+	var buffer bytes.Buffer
+	printErr := pretty.NewErrorPrettyPrinter(&buffer, false).
+		PrettyPrintError(err, location, s.loader.codes)
+	if printErr != nil {
+		return err.Error() + "\n"
+	}
+	return buffer.String()
+}
+
+// handleStops sends a stopped event for each stop of the execution,
+// until the execution terminates
+//
+func (s *server) handleStops() {
+	for {
+		select {
+		case stop := <-s.debugger.Stops():
+			s.onStop(stop)
+
+		case <-s.terminated:
+			return
+		}
+	}
+}
+
+func (s *server) onStop(stop interpreter.Stop) {
+	s.lock.Lock()
+
+	reason := s.stopReason
+	if stop.Breakpoint != nil {
+		reason = stopReasonBreakpoint
+	} else if reason == "" {
+		reason = stopReasonPause
+	}
+
+	frames := s.debugger.CallStack()
+	if len(frames) == 0 {
+		// The statement is not in a function, e.g. a statement of a transaction
+		frames = []interpreter.StackFrame{
+			{
+				Interpreter: stop.Interpreter,
+				Statement:   stop.Statement,
+				Activation:  s.debugger.CurrentActivation(stop.Interpreter),
+			},
+		}
+	}
+
+	s.stopped = true
+	s.stopReason = ""
+	s.frames = frames
+	s.references = nil
+
+	s.lock.Unlock()
+
+	var description string
+	if stop.ConditionError != nil {
+		description = fmt.Sprintf("failed to evaluate breakpoint condition: %s", stop.ConditionError)
+		s.sendOutput("stderr", description+"\n")
+	}
+
+	s.sendEvent("stopped", stoppedEventBody{
+		Reason:            reason,
+		Description:       description,
+		ThreadID:          threadID,
+		AllThreadsStopped: true,
+	})
+}
+
+// resume resumes the stopped execution using the given debugger function.
+// The given reason is the reason of the next stop, unless the stop is caused by a breakpoint
+//
+func (s *server) resume(reason string, resume func() bool) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.stopped {
+		return errors.New("program is not stopped")
+	}
+
+	s.stopped = false
+	s.stopReason = reason
+	s.frames = nil
+	s.references = nil
+
+	resume()
+
+	return nil
+}
+
+// disconnect cancels the execution, if it was started
+//
+func (s *server) disconnect() {
+	if !s.started {
+		return
+	}
+
+	for _, breakpoint := range s.debugger.Breakpoints() {
+		s.debugger.RemoveBreakpoint(breakpoint.ID)
+	}
+
+	s.cancel()
+
+	s.lock.Lock()
+	stopped := s.stopped
+	s.stopped = false
+	s.lock.Unlock()
+
+	if stopped {
+		s.debugger.Continue()
+	}
+}
+
+func (s *server) setBreakpoints(arguments setBreakpointsArguments) setBreakpointsResponseBody {
+	location := sourceLocation(arguments.Source)
+
+	s.debugger.ClearBreakpoints(location)
+
+	breakpoints := make([]breakpoint, 0, len(arguments.Breakpoints))
+
+	for _, sourceBreakpoint := range arguments.Breakpoints {
+
+		var condition ast.Expression
+		if sourceBreakpoint.Condition != "" {
+			var err error
+			condition, err = parseExpression(sourceBreakpoint.Condition)
+			if err != nil {
+				breakpoints = append(breakpoints, breakpoint{
+					Verified: false,
+					Line:     sourceBreakpoint.Line,
+					Message:  err.Error(),
+				})
+				continue
+			}
+		}
+
+		added := s.debugger.AddBreakpoint(
+			location,
+			sourceBreakpoint.Line,
+			condition,
+		)
+
+		breakpoints = append(breakpoints, breakpoint{
+			ID:       added.ID,
+			Verified: true,
+			Line:     added.Line,
+		})
+	}
+
+	return setBreakpointsResponseBody{
+		Breakpoints: breakpoints,
+	}
+}
+
+func sourceLocation(source source) common.Location {
+	path := source.Path
+	if absolutePath, err := filepath.Abs(path); err == nil {
+		path = absolutePath
+	}
+	return common.StringLocation(path)
+}
+
+func locationSource(location common.Location) *source {
+	if stringLocation, ok := location.(common.StringLocation); ok {
+		path := string(stringLocation)
+		return &source{
+			Name: filepath.Base(path),
+			Path: path,
+		}
+	}
+
+	return &source{
+		Name: location.String(),
+	}
+}
+
+func (s *server) stackTrace(arguments stackTraceArguments) (interface{}, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.stopped {
+		return nil, errors.New("program is not stopped")
+	}
+
+	total := len(s.frames)
+
+	start := arguments.StartFrame
+	if start > total {
+		start = total
+	}
+
+	end := total
+	if arguments.Levels > 0 && start+arguments.Levels < total {
+		end = start + arguments.Levels
+	}
+
+	stackFrames := make([]stackFrame, 0, end-start)
+
+	for index := start; index < end; index++ {
+		frame := s.frames[index]
+
+		result := stackFrame{
+			// Frame IDs are one-based indices into the frames of the stop
+			ID:     index + 1,
+			Name:   frameName(frame),
+			Source: locationSource(frame.Location()),
+		}
+
+		if frame.Statement != nil {
+			position := frame.Statement.StartPosition()
+			result.Line = position.Line
+			// Columns of AST positions are zero-based
+			result.Column = position.Column + 1
+		}
+
+		stackFrames = append(stackFrames, result)
+	}
+
+	return stackTraceResponseBody{
+		StackFrames: stackFrames,
+		TotalFrames: total,
+	}, nil
+}
+
+// frameName returns the name of the innermost function declaration
+// which contains the current statement of the frame,
+// qualified with the names of the containing composite declarations.
+//
+func frameName(frame interpreter.StackFrame) string {
+	const anonymousName = "<anonymous>"
+
+	if frame.Statement == nil ||
+		frame.Interpreter.Program == nil ||
+		frame.Interpreter.Program.Program == nil {
+
+		return anonymousName
+	}
+
+	offset := frame.Statement.StartPosition().Offset
+
+	var name string
+
+	for _, declaration := range frame.Interpreter.Program.Program.Declarations() {
+		ast.Inspect(declaration, func(element ast.Element) bool {
+			if element == nil ||
+				element.StartPosition().Offset > offset ||
+				element.EndPosition().Offset < offset {
+
+				return false
+			}
+
+			var identifier string
+
+			switch element := element.(type) {
+			case *ast.CompositeDeclaration:
+				identifier = element.Identifier.Identifier
+			case *ast.FunctionDeclaration:
+				identifier = element.Identifier.Identifier
+			case *ast.SpecialFunctionDeclaration:
+				identifier = element.FunctionDeclaration.Identifier.Identifier
+				// Do not also qualify with the nested function declaration
+				if name == "" {
+					name = identifier
+				} else {
+					name += "." + identifier
+				}
+				return false
+			}
+
+			if identifier != "" {
+				if name == "" {
+					name = identifier
+				} else {
+					name += "." + identifier
+				}
+			}
+
+			return true
+		})
+	}
+
+	if name == "" {
+		return anonymousName
+	}
+
+	return name
+}
+
+func (s *server) frame(frameID int) (interpreter.StackFrame, error) {
+	if !s.stopped {
+		return interpreter.StackFrame{}, errors.New("program is not stopped")
+	}
+
+	// The top-most frame is used if no frame is given
+	if frameID == 0 {
+		frameID = 1
+	}
+
+	index := frameID - 1
+	if index < 0 || index >= len(s.frames) {
+		return interpreter.StackFrame{}, fmt.Errorf("unknown frame: %d", frameID)
+	}
+
+	return s.frames[index], nil
+}
+
+func (s *server) scopes(arguments scopesArguments) (interface{}, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	frame, err := s.frame(arguments.FrameID)
+	if err != nil {
+		return nil, err
+	}
+
+	inter := frame.Interpreter
+
+	scopes := make([]scope, 0, 2)
+
+	if frame.Activation != nil {
+		locals := frame.Activation.FunctionValues()
+		scopes = append(scopes, scope{
+			Name: "Locals",
+			VariablesReference: s.addReference(func() []variable {
+				return s.namedVariables(inter, locals)
+			}),
+		})
+	}
+
+	scopes = append(scopes, scope{
+		Name: "Globals",
+		VariablesReference: s.addReference(func() []variable {
+			return s.namedVariables(inter, inter.Globals)
+		}),
+	})
+
+	return scopesResponseBody{
+		Scopes: scopes,
+	}, nil
+}
+
+func (s *server) variables(arguments variablesArguments) (interface{}, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.stopped {
+		return nil, errors.New("program is not stopped")
+	}
+
+	// Variable references are one-based indices into the references of the stop
+	index := arguments.VariablesReference - 1
+	if index < 0 || index >= len(s.references) {
+		return nil, fmt.Errorf("unknown variables reference: %d", arguments.VariablesReference)
+	}
+
+	return variablesResponseBody{
+		Variables: s.references[index](),
+	}, nil
+}
+
+func (s *server) evaluate(arguments evaluateArguments) (interface{}, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	frame, err := s.frame(arguments.FrameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if frame.Activation == nil {
+		return nil, errors.New("frame has no activation")
+	}
+
+	expression, err := parseExpression(arguments.Expression)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := s.debugger.Evaluate(
+		frame.Interpreter,
+		frame.Activation,
+		expression,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.newVariable(frame.Interpreter, "", value)
+
+	return evaluateResponseBody{
+		Result:             result.Value,
+		Type:               result.Type,
+		VariablesReference: result.VariablesReference,
+	}, nil
+}
+
+// addReference adds a function which returns the variables of a scope or a value,
+// and returns the reference which the client uses to request the variables.
+//
+// NOTE: the lock must be held
+//
+func (s *server) addReference(variables func() []variable) int {
+	s.references = append(s.references, variables)
+	return len(s.references)
+}
+
+func (s *server) namedVariables(inter *interpreter.Interpreter, variables map[string]*interpreter.Variable) []variable {
+	names := make([]string, 0, len(variables))
+	for name := range variables { //nolint:maprangecheck
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]variable, 0, len(names))
+	for _, name := range names {
+		result = append(result, s.newVariable(inter, name, variables[name].GetValue()))
+	}
+	return result
+}
+
+// newVariable returns a variable for the given value.
+// Nested values, e.g. the fields of a composite, can be requested using the variables reference.
+//
+// NOTE: the lock must be held
+//
+func (s *server) newVariable(inter *interpreter.Interpreter, name string, value interpreter.Value) variable {
+	return variable{
+		Name:               name,
+		Value:              value.String(),
+		Type:               valueTypeString(value),
+		VariablesReference: s.valueReference(inter, value),
+	}
+}
+
+// valueTypeString returns the type of the given value, for display.
+// Some values, e.g. links and capability controllers, have no static type,
+// so the Go type name is used instead
+//
+func valueTypeString(value interpreter.Value) string {
+	staticType := value.StaticType()
+	if staticType == nil {
+		return fmt.Sprintf("%T", value)
+	}
+	return staticType.String()
+}
+
+func (s *server) valueReference(inter *interpreter.Interpreter, value interpreter.Value) int {
+	switch value := value.(type) {
+	case *interpreter.CompositeValue:
+		return s.addReference(func() []variable {
+			var fields []variable
+			value.ForEachField(func(name string, fieldValue interpreter.Value) {
+				fields = append(fields, s.newVariable(inter, name, fieldValue))
+			})
+			return fields
+		})
+
+	case *interpreter.ArrayValue:
+		return s.addReference(func() []variable {
+			elements := make([]variable, 0, value.Count())
+			value.Iterate(func(element interpreter.Value) bool {
+				name := fmt.Sprint(len(elements))
+				elements = append(elements, s.newVariable(inter, name, element))
+				return true
+			})
+			return elements
+		})
+
+	case *interpreter.DictionaryValue:
+		return s.addReference(func() []variable {
+			entries := make([]variable, 0, value.Count())
+			value.Iterate(func(key, entryValue interpreter.Value) bool {
+				entries = append(entries, s.newVariable(inter, key.String(), entryValue))
+				return true
+			})
+			return entries
+		})
+
+	case *interpreter.SomeValue:
+		innerValue := value.InnerValue(inter, interpreter.ReturnEmptyLocationRange)
+		return s.valueReference(inter, innerValue)
+
+	default:
+		return 0
+	}
+}
+
+func (s *server) send(message interface{}) {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	s.seq++
+
+	switch message := message.(type) {
+	case *response:
+		message.Seq = s.seq
+	case *event:
+		message.Seq = s.seq
+	}
+
+	// Errors cannot be reported to the client
+	_ = writeMessage(s.writer, message)
+}
+
+func (s *server) sendEvent(name string, body interface{}) {
+	s.send(&event{
+		Type:  "event",
+		Event: name,
+		Body:  body,
+	})
+}
+
+func (s *server) sendOutput(category string, output string) {
+	s.sendEvent("output", outputEventBody{
+		Category: category,
+		Output:   output,
+	})
+}
+
+// parseExpression parses the given code as an expression,
+// e.g. the condition of a breakpoint, or an expression to be evaluated
+//
+func parseExpression(code string) (ast.Expression, error) {
+	expression, errs := parser2.ParseExpression(code, nil)
+	if len(errs) > 0 {
+		return nil, parser2.Error{
+			Code:   code,
+			Errors: errs,
+		}
+	}
+
+	return expression, nil
+}
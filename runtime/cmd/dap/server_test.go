@@ -0,0 +1,345 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+type testClient struct {
+	t        *testing.T
+	writer   io.Writer
+	messages <-chan map[string]interface{}
+	pending  []map[string]interface{}
+	seq      int
+}
+
+func newTestClient(t *testing.T) *testClient {
+	serverReader, clientWriter := io.Pipe()
+	clientReader, serverWriter := io.Pipe()
+
+	go func() {
+		err := newServer(serverReader, serverWriter).serve()
+		assert.NoError(t, err)
+		_ = serverWriter.Close()
+	}()
+
+	messages := make(chan map[string]interface{}, 100)
+
+	go func() {
+		defer close(messages)
+
+		reader := bufio.NewReader(clientReader)
+		for {
+			content, err := readMessage(reader)
+			if err != nil {
+				return
+			}
+
+			var message map[string]interface{}
+			err = json.Unmarshal(content, &message)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			messages <- message
+		}
+	}()
+
+	return &testClient{
+		t:        t,
+		writer:   clientWriter,
+		messages: messages,
+	}
+}
+
+func (c *testClient) next(matches func(message map[string]interface{}) bool) map[string]interface{} {
+	for i, message := range c.pending {
+		if matches(message) {
+			c.pending = append(c.pending[:i], c.pending[i+1:]...)
+			return message
+		}
+	}
+
+	for message := range c.messages {
+		if matches(message) {
+			return message
+		}
+		c.pending = append(c.pending, message)
+	}
+
+	require.FailNow(c.t, "missing message")
+	return nil
+}
+
+// request sends a request with the given command and arguments,
+// and returns the body of the successful response
+//
+func (c *testClient) request(command string, arguments interface{}) map[string]interface{} {
+	message := c.response(command, arguments)
+
+	require.Equal(c.t, true, message["success"], message["message"])
+
+	body, _ := message["body"].(map[string]interface{})
+	return body
+}
+
+// response sends a request with the given command and arguments,
+// and returns the response, which may be successful or not
+//
+func (c *testClient) response(command string, arguments interface{}) map[string]interface{} {
+	c.seq++
+
+	err := writeMessage(c.writer, request{
+		Seq:       c.seq,
+		Type:      "request",
+		Command:   command,
+		Arguments: mustMarshal(c.t, arguments),
+	})
+	require.NoError(c.t, err)
+
+	seq := float64(c.seq)
+	return c.next(func(message map[string]interface{}) bool {
+		return message["type"] == "response" &&
+			message["request_seq"] == seq
+	})
+}
+
+func (c *testClient) event(name string) map[string]interface{} {
+	message := c.next(func(message map[string]interface{}) bool {
+		return message["type"] == "event" &&
+			message["event"] == name
+	})
+
+	body, _ := message["body"].(map[string]interface{})
+	return body
+}
+
+func mustMarshal(t *testing.T, value interface{}) json.RawMessage {
+	if value == nil {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+	return data
+}
+
+const testProgram = `
+  pub fun add(_ a: Int, _ b: Int): Int {
+      return a + b
+  }
+
+  pub struct S {
+      pub let x: Int
+
+      init() {
+          self.x = 1
+      }
+  }
+
+  pub fun main() {
+      let s = S()
+      let y = add(s.x, 2)
+      log(y)
+  }
+`
+
+func TestServer(t *testing.T) {
+
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "program.cdc")
+	err := ioutil.WriteFile(path, []byte(testProgram), 0644)
+	require.NoError(t, err)
+
+	client := newTestClient(t)
+
+	capabilities := client.request("initialize", nil)
+	assert.Equal(t, true, capabilities["supportsConditionalBreakpoints"])
+
+	client.event("initialized")
+
+	breakpoints := client.request("setBreakpoints", setBreakpointsArguments{
+		Source: source{Path: path},
+		Breakpoints: []sourceBreakpoint{
+			{Line: 3, Condition: "b == 2"},
+			{Line: 17},
+			{Line: 18, Condition: "y =="},
+		},
+	})
+	require.Len(t, breakpoints["breakpoints"], 3)
+
+	// The breakpoint with the invalid condition is not verified
+
+	invalidBreakpoint := breakpoints["breakpoints"].([]interface{})[2].(map[string]interface{})
+	assert.Equal(t, false, invalidBreakpoint["verified"])
+	assert.NotEmpty(t, invalidBreakpoint["message"])
+
+	client.request("launch", launchArguments{Program: path})
+	client.request("configurationDone", nil)
+
+	// Stop at the conditional breakpoint in the invoked function
+
+	stopped := client.event("stopped")
+	assert.Equal(t, stopReasonBreakpoint, stopped["reason"])
+
+	stackTrace := client.request("stackTrace", stackTraceArguments{ThreadID: threadID})
+	frames := stackTrace["stackFrames"].([]interface{})
+	require.Len(t, frames, 2)
+
+	topFrame := frames[0].(map[string]interface{})
+	assert.Equal(t, "add", topFrame["name"])
+	assert.Equal(t, float64(3), topFrame["line"])
+	assert.Equal(t, path, topFrame["source"].(map[string]interface{})["path"])
+
+	callerFrame := frames[1].(map[string]interface{})
+	assert.Equal(t, "main", callerFrame["name"])
+	assert.Equal(t, float64(16), callerFrame["line"])
+
+	// Step out to the caller
+
+	client.request("stepOut", nil)
+
+	stopped = client.event("stopped")
+	assert.Equal(t, stopReasonStep, stopped["reason"])
+
+	stackTrace = client.request("stackTrace", stackTraceArguments{ThreadID: threadID})
+	frames = stackTrace["stackFrames"].([]interface{})
+	require.Len(t, frames, 1)
+	assert.Equal(t, float64(17), frames[0].(map[string]interface{})["line"])
+
+	// Inspect the local variables, including the fields of the composite
+
+	scopes := client.request("scopes", scopesArguments{FrameID: 1})["scopes"].([]interface{})
+	require.Len(t, scopes, 2)
+
+	locals := scopes[0].(map[string]interface{})
+	assert.Equal(t, "Locals", locals["name"])
+
+	variables := client.request("variables", variablesArguments{
+		VariablesReference: int(locals["variablesReference"].(float64)),
+	})["variables"].([]interface{})
+	require.Len(t, variables, 2)
+
+	s := variables[0].(map[string]interface{})
+	assert.Equal(t, "s", s["name"])
+
+	y := variables[1].(map[string]interface{})
+	assert.Equal(t, "y", y["name"])
+	assert.Equal(t, "3", y["value"])
+	assert.Equal(t, "Int", y["type"])
+	assert.Equal(t, float64(0), y["variablesReference"])
+
+	fields := client.request("variables", variablesArguments{
+		VariablesReference: int(s["variablesReference"].(float64)),
+	})["variables"].([]interface{})
+	require.Len(t, fields, 1)
+
+	x := fields[0].(map[string]interface{})
+	assert.Equal(t, "x", x["name"])
+	assert.Equal(t, "1", x["value"])
+
+	result := client.request("evaluate", evaluateArguments{
+		Expression: "y + s.x",
+		FrameID:    1,
+	})
+	assert.Equal(t, "4", result["result"])
+
+	// Expressions which fail to parse or check result in an unsuccessful response
+
+	for _, expression := range []string{"y +", "unknown"} {
+		response := client.response("evaluate", evaluateArguments{
+			Expression: expression,
+			FrameID:    1,
+		})
+		assert.Equal(t, false, response["success"])
+		assert.NotEmpty(t, response["message"])
+	}
+
+	// Continue until the program terminates
+
+	client.request("continue", nil)
+
+	output := client.event("output")
+	assert.Equal(t, "stdout", output["category"])
+	assert.Equal(t, "3\n", output["output"])
+
+	exited := client.event("exited")
+	assert.Equal(t, float64(0), exited["exitCode"])
+
+	client.event("terminated")
+
+	client.request("disconnect", nil)
+}
+
+func TestServerInvalidProgram(t *testing.T) {
+
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "program.cdc")
+	err := ioutil.WriteFile(path, []byte(`pub fun main() { let x: Int = true }`), 0644)
+	require.NoError(t, err)
+
+	client := newTestClient(t)
+
+	client.request("initialize", nil)
+
+	err = writeMessage(client.writer, request{
+		Seq:       2,
+		Type:      "request",
+		Command:   "launch",
+		Arguments: mustMarshal(t, launchArguments{Program: path}),
+	})
+	require.NoError(t, err)
+
+	response := client.next(func(message map[string]interface{}) bool {
+		return message["type"] == "response" &&
+			message["command"] == "launch"
+	})
+	assert.Equal(t, false, response["success"])
+	assert.Contains(t, response["message"], "mismatched types")
+}
+
+func TestValueTypeString(t *testing.T) {
+
+	t.Parallel()
+
+	link := interpreter.LinkValue{
+		TargetPath: interpreter.PathValue{
+			Domain:     common.PathDomainStorage,
+			Identifier: "foo",
+		},
+		Type: interpreter.PrimitiveStaticTypeInt,
+	}
+
+	assert.Equal(t, "interpreter.LinkValue", valueTypeString(link))
+	assert.Equal(t, "*interpreter.SomeValue", valueTypeString(interpreter.NewSomeValueNonCopying(link)))
+	assert.Equal(t, "Int", valueTypeString(interpreter.NewIntValueFromInt64(1)))
+}
@@ -21,12 +21,15 @@ package execute
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
 	"github.com/c-bata/go-prompt"
 
+	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
 )
 
 const commandShortHelp = "h"
@@ -35,6 +38,15 @@ const commandShortContinue = "c"
 const commandLongContinue = "continue"
 const commandShortNext = "n"
 const commandLongNext = "next"
+const commandShortStepIn = "i"
+const commandLongStepIn = "in"
+const commandShortStepOver = "o"
+const commandLongStepOver = "over"
+const commandLongStepOut = "out"
+const commandShortBreak = "b"
+const commandLongBreak = "break"
+const commandLongDelete = "delete"
+const commandLongStack = "stack"
 const commandLongExit = "exit"
 const commandShortShow = "s"
 const commandLongShow = "show"
@@ -44,6 +56,12 @@ const commandLongWhere = "where"
 var debuggerCommandSuggestions = []prompt.Suggest{
 	{Text: commandLongContinue, Description: "Continue"},
 	{Text: commandLongNext, Description: "Next / step"},
+	{Text: commandLongStepIn, Description: "Step into the invoked function"},
+	{Text: commandLongStepOver, Description: "Step over the invoked function"},
+	{Text: commandLongStepOut, Description: "Step out of the current function"},
+	{Text: commandLongBreak, Description: "Set breakpoint at line, with optional condition"},
+	{Text: commandLongDelete, Description: "Delete breakpoint"},
+	{Text: commandLongStack, Description: "Show call stack"},
 	{Text: commandLongWhere, Description: "Location info"},
 	{Text: commandLongShow, Description: "Show variable(s)"},
 	{Text: commandLongExit, Description: "Exit"},
@@ -70,6 +88,80 @@ func (d *InteractiveDebugger) Next() {
 	d.stop = d.debugger.Next()
 }
 
+func (d *InteractiveDebugger) StepIn() {
+	d.stop = d.debugger.StepIn()
+}
+
+func (d *InteractiveDebugger) StepOver() {
+	d.stop = d.debugger.StepOver()
+}
+
+func (d *InteractiveDebugger) StepOut() {
+	d.stop = d.debugger.StepOut()
+}
+
+// Break sets a breakpoint at the given line of the current program.
+// The remaining arguments are the optional condition of the breakpoint
+//
+func (d *InteractiveDebugger) Break(arguments []string) {
+	if len(arguments) < 1 {
+		fmt.Println(colorizeError("error: missing line"))
+		return
+	}
+
+	line, err := strconv.Atoi(arguments[0])
+	if err != nil {
+		fmt.Println(colorizeError(fmt.Sprintf("error: invalid line '%s'", arguments[0])))
+		return
+	}
+
+	var condition ast.Expression
+
+	code := strings.Join(arguments[1:], " ")
+	if code != "" {
+		var errs []error
+		condition, errs = parser2.ParseExpression(code, nil)
+		if len(errs) > 0 {
+			err := parser2.Error{
+				Code:   code,
+				Errors: errs,
+			}
+			fmt.Println(colorizeError(fmt.Sprintf("error: invalid condition: %s", err)))
+			return
+		}
+	}
+
+	breakpoint := d.debugger.AddBreakpoint(d.stop.Interpreter.Location, line, condition)
+
+	fmt.Printf("breakpoint %d @ %d\n", breakpoint.ID, breakpoint.Line)
+}
+
+// Delete deletes the breakpoint with the given ID
+//
+func (d *InteractiveDebugger) Delete(arguments []string) {
+	if len(arguments) < 1 {
+		fmt.Println(colorizeError("error: missing breakpoint"))
+		return
+	}
+
+	id, err := strconv.Atoi(arguments[0])
+	if err != nil || !d.debugger.RemoveBreakpoint(id) {
+		fmt.Println(colorizeError(fmt.Sprintf("error: unknown breakpoint '%s'", arguments[0])))
+	}
+}
+
+// Stack shows the call stack, starting with the current function
+//
+func (d *InteractiveDebugger) Stack() {
+	for _, frame := range d.debugger.CallStack() {
+		line := 0
+		if frame.Statement != nil {
+			line = frame.Statement.StartPosition().Line
+		}
+		fmt.Printf("%s @ %d\n", frame.Location(), line)
+	}
+}
+
 // Show shows the values for the variables with the given names.
 // If no names are given, lists all non-base variables
 //
@@ -123,6 +215,18 @@ func (d *InteractiveDebugger) Run() {
 			d.Continue()
 		case commandShortNext, commandLongNext:
 			d.Next()
+		case commandShortStepIn, commandLongStepIn:
+			d.StepIn()
+		case commandShortStepOver, commandLongStepOver:
+			d.StepOver()
+		case commandLongStepOut:
+			d.StepOut()
+		case commandShortBreak, commandLongBreak:
+			d.Break(arguments)
+		case commandLongDelete:
+			d.Delete(arguments)
+		case commandLongStack:
+			d.Stack()
 		case commandShortShow, commandLongShow:
 			d.Show(arguments)
 		case commandShortWhere, commandLongWhere:
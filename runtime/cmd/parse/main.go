@@ -175,7 +175,7 @@ func runPath(path string, bench bool) (res result, succeeded bool) {
 			}
 		}()
 
-		program, err = parser2.ParseProgram(code)
+		program, err = parser2.ParseProgram(code, nil)
 		if !bench {
 			res.Program = program
 		}
@@ -189,7 +189,7 @@ func runPath(path string, bench bool) (res result, succeeded bool) {
 
 	if bench {
 		benchRes := benchParse(func() (err error) {
-			_, err = parser2.ParseProgram(code)
+			_, err = parser2.ParseProgram(code, nil)
 			return
 		})
 		res.Bench = &benchResult{
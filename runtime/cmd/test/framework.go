@@ -0,0 +1,266 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/stdlib"
+)
+
+// testFramework is the host of the Test contract for the tests of one file.
+// Paths of files are relative to the directory of the test file.
+//
+type testFramework struct {
+	basePath       string
+	coverageReport *runtime.CoverageReport
+}
+
+var _ stdlib.TestFramework = testFramework{}
+
+func (f testFramework) NewEmulatorBackend() stdlib.TestBlockchainBackend {
+	return newEmulatorBackend(f.coverageReport)
+}
+
+func (f testFramework) ReadFile(path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(f.basePath, path)
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+// emulatorBackend is a blockchain backed by the runtime
+// and an in-memory implementation of the runtime interface.
+//
+type emulatorBackend struct {
+	runtime          runtime.Runtime
+	runtimeInterface *runtime.InMemoryInterface
+	scriptCount      uint64
+	transactionCount uint64
+}
+
+var _ stdlib.TestBlockchainBackend = &emulatorBackend{}
+
+func newEmulatorBackend(coverageReport *runtime.CoverageReport) *emulatorBackend {
+	rt := runtime.NewInterpreterRuntime()
+	if coverageReport != nil {
+		rt.SetCoverageReport(coverageReport)
+	}
+
+	return &emulatorBackend{
+		runtime:          rt,
+		runtimeInterface: runtime.NewInMemoryInterface(),
+	}
+}
+
+func (b *emulatorBackend) nextScriptLocation() common.ScriptLocation {
+	b.scriptCount++
+	location := make(common.ScriptLocation, 8)
+	binary.BigEndian.PutUint64(location, b.scriptCount)
+	return location
+}
+
+func (b *emulatorBackend) nextTransactionLocation() common.TransactionLocation {
+	b.transactionCount++
+	location := make(common.TransactionLocation, 8)
+	binary.BigEndian.PutUint64(location, b.transactionCount)
+	return location
+}
+
+func (b *emulatorBackend) CreateAccount() (common.Address, error) {
+	return b.runtimeInterface.CreateAccount(common.Address{})
+}
+
+func (b *emulatorBackend) ExecuteScript(
+	inter *interpreter.Interpreter,
+	script string,
+	arguments []interpreter.Value,
+) (interpreter.Value, error) {
+
+	encodedArguments, err := encodeArguments(inter, arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := b.runtime.ExecuteScript(
+		runtime.Script{
+			Source:    []byte(script),
+			Arguments: encodedArguments,
+		},
+		runtime.Context{
+			Interface: b.runtimeInterface,
+			Location:  b.nextScriptLocation(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return runtime.ImportValue(inter, result, nil)
+}
+
+func (b *emulatorBackend) ExecuteTransaction(
+	inter *interpreter.Interpreter,
+	transaction string,
+	signers []common.Address,
+	arguments []interpreter.Value,
+) error {
+
+	encodedArguments, err := encodeArguments(inter, arguments)
+	if err != nil {
+		return err
+	}
+
+	b.runtimeInterface.SetSigningAccounts(signers...)
+
+	return b.runtime.ExecuteTransaction(
+		runtime.Script{
+			Source:    []byte(transaction),
+			Arguments: encodedArguments,
+		},
+		runtime.Context{
+			Interface: b.runtimeInterface,
+			Location:  b.nextTransactionLocation(),
+		},
+	)
+}
+
+func (b *emulatorBackend) DeployContract(
+	inter *interpreter.Interpreter,
+	name string,
+	code string,
+	account common.Address,
+	arguments []interpreter.Value,
+) error {
+
+	// Deploy the contract using a transaction,
+	// which passes the arguments to the initializer of the contract
+
+	parameters := make([]string, len(arguments))
+	argumentNames := make([]string, len(arguments))
+
+	for i, argument := range arguments {
+		argumentType, err := inter.ConvertStaticToSemaType(argument.StaticType())
+		if err != nil {
+			return err
+		}
+
+		argumentNames[i] = fmt.Sprintf("arg%d", i)
+		parameters[i] = fmt.Sprintf("%s: %s", argumentNames[i], argumentType.QualifiedString())
+	}
+
+	var initializerArguments string
+	if len(argumentNames) > 0 {
+		initializerArguments = ", " + strings.Join(argumentNames, ", ")
+	}
+
+	transaction := fmt.Sprintf(
+		`
+          transaction(%s) {
+              prepare(signer: AuthAccount) {
+                  signer.contracts.add(name: "%s", code: "%s".decodeHex()%s)
+              }
+          }
+        `,
+		strings.Join(parameters, ", "),
+		name,
+		hex.EncodeToString([]byte(code)),
+		initializerArguments,
+	)
+
+	return b.ExecuteTransaction(
+		inter,
+		transaction,
+		[]common.Address{account},
+		arguments,
+	)
+}
+
+// Events returns the events emitted so far.
+//
+// Field values which cannot be imported into the test program,
+// e.g. values of composite types which are not known to the test program,
+// are represented by their string representation.
+//
+func (b *emulatorBackend) Events(inter *interpreter.Interpreter) ([]stdlib.TestEvent, error) {
+	events := b.runtimeInterface.Events()
+
+	testEvents := make([]stdlib.TestEvent, len(events))
+
+	for i, event := range events {
+		fields := make([]stdlib.TestEventField, len(event.Fields))
+
+		for j, fieldValue := range event.Fields {
+			value, err := runtime.ImportValue(inter, fieldValue, nil)
+			if err != nil {
+				value = interpreter.NewUnmeteredStringValue(fieldValue.String())
+			}
+
+			fields[j] = stdlib.TestEventField{
+				Name:  event.EventType.Fields[j].Identifier,
+				Value: value,
+			}
+		}
+
+		testEvents[i] = stdlib.TestEvent{
+			TypeID: event.EventType.ID(),
+			Fields: fields,
+		}
+	}
+
+	return testEvents, nil
+}
+
+func (b *emulatorBackend) CommitBlock() {
+	b.runtimeInterface.CommitBlock(time.Now())
+}
+
+func encodeArguments(inter *interpreter.Interpreter, arguments []interpreter.Value) ([][]byte, error) {
+	encodedArguments := make([][]byte, len(arguments))
+
+	for i, argument := range arguments {
+		exportedValue, err := runtime.ExportValue(argument, inter)
+		if err != nil {
+			return nil, err
+		}
+
+		encodedArguments[i], err = jsoncdc.Encode(exportedValue)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return encodedArguments, nil
+}
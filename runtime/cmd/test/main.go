@@ -0,0 +1,174 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// The test command runs the tests in the given Cadence files.
+//
+// Tests are the global functions without parameters whose name starts with `test`.
+// Test files import the Test contract, which provides assertions,
+// and a blockchain to deploy the contracts under test to,
+// and to execute scripts and transactions against.
+//
+// Usage:
+//
+//   test [-run regexp] [-coverprofile file] [-coverformat json|lcov|cobertura] files...
+//
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/pretty"
+)
+
+var runFlag = flag.String("run", "", "only run the tests whose name matches the regular expression")
+var coverProfileFlag = flag.String("coverprofile", "", "write the coverage report to the file")
+var coverFormatFlag = flag.String("coverformat", "json", "the format of the coverage report: json, lcov, or cobertura")
+
+func main() {
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, pretty.FormatErrorMessage("no input files", true))
+		os.Exit(2)
+	}
+
+	var filter *regexp.Regexp
+	if *runFlag != "" {
+		var err error
+		filter, err = regexp.Compile(*runFlag)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, pretty.FormatErrorMessage(err.Error(), true))
+			os.Exit(2)
+		}
+	}
+
+	var coverageReport *runtime.CoverageReport
+	if *coverProfileFlag != "" {
+		switch *coverFormatFlag {
+		case "json", "lcov", "cobertura":
+			break
+		default:
+			message := fmt.Sprintf("unsupported coverage format: %s", *coverFormatFlag)
+			fmt.Fprintln(os.Stderr, pretty.FormatErrorMessage(message, true))
+			os.Exit(2)
+		}
+
+		coverageReport = runtime.NewCoverageReport()
+	}
+
+	passed := true
+
+	for _, path := range args {
+		if !run(os.Stdout, path, filter, coverageReport) {
+			passed = false
+		}
+	}
+
+	if coverageReport != nil {
+		err := writeCoverageReport(*coverProfileFlag, *coverFormatFlag, coverageReport)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, pretty.FormatErrorMessage(err.Error(), true))
+			os.Exit(2)
+		}
+
+		fmt.Println(coverageReport)
+	}
+
+	if !passed {
+		os.Exit(1)
+	}
+}
+
+// run runs the tests in the file at the given path, and writes the results to the writer.
+// It returns true if all tests passed.
+//
+func run(
+	writer io.Writer,
+	path string,
+	filter *regexp.Regexp,
+	coverageReport *runtime.CoverageReport,
+) bool {
+
+	_, _ = fmt.Fprintf(writer, "Test results: %q\n", path)
+
+	file, err := prepareTestFile(path, coverageReport)
+	if err != nil {
+		_, _ = fmt.Fprintln(writer, "- ERROR: failed to prepare test file")
+		if file == nil {
+			_, _ = fmt.Fprintf(writer, "\t\t%s\n", err)
+			return false
+		}
+		printErr := pretty.NewErrorPrettyPrinter(writer, false).
+			PrettyPrintError(err, file.location, file.codes)
+		if printErr != nil {
+			_, _ = fmt.Fprintf(writer, "\t\t%s\n", err)
+		}
+		return false
+	}
+
+	passed := true
+
+	for _, result := range runTests(file, filter) {
+		if result.err == nil {
+			_, _ = fmt.Fprintf(writer, "- PASS: %s\n", result.name)
+			continue
+		}
+
+		passed = false
+		_, _ = fmt.Fprintf(writer, "- FAIL: %s\n", result.name)
+		_, _ = fmt.Fprintf(writer, "\t\t%s\n", result.err)
+	}
+
+	return passed
+}
+
+func writeCoverageReport(path string, format string, coverageReport *runtime.CoverageReport) error {
+	var buffer bytes.Buffer
+
+	switch format {
+	case "lcov":
+		err := coverageReport.WriteLCOV(&buffer)
+		if err != nil {
+			return err
+		}
+
+	case "cobertura":
+		err := coverageReport.WriteCobertura(&buffer)
+		if err != nil {
+			return err
+		}
+
+	default:
+		encoded, err := json.MarshalIndent(coverageReport, "", "  ")
+		if err != nil {
+			return err
+		}
+		buffer.Write(encoded)
+	}
+
+	return ioutil.WriteFile(path, buffer.Bytes(), 0644)
+}
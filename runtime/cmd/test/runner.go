@@ -0,0 +1,258 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+	"github.com/onflow/cadence/runtime/stdlib/contracts"
+)
+
+const testFunctionPrefix = "test"
+const setupFunctionName = "setup"
+
+// testResult is the result of a test function.
+// The error is nil if the test passed.
+//
+type testResult struct {
+	name string
+	err  error
+}
+
+// testFile is a checked and interpreted test file.
+//
+type testFile struct {
+	location    common.StringLocation
+	codes       map[common.LocationID]string
+	program     *ast.Program
+	interpreter *interpreter.Interpreter
+}
+
+// prepareTestFile parses, checks, and interprets the test file at the given path.
+//
+// Test files may only import the Test contract.
+// Contracts under test are deployed to a blockchain, e.g. after reading them with `Test.readFile`.
+//
+func prepareTestFile(path string, coverageReport *runtime.CoverageReport) (*testFile, error) {
+
+	location := common.StringLocation(path)
+
+	codes := map[common.LocationID]string{}
+
+	code, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	codes[location.ID()] = string(code)
+	codes[stdlib.TestContractLocation.ID()] = contracts.Test
+
+	result := &testFile{
+		location: location,
+		codes:    codes,
+	}
+
+	program, err := parser2.ParseProgram(string(code), nil)
+	if err != nil {
+		return result, err
+	}
+	result.program = program
+
+	checker, err := sema.NewChecker(
+		program,
+		location,
+		sema.WithPredeclaredValues(stdlib.BuiltinFunctions.ToSemaValueDeclarations()),
+		sema.WithPredeclaredTypes(stdlib.BuiltinTypes.ToTypeDeclarations()),
+		sema.WithImportHandler(
+			func(_ *sema.Checker, importedLocation common.Location, _ ast.Range) (sema.Import, error) {
+				if importedLocation != stdlib.TestContractLocation {
+					return nil, fmt.Errorf(
+						"cannot import `%s`: test files may only import `%s`",
+						importedLocation,
+						stdlib.TestContractLocation,
+					)
+				}
+
+				return sema.ElaborationImport{
+					Elaboration: stdlib.TestChecker.Elaboration,
+				}, nil
+			},
+		),
+	)
+	if err != nil {
+		return result, err
+	}
+
+	err = checker.Check()
+	if err != nil {
+		return result, err
+	}
+
+	framework := testFramework{
+		basePath:       filepath.Dir(path),
+		coverageReport: coverageReport,
+	}
+
+	var uuid uint64
+
+	inter, err := interpreter.NewInterpreter(
+		interpreter.ProgramFromChecker(checker),
+		location,
+		interpreter.WithStorage(interpreter.NewInMemoryStorage()),
+		interpreter.WithPredeclaredValues(stdlib.BuiltinFunctions.ToInterpreterValueDeclarations()),
+		interpreter.WithUUIDHandler(func() (uint64, error) {
+			uuid++
+			return uuid, nil
+		}),
+		interpreter.WithImportLocationHandler(
+			func(inter *interpreter.Interpreter, location common.Location) interpreter.Import {
+				program := interpreter.ProgramFromChecker(stdlib.TestChecker)
+				subInterpreter, err := inter.NewSubInterpreter(program, location)
+				if err != nil {
+					panic(err)
+				}
+
+				return interpreter.InterpreterImport{
+					Interpreter: subInterpreter,
+				}
+			},
+		),
+		interpreter.WithContractValueHandler(
+			func(
+				inter *interpreter.Interpreter,
+				compositeType *sema.CompositeType,
+				constructorGenerator func(common.Address) *interpreter.HostFunctionValue,
+				invocationRange ast.Range,
+			) *interpreter.CompositeValue {
+
+				constructor := constructorGenerator(common.Address{})
+
+				if compositeType.Location == stdlib.TestContractLocation {
+					contract, err := stdlib.NewTestContract(
+						inter,
+						framework,
+						constructor,
+						invocationRange,
+					)
+					if err != nil {
+						panic(err)
+					}
+					return contract
+				}
+
+				value, err := inter.InvokeFunctionValue(
+					constructor,
+					nil,
+					nil,
+					nil,
+					invocationRange,
+				)
+				if err != nil {
+					panic(err)
+				}
+
+				return value.(*interpreter.CompositeValue)
+			},
+		),
+	)
+	if err != nil {
+		return result, err
+	}
+	result.interpreter = inter
+
+	err = inter.Interpret()
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// testFunctionNames returns the names of the test functions of the program, in declaration order:
+// all global functions which have no parameters and whose name starts with `test`,
+// and which match the given filter, if any.
+//
+func testFunctionNames(program *ast.Program, filter *regexp.Regexp) []string {
+	var names []string
+
+	for _, declaration := range program.FunctionDeclarations() {
+		name := declaration.Identifier.Identifier
+
+		if !strings.HasPrefix(name, testFunctionPrefix) ||
+			len(declaration.ParameterList.Parameters) > 0 {
+
+			continue
+		}
+
+		if filter != nil && !filter.MatchString(name) {
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// runTests runs the test functions of the given test file.
+//
+// If the file declares a `setup` function, it is called once, before all tests.
+// If the setup fails, all tests fail with the error of the setup.
+//
+func runTests(file *testFile, filter *regexp.Regexp) []testResult {
+
+	names := testFunctionNames(file.program, filter)
+
+	results := make([]testResult, 0, len(names))
+
+	inter := file.interpreter
+
+	if inter.Globals.Contains(setupFunctionName) {
+		_, err := inter.Invoke(setupFunctionName)
+		if err != nil {
+			for _, name := range names {
+				results = append(results, testResult{
+					name: name,
+					err:  fmt.Errorf("setup failed: %w", err),
+				})
+			}
+			return results
+		}
+	}
+
+	for _, name := range names {
+		_, err := inter.Invoke(name)
+		results = append(results, testResult{
+			name: name,
+			err:  err,
+		})
+	}
+
+	return results
+}
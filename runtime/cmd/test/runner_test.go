@@ -0,0 +1,197 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/stdlib"
+)
+
+const testCounterContract = `
+  pub contract Counter {
+
+      pub event Incremented(count: Int)
+
+      pub var count: Int
+
+      init(initial: Int) {
+          self.count = initial
+      }
+
+      pub fun increment() {
+          self.count = self.count + 1
+          emit Incremented(count: self.count)
+      }
+  }
+`
+
+const testCounterTests = `
+  import Test
+
+  pub let blockchain = Test.newEmulatorBlockchain()
+  pub let account = blockchain.createAccount()
+
+  pub fun setup() {
+      let err = blockchain.deployContract(
+          name: "Counter",
+          code: Test.readFile("Counter.cdc"),
+          account: account,
+          arguments: [1]
+      )
+      Test.assert(err == nil)
+  }
+
+  pub fun testIncrement() {
+      let result = blockchain.executeTransaction(
+          Test.Transaction(
+              code: "import Counter from 0x1\n transaction { prepare(signer: AuthAccount) { Counter.increment() } }",
+              signers: [account],
+              arguments: []
+          )
+      )
+      Test.assertEqual(Test.ResultStatus.succeeded, result.status)
+
+      let scriptResult = blockchain.executeScript(
+          "import Counter from 0x1\n pub fun main(): Int { return Counter.count }",
+          []
+      )
+      Test.assertEqual(Test.ResultStatus.succeeded, scriptResult.status)
+      Test.assertEqual(2, scriptResult.returnValue!)
+
+      blockchain.assertEmitted("A.0000000000000001.Counter.Incremented")
+
+      let events = blockchain.eventsOfType("A.0000000000000001.Counter.Incremented")
+      Test.assertEqual(1, events.length)
+      Test.assertEqual(2, events[0].fields["count"]!)
+  }
+
+  pub fun testScriptFailure() {
+      let result = blockchain.executeScript(
+          "pub fun main(x: Int) { panic(\"oops\") }",
+          [42]
+      )
+      Test.assertEqual(Test.ResultStatus.failed, result.status)
+      Test.assert(result.returnValue == nil)
+  }
+
+  pub fun testExpectFailure() {
+      Test.expectFailure(
+          fun () {
+              Test.assertEqual(1, 2)
+          },
+          errorMessageSubstring: "not equal"
+      )
+  }
+
+  pub fun testFailure() {
+      Test.fail(message: "boom")
+  }
+
+  pub fun helper(x: Int) {}
+`
+
+func writeTestFiles(t *testing.T) string {
+	dir := t.TempDir()
+
+	err := ioutil.WriteFile(filepath.Join(dir, "Counter.cdc"), []byte(testCounterContract), 0644)
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, "counter_test.cdc")
+	err = ioutil.WriteFile(path, []byte(testCounterTests), 0644)
+	require.NoError(t, err)
+
+	return path
+}
+
+func TestRunTests(t *testing.T) {
+
+	t.Parallel()
+
+	path := writeTestFiles(t)
+
+	coverageReport := runtime.NewCoverageReport()
+
+	file, err := prepareTestFile(path, coverageReport)
+	require.NoError(t, err)
+
+	results := runTests(file, nil)
+	require.Len(t, results, 4)
+
+	assert.Equal(t, "testIncrement", results[0].name)
+	assert.NoError(t, results[0].err)
+
+	assert.Equal(t, "testScriptFailure", results[1].name)
+	assert.NoError(t, results[1].err)
+
+	assert.Equal(t, "testExpectFailure", results[2].name)
+	assert.NoError(t, results[2].err)
+
+	assert.Equal(t, "testFailure", results[3].name)
+	var assertionErr stdlib.AssertionError
+	require.ErrorAs(t, results[3].err, &assertionErr)
+	assert.Equal(t, "boom", assertionErr.Message)
+
+	counterLocation := common.AddressLocation{
+		Address: common.Address{0, 0, 0, 0, 0, 0, 0, 1},
+		Name:    "Counter",
+	}
+	assert.Contains(t, coverageReport.Coverage, counterLocation.ID())
+}
+
+func TestRunTestsFilter(t *testing.T) {
+
+	t.Parallel()
+
+	path := writeTestFiles(t)
+
+	var output bytes.Buffer
+	passed := run(&output, path, regexp.MustCompile("Increment|Expect"), nil)
+	assert.True(t, passed)
+
+	assert.Equal(t,
+		"Test results: \""+path+"\"\n"+
+			"- PASS: testIncrement\n"+
+			"- PASS: testExpectFailure\n",
+		output.String(),
+	)
+}
+
+func TestRunTestsInvalidImport(t *testing.T) {
+
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "invalid_test.cdc")
+
+	err := ioutil.WriteFile(path, []byte(`import Foo from "Foo.cdc"`), 0644)
+	require.NoError(t, err)
+
+	_, err = prepareTestFile(path, nil)
+	require.Error(t, err)
+}
@@ -84,9 +84,24 @@ const (
 	_
 	_
 	_
+	// interpreter string operations
+	ComputationKindStringSplit
+	ComputationKindStringJoin
+	ComputationKindStringReplaceAll
+	ComputationKindStringContains
+	ComputationKindStringIndex
+	ComputationKindStringToUpper
+	ComputationKindStringTrim
+	ComputationKindStringConcat
+	ComputationKindStringSlice
+	ComputationKindStringToLower
+	ComputationKindStringDecodeHex
+	ComputationKindStringEncodeHex
 	_
 	_
 	_
+	// interpreter encoding operations
+	ComputationKindToBigEndianBytes
 	_
 	_
 	_
@@ -101,21 +116,9 @@ const (
 	_
 	_
 	_
-	_
-	_
-	_
-	_
-	_
-	_
-	_
-	_
-	_
-	_
-	_
-	_
-	_
-	_
-	_
+	// storage operations
+	ComputationKindStorageRead
+	ComputationKindStorageWrite
 	_
 	_
 	_
@@ -134,8 +137,8 @@ const (
 	ComputationKindSTDLIBPanic
 	ComputationKindSTDLIBAssert
 	ComputationKindSTDLIBUnsafeRandom
-	_
-	_
+	ComputationKindSTDLIBHash
+	ComputationKindSTDLIBVerifySignature
 	_
 	_
 	_
@@ -21,9 +21,26 @@ func _() {
 	_ = x[ComputationKindCreateDictionaryValue-1040]
 	_ = x[ComputationKindTransferDictionaryValue-1041]
 	_ = x[ComputationKindDestroyDictionaryValue-1042]
+	_ = x[ComputationKindStringSplit-1055]
+	_ = x[ComputationKindStringJoin-1056]
+	_ = x[ComputationKindStringReplaceAll-1057]
+	_ = x[ComputationKindStringContains-1058]
+	_ = x[ComputationKindStringIndex-1059]
+	_ = x[ComputationKindStringToUpper-1060]
+	_ = x[ComputationKindStringTrim-1061]
+	_ = x[ComputationKindStringConcat-1062]
+	_ = x[ComputationKindStringSlice-1063]
+	_ = x[ComputationKindStringToLower-1064]
+	_ = x[ComputationKindStringDecodeHex-1065]
+	_ = x[ComputationKindStringEncodeHex-1066]
+	_ = x[ComputationKindToBigEndianBytes-1070]
+	_ = x[ComputationKindStorageRead-1085]
+	_ = x[ComputationKindStorageWrite-1086]
 	_ = x[ComputationKindSTDLIBPanic-1100]
 	_ = x[ComputationKindSTDLIBAssert-1101]
 	_ = x[ComputationKindSTDLIBUnsafeRandom-1102]
+	_ = x[ComputationKindSTDLIBHash-1103]
+	_ = x[ComputationKindSTDLIBVerifySignature-1104]
 	_ = x[ComputationKindSTDLIBRLPDecodeString-1108]
 	_ = x[ComputationKindSTDLIBRLPDecodeList-1109]
 }
@@ -34,8 +51,11 @@ const (
 	_ComputationKind_name_2 = "CreateCompositeValueTransferCompositeValueDestroyCompositeValue"
 	_ComputationKind_name_3 = "CreateArrayValueTransferArrayValueDestroyArrayValue"
 	_ComputationKind_name_4 = "CreateDictionaryValueTransferDictionaryValueDestroyDictionaryValue"
-	_ComputationKind_name_5 = "STDLIBPanicSTDLIBAssertSTDLIBUnsafeRandom"
-	_ComputationKind_name_6 = "STDLIBRLPDecodeStringSTDLIBRLPDecodeList"
+	_ComputationKind_name_5 = "StringSplitStringJoinStringReplaceAllStringContainsStringIndexStringToUpperStringTrimStringConcatStringSliceStringToLowerStringDecodeHexStringEncodeHex"
+	_ComputationKind_name_6 = "ToBigEndianBytes"
+	_ComputationKind_name_7 = "StorageReadStorageWrite"
+	_ComputationKind_name_8 = "STDLIBPanicSTDLIBAssertSTDLIBUnsafeRandomSTDLIBHashSTDLIBVerifySignature"
+	_ComputationKind_name_9 = "STDLIBRLPDecodeStringSTDLIBRLPDecodeList"
 )
 
 var (
@@ -43,8 +63,10 @@ var (
 	_ComputationKind_index_2 = [...]uint8{0, 20, 42, 63}
 	_ComputationKind_index_3 = [...]uint8{0, 16, 34, 51}
 	_ComputationKind_index_4 = [...]uint8{0, 21, 44, 66}
-	_ComputationKind_index_5 = [...]uint8{0, 11, 23, 41}
-	_ComputationKind_index_6 = [...]uint8{0, 21, 40}
+	_ComputationKind_index_5 = [...]uint8{0, 11, 21, 37, 51, 62, 75, 85, 97, 108, 121, 136, 151}
+	_ComputationKind_index_7 = [...]uint8{0, 11, 23}
+	_ComputationKind_index_8 = [...]uint8{0, 11, 23, 41, 51, 72}
+	_ComputationKind_index_9 = [...]uint8{0, 21, 40}
 )
 
 func (i ComputationKind) String() string {
@@ -63,12 +85,20 @@ func (i ComputationKind) String() string {
 	case 1040 <= i && i <= 1042:
 		i -= 1040
 		return _ComputationKind_name_4[_ComputationKind_index_4[i]:_ComputationKind_index_4[i+1]]
-	case 1100 <= i && i <= 1102:
-		i -= 1100
+	case 1055 <= i && i <= 1066:
+		i -= 1055
 		return _ComputationKind_name_5[_ComputationKind_index_5[i]:_ComputationKind_index_5[i+1]]
+	case i == 1070:
+		return _ComputationKind_name_6
+	case 1085 <= i && i <= 1086:
+		i -= 1085
+		return _ComputationKind_name_7[_ComputationKind_index_7[i]:_ComputationKind_index_7[i+1]]
+	case 1100 <= i && i <= 1104:
+		i -= 1100
+		return _ComputationKind_name_8[_ComputationKind_index_8[i]:_ComputationKind_index_8[i+1]]
 	case 1108 <= i && i <= 1109:
 		i -= 1108
-		return _ComputationKind_name_6[_ComputationKind_index_6[i]:_ComputationKind_index_6[i+1]]
+		return _ComputationKind_name_9[_ComputationKind_index_9[i]:_ComputationKind_index_9[i+1]]
 	default:
 		return "ComputationKind(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
@@ -56,6 +56,8 @@ const (
 	DeclarationKindPragma
 	DeclarationKindEnum
 	DeclarationKindEnumCase
+	DeclarationKindTypeAlias
+	DeclarationKindAttachment
 )
 
 func DeclarationKindCount() int {
@@ -72,7 +74,9 @@ func (k DeclarationKind) IsTypeDeclaration() bool {
 		DeclarationKindResourceInterface,
 		DeclarationKindContractInterface,
 		DeclarationKindTypeParameter,
-		DeclarationKindEnum:
+		DeclarationKindEnum,
+		DeclarationKindTypeAlias,
+		DeclarationKindAttachment:
 
 		return true
 
@@ -135,6 +139,10 @@ func (k DeclarationKind) Name() string {
 		return "enum"
 	case DeclarationKindEnumCase:
 		return "enum case"
+	case DeclarationKindTypeAlias:
+		return "type alias"
+	case DeclarationKindAttachment:
+		return "attachment"
 	case DeclarationKindUnknown:
 		return "unknown"
 	}
@@ -182,6 +190,10 @@ func (k DeclarationKind) Keywords() string {
 		return "enum"
 	case DeclarationKindEnumCase:
 		return "case"
+	case DeclarationKindTypeAlias:
+		return "typealias"
+	case DeclarationKindAttachment:
+		return "attachment"
 	default:
 		return ""
 	}
@@ -0,0 +1,372 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/onflow/cadence/runtime/errors"
+)
+
+// MemoryUsage is the amount of memory of a certain kind which is about to be used.
+//
+// For most kinds the amount is the number of allocated objects,
+// for kinds with a variable size (e.g. strings and big integers),
+// the amount is the number of bytes
+//
+type MemoryUsage struct {
+	Kind   MemoryKind
+	Amount uint64
+}
+
+// MemoryGauge is notified about memory which is about to be used.
+//
+// It can be used to account for memory usage, e.g. to enforce a deterministic limit.
+// An error returned by MeterMemory aborts the current operation.
+//
+type MemoryGauge interface {
+	MeterMemory(usage MemoryUsage) error
+}
+
+// UseMemory reports the given memory usage to the given gauge, if any.
+// If the gauge rejects the usage, e.g. because a limit is exceeded,
+// UseMemory panics with an errors.MemoryError
+//
+func UseMemory(gauge MemoryGauge, usage MemoryUsage) {
+	if gauge == nil {
+		return
+	}
+
+	err := gauge.MeterMemory(usage)
+	if err != nil {
+		panic(errors.MemoryError{Err: err})
+	}
+}
+
+// NewConstantMemoryUsage returns the memory usage of a single object of the given kind
+//
+func NewConstantMemoryUsage(kind MemoryKind) MemoryUsage {
+	return MemoryUsage{
+		Kind:   kind,
+		Amount: 1,
+	}
+}
+
+var (
+	// Values
+
+	AddressValueMemoryUsage             = NewConstantMemoryUsage(MemoryKindAddressValue)
+	ArrayValueBaseMemoryUsage           = NewConstantMemoryUsage(MemoryKindArrayValueBase)
+	DictionaryValueBaseMemoryUsage      = NewConstantMemoryUsage(MemoryKindDictionaryValueBase)
+	CompositeValueBaseMemoryUsage       = NewConstantMemoryUsage(MemoryKindCompositeValueBase)
+	OptionalValueMemoryUsage            = NewConstantMemoryUsage(MemoryKindOptionalValue)
+	TypeValueMemoryUsage                = NewConstantMemoryUsage(MemoryKindTypeValue)
+	PathValueMemoryUsage                = NewConstantMemoryUsage(MemoryKindPathValue)
+	CapabilityValueMemoryUsage          = NewConstantMemoryUsage(MemoryKindCapabilityValue)
+	LinkValueMemoryUsage                = NewConstantMemoryUsage(MemoryKindLinkValue)
+	StorageReferenceValueMemoryUsage    = NewConstantMemoryUsage(MemoryKindStorageReferenceValue)
+	EphemeralReferenceValueMemoryUsage  = NewConstantMemoryUsage(MemoryKindEphemeralReferenceValue)
+	InterpretedFunctionValueMemoryUsage = NewConstantMemoryUsage(MemoryKindInterpretedFunctionValue)
+	BoundFunctionValueMemoryUsage       = NewConstantMemoryUsage(MemoryKindBoundFunctionValue)
+
+	// Lexer and parser
+
+	TokenMemoryUsage          = NewConstantMemoryUsage(MemoryKindToken)
+	IdentifierMemoryUsage     = NewConstantMemoryUsage(MemoryKindIdentifier)
+	ArgumentMemoryUsage       = NewConstantMemoryUsage(MemoryKindArgument)
+	TypeAnnotationMemoryUsage = NewConstantMemoryUsage(MemoryKindTypeAnnotation)
+	DeclarationMemoryUsage    = NewConstantMemoryUsage(MemoryKindDeclaration)
+	StatementMemoryUsage      = NewConstantMemoryUsage(MemoryKindStatement)
+	ExpressionMemoryUsage     = NewConstantMemoryUsage(MemoryKindExpression)
+	TypeMemoryUsage           = NewConstantMemoryUsage(MemoryKindType)
+	ProgramMemoryUsage        = NewConstantMemoryUsage(MemoryKindProgram)
+
+	// Checker
+
+	VariableMemoryUsage              = NewConstantMemoryUsage(MemoryKindVariable)
+	CompositeSemaTypeMemoryUsage     = NewConstantMemoryUsage(MemoryKindCompositeSemaType)
+	InterfaceSemaTypeMemoryUsage     = NewConstantMemoryUsage(MemoryKindInterfaceSemaType)
+	FunctionSemaTypeMemoryUsage      = NewConstantMemoryUsage(MemoryKindFunctionSemaType)
+	OptionalSemaTypeMemoryUsage      = NewConstantMemoryUsage(MemoryKindOptionalSemaType)
+	VariableSizedSemaTypeMemoryUsage = NewConstantMemoryUsage(MemoryKindVariableSizedSemaType)
+	ConstantSizedSemaTypeMemoryUsage = NewConstantMemoryUsage(MemoryKindConstantSizedSemaType)
+	DictionarySemaTypeMemoryUsage    = NewConstantMemoryUsage(MemoryKindDictionarySemaType)
+	ReferenceSemaTypeMemoryUsage     = NewConstantMemoryUsage(MemoryKindReferenceSemaType)
+	RestrictedSemaTypeMemoryUsage    = NewConstantMemoryUsage(MemoryKindRestrictedSemaType)
+)
+
+// NewStringMemoryUsage returns the memory usage of a string value
+// with the given length in bytes
+//
+func NewStringMemoryUsage(length int) MemoryUsage {
+	return MemoryUsage{
+		Kind: MemoryKindStringValue,
+		// +1 to account for empty strings
+		Amount: uint64(length) + 1,
+	}
+}
+
+// NewCharacterMemoryUsage returns the memory usage of a character value
+// with the given length in bytes
+//
+func NewCharacterMemoryUsage(length int) MemoryUsage {
+	return MemoryUsage{
+		Kind:   MemoryKindCharacterValue,
+		Amount: uint64(length),
+	}
+}
+
+// bigIntWordSize is the size of a big.Word in bytes
+//
+const bigIntWordSize = 8
+
+// NewBigIntMemoryUsage returns the memory usage of a big integer
+// with the given length in bytes
+//
+func NewBigIntMemoryUsage(bytes int) MemoryUsage {
+	// big.Int stores its magnitude in words,
+	// so round up to the next full word
+	words := (bytes + bigIntWordSize - 1) / bigIntWordSize
+	if words == 0 {
+		words = 1
+	}
+	return MemoryUsage{
+		Kind:   MemoryKindBigInt,
+		Amount: uint64(words * bigIntWordSize),
+	}
+}
+
+// bigIntByteLength returns the length of the magnitude of the given big integer in bytes
+//
+func bigIntByteLength(v *big.Int) int {
+	return (v.BitLen() + 7) / 8
+}
+
+func maxBigIntByteLength(a, b *big.Int) int {
+	aLength := bigIntByteLength(a)
+	bLength := bigIntByteLength(b)
+	if aLength > bLength {
+		return aLength
+	}
+	return bLength
+}
+
+// NewPlusBigIntMemoryUsage returns the memory usage of the sum of the given big integers.
+// The result may need one more byte for the carry
+//
+func NewPlusBigIntMemoryUsage(a, b *big.Int) MemoryUsage {
+	return NewBigIntMemoryUsage(maxBigIntByteLength(a, b) + 1)
+}
+
+// NewMinusBigIntMemoryUsage returns the memory usage of the difference of the given big integers.
+// The result may need one more byte for the borrow
+//
+func NewMinusBigIntMemoryUsage(a, b *big.Int) MemoryUsage {
+	return NewBigIntMemoryUsage(maxBigIntByteLength(a, b) + 1)
+}
+
+// NewMulBigIntMemoryUsage returns the memory usage of the product of the given big integers
+//
+func NewMulBigIntMemoryUsage(a, b *big.Int) MemoryUsage {
+	return NewBigIntMemoryUsage(bigIntByteLength(a) + bigIntByteLength(b))
+}
+
+// NewDivBigIntMemoryUsage returns the memory usage of the quotient of the given big integers
+//
+func NewDivBigIntMemoryUsage(a, _ *big.Int) MemoryUsage {
+	return NewBigIntMemoryUsage(bigIntByteLength(a))
+}
+
+// NewModBigIntMemoryUsage returns the memory usage of the remainder of the given big integers
+//
+func NewModBigIntMemoryUsage(_, b *big.Int) MemoryUsage {
+	return NewBigIntMemoryUsage(bigIntByteLength(b))
+}
+
+// NewBitwiseBigIntMemoryUsage returns the memory usage of
+// the bitwise or, xor, or and of the given big integers
+//
+func NewBitwiseBigIntMemoryUsage(a, b *big.Int) MemoryUsage {
+	return NewBigIntMemoryUsage(maxBigIntByteLength(a, b))
+}
+
+// maxBigIntShiftByteLength is the maximum length in bytes
+// reported for the result of a left shift.
+// Any larger shift exceeds any reasonable memory limit anyway
+//
+const maxBigIntShiftByteLength = math.MaxInt32
+
+// NewBitwiseLeftShiftBigIntMemoryUsage returns the memory usage of
+// the given big integer shifted left by the given number of bits
+//
+func NewBitwiseLeftShiftBigIntMemoryUsage(a *big.Int, shift uint64) MemoryUsage {
+	shiftLength := shift/8 + 1
+	if shiftLength > maxBigIntShiftByteLength {
+		shiftLength = maxBigIntShiftByteLength
+	}
+	return NewBigIntMemoryUsage(bigIntByteLength(a) + int(shiftLength))
+}
+
+// NewBitwiseRightShiftBigIntMemoryUsage returns the memory usage of
+// the given big integer shifted right
+//
+func NewBitwiseRightShiftBigIntMemoryUsage(a *big.Int) MemoryUsage {
+	return NewBigIntMemoryUsage(bigIntByteLength(a))
+}
+
+// NewNegateBigIntMemoryUsage returns the memory usage of the negation of the given big integer
+//
+func NewNegateBigIntMemoryUsage(a *big.Int) MemoryUsage {
+	return NewBigIntMemoryUsage(bigIntByteLength(a))
+}
+
+// atreeSlabTargetSize is the estimated size of an atree data slab in bytes
+//
+const atreeSlabTargetSize = 1024
+
+// atreeMetaDataSlabChildren is the estimated number of children of an atree meta data slab
+//
+const atreeMetaDataSlabChildren = 64
+
+// newAtreeMemoryUsages returns the estimated memory usage of an atree container
+// with the given number of elements, which have the given estimated size in bytes
+//
+func newAtreeMemoryUsages(
+	dataSlabKind MemoryKind,
+	metaDataSlabKind MemoryKind,
+	elementOverheadKind MemoryKind,
+	count uint64,
+	elementSize uint,
+) (
+	dataSlabs MemoryUsage,
+	metaDataSlabs MemoryUsage,
+	elementOverhead MemoryUsage,
+) {
+	if elementSize == 0 {
+		elementSize = 1
+	}
+
+	elementsPerSlab := uint64(atreeSlabTargetSize / elementSize)
+	if elementsPerSlab == 0 {
+		elementsPerSlab = 1
+	}
+
+	// There is always at least one (root) data slab
+
+	dataSlabCount := count/elementsPerSlab + 1
+
+	// Data slabs are connected through a tree of meta data slabs
+
+	var metaDataSlabCount uint64
+	for slabs := dataSlabCount; slabs > 1; {
+		slabs = (slabs + atreeMetaDataSlabChildren - 1) / atreeMetaDataSlabChildren
+		metaDataSlabCount += slabs
+	}
+
+	return MemoryUsage{
+			Kind:   dataSlabKind,
+			Amount: dataSlabCount,
+		},
+		MemoryUsage{
+			Kind:   metaDataSlabKind,
+			Amount: metaDataSlabCount,
+		},
+		MemoryUsage{
+			Kind:   elementOverheadKind,
+			Amount: count,
+		}
+}
+
+// NewAtreeArrayMemoryUsages returns the estimated memory usage of an atree array
+// with the given number of elements, which have the given estimated size in bytes
+//
+func NewAtreeArrayMemoryUsages(count uint64, elementSize uint) (dataSlabs, metaDataSlabs, elementOverhead MemoryUsage) {
+	return newAtreeMemoryUsages(
+		MemoryKindAtreeArrayDataSlab,
+		MemoryKindAtreeArrayMetaDataSlab,
+		MemoryKindAtreeArrayElementOverhead,
+		count,
+		elementSize,
+	)
+}
+
+// NewAtreeMapMemoryUsages returns the estimated memory usage of an atree map
+// with the given number of entries, which have the given estimated size in bytes
+//
+func NewAtreeMapMemoryUsages(count uint64, elementSize uint) (dataSlabs, metaDataSlabs, elementOverhead MemoryUsage) {
+	return newAtreeMemoryUsages(
+		MemoryKindAtreeMapDataSlab,
+		MemoryKindAtreeMapMetaDataSlab,
+		MemoryKindAtreeMapElementOverhead,
+		count,
+		elementSize,
+	)
+}
+
+// useAtreeGrowthMemory reports the additional memory usage of adding an element
+// to an atree container which already has the given number of elements
+//
+func useAtreeGrowthMemory(
+	gauge MemoryGauge,
+	usages func(count uint64, elementSize uint) (dataSlabs, metaDataSlabs, elementOverhead MemoryUsage),
+	count uint64,
+	elementSize uint,
+) {
+	if gauge == nil {
+		return
+	}
+
+	oldDataSlabs, oldMetaDataSlabs, oldElementOverhead := usages(count, elementSize)
+	newDataSlabs, newMetaDataSlabs, newElementOverhead := usages(count+1, elementSize)
+
+	for _, usage := range []MemoryUsage{
+		{
+			Kind:   newDataSlabs.Kind,
+			Amount: newDataSlabs.Amount - oldDataSlabs.Amount,
+		},
+		{
+			Kind:   newMetaDataSlabs.Kind,
+			Amount: newMetaDataSlabs.Amount - oldMetaDataSlabs.Amount,
+		},
+		{
+			Kind:   newElementOverhead.Kind,
+			Amount: newElementOverhead.Amount - oldElementOverhead.Amount,
+		},
+	} {
+		if usage.Amount == 0 {
+			continue
+		}
+		UseMemory(gauge, usage)
+	}
+}
+
+// UseAtreeArrayGrowthMemory reports the additional memory usage of adding an element
+// to an atree array which already has the given number of elements
+//
+func UseAtreeArrayGrowthMemory(gauge MemoryGauge, count uint64, elementSize uint) {
+	useAtreeGrowthMemory(gauge, NewAtreeArrayMemoryUsages, count, elementSize)
+}
+
+// UseAtreeMapGrowthMemory reports the additional memory usage of adding an entry
+// to an atree map which already has the given number of entries
+//
+func UseAtreeMapGrowthMemory(gauge MemoryGauge, count uint64, elementSize uint) {
+	useAtreeGrowthMemory(gauge, NewAtreeMapMemoryUsages, count, elementSize)
+}
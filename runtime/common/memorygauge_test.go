@@ -0,0 +1,206 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/errors"
+)
+
+type testMemoryGauge struct {
+	meter map[MemoryKind]uint64
+	err   error
+}
+
+func (g *testMemoryGauge) MeterMemory(usage MemoryUsage) error {
+	if g.err != nil {
+		return g.err
+	}
+	g.meter[usage.Kind] += usage.Amount
+	return nil
+}
+
+func TestUseMemory(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("no gauge", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.NotPanics(t, func() {
+			UseMemory(nil, NewStringMemoryUsage(3))
+		})
+	})
+
+	t.Run("gauge", func(t *testing.T) {
+
+		t.Parallel()
+
+		gauge := &testMemoryGauge{
+			meter: map[MemoryKind]uint64{},
+		}
+
+		UseMemory(gauge, NewStringMemoryUsage(3))
+		UseMemory(gauge, NewStringMemoryUsage(0))
+		UseMemory(gauge, AddressValueMemoryUsage)
+
+		assert.Equal(t,
+			map[MemoryKind]uint64{
+				MemoryKindStringValue:  5,
+				MemoryKindAddressValue: 1,
+			},
+			gauge.meter,
+		)
+	})
+
+	t.Run("error", func(t *testing.T) {
+
+		t.Parallel()
+
+		limitErr := fmt.Errorf("memory limit exceeded")
+
+		gauge := &testMemoryGauge{
+			err: limitErr,
+		}
+
+		defer func() {
+			r := recover()
+			require.IsType(t, errors.MemoryError{}, r)
+
+			err := r.(errors.MemoryError)
+			require.ErrorIs(t, err, limitErr)
+		}()
+
+		UseMemory(gauge, AddressValueMemoryUsage)
+	})
+}
+
+func TestNewBigIntMemoryUsage(t *testing.T) {
+
+	t.Parallel()
+
+	for bytes, expected := range map[int]uint64{
+		0:  8,
+		1:  8,
+		8:  8,
+		9:  16,
+		16: 16,
+		17: 24,
+	} {
+		assert.Equal(t,
+			MemoryUsage{
+				Kind:   MemoryKindBigInt,
+				Amount: expected,
+			},
+			NewBigIntMemoryUsage(bytes),
+			fmt.Sprintf("%d bytes", bytes),
+		)
+	}
+}
+
+func TestNewBigIntOperationMemoryUsages(t *testing.T) {
+
+	t.Parallel()
+
+	// 2^64: 9 bytes
+	a := new(big.Int).Lsh(big.NewInt(1), 64)
+	// 1: 1 byte
+	b := big.NewInt(1)
+
+	assert.Equal(t, uint64(16), NewPlusBigIntMemoryUsage(a, b).Amount)
+	assert.Equal(t, uint64(16), NewMinusBigIntMemoryUsage(a, b).Amount)
+	assert.Equal(t, uint64(16), NewMulBigIntMemoryUsage(a, b).Amount)
+	assert.Equal(t, uint64(24), NewMulBigIntMemoryUsage(a, a).Amount)
+	assert.Equal(t, uint64(16), NewDivBigIntMemoryUsage(a, b).Amount)
+	assert.Equal(t, uint64(8), NewModBigIntMemoryUsage(a, b).Amount)
+	assert.Equal(t, uint64(16), NewBitwiseBigIntMemoryUsage(a, b).Amount)
+	assert.Equal(t, uint64(16), NewBitwiseRightShiftBigIntMemoryUsage(a).Amount)
+	assert.Equal(t, uint64(16), NewNegateBigIntMemoryUsage(a).Amount)
+
+	// 1 << 64: 1 + 9 bytes
+	assert.Equal(t, uint64(16), NewBitwiseLeftShiftBigIntMemoryUsage(b, 64).Amount)
+
+	// Huge shifts are capped, but still exceed any reasonable limit
+	assert.Equal(t,
+		uint64(math.MaxInt32+1),
+		NewBitwiseLeftShiftBigIntMemoryUsage(b, math.MaxUint64).Amount,
+	)
+}
+
+func TestNewAtreeArrayMemoryUsages(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("empty", func(t *testing.T) {
+
+		t.Parallel()
+
+		dataSlabs, metaDataSlabs, elementOverhead := NewAtreeArrayMemoryUsages(0, 32)
+
+		assert.Equal(t, MemoryUsage{Kind: MemoryKindAtreeArrayDataSlab, Amount: 1}, dataSlabs)
+		assert.Equal(t, MemoryUsage{Kind: MemoryKindAtreeArrayMetaDataSlab, Amount: 0}, metaDataSlabs)
+		assert.Equal(t, MemoryUsage{Kind: MemoryKindAtreeArrayElementOverhead, Amount: 0}, elementOverhead)
+	})
+
+	t.Run("large", func(t *testing.T) {
+
+		t.Parallel()
+
+		// 32 elements of size 32 fit into a data slab,
+		// so 2048 elements need 65 data slabs,
+		// which are connected through 2 + 1 meta data slabs
+
+		dataSlabs, metaDataSlabs, elementOverhead := NewAtreeArrayMemoryUsages(2048, 32)
+
+		assert.Equal(t, MemoryUsage{Kind: MemoryKindAtreeArrayDataSlab, Amount: 65}, dataSlabs)
+		assert.Equal(t, MemoryUsage{Kind: MemoryKindAtreeArrayMetaDataSlab, Amount: 3}, metaDataSlabs)
+		assert.Equal(t, MemoryUsage{Kind: MemoryKindAtreeArrayElementOverhead, Amount: 2048}, elementOverhead)
+	})
+}
+
+func TestUseAtreeArrayGrowthMemory(t *testing.T) {
+
+	t.Parallel()
+
+	gauge := &testMemoryGauge{
+		meter: map[MemoryKind]uint64{},
+	}
+
+	const count = 2048
+
+	for i := uint64(0); i < count; i++ {
+		UseAtreeArrayGrowthMemory(gauge, i, 32)
+	}
+
+	dataSlabs, metaDataSlabs, elementOverhead := NewAtreeArrayMemoryUsages(count, 32)
+
+	// The growth does not include the initial (root) data slab
+
+	assert.Equal(t, dataSlabs.Amount-1, gauge.meter[MemoryKindAtreeArrayDataSlab])
+	assert.Equal(t, metaDataSlabs.Amount, gauge.meter[MemoryKindAtreeArrayMetaDataSlab])
+	assert.Equal(t, elementOverhead.Amount, gauge.meter[MemoryKindAtreeArrayElementOverhead])
+}
@@ -0,0 +1,88 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=MemoryKind -trimprefix=MemoryKind
+
+// MemoryKind captures the kind of memory that is used,
+// e.g. by a value of the interpreter, a token of the lexer,
+// an AST element of the parser, or a type of the checker
+//
+type MemoryKind uint
+
+const (
+	MemoryKindUnknown MemoryKind = iota
+
+	// Values
+
+	MemoryKindAddressValue
+	MemoryKindStringValue
+	MemoryKindCharacterValue
+	MemoryKindBigInt
+	MemoryKindArrayValueBase
+	MemoryKindDictionaryValueBase
+	MemoryKindCompositeValueBase
+	MemoryKindOptionalValue
+	MemoryKindTypeValue
+	MemoryKindPathValue
+	MemoryKindCapabilityValue
+	MemoryKindLinkValue
+	MemoryKindStorageReferenceValue
+	MemoryKindEphemeralReferenceValue
+	MemoryKindInterpretedFunctionValue
+	MemoryKindBoundFunctionValue
+
+	// Atree
+
+	MemoryKindAtreeArrayDataSlab
+	MemoryKindAtreeArrayMetaDataSlab
+	MemoryKindAtreeArrayElementOverhead
+	MemoryKindAtreeMapDataSlab
+	MemoryKindAtreeMapMetaDataSlab
+	MemoryKindAtreeMapElementOverhead
+
+	// Lexer and parser
+
+	MemoryKindToken
+	MemoryKindIdentifier
+	MemoryKindArgument
+	MemoryKindTypeAnnotation
+	MemoryKindDeclaration
+	MemoryKindStatement
+	MemoryKindExpression
+	MemoryKindType
+	MemoryKindProgram
+
+	// Checker
+
+	MemoryKindVariable
+	MemoryKindCompositeSemaType
+	MemoryKindInterfaceSemaType
+	MemoryKindFunctionSemaType
+	MemoryKindOptionalSemaType
+	MemoryKindVariableSizedSemaType
+	MemoryKindConstantSizedSemaType
+	MemoryKindDictionarySemaType
+	MemoryKindReferenceSemaType
+	MemoryKindRestrictedSemaType
+
+	// MemoryKindLast is the last memory kind, it must be declared last
+	//
+	MemoryKindLast
+)
@@ -250,6 +250,11 @@ func (compiler *Compiler) VisitDestroyExpression(_ *ast.DestroyExpression) ast.R
 	panic(errors.NewUnreachableError())
 }
 
+func (compiler *Compiler) VisitAttachExpression(_ *ast.AttachExpression) ast.Repr {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
 func (compiler *Compiler) VisitReferenceExpression(_ *ast.ReferenceExpression) ast.Repr {
 	// TODO
 	panic(errors.NewUnreachableError())
@@ -362,6 +367,11 @@ func (compiler *Compiler) VisitPragmaDeclaration(_ *ast.PragmaDeclaration) ast.R
 	panic(errors.NewUnreachableError())
 }
 
+func (compiler *Compiler) VisitTypeAliasDeclaration(_ *ast.TypeAliasDeclaration) ast.Repr {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
 func (compiler *Compiler) VisitImportDeclaration(_ *ast.ImportDeclaration) ast.Repr {
 	// TODO
 	panic(errors.NewUnreachableError())
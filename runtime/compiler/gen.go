@@ -18,4 +18,4 @@
 
 package compiler
 
-//go:generate go run github.com/cheekybits/genny -pkg=compiler -in=../activations/activations.go -out=local_activations.go gen "ValueType=*Local"
+//go:generate go run github.com/cheekybits/genny -pkg=compiler -in=../activations/activations.go -out=local_activations.go gen "ValueType=*Local TypeParameterType=*sema.TypeParameter TypeArgumentType=sema.Type"
@@ -22,16 +22,19 @@
 
 package compiler
 
+import "github.com/onflow/cadence/runtime/sema"
+
 // A LocalActivation is a map of strings to values.
 // It can be used to represent an active scope in a program,
 // i.e. it can be used as a symbol table during semantic analysis,
 // or as an activation record during interpretation or compilation.
 //
 type LocalActivation struct {
-	entries    map[string]*Local
-	Depth      int
-	Parent     *LocalActivation
-	isFunction bool
+	entries       map[string]*Local
+	typeArguments map[*sema.TypeParameter]sema.Type
+	Depth         int
+	Parent        *LocalActivation
+	isFunction    bool
 }
 
 func NewLocalActivation(parent *LocalActivation) *LocalActivation {
@@ -105,6 +108,58 @@ func (a *LocalActivation) Set(name string, value *Local) {
 	a.entries[name] = value
 }
 
+// SetTypeArgument binds the given type parameter to the given type argument in the activation.
+//
+func (a *LocalActivation) SetTypeArgument(typeParameter *sema.TypeParameter, typeArgument sema.Type) {
+	if a.typeArguments == nil {
+		a.typeArguments = make(map[*sema.TypeParameter]sema.Type)
+	}
+
+	a.typeArguments[typeParameter] = typeArgument
+}
+
+// HasTypeArguments returns true if the activation or any of its parents
+// binds a type parameter to a type argument.
+//
+func (a *LocalActivation) HasTypeArguments() bool {
+
+	current := a
+
+	for current != nil {
+
+		if len(current.typeArguments) > 0 {
+			return true
+		}
+
+		current = current.Parent
+	}
+
+	return false
+}
+
+// TypeArguments returns all type arguments bound in the activation and its parents.
+// Type arguments bound in nested activations take precedence.
+//
+func (a *LocalActivation) TypeArguments() map[*sema.TypeParameter]sema.Type {
+
+	typeArguments := make(map[*sema.TypeParameter]sema.Type)
+
+	current := a
+
+	for current != nil {
+
+		for typeParameter, typeArgument := range current.typeArguments { //nolint:maprangecheck
+			if _, ok := typeArguments[typeParameter]; !ok {
+				typeArguments[typeParameter] = typeArgument
+			}
+		}
+
+		current = current.Parent
+	}
+
+	return typeArguments
+}
+
 // LocalActivations is a stack of activation records.
 // Each entry represents a new activation record.
 //
@@ -19,6 +19,8 @@
 package runtime
 
 import (
+	"context"
+
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 )
@@ -27,8 +29,11 @@ type Context struct {
 	Interface         Interface
 	Location          Location
 	PredeclaredValues []ValueDeclaration
-	codes             map[common.LocationID]string
-	programs          map[common.LocationID]*ast.Program
+	// GoContext is optional. When it is done, i.e. canceled or its deadline is exceeded,
+	// the execution is aborted with an ExecutionCanceledError
+	GoContext context.Context
+	codes     map[common.LocationID]string
+	programs  map[common.LocationID]*ast.Program
 }
 
 func (c Context) SetCode(location common.Location, code string) {
@@ -218,8 +218,8 @@ func TestRuntimeContract(t *testing.T) {
 		// so getting the storage map here once upfront would result in outdated data
 
 		getContractValueExists := func() bool {
-			return NewStorage(storage).
-				GetStorageMap(signerAddress, StorageDomainContract).
+			return NewStorage(storage, nil).
+				GetStorageMap(signerAddress, StorageDomainContract, true).
 				ValueExists("Test")
 		}
 
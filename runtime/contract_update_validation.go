@@ -138,6 +138,8 @@ func (validator *ContractUpdateValidator) checkDeclarationUpdatability(
 
 	validator.checkFields(oldDeclaration, newDeclaration)
 
+	validator.checkTypeAliases(oldDeclaration, newDeclaration)
+
 	validator.checkNestedDeclarations(oldDeclaration, newDeclaration)
 
 	if newDecl, ok := newDeclaration.(*ast.CompositeDeclaration); ok {
@@ -185,6 +187,34 @@ func (validator *ContractUpdateValidator) checkField(oldField *ast.FieldDeclarat
 	}
 }
 
+// checkTypeAliases validates updating type aliases.
+// Existing type aliases must keep their aliased type, as fields may refer to them.
+// Adding and removing type aliases is fine.
+func (validator *ContractUpdateValidator) checkTypeAliases(oldDeclaration ast.Declaration, newDeclaration ast.Declaration) {
+
+	oldTypeAliases := map[string]*ast.TypeAliasDeclaration{}
+	for _, oldTypeAlias := range oldDeclaration.DeclarationMembers().TypeAliases() {
+		oldTypeAliases[oldTypeAlias.Identifier.Identifier] = oldTypeAlias
+	}
+
+	for _, newTypeAlias := range newDeclaration.DeclarationMembers().TypeAliases() {
+		oldTypeAlias := oldTypeAliases[newTypeAlias.Identifier.Identifier]
+		if oldTypeAlias == nil {
+			continue
+		}
+
+		err := oldTypeAlias.TypeAnnotation.Type.CheckEqual(newTypeAlias.TypeAnnotation.Type, validator)
+		if err != nil {
+			validator.report(&TypeAliasMismatchError{
+				DeclName:      newDeclaration.DeclarationIdentifier().Identifier,
+				TypeAliasName: newTypeAlias.Identifier.Identifier,
+				Err:           err,
+				Range:         ast.NewRangeFromPositioned(newTypeAlias.TypeAnnotation),
+			})
+		}
+	}
+}
+
 func (validator *ContractUpdateValidator) checkNestedDeclarations(
 	oldDeclaration ast.Declaration,
 	newDeclaration ast.Declaration,
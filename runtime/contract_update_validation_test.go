@@ -973,6 +973,114 @@ func TestRuntimeContractUpdateValidation(t *testing.T) {
 		assertMissingDeclarationError(t, cause, "TestStruct")
 	})
 
+	t.Run("change type alias", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {
+                pub typealias Amount = UFix64
+
+                pub var a: Amount
+
+                init() {
+                    self.a = 1.0
+                }
+            }
+        `
+
+		const newCode = `
+            pub contract Test {
+                pub typealias Amount = UInt64
+
+                pub var a: Amount
+
+                init() {
+                    self.a = 1
+                }
+            }
+        `
+
+		err := testDeployAndUpdate(t, contractValidationEnabled, "Test", oldCode, newCode)
+		require.Error(t, err)
+
+		cause := getSingleContractUpdateErrorCause(t, err, "Test")
+
+		var typeAliasMismatchError *TypeAliasMismatchError
+		require.ErrorAs(t, cause, &typeAliasMismatchError)
+
+		assert.Equal(t, "Amount", typeAliasMismatchError.TypeAliasName)
+		assert.Equal(t, "Test", typeAliasMismatchError.DeclName)
+	})
+
+	t.Run("adding a type alias", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {
+            }
+        `
+
+		const newCode = `
+            pub contract Test {
+                pub typealias Amount = UFix64
+            }
+        `
+
+		err := testDeployAndUpdate(t, contractValidationEnabled, "Test", oldCode, newCode)
+		require.NoError(t, err)
+	})
+
+	t.Run("add default function to nested interface", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {
+                pub resource interface Vault {
+                    pub var balance: Int
+                    pub fun isEmpty(): Bool
+                }
+
+                pub resource R: Vault {
+                    pub var balance: Int
+
+                    init() {
+                        self.balance = 0
+                    }
+
+                    pub fun isEmpty(): Bool {
+                        return self.balance == 0
+                    }
+                }
+            }
+        `
+
+		const newCode = `
+            pub contract Test {
+                pub resource interface Vault {
+                    pub var balance: Int
+
+                    pub fun isEmpty(): Bool {
+                        return self.balance == 0
+                    }
+                }
+
+                pub resource R: Vault {
+                    pub var balance: Int
+
+                    init() {
+                        self.balance = 0
+                    }
+                }
+            }
+        `
+
+		err := testDeployAndUpdate(t, contractValidationEnabled, "Test", oldCode, newCode)
+		require.NoError(t, err)
+	})
+
 	t.Run("add and remove field", func(t *testing.T) {
 
 		t.Parallel()
@@ -418,10 +418,18 @@ func exportCapabilityValue(v *interpreter.CapabilityValue, inter *interpreter.In
 		borrowType = inter.MustConvertStaticToSemaType(v.BorrowType)
 	}
 
+	// Capabilities issued through a capability controller have no path
+
+	var path cadence.Path
+	if !v.IsControllerCapability() {
+		path = exportPathValue(v.Path)
+	}
+
 	return cadence.Capability{
-		Path:       exportPathValue(v.Path),
+		Path:       path,
 		Address:    cadence.NewAddress(v.Address),
 		BorrowType: ExportType(borrowType, map[sema.TypeID]cadence.Type{}),
+		ID:         uint64(v.ID),
 	}
 }
 
@@ -441,6 +449,15 @@ func exportEvent(event exportableEvent, seenReferences seenReferences) (cadence.
 	return cadence.NewEvent(fields).WithType(eventType), nil
 }
 
+// ImportValue converts a Cadence value to a runtime value.
+//
+// The expected type is optional. If it is nil,
+// the static types of containers are inferred from their elements.
+//
+func ImportValue(inter *interpreter.Interpreter, value cadence.Value, expectedType sema.Type) (interpreter.Value, error) {
+	return importValue(inter, value, expectedType)
+}
+
 // importValue converts a Cadence value to a runtime value.
 func importValue(inter *interpreter.Interpreter, value cadence.Value, expectedType sema.Type) (interpreter.Value, error) {
 	switch v := value.(type) {
@@ -451,9 +468,9 @@ func importValue(inter *interpreter.Interpreter, value cadence.Value, expectedTy
 	case cadence.Bool:
 		return interpreter.BoolValue(v), nil
 	case cadence.String:
-		return interpreter.NewStringValue(string(v)), nil
+		return importString(inter, v), nil
 	case cadence.Character:
-		return interpreter.NewCharacterValue(string(v)), nil
+		return importCharacter(inter, v), nil
 	case cadence.Bytes:
 		return interpreter.ByteSliceToByteArrayValue(inter, v), nil
 	case cadence.Address:
@@ -613,6 +630,30 @@ func importCapability(
 
 }
 
+func importString(inter *interpreter.Interpreter, v cadence.String) *interpreter.StringValue {
+	memoryUsage := common.NewStringMemoryUsage(len(v))
+
+	return interpreter.NewStringValue(
+		inter,
+		memoryUsage,
+		func() string {
+			return string(v)
+		},
+	)
+}
+
+func importCharacter(inter *interpreter.Interpreter, v cadence.Character) interpreter.CharacterValue {
+	memoryUsage := common.NewCharacterMemoryUsage(len(v))
+
+	return interpreter.NewCharacterValue(
+		inter,
+		memoryUsage,
+		func() string {
+			return string(v)
+		},
+	)
+}
+
 func importOptionalValue(
 	inter *interpreter.Interpreter,
 	v cadence.Optional,
@@ -146,12 +146,12 @@ func TestExportValue(t *testing.T) {
 
 		{
 			label:    "String empty",
-			value:    interpreter.NewStringValue(""),
+			value:    interpreter.NewUnmeteredStringValue(""),
 			expected: cadence.String(""),
 		},
 		{
 			label:    "String non-empty",
-			value:    interpreter.NewStringValue("foo"),
+			value:    interpreter.NewUnmeteredStringValue("foo"),
 			expected: cadence.String("foo"),
 		},
 		{
@@ -177,7 +177,7 @@ func TestExportValue(t *testing.T) {
 					},
 					common.Address{},
 					interpreter.NewIntValueFromInt64(42),
-					interpreter.NewStringValue("foo"),
+					interpreter.NewUnmeteredStringValue("foo"),
 				)
 			},
 			expected: cadence.NewArray([]cadence.Value{
@@ -207,9 +207,9 @@ func TestExportValue(t *testing.T) {
 						KeyType:   interpreter.PrimitiveStaticTypeString,
 						ValueType: interpreter.PrimitiveStaticTypeAnyStruct,
 					},
-					interpreter.NewStringValue("a"),
+					interpreter.NewUnmeteredStringValue("a"),
 					interpreter.NewIntValueFromInt64(1),
-					interpreter.NewStringValue("b"),
+					interpreter.NewUnmeteredStringValue("b"),
 					interpreter.NewIntValueFromInt64(2),
 				)
 			},
@@ -236,7 +236,7 @@ func TestExportValue(t *testing.T) {
 		},
 		{
 			label:    "Character",
-			value:    interpreter.NewCharacterValue("a"),
+			value:    interpreter.NewUnmeteredCharacterValue("a"),
 			expected: a,
 		},
 		{
@@ -444,7 +444,7 @@ func TestExportValue(t *testing.T) {
 			label: "Deployed contract (invalid)",
 			value: interpreter.NewDeployedContractValue(
 				interpreter.AddressValue{},
-				interpreter.NewStringValue("C"),
+				interpreter.NewUnmeteredStringValue("C"),
 				interpreter.NewArrayValue(
 					newTestInterpreter(t),
 					interpreter.ByteArrayStaticType,
@@ -522,12 +522,12 @@ func TestImportValue(t *testing.T) {
 		{
 			label:    "String empty",
 			value:    cadence.String(""),
-			expected: interpreter.NewStringValue(""),
+			expected: interpreter.NewUnmeteredStringValue(""),
 		},
 		{
 			label:    "String non-empty",
 			value:    cadence.String("foo"),
-			expected: interpreter.NewStringValue("foo"),
+			expected: interpreter.NewUnmeteredStringValue("foo"),
 		},
 		{
 			label: "Array empty",
@@ -556,7 +556,7 @@ func TestImportValue(t *testing.T) {
 				},
 				common.Address{},
 				interpreter.NewIntValueFromInt64(42),
-				interpreter.NewStringValue("foo"),
+				interpreter.NewUnmeteredStringValue("foo"),
 			),
 			expectedType: &sema.VariableSizedType{
 				Type: sema.AnyStructType,
@@ -585,9 +585,9 @@ func TestImportValue(t *testing.T) {
 					KeyType:   interpreter.PrimitiveStaticTypeString,
 					ValueType: interpreter.PrimitiveStaticTypeAnyStruct,
 				},
-				interpreter.NewStringValue("a"),
+				interpreter.NewUnmeteredStringValue("a"),
 				interpreter.NewIntValueFromInt64(1),
-				interpreter.NewStringValue("b"),
+				interpreter.NewUnmeteredStringValue("b"),
 				interpreter.NewIntValueFromInt64(2),
 			),
 			value: cadence.NewDictionary([]cadence.KeyValuePair{
@@ -618,7 +618,7 @@ func TestImportValue(t *testing.T) {
 		{
 			label:    "Character",
 			value:    a,
-			expected: interpreter.NewCharacterValue("a"),
+			expected: interpreter.NewUnmeteredCharacterValue("a"),
 		},
 		{
 			label:    "Int8",
@@ -1735,7 +1735,7 @@ func TestExportTypeValue(t *testing.T) {
 
           pub struct S: SI {}
 
-        `)
+        `, nil)
 		require.NoError(t, err)
 
 		checker, err := sema.NewChecker(program, TestLocation)
@@ -1818,7 +1818,7 @@ func TestExportCapabilityValue(t *testing.T) {
 
 	t.Run("Struct", func(t *testing.T) {
 
-		program, err := parser2.ParseProgram(`pub struct S {}`)
+		program, err := parser2.ParseProgram(`pub struct S {}`, nil)
 		require.NoError(t, err)
 
 		checker, err := sema.NewChecker(program, TestLocation)
@@ -1913,7 +1913,7 @@ func TestExportLinkValue(t *testing.T) {
 
 	t.Run("Struct", func(t *testing.T) {
 
-		program, err := parser2.ParseProgram(`pub struct S {}`)
+		program, err := parser2.ParseProgram(`pub struct S {}`, nil)
 		require.NoError(t, err)
 
 		checker, err := sema.NewChecker(program, TestLocation)
@@ -2963,7 +2963,7 @@ func TestRuntimeImportExportArrayValue(t *testing.T) {
 			},
 			common.Address{},
 			interpreter.NewIntValueFromInt64(42),
-			interpreter.NewStringValue("foo"),
+			interpreter.NewUnmeteredStringValue("foo"),
 		)
 
 		actual, err := exportValueWithInterpreter(value, nil, seenReferences{})
@@ -3008,7 +3008,7 @@ func TestRuntimeImportExportArrayValue(t *testing.T) {
 				},
 				common.Address{},
 				interpreter.NewIntValueFromInt64(42),
-				interpreter.NewStringValue("foo"),
+				interpreter.NewUnmeteredStringValue("foo"),
 			),
 			actual,
 		)
@@ -3140,8 +3140,8 @@ func TestRuntimeImportExportDictionaryValue(t *testing.T) {
 				KeyType:   interpreter.PrimitiveStaticTypeString,
 				ValueType: interpreter.PrimitiveStaticTypeInt,
 			},
-			interpreter.NewStringValue("a"), interpreter.NewIntValueFromInt64(1),
-			interpreter.NewStringValue("b"), interpreter.NewIntValueFromInt64(2),
+			interpreter.NewUnmeteredStringValue("a"), interpreter.NewIntValueFromInt64(1),
+			interpreter.NewUnmeteredStringValue("b"), interpreter.NewIntValueFromInt64(2),
 		)
 
 		actual, err := exportValueWithInterpreter(value, nil, seenReferences{})
@@ -3198,8 +3198,8 @@ func TestRuntimeImportExportDictionaryValue(t *testing.T) {
 					KeyType:   interpreter.PrimitiveStaticTypeString,
 					ValueType: interpreter.PrimitiveStaticTypeInt,
 				},
-				interpreter.NewStringValue("a"), interpreter.NewIntValueFromInt64(1),
-				interpreter.NewStringValue("b"), interpreter.NewIntValueFromInt64(2),
+				interpreter.NewUnmeteredStringValue("a"), interpreter.NewIntValueFromInt64(1),
+				interpreter.NewUnmeteredStringValue("b"), interpreter.NewIntValueFromInt64(2),
 			),
 			actual,
 		)
@@ -3260,7 +3260,7 @@ func TestRuntimeImportExportDictionaryValue(t *testing.T) {
 					},
 				},
 
-				interpreter.NewStringValue("a"),
+				interpreter.NewUnmeteredStringValue("a"),
 				interpreter.NewDictionaryValue(
 					inter,
 					interpreter.DictionaryStaticType{
@@ -3268,17 +3268,17 @@ func TestRuntimeImportExportDictionaryValue(t *testing.T) {
 						ValueType: interpreter.PrimitiveStaticTypeAnyStruct,
 					},
 					interpreter.Int8Value(1), interpreter.NewIntValueFromInt64(100),
-					interpreter.Int8Value(2), interpreter.NewStringValue("hello"),
+					interpreter.Int8Value(2), interpreter.NewUnmeteredStringValue("hello"),
 				),
 
-				interpreter.NewStringValue("b"),
+				interpreter.NewUnmeteredStringValue("b"),
 				interpreter.NewDictionaryValue(
 					inter,
 					interpreter.DictionaryStaticType{
 						KeyType:   interpreter.PrimitiveStaticTypeSignedInteger,
 						ValueType: interpreter.PrimitiveStaticTypeAnyStruct,
 					},
-					interpreter.Int8Value(1), interpreter.NewStringValue("foo"),
+					interpreter.Int8Value(1), interpreter.NewUnmeteredStringValue("foo"),
 					interpreter.NewIntValueFromInt64(2), interpreter.NewIntValueFromInt64(50),
 				),
 			),
@@ -4375,7 +4375,7 @@ func TestRuntimeImportExportComplex(t *testing.T) {
 		staticArrayType,
 		common.Address{},
 		interpreter.NewIntValueFromInt64(42),
-		interpreter.NewStringValue("foo"),
+		interpreter.NewUnmeteredStringValue("foo"),
 	)
 
 	externalArrayValue := cadence.NewArray([]cadence.Value{
@@ -4403,7 +4403,7 @@ func TestRuntimeImportExportComplex(t *testing.T) {
 	internalDictionaryValue := interpreter.NewDictionaryValue(
 		inter,
 		staticDictionaryType,
-		interpreter.NewStringValue("a"), internalArrayValue,
+		interpreter.NewUnmeteredStringValue("a"), internalArrayValue,
 	)
 
 	externalDictionaryValue := cadence.NewDictionary([]cadence.KeyValuePair{
@@ -4745,3 +4745,131 @@ func TestNestedStructArgPassing(t *testing.T) {
 		require.ErrorAs(t, err, &argErr)
 	})
 }
+
+func TestRuntimeTypedArgumentDecoding(t *testing.T) {
+
+	t.Parallel()
+
+	script := `
+        pub struct Item {
+            pub let id: UInt64
+
+            init(id: UInt64) {
+                self.id = id
+            }
+        }
+
+        pub struct Order {
+            pub let items: [Item]
+
+            init(items: [Item]) {
+                self.items = items
+            }
+        }
+
+        pub fun main(index: Int, order: Order): UInt64 {
+            return order.items[index].id
+        }
+    `
+
+	orderJSON := func(secondItemID string) string {
+		return fmt.Sprintf(
+			`
+              {
+                "type": "Struct",
+                "value": {
+                  "id": "S.test.Order",
+                  "fields": [
+                    {
+                      "name": "items",
+                      "value": {
+                        "type": "Array",
+                        "value": [
+                          {
+                            "type": "Struct",
+                            "value": {
+                              "id": "S.test.Item",
+                              "fields": [
+                                {
+                                  "name": "id",
+                                  "value": {"type": "UInt64", "value": "1"}
+                                }
+                              ]
+                            }
+                          },
+                          {
+                            "type": "Struct",
+                            "value": {
+                              "id": "S.test.Item",
+                              "fields": [
+                                {
+                                  "name": "id",
+                                  "value": %s
+                                }
+                              ]
+                            }
+                          }
+                        ]
+                      }
+                    }
+                  ]
+                }
+              }
+            `,
+			secondItemID,
+		)
+	}
+
+	execute := func(orderJSON string) (cadence.Value, error) {
+		rt := newTestInterpreterRuntime()
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			decodeArgument: func(b []byte, t cadence.Type) (value cadence.Value, err error) {
+				return json.DecodeWithType(b, t, json.DefaultLimits)
+			},
+		}
+
+		return rt.ExecuteScript(
+			Script{
+				Source: []byte(script),
+				Arguments: [][]byte{
+					[]byte(`{"type": "Int", "value": "1"}`),
+					[]byte(orderJSON),
+				},
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  TestLocation,
+			},
+		)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		value, err := execute(orderJSON(`{"type": "UInt64", "value": "2"}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewUInt64(2), value)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := execute(orderJSON(`{"type": "String", "value": "2"}`))
+		require.Error(t, err)
+
+		var argErr *InvalidEntryPointArgumentError
+		require.ErrorAs(t, err, &argErr)
+
+		assert.Contains(
+			t,
+			err.Error(),
+			"invalid argument at index 1: args[1].items[1].id: "+
+				"invalid JSON Cadence structure: expected UInt64, got String",
+		)
+	})
+}
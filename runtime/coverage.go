@@ -18,42 +18,578 @@
 
 package runtime
 
-import "github.com/onflow/cadence/runtime/common"
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
 
 // LocationCoverage records coverage information for a location
 //
 type LocationCoverage struct {
+	// LineHits maps each line to the number of times a statement on the line was executed.
+	// If the program was inspected, lines with statements which were never executed have zero hits
 	LineHits map[int]int `json:"line_hits"`
+	// Statements is the number of statically coverable statements
+	Statements int `json:"statements"`
+	// Branches records the coverage of the branching elements, in source order
+	Branches []*BranchCoverage `json:"branches,omitempty"`
+
+	location      common.Location
+	inspected     bool
+	branchIndices map[branchKey]int
+}
+
+// BranchCoverage records the number of times each branch of a branching element was executed,
+// e.g. the then-branch and the else-branch of an if-statement.
+// See interpreter.OnBranchFunc for the numbering of branches
+//
+type BranchCoverage struct {
+	Line   int   `json:"line"`
+	Column int   `json:"column"`
+	Hits   []int `json:"hits"`
+}
+
+// Executed returns true if any branch was executed
+//
+func (c *BranchCoverage) Executed() bool {
+	for _, hits := range c.Hits {
+		if hits > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// CoveredBranches returns the number of branches which were executed
+//
+func (c *BranchCoverage) CoveredBranches() int {
+	covered := 0
+	for _, hits := range c.Hits {
+		if hits > 0 {
+			covered++
+		}
+	}
+	return covered
+}
+
+// branchKey identifies a branching element.
+// Nested elements may start at the same offset, but end at different offsets,
+// e.g. a nil-coalescing expression in the test of a conditional expression
+//
+type branchKey struct {
+	startOffset int
+	endOffset   int
 }
 
 func (c *LocationCoverage) AddLineHit(line int) {
 	c.LineHits[line]++
 }
 
+// AddBranchHit records that the given branch of the given branching element was executed
+//
+func (c *LocationCoverage) AddBranchHit(element ast.Element, branch int) {
+	branches := c.branches(element, branch+1)
+	for len(branches.Hits) <= branch {
+		branches.Hits = append(branches.Hits, 0)
+	}
+	branches.Hits[branch]++
+}
+
+func (c *LocationCoverage) branches(element ast.Element, count int) *BranchCoverage {
+	startPosition := element.StartPosition()
+
+	key := branchKey{
+		startOffset: startPosition.Offset,
+		endOffset:   element.EndPosition().Offset,
+	}
+
+	if c.branchIndices == nil {
+		c.branchIndices = map[branchKey]int{}
+	}
+
+	if index, ok := c.branchIndices[key]; ok {
+		return c.Branches[index]
+	}
+
+	branches := &BranchCoverage{
+		Line:   startPosition.Line,
+		Column: startPosition.Column,
+		Hits:   make([]int, count),
+	}
+
+	c.branchIndices[key] = len(c.Branches)
+	c.Branches = append(c.Branches, branches)
+
+	return branches
+}
+
+// inspect records the statically coverable statements and branches of the given program
+//
+func (c *LocationCoverage) inspect(program *ast.Program) {
+	if c.inspected {
+		return
+	}
+	c.inspected = true
+
+	addStatements := func(statements []ast.Statement) {
+		for _, statement := range statements {
+			line := statement.StartPosition().Line
+			if _, ok := c.LineHits[line]; !ok {
+				c.LineHits[line] = 0
+			}
+			c.Statements++
+		}
+	}
+
+	for _, declaration := range program.Declarations() {
+		ast.Inspect(declaration, func(element ast.Element) bool {
+			switch element := element.(type) {
+			case *ast.Block:
+				addStatements(element.Statements)
+
+			case *ast.IfStatement:
+				c.branches(element, 2)
+
+			case *ast.SwitchStatement:
+				branchCount := len(element.Cases)
+				hasDefaultCase := branchCount > 0 &&
+					element.Cases[branchCount-1].Expression == nil
+				if !hasDefaultCase {
+					branchCount++
+				}
+				c.branches(element, branchCount)
+
+				// The statements of cases are not in a block
+				for _, switchCase := range element.Cases {
+					addStatements(switchCase.Statements)
+				}
+
+			case *ast.ConditionalExpression:
+				c.branches(element, 2)
+
+			case *ast.BinaryExpression:
+				if element.Operation == ast.OperationNilCoalesce {
+					c.branches(element, 2)
+				}
+			}
+
+			return true
+		})
+	}
+}
+
+// CoverableLines returns the number of lines with statements
+//
+func (c *LocationCoverage) CoverableLines() int {
+	return len(c.LineHits)
+}
+
+// CoveredLines returns the number of lines with statements which were executed
+//
+func (c *LocationCoverage) CoveredLines() int {
+	covered := 0
+	for _, hits := range c.LineHits { //nolint:maprangecheck
+		if hits > 0 {
+			covered++
+		}
+	}
+	return covered
+}
+
+// LinePercentage returns the percentage of coverable lines which were executed
+//
+func (c *LocationCoverage) LinePercentage() float64 {
+	return percentage(c.CoveredLines(), c.CoverableLines())
+}
+
+// TotalBranches returns the number of branches of all branching elements
+//
+func (c *LocationCoverage) TotalBranches() int {
+	total := 0
+	for _, branches := range c.Branches {
+		total += len(branches.Hits)
+	}
+	return total
+}
+
+// CoveredBranches returns the number of branches which were executed
+//
+func (c *LocationCoverage) CoveredBranches() int {
+	covered := 0
+	for _, branches := range c.Branches {
+		covered += branches.CoveredBranches()
+	}
+	return covered
+}
+
+// BranchPercentage returns the percentage of branches which were executed
+//
+func (c *LocationCoverage) BranchPercentage() float64 {
+	return percentage(c.CoveredBranches(), c.TotalBranches())
+}
+
+// sortedLines returns the lines with hits in ascending order
+//
+func (c *LocationCoverage) sortedLines() []int {
+	lines := make([]int, 0, len(c.LineHits))
+	for line := range c.LineHits { //nolint:maprangecheck
+		lines = append(lines, line)
+	}
+	sort.Ints(lines)
+	return lines
+}
+
+// sortedBranches returns the branch coverages in source order
+//
+func (c *LocationCoverage) sortedBranches() []*BranchCoverage {
+	branches := make([]*BranchCoverage, len(c.Branches))
+	copy(branches, c.Branches)
+	sort.SliceStable(branches, func(i, j int) bool {
+		a := branches[i]
+		b := branches[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return branches
+}
+
 func NewLocationCoverage() *LocationCoverage {
 	return &LocationCoverage{
 		LineHits: map[int]int{},
 	}
 }
 
+// rate returns the ratio of the covered items, between 0 and 1.
+// If there is nothing to cover, everything is covered
+//
+func rate(covered, total int) float64 {
+	if total == 0 {
+		return 1
+	}
+	return float64(covered) / float64(total)
+}
+
+// percentage returns the percentage of the covered items
+//
+func percentage(covered, total int) float64 {
+	return rate(covered, total) * 100
+}
+
 // CoverageReport is a collection of coverage per location
 //
 type CoverageReport struct {
 	Coverage map[common.LocationID]*LocationCoverage `json:"coverage"`
+
+	excludedLocations map[common.LocationID]struct{}
 }
 
-func (r *CoverageReport) AddLineHit(location common.Location, line int) {
+// ExcludeLocation excludes the given location from the report,
+// e.g. the location of a program which is not under test
+//
+func (r *CoverageReport) ExcludeLocation(location common.Location) {
+	locationID := location.ID()
+	r.excludedLocations[locationID] = struct{}{}
+	delete(r.Coverage, locationID)
+}
+
+// IsLocationExcluded returns true if the given location is excluded from the report
+//
+func (r *CoverageReport) IsLocationExcluded(location common.Location) bool {
+	_, ok := r.excludedLocations[location.ID()]
+	return ok
+}
+
+func (r *CoverageReport) locationCoverage(location common.Location) *LocationCoverage {
 	locationID := location.ID()
 	locationCoverage := r.Coverage[locationID]
 	if locationCoverage == nil {
 		locationCoverage = NewLocationCoverage()
+		locationCoverage.location = location
 		r.Coverage[locationID] = locationCoverage
 	}
-	locationCoverage.AddLineHit(line)
+	return locationCoverage
+}
+
+// InspectProgram records the statically coverable statements and branches of the given program,
+// so lines which are never executed are reported
+//
+func (r *CoverageReport) InspectProgram(location common.Location, program *ast.Program) {
+	if r.IsLocationExcluded(location) {
+		return
+	}
+	r.locationCoverage(location).inspect(program)
+}
+
+func (r *CoverageReport) AddLineHit(location common.Location, line int) {
+	if r.IsLocationExcluded(location) {
+		return
+	}
+	r.locationCoverage(location).AddLineHit(line)
+}
+
+// AddBranchHit records that the given branch of the given branching element was executed
+//
+func (r *CoverageReport) AddBranchHit(location common.Location, element ast.Element, branch int) {
+	if r.IsLocationExcluded(location) {
+		return
+	}
+	r.locationCoverage(location).AddBranchHit(element, branch)
+}
+
+// LinePercentage returns the percentage of coverable lines which were executed, in all locations
+//
+func (r *CoverageReport) LinePercentage() float64 {
+	covered, total := r.lineTotals()
+	return percentage(covered, total)
+}
+
+// BranchPercentage returns the percentage of branches which were executed, in all locations
+//
+func (r *CoverageReport) BranchPercentage() float64 {
+	covered, total := r.branchTotals()
+	return percentage(covered, total)
+}
+
+func (r *CoverageReport) lineTotals() (covered, total int) {
+	for _, locationCoverage := range r.Coverage { //nolint:maprangecheck
+		covered += locationCoverage.CoveredLines()
+		total += locationCoverage.CoverableLines()
+	}
+	return
+}
+
+func (r *CoverageReport) branchTotals() (covered, total int) {
+	for _, locationCoverage := range r.Coverage { //nolint:maprangecheck
+		covered += locationCoverage.CoveredBranches()
+		total += locationCoverage.TotalBranches()
+	}
+	return
+}
+
+func (r *CoverageReport) String() string {
+	return fmt.Sprintf(
+		"Coverage: %.1f%% of lines, %.1f%% of branches",
+		r.LinePercentage(),
+		r.BranchPercentage(),
+	)
+}
+
+// sortedLocationIDs returns the IDs of the covered locations in ascending order
+//
+func (r *CoverageReport) sortedLocationIDs() []common.LocationID {
+	locationIDs := make([]common.LocationID, 0, len(r.Coverage))
+	for locationID := range r.Coverage { //nolint:maprangecheck
+		locationIDs = append(locationIDs, locationID)
+	}
+	sort.Slice(locationIDs, func(i, j int) bool {
+		return locationIDs[i] < locationIDs[j]
+	})
+	return locationIDs
+}
+
+// sourceFile returns the file name of the given location used in exported reports:
+// The path for string locations, and the location ID for all other locations
+//
+func (r *CoverageReport) sourceFile(locationID common.LocationID) string {
+	if stringLocation, ok := r.Coverage[locationID].location.(common.StringLocation); ok {
+		return string(stringLocation)
+	}
+	return string(locationID)
+}
+
+// WriteLCOV writes the report in the LCOV tracefile format
+//
+func (r *CoverageReport) WriteLCOV(writer io.Writer) error {
+	var err error
+
+	write := func(format string, arguments ...interface{}) {
+		if err != nil {
+			return
+		}
+		_, err = fmt.Fprintf(writer, format, arguments...)
+	}
+
+	for _, locationID := range r.sortedLocationIDs() {
+		locationCoverage := r.Coverage[locationID]
+
+		write("TN:\n")
+		write("SF:%s\n", r.sourceFile(locationID))
+
+		for block, branches := range locationCoverage.sortedBranches() {
+			executed := branches.Executed()
+
+			for branch, hits := range branches.Hits {
+				// Branches of elements which were never reached are reported as not taken
+				taken := "-"
+				if executed {
+					taken = strconv.Itoa(hits)
+				}
+
+				write("BRDA:%d,%d,%d,%s\n", branches.Line, block, branch, taken)
+			}
+		}
+
+		write("BRF:%d\n", locationCoverage.TotalBranches())
+		write("BRH:%d\n", locationCoverage.CoveredBranches())
+
+		for _, line := range locationCoverage.sortedLines() {
+			write("DA:%d,%d\n", line, locationCoverage.LineHits[line])
+		}
+
+		write("LF:%d\n", locationCoverage.CoverableLines())
+		write("LH:%d\n", locationCoverage.CoveredLines())
+		write("end_of_record\n")
+	}
+
+	return err
+}
+
+type coberturaCoverage struct {
+	XMLName         xml.Name           `xml:"coverage"`
+	LineRate        float64            `xml:"line-rate,attr"`
+	BranchRate      float64            `xml:"branch-rate,attr"`
+	LinesCovered    int                `xml:"lines-covered,attr"`
+	LinesValid      int                `xml:"lines-valid,attr"`
+	BranchesCovered int                `xml:"branches-covered,attr"`
+	BranchesValid   int                `xml:"branches-valid,attr"`
+	Complexity      int                `xml:"complexity,attr"`
+	Packages        []coberturaPackage `xml:"packages>package"`
+}
+
+type coberturaPackage struct {
+	Name       string           `xml:"name,attr"`
+	LineRate   float64          `xml:"line-rate,attr"`
+	BranchRate float64          `xml:"branch-rate,attr"`
+	Complexity int              `xml:"complexity,attr"`
+	Classes    []coberturaClass `xml:"classes>class"`
+}
+
+type coberturaClass struct {
+	Name       string          `xml:"name,attr"`
+	Filename   string          `xml:"filename,attr"`
+	LineRate   float64         `xml:"line-rate,attr"`
+	BranchRate float64         `xml:"branch-rate,attr"`
+	Complexity int             `xml:"complexity,attr"`
+	Methods    struct{}        `xml:"methods"`
+	Lines      []coberturaLine `xml:"lines>line"`
+}
+
+type coberturaLine struct {
+	Number            int    `xml:"number,attr"`
+	Hits              int    `xml:"hits,attr"`
+	Branch            bool   `xml:"branch,attr"`
+	ConditionCoverage string `xml:"condition-coverage,attr,omitempty"`
+}
+
+// WriteCobertura writes the report in the Cobertura XML format.
+// Each location is reported as a package with a single class
+//
+func (r *CoverageReport) WriteCobertura(writer io.Writer) error {
+
+	linesCovered, linesValid := r.lineTotals()
+	branchesCovered, branchesValid := r.branchTotals()
+
+	report := coberturaCoverage{
+		LineRate:        rate(linesCovered, linesValid),
+		BranchRate:      rate(branchesCovered, branchesValid),
+		LinesCovered:    linesCovered,
+		LinesValid:      linesValid,
+		BranchesCovered: branchesCovered,
+		BranchesValid:   branchesValid,
+	}
+
+	for _, locationID := range r.sortedLocationIDs() {
+		locationCoverage := r.Coverage[locationID]
+
+		lineRate := rate(
+			locationCoverage.CoveredLines(),
+			locationCoverage.CoverableLines(),
+		)
+		branchRate := rate(
+			locationCoverage.CoveredBranches(),
+			locationCoverage.TotalBranches(),
+		)
+
+		// Group the branches by line
+
+		lineBranches := map[int][]*BranchCoverage{}
+		for _, branches := range locationCoverage.Branches {
+			lineBranches[branches.Line] = append(lineBranches[branches.Line], branches)
+		}
+
+		lines := make([]coberturaLine, 0, len(locationCoverage.LineHits))
+
+		for _, number := range locationCoverage.sortedLines() {
+			line := coberturaLine{
+				Number: number,
+				Hits:   locationCoverage.LineHits[number],
+			}
+
+			if branches, ok := lineBranches[number]; ok {
+				var covered, total int
+				for _, branch := range branches {
+					covered += branch.CoveredBranches()
+					total += len(branch.Hits)
+				}
+
+				line.Branch = true
+				line.ConditionCoverage = fmt.Sprintf(
+					"%.0f%% (%d/%d)",
+					percentage(covered, total),
+					covered,
+					total,
+				)
+			}
+
+			lines = append(lines, line)
+		}
+
+		name := string(locationID)
+
+		report.Packages = append(report.Packages, coberturaPackage{
+			Name:       name,
+			LineRate:   lineRate,
+			BranchRate: branchRate,
+			Classes: []coberturaClass{
+				{
+					Name:       name,
+					Filename:   r.sourceFile(locationID),
+					LineRate:   lineRate,
+					BranchRate: branchRate,
+					Lines:      lines,
+				},
+			},
+		})
+	}
+
+	_, err := io.WriteString(writer, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+
+	err = encoder.Encode(report)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(writer, "\n")
+	return err
 }
 
 func NewCoverageReport() *CoverageReport {
 	return &CoverageReport{
-		Coverage: map[common.LocationID]*LocationCoverage{},
+		Coverage:          map[common.LocationID]*LocationCoverage{},
+		excludedLocations: map[common.LocationID]struct{}{},
 	}
 }
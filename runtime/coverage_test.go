@@ -19,6 +19,7 @@
 package runtime
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"testing"
@@ -101,14 +102,24 @@ func TestRuntimeCoverage(t *testing.T) {
                 "4": 1,
                 "5": 42,
                 "7": 1
-              }
+              },
+              "statements": 4
             },
             "t.00": {
               "line_hits": {
                 "5": 1,
                 "6": 1,
+                "7": 0,
                 "9": 1
-              }
+              },
+              "statements": 4,
+              "branches": [
+                {
+                  "line": 6,
+                  "column": 10,
+                  "hits": [0, 1]
+                }
+              ]
             }
           }
         }
@@ -116,3 +127,222 @@ func TestRuntimeCoverage(t *testing.T) {
 		string(actual),
 	)
 }
+
+func executeScriptWithCoverage(t *testing.T, script string) *CoverageReport {
+
+	runtime := newTestInterpreterRuntime()
+
+	coverageReport := NewCoverageReport()
+
+	runtime.SetCoverageReport(coverageReport)
+
+	_, err := runtime.ExecuteScript(
+		Script{
+			Source: []byte(script),
+		},
+		Context{
+			Interface: &testRuntimeInterface{},
+			Location:  newTransactionLocationGenerator()(),
+		},
+	)
+	require.NoError(t, err)
+
+	return coverageReport
+}
+
+const branchCoverageTestScript = `
+        pub fun main(): Int {
+            let x: Int? = nil
+            let y = x ?? 1
+            let z = y > 0 ? 2 : 3
+            switch z {
+            case 1:
+                return 1
+            case 2:
+                return 2
+            }
+            return 0
+        }
+    `
+
+func TestRuntimeCoverageBranches(t *testing.T) {
+
+	t.Parallel()
+
+	coverageReport := executeScriptWithCoverage(t, branchCoverageTestScript)
+
+	actual, err := json.Marshal(coverageReport)
+	require.NoError(t, err)
+
+	require.JSONEq(t,
+		`
+        {
+          "coverage": {
+            "t.00": {
+              "line_hits": {
+                "3": 1,
+                "4": 1,
+                "5": 1,
+                "6": 1,
+                "8": 0,
+                "10": 1,
+                "12": 0
+              },
+              "statements": 7,
+              "branches": [
+                {
+                  "line": 4,
+                  "column": 20,
+                  "hits": [0, 1]
+                },
+                {
+                  "line": 5,
+                  "column": 20,
+                  "hits": [1, 0]
+                },
+                {
+                  "line": 6,
+                  "column": 12,
+                  "hits": [0, 1, 0]
+                }
+              ]
+            }
+          }
+        }
+        `,
+		string(actual),
+	)
+
+	assert.Equal(t,
+		"Coverage: 71.4% of lines, 42.9% of branches",
+		coverageReport.String(),
+	)
+}
+
+func TestRuntimeCoverageExcludeLocation(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	runtimeInterface := &testRuntimeInterface{
+		getCode: func(location Location) (bytes []byte, err error) {
+			switch location {
+			case common.StringLocation("imported"):
+				return []byte(`
+                  pub fun answer(): Int {
+                    return 42
+                  }
+                `), nil
+			default:
+				return nil, fmt.Errorf("unknown import location: %s", location)
+			}
+		},
+	}
+
+	coverageReport := NewCoverageReport()
+	coverageReport.ExcludeLocation(common.StringLocation("imported"))
+
+	runtime.SetCoverageReport(coverageReport)
+
+	_, err := runtime.ExecuteScript(
+		Script{
+			Source: []byte(`
+              import "imported"
+
+              pub fun main(): Int {
+                  return answer()
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  newTransactionLocationGenerator()(),
+		},
+	)
+	require.NoError(t, err)
+
+	assert.True(t, coverageReport.IsLocationExcluded(common.StringLocation("imported")))
+
+	require.Len(t, coverageReport.Coverage, 1)
+	require.Contains(t, coverageReport.Coverage, common.LocationID("t.00"))
+}
+
+func TestRuntimeCoverageLCOV(t *testing.T) {
+
+	t.Parallel()
+
+	coverageReport := executeScriptWithCoverage(t, branchCoverageTestScript)
+
+	var buffer bytes.Buffer
+	err := coverageReport.WriteLCOV(&buffer)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		`TN:
+SF:t.00
+BRDA:4,0,0,0
+BRDA:4,0,1,1
+BRDA:5,1,0,1
+BRDA:5,1,1,0
+BRDA:6,2,0,0
+BRDA:6,2,1,1
+BRDA:6,2,2,0
+BRF:7
+BRH:3
+DA:3,1
+DA:4,1
+DA:5,1
+DA:6,1
+DA:8,0
+DA:10,1
+DA:12,0
+LF:7
+LH:5
+end_of_record
+`,
+		buffer.String(),
+	)
+}
+
+func TestRuntimeCoverageCobertura(t *testing.T) {
+
+	t.Parallel()
+
+	coverageReport := executeScriptWithCoverage(t, `
+        pub fun main(): Int {
+            let x = 1
+            if x > 0 {
+                return 1
+            }
+            return 0
+        }
+    `)
+
+	var buffer bytes.Buffer
+	err := coverageReport.WriteCobertura(&buffer)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		`<?xml version="1.0" encoding="UTF-8"?>
+<coverage line-rate="0.75" branch-rate="0.5" lines-covered="3" lines-valid="4" branches-covered="1" branches-valid="2" complexity="0">
+  <packages>
+    <package name="t.00" line-rate="0.75" branch-rate="0.5" complexity="0">
+      <classes>
+        <class name="t.00" filename="t.00" line-rate="0.75" branch-rate="0.5" complexity="0">
+          <methods></methods>
+          <lines>
+            <line number="3" hits="1" branch="false"></line>
+            <line number="4" hits="1" branch="true" condition-coverage="50% (1/2)"></line>
+            <line number="5" hits="1" branch="false"></line>
+            <line number="7" hits="0" branch="false"></line>
+          </lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>
+`,
+		buffer.String(),
+	)
+}
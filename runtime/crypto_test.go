@@ -30,6 +30,7 @@ import (
 	"github.com/onflow/cadence/encoding/json"
 
 	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/sema"
 	"github.com/onflow/cadence/runtime/tests/utils"
 )
@@ -234,6 +235,39 @@ func TestRuntimeHashAlgorithm_hash(t *testing.T) {
 		assert.True(t, called)
 		assert.Equal(t, "some-tag", hashTag)
 	})
+
+	t.Run("hash - metering", func(t *testing.T) {
+		script := `
+            pub fun main() {
+                HashAlgorithm.SHA3_256.hash("01020304".decodeHex())
+                HashAlgorithm.SHA3_256.hashWithTag(
+                    "01020304".decodeHex(),
+                    tag: "some-tag"
+                )
+            }
+        `
+
+		storage := newTestLedger(nil, nil)
+
+		computation := map[common.ComputationKind]uint{}
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: storage,
+			hash: func(data []byte, tag string, hashAlgorithm HashAlgorithm) ([]byte, error) {
+				return nil, nil
+			},
+			meterComputation: func(compKind common.ComputationKind, intensity uint) error {
+				computation[compKind] += intensity
+				return nil
+			},
+		}
+
+		_, err := executeScript(script, runtimeInterface)
+		require.NoError(t, err)
+
+		// 4 bytes of data, and 4 bytes of data with 8 bytes of tag
+		assert.Equal(t, uint(16), computation[common.ComputationKindSTDLIBHash])
+	})
 }
 
 func TestRuntimeHashingAlgorithmExport(t *testing.T) {
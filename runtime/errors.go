@@ -19,9 +19,11 @@
 package runtime
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
+	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
@@ -74,6 +76,14 @@ func (e CallStackLimitExceededError) Error() string {
 	)
 }
 
+// ExecutionCanceledError is reported when the execution was aborted,
+// because the GoContext of the Context was canceled or its deadline was exceeded.
+//
+// Use errors.Is with context.Canceled or context.DeadlineExceeded
+// to determine the cause.
+//
+type ExecutionCanceledError = interpreter.ExecutionCanceledError
+
 // InvalidTransactionCountError
 
 type InvalidTransactionCountError struct {
@@ -133,10 +143,18 @@ func (e *InvalidEntryPointArgumentError) Unwrap() error {
 }
 
 func (e *InvalidEntryPointArgumentError) Error() string {
+	message := e.Err.Error()
+
+	// report the path of the invalid value within the argument, e.g. `args[1].items[3].id`
+	var decodingErr *jsoncdc.TypedDecodingError
+	if errors.As(e.Err, &decodingErr) {
+		message = decodingErr.ErrorWithRoot(fmt.Sprintf("args[%d]", e.Index))
+	}
+
 	return fmt.Sprintf(
 		"invalid argument at index %d: %s",
 		e.Index,
-		e.Err.Error(),
+		message,
 	)
 }
 
@@ -184,6 +202,16 @@ func (e *InvalidScriptReturnTypeError) Error() string {
 	)
 }
 
+// InvalidScriptEntryPointPurityError is an error that is reported
+// when the entry point of a script is required to be a view function,
+// but it is not.
+//
+type InvalidScriptEntryPointPurityError struct{}
+
+func (e *InvalidScriptEntryPointPurityError) Error() string {
+	return "invalid script entry point: `main` must be a view function"
+}
+
 // ScriptParameterTypeNotStorableError is an error that is reported for
 // script parameter types that are not storable.
 //
@@ -343,6 +371,26 @@ func (e *FieldMismatchError) SecondaryError() string {
 	return e.Err.Error()
 }
 
+// TypeAliasMismatchError is reported during a contract update, when the aliased type
+// of a type alias does not match the existing aliased type of the same type alias.
+type TypeAliasMismatchError struct {
+	DeclName      string
+	TypeAliasName string
+	Err           error
+	ast.Range
+}
+
+func (e *TypeAliasMismatchError) Error() string {
+	return fmt.Sprintf("mismatching type alias `%s` in `%s`",
+		e.TypeAliasName,
+		e.DeclName,
+	)
+}
+
+func (e *TypeAliasMismatchError) SecondaryError() string {
+	return e.Err.Error()
+}
+
 // TypeMismatchError is reported during a contract update, when a type of the new program
 // does not match the existing type.
 type TypeMismatchError struct {
@@ -66,3 +66,19 @@ type ParentError interface {
 	error
 	ChildErrors() []error
 }
+
+// MemoryError indicates that a memory limit has been reached.
+// It must not be recovered from, e.g. by the lexer or parser,
+// and must abort the parsing, checking, or interpretation of the program.
+//
+type MemoryError struct {
+	Err error
+}
+
+func (e MemoryError) Error() string {
+	return fmt.Sprintf("memory error: %s", e.Err.Error())
+}
+
+func (e MemoryError) Unwrap() error {
+	return e.Err
+}
@@ -35,3 +35,17 @@ func Capability(borrowType string, address string, path string) string {
 		path,
 	)
 }
+
+func ControllerCapability(borrowType string, address string, id string) string {
+	var typeArgument string
+	if borrowType != "" {
+		typeArgument = fmt.Sprintf("<%s>", borrowType)
+	}
+
+	return fmt.Sprintf(
+		"Capability%s(address: %s, id: %s)",
+		typeArgument,
+		address,
+		id,
+	)
+}
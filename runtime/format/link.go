@@ -29,3 +29,12 @@ func Link(ty string, targetPath string) string {
 		targetPath,
 	)
 }
+
+func CapabilityController(borrowType string, capabilityID string, targetPath string) string {
+	return fmt.Sprintf(
+		"CapabilityController<%s>(capabilityID: %s, target: %s)",
+		borrowType,
+		capabilityID,
+		targetPath,
+	)
+}
@@ -0,0 +1,729 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	goHash "hash"
+	"math/big"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/onflow/atree"
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// DefaultInMemoryStorageCapacity is the storage capacity, in bytes,
+// that InMemoryInterface reports for every account.
+//
+const DefaultInMemoryStorageCapacity = 100_000_000
+
+// inMemoryTagLength is the length the domain separation tag is padded to
+// when it is prefixed to the hashed data.
+//
+const inMemoryTagLength = 32
+
+type inMemoryRegisterID struct {
+	owner string
+	key   string
+}
+
+type inMemoryAccount struct {
+	keys        []*AccountKey
+	encodedKeys [][]byte
+	contracts   map[string][]byte
+	balance     uint64
+}
+
+func (a *inMemoryAccount) copy() *inMemoryAccount {
+	keys := make([]*AccountKey, len(a.keys))
+	for i, key := range a.keys {
+		keyCopy := *key
+		keys[i] = &keyCopy
+	}
+
+	encodedKeys := make([][]byte, len(a.encodedKeys))
+	copy(encodedKeys, a.encodedKeys)
+
+	contracts := make(map[string][]byte, len(a.contracts))
+	for name, code := range a.contracts {
+		contracts[name] = code
+	}
+
+	return &inMemoryAccount{
+		keys:        keys,
+		encodedKeys: encodedKeys,
+		contracts:   contracts,
+		balance:     a.balance,
+	}
+}
+
+// InMemorySnapshot is a snapshot of the ledger of an InMemoryInterface:
+// the stored values, the storage indices, and the accounts,
+// including their keys, contracts, and balances.
+//
+type InMemorySnapshot struct {
+	values         map[inMemoryRegisterID][]byte
+	storageIndices map[string]uint64
+	accounts       map[common.Address]*inMemoryAccount
+	nextAddress    uint64
+}
+
+func (s *InMemorySnapshot) copy() *InMemorySnapshot {
+	values := make(map[inMemoryRegisterID][]byte, len(s.values))
+	for id, value := range s.values {
+		values[id] = value
+	}
+
+	storageIndices := make(map[string]uint64, len(s.storageIndices))
+	for owner, index := range s.storageIndices {
+		storageIndices[owner] = index
+	}
+
+	accounts := make(map[common.Address]*inMemoryAccount, len(s.accounts))
+	for address, account := range s.accounts {
+		accounts[address] = account.copy()
+	}
+
+	return &InMemorySnapshot{
+		values:         values,
+		storageIndices: storageIndices,
+		accounts:       accounts,
+		nextAddress:    s.nextAddress,
+	}
+}
+
+// InMemoryInterface is an in-memory implementation of Interface.
+//
+// It keeps the ledger, accounts, keys and contract code in memory,
+// verifies signatures using real cryptography,
+// and captures the emitted events and logged messages.
+//
+// Signature verification and public key validation
+// are only supported for ECDSA_P256.
+// KMAC128_BLS_BLS12_381 hashing and the BLS functions are not supported.
+//
+type InMemoryInterface struct {
+	values          map[inMemoryRegisterID][]byte
+	storageIndices  map[string]uint64
+	accounts        map[common.Address]*inMemoryAccount
+	nextAddress     uint64
+	programs        map[common.LocationID]*interpreter.Program
+	codes           map[common.LocationID][]byte
+	signingAccounts []Address
+	events          []cadence.Event
+	logs            []string
+	uuid            uint64
+	blocks          []Block
+	random          *rand.Rand
+}
+
+// InMemoryInterface should implement Interface
+var _ Interface = &InMemoryInterface{}
+
+// NewInMemoryInterface returns a new in-memory implementation of Interface,
+// which has no accounts and is at the genesis block.
+//
+func NewInMemoryInterface() *InMemoryInterface {
+	i := &InMemoryInterface{
+		values:         map[inMemoryRegisterID][]byte{},
+		storageIndices: map[string]uint64{},
+		accounts:       map[common.Address]*inMemoryAccount{},
+		nextAddress:    1,
+		programs:       map[common.LocationID]*interpreter.Program{},
+		codes:          map[common.LocationID][]byte{},
+		random:         rand.New(rand.NewSource(0)),
+	}
+	i.appendBlock(time.Now())
+	return i
+}
+
+// Snapshot returns a snapshot of the ledger,
+// which can later be restored using Restore.
+//
+func (i *InMemoryInterface) Snapshot() *InMemorySnapshot {
+	snapshot := &InMemorySnapshot{
+		values:         i.values,
+		storageIndices: i.storageIndices,
+		accounts:       i.accounts,
+		nextAddress:    i.nextAddress,
+	}
+	return snapshot.copy()
+}
+
+// Restore resets the ledger to the given snapshot.
+//
+// The snapshot itself is not modified and can be restored again.
+// All cached programs are discarded, as the contract code might have changed.
+//
+func (i *InMemoryInterface) Restore(snapshot *InMemorySnapshot) {
+	restored := snapshot.copy()
+
+	i.values = restored.values
+	i.storageIndices = restored.storageIndices
+	i.accounts = restored.accounts
+	i.nextAddress = restored.nextAddress
+	i.programs = map[common.LocationID]*interpreter.Program{}
+}
+
+// SetSigningAccounts sets the accounts which are returned by GetSigningAccounts,
+// i.e. the accounts which authorize the next transaction.
+//
+func (i *InMemoryInterface) SetSigningAccounts(addresses ...Address) {
+	i.signingAccounts = addresses
+}
+
+// SetCode sets the code for the given location,
+// e.g. for imports of locations other than address locations.
+//
+func (i *InMemoryInterface) SetCode(location Location, code []byte) {
+	i.codes[location.ID()] = code
+}
+
+// SetAccountBalance sets the balance of the given account.
+//
+func (i *InMemoryInterface) SetAccountBalance(address Address, balance uint64) error {
+	account, err := i.account(address)
+	if err != nil {
+		return err
+	}
+	account.balance = balance
+	return nil
+}
+
+// Events returns the events emitted so far.
+//
+func (i *InMemoryInterface) Events() []cadence.Event {
+	return i.events
+}
+
+// Logs returns the messages logged so far.
+//
+func (i *InMemoryInterface) Logs() []string {
+	return i.logs
+}
+
+// ClearEventsAndLogs discards the events emitted and messages logged so far.
+//
+func (i *InMemoryInterface) ClearEventsAndLogs() {
+	i.events = nil
+	i.logs = nil
+}
+
+// CommitBlock finalizes the current block and starts a new block
+// with the next height and the given timestamp.
+//
+func (i *InMemoryInterface) CommitBlock(timestamp time.Time) Block {
+	return i.appendBlock(timestamp)
+}
+
+func (i *InMemoryInterface) appendBlock(timestamp time.Time) Block {
+	height := uint64(len(i.blocks))
+
+	var encodedHeight [8]byte
+	binary.BigEndian.PutUint64(encodedHeight[:], height)
+
+	block := Block{
+		Height:    height,
+		View:      height,
+		Hash:      sha3.Sum256(encodedHeight[:]),
+		Timestamp: timestamp.UnixNano(),
+	}
+	i.blocks = append(i.blocks, block)
+	return block
+}
+
+func (i *InMemoryInterface) account(address Address) (*inMemoryAccount, error) {
+	account, ok := i.accounts[address]
+	if !ok {
+		return nil, fmt.Errorf("account does not exist: %s", address.ShortHexWithPrefix())
+	}
+	return account, nil
+}
+
+func (i *InMemoryInterface) ResolveLocation(identifiers []Identifier, location Location) ([]ResolvedLocation, error) {
+	addressLocation, isAddress := location.(common.AddressLocation)
+
+	// If the location is not an address location, e.g. a string location,
+	// then return a single resolved location which declares all identifiers.
+
+	if !isAddress {
+		return []ResolvedLocation{
+			{
+				Location:    location,
+				Identifiers: identifiers,
+			},
+		}, nil
+	}
+
+	// If the location is an address location,
+	// and no specific identifiers were requested in the import statement,
+	// then import all contracts of the account.
+
+	if len(identifiers) == 0 {
+		names, err := i.GetAccountContractNames(addressLocation.Address)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range names {
+			identifiers = append(identifiers, Identifier{
+				Identifier: name,
+			})
+		}
+	}
+
+	// Return one resolved location per identifier,
+	// as each contract is stored separately
+
+	resolvedLocations := make([]ResolvedLocation, len(identifiers))
+	for index, identifier := range identifiers {
+		resolvedLocations[index] = ResolvedLocation{
+			Location: common.AddressLocation{
+				Address: addressLocation.Address,
+				Name:    identifier.Identifier,
+			},
+			Identifiers: []Identifier{identifier},
+		}
+	}
+
+	return resolvedLocations, nil
+}
+
+func (i *InMemoryInterface) GetCode(location Location) ([]byte, error) {
+	if addressLocation, ok := location.(common.AddressLocation); ok {
+		return i.GetAccountContractCode(addressLocation.Address, addressLocation.Name)
+	}
+	return i.codes[location.ID()], nil
+}
+
+func (i *InMemoryInterface) GetProgram(location Location) (*interpreter.Program, error) {
+	return i.programs[location.ID()], nil
+}
+
+func (i *InMemoryInterface) SetProgram(location Location, program *interpreter.Program) error {
+	i.programs[location.ID()] = program
+	return nil
+}
+
+func (i *InMemoryInterface) GetValue(owner, key []byte) (value []byte, err error) {
+	return i.values[inMemoryRegisterID{owner: string(owner), key: string(key)}], nil
+}
+
+func (i *InMemoryInterface) SetValue(owner, key, value []byte) (err error) {
+	id := inMemoryRegisterID{owner: string(owner), key: string(key)}
+	if len(value) == 0 {
+		delete(i.values, id)
+	} else {
+		i.values[id] = value
+	}
+	return nil
+}
+
+func (i *InMemoryInterface) ValueExists(owner, key []byte) (exists bool, err error) {
+	value := i.values[inMemoryRegisterID{owner: string(owner), key: string(key)}]
+	return len(value) > 0, nil
+}
+
+func (i *InMemoryInterface) AllocateStorageIndex(owner []byte) (result atree.StorageIndex, err error) {
+	index := i.storageIndices[string(owner)] + 1
+	i.storageIndices[string(owner)] = index
+	binary.BigEndian.PutUint64(result[:], index)
+	return
+}
+
+func (i *InMemoryInterface) CreateAccount(_ Address) (address Address, err error) {
+	binary.BigEndian.PutUint64(address[:], i.nextAddress)
+	i.nextAddress++
+
+	i.accounts[address] = &inMemoryAccount{
+		contracts: map[string][]byte{},
+	}
+
+	return address, nil
+}
+
+func (i *InMemoryInterface) AddEncodedAccountKey(address Address, publicKey []byte) error {
+	account, err := i.account(address)
+	if err != nil {
+		return err
+	}
+	account.encodedKeys = append(account.encodedKeys, publicKey)
+	return nil
+}
+
+func (i *InMemoryInterface) RevokeEncodedAccountKey(address Address, index int) (publicKey []byte, err error) {
+	account, err := i.account(address)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(account.encodedKeys) {
+		return nil, fmt.Errorf("invalid encoded key index: %d", index)
+	}
+	publicKey = account.encodedKeys[index]
+	account.encodedKeys = append(account.encodedKeys[:index:index], account.encodedKeys[index+1:]...)
+	return publicKey, nil
+}
+
+func (i *InMemoryInterface) AddAccountKey(
+	address Address,
+	publicKey *PublicKey,
+	hashAlgo HashAlgorithm,
+	weight int,
+) (*AccountKey, error) {
+	account, err := i.account(address)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.ValidatePublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	accountKey := &AccountKey{
+		KeyIndex:  len(account.keys),
+		PublicKey: publicKey,
+		HashAlgo:  hashAlgo,
+		Weight:    weight,
+	}
+	account.keys = append(account.keys, accountKey)
+
+	keyCopy := *accountKey
+	return &keyCopy, nil
+}
+
+// GetAccountKey returns the key at the given index,
+// or nil if the account has no key at the index.
+//
+func (i *InMemoryInterface) GetAccountKey(address Address, index int) (*AccountKey, error) {
+	account, ok := i.accounts[address]
+	if !ok || index < 0 || index >= len(account.keys) {
+		return nil, nil
+	}
+
+	keyCopy := *account.keys[index]
+	return &keyCopy, nil
+}
+
+// RevokeAccountKey marks the key at the given index as revoked and returns it,
+// or returns nil if the account has no key at the index.
+//
+func (i *InMemoryInterface) RevokeAccountKey(address Address, index int) (*AccountKey, error) {
+	account, ok := i.accounts[address]
+	if !ok || index < 0 || index >= len(account.keys) {
+		return nil, nil
+	}
+
+	accountKey := account.keys[index]
+	accountKey.IsRevoked = true
+
+	keyCopy := *accountKey
+	return &keyCopy, nil
+}
+
+func (i *InMemoryInterface) UpdateAccountContractCode(address Address, name string, code []byte) (err error) {
+	account, err := i.account(address)
+	if err != nil {
+		return err
+	}
+	account.contracts[name] = code
+
+	// The program of the previous code is stale
+	delete(i.programs, common.AddressLocation{Address: address, Name: name}.ID())
+
+	return nil
+}
+
+func (i *InMemoryInterface) GetAccountContractCode(address Address, name string) (code []byte, err error) {
+	account, ok := i.accounts[address]
+	if !ok {
+		return nil, nil
+	}
+	return account.contracts[name], nil
+}
+
+func (i *InMemoryInterface) RemoveAccountContractCode(address Address, name string) (err error) {
+	account, err := i.account(address)
+	if err != nil {
+		return err
+	}
+	delete(account.contracts, name)
+	delete(i.programs, common.AddressLocation{Address: address, Name: name}.ID())
+	return nil
+}
+
+func (i *InMemoryInterface) GetAccountContractNames(address Address) ([]string, error) {
+	names := []string{}
+
+	account, ok := i.accounts[address]
+	if !ok {
+		return names, nil
+	}
+
+	for name := range account.contracts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (i *InMemoryInterface) GetSigningAccounts() ([]Address, error) {
+	return i.signingAccounts, nil
+}
+
+func (i *InMemoryInterface) ProgramLog(message string) error {
+	i.logs = append(i.logs, message)
+	return nil
+}
+
+func (i *InMemoryInterface) EmitEvent(event cadence.Event) error {
+	i.events = append(i.events, event)
+	return nil
+}
+
+func (i *InMemoryInterface) GenerateUUID() (uint64, error) {
+	i.uuid++
+	return i.uuid, nil
+}
+
+func (i *InMemoryInterface) MeterComputation(_ common.ComputationKind, _ uint) error {
+	return nil
+}
+
+func (i *InMemoryInterface) MeterMemory(_ common.MemoryUsage) error {
+	return nil
+}
+
+func (i *InMemoryInterface) DecodeArgument(argument []byte, argumentType cadence.Type) (cadence.Value, error) {
+	return jsoncdc.DecodeWithType(argument, argumentType, jsoncdc.DefaultLimits)
+}
+
+func (i *InMemoryInterface) GetCurrentBlockHeight() (uint64, error) {
+	return uint64(len(i.blocks) - 1), nil
+}
+
+func (i *InMemoryInterface) GetBlockAtHeight(height uint64) (block Block, exists bool, err error) {
+	if height >= uint64(len(i.blocks)) {
+		return Block{}, false, nil
+	}
+	return i.blocks[height], true, nil
+}
+
+func (i *InMemoryInterface) UnsafeRandom() (uint64, error) {
+	return i.random.Uint64(), nil
+}
+
+func (i *InMemoryInterface) VerifySignature(
+	signature []byte,
+	tag string,
+	signedData []byte,
+	publicKey []byte,
+	signatureAlgorithm SignatureAlgorithm,
+	hashAlgorithm HashAlgorithm,
+) (bool, error) {
+
+	curve, err := inMemoryCurve(signatureAlgorithm)
+	if err != nil {
+		return false, err
+	}
+
+	key, err := inMemoryECDSAPublicKey(curve, publicKey)
+	if err != nil {
+		return false, err
+	}
+
+	digest, err := i.Hash(signedData, tag, hashAlgorithm)
+	if err != nil {
+		return false, err
+	}
+
+	// The signature is the concatenation of r and s,
+	// each padded to the byte size of the curve order
+
+	size := (curve.Params().N.BitLen() + 7) / 8
+	if len(signature) != 2*size {
+		return false, nil
+	}
+
+	r := new(big.Int).SetBytes(signature[:size])
+	s := new(big.Int).SetBytes(signature[size:])
+
+	return ecdsa.Verify(key, digest, r, s), nil
+}
+
+// Hash returns the digest of the given data.
+//
+// If the tag is not empty, it is padded with zeros to 32 bytes
+// and prefixed to the data.
+//
+func (i *InMemoryInterface) Hash(data []byte, tag string, hashAlgorithm HashAlgorithm) ([]byte, error) {
+	var hasher goHash.Hash
+
+	switch hashAlgorithm {
+	case HashAlgorithmSHA2_256:
+		hasher = sha256.New()
+	case HashAlgorithmSHA2_384:
+		hasher = sha512.New384()
+	case HashAlgorithmSHA3_256:
+		hasher = sha3.New256()
+	case HashAlgorithmSHA3_384:
+		hasher = sha3.New384()
+	case HashAlgorithmKECCAK_256:
+		hasher = sha3.NewLegacyKeccak256()
+	default:
+		return nil, fmt.Errorf("unsupported hash algorithm: %s", hashAlgorithm.Name())
+	}
+
+	if tag != "" {
+		if len(tag) > inMemoryTagLength {
+			return nil, fmt.Errorf(
+				"tag is too long: expected at most %d bytes, got %d",
+				inMemoryTagLength,
+				len(tag),
+			)
+		}
+
+		var paddedTag [inMemoryTagLength]byte
+		copy(paddedTag[:], tag)
+		hasher.Write(paddedTag[:])
+	}
+
+	hasher.Write(data)
+
+	return hasher.Sum(nil), nil
+}
+
+func (i *InMemoryInterface) GetAccountBalance(address common.Address) (value uint64, err error) {
+	account, ok := i.accounts[address]
+	if !ok {
+		return 0, nil
+	}
+	return account.balance, nil
+}
+
+func (i *InMemoryInterface) GetAccountAvailableBalance(address common.Address) (value uint64, err error) {
+	return i.GetAccountBalance(address)
+}
+
+// GetStorageUsed returns the sum of the sizes of all keys and values stored in the account.
+//
+func (i *InMemoryInterface) GetStorageUsed(address Address) (value uint64, err error) {
+	owner := string(address[:])
+	for id, storedValue := range i.values {
+		if id.owner != owner {
+			continue
+		}
+		value += uint64(len(id.key) + len(storedValue))
+	}
+	return value, nil
+}
+
+func (i *InMemoryInterface) GetStorageCapacity(_ Address) (value uint64, err error) {
+	return DefaultInMemoryStorageCapacity, nil
+}
+
+func (i *InMemoryInterface) ImplementationDebugLog(_ string) error {
+	return nil
+}
+
+func (i *InMemoryInterface) ValidatePublicKey(key *PublicKey) error {
+	curve, err := inMemoryCurve(key.SignAlgo)
+	if err != nil {
+		return err
+	}
+
+	_, err = inMemoryECDSAPublicKey(curve, key.PublicKey)
+	return err
+}
+
+func (i *InMemoryInterface) RecordTrace(_ string, _ common.Location, _ time.Duration, _ []opentracing.LogRecord) {
+	// NO-OP
+}
+
+func (i *InMemoryInterface) BLSVerifyPOP(_ *PublicKey, _ []byte) (bool, error) {
+	return false, fmt.Errorf("BLS is not supported")
+}
+
+func (i *InMemoryInterface) BLSAggregateSignatures(_ [][]byte) ([]byte, error) {
+	return nil, fmt.Errorf("BLS is not supported")
+}
+
+func (i *InMemoryInterface) BLSAggregatePublicKeys(_ []*PublicKey) (*PublicKey, error) {
+	return nil, fmt.Errorf("BLS is not supported")
+}
+
+func (i *InMemoryInterface) ResourceOwnerChanged(
+	_ *interpreter.Interpreter,
+	_ *interpreter.CompositeValue,
+	_ common.Address,
+	_ common.Address,
+) {
+	// NO-OP
+}
+
+func inMemoryCurve(signatureAlgorithm SignatureAlgorithm) (elliptic.Curve, error) {
+	switch signatureAlgorithm {
+	case SignatureAlgorithmECDSA_P256:
+		return elliptic.P256(), nil
+	default:
+		return nil, fmt.Errorf("unsupported signature algorithm: %s", signatureAlgorithm.Name())
+	}
+}
+
+// inMemoryECDSAPublicKey decodes the given public key,
+// the concatenation of the X and Y coordinates,
+// and ensures the point is on the given curve.
+//
+func inMemoryECDSAPublicKey(curve elliptic.Curve, publicKey []byte) (*ecdsa.PublicKey, error) {
+	size := (curve.Params().BitSize + 7) / 8
+	if len(publicKey) != 2*size {
+		return nil, fmt.Errorf(
+			"invalid public key length: expected %d bytes, got %d",
+			2*size,
+			len(publicKey),
+		)
+	}
+
+	x := new(big.Int).SetBytes(publicKey[:size])
+	y := new(big.Int).SetBytes(publicKey[size:])
+
+	if !curve.IsOnCurve(x, y) {
+		return nil, fmt.Errorf("invalid public key: point is not on curve")
+	}
+
+	return &ecdsa.PublicKey{
+		Curve: curve,
+		X:     x,
+		Y:     y,
+	}, nil
+}
@@ -0,0 +1,368 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestRuntimeInMemoryInterface(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("deploy and import contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+		runtimeInterface := NewInMemoryInterface()
+
+		address, err := runtimeInterface.CreateAccount(common.Address{})
+		require.NoError(t, err)
+		assert.Equal(t, common.Address{0, 0, 0, 0, 0, 0, 0, 1}, address)
+
+		runtimeInterface.SetSigningAccounts(address)
+
+		contract := []byte(`
+          pub contract Test {
+              pub event Hello(message: String)
+
+              pub fun hello(): String {
+                  emit Hello(message: "hello")
+                  return "hello"
+              }
+          }
+        `)
+
+		err = runtime.ExecuteTransaction(
+			Script{
+				Source: utils.DeploymentTransaction("Test", contract),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{0},
+			},
+		)
+		require.NoError(t, err)
+
+		names, err := runtimeInterface.GetAccountContractNames(address)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Test"}, names)
+
+		script := []byte(fmt.Sprintf(
+			`
+              import Test from %s
+
+              pub fun main(): String {
+                  log("calling")
+                  return Test.hello()
+              }
+            `,
+			address.HexWithPrefix(),
+		))
+
+		result, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.String("hello"), result)
+
+		assert.Equal(t, []string{`"calling"`}, runtimeInterface.Logs())
+
+		events := runtimeInterface.Events()
+		require.Len(t, events, 2)
+		assert.Equal(t, "flow.AccountContractAdded", events[0].EventType.ID())
+		assert.Equal(t, "A.0000000000000001.Test.Hello", events[1].EventType.ID())
+
+		runtimeInterface.ClearEventsAndLogs()
+		assert.Empty(t, runtimeInterface.Events())
+		assert.Empty(t, runtimeInterface.Logs())
+	})
+
+	t.Run("snapshot and restore", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+		runtimeInterface := NewInMemoryInterface()
+
+		address, err := runtimeInterface.CreateAccount(common.Address{})
+		require.NoError(t, err)
+
+		runtimeInterface.SetSigningAccounts(address)
+
+		snapshot := runtimeInterface.Snapshot()
+
+		err = runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          signer.save(42, to: /storage/answer)
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{0},
+			},
+		)
+		require.NoError(t, err)
+
+		storageUsed, err := runtimeInterface.GetStorageUsed(address)
+		require.NoError(t, err)
+		assert.NotZero(t, storageUsed)
+
+		script := []byte(fmt.Sprintf(
+			`
+              pub fun main(): Int? {
+                  return getAuthAccount(%s).copy<Int>(from: /storage/answer)
+              }
+            `,
+			address.HexWithPrefix(),
+		))
+
+		executeScript := func() cadence.Value {
+			result, err := runtime.ExecuteScript(
+				Script{
+					Source: script,
+				},
+				Context{
+					Interface: runtimeInterface,
+					Location:  common.ScriptLocation{},
+				},
+			)
+			require.NoError(t, err)
+			return result
+		}
+
+		assert.Equal(t,
+			cadence.NewOptional(cadence.NewInt(42)),
+			executeScript(),
+		)
+
+		runtimeInterface.Restore(snapshot)
+
+		// The storage used is determined from the restored registers.
+		// Check it before executing the script,
+		// as reading the storage may create the account's storage map
+
+		storageUsed, err = runtimeInterface.GetStorageUsed(address)
+		require.NoError(t, err)
+		assert.Zero(t, storageUsed)
+
+		assert.Equal(t,
+			cadence.NewOptional(nil),
+			executeScript(),
+		)
+
+		// Accounts created after the snapshot are discarded
+
+		newAddress, err := runtimeInterface.CreateAccount(common.Address{})
+		require.NoError(t, err)
+
+		snapshot = runtimeInterface.Snapshot()
+		runtimeInterface.Restore(snapshot)
+
+		otherAddress, err := runtimeInterface.CreateAccount(common.Address{})
+		require.NoError(t, err)
+		assert.NotEqual(t, newAddress, otherAddress)
+	})
+
+	t.Run("account keys and signature verification", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+		runtimeInterface := NewInMemoryInterface()
+
+		address, err := runtimeInterface.CreateAccount(common.Address{})
+		require.NoError(t, err)
+
+		runtimeInterface.SetSigningAccounts(address)
+
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		var encodedPublicKey [64]byte
+		privateKey.X.FillBytes(encodedPublicKey[:32])
+		privateKey.Y.FillBytes(encodedPublicKey[32:])
+
+		err = runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(fmt.Sprintf(
+					`
+                      transaction {
+                          prepare(signer: AuthAccount) {
+                              let publicKey = PublicKey(
+                                  publicKey: "%s".decodeHex(),
+                                  signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                              )
+                              signer.keys.add(
+                                  publicKey: publicKey,
+                                  hashAlgorithm: HashAlgorithm.SHA3_256,
+                                  weight: 1000.0
+                              )
+                          }
+                      }
+                    `,
+					hex.EncodeToString(encodedPublicKey[:]),
+				)),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{0},
+			},
+		)
+		require.NoError(t, err)
+
+		accountKey, err := runtimeInterface.GetAccountKey(address, 0)
+		require.NoError(t, err)
+		require.NotNil(t, accountKey)
+		assert.Equal(t, encodedPublicKey[:], accountKey.PublicKey.PublicKey)
+		assert.Equal(t, HashAlgorithmSHA3_256, accountKey.HashAlgo)
+		assert.False(t, accountKey.IsRevoked)
+
+		const tag = "FLOW-V0.0-user"
+		message := []byte("hello")
+
+		var paddedTag [32]byte
+		copy(paddedTag[:], tag)
+		digest := sha3.Sum256(append(paddedTag[:], message...))
+
+		r, s, err := ecdsa.Sign(rand.Reader, privateKey, digest[:])
+		require.NoError(t, err)
+
+		var signature [64]byte
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+
+		verify := func(signature []byte) cadence.Value {
+			script := []byte(fmt.Sprintf(
+				`
+                  pub fun main(): Bool {
+                      let key = getAccount(%s).keys.get(keyIndex: 0)!
+                      return key.publicKey.verify(
+                          signature: "%s".decodeHex(),
+                          signedData: "%s".decodeHex(),
+                          domainSeparationTag: "%s",
+                          hashAlgorithm: key.hashAlgorithm
+                      )
+                  }
+                `,
+				address.HexWithPrefix(),
+				hex.EncodeToString(signature),
+				hex.EncodeToString(message),
+				tag,
+			))
+
+			result, err := runtime.ExecuteScript(
+				Script{
+					Source: script,
+				},
+				Context{
+					Interface: runtimeInterface,
+					Location:  common.ScriptLocation{},
+				},
+			)
+			require.NoError(t, err)
+			return result
+		}
+
+		assert.Equal(t, cadence.NewBool(true), verify(signature[:]))
+
+		signature[0] ^= 0xff
+		assert.Equal(t, cadence.NewBool(false), verify(signature[:]))
+
+		revokedKey, err := runtimeInterface.RevokeAccountKey(address, 0)
+		require.NoError(t, err)
+		require.NotNil(t, revokedKey)
+		assert.True(t, revokedKey.IsRevoked)
+
+		missingKey, err := runtimeInterface.GetAccountKey(address, 1)
+		require.NoError(t, err)
+		assert.Nil(t, missingKey)
+
+		err = runtimeInterface.ValidatePublicKey(&PublicKey{
+			PublicKey: make([]byte, 64),
+			SignAlgo:  SignatureAlgorithmECDSA_P256,
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("blocks", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+		runtimeInterface := NewInMemoryInterface()
+
+		timestamp := time.Unix(1000, 0)
+		block := runtimeInterface.CommitBlock(timestamp)
+		assert.Equal(t, uint64(1), block.Height)
+
+		result, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(`
+                  pub fun main(): [UInt64] {
+                      let block = getCurrentBlock()
+                      return [block.height, getBlock(at: 0)!.height]
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+		assert.Equal(t,
+			cadence.NewArray([]cadence.Value{
+				cadence.NewUInt64(1),
+				cadence.NewUInt64(0),
+			}),
+			result,
+		)
+
+		_, exists, err := runtimeInterface.GetBlockAtHeight(2)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
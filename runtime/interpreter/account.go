@@ -21,6 +21,7 @@ package interpreter
 import (
 	"fmt"
 
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/sema"
 )
 
@@ -35,6 +36,7 @@ var authAccountFieldNames = []string{
 	sema.AuthAccountAddressField,
 	sema.AuthAccountContractsField,
 	sema.AuthAccountKeysField,
+	sema.AuthAccountCapabilitiesField,
 }
 
 // NewAuthAccountValue constructs an auth account value.
@@ -63,6 +65,7 @@ func NewAuthAccountValue(
 
 	var contracts Value
 	var keys Value
+	var capabilities Value
 
 	computedFields := map[string]ComputedField{
 		sema.AuthAccountContractsField: func(_ *Interpreter, _ func() LocationRange) Value {
@@ -77,6 +80,12 @@ func NewAuthAccountValue(
 			}
 			return keys
 		},
+		sema.AuthAccountCapabilitiesField: func(inter *Interpreter, _ func() LocationRange) Value {
+			if capabilities == nil {
+				capabilities = inter.authAccountCapabilitiesValue(address)
+			}
+			return capabilities
+		},
 		sema.AuthAccountBalanceField: func(_ *Interpreter, _ func() LocationRange) Value {
 			return accountBalanceGet()
 		},
@@ -113,6 +122,27 @@ func NewAuthAccountValue(
 		sema.AuthAccountGetLinkTargetField: func(inter *Interpreter, _ func() LocationRange) Value {
 			return inter.accountGetLinkTargetFunction(address)
 		},
+		sema.AuthAccountForEachStoredField: func(inter *Interpreter, _ func() LocationRange) Value {
+			return inter.accountForEachFunction(
+				address,
+				common.PathDomainStorage,
+				sema.AuthAccountForEachStoredFunctionType,
+			)
+		},
+		sema.AuthAccountForEachPublicField: func(inter *Interpreter, _ func() LocationRange) Value {
+			return inter.accountForEachFunction(
+				address,
+				common.PathDomainPublic,
+				sema.AccountForEachPublicFunctionType,
+			)
+		},
+		sema.AuthAccountForEachPrivateField: func(inter *Interpreter, _ func() LocationRange) Value {
+			return inter.accountForEachFunction(
+				address,
+				common.PathDomainPrivate,
+				sema.AuthAccountForEachPrivateFunctionType,
+			)
+		},
 	}
 
 	var str string
@@ -199,6 +229,13 @@ func NewPublicAccountValue(
 		sema.PublicAccountGetTargetLinkField: func(inter *Interpreter, _ func() LocationRange) Value {
 			return inter.accountGetLinkTargetFunction(address)
 		},
+		sema.PublicAccountForEachPublicField: func(inter *Interpreter, _ func() LocationRange) Value {
+			return inter.accountForEachFunction(
+				address,
+				common.PathDomainPublic,
+				sema.AccountForEachPublicFunctionType,
+			)
+		},
 	}
 
 	var str string
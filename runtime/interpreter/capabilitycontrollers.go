@@ -0,0 +1,512 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// CapabilityControllerStorageDomain is the storage domain
+// which stores the capability controllers of an account, keyed by capability ID
+//
+const CapabilityControllerStorageDomain = "cap_con"
+
+// CapabilityIDStorageDomain is the storage domain
+// which stores the last capability ID issued by an account
+//
+const CapabilityIDStorageDomain = "cap_id"
+
+const capabilityIDStorageKey = "last"
+
+func capabilityControllerStorageKey(capabilityID UInt64Value) string {
+	return strconv.FormatUint(uint64(capabilityID), 10)
+}
+
+// nextCapabilityID returns a new capability ID for the given account.
+// IDs start at 1, the ID 0 is reserved for capabilities which are not
+// issued through a controller, i.e. path-based capabilities created by `link`.
+//
+func (interpreter *Interpreter) nextCapabilityID(address common.Address) UInt64Value {
+	var capabilityID UInt64Value
+
+	lastCapabilityID := interpreter.ReadStored(
+		address,
+		CapabilityIDStorageDomain,
+		capabilityIDStorageKey,
+	)
+	if lastCapabilityID != nil {
+		var ok bool
+		capabilityID, ok = lastCapabilityID.(UInt64Value)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+	}
+
+	capabilityID++
+
+	interpreter.writeStored(
+		address,
+		CapabilityIDStorageDomain,
+		capabilityIDStorageKey,
+		capabilityID,
+	)
+
+	return capabilityID
+}
+
+// readCapabilityController returns the capability controller with the given ID,
+// or nil if no such controller exists, e.g. because it was revoked
+//
+func (interpreter *Interpreter) readCapabilityController(
+	address common.Address,
+	capabilityID UInt64Value,
+) *CapabilityControllerValue {
+
+	value := interpreter.ReadStored(
+		address,
+		CapabilityControllerStorageDomain,
+		capabilityControllerStorageKey(capabilityID),
+	)
+	if value == nil {
+		return nil
+	}
+
+	controller, ok := value.(CapabilityControllerValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	return &controller
+}
+
+func (interpreter *Interpreter) writeCapabilityController(
+	address common.Address,
+	controller CapabilityControllerValue,
+) {
+	interpreter.writeStored(
+		address,
+		CapabilityControllerStorageDomain,
+		capabilityControllerStorageKey(controller.CapabilityID),
+		controller,
+	)
+}
+
+// GetCapabilityControllerTargetPath returns the storage path targeted
+// by the capability controller with the given ID.
+//
+// If the controller was revoked, or its borrow type does not permit
+// borrowing with the wanted borrow type, the empty path is returned.
+//
+func (interpreter *Interpreter) GetCapabilityControllerTargetPath(
+	address common.Address,
+	capabilityID UInt64Value,
+	wantedBorrowType *sema.ReferenceType,
+) (
+	targetPath PathValue,
+	authorized bool,
+) {
+	controller := interpreter.readCapabilityController(address, capabilityID)
+	if controller == nil {
+		return EmptyPathValue, false
+	}
+
+	allowedType := interpreter.MustConvertStaticToSemaType(controller.BorrowType)
+
+	if !sema.IsSubType(allowedType, wantedBorrowType) {
+		return EmptyPathValue, false
+	}
+
+	return controller.TargetPath, wantedBorrowType.Authorized
+}
+
+// AuthAccount.Capabilities
+
+var authAccountCapabilitiesTypeID = sema.AuthAccountCapabilitiesType.ID()
+var authAccountCapabilitiesStaticType StaticType = PrimitiveStaticTypeAuthAccountCapabilities
+var authAccountCapabilitiesDynamicType DynamicType = CompositeDynamicType{
+	StaticType: sema.AuthAccountCapabilitiesType,
+}
+
+// NewAuthAccountCapabilitiesValue constructs a AuthAccount.Capabilities value.
+func NewAuthAccountCapabilitiesValue(
+	address AddressValue,
+	issueFunction FunctionValue,
+	getControllerFunction FunctionValue,
+	getControllersFunction FunctionValue,
+	publishFunction FunctionValue,
+) Value {
+
+	fields := map[string]Value{
+		sema.AuthAccountCapabilitiesTypeIssueFunctionName:          issueFunction,
+		sema.AuthAccountCapabilitiesTypeGetControllerFunctionName:  getControllerFunction,
+		sema.AuthAccountCapabilitiesTypeGetControllersFunctionName: getControllersFunction,
+		sema.AuthAccountCapabilitiesTypePublishFunctionName:        publishFunction,
+	}
+
+	var str string
+	stringer := func(_ SeenReferences) string {
+		if str == "" {
+			str = fmt.Sprintf("AuthAccount.Capabilities(%s)", address)
+		}
+		return str
+	}
+
+	return NewSimpleCompositeValue(
+		authAccountCapabilitiesTypeID,
+		authAccountCapabilitiesStaticType,
+		authAccountCapabilitiesDynamicType,
+		nil,
+		fields,
+		nil,
+		nil,
+		stringer,
+	)
+}
+
+func (interpreter *Interpreter) authAccountCapabilitiesValue(addressValue AddressValue) Value {
+	return NewAuthAccountCapabilitiesValue(
+		addressValue,
+		interpreter.authAccountCapabilitiesIssueFunction(addressValue),
+		interpreter.authAccountCapabilitiesGetControllerFunction(addressValue),
+		interpreter.authAccountCapabilitiesGetControllersFunction(addressValue),
+		interpreter.authAccountCapabilitiesPublishFunction(addressValue),
+	)
+}
+
+func (interpreter *Interpreter) authAccountCapabilitiesIssueFunction(addressValue AddressValue) *HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return NewHostFunctionValue(
+		func(invocation Invocation) Value {
+
+			typeParameterPair := invocation.TypeParameterTypes.Oldest()
+			if typeParameterPair == nil {
+				panic(errors.NewUnreachableError())
+			}
+
+			borrowType, ok := typeParameterPair.Value.(*sema.ReferenceType)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			targetPath, ok := invocation.Arguments[0].(PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			borrowStaticType := ConvertSemaToStaticType(borrowType)
+
+			capabilityID := interpreter.nextCapabilityID(address)
+
+			interpreter.writeCapabilityController(
+				address,
+				CapabilityControllerValue{
+					BorrowType:   borrowStaticType,
+					TargetPath:   targetPath,
+					CapabilityID: capabilityID,
+				},
+			)
+
+			common.UseMemory(invocation.Interpreter.memoryGauge, common.CapabilityValueMemoryUsage)
+
+			return &CapabilityValue{
+				Address:    addressValue,
+				Path:       EmptyPathValue,
+				BorrowType: borrowStaticType,
+				ID:         capabilityID,
+			}
+		},
+		sema.AuthAccountCapabilitiesTypeIssueFunctionType,
+	)
+}
+
+func (interpreter *Interpreter) authAccountCapabilitiesGetControllerFunction(addressValue AddressValue) *HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return NewHostFunctionValue(
+		func(invocation Invocation) Value {
+
+			capabilityID, ok := invocation.Arguments[0].(UInt64Value)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			controller := interpreter.readCapabilityController(address, capabilityID)
+			if controller == nil {
+				return NilValue{}
+			}
+
+			return NewSomeValueNonCopying(
+				interpreter.newCapabilityControllerValue(address, *controller),
+			)
+		},
+		sema.AuthAccountCapabilitiesTypeGetControllerFunctionType,
+	)
+}
+
+// authAccountCapabilitiesGetControllersFunction returns the function `getControllers`.
+//
+// The controllers are not indexed by target path, so the function reads
+// all controllers of the account, and its cost is linear in their number
+//
+func (interpreter *Interpreter) authAccountCapabilitiesGetControllersFunction(addressValue AddressValue) *HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return NewHostFunctionValue(
+		func(invocation Invocation) Value {
+
+			targetPath, ok := invocation.Arguments[0].(PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			var controllers []CapabilityControllerValue
+
+			storageMap := interpreter.Storage.GetStorageMap(
+				address,
+				CapabilityControllerStorageDomain,
+				false,
+			)
+			if storageMap != nil {
+				iterator := storageMap.Iterator()
+
+				for {
+					_, value := iterator.Next()
+					if value == nil {
+						break
+					}
+
+					controller, ok := value.(CapabilityControllerValue)
+					if !ok {
+						panic(errors.NewUnreachableError())
+					}
+
+					if controller.TargetPath != targetPath {
+						continue
+					}
+
+					controllers = append(controllers, controller)
+				}
+			}
+
+			// The storage map is ordered by the hash of the key,
+			// sort the controllers so the result is deterministic and predictable
+
+			sort.Slice(controllers, func(i, j int) bool {
+				return controllers[i].CapabilityID < controllers[j].CapabilityID
+			})
+
+			values := make([]Value, len(controllers))
+			for i, controller := range controllers {
+				values[i] = interpreter.newCapabilityControllerValue(address, controller)
+			}
+
+			return NewArrayValue(
+				interpreter,
+				VariableSizedStaticType{
+					Type: PrimitiveStaticTypeCapabilityController,
+				},
+				common.Address{},
+				values...,
+			)
+		},
+		sema.AuthAccountCapabilitiesTypeGetControllersFunctionType,
+	)
+}
+
+func (interpreter *Interpreter) authAccountCapabilitiesPublishFunction(addressValue AddressValue) *HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return NewHostFunctionValue(
+		func(invocation Invocation) Value {
+
+			capability, ok := invocation.Arguments[0].(*CapabilityValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			path, ok := invocation.Arguments[1].(PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			// Only capabilities issued by this account's controllers can be published.
+			// Path-based capabilities are published using `link`
+
+			if !capability.IsControllerCapability() ||
+				capability.Address.ToAddress() != address {
+
+				panic(InvalidCapabilityPublicationError{
+					Address:       addressValue,
+					LocationRange: invocation.GetLocationRange(),
+				})
+			}
+
+			domain := path.Domain.Identifier()
+			identifier := path.Identifier
+
+			if interpreter.storedValueExists(address, domain, identifier) {
+				panic(OverwriteError{
+					Address:       addressValue,
+					Path:          path,
+					LocationRange: invocation.GetLocationRange(),
+				})
+			}
+
+			interpreter.writeStored(
+				address,
+				domain,
+				identifier,
+				capability.Clone(interpreter),
+			)
+
+			return VoidValue{}
+		},
+		sema.AuthAccountCapabilitiesTypePublishFunctionType,
+	)
+}
+
+// CapabilityController
+
+var capabilityControllerTypeID = sema.CapabilityControllerType.ID()
+var capabilityControllerStaticType StaticType = PrimitiveStaticTypeCapabilityController
+var capabilityControllerDynamicType DynamicType = CompositeDynamicType{
+	StaticType: sema.CapabilityControllerType,
+}
+var capabilityControllerFieldNames = []string{
+	sema.CapabilityControllerTypeCapabilityIDField,
+	sema.CapabilityControllerTypeBorrowTypeField,
+	sema.CapabilityControllerTypeTagField,
+}
+
+// newCapabilityControllerValue constructs a CapabilityController value,
+// which gives programs access to the stored capability controller.
+//
+// The tag is read from storage on each access,
+// so the value reflects changes made through other references to the same controller.
+//
+func (interpreter *Interpreter) newCapabilityControllerValue(
+	address common.Address,
+	controller CapabilityControllerValue,
+) Value {
+
+	capabilityID := controller.CapabilityID
+
+	mustReadController := func(getLocationRange func() LocationRange) *CapabilityControllerValue {
+		controller := interpreter.readCapabilityController(address, capabilityID)
+		if controller == nil {
+			panic(RevokedCapabilityControllerError{
+				Address:       address,
+				CapabilityID:  capabilityID,
+				LocationRange: getLocationRange(),
+			})
+		}
+		return controller
+	}
+
+	fields := map[string]Value{
+		sema.CapabilityControllerTypeCapabilityIDField: capabilityID,
+		sema.CapabilityControllerTypeBorrowTypeField: TypeValue{
+			Type: controller.BorrowType,
+		},
+		sema.CapabilityControllerTypeSetTagFunctionName: NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				tag, ok := invocation.Arguments[0].(*StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				controller := mustReadController(invocation.GetLocationRange)
+				controller.Tag = tag.Str
+
+				interpreter.writeCapabilityController(address, *controller)
+
+				return VoidValue{}
+			},
+			sema.CapabilityControllerTypeSetTagFunctionType,
+		),
+		sema.CapabilityControllerTypeTargetFunctionName: NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				controller := mustReadController(invocation.GetLocationRange)
+				return controller.TargetPath
+			},
+			sema.CapabilityControllerTypeTargetFunctionType,
+		),
+		sema.CapabilityControllerTypeRevokeFunctionName: NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				// Ensure the controller was not already revoked
+				mustReadController(invocation.GetLocationRange)
+
+				interpreter.writeStored(
+					address,
+					CapabilityControllerStorageDomain,
+					capabilityControllerStorageKey(capabilityID),
+					nil,
+				)
+
+				return VoidValue{}
+			},
+			sema.CapabilityControllerTypeRevokeFunctionType,
+		),
+	}
+
+	computedFields := map[string]ComputedField{
+		sema.CapabilityControllerTypeTagField: func(interpreter *Interpreter, getLocationRange func() LocationRange) Value {
+			controller := mustReadController(getLocationRange)
+			memoryUsage := common.NewStringMemoryUsage(len(controller.Tag))
+
+			return NewStringValue(
+				interpreter,
+				memoryUsage,
+				func() string {
+					return controller.Tag
+				},
+			)
+		},
+	}
+
+	stringer := func(_ SeenReferences) string {
+		return controller.String()
+	}
+
+	return NewSimpleCompositeValue(
+		capabilityControllerTypeID,
+		capabilityControllerStaticType,
+		capabilityControllerDynamicType,
+		capabilityControllerFieldNames,
+		fields,
+		computedFields,
+		nil,
+		stringer,
+	)
+}
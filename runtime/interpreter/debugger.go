@@ -19,26 +19,107 @@
 package interpreter
 
 import (
+	"sort"
+	"sync"
 	"sync/atomic"
 
 	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
 )
 
+// debuggerLocation is the location of the sub-interpreters
+// which evaluate expressions for the debugger, e.g. breakpoint conditions
+//
+var debuggerLocation = common.IdentifierLocation("debugger")
+
+// Stop is a point in the execution where the debugger paused the execution.
+//
 type Stop struct {
 	Interpreter *Interpreter
 	Statement   ast.Statement
+	// Breakpoint is the breakpoint that caused the stop, if any
+	Breakpoint *Breakpoint
+	// ConditionError is the error that occurred when the condition of the breakpoint was evaluated, if any.
+	// The execution is stopped when the condition of a breakpoint cannot be evaluated
+	ConditionError error
+}
+
+// Breakpoint stops the execution before a statement on a line in a program.
+//
+// If the breakpoint has a condition, the execution is only stopped
+// if the condition evaluates to true in the activation of the statement.
+//
+type Breakpoint struct {
+	ID        int
+	Location  common.Location
+	Line      int
+	Condition ast.Expression
+}
+
+type breakpointKey struct {
+	locationID common.LocationID
+	line       int
+}
+
+// StackFrame is the activation record of an invocation of an interpreted function.
+//
+type StackFrame struct {
+	Interpreter *Interpreter
+	Function    *InterpretedFunctionValue
+	// Statement is the statement of the function that is currently executed.
+	// It is nil if no statement of the function was executed yet
+	Statement ast.Statement
+	// Activation is the activation of the statement that is currently executed
+	Activation *VariableActivation
+}
+
+func (f StackFrame) Location() common.Location {
+	return f.Interpreter.Location
+}
+
+type stepMode uint8
+
+const (
+	stepModeNone stepMode = iota
+	// stepModeIn stops at the next statement
+	stepModeIn
+	// stepModeOver stops at the next statement of the current function,
+	// or the next statement of a calling function
+	stepModeOver
+	// stepModeOut stops at the next statement of a calling function
+	stepModeOut
+)
+
+type resumption struct {
+	stepMode stepMode
 }
 
 type Debugger struct {
 	pauseRequested uint32
+	stopped        uint32
 	stops          chan Stop
-	continues      chan struct{}
+	continues      chan resumption
+
+	breakpointsLock  sync.Mutex
+	breakpoints      map[breakpointKey]*Breakpoint
+	nextBreakpointID int
+
+	// The following fields are only accessed by the interpreter,
+	// i.e. while the execution is running, or by clients while the execution is stopped
+
+	callStack []StackFrame
+	stepMode  stepMode
+	// stepDepth is the depth of the call stack when the step was requested
+	stepDepth  int
+	evaluating bool
 }
 
 func NewDebugger() *Debugger {
 	return &Debugger{
-		stops:     make(chan Stop),
-		continues: make(chan struct{}),
+		stops:       make(chan Stop),
+		continues:   make(chan resumption),
+		breakpoints: map[breakpointKey]*Breakpoint{},
 	}
 }
 
@@ -47,18 +128,106 @@ func (d *Debugger) Stops() <-chan Stop {
 }
 
 func (d *Debugger) onStatement(interpreter *Interpreter, statement ast.Statement) {
-	if !d.PauseRequested() {
+	if d.evaluating {
+		return
+	}
+
+	depth := len(d.callStack)
+	if depth > 0 {
+		frame := &d.callStack[depth-1]
+		frame.Statement = statement
+		frame.Activation = interpreter.activations.Current()
+	}
+
+	stop, ok := d.shouldStop(interpreter, statement, depth)
+	if !ok {
 		return
 	}
 
-	d.stops <- Stop{
+	d.resetPauseRequest()
+
+	atomic.StoreUint32(&d.stopped, 1)
+
+	d.stops <- stop
+
+	resumption := <-d.continues
+
+	d.stepMode = resumption.stepMode
+	d.stepDepth = len(d.callStack)
+}
+
+func (d *Debugger) shouldStop(interpreter *Interpreter, statement ast.Statement, depth int) (Stop, bool) {
+
+	stop := Stop{
 		Interpreter: interpreter,
 		Statement:   statement,
 	}
 
-	d.resetPauseRequest()
+	if d.PauseRequested() {
+		return stop, true
+	}
+
+	switch d.stepMode {
+	case stepModeIn:
+		return stop, true
+
+	case stepModeOver:
+		if depth <= d.stepDepth {
+			return stop, true
+		}
+
+	case stepModeOut:
+		if depth < d.stepDepth {
+			return stop, true
+		}
+	}
+
+	breakpoint := d.breakpoint(interpreter.Location, statement.StartPosition().Line)
+	if breakpoint == nil {
+		return stop, false
+	}
+
+	stop.Breakpoint = breakpoint
+
+	if breakpoint.Condition == nil {
+		return stop, true
+	}
+
+	result, err := d.evaluate(
+		interpreter,
+		interpreter.activations.Current(),
+		breakpoint.Condition,
+		sema.BoolType,
+	)
+	if err != nil {
+		stop.ConditionError = err
+		return stop, true
+	}
 
-	<-d.continues
+	return stop, bool(result.(BoolValue))
+}
+
+func (d *Debugger) onFunctionInvocation(interpreter *Interpreter, function *InterpretedFunctionValue) {
+	if d.evaluating {
+		return
+	}
+
+	d.callStack = append(
+		d.callStack,
+		StackFrame{
+			Interpreter: interpreter,
+			Function:    function,
+			Activation:  interpreter.activations.Current(),
+		},
+	)
+}
+
+func (d *Debugger) onFunctionReturn() {
+	if d.evaluating {
+		return
+	}
+
+	d.callStack = d.callStack[:len(d.callStack)-1]
 }
 
 func (d *Debugger) PauseRequested() bool {
@@ -73,13 +242,25 @@ func (d *Debugger) RequestPause() {
 	atomic.StoreUint32(&d.pauseRequested, 1)
 }
 
+// Continue continues the stopped execution.
+// It returns false if the execution is not stopped.
+//
 func (d *Debugger) Continue() bool {
-	select {
-	case d.continues <- struct{}{}:
-		return true
-	default:
+	return d.resume(stepModeNone)
+}
+
+// resume resumes the stopped execution.
+// It waits until the interpreter received the resumption,
+// as the stop might be received before the interpreter waits for the resumption
+//
+func (d *Debugger) resume(stepMode stepMode) bool {
+	if !atomic.CompareAndSwapUint32(&d.stopped, 1, 0) {
 		return false
 	}
+
+	d.continues <- resumption{stepMode: stepMode}
+
+	return true
 }
 
 func (d *Debugger) Pause() Stop {
@@ -93,6 +274,322 @@ func (d *Debugger) Next() Stop {
 	return <-d.Stops()
 }
 
+// ContinueStepIn continues the stopped execution until the next statement,
+// which might be in an invoked function.
+// It returns false if the execution is not stopped.
+//
+// The stop is delivered through the stops channel.
+//
+func (d *Debugger) ContinueStepIn() bool {
+	return d.resume(stepModeIn)
+}
+
+// ContinueStepOver continues the stopped execution until the next statement
+// of the current function, i.e. invoked functions are executed without stopping.
+// It returns false if the execution is not stopped.
+//
+// The stop is delivered through the stops channel.
+//
+func (d *Debugger) ContinueStepOver() bool {
+	return d.resume(stepModeOver)
+}
+
+// ContinueStepOut continues the stopped execution until the current function returns,
+// and stops at the next statement of the calling function.
+// It returns false if the execution is not stopped.
+//
+// The stop is delivered through the stops channel.
+//
+func (d *Debugger) ContinueStepOut() bool {
+	return d.resume(stepModeOut)
+}
+
+// StepIn continues the stopped execution until the next statement, and waits for the stop.
+//
+func (d *Debugger) StepIn() Stop {
+	d.ContinueStepIn()
+	return <-d.Stops()
+}
+
+// StepOver continues the stopped execution until the next statement of the current function,
+// and waits for the stop.
+//
+func (d *Debugger) StepOver() Stop {
+	d.ContinueStepOver()
+	return <-d.Stops()
+}
+
+// StepOut continues the stopped execution until the current function returns,
+// and waits for the stop.
+//
+func (d *Debugger) StepOut() Stop {
+	d.ContinueStepOut()
+	return <-d.Stops()
+}
+
 func (d *Debugger) CurrentActivation(interpreter *Interpreter) *VariableActivation {
 	return interpreter.activations.Current()
 }
+
+// CallStack returns the stack frames of the stopped execution.
+// The innermost frame, i.e. the frame of the current function, is the first element.
+//
+// The call stack may only be requested while the execution is stopped.
+//
+func (d *Debugger) CallStack() []StackFrame {
+	count := len(d.callStack)
+	frames := make([]StackFrame, count)
+	for i, frame := range d.callStack {
+		frames[count-1-i] = frame
+	}
+	return frames
+}
+
+// AddBreakpoint adds a breakpoint for the given line of the program at the given location.
+// An existing breakpoint for the line is replaced.
+//
+// The condition is optional. If it is not nil, it must be an expression of type Bool.
+// The condition is parsed by the caller, e.g. using parser2.ParseExpression.
+//
+// Breakpoints may be added while the execution is running.
+//
+func (d *Debugger) AddBreakpoint(location common.Location, line int, condition ast.Expression) *Breakpoint {
+
+	d.breakpointsLock.Lock()
+	defer d.breakpointsLock.Unlock()
+
+	d.nextBreakpointID++
+
+	breakpoint := &Breakpoint{
+		ID:        d.nextBreakpointID,
+		Location:  location,
+		Line:      line,
+		Condition: condition,
+	}
+
+	key := breakpointKey{
+		locationID: location.ID(),
+		line:       line,
+	}
+	d.breakpoints[key] = breakpoint
+
+	return breakpoint
+}
+
+// RemoveBreakpoint removes the breakpoint with the given ID.
+// It returns false if there is no such breakpoint.
+//
+func (d *Debugger) RemoveBreakpoint(id int) bool {
+	d.breakpointsLock.Lock()
+	defer d.breakpointsLock.Unlock()
+
+	for key, breakpoint := range d.breakpoints { //nolint:maprangecheck
+		if breakpoint.ID == id {
+			delete(d.breakpoints, key)
+			return true
+		}
+	}
+
+	return false
+}
+
+// ClearBreakpoints removes all breakpoints for the program at the given location.
+//
+func (d *Debugger) ClearBreakpoints(location common.Location) {
+	d.breakpointsLock.Lock()
+	defer d.breakpointsLock.Unlock()
+
+	locationID := location.ID()
+
+	for key := range d.breakpoints { //nolint:maprangecheck
+		if key.locationID == locationID {
+			delete(d.breakpoints, key)
+		}
+	}
+}
+
+// Breakpoints returns all breakpoints, ordered by ID.
+//
+func (d *Debugger) Breakpoints() []*Breakpoint {
+	d.breakpointsLock.Lock()
+	defer d.breakpointsLock.Unlock()
+
+	breakpoints := make([]*Breakpoint, 0, len(d.breakpoints))
+
+	// Iterating over the map is safe,
+	// as the breakpoints are sorted afterwards
+
+	for _, breakpoint := range d.breakpoints { //nolint:maprangecheck
+		breakpoints = append(breakpoints, breakpoint)
+	}
+
+	sort.Slice(breakpoints, func(i, j int) bool {
+		return breakpoints[i].ID < breakpoints[j].ID
+	})
+
+	return breakpoints
+}
+
+func (d *Debugger) breakpoint(location common.Location, line int) *Breakpoint {
+	d.breakpointsLock.Lock()
+	defer d.breakpointsLock.Unlock()
+
+	if len(d.breakpoints) == 0 || location == nil {
+		return nil
+	}
+
+	key := breakpointKey{
+		locationID: location.ID(),
+		line:       line,
+	}
+	return d.breakpoints[key]
+}
+
+// Evaluate evaluates the given expression in the given activation,
+// e.g. the activation of a frame of the stopped execution.
+//
+// Evaluation may only be requested while the execution is stopped.
+//
+func (d *Debugger) Evaluate(
+	interpreter *Interpreter,
+	activation *VariableActivation,
+	expression ast.Expression,
+) (
+	Value,
+	error,
+) {
+	return d.evaluate(interpreter, activation, expression, nil)
+}
+
+// evaluate checks and interprets the given expression in the given activation.
+//
+// The variables referenced by the expression are declared with the types of their current values.
+//
+func (d *Debugger) evaluate(
+	interpreter *Interpreter,
+	activation *VariableActivation,
+	expression ast.Expression,
+	expectedType sema.Type,
+) (
+	result Value,
+	err error,
+) {
+	d.evaluating = true
+	defer func() {
+		d.evaluating = false
+	}()
+
+	// recover internal panics and return them as an error
+	defer interpreter.RecoverErrors(func(internalErr error) {
+		err = internalErr
+	})
+
+	var valueDeclarations []sema.ValueDeclaration
+
+	declared := map[string]bool{}
+
+	ast.Inspect(expression, func(element ast.Element) bool {
+		identifierExpression, ok := element.(*ast.IdentifierExpression)
+		if !ok {
+			return true
+		}
+
+		name := identifierExpression.Identifier.Identifier
+		if declared[name] {
+			return true
+		}
+		declared[name] = true
+
+		variable := activation.Find(name)
+		if variable == nil {
+			return true
+		}
+
+		valueType, convertErr := interpreter.ConvertStaticToSemaType(variable.GetValue().StaticType())
+		if convertErr != nil {
+			return true
+		}
+
+		valueDeclarations = append(
+			valueDeclarations,
+			debuggerValueDeclaration{
+				name:      name,
+				valueType: valueType,
+			},
+		)
+
+		return true
+	})
+
+	checker, err := sema.NewChecker(
+		nil,
+		debuggerLocation,
+		sema.WithPredeclaredValues(valueDeclarations),
+		sema.WithAccessCheckMode(sema.AccessCheckModeNone),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	checker.VisitExpression(expression, expectedType)
+
+	checkerErr := checker.CheckerError()
+	if checkerErr != nil {
+		return nil, checkerErr
+	}
+
+	subInterpreter, err := interpreter.NewSubInterpreter(
+		ProgramFromChecker(checker),
+		debuggerLocation,
+		WithDebugger(nil),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	subInterpreter.activations.Push(activation)
+
+	return subInterpreter.evalExpression(expression), nil
+}
+
+// debuggerValueDeclaration declares a variable of the activation
+// in which the debugger evaluates an expression
+//
+type debuggerValueDeclaration struct {
+	name      string
+	valueType sema.Type
+}
+
+var _ sema.ValueDeclaration = debuggerValueDeclaration{}
+
+func (d debuggerValueDeclaration) ValueDeclarationName() string {
+	return d.name
+}
+
+func (d debuggerValueDeclaration) ValueDeclarationType() sema.Type {
+	return d.valueType
+}
+
+func (debuggerValueDeclaration) ValueDeclarationDocString() string {
+	return ""
+}
+
+func (debuggerValueDeclaration) ValueDeclarationKind() common.DeclarationKind {
+	return common.DeclarationKindConstant
+}
+
+func (debuggerValueDeclaration) ValueDeclarationPosition() ast.Position {
+	return ast.Position{}
+}
+
+func (debuggerValueDeclaration) ValueDeclarationIsConstant() bool {
+	return true
+}
+
+func (debuggerValueDeclaration) ValueDeclarationArgumentLabels() []string {
+	return nil
+}
+
+func (debuggerValueDeclaration) ValueDeclarationAvailable(_ common.Location) bool {
+	return true
+}
@@ -225,6 +225,9 @@ func (d Decoder) decodeStorable() (atree.Storable, error) {
 		case CBORTagLinkValue:
 			storable, err = d.decodeLink()
 
+		case CBORTagCapabilityControllerValue:
+			storable, err = d.decodeCapabilityController()
+
 		case CBORTagTypeValue:
 			storable, err = d.decodeType()
 
@@ -249,7 +252,7 @@ func (d Decoder) decodeStorable() (atree.Storable, error) {
 }
 
 func (d Decoder) decodeString(v string) *StringValue {
-	return NewStringValue(v)
+	return NewUnmeteredStringValue(v)
 }
 
 func (d Decoder) decodeCharacter(v string) (CharacterValue, error) {
@@ -259,7 +262,7 @@ func (d Decoder) decodeCharacter(v string) (CharacterValue, error) {
 			v,
 		)
 	}
-	return NewCharacterValue(v), nil
+	return NewUnmeteredCharacterValue(v), nil
 }
 
 func decodeLocation(dec *cbor.StreamDecoder) (common.Location, error) {
@@ -880,7 +883,10 @@ func (d Decoder) decodeCapability() (*CapabilityValue, error) {
 		return nil, err
 	}
 
-	if size != expectedLength {
+	// The ID is optional, for backwards compatibility.
+	// Only capabilities issued through a capability controller have an ID
+
+	if size != expectedLength && size != expectedLength-1 {
 		return nil, fmt.Errorf(
 			"invalid capability encoding: expected [%d]interface{}, got [%d]interface{}",
 			expectedLength,
@@ -947,10 +953,22 @@ func (d Decoder) decodeCapability() (*CapabilityValue, error) {
 		return nil, fmt.Errorf("invalid capability borrow type encoding: %w", err)
 	}
 
+	// Decode ID at array index encodedCapabilityValueIDFieldKey
+
+	var id uint64
+
+	if size == expectedLength {
+		id, err = d.decoder.DecodeUint64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid capability ID encoding: %w", err)
+		}
+	}
+
 	return &CapabilityValue{
 		Address:    address,
 		Path:       pathValue,
 		BorrowType: borrowType,
+		ID:         UInt64Value(id),
 	}, nil
 }
 
@@ -1003,6 +1021,73 @@ func (d Decoder) decodeLink() (LinkValue, error) {
 	}, nil
 }
 
+func (d Decoder) decodeCapabilityController() (CapabilityControllerValue, error) {
+
+	const expectedLength = encodedCapabilityControllerValueLength
+
+	size, err := d.decoder.DecodeArrayHead()
+	if err != nil {
+		if e, ok := err.(*cbor.WrongTypeError); ok {
+			return CapabilityControllerValue{}, fmt.Errorf(
+				"invalid capability controller encoding: expected [%d]interface{}, got %s",
+				expectedLength,
+				e.ActualType.String(),
+			)
+		}
+		return CapabilityControllerValue{}, err
+	}
+
+	if size != expectedLength {
+		return CapabilityControllerValue{}, fmt.Errorf(
+			"invalid capability controller encoding: expected [%d]interface{}, got [%d]interface{}",
+			expectedLength,
+			size,
+		)
+	}
+
+	// Decode path at array index encodedCapabilityControllerValueTargetPathFieldKey
+	num, err := d.decoder.DecodeTagNumber()
+	if err != nil {
+		return CapabilityControllerValue{}, fmt.Errorf("invalid capability controller target path encoding: %w", err)
+	}
+	if num != CBORTagPathValue {
+		return CapabilityControllerValue{}, fmt.Errorf(
+			"invalid capability controller target path encoding: expected CBOR tag %d, got %d",
+			CBORTagPathValue,
+			num,
+		)
+	}
+	pathValue, err := d.decodePath()
+	if err != nil {
+		return CapabilityControllerValue{}, fmt.Errorf("invalid capability controller target path encoding: %w", err)
+	}
+
+	// Decode type at array index encodedCapabilityControllerValueBorrowTypeFieldKey
+	staticType, err := decodeStaticType(d.decoder)
+	if err != nil {
+		return CapabilityControllerValue{}, fmt.Errorf("invalid capability controller borrow type encoding: %w", err)
+	}
+
+	// Decode ID at array index encodedCapabilityControllerValueCapabilityIDFieldKey
+	capabilityID, err := d.decoder.DecodeUint64()
+	if err != nil {
+		return CapabilityControllerValue{}, fmt.Errorf("invalid capability controller ID encoding: %w", err)
+	}
+
+	// Decode tag at array index encodedCapabilityControllerValueTagFieldKey
+	tag, err := d.decoder.DecodeString()
+	if err != nil {
+		return CapabilityControllerValue{}, fmt.Errorf("invalid capability controller tag encoding: %w", err)
+	}
+
+	return CapabilityControllerValue{
+		TargetPath:   pathValue,
+		BorrowType:   staticType,
+		CapabilityID: UInt64Value(capabilityID),
+		Tag:          tag,
+	}, nil
+}
+
 func (d Decoder) decodeType() (TypeValue, error) {
 	const expectedLength = encodedTypeValueTypeLength
 
@@ -1134,7 +1219,9 @@ func decodeCompositeStaticType(dec *cbor.StreamDecoder) (StaticType, error) {
 		return nil, err
 	}
 
-	if size != expectedLength {
+	if size != expectedLength &&
+		size != encodedInstantiatedCompositeStaticTypeLength {
+
 		return nil, fmt.Errorf(
 			"invalid composite static type encoding: expected [%d]interface{}, got [%d]interface{}",
 			expectedLength,
@@ -1160,7 +1247,38 @@ func decodeCompositeStaticType(dec *cbor.StreamDecoder) (StaticType, error) {
 		return nil, err
 	}
 
-	return NewCompositeStaticType(location, qualifiedIdentifier), nil
+	staticType := NewCompositeStaticType(location, qualifiedIdentifier)
+
+	if size == encodedInstantiatedCompositeStaticTypeLength {
+		// Decode type arguments at array index encodedCompositeStaticTypeTypeArgumentsFieldKey
+		staticType.TypeArguments, err = decodeTypeArguments(dec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid composite static type type arguments encoding: %w", err)
+		}
+	}
+
+	return staticType, nil
+}
+
+func decodeTypeArguments(dec *cbor.StreamDecoder) ([]StaticType, error) {
+	size, err := dec.DecodeArrayHead()
+	if err != nil {
+		return nil, err
+	}
+
+	if size == 0 {
+		return nil, fmt.Errorf("expected at least one type argument")
+	}
+
+	typeArguments := make([]StaticType, size)
+	for i := 0; i < int(size); i++ {
+		typeArguments[i], err = decodeStaticType(dec)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return typeArguments, nil
 }
 
 func decodeInterfaceStaticType(dec *cbor.StreamDecoder) (InterfaceStaticType, error) {
@@ -1505,10 +1623,14 @@ func decodeCompositeTypeInfo(dec *cbor.StreamDecoder) (atree.TypeInfo, error) {
 		return nil, err
 	}
 
-	if length != encodedCompositeTypeInfoLength {
+	if length != encodedCompositeTypeInfoLength &&
+		length != encodedInstantiatedCompositeTypeInfoLength {
+
 		return nil, fmt.Errorf(
-			"invalid composite type info: expected %d elements, got %d",
-			encodedCompositeTypeInfoLength, length,
+			"invalid composite type info: expected %d or %d elements, got %d",
+			encodedCompositeTypeInfoLength,
+			encodedInstantiatedCompositeTypeInfoLength,
+			length,
 		)
 	}
 
@@ -1534,9 +1656,21 @@ func decodeCompositeTypeInfo(dec *cbor.StreamDecoder) (atree.TypeInfo, error) {
 		)
 	}
 
+	var typeArguments []StaticType
+	if length == encodedInstantiatedCompositeTypeInfoLength {
+		typeArguments, err = decodeTypeArguments(dec)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"invalid composite ordered map type info: invalid type arguments: %w",
+				err,
+			)
+		}
+	}
+
 	return compositeTypeInfo{
 		location:            location,
 		qualifiedIdentifier: qualifiedIdentifier,
 		kind:                common.CompositeKind(kind),
+		typeArguments:       typeArguments,
 	}, nil
 }
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"strings"
 
 	"github.com/fxamacker/cbor/v2"
 	"github.com/onflow/atree"
@@ -198,7 +199,7 @@ const (
 	CBORTagCapabilityValue
 	_ // DO NOT REPLACE! used to be used for storage references
 	CBORTagLinkValue
-	_
+	CBORTagCapabilityControllerValue
 	_
 	_
 	_
@@ -710,12 +711,13 @@ const (
 	// encodedCapabilityValueAddressFieldKey    uint64 = 0
 	// encodedCapabilityValuePathFieldKey       uint64 = 1
 	// encodedCapabilityValueBorrowTypeFieldKey uint64 = 2
+	// encodedCapabilityValueIDFieldKey         uint64 = 3
 
 	// !!! *WARNING* !!!
 	//
 	// encodedCapabilityValueLength MUST be updated when new element is added.
 	// It is used to verify encoded capability length during decoding.
-	encodedCapabilityValueLength = 3
+	encodedCapabilityValueLength = 4
 )
 
 // Encode encodes CapabilityStorable as
@@ -725,15 +727,28 @@ const (
 //					encodedCapabilityValueAddressFieldKey:    AddressValue(v.Address),
 // 					encodedCapabilityValuePathFieldKey:       PathValue(v.Path),
 // 					encodedCapabilityValueBorrowTypeFieldKey: StaticType(v.BorrowType),
+// 					encodedCapabilityValueIDFieldKey:         uint64(v.ID),
 // 				},
 // }
+//
+// The ID is only encoded for capabilities issued through a capability controller,
+// so the encoding of capabilities of links stays unchanged.
+//
 func (v *CapabilityValue) Encode(e *atree.Encoder) error {
+
+	isControllerCapability := v.IsControllerCapability()
+
+	// array, 3 or 4 items follow
+	arrayHead := byte(0x83)
+	if isControllerCapability {
+		arrayHead = 0x84
+	}
+
 	// Encode tag number and array head
 	err := e.CBOR.EncodeRawBytes([]byte{
 		// tag number
 		0xd8, CBORTagCapabilityValue,
-		// array, 3 items follow
-		0x83,
+		arrayHead,
 	})
 	if err != nil {
 		return err
@@ -752,7 +767,17 @@ func (v *CapabilityValue) Encode(e *atree.Encoder) error {
 	}
 
 	// Encode borrow type at array index encodedCapabilityValueBorrowTypeFieldKey
-	return EncodeStaticType(e.CBOR, v.BorrowType)
+	err = EncodeStaticType(e.CBOR, v.BorrowType)
+	if err != nil {
+		return err
+	}
+
+	if !isControllerCapability {
+		return nil
+	}
+
+	// Encode ID at array index encodedCapabilityValueIDFieldKey
+	return e.CBOR.EncodeUint64(uint64(v.ID))
 }
 
 // NOTE: NEVER change, only add/increment; ensure uint64
@@ -914,6 +939,60 @@ func (v LinkValue) Encode(e *atree.Encoder) error {
 	return EncodeStaticType(e.CBOR, v.Type)
 }
 
+// NOTE: NEVER change, only add/increment; ensure uint64
+const (
+	// encodedCapabilityControllerValueTargetPathFieldKey   uint64 = 0
+	// encodedCapabilityControllerValueBorrowTypeFieldKey   uint64 = 1
+	// encodedCapabilityControllerValueCapabilityIDFieldKey uint64 = 2
+	// encodedCapabilityControllerValueTagFieldKey          uint64 = 3
+
+	// !!! *WARNING* !!!
+	//
+	// encodedCapabilityControllerValueLength MUST be updated when new element is added.
+	// It is used to verify encoded capability controller length during decoding.
+	encodedCapabilityControllerValueLength = 4
+)
+
+// Encode encodes CapabilityControllerValue as
+// cbor.Tag{
+//			Number: CBORTagCapabilityControllerValue,
+//			Content: []interface{}{
+//				encodedCapabilityControllerValueTargetPathFieldKey:   PathValue(v.TargetPath),
+//				encodedCapabilityControllerValueBorrowTypeFieldKey:   StaticType(v.BorrowType),
+//				encodedCapabilityControllerValueCapabilityIDFieldKey: uint64(v.CapabilityID),
+//				encodedCapabilityControllerValueTagFieldKey:          string(v.Tag),
+//			},
+// }
+func (v CapabilityControllerValue) Encode(e *atree.Encoder) error {
+	// Encode tag number and array head
+	err := e.CBOR.EncodeRawBytes([]byte{
+		// tag number
+		0xd8, CBORTagCapabilityControllerValue,
+		// array, 4 items follow
+		0x84,
+	})
+	if err != nil {
+		return err
+	}
+	// Encode path at array index encodedCapabilityControllerValueTargetPathFieldKey
+	err = v.TargetPath.Encode(e)
+	if err != nil {
+		return err
+	}
+	// Encode type at array index encodedCapabilityControllerValueBorrowTypeFieldKey
+	err = EncodeStaticType(e.CBOR, v.BorrowType)
+	if err != nil {
+		return err
+	}
+	// Encode ID at array index encodedCapabilityControllerValueCapabilityIDFieldKey
+	err = e.CBOR.EncodeUint64(uint64(v.CapabilityID))
+	if err != nil {
+		return err
+	}
+	// Encode tag at array index encodedCapabilityControllerValueTagFieldKey
+	return e.CBOR.EncodeString(v.Tag)
+}
+
 // NOTE: NEVER change, only add/increment; ensure uint64
 const (
 	// encodedTypeValueTypeFieldKey uint64 = 0
@@ -1014,12 +1093,18 @@ func (t OptionalStaticType) Encode(e *cbor.StreamEncoder) error {
 const (
 	// encodedCompositeStaticTypeLocationFieldKey            uint64 = 0
 	// encodedCompositeStaticTypeQualifiedIdentifierFieldKey uint64 = 1
+	// encodedCompositeStaticTypeTypeArgumentsFieldKey       uint64 = 2
 
 	// !!! *WARNING* !!!
 	//
 	// encodedCompositeStaticTypeLength MUST be updated when new element is added.
 	// It is used to verify encoded composite static type length during decoding.
 	encodedCompositeStaticTypeLength = 2
+
+	// encodedInstantiatedCompositeStaticTypeLength is the length
+	// of the encoding of an instantiated generic composite static type,
+	// which additionally has the type arguments
+	encodedInstantiatedCompositeStaticTypeLength = 3
 )
 
 // Encode encodes CompositeStaticType as
@@ -1028,15 +1113,26 @@ const (
 // 			Content: cborArray{
 //				encodedCompositeStaticTypeLocationFieldKey:            Location(v.Location),
 //				encodedCompositeStaticTypeQualifiedIdentifierFieldKey: string(v.QualifiedIdentifier),
+//				encodedCompositeStaticTypeTypeArgumentsFieldKey:       []StaticType(v.TypeArguments),
 //		},
 // }
+//
+// The type arguments are only encoded for instantiated generic composite types,
+// so the encoding of non-generic composite static types is unchanged.
+//
 func (t CompositeStaticType) Encode(e *cbor.StreamEncoder) error {
 	// Encode tag number and array head
+
+	arrayHead := byte(0x82)
+	if len(t.TypeArguments) > 0 {
+		arrayHead = 0x83
+	}
+
 	err := e.EncodeRawBytes([]byte{
 		// tag number
 		0xd8, CBORTagCompositeStaticType,
-		// array, 2 items follow
-		0x82,
+		// array, 2 or 3 items follow
+		arrayHead,
 	})
 	if err != nil {
 		return err
@@ -1049,7 +1145,34 @@ func (t CompositeStaticType) Encode(e *cbor.StreamEncoder) error {
 	}
 
 	// Encode qualified identifier at array index encodedCompositeStaticTypeQualifiedIdentifierFieldKey
-	return e.EncodeString(t.QualifiedIdentifier)
+	err = e.EncodeString(t.QualifiedIdentifier)
+	if err != nil {
+		return err
+	}
+
+	if len(t.TypeArguments) == 0 {
+		return nil
+	}
+
+	// Encode type arguments (as array) at array index encodedCompositeStaticTypeTypeArgumentsFieldKey
+	return encodeTypeArguments(e, t.TypeArguments)
+}
+
+func encodeTypeArguments(e *cbor.StreamEncoder, typeArguments []StaticType) error {
+	err := e.EncodeArrayHead(uint64(len(typeArguments)))
+	if err != nil {
+		return err
+	}
+
+	for _, typeArgument := range typeArguments {
+		// Encode type argument as array type arguments element
+		err = EncodeStaticType(e, typeArgument)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // NOTE: NEVER change, only add/increment; ensure uint64
@@ -1303,24 +1426,59 @@ func (t FunctionStaticType) Encode(_ *cbor.StreamEncoder) error {
 	}
 }
 
+// attachmentFieldNamePrefix is the prefix of the names of the fields
+// in which the attachments of a composite value are stored.
+//
+// Attachments are stored alongside the fields of the composite value they are attached to,
+// so they are encoded and decoded together with the value.
+// The prefix is not a valid identifier, so the field names never clash with declared fields.
+//
+const attachmentFieldNamePrefix = "$"
+
+// attachmentFieldName returns the name of the field
+// in which the attachment with the given type ID is stored.
+//
+func attachmentFieldName(attachmentTypeID common.TypeID) string {
+	return attachmentFieldNamePrefix + string(attachmentTypeID)
+}
+
+// isAttachmentFieldName returns true if the given field name
+// is the name of a field in which an attachment is stored.
+//
+func isAttachmentFieldName(fieldName string) bool {
+	return strings.HasPrefix(fieldName, attachmentFieldNamePrefix)
+}
+
 // compositeTypeInfo
 //
 type compositeTypeInfo struct {
 	location            common.Location
 	qualifiedIdentifier string
 	kind                common.CompositeKind
+	typeArguments       []StaticType
 }
 
 var _ atree.TypeInfo = compositeTypeInfo{}
 
 const encodedCompositeTypeInfoLength = 3
 
+// encodedInstantiatedCompositeTypeInfoLength is the length of the encoding
+// of the type info of a value of an instantiated generic composite type,
+// which additionally has the type arguments
+//
+const encodedInstantiatedCompositeTypeInfoLength = 4
+
 func (c compositeTypeInfo) Encode(e *cbor.StreamEncoder) error {
+	arrayHead := byte(0x83)
+	if len(c.typeArguments) > 0 {
+		arrayHead = 0x84
+	}
+
 	err := e.EncodeRawBytes([]byte{
 		// tag number
 		0xd8, CBORTagCompositeValue,
-		// array, 3 items follow
-		0x83,
+		// array, 3 or 4 items follow
+		arrayHead,
 	})
 	if err != nil {
 		return err
@@ -1341,15 +1499,31 @@ func (c compositeTypeInfo) Encode(e *cbor.StreamEncoder) error {
 		return err
 	}
 
-	return nil
+	if len(c.typeArguments) == 0 {
+		return nil
+	}
+
+	return encodeTypeArguments(e, c.typeArguments)
 }
 
 func (c compositeTypeInfo) Equal(o atree.TypeInfo) bool {
 	other, ok := o.(compositeTypeInfo)
-	return ok &&
-		common.LocationsMatch(c.location, other.location) &&
-		c.qualifiedIdentifier == other.qualifiedIdentifier &&
-		c.kind == other.kind
+	if !ok ||
+		!common.LocationsMatch(c.location, other.location) ||
+		c.qualifiedIdentifier != other.qualifiedIdentifier ||
+		c.kind != other.kind ||
+		len(c.typeArguments) != len(other.typeArguments) {
+
+		return false
+	}
+
+	for i, typeArgument := range c.typeArguments {
+		if !typeArgument.Equal(other.typeArguments[i]) {
+			return false
+		}
+	}
+
+	return true
 }
 
 // EmptyTypeInfo
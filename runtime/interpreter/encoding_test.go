@@ -193,7 +193,7 @@ func TestEncodeDecodeString(t *testing.T) {
 
 		t.Parallel()
 
-		expected := NewStringValue("")
+		expected := NewUnmeteredStringValue("")
 
 		testEncodeDecode(t,
 			encodeDecodeTest{
@@ -212,7 +212,7 @@ func TestEncodeDecodeString(t *testing.T) {
 
 		t.Parallel()
 
-		expected := NewStringValue("foo")
+		expected := NewUnmeteredStringValue("foo")
 
 		testEncodeDecode(t,
 			encodeDecodeTest{
@@ -235,7 +235,7 @@ func TestEncodeDecodeString(t *testing.T) {
 		t.Parallel()
 
 		maxInlineElementSize := atree.MaxInlineArrayElementSize
-		expected := NewStringValue(strings.Repeat("x", int(maxInlineElementSize+1)))
+		expected := NewUnmeteredStringValue(strings.Repeat("x", int(maxInlineElementSize+1)))
 
 		testEncodeDecode(t,
 			encodeDecodeTest{
@@ -292,7 +292,7 @@ func TestEncodeDecodeArray(t *testing.T) {
 
 		inter := newTestInterpreter(t)
 
-		expectedString := NewStringValue("test")
+		expectedString := NewUnmeteredStringValue("test")
 
 		expected := NewArrayValue(
 			inter,
@@ -360,7 +360,7 @@ func TestEncodeDecodeComposite(t *testing.T) {
 
 		inter := newTestInterpreter(t)
 
-		stringValue := NewStringValue("test")
+		stringValue := NewUnmeteredStringValue("test")
 
 		fields := []CompositeField{
 			{Name: "string", Value: stringValue},
@@ -390,6 +390,68 @@ func TestEncodeDecodeComposite(t *testing.T) {
 			},
 		)
 	})
+
+	t.Run("resource with attachment", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := newTestInterpreter(t)
+
+		expected := NewCompositeValue(
+			inter,
+			utils.TestLocation,
+			"TestResource",
+			common.CompositeKindResource,
+			nil,
+			testOwner,
+		)
+
+		attachment := NewCompositeValue(
+			inter,
+			utils.TestLocation,
+			"TestAttachment",
+			common.CompositeKindAttachment,
+			[]CompositeField{
+				{Name: "string", Value: NewUnmeteredStringValue("test")},
+			},
+			testOwner,
+		)
+
+		expected.Attach(inter, ReturnEmptyLocationRange, attachment)
+
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				storage: inter.Storage,
+				value:   expected,
+				encoded: []byte{
+					// tag
+					0xd8, atree.CBORTagStorageID,
+
+					// storage ID
+					0x50, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x42, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1,
+				},
+				check: func(actual Value) {
+					require.IsType(t, &CompositeValue{}, actual)
+					composite := actual.(*CompositeValue)
+
+					decodedAttachment := composite.GetAttachment(
+						inter,
+						ReturnEmptyLocationRange,
+						attachment.TypeID(),
+					)
+					require.NotNil(t, decodedAttachment)
+
+					assert.Equal(t, common.CompositeKindAttachment, decodedAttachment.Kind)
+					AssertValuesEqual(
+						t,
+						inter,
+						NewUnmeteredStringValue("test"),
+						decodedAttachment.GetField(inter, ReturnEmptyLocationRange, "string"),
+					)
+				},
+			},
+		)
+	})
 }
 
 func TestEncodeDecodeIntValue(t *testing.T) {
@@ -522,7 +584,7 @@ func TestEncodeDecodeIntValue(t *testing.T) {
 
 		maxInlineElementSize := atree.MaxInlineArrayElementSize
 		for len(expected.BigInt.Bytes()) < int(maxInlineElementSize+1) {
-			expected = expected.Mul(expected).(IntValue)
+			expected = expected.Mul(nil, expected).(IntValue)
 		}
 
 		testEncodeDecode(t,
@@ -1477,7 +1539,7 @@ func TestEncodeDecodeUIntValue(t *testing.T) {
 
 		maxInlineElementSize := atree.MaxInlineArrayElementSize
 		for len(expected.BigInt.Bytes()) < int(maxInlineElementSize+1) {
-			expected = expected.Mul(expected).(UIntValue)
+			expected = expected.Mul(nil, expected).(UIntValue)
 		}
 
 		testEncodeDecode(t,
@@ -2374,7 +2436,7 @@ func TestEncodeDecodeSomeValue(t *testing.T) {
 	t.Run("string", func(t *testing.T) {
 		t.Parallel()
 
-		expectedString := NewStringValue("test")
+		expectedString := NewUnmeteredStringValue("test")
 
 		testEncodeDecode(t,
 			encodeDecodeTest{
@@ -2421,7 +2483,7 @@ func TestEncodeDecodeSomeValue(t *testing.T) {
 		var str *StringValue
 		maxInlineElementSize := atree.MaxInlineArrayElementSize
 		for i := uint64(0); i < maxInlineElementSize; i++ {
-			str = NewStringValue(strings.Repeat("x", int(maxInlineElementSize-i)))
+			str = NewUnmeteredStringValue(strings.Repeat("x", int(maxInlineElementSize-i)))
 			size, err := StorableSize(str)
 			require.NoError(t, err)
 			if uint64(size) == maxInlineElementSize-1 {
@@ -2455,7 +2517,7 @@ func TestEncodeDecodeSomeValue(t *testing.T) {
 		var str *StringValue
 		maxInlineElementSize := atree.MaxInlineArrayElementSize
 		for i := uint64(0); i < maxInlineElementSize; i++ {
-			str = NewStringValue(strings.Repeat("x", int(maxInlineElementSize-i)))
+			str = NewUnmeteredStringValue(strings.Repeat("x", int(maxInlineElementSize-i)))
 			size, err := StorableSize(str)
 			require.NoError(t, err)
 			if uint64(size) == maxInlineElementSize+1 {
@@ -3136,6 +3198,52 @@ func TestEncodeDecodeCapabilityValue(t *testing.T) {
 		)
 	})
 
+	t.Run("controller capability", func(t *testing.T) {
+
+		t.Parallel()
+
+		value := &CapabilityValue{
+			Address:    NewAddressValueFromBytes([]byte{0x2}),
+			Path:       EmptyPathValue,
+			BorrowType: PrimitiveStaticTypeBool,
+			ID:         5,
+		}
+
+		encoded := []byte{
+			// tag
+			0xd8, CBORTagCapabilityValue,
+			// array, 4 items follow
+			0x84,
+			// tag for address
+			0xd8, CBORTagAddressValue,
+			// byte sequence, length 1
+			0x41,
+			// address
+			0x02,
+			// tag for path
+			0xd8, CBORTagPathValue,
+			// array, 2 items follow
+			0x82,
+			// positive integer 0
+			0x0,
+			// UTF-8 string, length 0
+			0x60,
+			// tag
+			0xd8, CBORTagPrimitiveStaticType,
+			// bool
+			0x6,
+			// positive integer 5
+			0x5,
+		}
+
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				value:   value,
+				encoded: encoded,
+			},
+		)
+	})
+
 	t.Run("larger than max inline size due to path", func(t *testing.T) {
 
 		t.Parallel()
@@ -3674,6 +3782,55 @@ func TestEncodeDecodeLinkValue(t *testing.T) {
 	})
 }
 
+func TestEncodeDecodeCapabilityControllerValue(t *testing.T) {
+
+	t.Parallel()
+
+	value := CapabilityControllerValue{
+		BorrowType: PrimitiveStaticTypeBool,
+		TargetPath: PathValue{
+			Domain:     common.PathDomainStorage,
+			Identifier: "foo",
+		},
+		CapabilityID: 5,
+		Tag:          "bar",
+	}
+
+	encoded := []byte{
+		// tag
+		0xd8, CBORTagCapabilityControllerValue,
+		// array, 4 items follow
+		0x84,
+		// tag for path
+		0xd8, CBORTagPathValue,
+		// array, 2 items follow
+		0x82,
+		// positive integer 1
+		0x1,
+		// UTF-8 string, length 3
+		0x63,
+		// f, o, o
+		0x66, 0x6f, 0x6f,
+		// tag
+		0xd8, CBORTagPrimitiveStaticType,
+		// bool
+		0x6,
+		// positive integer 5
+		0x5,
+		// UTF-8 string, length 3
+		0x63,
+		// b, a, r
+		0x62, 0x61, 0x72,
+	}
+
+	testEncodeDecode(t,
+		encodeDecodeTest{
+			value:   value,
+			encoded: encoded,
+		},
+	)
+}
+
 func TestEncodeDecodeTypeValue(t *testing.T) {
 
 	t.Parallel()
@@ -3823,6 +3980,45 @@ func TestEncodeDecodeStaticType(t *testing.T) {
 
 		require.Equal(t, ty, actualType)
 	})
+
+	t.Run("composite, struct, type arguments", func(t *testing.T) {
+
+		t.Parallel()
+
+		ty := NewCompositeStaticType(nil, "Box")
+		ty.TypeArguments = []StaticType{
+			PrimitiveStaticTypeInt,
+		}
+
+		encoded := cbor.RawMessage{
+			// tag
+			0xd8, CBORTagCompositeStaticType,
+			// array, 3 items follow
+			0x83,
+			// location: nil
+			0xf6,
+			// UTF-8 string, length 3
+			0x63,
+			// Box
+			0x42, 0x6f, 0x78,
+			// array, 1 items follow
+			0x81,
+			// tag
+			0xd8, CBORTagPrimitiveStaticType,
+			// positive integer 36
+			0x18, 0x24,
+		}
+
+		actualEncoded, err := StaticTypeToBytes(ty)
+		require.NoError(t, err)
+
+		AssertEqualWithDiff(t, encoded, actualEncoded)
+
+		actualType, err := StaticTypeFromBytes(encoded)
+		require.NoError(t, err)
+
+		require.Equal(t, ty, actualType)
+	})
 }
 
 func TestCBORTagValue(t *testing.T) {
@@ -91,6 +91,21 @@ func (e ExternalError) Error() string {
 	return fmt.Sprint(e.Recovered)
 }
 
+// ExecutionCanceledError is reported when the execution was aborted,
+// because the context of the interpreter was canceled or its deadline was exceeded.
+//
+type ExecutionCanceledError struct {
+	Err error
+}
+
+func (e ExecutionCanceledError) Unwrap() error {
+	return e.Err
+}
+
+func (e ExecutionCanceledError) Error() string {
+	return fmt.Sprintf("execution canceled: %s", e.Err.Error())
+}
+
 // NotDeclaredError
 
 type NotDeclaredError struct {
@@ -511,6 +526,30 @@ func (e ResourceConstructionError) Error() string {
 	)
 }
 
+// DuplicateAttachmentError
+//
+type DuplicateAttachmentError struct {
+	AttachmentType *sema.CompositeType
+	LocationRange
+}
+
+func (e DuplicateAttachmentError) Error() string {
+	return fmt.Sprintf(
+		"cannot attach %s: value already has an attachment of this type",
+		e.AttachmentType.QualifiedString(),
+	)
+}
+
+// AttachmentBaseUnavailableError
+//
+type AttachmentBaseUnavailableError struct {
+	LocationRange
+}
+
+func (e AttachmentBaseUnavailableError) Error() string {
+	return "cannot access base: attachment is not attached to a value"
+}
+
 // ContainerMutationError
 //
 type ContainerMutationError struct {
@@ -606,3 +645,73 @@ func (e InvalidPublicKeyError) Error() string {
 func (e InvalidPublicKeyError) Unwrap() error {
 	return e.Err
 }
+
+// StorageMutatedDuringIterationError is reported when an account storage domain is modified
+// while it is iterated over, e.g. using `forEachStored`
+//
+type StorageMutatedDuringIterationError struct {
+	Address common.Address
+	Domain  string
+}
+
+func (e StorageMutatedDuringIterationError) Error() string {
+	return fmt.Sprintf(
+		"account storage of %s in domain `%s` cannot be modified while iterating over it",
+		e.Address,
+		e.Domain,
+	)
+}
+
+// ArrayMutatedDuringIterationError is reported when an array is modified
+// while it is iterated over, e.g. using a for-in loop, `map`, or `sort`
+//
+type ArrayMutatedDuringIterationError struct {
+	LocationRange
+}
+
+func (e ArrayMutatedDuringIterationError) Error() string {
+	return "array cannot be modified while iterating over it"
+}
+
+// DictionaryMutatedDuringIterationError is reported when a dictionary is modified
+// while it is iterated over using a for-in loop
+//
+type DictionaryMutatedDuringIterationError struct {
+	LocationRange
+}
+
+func (e DictionaryMutatedDuringIterationError) Error() string {
+	return "dictionary cannot be modified while iterating over it"
+}
+
+// RevokedCapabilityControllerError is reported when a capability controller
+// is used after it was revoked
+//
+type RevokedCapabilityControllerError struct {
+	Address      common.Address
+	CapabilityID UInt64Value
+	LocationRange
+}
+
+func (e RevokedCapabilityControllerError) Error() string {
+	return fmt.Sprintf(
+		"capability controller %s of account %s was revoked",
+		e.CapabilityID,
+		e.Address,
+	)
+}
+
+// InvalidCapabilityPublicationError is reported when a capability is published
+// which was not issued through a capability controller of the publishing account
+//
+type InvalidCapabilityPublicationError struct {
+	Address AddressValue
+	LocationRange
+}
+
+func (e InvalidCapabilityPublicationError) Error() string {
+	return fmt.Sprintf(
+		"cannot publish capability: only capabilities issued by account %s can be published",
+		e.Address,
+	)
+}
@@ -18,4 +18,4 @@
 
 package interpreter
 
-//go:generate go run github.com/cheekybits/genny -pkg=interpreter -in=../activations/activations.go -out=variable_activations.go gen "ValueType=*Variable"
+//go:generate go run github.com/cheekybits/genny -pkg=interpreter -in=../activations/activations.go -out=variable_activations.go gen "ValueType=*Variable TypeParameterType=*sema.TypeParameter TypeArgumentType=sema.Type"
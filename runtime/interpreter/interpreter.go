@@ -19,6 +19,7 @@
 package interpreter
 
 import (
+	"context"
 	"encoding/hex"
 	goErrors "errors"
 	"fmt"
@@ -95,6 +96,28 @@ type OnStatementFunc func(
 	statement ast.Statement,
 )
 
+// OnStatementStartFunc is a function that is triggered when a statement starts,
+// i.e. before the computation of the statement is metered,
+// and before the statement handler is triggered.
+//
+type OnStatementStartFunc func(
+	inter *Interpreter,
+	statement ast.Statement,
+)
+
+// OnBranchFunc is a function that is triggered when a branch of a branching element is about to be executed,
+// i.e. a branch of an if-statement, a switch-statement, a conditional expression, or a nil-coalescing expression.
+//
+// Branches are numbered in source order, e.g. the then-branch of an if-statement is branch 0,
+// and the (possibly implicit) else-branch is branch 1. The implicit branch of a switch-statement without a default case,
+// which is executed when no case matches, is numbered after all cases.
+//
+type OnBranchFunc func(
+	inter *Interpreter,
+	element ast.Element,
+	branch int,
+)
+
 // OnLoopIterationFunc is a function that is triggered when a loop iteration is about to be executed.
 //
 type OnLoopIterationFunc func(
@@ -283,6 +306,7 @@ type WrapperCode struct {
 	InitializerFunctionWrapper FunctionWrapper
 	DestructorFunctionWrapper  FunctionWrapper
 	FunctionWrappers           map[string]FunctionWrapper
+	DefaultFunctions           map[string]FunctionValue
 }
 
 // TypeCodes is the value which stores the "prepared" / "callable" "code"
@@ -314,7 +338,7 @@ func (c TypeCodes) Merge(codes TypeCodes) {
 
 type Storage interface {
 	atree.SlabStorage
-	GetStorageMap(address common.Address, domain string) *StorageMap
+	GetStorageMap(address common.Address, domain string, createIfNotExists bool) *StorageMap
 	CheckHealth() error
 }
 
@@ -333,12 +357,16 @@ type Interpreter struct {
 	Storage                        Storage
 	onEventEmitted                 OnEventEmittedFunc
 	onStatement                    OnStatementFunc
+	onStatementStart               OnStatementStartFunc
 	onLoopIteration                OnLoopIterationFunc
+	onBranch                       OnBranchFunc
 	onFunctionInvocation           OnFunctionInvocationFunc
 	onInvokedFunctionReturn        OnInvokedFunctionReturnFunc
 	onRecordTrace                  OnRecordTraceFunc
 	onResourceOwnerChange          OnResourceOwnerChangeFunc
 	onMeterComputation             OnMeterComputationFunc
+	memoryGauge                    common.MemoryGauge
+	ctx                            context.Context
 	injectedCompositeFieldsHandler InjectedCompositeFieldsHandlerFunc
 	contractValueHandler           ContractValueHandlerFunc
 	importLocationHandler          ImportLocationHandlerFunc
@@ -361,6 +389,12 @@ type Interpreter struct {
 	referencedResourceKindedValues       ReferencedResourceKindedValues
 	invalidatedResourceValidationEnabled bool
 	resourceVariables                    map[ResourceKindedValue]*Variable
+	// storageIterations counts the iterations over account storage domains that are in progress,
+	// so that modifications of the iterated storage maps can be rejected
+	storageIterations map[StorageKey]int
+	// containerIterations counts the iterations over arrays and dictionaries that are in progress,
+	// so that modifications of the iterated containers can be rejected
+	containerIterations map[atree.StorageID]int
 }
 
 type Option func(*Interpreter) error
@@ -385,6 +419,26 @@ func WithOnStatementHandler(handler OnStatementFunc) Option {
 	}
 }
 
+// WithOnStatementStartHandler returns an interpreter option which sets
+// the given function as the statement start handler.
+//
+func WithOnStatementStartHandler(handler OnStatementStartFunc) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetOnStatementStartHandler(handler)
+		return nil
+	}
+}
+
+// WithOnBranchHandler returns an interpreter option which sets
+// the given function as the branch handler.
+//
+func WithOnBranchHandler(handler OnBranchFunc) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetOnBranchHandler(handler)
+		return nil
+	}
+}
+
 // WithOnLoopIterationHandler returns an interpreter option which sets
 // the given function as the loop iteration handler.
 //
@@ -445,6 +499,26 @@ func WithOnMeterComputationFuncHandler(handler OnMeterComputationFunc) Option {
 	}
 }
 
+// WithMemoryGauge returns an interpreter option which sets
+// the given gauge as the gauge for metering the memory of values.
+//
+func WithMemoryGauge(memoryGauge common.MemoryGauge) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetMemoryGauge(memoryGauge)
+		return nil
+	}
+}
+
+// WithContext returns an interpreter option which sets
+// the given context as the context which aborts the execution when done.
+//
+func WithContext(ctx context.Context) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetContext(ctx)
+		return nil
+	}
+}
+
 // WithPredeclaredValues returns an interpreter option which declares
 // the given the predeclared values.
 //
@@ -652,6 +726,24 @@ func withReferencedResourceKindedValues(referencedResourceKindedValues Reference
 	}
 }
 
+// withStorageIterations returns an interpreter option which sets the storage iterations.
+//
+func withStorageIterations(storageIterations map[StorageKey]int) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.storageIterations = storageIterations
+		return nil
+	}
+}
+
+// withContainerIterations returns an interpreter option which sets the container iterations.
+//
+func withContainerIterations(containerIterations map[atree.StorageID]int) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.containerIterations = containerIterations
+		return nil
+	}
+}
+
 // WithDebugger returns an interpreter option which sets the given debugger
 //
 func WithDebugger(debugger *Debugger) Option {
@@ -692,6 +784,8 @@ func NewInterpreter(program *Program, location common.Location, options ...Optio
 			TypeRequirementCodes: map[sema.TypeID]WrapperCode{},
 		}),
 		withReferencedResourceKindedValues(map[atree.StorageID]map[ReferenceTrackedResourceKindedValue]struct{}{}),
+		withStorageIterations(map[StorageKey]int{}),
+		withContainerIterations(map[atree.StorageID]int{}),
 		WithInvalidatedResourceValidationEnabled(true),
 	}
 
@@ -724,6 +818,19 @@ func (interpreter *Interpreter) SetOnStatementHandler(function OnStatementFunc)
 	interpreter.onStatement = function
 }
 
+// SetOnStatementStartHandler sets the function that is triggered when a statement starts,
+// before its computation is metered.
+//
+func (interpreter *Interpreter) SetOnStatementStartHandler(function OnStatementStartFunc) {
+	interpreter.onStatementStart = function
+}
+
+// SetOnBranchHandler sets the function that is triggered when a branch is about to be executed.
+//
+func (interpreter *Interpreter) SetOnBranchHandler(function OnBranchFunc) {
+	interpreter.onBranch = function
+}
+
 // SetOnLoopIterationHandler sets the function that is triggered when a loop iteration is about to be executed.
 //
 func (interpreter *Interpreter) SetOnLoopIterationHandler(function OnLoopIterationFunc) {
@@ -760,6 +867,20 @@ func (interpreter *Interpreter) SetOnMeterComputationHandler(function OnMeterCom
 	interpreter.onMeterComputation = function
 }
 
+// SetMemoryGauge sets the gauge that is notified about the memory used by values.
+//
+func (interpreter *Interpreter) SetMemoryGauge(memoryGauge common.MemoryGauge) {
+	interpreter.memoryGauge = memoryGauge
+}
+
+// SetContext sets the context that is checked at statement and loop boundaries.
+// Once the context is done, i.e. canceled or its deadline is exceeded,
+// the execution is aborted with an ExecutionCanceledError.
+//
+func (interpreter *Interpreter) SetContext(ctx context.Context) {
+	interpreter.ctx = ctx
+}
+
 // SetStorage sets the value that is used for storage operations.
 func (interpreter *Interpreter) SetStorage(storage Storage) {
 	interpreter.Storage = storage
@@ -1278,6 +1399,8 @@ func (interpreter *Interpreter) functionDeclarationValue(
 		beforeStatements = postConditionsRewrite.BeforeStatements
 	}
 
+	common.UseMemory(interpreter.memoryGauge, common.InterpretedFunctionValueMemoryUsage)
+
 	return &InterpretedFunctionValue{
 		Interpreter:      interpreter,
 		ParameterList:    declaration.ParameterList,
@@ -1604,6 +1727,29 @@ func (interpreter *Interpreter) declareNonEnumCompositeValue(
 
 	functions := interpreter.compositeFunctions(declaration, lexicalScope)
 
+	// Inherit the default functions of the conformances,
+	// if the composite does not implement the functions itself.
+	//
+	// The checker ensures that at most one conformance
+	// provides a default function with a given name.
+	//
+	// NOTE: The conditions of the interface function are not part of the default function,
+	// they are added below by the function wrappers of the conformance
+
+	for _, conformance := range compositeType.ExplicitInterfaceConformances {
+		defaultFunctions := interpreter.typeCodes.InterfaceCodes[conformance.ID()].DefaultFunctions
+
+		// Iterating over the map in a non-deterministic way is OK,
+		// we only copy the functions over.
+
+		for name, defaultFunction := range defaultFunctions { //nolint:maprangecheck
+			if _, ok := functions[name]; ok {
+				continue
+			}
+			functions[name] = defaultFunction
+		}
+	}
+
 	wrapFunctions := func(code WrapperCode) {
 
 		// Wrap initializer
@@ -1716,11 +1862,29 @@ func (interpreter *Interpreter) declareNonEnumCompositeValue(
 					)
 				}
 
-				value := NewCompositeValue(
+				// The type arguments of an instantiated generic composite type
+				// are given by the constructor invocation
+
+				var typeArguments []StaticType
+
+				typeParameters := compositeType.TypeParameters()
+				if len(typeParameters) > 0 {
+					typeArguments = make([]StaticType, len(typeParameters))
+					for i, typeParameter := range typeParameters {
+						typeArgument, ok := invocation.TypeParameterTypes.Get(typeParameter)
+						if !ok {
+							panic(errors.NewUnreachableError())
+						}
+						typeArguments[i] = ConvertSemaToStaticType(typeArgument)
+					}
+				}
+
+				value := NewInstantiatedCompositeValue(
 					interpreter,
 					location,
 					qualifiedIdentifier,
 					declaration.CompositeKind,
+					typeArguments,
 					fields,
 					address,
 				)
@@ -1927,6 +2091,8 @@ func (interpreter *Interpreter) compositeInitializerFunction(
 		rewrittenPostConditions = postConditionsRewrite.RewrittenPostConditions
 	}
 
+	common.UseMemory(interpreter.memoryGauge, common.InterpretedFunctionValueMemoryUsage)
+
 	return &InterpretedFunctionValue{
 		Interpreter:      interpreter,
 		ParameterList:    parameterList,
@@ -1970,6 +2136,8 @@ func (interpreter *Interpreter) compositeDestructorFunction(
 		rewrittenPostConditions = postConditionsRewrite.RewrittenPostConditions
 	}
 
+	common.UseMemory(interpreter.memoryGauge, common.InterpretedFunctionValueMemoryUsage)
+
 	return &InterpretedFunctionValue{
 		Interpreter:      interpreter,
 		Type:             emptyFunctionType,
@@ -2026,6 +2194,42 @@ func (interpreter *Interpreter) functionWrappers(
 	return functionWrappers
 }
 
+// defaultFunctions returns the default functions of an interface,
+// i.e. the functions which have an implementation.
+//
+// The conditions of the functions are not part of the default functions,
+// they are added by the function wrappers of the interface.
+//
+func (interpreter *Interpreter) defaultFunctions(
+	members *ast.Members,
+	lexicalScope *VariableActivation,
+) map[string]FunctionValue {
+
+	defaultFunctions := map[string]FunctionValue{}
+
+	for _, functionDeclaration := range members.Functions() {
+
+		if !functionDeclaration.FunctionBlock.HasStatements() {
+			continue
+		}
+
+		functionType := interpreter.Program.Elaboration.FunctionDeclarationFunctionTypes[functionDeclaration]
+
+		name := functionDeclaration.Identifier.Identifier
+		common.UseMemory(interpreter.memoryGauge, common.InterpretedFunctionValueMemoryUsage)
+
+		defaultFunctions[name] = &InterpretedFunctionValue{
+			Interpreter:   interpreter,
+			ParameterList: functionDeclaration.ParameterList,
+			Type:          functionType,
+			Activation:    lexicalScope,
+			Statements:    functionDeclaration.FunctionBlock.Block.Statements,
+		}
+	}
+
+	return defaultFunctions
+}
+
 func (interpreter *Interpreter) compositeFunction(
 	functionDeclaration *ast.FunctionDeclaration,
 	lexicalScope *VariableActivation,
@@ -2054,6 +2258,8 @@ func (interpreter *Interpreter) compositeFunction(
 	parameterList := functionDeclaration.ParameterList
 	statements := functionDeclaration.FunctionBlock.Block.Statements
 
+	common.UseMemory(interpreter.memoryGauge, common.InterpretedFunctionValueMemoryUsage)
+
 	return &InterpretedFunctionValue{
 		Interpreter:      interpreter,
 		ParameterList:    parameterList,
@@ -2118,6 +2324,12 @@ func (interpreter *Interpreter) transferAndConvert(
 	getLocationRange func() LocationRange,
 ) Value {
 
+	// The static types might refer to type parameters,
+	// e.g. in a generic function
+
+	valueType = interpreter.resolveType(valueType)
+	targetType = interpreter.resolveType(targetType)
+
 	transferredValue := value.Transfer(
 		interpreter,
 		getLocationRange,
@@ -2305,6 +2517,8 @@ func (interpreter *Interpreter) BoxOptional(
 			return inner
 
 		default:
+			common.UseMemory(interpreter.memoryGauge, common.OptionalValueMemoryUsage)
+
 			value = NewSomeValueNonCopying(value)
 		}
 
@@ -2361,11 +2575,13 @@ func (interpreter *Interpreter) declareInterface(
 	initializerFunctionWrapper := interpreter.initializerFunctionWrapper(declaration.Members, lexicalScope)
 	destructorFunctionWrapper := interpreter.destructorFunctionWrapper(declaration.Members, lexicalScope)
 	functionWrappers := interpreter.functionWrappers(declaration.Members, lexicalScope)
+	defaultFunctions := interpreter.defaultFunctions(declaration.Members, lexicalScope)
 
 	interpreter.typeCodes.InterfaceCodes[typeID] = WrapperCode{
 		InitializerFunctionWrapper: initializerFunctionWrapper,
 		DestructorFunctionWrapper:  destructorFunctionWrapper,
 		FunctionWrappers:           functionWrappers,
+		DefaultFunctions:           defaultFunctions,
 	}
 }
 
@@ -2620,7 +2836,9 @@ func (interpreter *Interpreter) NewSubInterpreter(
 		WithPredeclaredValues(interpreter.PredeclaredValues),
 		WithOnEventEmittedHandler(interpreter.onEventEmitted),
 		WithOnStatementHandler(interpreter.onStatement),
+		WithOnStatementStartHandler(interpreter.onStatementStart),
 		WithOnLoopIterationHandler(interpreter.onLoopIteration),
+		WithOnBranchHandler(interpreter.onBranch),
 		WithOnFunctionInvocationHandler(interpreter.onFunctionInvocation),
 		WithOnInvokedFunctionReturnHandler(interpreter.onInvokedFunctionReturn),
 		WithInjectedCompositeFieldsHandler(interpreter.injectedCompositeFieldsHandler),
@@ -2632,6 +2850,8 @@ func (interpreter *Interpreter) NewSubInterpreter(
 		WithAtreeStorageValidationEnabled(interpreter.atreeStorageValidationEnabled),
 		withTypeCodes(interpreter.typeCodes),
 		withReferencedResourceKindedValues(interpreter.referencedResourceKindedValues),
+		withStorageIterations(interpreter.storageIterations),
+		withContainerIterations(interpreter.containerIterations),
 		WithPublicAccountHandler(interpreter.publicAccountHandler),
 		WithPublicKeyValidationHandler(interpreter.PublicKeyValidationHandler),
 		WithSignatureVerificationHandler(interpreter.SignatureVerificationHandler),
@@ -2647,6 +2867,8 @@ func (interpreter *Interpreter) NewSubInterpreter(
 		WithOnRecordTraceHandler(interpreter.onRecordTrace),
 		WithOnResourceOwnerChangeHandler(interpreter.onResourceOwnerChange),
 		WithOnMeterComputationFuncHandler(interpreter.onMeterComputation),
+		WithMemoryGauge(interpreter.memoryGauge),
+		WithContext(interpreter.ctx),
 	}
 
 	return NewInterpreter(
@@ -2664,7 +2886,7 @@ func (interpreter *Interpreter) storedValueExists(
 	domain string,
 	identifier string,
 ) bool {
-	accountStorage := interpreter.Storage.GetStorageMap(storageAddress, domain)
+	accountStorage := interpreter.Storage.GetStorageMap(storageAddress, domain, true)
 	return accountStorage.ValueExists(identifier)
 }
 
@@ -2673,7 +2895,7 @@ func (interpreter *Interpreter) ReadStored(
 	domain string,
 	identifier string,
 ) Value {
-	accountStorage := interpreter.Storage.GetStorageMap(storageAddress, domain)
+	accountStorage := interpreter.Storage.GetStorageMap(storageAddress, domain, true)
 	return accountStorage.ReadValue(identifier)
 }
 
@@ -2683,7 +2905,20 @@ func (interpreter *Interpreter) writeStored(
 	identifier string,
 	value Value,
 ) {
-	accountStorage := interpreter.Storage.GetStorageMap(storageAddress, domain)
+	// The storage map must not be modified while it is iterated over
+
+	storageKey := StorageKey{
+		Address: storageAddress,
+		Key:     domain,
+	}
+	if interpreter.storageIterations[storageKey] > 0 {
+		panic(StorageMutatedDuringIterationError{
+			Address: storageAddress,
+			Domain:  domain,
+		})
+	}
+
+	accountStorage := interpreter.Storage.GetStorageMap(storageAddress, domain, true)
 	accountStorage.WriteValue(interpreter, identifier, value)
 }
 
@@ -3365,6 +3600,8 @@ var typeFunction = NewHostFunctionValue(
 
 		ty := typeParameterPair.Value
 
+		common.UseMemory(invocation.Interpreter.memoryGauge, common.TypeValueMemoryUsage)
+
 		return TypeValue{
 			Type: ConvertSemaToStaticType(ty),
 		}
@@ -3390,7 +3627,7 @@ func defineBaseValue(activation *VariableActivation, name string, value Value) {
 var stringFunction = func() Value {
 	functionValue := NewHostFunctionValue(
 		func(invocation Invocation) Value {
-			return NewStringValue("")
+			return emptyString
 		},
 		&sema.FunctionType{
 			ReturnTypeAnnotation: sema.NewTypeAnnotation(
@@ -3416,12 +3653,46 @@ var stringFunction = func() Value {
 				}
 
 				bytes, _ := ByteArrayValueToByteSlice(argument)
-				return NewStringValue(hex.EncodeToString(bytes))
+
+				invocation.Interpreter.ReportComputation(
+					common.ComputationKindStringEncodeHex,
+					uint(len(bytes)),
+				)
+
+				memoryUsage := common.NewStringMemoryUsage(hex.EncodedLen(len(bytes)))
+
+				return NewStringValue(
+					invocation.Interpreter,
+					memoryUsage,
+					func() string {
+						return hex.EncodeToString(bytes)
+					},
+				)
 			},
 			sema.StringTypeEncodeHexFunctionType,
 		),
 	)
 
+	addMember(
+		sema.StringTypeJoinFunctionName,
+		NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				strs, ok := invocation.Arguments[0].(*ArrayValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				separator, ok := invocation.Arguments[1].(*StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return JoinStrings(invocation.Interpreter, strs, separator)
+			},
+			sema.StringTypeJoinFunctionType,
+		),
+	)
+
 	return functionValue
 }()
 
@@ -3440,6 +3711,17 @@ func (interpreter *Interpreter) IsSubType(subType DynamicType, superType sema.Ty
 		return true
 	}
 
+	// A type parameter which is not resolved
+	// can only be instantiated with subtypes of its type bound
+
+	if genericType, ok := superType.(*sema.GenericType); ok {
+		typeBound := genericType.TypeParameter.TypeBound
+		if typeBound == nil {
+			return true
+		}
+		return interpreter.IsSubType(subType, typeBound)
+	}
+
 	switch typedSubType := subType.(type) {
 	case MetaTypeDynamicType:
 		switch superType {
@@ -3758,6 +4040,107 @@ func (interpreter *Interpreter) authAccountTypeFunction(addressValue AddressValu
 	)
 }
 
+func (interpreter *Interpreter) accountForEachFunction(
+	addressValue AddressValue,
+	domain common.PathDomain,
+	functionType *sema.FunctionType,
+) *HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return NewHostFunctionValue(
+		func(invocation Invocation) Value {
+
+			function, ok := invocation.Arguments[0].(FunctionValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			functionArgumentType, ok := invocation.ArgumentTypes[0].(*sema.FunctionType)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			argumentTypes := []sema.Type{
+				functionArgumentType.Parameters[0].TypeAnnotation.Type,
+				functionArgumentType.Parameters[1].TypeAnnotation.Type,
+			}
+
+			identifier := domain.Identifier()
+
+			// Reject modifications of the storage map while iterating over it,
+			// as the iterator would be invalidated
+
+			storageKey := StorageKey{
+				Address: address,
+				Key:     identifier,
+			}
+
+			interpreter.storageIterations[storageKey]++
+			defer func() {
+				interpreter.storageIterations[storageKey]--
+				if interpreter.storageIterations[storageKey] == 0 {
+					delete(interpreter.storageIterations, storageKey)
+				}
+			}()
+
+			// Iterating must not write to storage,
+			// so do not create the storage map if the account has none for the domain
+
+			storageMap := interpreter.Storage.GetStorageMap(address, identifier, false)
+			if storageMap == nil {
+				return VoidValue{}
+			}
+
+			iterator := storageMap.Iterator()
+
+			getLocationRange := invocation.GetLocationRange
+
+			for {
+				key := iterator.NextKey()
+				if key == "" {
+					break
+				}
+
+				interpreter.reportLoopIteration(getLocationRange())
+
+				// Only the static type of the stored value is needed,
+				// so the value itself is not loaded
+
+				staticType := storageMap.ReadStaticType(key)
+
+				result := function.invoke(Invocation{
+					Arguments: []Value{
+						PathValue{
+							Domain:     domain,
+							Identifier: key,
+						},
+						TypeValue{
+							Type: staticType,
+						},
+					},
+					ArgumentTypes:    argumentTypes,
+					GetLocationRange: getLocationRange,
+					Interpreter:      invocation.Interpreter,
+				})
+
+				shouldContinue, ok := result.(BoolValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				if !shouldContinue {
+					break
+				}
+			}
+
+			return VoidValue{}
+		},
+		functionType,
+	)
+}
+
 func (interpreter *Interpreter) authAccountLoadFunction(addressValue AddressValue) *HostFunctionValue {
 	return interpreter.authAccountReadFunction(addressValue, true)
 }
@@ -3857,6 +4240,8 @@ func (interpreter *Interpreter) authAccountBorrowFunction(addressValue AddressVa
 				panic(errors.NewUnreachableError())
 			}
 
+			common.UseMemory(interpreter.memoryGauge, common.StorageReferenceValueMemoryUsage)
+
 			reference := &StorageReferenceValue{
 				Authorized:           referenceType.Authorized,
 				TargetStorageAddress: address,
@@ -3926,6 +4311,8 @@ func (interpreter *Interpreter) authAccountLinkFunction(addressValue AddressValu
 
 			borrowStaticType := ConvertSemaToStaticType(borrowType)
 
+			common.UseMemory(interpreter.memoryGauge, common.LinkValueMemoryUsage)
+
 			linkValue := LinkValue{
 				TargetPath: targetPath,
 				Type:       borrowStaticType,
@@ -3938,6 +4325,8 @@ func (interpreter *Interpreter) authAccountLinkFunction(addressValue AddressValu
 				linkValue,
 			)
 
+			common.UseMemory(interpreter.memoryGauge, common.CapabilityValueMemoryUsage)
+
 			return NewSomeValueNonCopying(
 				&CapabilityValue{
 					Address:    addressValue,
@@ -4013,6 +4402,7 @@ func (interpreter *Interpreter) authAccountUnlinkFunction(addressValue AddressVa
 func (interpreter *Interpreter) capabilityBorrowFunction(
 	addressValue AddressValue,
 	pathValue PathValue,
+	capabilityID UInt64Value,
 	borrowType *sema.ReferenceType,
 ) *HostFunctionValue {
 
@@ -4040,9 +4430,10 @@ func (interpreter *Interpreter) capabilityBorrowFunction(
 			}
 
 			targetPath, authorized, err :=
-				interpreter.GetCapabilityFinalTargetPath(
+				interpreter.getCapabilityTargetPath(
 					address,
 					pathValue,
+					capabilityID,
 					borrowType,
 					invocation.GetLocationRange,
 				)
@@ -4054,6 +4445,8 @@ func (interpreter *Interpreter) capabilityBorrowFunction(
 				return NilValue{}
 			}
 
+			common.UseMemory(interpreter.memoryGauge, common.StorageReferenceValueMemoryUsage)
+
 			reference := &StorageReferenceValue{
 				Authorized:           authorized,
 				TargetStorageAddress: address,
@@ -4082,6 +4475,7 @@ func (interpreter *Interpreter) capabilityBorrowFunction(
 func (interpreter *Interpreter) capabilityCheckFunction(
 	addressValue AddressValue,
 	pathValue PathValue,
+	capabilityID UInt64Value,
 	borrowType *sema.ReferenceType,
 ) *HostFunctionValue {
 
@@ -4110,9 +4504,10 @@ func (interpreter *Interpreter) capabilityCheckFunction(
 			}
 
 			targetPath, authorized, err :=
-				interpreter.GetCapabilityFinalTargetPath(
+				interpreter.getCapabilityTargetPath(
 					address,
 					pathValue,
+					capabilityID,
 					borrowType,
 					invocation.GetLocationRange,
 				)
@@ -4124,6 +4519,8 @@ func (interpreter *Interpreter) capabilityCheckFunction(
 				return BoolValue(false)
 			}
 
+			common.UseMemory(interpreter.memoryGauge, common.StorageReferenceValueMemoryUsage)
+
 			reference := &StorageReferenceValue{
 				Authorized:           authorized,
 				TargetStorageAddress: address,
@@ -4145,6 +4542,35 @@ func (interpreter *Interpreter) capabilityCheckFunction(
 	)
 }
 
+// getCapabilityTargetPath returns the storage path targeted by a capability.
+// Capabilities issued through a capability controller are resolved through the controller,
+// path-based capabilities are resolved by following links.
+//
+func (interpreter *Interpreter) getCapabilityTargetPath(
+	address common.Address,
+	path PathValue,
+	capabilityID UInt64Value,
+	wantedBorrowType *sema.ReferenceType,
+	getLocationRange func() LocationRange,
+) (
+	targetPath PathValue,
+	authorized bool,
+	err error,
+) {
+	if capabilityID != 0 {
+		targetPath, authorized =
+			interpreter.GetCapabilityControllerTargetPath(address, capabilityID, wantedBorrowType)
+		return targetPath, authorized, nil
+	}
+
+	return interpreter.GetCapabilityFinalTargetPath(
+		address,
+		path,
+		wantedBorrowType,
+		getLocationRange,
+	)
+}
+
 func (interpreter *Interpreter) GetCapabilityFinalTargetPath(
 	address common.Address,
 	path PathValue,
@@ -4195,6 +4621,18 @@ func (interpreter *Interpreter) GetCapabilityFinalTargetPath(
 			paths = append(paths, targetPath)
 			path = targetPath
 
+		} else if capability, ok := value.(*CapabilityValue); ok &&
+			capability.IsControllerCapability() &&
+			capability.Address.ToAddress() == address {
+
+			// A capability issued through a capability controller
+			// was published at the path using `AuthAccount.capabilities.publish`,
+			// resolve the target through the controller
+
+			finalPath, authorized =
+				interpreter.GetCapabilityControllerTargetPath(address, capability.ID, wantedBorrowType)
+			return finalPath, authorized, nil
+
 		} else {
 			return path, wantedReferenceType.Authorized, nil
 		}
@@ -4329,9 +4767,38 @@ func (interpreter *Interpreter) reportLoopIteration(pos ast.HasPosition) {
 		interpreter.onMeterComputation(common.ComputationKindLoop, 1)
 	}
 
+	interpreter.checkContext()
+
 	if interpreter.onLoopIteration != nil {
 		line := pos.StartPosition().Line
 		interpreter.onLoopIteration(interpreter, line)
+
+		// The handler may have taken a long time, check again
+		interpreter.checkContext()
+	}
+}
+
+func (interpreter *Interpreter) reportBranch(element ast.Element, branch int) {
+	if interpreter.onBranch == nil {
+		return
+	}
+
+	interpreter.onBranch(interpreter, element, branch)
+}
+
+// checkContext aborts the execution if the context is done,
+// i.e. it was canceled or its deadline is exceeded.
+//
+func (interpreter *Interpreter) checkContext() {
+	if interpreter.ctx == nil {
+		return
+	}
+
+	err := interpreter.ctx.Err()
+	if err != nil {
+		panic(ExecutionCanceledError{
+			Err: err,
+		})
 	}
 }
 
@@ -4358,6 +4825,72 @@ func (interpreter *Interpreter) ReportComputation(compKind common.ComputationKin
 	}
 }
 
+var _ common.MemoryGauge = &Interpreter{}
+
+// MeterMemory reports the given memory usage to the memory gauge of the interpreter, if any.
+// Passing the interpreter as a memory gauge allows metering memory
+// in code which has access to the interpreter, e.g. value constructors.
+// A nil interpreter meters nothing.
+//
+func (interpreter *Interpreter) MeterMemory(usage common.MemoryUsage) error {
+	if interpreter == nil || interpreter.memoryGauge == nil {
+		return nil
+	}
+	return interpreter.memoryGauge.MeterMemory(usage)
+}
+
+// atreeElementSizeEstimate is the estimated size in bytes of an element of an atree container.
+// It is used to estimate the memory usage of arrays, dictionaries, and composites
+//
+const atreeElementSizeEstimate = 32
+
+// meterContainerValue reports the estimated memory usage of a container value
+// with the given base usage, which is backed by atree slabs holding the given number of elements
+//
+func (interpreter *Interpreter) meterContainerValue(
+	baseUsage common.MemoryUsage,
+	atreeUsages func(count uint64, elementSize uint) (dataSlabs, metaDataSlabs, elementOverhead common.MemoryUsage),
+	count uint64,
+) {
+	if interpreter.memoryGauge == nil {
+		return
+	}
+
+	common.UseMemory(interpreter.memoryGauge, baseUsage)
+
+	dataSlabs, metaDataSlabs, elementOverhead := atreeUsages(count, atreeElementSizeEstimate)
+	for _, usage := range []common.MemoryUsage{dataSlabs, metaDataSlabs, elementOverhead} {
+		if usage.Amount == 0 {
+			continue
+		}
+		common.UseMemory(interpreter.memoryGauge, usage)
+	}
+}
+
+func (interpreter *Interpreter) meterArrayValue(count uint64) {
+	interpreter.meterContainerValue(
+		common.ArrayValueBaseMemoryUsage,
+		common.NewAtreeArrayMemoryUsages,
+		count,
+	)
+}
+
+func (interpreter *Interpreter) meterDictionaryValue(count uint64) {
+	interpreter.meterContainerValue(
+		common.DictionaryValueBaseMemoryUsage,
+		common.NewAtreeMapMemoryUsages,
+		count,
+	)
+}
+
+func (interpreter *Interpreter) meterCompositeValue(count uint64) {
+	interpreter.meterContainerValue(
+		common.CompositeValueBaseMemoryUsage,
+		common.NewAtreeMapMemoryUsages,
+		count,
+	)
+}
+
 // getMember gets the member value by the given identifier from the given Value depending on its type.
 // May return nil if the member does not exist.
 func (interpreter *Interpreter) getMember(self Value, getLocationRange func() LocationRange, identifier string) Value {
@@ -4415,6 +4948,8 @@ func (interpreter *Interpreter) isInstanceFunction(self Value) *HostFunctionValu
 func (interpreter *Interpreter) getTypeFunction(self Value) *HostFunctionValue {
 	return NewHostFunctionValue(
 		func(invocation Invocation) Value {
+			common.UseMemory(interpreter.memoryGauge, common.TypeValueMemoryUsage)
+
 			return TypeValue{
 				Type: self.StaticType(),
 			}
@@ -4462,6 +4997,28 @@ func (interpreter *Interpreter) checkContainerMutation(
 	}
 }
 
+// isContainerIterated returns true if the array or dictionary
+// with the given storage ID is currently iterated over.
+//
+func (interpreter *Interpreter) isContainerIterated(storageID atree.StorageID) bool {
+	return interpreter.containerIterations[storageID] > 0
+}
+
+// withContainerIteration calls the given function,
+// during which the array or dictionary with the given storage ID is considered iterated over.
+//
+func (interpreter *Interpreter) withContainerIteration(storageID atree.StorageID, f func()) {
+	interpreter.containerIterations[storageID]++
+	defer func() {
+		interpreter.containerIterations[storageID]--
+		if interpreter.containerIterations[storageID] == 0 {
+			delete(interpreter.containerIterations, storageID)
+		}
+	}()
+
+	f()
+}
+
 func (interpreter *Interpreter) checkResourceNotDestroyed(value Value, getLocationRange func() LocationRange) {
 	resourceKindedValue, ok := value.(ResourceKindedValue)
 	if !ok || !resourceKindedValue.IsDestroyed() {
@@ -22,6 +22,8 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/onflow/atree"
+
 	"github.com/onflow/cadence/fixedpoint"
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
@@ -200,7 +202,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.Plus(right)
+		return left.Plus(interpreter, right)
 
 	case ast.OperationMinus:
 		left, leftOk := leftValue.(NumberValue)
@@ -208,7 +210,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.Minus(right)
+		return left.Minus(interpreter, right)
 
 	case ast.OperationMod:
 		left, leftOk := leftValue.(NumberValue)
@@ -216,7 +218,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.Mod(right)
+		return left.Mod(interpreter, right)
 
 	case ast.OperationMul:
 		left, leftOk := leftValue.(NumberValue)
@@ -224,7 +226,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.Mul(right)
+		return left.Mul(interpreter, right)
 
 	case ast.OperationDiv:
 		left, leftOk := leftValue.(NumberValue)
@@ -232,7 +234,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.Div(right)
+		return left.Div(interpreter, right)
 
 	case ast.OperationBitwiseOr:
 		left, leftOk := leftValue.(IntegerValue)
@@ -240,7 +242,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.BitwiseOr(right)
+		return left.BitwiseOr(interpreter, right)
 
 	case ast.OperationBitwiseXor:
 		left, leftOk := leftValue.(IntegerValue)
@@ -248,7 +250,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.BitwiseXor(right)
+		return left.BitwiseXor(interpreter, right)
 
 	case ast.OperationBitwiseAnd:
 		left, leftOk := leftValue.(IntegerValue)
@@ -256,7 +258,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.BitwiseAnd(right)
+		return left.BitwiseAnd(interpreter, right)
 
 	case ast.OperationBitwiseLeftShift:
 		left, leftOk := leftValue.(IntegerValue)
@@ -264,7 +266,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.BitwiseLeftShift(right)
+		return left.BitwiseLeftShift(interpreter, right)
 
 	case ast.OperationBitwiseRightShift:
 		left, leftOk := leftValue.(IntegerValue)
@@ -272,7 +274,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.BitwiseRightShift(right)
+		return left.BitwiseRightShift(interpreter, right)
 
 	case ast.OperationLess:
 		left, leftOk := leftValue.(NumberValue)
@@ -357,9 +359,12 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 
 		// only evaluate right-hand side if left-hand side is nil
 		if some, ok := leftValue.(*SomeValue); ok {
+			interpreter.reportBranch(expression, 0)
 			return some.InnerValue(interpreter, getLocationRange)
 		}
 
+		interpreter.reportBranch(expression, 1)
+
 		value := rightValue()
 
 		rightType := interpreter.Program.Elaboration.BinaryExpressionRightTypes[expression]
@@ -415,7 +420,7 @@ func (interpreter *Interpreter) VisitUnaryExpression(expression *ast.UnaryExpres
 		if !ok {
 			panic(errors.NewUnreachableError())
 		}
-		return integerValue.Negate()
+		return integerValue.Negate(interpreter)
 
 	case ast.OperationMove:
 		interpreter.invalidateResource(value)
@@ -443,6 +448,8 @@ func (interpreter *Interpreter) VisitIntegerExpression(expression *ast.IntegerEx
 	value := expression.Value
 
 	if _, ok := typ.(*sema.AddressType); ok {
+		common.UseMemory(interpreter.memoryGauge, common.AddressValueMemoryUsage)
+
 		return NewAddressValueFromBytes(value.Bytes())
 	}
 
@@ -538,17 +545,35 @@ func (interpreter *Interpreter) VisitStringExpression(expression *ast.StringExpr
 
 	switch stringType {
 	case sema.CharacterType:
-		return NewCharacterValue(expression.Value)
+		memoryUsage := common.NewCharacterMemoryUsage(len(expression.Value))
+
+		return NewCharacterValue(
+			interpreter,
+			memoryUsage,
+			func() string {
+				return expression.Value
+			},
+		)
 	}
 
-	return NewStringValue(expression.Value)
+	memoryUsage := common.NewStringMemoryUsage(len(expression.Value))
+
+	return NewStringValue(
+		interpreter,
+		memoryUsage,
+		func() string {
+			return expression.Value
+		},
+	)
 }
 
 func (interpreter *Interpreter) VisitArrayExpression(expression *ast.ArrayExpression) ast.Repr {
 	values := interpreter.visitExpressionsNonCopying(expression.Values)
 
 	argumentTypes := interpreter.Program.Elaboration.ArrayExpressionArgumentTypes[expression]
-	arrayType := interpreter.Program.Elaboration.ArrayExpressionArrayType[expression]
+	arrayType := interpreter.resolveType(
+		interpreter.Program.Elaboration.ArrayExpressionArrayType[expression],
+	).(sema.ArrayType)
 	elementType := arrayType.ElementType(false)
 
 	copies := make([]Value, len(values))
@@ -574,7 +599,9 @@ func (interpreter *Interpreter) VisitDictionaryExpression(expression *ast.Dictio
 	values := interpreter.visitEntries(expression.Entries)
 
 	entryTypes := interpreter.Program.Elaboration.DictionaryExpressionEntryTypes[expression]
-	dictionaryType := interpreter.Program.Elaboration.DictionaryExpressionType[expression]
+	dictionaryType := interpreter.resolveType(
+		interpreter.Program.Elaboration.DictionaryExpressionType[expression],
+	).(*sema.DictionaryType)
 
 	var keyValuePairs []Value
 
@@ -616,6 +643,10 @@ func (interpreter *Interpreter) VisitMemberExpression(expression *ast.MemberExpr
 }
 
 func (interpreter *Interpreter) VisitIndexExpression(expression *ast.IndexExpression) ast.Repr {
+	if attachmentType, ok := interpreter.Program.Elaboration.AttachmentAccessTypes[expression]; ok {
+		return interpreter.visitAttachmentAccessExpression(expression, attachmentType)
+	}
+
 	typedResult, ok := interpreter.evalExpression(expression.TargetExpression).(ValueIndexableValue)
 	if !ok {
 		panic(errors.NewUnreachableError())
@@ -625,14 +656,67 @@ func (interpreter *Interpreter) VisitIndexExpression(expression *ast.IndexExpres
 	return typedResult.GetKey(interpreter, getLocationRange, indexingValue)
 }
 
+// visitAttachmentAccessExpression evaluates an access of an attachment, e.g. `r[A]`,
+// and returns an optional reference to the attachment.
+//
+func (interpreter *Interpreter) visitAttachmentAccessExpression(
+	expression *ast.IndexExpression,
+	attachmentType *sema.CompositeType,
+) Value {
+	getLocationRange := locationRangeGetter(interpreter.Location, expression)
+
+	var base *CompositeValue
+
+	switch target := interpreter.evalExpression(expression.TargetExpression).(type) {
+	case *CompositeValue:
+		base = target
+
+	case *EphemeralReferenceValue:
+		referencedValue := target.ReferencedValue(interpreter, getLocationRange)
+		if referencedValue == nil {
+			panic(DereferenceError{
+				LocationRange: getLocationRange(),
+			})
+		}
+		base, _ = (*referencedValue).(*CompositeValue)
+
+	case *StorageReferenceValue:
+		referencedValue := target.ReferencedValue(interpreter)
+		if referencedValue == nil {
+			panic(DereferenceError{
+				LocationRange: getLocationRange(),
+			})
+		}
+		base, _ = (*referencedValue).(*CompositeValue)
+	}
+
+	if base == nil {
+		panic(errors.NewUnreachableError())
+	}
+
+	attachment := base.GetAttachment(interpreter, getLocationRange, common.TypeID(attachmentType.ID()))
+	if attachment == nil {
+		return NilValue{}
+	}
+
+	common.UseMemory(interpreter.memoryGauge, common.EphemeralReferenceValueMemoryUsage)
+
+	return NewSomeValueNonCopying(&EphemeralReferenceValue{
+		Value:        attachment,
+		BorrowedType: attachmentType,
+	})
+}
+
 func (interpreter *Interpreter) VisitConditionalExpression(expression *ast.ConditionalExpression) ast.Repr {
 	value, ok := interpreter.evalExpression(expression.Test).(BoolValue)
 	if !ok {
 		panic(errors.NewUnreachableError())
 	}
 	if value {
+		interpreter.reportBranch(expression, 0)
 		return interpreter.evalExpression(expression.Then)
 	} else {
+		interpreter.reportBranch(expression, 1)
 		return interpreter.evalExpression(expression.Else)
 	}
 }
@@ -693,8 +777,9 @@ func (interpreter *Interpreter) VisitInvocationExpression(invocationExpression *
 
 	arguments := interpreter.visitExpressionsNonCopying(argumentExpressions)
 
-	typeParameterTypes :=
-		interpreter.Program.Elaboration.InvocationExpressionTypeArguments[invocationExpression]
+	typeParameterTypes := interpreter.resolveTypeParameterTypes(
+		interpreter.Program.Elaboration.InvocationExpressionTypeArguments[invocationExpression],
+	)
 	argumentTypes :=
 		interpreter.Program.Elaboration.InvocationExpressionArgumentTypes[invocationExpression]
 	parameterTypes :=
@@ -780,6 +865,8 @@ func (interpreter *Interpreter) VisitFunctionExpression(expression *ast.Function
 
 	statements := expression.FunctionBlock.Block.Statements
 
+	common.UseMemory(interpreter.memoryGauge, common.InterpretedFunctionValueMemoryUsage)
+
 	return &InterpretedFunctionValue{
 		Interpreter:      interpreter,
 		ParameterList:    expression.ParameterList,
@@ -797,7 +884,9 @@ func (interpreter *Interpreter) VisitCastingExpression(expression *ast.CastingEx
 
 	getLocationRange := locationRangeGetter(interpreter.Location, expression.Expression)
 
-	expectedType := interpreter.Program.Elaboration.CastingTargetTypes[expression]
+	expectedType := interpreter.resolveType(
+		interpreter.Program.Elaboration.CastingTargetTypes[expression],
+	)
 
 	switch expression.Operation {
 	case ast.OperationFailableCast, ast.OperationForceCast:
@@ -857,9 +946,54 @@ func (interpreter *Interpreter) VisitDestroyExpression(expression *ast.DestroyEx
 	return VoidValue{}
 }
 
+func (interpreter *Interpreter) VisitAttachExpression(expression *ast.AttachExpression) ast.Repr {
+
+	getLocationRange := locationRangeGetter(interpreter.Location, expression)
+
+	// NOTE: evaluate the base first, as the checker checks the base first
+
+	base, ok := interpreter.evalExpression(expression.Base).(*CompositeValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	// Structures are copied, resources are moved
+
+	base, ok = base.Transfer(
+		interpreter,
+		getLocationRange,
+		atree.Address{},
+		false,
+		nil,
+	).(*CompositeValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	attachment, ok := interpreter.evalExpression(expression.Attachment).(*CompositeValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	// A value can only have one attachment of each type
+
+	if base.GetAttachment(interpreter, getLocationRange, attachment.TypeID()) != nil {
+		panic(DuplicateAttachmentError{
+			AttachmentType: interpreter.Program.Elaboration.AttachExpressionTypes[expression],
+			LocationRange:  getLocationRange(),
+		})
+	}
+
+	base.Attach(interpreter, getLocationRange, attachment)
+
+	return base
+}
+
 func (interpreter *Interpreter) VisitReferenceExpression(referenceExpression *ast.ReferenceExpression) ast.Repr {
 
-	borrowType := interpreter.Program.Elaboration.ReferenceExpressionBorrowTypes[referenceExpression]
+	borrowType := interpreter.resolveType(
+		interpreter.Program.Elaboration.ReferenceExpressionBorrowTypes[referenceExpression],
+	)
 
 	result := interpreter.evalExpression(referenceExpression.Expression)
 
@@ -867,6 +1001,8 @@ func (interpreter *Interpreter) VisitReferenceExpression(referenceExpression *as
 		interpreter.trackReferencedResourceKindedValue(result.StorageID(), result)
 	}
 
+	common.UseMemory(interpreter.memoryGauge, common.EphemeralReferenceValueMemoryUsage)
+
 	switch typ := borrowType.(type) {
 	case *sema.OptionalType:
 		innerBorrowType, ok := typ.Type.(*sema.ReferenceType)
@@ -933,6 +1069,8 @@ func (interpreter *Interpreter) VisitForceExpression(expression *ast.ForceExpres
 func (interpreter *Interpreter) VisitPathExpression(expression *ast.PathExpression) ast.Repr {
 	domain := common.PathDomainFromIdentifier(expression.Domain.Identifier)
 
+	common.UseMemory(interpreter.memoryGauge, common.PathValueMemoryUsage)
+
 	return PathValue{
 		Domain:     domain,
 		Identifier: expression.Identifier.Identifier,
@@ -22,6 +22,7 @@ import (
 	"github.com/onflow/atree"
 
 	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/sema"
 )
 
@@ -120,14 +121,141 @@ func (interpreter *Interpreter) invokeInterpretedFunction(
 	interpreter.activations.PushNewWithParent(function.Activation)
 	interpreter.activations.Current().isFunction = true
 
+	if interpreter.debugger != nil {
+		interpreter.debugger.onFunctionInvocation(interpreter, function)
+		defer interpreter.debugger.onFunctionReturn()
+	}
+
 	// Make `self` available, if any
 	if invocation.Self != nil {
 		interpreter.declareVariable(sema.SelfIdentifier, invocation.Self)
 	}
 
+	// Make `base` available, if `self` is an attachment
+	if attachment, ok := invocation.Self.(*CompositeValue); ok &&
+		attachment.Kind == common.CompositeKindAttachment {
+
+		interpreter.declareAttachmentBase(attachment, invocation.GetLocationRange)
+	}
+
+	interpreter.bindTypeArguments(invocation)
+
 	return interpreter.invokeInterpretedFunctionActivated(function, invocation.Arguments)
 }
 
+// declareAttachmentBase declares `base`, a reference to the value the given attachment is attached to.
+//
+// The reference is only created when `base` is used,
+// as an attachment's functions can only access it when the attachment is attached.
+//
+func (interpreter *Interpreter) declareAttachmentBase(
+	attachment *CompositeValue,
+	getLocationRange func() LocationRange,
+) {
+	variable := NewVariableWithGetter(func() Value {
+		base := attachment.base
+		if base == nil {
+			panic(AttachmentBaseUnavailableError{
+				LocationRange: getLocationRange(),
+			})
+		}
+
+		attachmentType, err := interpreter.getUserCompositeType(attachment.Location, attachment.TypeID())
+		if err != nil {
+			panic(err)
+		}
+
+		interpreter.trackReferencedResourceKindedValue(base.StorageID(), base)
+
+		return &EphemeralReferenceValue{
+			Value:        base,
+			BorrowedType: attachmentType.AttachmentBaseType,
+		}
+	})
+
+	interpreter.setVariable(sema.BaseIdentifier, variable)
+}
+
+// bindTypeArguments binds the type arguments of the given invocation
+// in the current activation:
+// The type arguments of a generic function,
+// and the type arguments of the instantiated generic composite type of `self`, if any.
+//
+func (interpreter *Interpreter) bindTypeArguments(invocation Invocation) {
+	activation := interpreter.activations.Current()
+
+	if invocation.TypeParameterTypes != nil {
+		invocation.TypeParameterTypes.Foreach(func(typeParameter *sema.TypeParameter, typeArgument sema.Type) {
+			activation.SetTypeArgument(typeParameter, typeArgument)
+		})
+	}
+
+	compositeValue, ok := invocation.Self.(*CompositeValue)
+	if !ok || len(compositeValue.TypeArguments) == 0 {
+		return
+	}
+
+	compositeType, err := interpreter.getUserCompositeType(compositeValue.Location, compositeValue.TypeID())
+	if err != nil {
+		panic(err)
+	}
+
+	for i, typeParameter := range compositeType.TypeParameters() {
+		typeArgument := interpreter.MustConvertStaticToSemaType(compositeValue.TypeArguments[i])
+		activation.SetTypeArgument(typeParameter, typeArgument)
+	}
+}
+
+// resolveType resolves the type parameters in the given static type
+// with the type arguments bound in the current activation,
+// e.g. the type arguments of the invocation of a generic function.
+//
+func (interpreter *Interpreter) resolveType(ty sema.Type) sema.Type {
+	if ty == nil {
+		return nil
+	}
+
+	activation := interpreter.activations.Current()
+	if activation == nil || !activation.HasTypeArguments() {
+		return ty
+	}
+
+	typeArguments := sema.NewTypeParameterTypeOrderedMap()
+	for typeParameter, typeArgument := range activation.TypeArguments() { //nolint:maprangecheck
+		typeArguments.Set(typeParameter, typeArgument)
+	}
+
+	resolvedType := ty.Resolve(typeArguments)
+	if resolvedType == nil {
+		return ty
+	}
+
+	return resolvedType
+}
+
+// resolveTypeParameterTypes resolves the type arguments of an invocation
+// with the type arguments bound in the current activation, see resolveType.
+//
+func (interpreter *Interpreter) resolveTypeParameterTypes(
+	typeParameterTypes *sema.TypeParameterTypeOrderedMap,
+) *sema.TypeParameterTypeOrderedMap {
+
+	activation := interpreter.activations.Current()
+	if typeParameterTypes == nil ||
+		activation == nil ||
+		!activation.HasTypeArguments() {
+
+		return typeParameterTypes
+	}
+
+	resolvedTypeParameterTypes := sema.NewTypeParameterTypeOrderedMap()
+	typeParameterTypes.Foreach(func(typeParameter *sema.TypeParameter, typeArgument sema.Type) {
+		resolvedTypeParameterTypes.Set(typeParameter, interpreter.resolveType(typeArgument))
+	})
+
+	return resolvedTypeParameterTypes
+}
+
 // NOTE: assumes the function's activation (or an extension of it) is pushed!
 //
 func (interpreter *Interpreter) invokeInterpretedFunctionActivated(
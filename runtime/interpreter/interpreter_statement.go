@@ -24,6 +24,7 @@ import (
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/sema"
 )
 
 func (interpreter *Interpreter) evalStatement(statement ast.Statement) interface{} {
@@ -37,6 +38,10 @@ func (interpreter *Interpreter) evalStatement(statement ast.Statement) interface
 
 	interpreter.statement = statement
 
+	if interpreter.onStatementStart != nil {
+		interpreter.onStatementStart(interpreter, statement)
+	}
+
 	if interpreter.onMeterComputation != nil {
 		interpreter.onMeterComputation(common.ComputationKindStatement, 1)
 	}
@@ -45,8 +50,13 @@ func (interpreter *Interpreter) evalStatement(statement ast.Statement) interface
 		interpreter.debugger.onStatement(interpreter, statement)
 	}
 
+	interpreter.checkContext()
+
 	if interpreter.onStatement != nil {
 		interpreter.onStatement(interpreter, statement)
+
+		// The handler may have taken a long time, check again
+		interpreter.checkContext()
 	}
 
 	return statement.Accept(interpreter)
@@ -96,15 +106,16 @@ func (interpreter *Interpreter) VisitContinueStatement(_ *ast.ContinueStatement)
 func (interpreter *Interpreter) VisitIfStatement(statement *ast.IfStatement) ast.Repr {
 	switch test := statement.Test.(type) {
 	case ast.Expression:
-		return interpreter.visitIfStatementWithTestExpression(test, statement.Then, statement.Else)
+		return interpreter.visitIfStatementWithTestExpression(statement, test, statement.Then, statement.Else)
 	case *ast.VariableDeclaration:
-		return interpreter.visitIfStatementWithVariableDeclaration(test, statement.Then, statement.Else)
+		return interpreter.visitIfStatementWithVariableDeclaration(statement, test, statement.Then, statement.Else)
 	default:
 		panic(errors.NewUnreachableError())
 	}
 }
 
 func (interpreter *Interpreter) visitIfStatementWithTestExpression(
+	statement *ast.IfStatement,
 	test ast.Expression,
 	thenBlock, elseBlock *ast.Block,
 ) controlReturn {
@@ -115,9 +126,13 @@ func (interpreter *Interpreter) visitIfStatementWithTestExpression(
 	}
 	var result interface{}
 	if value {
+		interpreter.reportBranch(statement, 0)
 		result = thenBlock.Accept(interpreter)
-	} else if elseBlock != nil {
-		result = elseBlock.Accept(interpreter)
+	} else {
+		interpreter.reportBranch(statement, 1)
+		if elseBlock != nil {
+			result = elseBlock.Accept(interpreter)
+		}
 	}
 
 	if ret, ok := result.(controlReturn); ok {
@@ -127,6 +142,7 @@ func (interpreter *Interpreter) visitIfStatementWithTestExpression(
 }
 
 func (interpreter *Interpreter) visitIfStatementWithVariableDeclaration(
+	statement *ast.IfStatement,
 	declaration *ast.VariableDeclaration,
 	thenBlock, elseBlock *ast.Block,
 ) controlReturn {
@@ -188,9 +204,13 @@ func (interpreter *Interpreter) visitIfStatementWithVariableDeclaration(
 			transferredUnwrappedValue,
 		)
 
+		interpreter.reportBranch(statement, 0)
 		result = thenBlock.Accept(interpreter)
-	} else if elseBlock != nil {
-		result = elseBlock.Accept(interpreter)
+	} else {
+		interpreter.reportBranch(statement, 1)
+		if elseBlock != nil {
+			result = elseBlock.Accept(interpreter)
+		}
 	}
 
 	if ret, ok := result.(controlReturn); ok {
@@ -206,9 +226,11 @@ func (interpreter *Interpreter) VisitSwitchStatement(switchStatement *ast.Switch
 		panic(errors.NewUnreachableError())
 	}
 
-	for _, switchCase := range switchStatement.Cases {
+	for caseIndex, switchCase := range switchStatement.Cases {
 
 		runStatements := func() ast.Repr {
+			interpreter.reportBranch(switchStatement, caseIndex)
+
 			// NOTE: the new block ensures that a new scope is introduced
 
 			block := &ast.Block{
@@ -255,6 +277,10 @@ func (interpreter *Interpreter) VisitSwitchStatement(switchStatement *ast.Switch
 		// then try the next case
 	}
 
+	// No case matched, and there is no default case
+
+	interpreter.reportBranch(switchStatement, len(switchStatement.Cases))
+
 	return nil
 }
 
@@ -294,29 +320,189 @@ func (interpreter *Interpreter) VisitForStatement(statement *ast.ForStatement) a
 		nil,
 	)
 
+	var indexVariable *Variable
+	if statement.Index != nil {
+		indexVariable = interpreter.declareVariable(
+			statement.Index.Identifier,
+			nil,
+		)
+	}
+
 	getLocationRange := locationRangeGetter(interpreter.Location, statement)
 
+	valueType := interpreter.Program.Elaboration.ForStatementValueTypes[statement]
+
 	value := interpreter.evalExpression(statement.Value)
-	transferredValue := value.Transfer(
+
+	// References are iterated in place, without a transfer.
+	// All other values are iterated over a copy
+
+	var referenceType *sema.ReferenceType
+	if valueReferenceType, ok := valueType.(*sema.ReferenceType); ok {
+		referenceType = valueReferenceType
+		value = interpreter.forStatementReferencedValue(value, getLocationRange)
+	} else {
+		value = value.Transfer(
+			interpreter,
+			getLocationRange,
+			atree.Address{},
+			false,
+			nil,
+		)
+	}
+
+	var result ast.Repr
+
+	switch value := value.(type) {
+	case *ArrayValue:
+		value.withIteration(interpreter, func() {
+			result = interpreter.forStatementArray(
+				statement,
+				value,
+				referenceType,
+				variable,
+				indexVariable,
+				getLocationRange,
+			)
+		})
+
+	case *DictionaryValue:
+		interpreter.withContainerIteration(value.StorageID(), func() {
+			result = interpreter.forStatementDictionary(
+				statement,
+				value,
+				referenceType,
+				variable,
+				indexVariable,
+				getLocationRange,
+			)
+		})
+
+	default:
+		panic(errors.NewUnreachableError())
+	}
+
+	return result
+}
+
+// forStatementReferencedValue returns the array or dictionary
+// referenced by the iterated value of a for-in statement
+//
+func (interpreter *Interpreter) forStatementReferencedValue(
+	value Value,
+	getLocationRange func() LocationRange,
+) Value {
+
+	var referencedValue *Value
+
+	switch reference := value.(type) {
+	case *EphemeralReferenceValue:
+		referencedValue = reference.ReferencedValue(interpreter, getLocationRange)
+
+	case *StorageReferenceValue:
+		referencedValue = reference.ReferencedValue(interpreter)
+
+	default:
+		panic(errors.NewUnreachableError())
+	}
+
+	if referencedValue == nil {
+		panic(DereferenceError{
+			LocationRange: getLocationRange(),
+		})
+	}
+
+	self := *referencedValue
+
+	interpreter.checkResourceNotDestroyed(self, getLocationRange)
+
+	return self
+}
+
+// forStatementElement returns the value the loop variable of a for-in statement is bound to.
+//
+// When a reference is iterated, the element is still owned by the referenced container:
+// Resources are bound as references, all other values are copied.
+//
+func (interpreter *Interpreter) forStatementElement(
+	element Value,
+	elementType sema.Type,
+	referenceType *sema.ReferenceType,
+	getLocationRange func() LocationRange,
+) Value {
+
+	if referenceType == nil {
+		return element
+	}
+
+	if elementType.IsResourceType() {
+		if element, ok := element.(ReferenceTrackedResourceKindedValue); ok {
+			interpreter.trackReferencedResourceKindedValue(element.StorageID(), element)
+		}
+
+		common.UseMemory(interpreter.memoryGauge, common.EphemeralReferenceValueMemoryUsage)
+
+		return &EphemeralReferenceValue{
+			Authorized:   referenceType.Authorized,
+			Value:        element,
+			BorrowedType: elementType,
+		}
+	}
+
+	return element.Transfer(
 		interpreter,
 		getLocationRange,
 		atree.Address{},
 		false,
 		nil,
 	)
+}
+
+// forStatementBody evaluates the body of a for-in statement
+// and reports if the loop should be exited, and the result of the statement.
+//
+func (interpreter *Interpreter) forStatementBody(statement *ast.ForStatement) (exit bool, result ast.Repr) {
+
+	interpreter.reportLoopIteration(statement)
+
+	result = statement.Block.Accept(interpreter)
+
+	switch result.(type) {
+	case controlBreak:
+		return true, nil
+
+	case controlContinue:
+		// NO-OP
+
+	case functionReturn:
+		return true, result
+	}
+
+	return false, nil
+}
 
-	iterator, err := transferredValue.(*ArrayValue).array.Iterator()
+func (interpreter *Interpreter) forStatementArray(
+	statement *ast.ForStatement,
+	array *ArrayValue,
+	referenceType *sema.ReferenceType,
+	variable *Variable,
+	indexVariable *Variable,
+	getLocationRange func() LocationRange,
+) ast.Repr {
+
+	var elementType sema.Type
+	if referenceType != nil {
+		elementType = referenceType.Type.(sema.ArrayType).ElementType(false)
+	}
+
+	iterator, err := array.array.Iterator()
 	if err != nil {
 		panic(ExternalError{err})
 	}
 
-	var indexVariable *Variable
 	var one = NewIntValueFromInt64(1)
-	if statement.Index != nil {
-		indexVariable = interpreter.declareVariable(
-			statement.Index.Identifier,
-			NewIntValueFromInt64(0),
-		)
+	if indexVariable != nil {
+		indexVariable.SetValue(NewIntValueFromInt64(0))
 	}
 
 	for {
@@ -330,29 +516,96 @@ func (interpreter *Interpreter) VisitForStatement(statement *ast.ForStatement) a
 			return nil
 		}
 
-		interpreter.reportLoopIteration(statement)
-
 		// atree.Array iterator returns low-level atree.Value,
 		// convert to high-level interpreter.Value
 		value := MustConvertStoredValue(atreeValue)
 
-		variable.SetValue(value)
+		variable.SetValue(
+			interpreter.forStatementElement(
+				value,
+				elementType,
+				referenceType,
+				getLocationRange,
+			),
+		)
 
-		result := statement.Block.Accept(interpreter)
+		exit, result := interpreter.forStatementBody(statement)
+		if exit {
+			return result
+		}
 
-		switch result.(type) {
-		case controlBreak:
+		if indexVariable != nil {
+			indexVariable.SetValue(indexVariable.GetValue().(IntValue).Plus(interpreter, one))
+		}
+	}
+}
+
+func (interpreter *Interpreter) forStatementDictionary(
+	statement *ast.ForStatement,
+	dictionary *DictionaryValue,
+	referenceType *sema.ReferenceType,
+	variable *Variable,
+	indexVariable *Variable,
+	getLocationRange func() LocationRange,
+) ast.Repr {
+
+	var valueType sema.Type
+	if referenceType != nil {
+		valueType = referenceType.Type.(*sema.DictionaryType).ValueType
+	}
+
+	iterator, err := dictionary.dictionary.Iterator()
+	if err != nil {
+		panic(ExternalError{err})
+	}
+
+	for {
+		var atreeKey, atreeValue atree.Value
+		atreeKey, atreeValue, err = iterator.Next()
+		if err != nil {
+			panic(ExternalError{err})
+		}
+
+		if atreeKey == nil {
 			return nil
+		}
 
-		case controlContinue:
-			// NO-OP
+		// atree.OrderedMap iterator returns low-level atree.Value,
+		// convert to high-level interpreter.Value.
+		// Keys are never resources, so they can always be copied
+
+		key := MustConvertStoredValue(atreeKey)
+		if referenceType != nil {
+			key = key.Transfer(
+				interpreter,
+				getLocationRange,
+				atree.Address{},
+				false,
+				nil,
+			)
+		}
 
-		case functionReturn:
-			return result
+		if indexVariable == nil {
+			// Only the keys are bound
+			variable.SetValue(key)
+		} else {
+			indexVariable.SetValue(key)
+
+			value := MustConvertStoredValue(atreeValue)
+
+			variable.SetValue(
+				interpreter.forStatementElement(
+					value,
+					valueType,
+					referenceType,
+					getLocationRange,
+				),
+			)
 		}
 
-		if indexVariable != nil {
-			indexVariable.SetValue(indexVariable.GetValue().(IntValue).Plus(one))
+		exit, result := interpreter.forStatementBody(statement)
+		if exit {
+			return result
 		}
 	}
 }
@@ -385,6 +638,11 @@ func (interpreter *Interpreter) VisitPragmaDeclaration(_ *ast.PragmaDeclaration)
 	return nil
 }
 
+func (interpreter *Interpreter) VisitTypeAliasDeclaration(_ *ast.TypeAliasDeclaration) ast.Repr {
+	// NOTE: type aliases were already resolved by the checker
+	return nil
+}
+
 // VisitVariableDeclaration first visits the declaration's value,
 // then declares the variable with the name bound to the value
 func (interpreter *Interpreter) VisitVariableDeclaration(declaration *ast.VariableDeclaration) ast.Repr {
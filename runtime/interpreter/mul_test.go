@@ -162,7 +162,7 @@ func TestMulUInt8(t *testing.T) {
 
 	for _, test := range tests {
 		f := func() {
-			test.a.Mul(test.b)
+			test.a.Mul(nil, test.b)
 		}
 		if test.valid {
 			assert.NotPanics(t, f)
@@ -308,7 +308,7 @@ func TestMulUInt16(t *testing.T) {
 
 	for _, test := range tests {
 		f := func() {
-			test.a.Mul(test.b)
+			test.a.Mul(nil, test.b)
 		}
 		if test.valid {
 			assert.NotPanics(t, f)
@@ -454,7 +454,7 @@ func TestMulUInt32(t *testing.T) {
 
 	for _, test := range tests {
 		f := func() {
-			test.a.Mul(test.b)
+			test.a.Mul(nil, test.b)
 		}
 		if test.valid {
 			assert.NotPanics(t, f)
@@ -611,7 +611,7 @@ func TestMulUInt64(t *testing.T) {
 
 	for _, test := range tests {
 		f := func() {
-			test.a.Mul(test.b)
+			test.a.Mul(nil, test.b)
 		}
 		if test.valid {
 			assert.NotPanics(t, f)
@@ -770,7 +770,7 @@ func TestMulUInt128(t *testing.T) {
 
 	for _, test := range tests {
 		f := func() {
-			test.a.Mul(test.b)
+			test.a.Mul(nil, test.b)
 		}
 		if test.valid {
 			assert.NotPanics(t, f)
@@ -1438,7 +1438,7 @@ func TestMulUInt256(t *testing.T) {
 
 	for _, test := range tests {
 		f := func() {
-			test.a.Mul(test.b)
+			test.a.Mul(nil, test.b)
 		}
 		if test.valid {
 			assert.NotPanics(t, f)
@@ -1617,7 +1617,7 @@ func TestMulInt8(t *testing.T) {
 
 	for _, test := range tests {
 		f := func() {
-			test.a.Mul(test.b)
+			test.a.Mul(nil, test.b)
 		}
 		if test.valid {
 			assert.NotPanics(t, f)
@@ -1805,7 +1805,7 @@ func TestMulInt16(t *testing.T) {
 
 	for _, test := range tests {
 		f := func() {
-			test.a.Mul(test.b)
+			test.a.Mul(nil, test.b)
 		}
 		if test.valid {
 			assert.NotPanics(t, f)
@@ -1976,7 +1976,7 @@ func TestMulInt32(t *testing.T) {
 
 	for _, test := range tests {
 		f := func() {
-			test.a.Mul(test.b)
+			test.a.Mul(nil, test.b)
 		}
 		if test.valid {
 			assert.NotPanics(t, f)
@@ -2132,7 +2132,7 @@ func TestMulInt64(t *testing.T) {
 
 	for _, test := range tests {
 		f := func() {
-			test.a.Mul(test.b)
+			test.a.Mul(nil, test.b)
 		}
 		if test.valid {
 			assert.NotPanics(t, f)
@@ -2290,7 +2290,7 @@ func TestMulInt128(t *testing.T) {
 
 	for _, test := range tests {
 		f := func() {
-			test.a.Mul(test.b)
+			test.a.Mul(nil, test.b)
 		}
 		if test.valid {
 			assert.NotPanics(t, f)
@@ -2961,7 +2961,7 @@ func TestMulInt256(t *testing.T) {
 
 	for _, test := range tests {
 		f := func() {
-			test.a.Mul(test.b)
+			test.a.Mul(nil, test.b)
 		}
 		if test.valid {
 			assert.NotPanics(t, f)
@@ -168,6 +168,8 @@ const (
 	PrimitiveStaticTypeAuthAccountKeys
 	PrimitiveStaticTypePublicAccountKeys
 	PrimitiveStaticTypeAccountKey
+	PrimitiveStaticTypeAuthAccountCapabilities
+	PrimitiveStaticTypeCapabilityController
 
 	// !!! *WARNING* !!!
 	// ADD NEW TYPES *BEFORE* THIS WARNING.
@@ -312,6 +314,10 @@ func (i PrimitiveStaticType) SemaType() sema.Type {
 		return sema.PublicAccountKeysType
 	case PrimitiveStaticTypeAccountKey:
 		return sema.AccountKeyType
+	case PrimitiveStaticTypeAuthAccountCapabilities:
+		return sema.AuthAccountCapabilitiesType
+	case PrimitiveStaticTypeCapabilityController:
+		return sema.CapabilityControllerType
 	default:
 		panic(errors.NewUnreachableError())
 	}
@@ -437,6 +443,10 @@ func ConvertSemaToPrimitiveStaticType(t sema.Type) PrimitiveStaticType {
 		return PrimitiveStaticTypePublicAccountKeys
 	case sema.AccountKeyType:
 		return PrimitiveStaticTypeAccountKey
+	case sema.AuthAccountCapabilitiesType:
+		return PrimitiveStaticTypeAuthAccountCapabilities
+	case sema.CapabilityControllerType:
+		return PrimitiveStaticTypeCapabilityController
 	case sema.StringType:
 		return PrimitiveStaticTypeString
 	}
@@ -49,7 +49,10 @@ type StaticType interface {
 type CompositeStaticType struct {
 	Location            common.Location
 	QualifiedIdentifier string
-	TypeID              common.TypeID
+	// TypeID is the type ID of the composite type, without type arguments
+	TypeID common.TypeID
+	// TypeArguments are the type arguments of an instantiated generic composite type
+	TypeArguments []StaticType
 }
 
 var _ StaticType = CompositeStaticType{}
@@ -68,10 +71,28 @@ func NewCompositeStaticType(location common.Location, qualifiedIdentifier string
 func (CompositeStaticType) isStaticType() {}
 
 func (t CompositeStaticType) String() string {
+	var identifier string
 	if t.Location == nil {
-		return t.QualifiedIdentifier
+		identifier = t.QualifiedIdentifier
+	} else {
+		identifier = string(t.TypeID)
+	}
+
+	if len(t.TypeArguments) == 0 {
+		return identifier
+	}
+
+	var builder strings.Builder
+	builder.WriteString(identifier)
+	builder.WriteRune('<')
+	for i, typeArgument := range t.TypeArguments {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(typeArgument.String())
 	}
-	return string(t.TypeID)
+	builder.WriteRune('>')
+	return builder.String()
 }
 
 func (t CompositeStaticType) Equal(other StaticType) bool {
@@ -80,7 +101,19 @@ func (t CompositeStaticType) Equal(other StaticType) bool {
 		return false
 	}
 
-	return otherCompositeType.TypeID == t.TypeID
+	if otherCompositeType.TypeID != t.TypeID ||
+		len(otherCompositeType.TypeArguments) != len(t.TypeArguments) {
+
+		return false
+	}
+
+	for i, typeArgument := range t.TypeArguments {
+		if !typeArgument.Equal(otherCompositeType.TypeArguments[i]) {
+			return false
+		}
+	}
+
+	return true
 }
 
 // InterfaceStaticType
@@ -344,11 +377,7 @@ func (t CapabilityStaticType) Equal(other StaticType) bool {
 func ConvertSemaToStaticType(t sema.Type) StaticType {
 	switch t := t.(type) {
 	case *sema.CompositeType:
-		return CompositeStaticType{
-			Location:            t.Location,
-			QualifiedIdentifier: t.QualifiedIdentifier(),
-			TypeID:              t.ID(),
-		}
+		return ConvertSemaCompositeTypeToStaticCompositeType(t)
 
 	case *sema.InterfaceType:
 		return ConvertSemaInterfaceTypeToStaticInterfaceType(t)
@@ -431,6 +460,31 @@ func ConvertSemaReferenceTyoeToStaticReferenceType(t *sema.ReferenceType) Refere
 	}
 }
 
+func ConvertSemaCompositeTypeToStaticCompositeType(t *sema.CompositeType) CompositeStaticType {
+
+	// An instantiated generic composite type is converted
+	// to the static type of the generic composite type, with the type arguments
+
+	var typeArguments []StaticType
+
+	if baseType, ok := t.BaseType().(*sema.CompositeType); ok {
+		semaTypeArguments := t.TypeArguments()
+		typeArguments = make([]StaticType, len(semaTypeArguments))
+		for i, typeArgument := range semaTypeArguments {
+			typeArguments[i] = ConvertSemaToStaticType(typeArgument)
+		}
+
+		t = baseType
+	}
+
+	return CompositeStaticType{
+		Location:            t.Location,
+		QualifiedIdentifier: t.QualifiedIdentifier(),
+		TypeID:              t.ID(),
+		TypeArguments:       typeArguments,
+	}
+}
+
 func ConvertSemaInterfaceTypeToStaticInterfaceType(t *sema.InterfaceType) InterfaceStaticType {
 	return InterfaceStaticType{
 		Location:            t.Location,
@@ -445,7 +499,29 @@ func ConvertStaticToSemaType(
 ) (_ sema.Type, err error) {
 	switch t := typ.(type) {
 	case CompositeStaticType:
-		return getComposite(t.Location, t.QualifiedIdentifier, t.TypeID)
+		compositeType, err := getComposite(t.Location, t.QualifiedIdentifier, t.TypeID)
+		if err != nil || len(t.TypeArguments) == 0 {
+			return compositeType, err
+		}
+
+		typeArguments := make([]sema.Type, len(t.TypeArguments))
+		for i, typeArgument := range t.TypeArguments {
+			typeArguments[i], err = ConvertStaticToSemaType(typeArgument, getInterface, getComposite)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if len(typeArguments) != len(compositeType.TypeParameters()) {
+			return nil, fmt.Errorf(
+				"invalid type arguments for composite type %s: expected %d, got %d",
+				compositeType.QualifiedString(),
+				len(compositeType.TypeParameters()),
+				len(typeArguments),
+			)
+		}
+
+		return compositeType.Instantiate(typeArguments, nil), nil
 
 	case InterfaceStaticType:
 		return getInterface(t.Location, t.QualifiedIdentifier)
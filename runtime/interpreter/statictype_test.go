@@ -205,6 +205,20 @@ func TestCompositeStaticType_Equal(t *testing.T) {
 		)
 	})
 
+	t.Run("different type arguments", func(t *testing.T) {
+
+		t.Parallel()
+
+		a := NewCompositeStaticType(utils.TestLocation, "X")
+		a.TypeArguments = []StaticType{PrimitiveStaticTypeInt}
+
+		b := NewCompositeStaticType(utils.TestLocation, "X")
+		b.TypeArguments = []StaticType{PrimitiveStaticTypeString}
+
+		require.False(t, a.Equal(b))
+		require.False(t, a.Equal(NewCompositeStaticType(utils.TestLocation, "X")))
+	})
+
 	t.Run("different locations of same kind, same qualified identifier", func(t *testing.T) {
 
 		t.Parallel()
@@ -947,6 +961,6 @@ func TestPrimitiveStaticTypeCount(t *testing.T) {
 	t.Parallel()
 
 	t.Run("No new types added in between", func(t *testing.T) {
-		require.Equal(t, byte(98), byte(PrimitiveStaticType_Count))
+		require.Equal(t, byte(100), byte(PrimitiveStaticType_Count))
 	})
 }
@@ -73,6 +73,7 @@ func ConvertStoredValue(value atree.Value) (Value, error) {
 				Location:            typeInfo.location,
 				QualifiedIdentifier: typeInfo.qualifiedIdentifier,
 				Kind:                typeInfo.kind,
+				TypeArguments:       typeInfo.typeArguments,
 			}, nil
 
 		default:
@@ -167,10 +168,16 @@ func NewInMemoryStorage() InMemoryStorage {
 	}
 }
 
-func (i InMemoryStorage) GetStorageMap(address common.Address, domain string) (storageMap *StorageMap) {
+func (i InMemoryStorage) GetStorageMap(
+	address common.Address,
+	domain string,
+	createIfNotExists bool,
+) (
+	storageMap *StorageMap,
+) {
 	key := StorageKey{address, domain}
 	storageMap = i.StorageMaps[key]
-	if storageMap == nil {
+	if storageMap == nil && createIfNotExists {
 		storageMap = NewStorageMap(i, atree.Address(address))
 		i.StorageMaps[key] = storageMap
 	}
@@ -250,7 +250,7 @@ func TestDictionaryStorage(t *testing.T) {
 		require.NoError(t, err)
 		require.True(t, ok)
 
-		entryKey := NewStringValue("test")
+		entryKey := NewUnmeteredStringValue("test")
 		entryValue := BoolValue(true)
 
 		value.SetKey(
@@ -296,7 +296,7 @@ func TestDictionaryStorage(t *testing.T) {
 				KeyType:   PrimitiveStaticTypeString,
 				ValueType: PrimitiveStaticTypeAnyStruct,
 			},
-			NewStringValue("test"),
+			NewUnmeteredStringValue("test"),
 			NewSomeValueNonCopying(BoolValue(true)),
 		)
 
@@ -311,7 +311,7 @@ func TestDictionaryStorage(t *testing.T) {
 		value.SetKey(
 			inter,
 			ReturnEmptyLocationRange,
-			NewStringValue("test"),
+			NewUnmeteredStringValue("test"),
 			NilValue{},
 		)
 
@@ -345,7 +345,7 @@ func TestDictionaryStorage(t *testing.T) {
 				KeyType:   PrimitiveStaticTypeString,
 				ValueType: PrimitiveStaticTypeAnyStruct,
 			},
-			NewStringValue("test"),
+			NewUnmeteredStringValue("test"),
 			NewSomeValueNonCopying(BoolValue(true)),
 		)
 
@@ -360,7 +360,7 @@ func TestDictionaryStorage(t *testing.T) {
 		value.Remove(
 			inter,
 			ReturnEmptyLocationRange,
-			NewStringValue("test"),
+			NewUnmeteredStringValue("test"),
 		)
 
 		require.Equal(t, 1, storage.BasicSlabStorage.Count())
@@ -406,7 +406,7 @@ func TestDictionaryStorage(t *testing.T) {
 		value.Insert(
 			inter,
 			ReturnEmptyLocationRange,
-			NewStringValue("test"),
+			NewUnmeteredStringValue("test"),
 			NewSomeValueNonCopying(BoolValue(true)),
 		)
 
@@ -443,12 +443,12 @@ func TestStorageOverwriteAndRemove(t *testing.T) {
 			Type: PrimitiveStaticTypeAnyStruct,
 		},
 		address,
-		NewStringValue("first"),
+		NewUnmeteredStringValue("first"),
 	)
 
 	const identifier = "test"
 
-	storageMap := storage.GetStorageMap(address, "storage")
+	storageMap := storage.GetStorageMap(address, "storage", true)
 
 	storageMap.WriteValue(inter, identifier, array1)
 
@@ -460,7 +460,7 @@ func TestStorageOverwriteAndRemove(t *testing.T) {
 			Type: PrimitiveStaticTypeAnyStruct,
 		},
 		address,
-		NewStringValue("second"),
+		NewUnmeteredStringValue("second"),
 	)
 
 	storageMap.WriteValue(inter, identifier, array2)
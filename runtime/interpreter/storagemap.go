@@ -96,6 +96,55 @@ func (s StorageMap) ReadValue(key string) Value {
 	return StoredValue(storable, s.orderedMap.Storage)
 }
 
+// ReadStaticType returns the static type of the value for the given key,
+// without loading the value itself: only the root slab of a container value is read.
+// Returns nil if the key does not exist.
+//
+func (s StorageMap) ReadStaticType(key string) StaticType {
+	storable, err := s.orderedMap.Get(
+		StringAtreeComparator,
+		StringAtreeHashInput,
+		StringAtreeValue(key),
+	)
+	if err != nil {
+		if _, ok := err.(*atree.KeyNotFoundError); ok {
+			return nil
+		}
+		panic(ExternalError{err})
+	}
+
+	return storedStaticType(storable, s.orderedMap.Storage)
+}
+
+// storedStaticType returns the static type of the value stored as the given storable.
+// Links are reported as capabilities with the link's borrow type
+//
+func storedStaticType(storable atree.Storable, storage atree.SlabStorage) StaticType {
+	switch storable := storable.(type) {
+	case SomeStorable:
+		innerType := storedStaticType(storable.Storable, storage)
+		if innerType == nil {
+			return nil
+		}
+		return OptionalStaticType{
+			Type: innerType,
+		}
+
+	case LinkValue:
+		return CapabilityStaticType{
+			BorrowType: storable.Type,
+		}
+
+	case Value:
+		// Inlined values are already decoded
+		return storable.StaticType()
+
+	default:
+		// Container values only load their root slab
+		return StoredValue(storable, storage).StaticType()
+	}
+}
+
 // WriteValue sets or removes a value in the storage map.
 // If the given value is nil, the key is removed.
 // If the given value is non-nil, the key is added/updated.
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"sort"
 	"strings"
 	"time"
 
@@ -249,7 +250,15 @@ func (v TypeValue) GetMember(interpreter *Interpreter, _ func() LocationRange, n
 		if staticType != nil {
 			typeID = string(interpreter.MustConvertStaticToSemaType(staticType).ID())
 		}
-		return NewStringValue(typeID)
+		memoryUsage := common.NewStringMemoryUsage(len(typeID))
+
+		return NewStringValue(
+			interpreter,
+			memoryUsage,
+			func() string {
+				return typeID
+			},
+		)
 	case "isSubtype":
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
@@ -594,10 +603,21 @@ func (BoolValue) ChildStorables() []atree.Storable {
 //
 type CharacterValue string
 
-func NewCharacterValue(r string) CharacterValue {
+func NewUnmeteredCharacterValue(r string) CharacterValue {
 	return CharacterValue(r)
 }
 
+func NewCharacterValue(
+	memoryGauge common.MemoryGauge,
+	memoryUsage common.MemoryUsage,
+	characterConstructor func() string,
+) CharacterValue {
+	common.UseMemory(memoryGauge, memoryUsage)
+
+	character := characterConstructor()
+	return NewUnmeteredCharacterValue(character)
+}
+
 var _ Value = CharacterValue("a")
 var _ atree.Storable = CharacterValue("a")
 var _ EquatableValue = CharacterValue("a")
@@ -714,12 +734,20 @@ func (CharacterValue) ChildStorables() []atree.Storable {
 	return nil
 }
 
-func (v CharacterValue) GetMember(_ *Interpreter, _ func() LocationRange, name string) Value {
+func (v CharacterValue) GetMember(interpreter *Interpreter, _ func() LocationRange, name string) Value {
 	switch name {
 	case sema.ToStringFunctionName:
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
-				return NewStringValue(string(v))
+				memoryUsage := common.NewStringMemoryUsage(len(v))
+
+				return NewStringValue(
+					interpreter,
+					memoryUsage,
+					func() string {
+						return string(v)
+					},
+				)
 			},
 			sema.ToStringFunctionType,
 		)
@@ -750,7 +778,7 @@ type StringValue struct {
 	graphemes *uniseg.Graphemes
 }
 
-func NewStringValue(str string) *StringValue {
+func NewUnmeteredStringValue(str string) *StringValue {
 	return &StringValue{
 		Str: str,
 		// a negative value indicates the length has not been initialized, see Length()
@@ -758,6 +786,21 @@ func NewStringValue(str string) *StringValue {
 	}
 }
 
+// NewStringValue reports the given memory usage to the memory gauge,
+// and only then constructs the string, so that the memory limit is enforced
+// before the memory is actually allocated
+//
+func NewStringValue(
+	memoryGauge common.MemoryGauge,
+	memoryUsage common.MemoryUsage,
+	stringConstructor func() string,
+) *StringValue {
+	common.UseMemory(memoryGauge, memoryUsage)
+
+	str := stringConstructor()
+	return NewUnmeteredStringValue(str)
+}
+
 var _ Value = &StringValue{}
 var _ atree.Storable = &StringValue{}
 var _ EquatableValue = &StringValue{}
@@ -830,16 +873,37 @@ func (v *StringValue) NormalForm() string {
 	return norm.NFC.String(v.Str)
 }
 
-func (v *StringValue) Concat(other *StringValue) Value {
-	var sb strings.Builder
+func (v *StringValue) Concat(interpreter *Interpreter, other *StringValue) Value {
+	length := len(v.Str) + len(other.Str)
+
+	interpreter.ReportComputation(common.ComputationKindStringConcat, uint(length))
 
-	sb.WriteString(v.Str)
-	sb.WriteString(other.Str)
+	memoryUsage := common.NewStringMemoryUsage(length)
 
-	return NewStringValue(sb.String())
+	return NewStringValue(
+		interpreter,
+		memoryUsage,
+		func() string {
+			var sb strings.Builder
+
+			sb.WriteString(v.Str)
+			sb.WriteString(other.Str)
+
+			return sb.String()
+		},
+	)
 }
 
-func (v *StringValue) Slice(from IntValue, to IntValue, getLocationRange func() LocationRange) Value {
+var emptyString = NewUnmeteredStringValue("")
+
+func (v *StringValue) Slice(
+	interpreter *Interpreter,
+	from IntValue,
+	to IntValue,
+	getLocationRange func() LocationRange,
+) Value {
+	interpreter.ReportComputation(common.ComputationKindStringSlice, uint(len(v.Str)))
+
 	fromIndex := from.ToInt()
 
 	toIndex := to.ToInt()
@@ -864,7 +928,7 @@ func (v *StringValue) Slice(from IntValue, to IntValue, getLocationRange func()
 	}
 
 	if fromIndex == toIndex {
-		return NewStringValue("")
+		return emptyString
 	}
 
 	v.prepareGraphemes()
@@ -881,7 +945,38 @@ func (v *StringValue) Slice(from IntValue, to IntValue, getLocationRange func()
 	}
 	_, end := v.graphemes.Positions()
 
-	return NewStringValue(v.Str[start:end])
+	return v.substring(interpreter, start, end)
+}
+
+// newStringValueFromStringer returns a string value for the string representation of the given value.
+// The representation is produced before it is metered,
+// so this function should only be used for values with a bounded string representation
+//
+func newStringValueFromStringer(interpreter *Interpreter, stringer fmt.Stringer) *StringValue {
+	str := stringer.String()
+	memoryUsage := common.NewStringMemoryUsage(len(str))
+
+	return NewStringValue(
+		interpreter,
+		memoryUsage,
+		func() string {
+			return str
+		},
+	)
+}
+
+// substring returns the part of the string between the given byte offsets
+//
+func (v *StringValue) substring(interpreter *Interpreter, start, end int) *StringValue {
+	memoryUsage := common.NewStringMemoryUsage(end - start)
+
+	return NewStringValue(
+		interpreter,
+		memoryUsage,
+		func() string {
+			return v.Str[start:end]
+		},
+	)
 }
 
 func (v *StringValue) checkBounds(index int, getLocationRange func() LocationRange) {
@@ -896,7 +991,7 @@ func (v *StringValue) checkBounds(index int, getLocationRange func() LocationRan
 	}
 }
 
-func (v *StringValue) GetKey(_ *Interpreter, getLocationRange func() LocationRange, key Value) Value {
+func (v *StringValue) GetKey(interpreter *Interpreter, getLocationRange func() LocationRange, key Value) Value {
 	index := key.(NumberValue).ToInt()
 	v.checkBounds(index, getLocationRange)
 
@@ -907,7 +1002,15 @@ func (v *StringValue) GetKey(_ *Interpreter, getLocationRange func() LocationRan
 	}
 
 	char := v.graphemes.Str()
-	return NewCharacterValue(char)
+	memoryUsage := common.NewCharacterMemoryUsage(len(char))
+
+	return NewCharacterValue(
+		interpreter,
+		memoryUsage,
+		func() string {
+			return char
+		},
+	)
 }
 
 func (*StringValue) SetKey(_ *Interpreter, _ func() LocationRange, _ Value, _ Value) {
@@ -938,7 +1041,7 @@ func (v *StringValue) GetMember(interpreter *Interpreter, _ func() LocationRange
 				if !ok {
 					panic(errors.NewUnreachableError())
 				}
-				return v.Concat(otherArray)
+				return v.Concat(invocation.Interpreter, otherArray)
 			},
 			sema.StringTypeConcatFunctionType,
 		)
@@ -956,7 +1059,7 @@ func (v *StringValue) GetMember(interpreter *Interpreter, _ func() LocationRange
 					panic(errors.NewUnreachableError())
 				}
 
-				return v.Slice(from, to, invocation.GetLocationRange)
+				return v.Slice(invocation.Interpreter, from, to, invocation.GetLocationRange)
 			},
 			sema.StringTypeSliceFunctionType,
 		)
@@ -972,10 +1075,83 @@ func (v *StringValue) GetMember(interpreter *Interpreter, _ func() LocationRange
 	case "toLower":
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
-				return v.ToLower()
+				return v.ToLower(invocation.Interpreter)
 			},
 			sema.StringTypeToLowerFunctionType,
 		)
+
+	case "toUpper":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return v.ToUpper(invocation.Interpreter)
+			},
+			sema.StringTypeToUpperFunctionType,
+		)
+
+	case "trim":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return v.Trim(invocation.Interpreter)
+			},
+			sema.StringTypeTrimFunctionType,
+		)
+
+	case "split":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				separator, ok := invocation.Arguments[0].(*StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.Split(invocation.Interpreter, separator)
+			},
+			sema.StringTypeSplitFunctionType,
+		)
+
+	case "replaceAll":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				original, ok := invocation.Arguments[0].(*StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				replacement, ok := invocation.Arguments[1].(*StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.ReplaceAll(invocation.Interpreter, original, replacement)
+			},
+			sema.StringTypeReplaceAllFunctionType,
+		)
+
+	case "contains":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				other, ok := invocation.Arguments[0].(*StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.Contains(invocation.Interpreter, other)
+			},
+			sema.StringTypeContainsFunctionType,
+		)
+
+	case "index":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				other, ok := invocation.Arguments[0].(*StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.Index(invocation.Interpreter, other)
+			},
+			sema.StringTypeIndexFunctionType,
+		)
 	}
 
 	return nil
@@ -1005,8 +1181,305 @@ func (v *StringValue) Length() int {
 	return v.length
 }
 
-func (v *StringValue) ToLower() *StringValue {
-	return NewStringValue(strings.ToLower(v.Str))
+func (v *StringValue) ToLower(interpreter *Interpreter) *StringValue {
+	interpreter.ReportComputation(common.ComputationKindStringToLower, uint(len(v.Str)))
+
+	// Lowercasing may change the byte length of some characters,
+	// so the length of the original string is used as an estimate
+	memoryUsage := common.NewStringMemoryUsage(len(v.Str))
+
+	return NewStringValue(
+		interpreter,
+		memoryUsage,
+		func() string {
+			return strings.ToLower(v.Str)
+		},
+	)
+}
+
+func (v *StringValue) ToUpper(interpreter *Interpreter) *StringValue {
+	interpreter.ReportComputation(common.ComputationKindStringToUpper, uint(len(v.Str)))
+
+	memoryUsage := common.NewStringMemoryUsage(len(v.Str))
+
+	return NewStringValue(
+		interpreter,
+		memoryUsage,
+		func() string {
+			return strings.ToUpper(v.Str)
+		},
+	)
+}
+
+// Trim returns the string without leading and trailing characters (grapheme clusters)
+// which only consist of whitespace
+//
+func (v *StringValue) Trim(interpreter *Interpreter) *StringValue {
+	interpreter.ReportComputation(common.ComputationKindStringTrim, uint(len(v.Str)))
+
+	start := -1
+	end := -1
+
+	v.prepareGraphemes()
+	for v.graphemes.Next() {
+		if strings.TrimSpace(v.graphemes.Str()) == "" {
+			continue
+		}
+
+		from, to := v.graphemes.Positions()
+		if start < 0 {
+			start = from
+		}
+		end = to
+	}
+
+	if start < 0 {
+		return emptyString
+	}
+
+	return v.substring(interpreter, start, end)
+}
+
+// graphemeBoundaries returns the byte offsets of the characters (grapheme clusters) of the string,
+// followed by the length of the string.
+//
+// The character at index i of the string is v.Str[boundaries[i]:boundaries[i+1]].
+//
+func (v *StringValue) graphemeBoundaries() []int {
+	var boundaries []int
+
+	v.prepareGraphemes()
+	for v.graphemes.Next() {
+		start, _ := v.graphemes.Positions()
+		boundaries = append(boundaries, start)
+	}
+
+	v.length = len(boundaries)
+
+	return append(boundaries, len(v.Str))
+}
+
+// indexOf returns the character index of the first occurrence of the given string,
+// starting at the given character index, and the character index after the occurrence,
+// or -1 if there is no occurrence.
+//
+// Only occurrences which start and end at character (grapheme cluster) boundaries are considered,
+// e.g. the string "e" does not occur in the string "e\u{301}" ("é").
+//
+func (v *StringValue) indexOf(boundaries []int, other string, fromIndex int) (startIndex int, endIndex int) {
+	offset := boundaries[fromIndex]
+
+	for offset <= len(v.Str) {
+		i := strings.Index(v.Str[offset:], other)
+		if i < 0 {
+			break
+		}
+
+		start := offset + i
+		end := start + len(other)
+
+		startIndex = sort.SearchInts(boundaries, start)
+		endIndex = sort.SearchInts(boundaries, end)
+
+		if boundaries[startIndex] == start &&
+			endIndex < len(boundaries) &&
+			boundaries[endIndex] == end {
+
+			return startIndex, endIndex
+		}
+
+		offset = start + 1
+	}
+
+	return -1, -1
+}
+
+// Split returns the substrings of the string which are separated by the given separator.
+// If the separator is empty, the string is split into its characters (grapheme clusters)
+//
+func (v *StringValue) Split(interpreter *Interpreter, separator *StringValue) *ArrayValue {
+	interpreter.ReportComputation(common.ComputationKindStringSplit, uint(len(v.Str)))
+
+	boundaries := v.graphemeBoundaries()
+	count := len(boundaries) - 1
+
+	var parts []Value
+
+	if len(separator.Str) == 0 {
+		parts = make([]Value, 0, count)
+		for i := 0; i < count; i++ {
+			parts = append(parts, v.substring(interpreter, boundaries[i], boundaries[i+1]))
+		}
+	} else {
+		index := 0
+		for {
+			startIndex, endIndex := v.indexOf(boundaries, separator.Str, index)
+			if startIndex < 0 {
+				break
+			}
+
+			parts = append(parts, v.substring(interpreter, boundaries[index], boundaries[startIndex]))
+			index = endIndex
+		}
+
+		parts = append(parts, v.substring(interpreter, boundaries[index], len(v.Str)))
+	}
+
+	return NewArrayValue(
+		interpreter,
+		VariableSizedStaticType{
+			Type: PrimitiveStaticTypeString,
+		},
+		common.Address{},
+		parts...,
+	)
+}
+
+// ReplaceAll returns a new string in which all occurrences of the given original string
+// are replaced with the given replacement string.
+// If the original string is empty, the replacement is inserted before each character
+// and at the end of the string
+//
+func (v *StringValue) ReplaceAll(interpreter *Interpreter, original *StringValue, replacement *StringValue) *StringValue {
+	interpreter.ReportComputation(common.ComputationKindStringReplaceAll, uint(len(v.Str)))
+
+	boundaries := v.graphemeBoundaries()
+	count := len(boundaries) - 1
+
+	// Count the replacements first, so the length of the result is known
+	// and its memory usage can be metered before the result is built
+
+	var replacements int
+	if len(original.Str) == 0 {
+		replacements = count + 1
+	} else {
+		index := 0
+		for {
+			startIndex, endIndex := v.indexOf(boundaries, original.Str, index)
+			if startIndex < 0 {
+				break
+			}
+
+			replacements++
+			index = endIndex
+		}
+	}
+
+	length := len(v.Str) + replacements*(len(replacement.Str)-len(original.Str))
+
+	memoryUsage := common.NewStringMemoryUsage(length)
+
+	return NewStringValue(
+		interpreter,
+		memoryUsage,
+		func() string {
+			var sb strings.Builder
+			sb.Grow(length)
+
+			if len(original.Str) == 0 {
+				for i := 0; i < count; i++ {
+					sb.WriteString(replacement.Str)
+					sb.WriteString(v.Str[boundaries[i]:boundaries[i+1]])
+				}
+				sb.WriteString(replacement.Str)
+			} else {
+				index := 0
+				for {
+					startIndex, endIndex := v.indexOf(boundaries, original.Str, index)
+					if startIndex < 0 {
+						break
+					}
+
+					sb.WriteString(v.Str[boundaries[index]:boundaries[startIndex]])
+					sb.WriteString(replacement.Str)
+					index = endIndex
+				}
+
+				sb.WriteString(v.Str[boundaries[index]:])
+			}
+
+			return sb.String()
+		},
+	)
+}
+
+// Contains returns true if the given string occurs in the string as a sequence of whole characters
+//
+func (v *StringValue) Contains(interpreter *Interpreter, other *StringValue) BoolValue {
+	interpreter.ReportComputation(common.ComputationKindStringContains, uint(len(v.Str)))
+
+	startIndex, _ := v.indexOf(v.graphemeBoundaries(), other.Str, 0)
+	return startIndex >= 0
+}
+
+// Index returns the character index of the first occurrence of the given string in the string
+//
+func (v *StringValue) Index(interpreter *Interpreter, other *StringValue) OptionalValue {
+	interpreter.ReportComputation(common.ComputationKindStringIndex, uint(len(v.Str)))
+
+	startIndex, _ := v.indexOf(v.graphemeBoundaries(), other.Str, 0)
+	if startIndex < 0 {
+		return NilValue{}
+	}
+
+	return NewSomeValueNonCopying(NewIntValueFromInt64(int64(startIndex)))
+}
+
+// JoinStrings returns a string which contains the given strings, separated by the given separator
+//
+func JoinStrings(interpreter *Interpreter, strs *ArrayValue, separator *StringValue) *StringValue {
+
+	// Determine the length of the result first,
+	// so its memory usage can be metered before the result is built
+
+	count := strs.Count()
+
+	length := 0
+	if count > 0 {
+		length = (count - 1) * len(separator.Str)
+	}
+
+	strs.Iterate(func(element Value) (resume bool) {
+		str, ok := element.(*StringValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		length += len(str.Str)
+
+		// continue iteration
+		return true
+	})
+
+	interpreter.ReportComputation(common.ComputationKindStringJoin, uint(length))
+
+	memoryUsage := common.NewStringMemoryUsage(length)
+
+	return NewStringValue(
+		interpreter,
+		memoryUsage,
+		func() string {
+			var sb strings.Builder
+			sb.Grow(length)
+
+			first := true
+			strs.Iterate(func(element Value) (resume bool) {
+				str := element.(*StringValue)
+
+				if !first {
+					sb.WriteString(separator.Str)
+				}
+				first = false
+
+				sb.WriteString(str.Str)
+
+				// continue iteration
+				return true
+			})
+
+			return sb.String()
+		},
+	)
 }
 
 func (v *StringValue) Storable(storage atree.SlabStorage, address atree.Address, maxInlineSize uint64) (atree.Storable, error) {
@@ -1034,8 +1507,16 @@ func (v *StringValue) Transfer(
 	return v
 }
 
-func (v *StringValue) Clone(_ *Interpreter) Value {
-	return NewStringValue(v.Str)
+func (v *StringValue) Clone(interpreter *Interpreter) Value {
+	memoryUsage := common.NewStringMemoryUsage(len(v.Str))
+
+	return NewStringValue(
+		interpreter,
+		memoryUsage,
+		func() string {
+			return v.Str
+		},
+	)
 }
 
 func (*StringValue) DeepRemove(_ *Interpreter) {
@@ -1059,6 +1540,8 @@ var ByteArrayStaticType = ConvertSemaArrayTypeToStaticArrayType(sema.ByteArrayTy
 // DecodeHex hex-decodes this string and returns an array of UInt8 values
 //
 func (v *StringValue) DecodeHex(interpreter *Interpreter) *ArrayValue {
+	interpreter.ReportComputation(common.ComputationKindStringDecodeHex, uint(len(v.Str)))
+
 	bs, err := hex.DecodeString(v.Str)
 	if err != nil {
 		panic(err)
@@ -1150,6 +1633,8 @@ func NewArrayValueWithIterator(
 
 	interpreter.ReportComputation(common.ComputationKindCreateArrayValue, 1)
 
+	interpreter.meterArrayValue(0)
+
 	var v *ArrayValue
 
 	if interpreter.tracingEnabled {
@@ -1173,12 +1658,22 @@ func NewArrayValueWithIterator(
 		}()
 	}
 
+	var count uint64
+
 	array, err := atree.NewArrayFromBatchData(
 		interpreter.Storage,
 		atree.Address(address),
 		arrayType,
 		func() (atree.Value, error) {
-			return values(), nil
+			value := values()
+			if value == nil {
+				return nil, nil
+			}
+
+			common.UseAtreeArrayGrowthMemory(interpreter.memoryGauge, count, atreeElementSizeEstimate)
+			count++
+
+			return value, nil
 		},
 	)
 	if err != nil {
@@ -1430,6 +1925,8 @@ func (v *ArrayValue) Set(interpreter *Interpreter, getLocationRange func() Locat
 		})
 	}
 
+	v.checkIteration(interpreter, getLocationRange)
+
 	interpreter.checkContainerMutation(v.Type.ElementType(), element, getLocationRange)
 
 	element = element.Transfer(
@@ -1473,6 +1970,8 @@ func (v *ArrayValue) RecursiveString(seenReferences SeenReferences) string {
 
 func (v *ArrayValue) Append(interpreter *Interpreter, getLocationRange func() LocationRange, element Value) {
 
+	v.checkIteration(interpreter, getLocationRange)
+
 	interpreter.checkContainerMutation(v.Type.ElementType(), element, getLocationRange)
 
 	element = element.Transfer(
@@ -1483,6 +1982,8 @@ func (v *ArrayValue) Append(interpreter *Interpreter, getLocationRange func() Lo
 		nil,
 	)
 
+	common.UseAtreeArrayGrowthMemory(interpreter.memoryGauge, v.array.Count(), atreeElementSizeEstimate)
+
 	err := v.array.Append(element)
 	if err != nil {
 		panic(ExternalError{err})
@@ -1519,6 +2020,8 @@ func (v *ArrayValue) Insert(interpreter *Interpreter, getLocationRange func() Lo
 		})
 	}
 
+	v.checkIteration(interpreter, getLocationRange)
+
 	interpreter.checkContainerMutation(v.Type.ElementType(), element, getLocationRange)
 
 	element = element.Transfer(
@@ -1529,111 +2032,451 @@ func (v *ArrayValue) Insert(interpreter *Interpreter, getLocationRange func() Lo
 		nil,
 	)
 
+	common.UseAtreeArrayGrowthMemory(interpreter.memoryGauge, v.array.Count(), atreeElementSizeEstimate)
+
 	err := v.array.Insert(uint64(index), element)
 	if err != nil {
 		v.handleIndexOutOfBoundsError(err, index, getLocationRange)
 
 		panic(ExternalError{err})
 	}
-	interpreter.maybeValidateAtreeValue(v.array)
-}
+	interpreter.maybeValidateAtreeValue(v.array)
+}
+
+func (v *ArrayValue) RemoveKey(interpreter *Interpreter, getLocationRange func() LocationRange, key Value) Value {
+
+	if interpreter.invalidatedResourceValidationEnabled {
+		v.checkInvalidatedResourceUse(interpreter, getLocationRange)
+	}
+
+	index := key.(NumberValue).ToInt()
+	return v.Remove(interpreter, getLocationRange, index)
+}
+
+func (v *ArrayValue) Remove(interpreter *Interpreter, getLocationRange func() LocationRange, index int) Value {
+
+	// We only need to check the lower bound before converting from `int` (signed) to `uint64` (unsigned).
+	// atree's Array.Remove function will check the upper bound and report an atree.IndexOutOfBoundsError
+
+	if index < 0 {
+		panic(ArrayIndexOutOfBoundsError{
+			Index:         index,
+			Size:          v.Count(),
+			LocationRange: getLocationRange(),
+		})
+	}
+
+	v.checkIteration(interpreter, getLocationRange)
+
+	storable, err := v.array.Remove(uint64(index))
+	if err != nil {
+		v.handleIndexOutOfBoundsError(err, index, getLocationRange)
+
+		panic(ExternalError{err})
+	}
+	interpreter.maybeValidateAtreeValue(v.array)
+
+	value := StoredValue(storable, interpreter.Storage)
+
+	return value.Transfer(
+		interpreter,
+		getLocationRange,
+		atree.Address{},
+		true,
+		storable,
+	)
+}
+
+func (v *ArrayValue) RemoveFirst(interpreter *Interpreter, getLocationRange func() LocationRange) Value {
+	return v.Remove(interpreter, getLocationRange, 0)
+}
+
+func (v *ArrayValue) RemoveLast(interpreter *Interpreter, getLocationRange func() LocationRange) Value {
+	return v.Remove(interpreter, getLocationRange, v.Count()-1)
+}
+
+func (v *ArrayValue) FirstIndex(interpreter *Interpreter, getLocationRange func() LocationRange, needleValue Value) OptionalValue {
+
+	needleEquatable, ok := needleValue.(EquatableValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	var counter int64
+	var result bool
+	v.Iterate(func(element Value) (resume bool) {
+		if needleEquatable.Equal(interpreter, getLocationRange, element) {
+			result = true
+			// stop iteration
+			return false
+		}
+		counter++
+		// continue iteration
+		return true
+	})
+
+	if result {
+		value := NewIntValueFromInt64(counter)
+		return NewSomeValueNonCopying(value)
+	}
+	return NilValue{}
+}
+
+func (v *ArrayValue) Contains(interpreter *Interpreter, getLocationRange func() LocationRange, needleValue Value) BoolValue {
+
+	needleEquatable, ok := needleValue.(EquatableValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	var result bool
+	v.Iterate(func(element Value) (resume bool) {
+		if needleEquatable.Equal(interpreter, getLocationRange, element) {
+			result = true
+			// stop iteration
+			return false
+		}
+		// continue iteration
+		return true
+	})
+
+	return BoolValue(result)
+}
+
+// checkIteration panics if the array is currently iterated over,
+// e.g. by a for-in loop or by a higher-order function like `map` or `sort`.
+//
+func (v *ArrayValue) checkIteration(interpreter *Interpreter, getLocationRange func() LocationRange) {
+	if interpreter.isContainerIterated(v.StorageID()) {
+		panic(ArrayMutatedDuringIterationError{
+			LocationRange: getLocationRange(),
+		})
+	}
+}
+
+// withIteration calls the given function,
+// during which modifications of the array are rejected.
+//
+func (v *ArrayValue) withIteration(interpreter *Interpreter, f func()) {
+	interpreter.withContainerIteration(v.StorageID(), f)
+}
+
+// nextElement returns the next element of the given iterator,
+// or nil if there are no more elements.
+// The iteration is metered per element.
+//
+func (v *ArrayValue) nextElement(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	iterator *atree.ArrayIterator,
+) Value {
+	atreeValue, err := iterator.Next()
+	if err != nil {
+		panic(ExternalError{err})
+	}
+
+	if atreeValue == nil {
+		return nil
+	}
+
+	interpreter.reportLoopIteration(getLocationRange())
+
+	// atree.Array iterator returns low-level atree.Value,
+	// convert to high-level interpreter.Value
+	return MustConvertStoredValue(atreeValue)
+}
+
+// Map returns a new array which contains the results of applying
+// the given transform function to each element of the array.
+//
+func (v *ArrayValue) Map(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	transformFunction FunctionValue,
+	transformFunctionType *sema.FunctionType,
+	resultType ArrayStaticType,
+) *ArrayValue {
+
+	elementType := v.SemaType(interpreter).ElementType(false)
+	parameterType := transformFunctionType.Parameters[0].TypeAnnotation.Type
+
+	iterator, err := v.array.Iterator()
+	if err != nil {
+		panic(ExternalError{err})
+	}
+
+	var result *ArrayValue
+
+	v.withIteration(interpreter, func() {
+		result = NewArrayValueWithIterator(
+			interpreter,
+			resultType,
+			common.Address{},
+			func() Value {
+
+				element := v.nextElement(interpreter, getLocationRange, iterator)
+				if element == nil {
+					return nil
+				}
+
+				transformInvocation := Invocation{
+					Arguments: []Value{
+						interpreter.transferAndConvert(element, elementType, parameterType, getLocationRange),
+					},
+					ArgumentTypes:    []sema.Type{parameterType},
+					GetLocationRange: getLocationRange,
+					Interpreter:      interpreter,
+				}
+
+				return transformFunction.invoke(transformInvocation).
+					Transfer(
+						interpreter,
+						getLocationRange,
+						atree.Address{},
+						true,
+						nil,
+					)
+			},
+		)
+	})
+
+	return result
+}
+
+// Filter returns a new variable-sized array which contains
+// the elements of the array for which the given predicate function returns true.
+//
+func (v *ArrayValue) Filter(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	predicateFunction FunctionValue,
+	predicateFunctionType *sema.FunctionType,
+) *ArrayValue {
+
+	elementType := v.SemaType(interpreter).ElementType(false)
+	parameterType := predicateFunctionType.Parameters[0].TypeAnnotation.Type
+
+	iterator, err := v.array.Iterator()
+	if err != nil {
+		panic(ExternalError{err})
+	}
+
+	var result *ArrayValue
+
+	v.withIteration(interpreter, func() {
+		result = NewArrayValueWithIterator(
+			interpreter,
+			VariableSizedStaticType{
+				Type: v.Type.ElementType(),
+			},
+			common.Address{},
+			func() Value {
+
+				for {
+					element := v.nextElement(interpreter, getLocationRange, iterator)
+					if element == nil {
+						return nil
+					}
+
+					predicateInvocation := Invocation{
+						Arguments: []Value{
+							interpreter.transferAndConvert(element, elementType, parameterType, getLocationRange),
+						},
+						ArgumentTypes:    []sema.Type{parameterType},
+						GetLocationRange: getLocationRange,
+						Interpreter:      interpreter,
+					}
+
+					include, ok := predicateFunction.invoke(predicateInvocation).(BoolValue)
+					if !ok {
+						panic(errors.NewUnreachableError())
+					}
+
+					if include {
+						return element.Transfer(
+							interpreter,
+							getLocationRange,
+							atree.Address{},
+							false,
+							nil,
+						)
+					}
+				}
+			},
+		)
+	})
+
+	return result
+}
+
+// Reduce applies the given function to the initial result and each element of the array,
+// from the first to the last element, and returns the final result.
+//
+func (v *ArrayValue) Reduce(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	initialResult Value,
+	resultType sema.Type,
+	nextPartialResultFunction FunctionValue,
+	nextPartialResultFunctionType *sema.FunctionType,
+) Value {
+
+	elementType := v.SemaType(interpreter).ElementType(false)
+	partialResultParameterType := nextPartialResultFunctionType.Parameters[0].TypeAnnotation.Type
+	elementParameterType := nextPartialResultFunctionType.Parameters[1].TypeAnnotation.Type
+
+	argumentTypes := []sema.Type{
+		partialResultParameterType,
+		elementParameterType,
+	}
+
+	iterator, err := v.array.Iterator()
+	if err != nil {
+		panic(ExternalError{err})
+	}
 
-func (v *ArrayValue) RemoveKey(interpreter *Interpreter, getLocationRange func() LocationRange, key Value) Value {
+	result := initialResult
 
-	if interpreter.invalidatedResourceValidationEnabled {
-		v.checkInvalidatedResourceUse(interpreter, getLocationRange)
-	}
+	v.withIteration(interpreter, func() {
+		for {
+			element := v.nextElement(interpreter, getLocationRange, iterator)
+			if element == nil {
+				return
+			}
 
-	index := key.(NumberValue).ToInt()
-	return v.Remove(interpreter, getLocationRange, index)
+			nextPartialResultInvocation := Invocation{
+				Arguments: []Value{
+					interpreter.transferAndConvert(result, resultType, partialResultParameterType, getLocationRange),
+					interpreter.transferAndConvert(element, elementType, elementParameterType, getLocationRange),
+				},
+				ArgumentTypes:    argumentTypes,
+				GetLocationRange: getLocationRange,
+				Interpreter:      interpreter,
+			}
+
+			result = nextPartialResultFunction.invoke(nextPartialResultInvocation)
+		}
+	})
+
+	return result
 }
 
-func (v *ArrayValue) Remove(interpreter *Interpreter, getLocationRange func() LocationRange, index int) Value {
+// Reverse returns a new array which contains the elements of the array in reverse order.
+//
+func (v *ArrayValue) Reverse(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+) *ArrayValue {
 
-	// We only need to check the lower bound before converting from `int` (signed) to `uint64` (unsigned).
-	// atree's Array.Remove function will check the upper bound and report an atree.IndexOutOfBoundsError
+	index := v.Count() - 1
 
-	if index < 0 {
-		panic(ArrayIndexOutOfBoundsError{
-			Index:         index,
-			Size:          v.Count(),
-			LocationRange: getLocationRange(),
-		})
-	}
+	return NewArrayValueWithIterator(
+		interpreter,
+		v.Type,
+		common.Address{},
+		func() Value {
+			if index < 0 {
+				return nil
+			}
 
-	storable, err := v.array.Remove(uint64(index))
-	if err != nil {
-		v.handleIndexOutOfBoundsError(err, index, getLocationRange)
+			storable, err := v.array.Get(uint64(index))
+			if err != nil {
+				panic(ExternalError{err})
+			}
 
-		panic(ExternalError{err})
-	}
-	interpreter.maybeValidateAtreeValue(v.array)
+			index--
 
-	value := StoredValue(storable, interpreter.Storage)
+			interpreter.reportLoopIteration(getLocationRange())
 
-	return value.Transfer(
-		interpreter,
-		getLocationRange,
-		atree.Address{},
-		true,
-		storable,
+			return StoredValue(storable, interpreter.Storage).
+				Transfer(
+					interpreter,
+					getLocationRange,
+					atree.Address{},
+					false,
+					nil,
+				)
+		},
 	)
 }
 
-func (v *ArrayValue) RemoveFirst(interpreter *Interpreter, getLocationRange func() LocationRange) Value {
-	return v.Remove(interpreter, getLocationRange, 0)
-}
+// Sort sorts the elements of the array in-place, using the given comparator function,
+// which returns true if the first argument should be ordered before the second argument.
+//
+// The sort is stable, i.e. equal elements keep their original order.
+//
+func (v *ArrayValue) Sort(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	comparatorFunction FunctionValue,
+	comparatorFunctionType *sema.FunctionType,
+) {
 
-func (v *ArrayValue) RemoveLast(interpreter *Interpreter, getLocationRange func() LocationRange) Value {
-	return v.Remove(interpreter, getLocationRange, v.Count()-1)
-}
+	elementType := v.SemaType(interpreter).ElementType(false)
+	firstParameterType := comparatorFunctionType.Parameters[0].TypeAnnotation.Type
+	secondParameterType := comparatorFunctionType.Parameters[1].TypeAnnotation.Type
 
-func (v *ArrayValue) FirstIndex(interpreter *Interpreter, getLocationRange func() LocationRange, needleValue Value) OptionalValue {
+	argumentTypes := []sema.Type{
+		firstParameterType,
+		secondParameterType,
+	}
 
-	needleEquatable, ok := needleValue.(EquatableValue)
-	if !ok {
-		panic(errors.NewUnreachableError())
+	iterator, err := v.array.Iterator()
+	if err != nil {
+		panic(ExternalError{err})
 	}
 
-	var counter int64
-	var result bool
-	v.Iterate(func(element Value) (resume bool) {
-		if needleEquatable.Equal(interpreter, getLocationRange, element) {
-			result = true
-			// stop iteration
-			return false
+	elements := make([]Value, 0, v.Count())
+
+	v.withIteration(interpreter, func() {
+
+		for {
+			element := v.nextElement(interpreter, getLocationRange, iterator)
+			if element == nil {
+				break
+			}
+
+			elements = append(
+				elements,
+				element.Transfer(
+					interpreter,
+					getLocationRange,
+					atree.Address{},
+					false,
+					nil,
+				),
+			)
 		}
-		counter++
-		// continue iteration
-		return true
-	})
 
-	if result {
-		value := NewIntValueFromInt64(counter)
-		return NewSomeValueNonCopying(value)
-	}
-	return NilValue{}
-}
+		// The comparator function is passed copies of the elements,
+		// so the sorted elements cannot be modified by it
 
-func (v *ArrayValue) Contains(interpreter *Interpreter, getLocationRange func() LocationRange, needleValue Value) BoolValue {
+		sort.SliceStable(elements, func(i, j int) bool {
 
-	needleEquatable, ok := needleValue.(EquatableValue)
-	if !ok {
-		panic(errors.NewUnreachableError())
-	}
+			interpreter.reportLoopIteration(getLocationRange())
 
-	var result bool
-	v.Iterate(func(element Value) (resume bool) {
-		if needleEquatable.Equal(interpreter, getLocationRange, element) {
-			result = true
-			// stop iteration
-			return false
-		}
-		// continue iteration
-		return true
+			comparatorInvocation := Invocation{
+				Arguments: []Value{
+					interpreter.transferAndConvert(elements[i], elementType, firstParameterType, getLocationRange),
+					interpreter.transferAndConvert(elements[j], elementType, secondParameterType, getLocationRange),
+				},
+				ArgumentTypes:    argumentTypes,
+				GetLocationRange: getLocationRange,
+				Interpreter:      interpreter,
+			}
+
+			less, ok := comparatorFunction.invoke(comparatorInvocation).(BoolValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			return bool(less)
+		})
 	})
 
-	return BoolValue(result)
+	for index, element := range elements {
+		v.Set(interpreter, getLocationRange, index, element)
+	}
 }
 
 func (v *ArrayValue) GetMember(interpreter *Interpreter, getLocationRange func() LocationRange, name string) Value {
@@ -1820,6 +2663,152 @@ func (v *ArrayValue) GetMember(interpreter *Interpreter, getLocationRange func()
 				v.SemaType(interpreter).ElementType(false),
 			),
 		)
+
+	case "map":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				transformFunction, ok := invocation.Arguments[0].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				transformFunctionType, ok := invocation.ArgumentTypes[0].(*sema.FunctionType)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				typeParameterPair := invocation.TypeParameterTypes.Oldest()
+				if typeParameterPair == nil {
+					panic(errors.NewUnreachableError())
+				}
+
+				resultElementType := ConvertSemaToStaticType(typeParameterPair.Value)
+
+				var resultType ArrayStaticType
+				switch arrayType := v.Type.(type) {
+				case ConstantSizedStaticType:
+					resultType = ConstantSizedStaticType{
+						Type: resultElementType,
+						Size: arrayType.Size,
+					}
+				default:
+					resultType = VariableSizedStaticType{
+						Type: resultElementType,
+					}
+				}
+
+				return v.Map(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+					transformFunction,
+					transformFunctionType,
+					resultType,
+				)
+			},
+			sema.ArrayMapFunctionType(
+				v.SemaType(interpreter),
+			),
+		)
+
+	case "filter":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				predicateFunction, ok := invocation.Arguments[0].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				predicateFunctionType, ok := invocation.ArgumentTypes[0].(*sema.FunctionType)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.Filter(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+					predicateFunction,
+					predicateFunctionType,
+				)
+			},
+			sema.ArrayFilterFunctionType(
+				v.SemaType(interpreter).ElementType(false),
+			),
+		)
+
+	case "reduce":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				initialResult := invocation.Arguments[0]
+
+				typeParameterPair := invocation.TypeParameterTypes.Oldest()
+				if typeParameterPair == nil {
+					panic(errors.NewUnreachableError())
+				}
+
+				resultType := typeParameterPair.Value
+
+				nextPartialResultFunction, ok := invocation.Arguments[1].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				nextPartialResultFunctionType, ok := invocation.ArgumentTypes[1].(*sema.FunctionType)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.Reduce(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+					initialResult,
+					resultType,
+					nextPartialResultFunction,
+					nextPartialResultFunctionType,
+				)
+			},
+			sema.ArrayReduceFunctionType(
+				v.SemaType(interpreter).ElementType(false),
+			),
+		)
+
+	case "reverse":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return v.Reverse(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+				)
+			},
+			sema.ArrayReverseFunctionType(
+				v.SemaType(interpreter),
+			),
+		)
+
+	case "sort":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				comparatorFunction, ok := invocation.Arguments[0].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				comparatorFunctionType, ok := invocation.ArgumentTypes[0].(*sema.FunctionType)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				v.Sort(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+					comparatorFunction,
+					comparatorFunctionType,
+				)
+				return VoidValue{}
+			},
+			sema.ArraySortFunctionType(
+				v.SemaType(interpreter).ElementType(false),
+			),
+		)
 	}
 
 	return nil
@@ -1952,6 +2941,11 @@ func (v *ArrayValue) Transfer(
 		v.checkInvalidatedResourceUse(interpreter, getLocationRange)
 	}
 
+	// Moving the array, e.g. out of storage, removes it
+	if remove {
+		v.checkIteration(interpreter, getLocationRange)
+	}
+
 	interpreter.ReportComputation(common.ComputationKindTransferArrayValue, uint(v.Count()))
 
 	if interpreter.tracingEnabled {
@@ -1979,6 +2973,8 @@ func (v *ArrayValue) Transfer(
 
 	if needsStoreTo || !isResourceKinded {
 
+		interpreter.meterArrayValue(v.array.Count())
+
 		iterator, err := v.array.Iterator()
 		if err != nil {
 			panic(ExternalError{err})
@@ -2069,6 +3065,8 @@ func (v *ArrayValue) Transfer(
 
 func (v *ArrayValue) Clone(interpreter *Interpreter) Value {
 
+	interpreter.meterArrayValue(v.array.Count())
+
 	iterator, err := v.array.Iterator()
 	if err != nil {
 		panic(ExternalError{err})
@@ -2248,16 +3246,16 @@ func (v *ArrayValue) Slice(
 type NumberValue interface {
 	EquatableValue
 	ToInt() int
-	Negate() NumberValue
-	Plus(other NumberValue) NumberValue
-	SaturatingPlus(other NumberValue) NumberValue
-	Minus(other NumberValue) NumberValue
-	SaturatingMinus(other NumberValue) NumberValue
-	Mod(other NumberValue) NumberValue
-	Mul(other NumberValue) NumberValue
-	SaturatingMul(other NumberValue) NumberValue
-	Div(other NumberValue) NumberValue
-	SaturatingDiv(other NumberValue) NumberValue
+	Negate(interpreter *Interpreter) NumberValue
+	Plus(interpreter *Interpreter, other NumberValue) NumberValue
+	SaturatingPlus(interpreter *Interpreter, other NumberValue) NumberValue
+	Minus(interpreter *Interpreter, other NumberValue) NumberValue
+	SaturatingMinus(interpreter *Interpreter, other NumberValue) NumberValue
+	Mod(interpreter *Interpreter, other NumberValue) NumberValue
+	Mul(interpreter *Interpreter, other NumberValue) NumberValue
+	SaturatingMul(interpreter *Interpreter, other NumberValue) NumberValue
+	Div(interpreter *Interpreter, other NumberValue) NumberValue
+	SaturatingDiv(interpreter *Interpreter, other NumberValue) NumberValue
 	Less(other NumberValue) BoolValue
 	LessEqual(other NumberValue) BoolValue
 	Greater(other NumberValue) BoolValue
@@ -2271,7 +3269,7 @@ func getNumberValueMember(v NumberValue, name string, typ sema.Type) Value {
 	case sema.ToStringFunctionName:
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
-				return NewStringValue(v.String())
+				return newStringValueFromStringer(invocation.Interpreter, v)
 			},
 			sema.ToStringFunctionType,
 		)
@@ -2279,9 +3277,16 @@ func getNumberValueMember(v NumberValue, name string, typ sema.Type) Value {
 	case sema.ToBigEndianBytesFunctionName:
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
+				bytes := v.ToBigEndianBytes()
+
+				invocation.Interpreter.ReportComputation(
+					common.ComputationKindToBigEndianBytes,
+					uint(len(bytes)),
+				)
+
 				return ByteSliceToByteArrayValue(
 					invocation.Interpreter,
-					v.ToBigEndianBytes(),
+					bytes,
 				)
 			},
 			&sema.FunctionType{
@@ -2298,7 +3303,7 @@ func getNumberValueMember(v NumberValue, name string, typ sema.Type) Value {
 				if !ok {
 					panic(errors.NewUnreachableError())
 				}
-				return v.SaturatingPlus(other)
+				return v.SaturatingPlus(invocation.Interpreter, other)
 			},
 			&sema.FunctionType{
 				ReturnTypeAnnotation: sema.NewTypeAnnotation(
@@ -2314,7 +3319,7 @@ func getNumberValueMember(v NumberValue, name string, typ sema.Type) Value {
 				if !ok {
 					panic(errors.NewUnreachableError())
 				}
-				return v.SaturatingMinus(other)
+				return v.SaturatingMinus(invocation.Interpreter, other)
 			},
 			&sema.FunctionType{
 				ReturnTypeAnnotation: sema.NewTypeAnnotation(
@@ -2330,7 +3335,7 @@ func getNumberValueMember(v NumberValue, name string, typ sema.Type) Value {
 				if !ok {
 					panic(errors.NewUnreachableError())
 				}
-				return v.SaturatingMul(other)
+				return v.SaturatingMul(invocation.Interpreter, other)
 			},
 			&sema.FunctionType{
 				ReturnTypeAnnotation: sema.NewTypeAnnotation(
@@ -2346,7 +3351,7 @@ func getNumberValueMember(v NumberValue, name string, typ sema.Type) Value {
 				if !ok {
 					panic(errors.NewUnreachableError())
 				}
-				return v.SaturatingDiv(other)
+				return v.SaturatingDiv(invocation.Interpreter, other)
 			},
 			&sema.FunctionType{
 				ReturnTypeAnnotation: sema.NewTypeAnnotation(
@@ -2361,11 +3366,11 @@ func getNumberValueMember(v NumberValue, name string, typ sema.Type) Value {
 
 type IntegerValue interface {
 	NumberValue
-	BitwiseOr(other IntegerValue) IntegerValue
-	BitwiseXor(other IntegerValue) IntegerValue
-	BitwiseAnd(other IntegerValue) IntegerValue
-	BitwiseLeftShift(other IntegerValue) IntegerValue
-	BitwiseRightShift(other IntegerValue) IntegerValue
+	BitwiseOr(interpreter *Interpreter, other IntegerValue) IntegerValue
+	BitwiseXor(interpreter *Interpreter, other IntegerValue) IntegerValue
+	BitwiseAnd(interpreter *Interpreter, other IntegerValue) IntegerValue
+	BitwiseLeftShift(interpreter *Interpreter, other IntegerValue) IntegerValue
+	BitwiseRightShift(interpreter *Interpreter, other IntegerValue) IntegerValue
 }
 
 // BigNumberValue.
@@ -2450,11 +3455,13 @@ func (v IntValue) RecursiveString(_ SeenReferences) string {
 	return v.String()
 }
 
-func (v IntValue) Negate() NumberValue {
+func (v IntValue) Negate(interpreter *Interpreter) NumberValue {
+	common.UseMemory(interpreter, common.NewNegateBigIntMemoryUsage(v.BigInt))
+
 	return NewIntValueFromBigInt(new(big.Int).Neg(v.BigInt))
 }
 
-func (v IntValue) Plus(other NumberValue) NumberValue {
+func (v IntValue) Plus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(IntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -2464,12 +3471,14 @@ func (v IntValue) Plus(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewPlusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Add(v.BigInt, o.BigInt)
 	return IntValue{res}
 }
 
-func (v IntValue) SaturatingPlus(other NumberValue) NumberValue {
+func (v IntValue) SaturatingPlus(interpreter *Interpreter, other NumberValue) NumberValue {
 	defer func() {
 		r := recover()
 		if _, ok := r.(InvalidOperandsError); ok {
@@ -2481,10 +3490,10 @@ func (v IntValue) SaturatingPlus(other NumberValue) NumberValue {
 		}
 	}()
 
-	return v.Plus(other)
+	return v.Plus(interpreter, other)
 }
 
-func (v IntValue) Minus(other NumberValue) NumberValue {
+func (v IntValue) Minus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(IntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -2494,12 +3503,14 @@ func (v IntValue) Minus(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewMinusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Sub(v.BigInt, o.BigInt)
 	return IntValue{res}
 }
 
-func (v IntValue) SaturatingMinus(other NumberValue) NumberValue {
+func (v IntValue) SaturatingMinus(interpreter *Interpreter, other NumberValue) NumberValue {
 	defer func() {
 		r := recover()
 		if _, ok := r.(InvalidOperandsError); ok {
@@ -2511,10 +3522,10 @@ func (v IntValue) SaturatingMinus(other NumberValue) NumberValue {
 		}
 	}()
 
-	return v.Minus(other)
+	return v.Minus(interpreter, other)
 }
 
-func (v IntValue) Mod(other NumberValue) NumberValue {
+func (v IntValue) Mod(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(IntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -2524,6 +3535,8 @@ func (v IntValue) Mod(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewModBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	// INT33-C
 	if o.BigInt.Cmp(res) == 0 {
@@ -2533,7 +3546,7 @@ func (v IntValue) Mod(other NumberValue) NumberValue {
 	return IntValue{res}
 }
 
-func (v IntValue) Mul(other NumberValue) NumberValue {
+func (v IntValue) Mul(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(IntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -2543,12 +3556,14 @@ func (v IntValue) Mul(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewMulBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Mul(v.BigInt, o.BigInt)
 	return IntValue{res}
 }
 
-func (v IntValue) SaturatingMul(other NumberValue) NumberValue {
+func (v IntValue) SaturatingMul(interpreter *Interpreter, other NumberValue) NumberValue {
 	defer func() {
 		r := recover()
 		if _, ok := r.(InvalidOperandsError); ok {
@@ -2560,10 +3575,10 @@ func (v IntValue) SaturatingMul(other NumberValue) NumberValue {
 		}
 	}()
 
-	return v.Mul(other)
+	return v.Mul(interpreter, other)
 }
 
-func (v IntValue) Div(other NumberValue) NumberValue {
+func (v IntValue) Div(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(IntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -2573,6 +3588,8 @@ func (v IntValue) Div(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewDivBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	// INT33-C
 	if o.BigInt.Cmp(res) == 0 {
@@ -2582,7 +3599,7 @@ func (v IntValue) Div(other NumberValue) NumberValue {
 	return IntValue{res}
 }
 
-func (v IntValue) SaturatingDiv(other NumberValue) NumberValue {
+func (v IntValue) SaturatingDiv(interpreter *Interpreter, other NumberValue) NumberValue {
 	defer func() {
 		r := recover()
 		if _, ok := r.(InvalidOperandsError); ok {
@@ -2594,7 +3611,7 @@ func (v IntValue) SaturatingDiv(other NumberValue) NumberValue {
 		}
 	}()
 
-	return v.Div(other)
+	return v.Div(interpreter, other)
 }
 
 func (v IntValue) Less(other NumberValue) BoolValue {
@@ -2681,7 +3698,7 @@ func (v IntValue) HashInput(_ *Interpreter, _ func() LocationRange, scratch []by
 	return buffer
 }
 
-func (v IntValue) BitwiseOr(other IntegerValue) IntegerValue {
+func (v IntValue) BitwiseOr(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(IntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -2691,12 +3708,14 @@ func (v IntValue) BitwiseOr(other IntegerValue) IntegerValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewBitwiseBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Or(v.BigInt, o.BigInt)
 	return IntValue{res}
 }
 
-func (v IntValue) BitwiseXor(other IntegerValue) IntegerValue {
+func (v IntValue) BitwiseXor(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(IntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -2706,12 +3725,14 @@ func (v IntValue) BitwiseXor(other IntegerValue) IntegerValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewBitwiseBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Xor(v.BigInt, o.BigInt)
 	return IntValue{res}
 }
 
-func (v IntValue) BitwiseAnd(other IntegerValue) IntegerValue {
+func (v IntValue) BitwiseAnd(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(IntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -2721,12 +3742,14 @@ func (v IntValue) BitwiseAnd(other IntegerValue) IntegerValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewBitwiseBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.And(v.BigInt, o.BigInt)
 	return IntValue{res}
 }
 
-func (v IntValue) BitwiseLeftShift(other IntegerValue) IntegerValue {
+func (v IntValue) BitwiseLeftShift(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(IntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -2736,18 +3759,21 @@ func (v IntValue) BitwiseLeftShift(other IntegerValue) IntegerValue {
 		})
 	}
 
-	res := new(big.Int)
 	if o.BigInt.Sign() < 0 {
 		panic(UnderflowError{})
 	}
 	if !o.BigInt.IsUint64() {
 		panic(OverflowError{})
 	}
+
+	common.UseMemory(interpreter, common.NewBitwiseLeftShiftBigIntMemoryUsage(v.BigInt, o.BigInt.Uint64()))
+
+	res := new(big.Int)
 	res.Lsh(v.BigInt, uint(o.BigInt.Uint64()))
 	return IntValue{res}
 }
 
-func (v IntValue) BitwiseRightShift(other IntegerValue) IntegerValue {
+func (v IntValue) BitwiseRightShift(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(IntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -2757,13 +3783,16 @@ func (v IntValue) BitwiseRightShift(other IntegerValue) IntegerValue {
 		})
 	}
 
-	res := new(big.Int)
 	if o.BigInt.Sign() < 0 {
 		panic(UnderflowError{})
 	}
 	if !o.BigInt.IsUint64() {
 		panic(OverflowError{})
 	}
+
+	common.UseMemory(interpreter, common.NewBitwiseRightShiftBigIntMemoryUsage(v.BigInt))
+
+	res := new(big.Int)
 	res.Rsh(v.BigInt, uint(o.BigInt.Uint64()))
 	return IntValue{res}
 }
@@ -2884,7 +3913,7 @@ func (v Int8Value) ToInt() int {
 	return int(v)
 }
 
-func (v Int8Value) Negate() NumberValue {
+func (v Int8Value) Negate(_ *Interpreter) NumberValue {
 	// INT32-C
 	if v == math.MinInt8 {
 		panic(OverflowError{})
@@ -2892,7 +3921,7 @@ func (v Int8Value) Negate() NumberValue {
 	return -v
 }
 
-func (v Int8Value) Plus(other NumberValue) NumberValue {
+func (v Int8Value) Plus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -2911,7 +3940,7 @@ func (v Int8Value) Plus(other NumberValue) NumberValue {
 	return v + o
 }
 
-func (v Int8Value) SaturatingPlus(other NumberValue) NumberValue {
+func (v Int8Value) SaturatingPlus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -2930,7 +3959,7 @@ func (v Int8Value) SaturatingPlus(other NumberValue) NumberValue {
 	return v + o
 }
 
-func (v Int8Value) Minus(other NumberValue) NumberValue {
+func (v Int8Value) Minus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -2949,7 +3978,7 @@ func (v Int8Value) Minus(other NumberValue) NumberValue {
 	return v - o
 }
 
-func (v Int8Value) SaturatingMinus(other NumberValue) NumberValue {
+func (v Int8Value) SaturatingMinus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -2968,7 +3997,7 @@ func (v Int8Value) SaturatingMinus(other NumberValue) NumberValue {
 	return v - o
 }
 
-func (v Int8Value) Mod(other NumberValue) NumberValue {
+func (v Int8Value) Mod(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -2985,7 +4014,7 @@ func (v Int8Value) Mod(other NumberValue) NumberValue {
 	return v % o
 }
 
-func (v Int8Value) Mul(other NumberValue) NumberValue {
+func (v Int8Value) Mul(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3024,7 +4053,7 @@ func (v Int8Value) Mul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v Int8Value) SaturatingMul(other NumberValue) NumberValue {
+func (v Int8Value) SaturatingMul(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3063,7 +4092,7 @@ func (v Int8Value) SaturatingMul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v Int8Value) Div(other NumberValue) NumberValue {
+func (v Int8Value) Div(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3083,7 +4112,7 @@ func (v Int8Value) Div(other NumberValue) NumberValue {
 	return v / o
 }
 
-func (v Int8Value) SaturatingDiv(other NumberValue) NumberValue {
+func (v Int8Value) SaturatingDiv(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3201,7 +4230,7 @@ func ConvertInt8(value Value) Int8Value {
 	return Int8Value(res)
 }
 
-func (v Int8Value) BitwiseOr(other IntegerValue) IntegerValue {
+func (v Int8Value) BitwiseOr(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3214,7 +4243,7 @@ func (v Int8Value) BitwiseOr(other IntegerValue) IntegerValue {
 	return v | o
 }
 
-func (v Int8Value) BitwiseXor(other IntegerValue) IntegerValue {
+func (v Int8Value) BitwiseXor(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3227,7 +4256,7 @@ func (v Int8Value) BitwiseXor(other IntegerValue) IntegerValue {
 	return v ^ o
 }
 
-func (v Int8Value) BitwiseAnd(other IntegerValue) IntegerValue {
+func (v Int8Value) BitwiseAnd(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3240,7 +4269,7 @@ func (v Int8Value) BitwiseAnd(other IntegerValue) IntegerValue {
 	return v & o
 }
 
-func (v Int8Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
+func (v Int8Value) BitwiseLeftShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3253,7 +4282,7 @@ func (v Int8Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
 	return v << o
 }
 
-func (v Int8Value) BitwiseRightShift(other IntegerValue) IntegerValue {
+func (v Int8Value) BitwiseRightShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3383,7 +4412,7 @@ func (v Int16Value) ToInt() int {
 	return int(v)
 }
 
-func (v Int16Value) Negate() NumberValue {
+func (v Int16Value) Negate(_ *Interpreter) NumberValue {
 	// INT32-C
 	if v == math.MinInt16 {
 		panic(OverflowError{})
@@ -3391,7 +4420,7 @@ func (v Int16Value) Negate() NumberValue {
 	return -v
 }
 
-func (v Int16Value) Plus(other NumberValue) NumberValue {
+func (v Int16Value) Plus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3410,7 +4439,7 @@ func (v Int16Value) Plus(other NumberValue) NumberValue {
 	return v + o
 }
 
-func (v Int16Value) SaturatingPlus(other NumberValue) NumberValue {
+func (v Int16Value) SaturatingPlus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3429,7 +4458,7 @@ func (v Int16Value) SaturatingPlus(other NumberValue) NumberValue {
 	return v + o
 }
 
-func (v Int16Value) Minus(other NumberValue) NumberValue {
+func (v Int16Value) Minus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3448,7 +4477,7 @@ func (v Int16Value) Minus(other NumberValue) NumberValue {
 	return v - o
 }
 
-func (v Int16Value) SaturatingMinus(other NumberValue) NumberValue {
+func (v Int16Value) SaturatingMinus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3467,7 +4496,7 @@ func (v Int16Value) SaturatingMinus(other NumberValue) NumberValue {
 	return v - o
 }
 
-func (v Int16Value) Mod(other NumberValue) NumberValue {
+func (v Int16Value) Mod(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3484,7 +4513,7 @@ func (v Int16Value) Mod(other NumberValue) NumberValue {
 	return v % o
 }
 
-func (v Int16Value) Mul(other NumberValue) NumberValue {
+func (v Int16Value) Mul(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3523,7 +4552,7 @@ func (v Int16Value) Mul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v Int16Value) SaturatingMul(other NumberValue) NumberValue {
+func (v Int16Value) SaturatingMul(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3562,7 +4591,7 @@ func (v Int16Value) SaturatingMul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v Int16Value) Div(other NumberValue) NumberValue {
+func (v Int16Value) Div(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3582,7 +4611,7 @@ func (v Int16Value) Div(other NumberValue) NumberValue {
 	return v / o
 }
 
-func (v Int16Value) SaturatingDiv(other NumberValue) NumberValue {
+func (v Int16Value) SaturatingDiv(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3700,7 +4729,7 @@ func ConvertInt16(value Value) Int16Value {
 	return Int16Value(res)
 }
 
-func (v Int16Value) BitwiseOr(other IntegerValue) IntegerValue {
+func (v Int16Value) BitwiseOr(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3713,7 +4742,7 @@ func (v Int16Value) BitwiseOr(other IntegerValue) IntegerValue {
 	return v | o
 }
 
-func (v Int16Value) BitwiseXor(other IntegerValue) IntegerValue {
+func (v Int16Value) BitwiseXor(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3726,7 +4755,7 @@ func (v Int16Value) BitwiseXor(other IntegerValue) IntegerValue {
 	return v ^ o
 }
 
-func (v Int16Value) BitwiseAnd(other IntegerValue) IntegerValue {
+func (v Int16Value) BitwiseAnd(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3739,7 +4768,7 @@ func (v Int16Value) BitwiseAnd(other IntegerValue) IntegerValue {
 	return v & o
 }
 
-func (v Int16Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
+func (v Int16Value) BitwiseLeftShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3752,7 +4781,7 @@ func (v Int16Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
 	return v << o
 }
 
-func (v Int16Value) BitwiseRightShift(other IntegerValue) IntegerValue {
+func (v Int16Value) BitwiseRightShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3884,7 +4913,7 @@ func (v Int32Value) ToInt() int {
 	return int(v)
 }
 
-func (v Int32Value) Negate() NumberValue {
+func (v Int32Value) Negate(_ *Interpreter) NumberValue {
 	// INT32-C
 	if v == math.MinInt32 {
 		panic(OverflowError{})
@@ -3892,7 +4921,7 @@ func (v Int32Value) Negate() NumberValue {
 	return -v
 }
 
-func (v Int32Value) Plus(other NumberValue) NumberValue {
+func (v Int32Value) Plus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3911,7 +4940,7 @@ func (v Int32Value) Plus(other NumberValue) NumberValue {
 	return v + o
 }
 
-func (v Int32Value) SaturatingPlus(other NumberValue) NumberValue {
+func (v Int32Value) SaturatingPlus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3930,7 +4959,7 @@ func (v Int32Value) SaturatingPlus(other NumberValue) NumberValue {
 	return v + o
 }
 
-func (v Int32Value) Minus(other NumberValue) NumberValue {
+func (v Int32Value) Minus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3949,7 +4978,7 @@ func (v Int32Value) Minus(other NumberValue) NumberValue {
 	return v - o
 }
 
-func (v Int32Value) SaturatingMinus(other NumberValue) NumberValue {
+func (v Int32Value) SaturatingMinus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3968,7 +4997,7 @@ func (v Int32Value) SaturatingMinus(other NumberValue) NumberValue {
 	return v - o
 }
 
-func (v Int32Value) Mod(other NumberValue) NumberValue {
+func (v Int32Value) Mod(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -3985,7 +5014,7 @@ func (v Int32Value) Mod(other NumberValue) NumberValue {
 	return v % o
 }
 
-func (v Int32Value) Mul(other NumberValue) NumberValue {
+func (v Int32Value) Mul(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4024,7 +5053,7 @@ func (v Int32Value) Mul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v Int32Value) SaturatingMul(other NumberValue) NumberValue {
+func (v Int32Value) SaturatingMul(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4063,7 +5092,7 @@ func (v Int32Value) SaturatingMul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v Int32Value) Div(other NumberValue) NumberValue {
+func (v Int32Value) Div(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4083,7 +5112,7 @@ func (v Int32Value) Div(other NumberValue) NumberValue {
 	return v / o
 }
 
-func (v Int32Value) SaturatingDiv(other NumberValue) NumberValue {
+func (v Int32Value) SaturatingDiv(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4201,7 +5230,7 @@ func ConvertInt32(value Value) Int32Value {
 	return Int32Value(res)
 }
 
-func (v Int32Value) BitwiseOr(other IntegerValue) IntegerValue {
+func (v Int32Value) BitwiseOr(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4214,7 +5243,7 @@ func (v Int32Value) BitwiseOr(other IntegerValue) IntegerValue {
 	return v | o
 }
 
-func (v Int32Value) BitwiseXor(other IntegerValue) IntegerValue {
+func (v Int32Value) BitwiseXor(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4227,7 +5256,7 @@ func (v Int32Value) BitwiseXor(other IntegerValue) IntegerValue {
 	return v ^ o
 }
 
-func (v Int32Value) BitwiseAnd(other IntegerValue) IntegerValue {
+func (v Int32Value) BitwiseAnd(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4240,7 +5269,7 @@ func (v Int32Value) BitwiseAnd(other IntegerValue) IntegerValue {
 	return v & o
 }
 
-func (v Int32Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
+func (v Int32Value) BitwiseLeftShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4253,7 +5282,7 @@ func (v Int32Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
 	return v << o
 }
 
-func (v Int32Value) BitwiseRightShift(other IntegerValue) IntegerValue {
+func (v Int32Value) BitwiseRightShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4385,7 +5414,7 @@ func (v Int64Value) ToInt() int {
 	return int(v)
 }
 
-func (v Int64Value) Negate() NumberValue {
+func (v Int64Value) Negate(_ *Interpreter) NumberValue {
 	// INT32-C
 	if v == math.MinInt64 {
 		panic(OverflowError{})
@@ -4403,7 +5432,7 @@ func safeAddInt64(a, b int64) int64 {
 	return a + b
 }
 
-func (v Int64Value) Plus(other NumberValue) NumberValue {
+func (v Int64Value) Plus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4416,7 +5445,7 @@ func (v Int64Value) Plus(other NumberValue) NumberValue {
 	return Int64Value(safeAddInt64(int64(v), int64(o)))
 }
 
-func (v Int64Value) SaturatingPlus(other NumberValue) NumberValue {
+func (v Int64Value) SaturatingPlus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4435,7 +5464,7 @@ func (v Int64Value) SaturatingPlus(other NumberValue) NumberValue {
 	return v + o
 }
 
-func (v Int64Value) Minus(other NumberValue) NumberValue {
+func (v Int64Value) Minus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4454,7 +5483,7 @@ func (v Int64Value) Minus(other NumberValue) NumberValue {
 	return v - o
 }
 
-func (v Int64Value) SaturatingMinus(other NumberValue) NumberValue {
+func (v Int64Value) SaturatingMinus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4473,7 +5502,7 @@ func (v Int64Value) SaturatingMinus(other NumberValue) NumberValue {
 	return v - o
 }
 
-func (v Int64Value) Mod(other NumberValue) NumberValue {
+func (v Int64Value) Mod(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4490,7 +5519,7 @@ func (v Int64Value) Mod(other NumberValue) NumberValue {
 	return v % o
 }
 
-func (v Int64Value) Mul(other NumberValue) NumberValue {
+func (v Int64Value) Mul(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4529,7 +5558,7 @@ func (v Int64Value) Mul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v Int64Value) SaturatingMul(other NumberValue) NumberValue {
+func (v Int64Value) SaturatingMul(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4568,7 +5597,7 @@ func (v Int64Value) SaturatingMul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v Int64Value) Div(other NumberValue) NumberValue {
+func (v Int64Value) Div(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4588,7 +5617,7 @@ func (v Int64Value) Div(other NumberValue) NumberValue {
 	return v / o
 }
 
-func (v Int64Value) SaturatingDiv(other NumberValue) NumberValue {
+func (v Int64Value) SaturatingDiv(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4701,7 +5730,7 @@ func ConvertInt64(value Value) Int64Value {
 	return Int64Value(res)
 }
 
-func (v Int64Value) BitwiseOr(other IntegerValue) IntegerValue {
+func (v Int64Value) BitwiseOr(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4714,7 +5743,7 @@ func (v Int64Value) BitwiseOr(other IntegerValue) IntegerValue {
 	return v | o
 }
 
-func (v Int64Value) BitwiseXor(other IntegerValue) IntegerValue {
+func (v Int64Value) BitwiseXor(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4727,7 +5756,7 @@ func (v Int64Value) BitwiseXor(other IntegerValue) IntegerValue {
 	return v ^ o
 }
 
-func (v Int64Value) BitwiseAnd(other IntegerValue) IntegerValue {
+func (v Int64Value) BitwiseAnd(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4740,7 +5769,7 @@ func (v Int64Value) BitwiseAnd(other IntegerValue) IntegerValue {
 	return v & o
 }
 
-func (v Int64Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
+func (v Int64Value) BitwiseLeftShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4753,7 +5782,7 @@ func (v Int64Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
 	return v << o
 }
 
-func (v Int64Value) BitwiseRightShift(other IntegerValue) IntegerValue {
+func (v Int64Value) BitwiseRightShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4902,7 +5931,7 @@ func (v Int128Value) RecursiveString(_ SeenReferences) string {
 	return v.String()
 }
 
-func (v Int128Value) Negate() NumberValue {
+func (v Int128Value) Negate(interpreter *Interpreter) NumberValue {
 	// INT32-C
 	//   if v == Int128TypeMinIntBig {
 	//       ...
@@ -4910,10 +5939,12 @@ func (v Int128Value) Negate() NumberValue {
 	if v.BigInt.Cmp(sema.Int128TypeMinIntBig) == 0 {
 		panic(OverflowError{})
 	}
+	common.UseMemory(interpreter, common.NewNegateBigIntMemoryUsage(v.BigInt))
+
 	return Int128Value{new(big.Int).Neg(v.BigInt)}
 }
 
-func (v Int128Value) Plus(other NumberValue) NumberValue {
+func (v Int128Value) Plus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4935,6 +5966,8 @@ func (v Int128Value) Plus(other NumberValue) NumberValue {
 	//       ...
 	//   }
 	//
+	common.UseMemory(interpreter, common.NewPlusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Add(v.BigInt, o.BigInt)
 	if res.Cmp(sema.Int128TypeMinIntBig) < 0 {
@@ -4945,7 +5978,7 @@ func (v Int128Value) Plus(other NumberValue) NumberValue {
 	return Int128Value{res}
 }
 
-func (v Int128Value) SaturatingPlus(other NumberValue) NumberValue {
+func (v Int128Value) SaturatingPlus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4967,6 +6000,8 @@ func (v Int128Value) SaturatingPlus(other NumberValue) NumberValue {
 	//       ...
 	//   }
 	//
+	common.UseMemory(interpreter, common.NewPlusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Add(v.BigInt, o.BigInt)
 	if res.Cmp(sema.Int128TypeMinIntBig) < 0 {
@@ -4977,7 +6012,7 @@ func (v Int128Value) SaturatingPlus(other NumberValue) NumberValue {
 	return Int128Value{res}
 }
 
-func (v Int128Value) Minus(other NumberValue) NumberValue {
+func (v Int128Value) Minus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -4999,6 +6034,8 @@ func (v Int128Value) Minus(other NumberValue) NumberValue {
 	//       ...
 	//   }
 	//
+	common.UseMemory(interpreter, common.NewMinusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Sub(v.BigInt, o.BigInt)
 	if res.Cmp(sema.Int128TypeMinIntBig) < 0 {
@@ -5009,7 +6046,7 @@ func (v Int128Value) Minus(other NumberValue) NumberValue {
 	return Int128Value{res}
 }
 
-func (v Int128Value) SaturatingMinus(other NumberValue) NumberValue {
+func (v Int128Value) SaturatingMinus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5031,6 +6068,8 @@ func (v Int128Value) SaturatingMinus(other NumberValue) NumberValue {
 	//       ...
 	//   }
 	//
+	common.UseMemory(interpreter, common.NewMinusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Sub(v.BigInt, o.BigInt)
 	if res.Cmp(sema.Int128TypeMinIntBig) < 0 {
@@ -5041,7 +6080,7 @@ func (v Int128Value) SaturatingMinus(other NumberValue) NumberValue {
 	return Int128Value{res}
 }
 
-func (v Int128Value) Mod(other NumberValue) NumberValue {
+func (v Int128Value) Mod(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5051,6 +6090,8 @@ func (v Int128Value) Mod(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewModBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	// INT33-C
 	if o.BigInt.Cmp(res) == 0 {
@@ -5060,7 +6101,7 @@ func (v Int128Value) Mod(other NumberValue) NumberValue {
 	return Int128Value{res}
 }
 
-func (v Int128Value) Mul(other NumberValue) NumberValue {
+func (v Int128Value) Mul(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5070,6 +6111,8 @@ func (v Int128Value) Mul(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewMulBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Mul(v.BigInt, o.BigInt)
 	if res.Cmp(sema.Int128TypeMinIntBig) < 0 {
@@ -5080,7 +6123,7 @@ func (v Int128Value) Mul(other NumberValue) NumberValue {
 	return Int128Value{res}
 }
 
-func (v Int128Value) SaturatingMul(other NumberValue) NumberValue {
+func (v Int128Value) SaturatingMul(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5090,6 +6133,8 @@ func (v Int128Value) SaturatingMul(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewMulBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Mul(v.BigInt, o.BigInt)
 	if res.Cmp(sema.Int128TypeMinIntBig) < 0 {
@@ -5100,7 +6145,7 @@ func (v Int128Value) SaturatingMul(other NumberValue) NumberValue {
 	return Int128Value{res}
 }
 
-func (v Int128Value) Div(other NumberValue) NumberValue {
+func (v Int128Value) Div(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5110,6 +6155,8 @@ func (v Int128Value) Div(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewDivBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	// INT33-C:
 	//   if o == 0 {
@@ -5128,7 +6175,7 @@ func (v Int128Value) Div(other NumberValue) NumberValue {
 	return Int128Value{res}
 }
 
-func (v Int128Value) SaturatingDiv(other NumberValue) NumberValue {
+func (v Int128Value) SaturatingDiv(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5138,6 +6185,8 @@ func (v Int128Value) SaturatingDiv(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewDivBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	// INT33-C:
 	//   if o == 0 {
@@ -5263,7 +6312,7 @@ func ConvertInt128(value Value) Int128Value {
 	return NewInt128ValueFromBigInt(v)
 }
 
-func (v Int128Value) BitwiseOr(other IntegerValue) IntegerValue {
+func (v Int128Value) BitwiseOr(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5273,12 +6322,14 @@ func (v Int128Value) BitwiseOr(other IntegerValue) IntegerValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewBitwiseBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Or(v.BigInt, o.BigInt)
 	return Int128Value{res}
 }
 
-func (v Int128Value) BitwiseXor(other IntegerValue) IntegerValue {
+func (v Int128Value) BitwiseXor(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5288,12 +6339,14 @@ func (v Int128Value) BitwiseXor(other IntegerValue) IntegerValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewBitwiseBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Xor(v.BigInt, o.BigInt)
 	return Int128Value{res}
 }
 
-func (v Int128Value) BitwiseAnd(other IntegerValue) IntegerValue {
+func (v Int128Value) BitwiseAnd(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5303,12 +6356,14 @@ func (v Int128Value) BitwiseAnd(other IntegerValue) IntegerValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewBitwiseBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.And(v.BigInt, o.BigInt)
 	return Int128Value{res}
 }
 
-func (v Int128Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
+func (v Int128Value) BitwiseLeftShift(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5318,18 +6373,21 @@ func (v Int128Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
 		})
 	}
 
-	res := new(big.Int)
 	if o.BigInt.Sign() < 0 {
 		panic(UnderflowError{})
 	}
 	if !o.BigInt.IsUint64() {
 		panic(OverflowError{})
 	}
+
+	common.UseMemory(interpreter, common.NewBitwiseLeftShiftBigIntMemoryUsage(v.BigInt, o.BigInt.Uint64()))
+
+	res := new(big.Int)
 	res.Lsh(v.BigInt, uint(o.BigInt.Uint64()))
 	return Int128Value{res}
 }
 
-func (v Int128Value) BitwiseRightShift(other IntegerValue) IntegerValue {
+func (v Int128Value) BitwiseRightShift(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5339,13 +6397,16 @@ func (v Int128Value) BitwiseRightShift(other IntegerValue) IntegerValue {
 		})
 	}
 
-	res := new(big.Int)
 	if o.BigInt.Sign() < 0 {
 		panic(UnderflowError{})
 	}
 	if !o.BigInt.IsUint64() {
 		panic(OverflowError{})
 	}
+
+	common.UseMemory(interpreter, common.NewBitwiseRightShiftBigIntMemoryUsage(v.BigInt))
+
+	res := new(big.Int)
 	res.Rsh(v.BigInt, uint(o.BigInt.Uint64()))
 	return Int128Value{res}
 }
@@ -5484,7 +6545,7 @@ func (v Int256Value) RecursiveString(_ SeenReferences) string {
 	return v.String()
 }
 
-func (v Int256Value) Negate() NumberValue {
+func (v Int256Value) Negate(interpreter *Interpreter) NumberValue {
 	// INT32-C
 	//   if v == Int256TypeMinIntBig {
 	//       ...
@@ -5492,10 +6553,12 @@ func (v Int256Value) Negate() NumberValue {
 	if v.BigInt.Cmp(sema.Int256TypeMinIntBig) == 0 {
 		panic(OverflowError{})
 	}
+	common.UseMemory(interpreter, common.NewNegateBigIntMemoryUsage(v.BigInt))
+
 	return Int256Value{BigInt: new(big.Int).Neg(v.BigInt)}
 }
 
-func (v Int256Value) Plus(other NumberValue) NumberValue {
+func (v Int256Value) Plus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5517,6 +6580,8 @@ func (v Int256Value) Plus(other NumberValue) NumberValue {
 	//       ...
 	//   }
 	//
+	common.UseMemory(interpreter, common.NewPlusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Add(v.BigInt, o.BigInt)
 	if res.Cmp(sema.Int256TypeMinIntBig) < 0 {
@@ -5527,7 +6592,7 @@ func (v Int256Value) Plus(other NumberValue) NumberValue {
 	return Int256Value{res}
 }
 
-func (v Int256Value) SaturatingPlus(other NumberValue) NumberValue {
+func (v Int256Value) SaturatingPlus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5549,6 +6614,8 @@ func (v Int256Value) SaturatingPlus(other NumberValue) NumberValue {
 	//       ...
 	//   }
 	//
+	common.UseMemory(interpreter, common.NewPlusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Add(v.BigInt, o.BigInt)
 	if res.Cmp(sema.Int256TypeMinIntBig) < 0 {
@@ -5559,7 +6626,7 @@ func (v Int256Value) SaturatingPlus(other NumberValue) NumberValue {
 	return Int256Value{res}
 }
 
-func (v Int256Value) Minus(other NumberValue) NumberValue {
+func (v Int256Value) Minus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5581,6 +6648,8 @@ func (v Int256Value) Minus(other NumberValue) NumberValue {
 	//       ...
 	//   }
 	//
+	common.UseMemory(interpreter, common.NewMinusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Sub(v.BigInt, o.BigInt)
 	if res.Cmp(sema.Int256TypeMinIntBig) < 0 {
@@ -5591,7 +6660,7 @@ func (v Int256Value) Minus(other NumberValue) NumberValue {
 	return Int256Value{res}
 }
 
-func (v Int256Value) SaturatingMinus(other NumberValue) NumberValue {
+func (v Int256Value) SaturatingMinus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5613,6 +6682,8 @@ func (v Int256Value) SaturatingMinus(other NumberValue) NumberValue {
 	//       ...
 	//   }
 	//
+	common.UseMemory(interpreter, common.NewMinusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Sub(v.BigInt, o.BigInt)
 	if res.Cmp(sema.Int256TypeMinIntBig) < 0 {
@@ -5623,7 +6694,7 @@ func (v Int256Value) SaturatingMinus(other NumberValue) NumberValue {
 	return Int256Value{res}
 }
 
-func (v Int256Value) Mod(other NumberValue) NumberValue {
+func (v Int256Value) Mod(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5633,6 +6704,8 @@ func (v Int256Value) Mod(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewModBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	// INT33-C
 	if o.BigInt.Cmp(res) == 0 {
@@ -5642,7 +6715,7 @@ func (v Int256Value) Mod(other NumberValue) NumberValue {
 	return Int256Value{res}
 }
 
-func (v Int256Value) Mul(other NumberValue) NumberValue {
+func (v Int256Value) Mul(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5652,6 +6725,8 @@ func (v Int256Value) Mul(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewMulBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Mul(v.BigInt, o.BigInt)
 	if res.Cmp(sema.Int256TypeMinIntBig) < 0 {
@@ -5662,7 +6737,7 @@ func (v Int256Value) Mul(other NumberValue) NumberValue {
 	return Int256Value{res}
 }
 
-func (v Int256Value) SaturatingMul(other NumberValue) NumberValue {
+func (v Int256Value) SaturatingMul(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5672,6 +6747,8 @@ func (v Int256Value) SaturatingMul(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewMulBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Mul(v.BigInt, o.BigInt)
 	if res.Cmp(sema.Int256TypeMinIntBig) < 0 {
@@ -5682,7 +6759,7 @@ func (v Int256Value) SaturatingMul(other NumberValue) NumberValue {
 	return Int256Value{res}
 }
 
-func (v Int256Value) Div(other NumberValue) NumberValue {
+func (v Int256Value) Div(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5692,6 +6769,8 @@ func (v Int256Value) Div(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewDivBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	// INT33-C:
 	//   if o == 0 {
@@ -5710,7 +6789,7 @@ func (v Int256Value) Div(other NumberValue) NumberValue {
 	return Int256Value{res}
 }
 
-func (v Int256Value) SaturatingDiv(other NumberValue) NumberValue {
+func (v Int256Value) SaturatingDiv(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Int256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5720,6 +6799,8 @@ func (v Int256Value) SaturatingDiv(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewDivBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	// INT33-C:
 	//   if o == 0 {
@@ -5845,7 +6926,7 @@ func ConvertInt256(value Value) Int256Value {
 	return NewInt256ValueFromBigInt(v)
 }
 
-func (v Int256Value) BitwiseOr(other IntegerValue) IntegerValue {
+func (v Int256Value) BitwiseOr(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5855,12 +6936,14 @@ func (v Int256Value) BitwiseOr(other IntegerValue) IntegerValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewBitwiseBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Or(v.BigInt, o.BigInt)
 	return Int256Value{res}
 }
 
-func (v Int256Value) BitwiseXor(other IntegerValue) IntegerValue {
+func (v Int256Value) BitwiseXor(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5870,12 +6953,14 @@ func (v Int256Value) BitwiseXor(other IntegerValue) IntegerValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewBitwiseBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Xor(v.BigInt, o.BigInt)
 	return Int256Value{res}
 }
 
-func (v Int256Value) BitwiseAnd(other IntegerValue) IntegerValue {
+func (v Int256Value) BitwiseAnd(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5885,12 +6970,14 @@ func (v Int256Value) BitwiseAnd(other IntegerValue) IntegerValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewBitwiseBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.And(v.BigInt, o.BigInt)
 	return Int256Value{res}
 }
 
-func (v Int256Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
+func (v Int256Value) BitwiseLeftShift(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5900,18 +6987,21 @@ func (v Int256Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
 		})
 	}
 
-	res := new(big.Int)
 	if o.BigInt.Sign() < 0 {
 		panic(UnderflowError{})
 	}
 	if !o.BigInt.IsUint64() {
 		panic(OverflowError{})
 	}
+
+	common.UseMemory(interpreter, common.NewBitwiseLeftShiftBigIntMemoryUsage(v.BigInt, o.BigInt.Uint64()))
+
+	res := new(big.Int)
 	res.Lsh(v.BigInt, uint(o.BigInt.Uint64()))
 	return Int256Value{res}
 }
 
-func (v Int256Value) BitwiseRightShift(other IntegerValue) IntegerValue {
+func (v Int256Value) BitwiseRightShift(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Int256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -5921,13 +7011,16 @@ func (v Int256Value) BitwiseRightShift(other IntegerValue) IntegerValue {
 		})
 	}
 
-	res := new(big.Int)
 	if o.BigInt.Sign() < 0 {
 		panic(UnderflowError{})
 	}
 	if !o.BigInt.IsUint64() {
 		panic(OverflowError{})
 	}
+
+	common.UseMemory(interpreter, common.NewBitwiseRightShiftBigIntMemoryUsage(v.BigInt))
+
+	res := new(big.Int)
 	res.Rsh(v.BigInt, uint(o.BigInt.Uint64()))
 	return Int256Value{res}
 }
@@ -6087,11 +7180,11 @@ func (v UIntValue) RecursiveString(_ SeenReferences) string {
 	return v.String()
 }
 
-func (v UIntValue) Negate() NumberValue {
+func (v UIntValue) Negate(_ *Interpreter) NumberValue {
 	panic(errors.NewUnreachableError())
 }
 
-func (v UIntValue) Plus(other NumberValue) NumberValue {
+func (v UIntValue) Plus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UIntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6101,12 +7194,14 @@ func (v UIntValue) Plus(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewPlusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Add(v.BigInt, o.BigInt)
 	return UIntValue{res}
 }
 
-func (v UIntValue) SaturatingPlus(other NumberValue) NumberValue {
+func (v UIntValue) SaturatingPlus(interpreter *Interpreter, other NumberValue) NumberValue {
 	defer func() {
 		r := recover()
 		if _, ok := r.(InvalidOperandsError); ok {
@@ -6118,10 +7213,10 @@ func (v UIntValue) SaturatingPlus(other NumberValue) NumberValue {
 		}
 	}()
 
-	return v.Plus(other)
+	return v.Plus(interpreter, other)
 }
 
-func (v UIntValue) Minus(other NumberValue) NumberValue {
+func (v UIntValue) Minus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UIntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6131,6 +7226,8 @@ func (v UIntValue) Minus(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewMinusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Sub(v.BigInt, o.BigInt)
 	// INT30-C
@@ -6140,7 +7237,7 @@ func (v UIntValue) Minus(other NumberValue) NumberValue {
 	return UIntValue{res}
 }
 
-func (v UIntValue) SaturatingMinus(other NumberValue) NumberValue {
+func (v UIntValue) SaturatingMinus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UIntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6150,6 +7247,8 @@ func (v UIntValue) SaturatingMinus(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewMinusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Sub(v.BigInt, o.BigInt)
 	// INT30-C
@@ -6159,7 +7258,7 @@ func (v UIntValue) SaturatingMinus(other NumberValue) NumberValue {
 	return UIntValue{res}
 }
 
-func (v UIntValue) Mod(other NumberValue) NumberValue {
+func (v UIntValue) Mod(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UIntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6169,6 +7268,8 @@ func (v UIntValue) Mod(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewModBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	// INT33-C
 	if o.BigInt.Cmp(res) == 0 {
@@ -6178,7 +7279,7 @@ func (v UIntValue) Mod(other NumberValue) NumberValue {
 	return UIntValue{res}
 }
 
-func (v UIntValue) Mul(other NumberValue) NumberValue {
+func (v UIntValue) Mul(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UIntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6188,12 +7289,14 @@ func (v UIntValue) Mul(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewMulBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Mul(v.BigInt, o.BigInt)
 	return UIntValue{res}
 }
 
-func (v UIntValue) SaturatingMul(other NumberValue) NumberValue {
+func (v UIntValue) SaturatingMul(interpreter *Interpreter, other NumberValue) NumberValue {
 	defer func() {
 		r := recover()
 		if _, ok := r.(InvalidOperandsError); ok {
@@ -6205,10 +7308,10 @@ func (v UIntValue) SaturatingMul(other NumberValue) NumberValue {
 		}
 	}()
 
-	return v.Mul(other)
+	return v.Mul(interpreter, other)
 }
 
-func (v UIntValue) Div(other NumberValue) NumberValue {
+func (v UIntValue) Div(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UIntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6218,6 +7321,8 @@ func (v UIntValue) Div(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewDivBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	// INT33-C
 	if o.BigInt.Cmp(res) == 0 {
@@ -6227,7 +7332,7 @@ func (v UIntValue) Div(other NumberValue) NumberValue {
 	return UIntValue{res}
 }
 
-func (v UIntValue) SaturatingDiv(other NumberValue) NumberValue {
+func (v UIntValue) SaturatingDiv(interpreter *Interpreter, other NumberValue) NumberValue {
 	defer func() {
 		r := recover()
 		if _, ok := r.(InvalidOperandsError); ok {
@@ -6239,7 +7344,7 @@ func (v UIntValue) SaturatingDiv(other NumberValue) NumberValue {
 		}
 	}()
 
-	return v.Div(other)
+	return v.Div(interpreter, other)
 }
 
 func (v UIntValue) Less(other NumberValue) BoolValue {
@@ -6326,7 +7431,7 @@ func (v UIntValue) HashInput(_ *Interpreter, _ func() LocationRange, scratch []b
 	return buffer
 }
 
-func (v UIntValue) BitwiseOr(other IntegerValue) IntegerValue {
+func (v UIntValue) BitwiseOr(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UIntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6336,12 +7441,14 @@ func (v UIntValue) BitwiseOr(other IntegerValue) IntegerValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewBitwiseBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Or(v.BigInt, o.BigInt)
 	return UIntValue{res}
 }
 
-func (v UIntValue) BitwiseXor(other IntegerValue) IntegerValue {
+func (v UIntValue) BitwiseXor(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UIntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6351,12 +7458,14 @@ func (v UIntValue) BitwiseXor(other IntegerValue) IntegerValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewBitwiseBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Xor(v.BigInt, o.BigInt)
 	return UIntValue{res}
 }
 
-func (v UIntValue) BitwiseAnd(other IntegerValue) IntegerValue {
+func (v UIntValue) BitwiseAnd(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UIntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6366,12 +7475,14 @@ func (v UIntValue) BitwiseAnd(other IntegerValue) IntegerValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewBitwiseBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.And(v.BigInt, o.BigInt)
 	return UIntValue{res}
 }
 
-func (v UIntValue) BitwiseLeftShift(other IntegerValue) IntegerValue {
+func (v UIntValue) BitwiseLeftShift(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UIntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6381,18 +7492,21 @@ func (v UIntValue) BitwiseLeftShift(other IntegerValue) IntegerValue {
 		})
 	}
 
-	res := new(big.Int)
 	if o.BigInt.Sign() < 0 {
 		panic(UnderflowError{})
 	}
 	if !o.BigInt.IsUint64() {
 		panic(OverflowError{})
 	}
+
+	common.UseMemory(interpreter, common.NewBitwiseLeftShiftBigIntMemoryUsage(v.BigInt, o.BigInt.Uint64()))
+
+	res := new(big.Int)
 	res.Lsh(v.BigInt, uint(o.BigInt.Uint64()))
 	return UIntValue{res}
 }
 
-func (v UIntValue) BitwiseRightShift(other IntegerValue) IntegerValue {
+func (v UIntValue) BitwiseRightShift(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UIntValue)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6402,13 +7516,16 @@ func (v UIntValue) BitwiseRightShift(other IntegerValue) IntegerValue {
 		})
 	}
 
-	res := new(big.Int)
 	if o.BigInt.Sign() < 0 {
 		panic(UnderflowError{})
 	}
 	if !o.BigInt.IsUint64() {
 		panic(OverflowError{})
 	}
+
+	common.UseMemory(interpreter, common.NewBitwiseRightShiftBigIntMemoryUsage(v.BigInt))
+
+	res := new(big.Int)
 	res.Rsh(v.BigInt, uint(o.BigInt.Uint64()))
 	return UIntValue{res}
 }
@@ -6530,11 +7647,11 @@ func (v UInt8Value) ToInt() int {
 	return int(v)
 }
 
-func (v UInt8Value) Negate() NumberValue {
+func (v UInt8Value) Negate(_ *Interpreter) NumberValue {
 	panic(errors.NewUnreachableError())
 }
 
-func (v UInt8Value) Plus(other NumberValue) NumberValue {
+func (v UInt8Value) Plus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6552,7 +7669,7 @@ func (v UInt8Value) Plus(other NumberValue) NumberValue {
 	return sum
 }
 
-func (v UInt8Value) SaturatingPlus(other NumberValue) NumberValue {
+func (v UInt8Value) SaturatingPlus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6570,7 +7687,7 @@ func (v UInt8Value) SaturatingPlus(other NumberValue) NumberValue {
 	return sum
 }
 
-func (v UInt8Value) Minus(other NumberValue) NumberValue {
+func (v UInt8Value) Minus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6589,7 +7706,7 @@ func (v UInt8Value) Minus(other NumberValue) NumberValue {
 	return diff
 }
 
-func (v UInt8Value) SaturatingMinus(other NumberValue) NumberValue {
+func (v UInt8Value) SaturatingMinus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6608,7 +7725,7 @@ func (v UInt8Value) SaturatingMinus(other NumberValue) NumberValue {
 	return diff
 }
 
-func (v UInt8Value) Mod(other NumberValue) NumberValue {
+func (v UInt8Value) Mod(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6624,7 +7741,7 @@ func (v UInt8Value) Mod(other NumberValue) NumberValue {
 	return v % o
 }
 
-func (v UInt8Value) Mul(other NumberValue) NumberValue {
+func (v UInt8Value) Mul(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6641,7 +7758,7 @@ func (v UInt8Value) Mul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v UInt8Value) SaturatingMul(other NumberValue) NumberValue {
+func (v UInt8Value) SaturatingMul(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6658,7 +7775,7 @@ func (v UInt8Value) SaturatingMul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v UInt8Value) Div(other NumberValue) NumberValue {
+func (v UInt8Value) Div(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6674,7 +7791,7 @@ func (v UInt8Value) Div(other NumberValue) NumberValue {
 	return v / o
 }
 
-func (v UInt8Value) SaturatingDiv(other NumberValue) NumberValue {
+func (v UInt8Value) SaturatingDiv(interpreter *Interpreter, other NumberValue) NumberValue {
 	defer func() {
 		r := recover()
 		if _, ok := r.(InvalidOperandsError); ok {
@@ -6686,7 +7803,7 @@ func (v UInt8Value) SaturatingDiv(other NumberValue) NumberValue {
 		}
 	}()
 
-	return v.Div(other)
+	return v.Div(interpreter, other)
 }
 
 func (v UInt8Value) Less(other NumberValue) BoolValue {
@@ -6787,7 +7904,7 @@ func ConvertUInt8(value Value) UInt8Value {
 	return UInt8Value(res)
 }
 
-func (v UInt8Value) BitwiseOr(other IntegerValue) IntegerValue {
+func (v UInt8Value) BitwiseOr(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6800,7 +7917,7 @@ func (v UInt8Value) BitwiseOr(other IntegerValue) IntegerValue {
 	return v | o
 }
 
-func (v UInt8Value) BitwiseXor(other IntegerValue) IntegerValue {
+func (v UInt8Value) BitwiseXor(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6813,7 +7930,7 @@ func (v UInt8Value) BitwiseXor(other IntegerValue) IntegerValue {
 	return v ^ o
 }
 
-func (v UInt8Value) BitwiseAnd(other IntegerValue) IntegerValue {
+func (v UInt8Value) BitwiseAnd(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6826,7 +7943,7 @@ func (v UInt8Value) BitwiseAnd(other IntegerValue) IntegerValue {
 	return v & o
 }
 
-func (v UInt8Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
+func (v UInt8Value) BitwiseLeftShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6839,7 +7956,7 @@ func (v UInt8Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
 	return v << o
 }
 
-func (v UInt8Value) BitwiseRightShift(other IntegerValue) IntegerValue {
+func (v UInt8Value) BitwiseRightShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6968,11 +8085,11 @@ func (v UInt16Value) RecursiveString(_ SeenReferences) string {
 func (v UInt16Value) ToInt() int {
 	return int(v)
 }
-func (v UInt16Value) Negate() NumberValue {
+func (v UInt16Value) Negate(_ *Interpreter) NumberValue {
 	panic(errors.NewUnreachableError())
 }
 
-func (v UInt16Value) Plus(other NumberValue) NumberValue {
+func (v UInt16Value) Plus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -6990,7 +8107,7 @@ func (v UInt16Value) Plus(other NumberValue) NumberValue {
 	return sum
 }
 
-func (v UInt16Value) SaturatingPlus(other NumberValue) NumberValue {
+func (v UInt16Value) SaturatingPlus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7008,7 +8125,7 @@ func (v UInt16Value) SaturatingPlus(other NumberValue) NumberValue {
 	return sum
 }
 
-func (v UInt16Value) Minus(other NumberValue) NumberValue {
+func (v UInt16Value) Minus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7027,7 +8144,7 @@ func (v UInt16Value) Minus(other NumberValue) NumberValue {
 	return diff
 }
 
-func (v UInt16Value) SaturatingMinus(other NumberValue) NumberValue {
+func (v UInt16Value) SaturatingMinus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7046,7 +8163,7 @@ func (v UInt16Value) SaturatingMinus(other NumberValue) NumberValue {
 	return diff
 }
 
-func (v UInt16Value) Mod(other NumberValue) NumberValue {
+func (v UInt16Value) Mod(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7062,7 +8179,7 @@ func (v UInt16Value) Mod(other NumberValue) NumberValue {
 	return v % o
 }
 
-func (v UInt16Value) Mul(other NumberValue) NumberValue {
+func (v UInt16Value) Mul(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7079,7 +8196,7 @@ func (v UInt16Value) Mul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v UInt16Value) SaturatingMul(other NumberValue) NumberValue {
+func (v UInt16Value) SaturatingMul(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7096,7 +8213,7 @@ func (v UInt16Value) SaturatingMul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v UInt16Value) Div(other NumberValue) NumberValue {
+func (v UInt16Value) Div(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7112,7 +8229,7 @@ func (v UInt16Value) Div(other NumberValue) NumberValue {
 	return v / o
 }
 
-func (v UInt16Value) SaturatingDiv(other NumberValue) NumberValue {
+func (v UInt16Value) SaturatingDiv(interpreter *Interpreter, other NumberValue) NumberValue {
 	defer func() {
 		r := recover()
 		if _, ok := r.(InvalidOperandsError); ok {
@@ -7124,7 +8241,7 @@ func (v UInt16Value) SaturatingDiv(other NumberValue) NumberValue {
 		}
 	}()
 
-	return v.Div(other)
+	return v.Div(interpreter, other)
 }
 
 func (v UInt16Value) Less(other NumberValue) BoolValue {
@@ -7225,7 +8342,7 @@ func ConvertUInt16(value Value) UInt16Value {
 	return UInt16Value(res)
 }
 
-func (v UInt16Value) BitwiseOr(other IntegerValue) IntegerValue {
+func (v UInt16Value) BitwiseOr(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7238,7 +8355,7 @@ func (v UInt16Value) BitwiseOr(other IntegerValue) IntegerValue {
 	return v | o
 }
 
-func (v UInt16Value) BitwiseXor(other IntegerValue) IntegerValue {
+func (v UInt16Value) BitwiseXor(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7250,7 +8367,7 @@ func (v UInt16Value) BitwiseXor(other IntegerValue) IntegerValue {
 	return v ^ o
 }
 
-func (v UInt16Value) BitwiseAnd(other IntegerValue) IntegerValue {
+func (v UInt16Value) BitwiseAnd(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7263,7 +8380,7 @@ func (v UInt16Value) BitwiseAnd(other IntegerValue) IntegerValue {
 	return v & o
 }
 
-func (v UInt16Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
+func (v UInt16Value) BitwiseLeftShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7276,7 +8393,7 @@ func (v UInt16Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
 	return v << o
 }
 
-func (v UInt16Value) BitwiseRightShift(other IntegerValue) IntegerValue {
+func (v UInt16Value) BitwiseRightShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7412,11 +8529,11 @@ func (v UInt32Value) ToInt() int {
 	return int(v)
 }
 
-func (v UInt32Value) Negate() NumberValue {
+func (v UInt32Value) Negate(_ *Interpreter) NumberValue {
 	panic(errors.NewUnreachableError())
 }
 
-func (v UInt32Value) Plus(other NumberValue) NumberValue {
+func (v UInt32Value) Plus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7435,7 +8552,7 @@ func (v UInt32Value) Plus(other NumberValue) NumberValue {
 	return sum
 }
 
-func (v UInt32Value) SaturatingPlus(other NumberValue) NumberValue {
+func (v UInt32Value) SaturatingPlus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7453,7 +8570,7 @@ func (v UInt32Value) SaturatingPlus(other NumberValue) NumberValue {
 	return sum
 }
 
-func (v UInt32Value) Minus(other NumberValue) NumberValue {
+func (v UInt32Value) Minus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7472,7 +8589,7 @@ func (v UInt32Value) Minus(other NumberValue) NumberValue {
 	return diff
 }
 
-func (v UInt32Value) SaturatingMinus(other NumberValue) NumberValue {
+func (v UInt32Value) SaturatingMinus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7491,7 +8608,7 @@ func (v UInt32Value) SaturatingMinus(other NumberValue) NumberValue {
 	return diff
 }
 
-func (v UInt32Value) Mod(other NumberValue) NumberValue {
+func (v UInt32Value) Mod(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7507,7 +8624,7 @@ func (v UInt32Value) Mod(other NumberValue) NumberValue {
 	return v % o
 }
 
-func (v UInt32Value) Mul(other NumberValue) NumberValue {
+func (v UInt32Value) Mul(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7523,7 +8640,7 @@ func (v UInt32Value) Mul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v UInt32Value) SaturatingMul(other NumberValue) NumberValue {
+func (v UInt32Value) SaturatingMul(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7540,7 +8657,7 @@ func (v UInt32Value) SaturatingMul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v UInt32Value) Div(other NumberValue) NumberValue {
+func (v UInt32Value) Div(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7556,7 +8673,7 @@ func (v UInt32Value) Div(other NumberValue) NumberValue {
 	return v / o
 }
 
-func (v UInt32Value) SaturatingDiv(other NumberValue) NumberValue {
+func (v UInt32Value) SaturatingDiv(interpreter *Interpreter, other NumberValue) NumberValue {
 	defer func() {
 		r := recover()
 		if _, ok := r.(InvalidOperandsError); ok {
@@ -7568,7 +8685,7 @@ func (v UInt32Value) SaturatingDiv(other NumberValue) NumberValue {
 		}
 	}()
 
-	return v.Div(other)
+	return v.Div(interpreter, other)
 }
 
 func (v UInt32Value) Less(other NumberValue) BoolValue {
@@ -7669,7 +8786,7 @@ func ConvertUInt32(value Value) UInt32Value {
 	return UInt32Value(res)
 }
 
-func (v UInt32Value) BitwiseOr(other IntegerValue) IntegerValue {
+func (v UInt32Value) BitwiseOr(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7682,7 +8799,7 @@ func (v UInt32Value) BitwiseOr(other IntegerValue) IntegerValue {
 	return v | o
 }
 
-func (v UInt32Value) BitwiseXor(other IntegerValue) IntegerValue {
+func (v UInt32Value) BitwiseXor(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7694,7 +8811,7 @@ func (v UInt32Value) BitwiseXor(other IntegerValue) IntegerValue {
 	return v ^ o
 }
 
-func (v UInt32Value) BitwiseAnd(other IntegerValue) IntegerValue {
+func (v UInt32Value) BitwiseAnd(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7707,7 +8824,7 @@ func (v UInt32Value) BitwiseAnd(other IntegerValue) IntegerValue {
 	return v & o
 }
 
-func (v UInt32Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
+func (v UInt32Value) BitwiseLeftShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7720,7 +8837,7 @@ func (v UInt32Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
 	return v << o
 }
 
-func (v UInt32Value) BitwiseRightShift(other IntegerValue) IntegerValue {
+func (v UInt32Value) BitwiseRightShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7877,7 +8994,7 @@ func (v UInt64Value) ToBigInt() *big.Int {
 	return new(big.Int).SetUint64(uint64(v))
 }
 
-func (v UInt64Value) Negate() NumberValue {
+func (v UInt64Value) Negate(_ *Interpreter) NumberValue {
 	panic(errors.NewUnreachableError())
 }
 
@@ -7890,7 +9007,7 @@ func safeAddUint64(a, b uint64) uint64 {
 	return sum
 }
 
-func (v UInt64Value) Plus(other NumberValue) NumberValue {
+func (v UInt64Value) Plus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7903,7 +9020,7 @@ func (v UInt64Value) Plus(other NumberValue) NumberValue {
 	return UInt64Value(safeAddUint64(uint64(v), uint64(o)))
 }
 
-func (v UInt64Value) SaturatingPlus(other NumberValue) NumberValue {
+func (v UInt64Value) SaturatingPlus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7922,7 +9039,7 @@ func (v UInt64Value) SaturatingPlus(other NumberValue) NumberValue {
 	return sum
 }
 
-func (v UInt64Value) Minus(other NumberValue) NumberValue {
+func (v UInt64Value) Minus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7941,7 +9058,7 @@ func (v UInt64Value) Minus(other NumberValue) NumberValue {
 	return diff
 }
 
-func (v UInt64Value) SaturatingMinus(other NumberValue) NumberValue {
+func (v UInt64Value) SaturatingMinus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7960,7 +9077,7 @@ func (v UInt64Value) SaturatingMinus(other NumberValue) NumberValue {
 	return diff
 }
 
-func (v UInt64Value) Mod(other NumberValue) NumberValue {
+func (v UInt64Value) Mod(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7976,7 +9093,7 @@ func (v UInt64Value) Mod(other NumberValue) NumberValue {
 	return v % o
 }
 
-func (v UInt64Value) Mul(other NumberValue) NumberValue {
+func (v UInt64Value) Mul(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -7992,7 +9109,7 @@ func (v UInt64Value) Mul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v UInt64Value) SaturatingMul(other NumberValue) NumberValue {
+func (v UInt64Value) SaturatingMul(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8009,7 +9126,7 @@ func (v UInt64Value) SaturatingMul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v UInt64Value) Div(other NumberValue) NumberValue {
+func (v UInt64Value) Div(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8025,7 +9142,7 @@ func (v UInt64Value) Div(other NumberValue) NumberValue {
 	return v / o
 }
 
-func (v UInt64Value) SaturatingDiv(other NumberValue) NumberValue {
+func (v UInt64Value) SaturatingDiv(interpreter *Interpreter, other NumberValue) NumberValue {
 	defer func() {
 		r := recover()
 		if _, ok := r.(InvalidOperandsError); ok {
@@ -8037,7 +9154,7 @@ func (v UInt64Value) SaturatingDiv(other NumberValue) NumberValue {
 		}
 	}()
 
-	return v.Div(other)
+	return v.Div(interpreter, other)
 }
 
 func (v UInt64Value) Less(other NumberValue) BoolValue {
@@ -8136,7 +9253,7 @@ func ConvertUInt64(value Value) UInt64Value {
 	return UInt64Value(res)
 }
 
-func (v UInt64Value) BitwiseOr(other IntegerValue) IntegerValue {
+func (v UInt64Value) BitwiseOr(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8149,7 +9266,7 @@ func (v UInt64Value) BitwiseOr(other IntegerValue) IntegerValue {
 	return v | o
 }
 
-func (v UInt64Value) BitwiseXor(other IntegerValue) IntegerValue {
+func (v UInt64Value) BitwiseXor(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8161,7 +9278,7 @@ func (v UInt64Value) BitwiseXor(other IntegerValue) IntegerValue {
 	return v ^ o
 }
 
-func (v UInt64Value) BitwiseAnd(other IntegerValue) IntegerValue {
+func (v UInt64Value) BitwiseAnd(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8174,7 +9291,7 @@ func (v UInt64Value) BitwiseAnd(other IntegerValue) IntegerValue {
 	return v & o
 }
 
-func (v UInt64Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
+func (v UInt64Value) BitwiseLeftShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8187,7 +9304,7 @@ func (v UInt64Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
 	return v << o
 }
 
-func (v UInt64Value) BitwiseRightShift(other IntegerValue) IntegerValue {
+func (v UInt64Value) BitwiseRightShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8340,11 +9457,11 @@ func (v UInt128Value) RecursiveString(_ SeenReferences) string {
 	return v.String()
 }
 
-func (v UInt128Value) Negate() NumberValue {
+func (v UInt128Value) Negate(_ *Interpreter) NumberValue {
 	panic(errors.NewUnreachableError())
 }
 
-func (v UInt128Value) Plus(other NumberValue) NumberValue {
+func (v UInt128Value) Plus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8354,6 +9471,8 @@ func (v UInt128Value) Plus(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewPlusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	sum := new(big.Int)
 	sum.Add(v.BigInt, o.BigInt)
 	// Given that this value is backed by an arbitrary size integer,
@@ -8372,7 +9491,7 @@ func (v UInt128Value) Plus(other NumberValue) NumberValue {
 	return UInt128Value{sum}
 }
 
-func (v UInt128Value) SaturatingPlus(other NumberValue) NumberValue {
+func (v UInt128Value) SaturatingPlus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8382,6 +9501,8 @@ func (v UInt128Value) SaturatingPlus(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewPlusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	sum := new(big.Int)
 	sum.Add(v.BigInt, o.BigInt)
 	// Given that this value is backed by an arbitrary size integer,
@@ -8400,7 +9521,7 @@ func (v UInt128Value) SaturatingPlus(other NumberValue) NumberValue {
 	return UInt128Value{sum}
 }
 
-func (v UInt128Value) Minus(other NumberValue) NumberValue {
+func (v UInt128Value) Minus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8410,6 +9531,8 @@ func (v UInt128Value) Minus(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewMinusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	diff := new(big.Int)
 	diff.Sub(v.BigInt, o.BigInt)
 	// Given that this value is backed by an arbitrary size integer,
@@ -8428,7 +9551,7 @@ func (v UInt128Value) Minus(other NumberValue) NumberValue {
 	return UInt128Value{diff}
 }
 
-func (v UInt128Value) SaturatingMinus(other NumberValue) NumberValue {
+func (v UInt128Value) SaturatingMinus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8438,6 +9561,8 @@ func (v UInt128Value) SaturatingMinus(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewMinusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	diff := new(big.Int)
 	diff.Sub(v.BigInt, o.BigInt)
 	// Given that this value is backed by an arbitrary size integer,
@@ -8456,7 +9581,7 @@ func (v UInt128Value) SaturatingMinus(other NumberValue) NumberValue {
 	return UInt128Value{diff}
 }
 
-func (v UInt128Value) Mod(other NumberValue) NumberValue {
+func (v UInt128Value) Mod(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8466,6 +9591,8 @@ func (v UInt128Value) Mod(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewModBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	if o.BigInt.Cmp(res) == 0 {
 		panic(DivisionByZeroError{})
@@ -8474,7 +9601,7 @@ func (v UInt128Value) Mod(other NumberValue) NumberValue {
 	return UInt128Value{res}
 }
 
-func (v UInt128Value) Mul(other NumberValue) NumberValue {
+func (v UInt128Value) Mul(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8484,6 +9611,8 @@ func (v UInt128Value) Mul(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewMulBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Mul(v.BigInt, o.BigInt)
 	if res.Cmp(sema.UInt128TypeMaxIntBig) > 0 {
@@ -8492,7 +9621,7 @@ func (v UInt128Value) Mul(other NumberValue) NumberValue {
 	return UInt128Value{res}
 }
 
-func (v UInt128Value) SaturatingMul(other NumberValue) NumberValue {
+func (v UInt128Value) SaturatingMul(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8502,6 +9631,8 @@ func (v UInt128Value) SaturatingMul(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewMulBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Mul(v.BigInt, o.BigInt)
 	if res.Cmp(sema.UInt128TypeMaxIntBig) > 0 {
@@ -8510,7 +9641,7 @@ func (v UInt128Value) SaturatingMul(other NumberValue) NumberValue {
 	return UInt128Value{res}
 }
 
-func (v UInt128Value) Div(other NumberValue) NumberValue {
+func (v UInt128Value) Div(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8520,6 +9651,8 @@ func (v UInt128Value) Div(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewDivBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	if o.BigInt.Cmp(res) == 0 {
 		panic(DivisionByZeroError{})
@@ -8528,7 +9661,7 @@ func (v UInt128Value) Div(other NumberValue) NumberValue {
 	return UInt128Value{res}
 }
 
-func (v UInt128Value) SaturatingDiv(other NumberValue) NumberValue {
+func (v UInt128Value) SaturatingDiv(interpreter *Interpreter, other NumberValue) NumberValue {
 	defer func() {
 		r := recover()
 		if _, ok := r.(InvalidOperandsError); ok {
@@ -8540,7 +9673,7 @@ func (v UInt128Value) SaturatingDiv(other NumberValue) NumberValue {
 		}
 	}()
 
-	return v.Div(other)
+	return v.Div(interpreter, other)
 }
 
 func (v UInt128Value) Less(other NumberValue) BoolValue {
@@ -8650,7 +9783,7 @@ func ConvertUInt128(value Value) UInt128Value {
 	return NewUInt128ValueFromBigInt(v)
 }
 
-func (v UInt128Value) BitwiseOr(other IntegerValue) IntegerValue {
+func (v UInt128Value) BitwiseOr(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8660,12 +9793,14 @@ func (v UInt128Value) BitwiseOr(other IntegerValue) IntegerValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewBitwiseBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Or(v.BigInt, o.BigInt)
 	return UInt128Value{res}
 }
 
-func (v UInt128Value) BitwiseXor(other IntegerValue) IntegerValue {
+func (v UInt128Value) BitwiseXor(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8675,12 +9810,14 @@ func (v UInt128Value) BitwiseXor(other IntegerValue) IntegerValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewBitwiseBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Xor(v.BigInt, o.BigInt)
 	return UInt128Value{res}
 }
 
-func (v UInt128Value) BitwiseAnd(other IntegerValue) IntegerValue {
+func (v UInt128Value) BitwiseAnd(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8690,12 +9827,14 @@ func (v UInt128Value) BitwiseAnd(other IntegerValue) IntegerValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewBitwiseBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.And(v.BigInt, o.BigInt)
 	return UInt128Value{res}
 }
 
-func (v UInt128Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
+func (v UInt128Value) BitwiseLeftShift(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8705,18 +9844,21 @@ func (v UInt128Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
 		})
 	}
 
-	res := new(big.Int)
 	if o.BigInt.Sign() < 0 {
 		panic(UnderflowError{})
 	}
 	if !o.BigInt.IsUint64() {
 		panic(OverflowError{})
 	}
+
+	common.UseMemory(interpreter, common.NewBitwiseLeftShiftBigIntMemoryUsage(v.BigInt, o.BigInt.Uint64()))
+
+	res := new(big.Int)
 	res.Lsh(v.BigInt, uint(o.BigInt.Uint64()))
 	return UInt128Value{res}
 }
 
-func (v UInt128Value) BitwiseRightShift(other IntegerValue) IntegerValue {
+func (v UInt128Value) BitwiseRightShift(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt128Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8726,13 +9868,16 @@ func (v UInt128Value) BitwiseRightShift(other IntegerValue) IntegerValue {
 		})
 	}
 
-	res := new(big.Int)
 	if o.BigInt.Sign() < 0 {
 		panic(UnderflowError{})
 	}
 	if !o.BigInt.IsUint64() {
 		panic(OverflowError{})
 	}
+
+	common.UseMemory(interpreter, common.NewBitwiseRightShiftBigIntMemoryUsage(v.BigInt))
+
+	res := new(big.Int)
 	res.Rsh(v.BigInt, uint(o.BigInt.Uint64()))
 	return UInt128Value{res}
 }
@@ -8876,11 +10021,11 @@ func (v UInt256Value) RecursiveString(_ SeenReferences) string {
 	return v.String()
 }
 
-func (v UInt256Value) Negate() NumberValue {
+func (v UInt256Value) Negate(_ *Interpreter) NumberValue {
 	panic(errors.NewUnreachableError())
 }
 
-func (v UInt256Value) Plus(other NumberValue) NumberValue {
+func (v UInt256Value) Plus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8890,6 +10035,8 @@ func (v UInt256Value) Plus(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewPlusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	sum := new(big.Int)
 	sum.Add(v.BigInt, o.BigInt)
 	// Given that this value is backed by an arbitrary size integer,
@@ -8908,7 +10055,7 @@ func (v UInt256Value) Plus(other NumberValue) NumberValue {
 	return UInt256Value{sum}
 }
 
-func (v UInt256Value) SaturatingPlus(other NumberValue) NumberValue {
+func (v UInt256Value) SaturatingPlus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8918,6 +10065,8 @@ func (v UInt256Value) SaturatingPlus(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewPlusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	sum := new(big.Int)
 	sum.Add(v.BigInt, o.BigInt)
 	// Given that this value is backed by an arbitrary size integer,
@@ -8936,7 +10085,7 @@ func (v UInt256Value) SaturatingPlus(other NumberValue) NumberValue {
 	return UInt256Value{sum}
 }
 
-func (v UInt256Value) Minus(other NumberValue) NumberValue {
+func (v UInt256Value) Minus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8946,6 +10095,8 @@ func (v UInt256Value) Minus(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewMinusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	diff := new(big.Int)
 	diff.Sub(v.BigInt, o.BigInt)
 	// Given that this value is backed by an arbitrary size integer,
@@ -8964,7 +10115,7 @@ func (v UInt256Value) Minus(other NumberValue) NumberValue {
 	return UInt256Value{diff}
 }
 
-func (v UInt256Value) SaturatingMinus(other NumberValue) NumberValue {
+func (v UInt256Value) SaturatingMinus(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -8974,6 +10125,8 @@ func (v UInt256Value) SaturatingMinus(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewMinusBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	diff := new(big.Int)
 	diff.Sub(v.BigInt, o.BigInt)
 	// Given that this value is backed by an arbitrary size integer,
@@ -8992,7 +10145,7 @@ func (v UInt256Value) SaturatingMinus(other NumberValue) NumberValue {
 	return UInt256Value{diff}
 }
 
-func (v UInt256Value) Mod(other NumberValue) NumberValue {
+func (v UInt256Value) Mod(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9002,6 +10155,8 @@ func (v UInt256Value) Mod(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewModBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	if o.BigInt.Cmp(res) == 0 {
 		panic(DivisionByZeroError{})
@@ -9010,7 +10165,7 @@ func (v UInt256Value) Mod(other NumberValue) NumberValue {
 	return UInt256Value{res}
 }
 
-func (v UInt256Value) Mul(other NumberValue) NumberValue {
+func (v UInt256Value) Mul(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9020,6 +10175,8 @@ func (v UInt256Value) Mul(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewMulBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Mul(v.BigInt, o.BigInt)
 	if res.Cmp(sema.UInt256TypeMaxIntBig) > 0 {
@@ -9028,7 +10185,7 @@ func (v UInt256Value) Mul(other NumberValue) NumberValue {
 	return UInt256Value{res}
 }
 
-func (v UInt256Value) SaturatingMul(other NumberValue) NumberValue {
+func (v UInt256Value) SaturatingMul(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9038,6 +10195,8 @@ func (v UInt256Value) SaturatingMul(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewMulBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Mul(v.BigInt, o.BigInt)
 	if res.Cmp(sema.UInt256TypeMaxIntBig) > 0 {
@@ -9046,7 +10205,7 @@ func (v UInt256Value) SaturatingMul(other NumberValue) NumberValue {
 	return UInt256Value{res}
 }
 
-func (v UInt256Value) Div(other NumberValue) NumberValue {
+func (v UInt256Value) Div(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UInt256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9056,6 +10215,8 @@ func (v UInt256Value) Div(other NumberValue) NumberValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewDivBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	if o.BigInt.Cmp(res) == 0 {
 		panic(DivisionByZeroError{})
@@ -9064,7 +10225,7 @@ func (v UInt256Value) Div(other NumberValue) NumberValue {
 	return UInt256Value{res}
 }
 
-func (v UInt256Value) SaturatingDiv(other NumberValue) NumberValue {
+func (v UInt256Value) SaturatingDiv(interpreter *Interpreter, other NumberValue) NumberValue {
 	defer func() {
 		r := recover()
 		if _, ok := r.(InvalidOperandsError); ok {
@@ -9076,7 +10237,7 @@ func (v UInt256Value) SaturatingDiv(other NumberValue) NumberValue {
 		}
 	}()
 
-	return v.Div(other)
+	return v.Div(interpreter, other)
 }
 
 func (v UInt256Value) Less(other NumberValue) BoolValue {
@@ -9186,7 +10347,7 @@ func ConvertUInt256(value Value) UInt256Value {
 	return NewUInt256ValueFromBigInt(v)
 }
 
-func (v UInt256Value) BitwiseOr(other IntegerValue) IntegerValue {
+func (v UInt256Value) BitwiseOr(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9196,12 +10357,14 @@ func (v UInt256Value) BitwiseOr(other IntegerValue) IntegerValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewBitwiseBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Or(v.BigInt, o.BigInt)
 	return UInt256Value{res}
 }
 
-func (v UInt256Value) BitwiseXor(other IntegerValue) IntegerValue {
+func (v UInt256Value) BitwiseXor(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9211,12 +10374,14 @@ func (v UInt256Value) BitwiseXor(other IntegerValue) IntegerValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewBitwiseBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.Xor(v.BigInt, o.BigInt)
 	return UInt256Value{res}
 }
 
-func (v UInt256Value) BitwiseAnd(other IntegerValue) IntegerValue {
+func (v UInt256Value) BitwiseAnd(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9226,12 +10391,14 @@ func (v UInt256Value) BitwiseAnd(other IntegerValue) IntegerValue {
 		})
 	}
 
+	common.UseMemory(interpreter, common.NewBitwiseBigIntMemoryUsage(v.BigInt, o.BigInt))
+
 	res := new(big.Int)
 	res.And(v.BigInt, o.BigInt)
 	return UInt256Value{res}
 }
 
-func (v UInt256Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
+func (v UInt256Value) BitwiseLeftShift(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9241,18 +10408,21 @@ func (v UInt256Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
 		})
 	}
 
-	res := new(big.Int)
 	if o.BigInt.Sign() < 0 {
 		panic(UnderflowError{})
 	}
 	if !o.BigInt.IsUint64() {
 		panic(OverflowError{})
 	}
+
+	common.UseMemory(interpreter, common.NewBitwiseLeftShiftBigIntMemoryUsage(v.BigInt, o.BigInt.Uint64()))
+
+	res := new(big.Int)
 	res.Lsh(v.BigInt, uint(o.BigInt.Uint64()))
 	return UInt256Value{res}
 }
 
-func (v UInt256Value) BitwiseRightShift(other IntegerValue) IntegerValue {
+func (v UInt256Value) BitwiseRightShift(interpreter *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(UInt256Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9262,13 +10432,16 @@ func (v UInt256Value) BitwiseRightShift(other IntegerValue) IntegerValue {
 		})
 	}
 
-	res := new(big.Int)
 	if o.BigInt.Sign() < 0 {
 		panic(UnderflowError{})
 	}
 	if !o.BigInt.IsUint64() {
 		panic(OverflowError{})
 	}
+
+	common.UseMemory(interpreter, common.NewBitwiseRightShiftBigIntMemoryUsage(v.BigInt))
+
+	res := new(big.Int)
 	res.Rsh(v.BigInt, uint(o.BigInt.Uint64()))
 	return UInt256Value{res}
 }
@@ -9393,11 +10566,11 @@ func (v Word8Value) ToInt() int {
 	return int(v)
 }
 
-func (v Word8Value) Negate() NumberValue {
+func (v Word8Value) Negate(_ *Interpreter) NumberValue {
 	panic(errors.NewUnreachableError())
 }
 
-func (v Word8Value) Plus(other NumberValue) NumberValue {
+func (v Word8Value) Plus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Word8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9410,11 +10583,11 @@ func (v Word8Value) Plus(other NumberValue) NumberValue {
 	return v + o
 }
 
-func (v Word8Value) SaturatingPlus(_ NumberValue) NumberValue {
+func (v Word8Value) SaturatingPlus(_ *Interpreter, _ NumberValue) NumberValue {
 	panic(errors.UnreachableError{})
 }
 
-func (v Word8Value) Minus(other NumberValue) NumberValue {
+func (v Word8Value) Minus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Word8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9427,11 +10600,11 @@ func (v Word8Value) Minus(other NumberValue) NumberValue {
 	return v - o
 }
 
-func (v Word8Value) SaturatingMinus(_ NumberValue) NumberValue {
+func (v Word8Value) SaturatingMinus(_ *Interpreter, _ NumberValue) NumberValue {
 	panic(errors.UnreachableError{})
 }
 
-func (v Word8Value) Mod(other NumberValue) NumberValue {
+func (v Word8Value) Mod(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Word8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9447,7 +10620,7 @@ func (v Word8Value) Mod(other NumberValue) NumberValue {
 	return v % o
 }
 
-func (v Word8Value) Mul(other NumberValue) NumberValue {
+func (v Word8Value) Mul(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Word8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9460,11 +10633,11 @@ func (v Word8Value) Mul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v Word8Value) SaturatingMul(_ NumberValue) NumberValue {
+func (v Word8Value) SaturatingMul(_ *Interpreter, _ NumberValue) NumberValue {
 	panic(errors.UnreachableError{})
 }
 
-func (v Word8Value) Div(other NumberValue) NumberValue {
+func (v Word8Value) Div(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Word8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9480,7 +10653,7 @@ func (v Word8Value) Div(other NumberValue) NumberValue {
 	return v / o
 }
 
-func (v Word8Value) SaturatingDiv(_ NumberValue) NumberValue {
+func (v Word8Value) SaturatingDiv(_ *Interpreter, _ NumberValue) NumberValue {
 	panic(errors.UnreachableError{})
 }
 
@@ -9557,7 +10730,7 @@ func ConvertWord8(value Value) Word8Value {
 	return Word8Value(ConvertUInt8(value))
 }
 
-func (v Word8Value) BitwiseOr(other IntegerValue) IntegerValue {
+func (v Word8Value) BitwiseOr(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Word8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9570,7 +10743,7 @@ func (v Word8Value) BitwiseOr(other IntegerValue) IntegerValue {
 	return v | o
 }
 
-func (v Word8Value) BitwiseXor(other IntegerValue) IntegerValue {
+func (v Word8Value) BitwiseXor(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Word8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9582,7 +10755,7 @@ func (v Word8Value) BitwiseXor(other IntegerValue) IntegerValue {
 	return v ^ o
 }
 
-func (v Word8Value) BitwiseAnd(other IntegerValue) IntegerValue {
+func (v Word8Value) BitwiseAnd(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Word8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9595,7 +10768,7 @@ func (v Word8Value) BitwiseAnd(other IntegerValue) IntegerValue {
 	return v & o
 }
 
-func (v Word8Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
+func (v Word8Value) BitwiseLeftShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Word8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9608,7 +10781,7 @@ func (v Word8Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
 	return v << o
 }
 
-func (v Word8Value) BitwiseRightShift(other IntegerValue) IntegerValue {
+func (v Word8Value) BitwiseRightShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Word8Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9741,11 +10914,11 @@ func (v Word16Value) RecursiveString(_ SeenReferences) string {
 func (v Word16Value) ToInt() int {
 	return int(v)
 }
-func (v Word16Value) Negate() NumberValue {
+func (v Word16Value) Negate(_ *Interpreter) NumberValue {
 	panic(errors.NewUnreachableError())
 }
 
-func (v Word16Value) Plus(other NumberValue) NumberValue {
+func (v Word16Value) Plus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Word16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9758,11 +10931,11 @@ func (v Word16Value) Plus(other NumberValue) NumberValue {
 	return v + o
 }
 
-func (v Word16Value) SaturatingPlus(_ NumberValue) NumberValue {
+func (v Word16Value) SaturatingPlus(_ *Interpreter, _ NumberValue) NumberValue {
 	panic(errors.UnreachableError{})
 }
 
-func (v Word16Value) Minus(other NumberValue) NumberValue {
+func (v Word16Value) Minus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Word16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9775,11 +10948,11 @@ func (v Word16Value) Minus(other NumberValue) NumberValue {
 	return v - o
 }
 
-func (v Word16Value) SaturatingMinus(_ NumberValue) NumberValue {
+func (v Word16Value) SaturatingMinus(_ *Interpreter, _ NumberValue) NumberValue {
 	panic(errors.UnreachableError{})
 }
 
-func (v Word16Value) Mod(other NumberValue) NumberValue {
+func (v Word16Value) Mod(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Word16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9795,7 +10968,7 @@ func (v Word16Value) Mod(other NumberValue) NumberValue {
 	return v % o
 }
 
-func (v Word16Value) Mul(other NumberValue) NumberValue {
+func (v Word16Value) Mul(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Word16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9808,11 +10981,11 @@ func (v Word16Value) Mul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v Word16Value) SaturatingMul(_ NumberValue) NumberValue {
+func (v Word16Value) SaturatingMul(_ *Interpreter, _ NumberValue) NumberValue {
 	panic(errors.UnreachableError{})
 }
 
-func (v Word16Value) Div(other NumberValue) NumberValue {
+func (v Word16Value) Div(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Word16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9828,7 +11001,7 @@ func (v Word16Value) Div(other NumberValue) NumberValue {
 	return v / o
 }
 
-func (v Word16Value) SaturatingDiv(_ NumberValue) NumberValue {
+func (v Word16Value) SaturatingDiv(_ *Interpreter, _ NumberValue) NumberValue {
 	panic(errors.UnreachableError{})
 }
 
@@ -9905,7 +11078,7 @@ func ConvertWord16(value Value) Word16Value {
 	return Word16Value(ConvertUInt16(value))
 }
 
-func (v Word16Value) BitwiseOr(other IntegerValue) IntegerValue {
+func (v Word16Value) BitwiseOr(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Word16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9918,7 +11091,7 @@ func (v Word16Value) BitwiseOr(other IntegerValue) IntegerValue {
 	return v | o
 }
 
-func (v Word16Value) BitwiseXor(other IntegerValue) IntegerValue {
+func (v Word16Value) BitwiseXor(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Word16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9930,7 +11103,7 @@ func (v Word16Value) BitwiseXor(other IntegerValue) IntegerValue {
 	return v ^ o
 }
 
-func (v Word16Value) BitwiseAnd(other IntegerValue) IntegerValue {
+func (v Word16Value) BitwiseAnd(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Word16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9943,7 +11116,7 @@ func (v Word16Value) BitwiseAnd(other IntegerValue) IntegerValue {
 	return v & o
 }
 
-func (v Word16Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
+func (v Word16Value) BitwiseLeftShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Word16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -9956,7 +11129,7 @@ func (v Word16Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
 	return v << o
 }
 
-func (v Word16Value) BitwiseRightShift(other IntegerValue) IntegerValue {
+func (v Word16Value) BitwiseRightShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Word16Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10092,11 +11265,11 @@ func (v Word32Value) ToInt() int {
 	return int(v)
 }
 
-func (v Word32Value) Negate() NumberValue {
+func (v Word32Value) Negate(_ *Interpreter) NumberValue {
 	panic(errors.NewUnreachableError())
 }
 
-func (v Word32Value) Plus(other NumberValue) NumberValue {
+func (v Word32Value) Plus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Word32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10109,11 +11282,11 @@ func (v Word32Value) Plus(other NumberValue) NumberValue {
 	return v + o
 }
 
-func (v Word32Value) SaturatingPlus(_ NumberValue) NumberValue {
+func (v Word32Value) SaturatingPlus(_ *Interpreter, _ NumberValue) NumberValue {
 	panic(errors.UnreachableError{})
 }
 
-func (v Word32Value) Minus(other NumberValue) NumberValue {
+func (v Word32Value) Minus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Word32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10126,11 +11299,11 @@ func (v Word32Value) Minus(other NumberValue) NumberValue {
 	return v - o
 }
 
-func (v Word32Value) SaturatingMinus(_ NumberValue) NumberValue {
+func (v Word32Value) SaturatingMinus(_ *Interpreter, _ NumberValue) NumberValue {
 	panic(errors.UnreachableError{})
 }
 
-func (v Word32Value) Mod(other NumberValue) NumberValue {
+func (v Word32Value) Mod(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Word32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10146,7 +11319,7 @@ func (v Word32Value) Mod(other NumberValue) NumberValue {
 	return v % o
 }
 
-func (v Word32Value) Mul(other NumberValue) NumberValue {
+func (v Word32Value) Mul(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Word32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10159,11 +11332,11 @@ func (v Word32Value) Mul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v Word32Value) SaturatingMul(_ NumberValue) NumberValue {
+func (v Word32Value) SaturatingMul(_ *Interpreter, _ NumberValue) NumberValue {
 	panic(errors.UnreachableError{})
 }
 
-func (v Word32Value) Div(other NumberValue) NumberValue {
+func (v Word32Value) Div(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Word32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10179,7 +11352,7 @@ func (v Word32Value) Div(other NumberValue) NumberValue {
 	return v / o
 }
 
-func (v Word32Value) SaturatingDiv(_ NumberValue) NumberValue {
+func (v Word32Value) SaturatingDiv(_ *Interpreter, _ NumberValue) NumberValue {
 	panic(errors.UnreachableError{})
 }
 
@@ -10256,7 +11429,7 @@ func ConvertWord32(value Value) Word32Value {
 	return Word32Value(ConvertUInt32(value))
 }
 
-func (v Word32Value) BitwiseOr(other IntegerValue) IntegerValue {
+func (v Word32Value) BitwiseOr(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Word32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10269,7 +11442,7 @@ func (v Word32Value) BitwiseOr(other IntegerValue) IntegerValue {
 	return v | o
 }
 
-func (v Word32Value) BitwiseXor(other IntegerValue) IntegerValue {
+func (v Word32Value) BitwiseXor(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Word32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10281,7 +11454,7 @@ func (v Word32Value) BitwiseXor(other IntegerValue) IntegerValue {
 	return v ^ o
 }
 
-func (v Word32Value) BitwiseAnd(other IntegerValue) IntegerValue {
+func (v Word32Value) BitwiseAnd(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Word32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10294,7 +11467,7 @@ func (v Word32Value) BitwiseAnd(other IntegerValue) IntegerValue {
 	return v & o
 }
 
-func (v Word32Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
+func (v Word32Value) BitwiseLeftShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Word32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10307,7 +11480,7 @@ func (v Word32Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
 	return v << o
 }
 
-func (v Word32Value) BitwiseRightShift(other IntegerValue) IntegerValue {
+func (v Word32Value) BitwiseRightShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Word32Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10464,11 +11637,11 @@ func (v Word64Value) ToBigInt() *big.Int {
 	return new(big.Int).SetUint64(uint64(v))
 }
 
-func (v Word64Value) Negate() NumberValue {
+func (v Word64Value) Negate(_ *Interpreter) NumberValue {
 	panic(errors.NewUnreachableError())
 }
 
-func (v Word64Value) Plus(other NumberValue) NumberValue {
+func (v Word64Value) Plus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Word64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10481,11 +11654,11 @@ func (v Word64Value) Plus(other NumberValue) NumberValue {
 	return v + o
 }
 
-func (v Word64Value) SaturatingPlus(_ NumberValue) NumberValue {
+func (v Word64Value) SaturatingPlus(_ *Interpreter, _ NumberValue) NumberValue {
 	panic(errors.UnreachableError{})
 }
 
-func (v Word64Value) Minus(other NumberValue) NumberValue {
+func (v Word64Value) Minus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Word64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10498,11 +11671,11 @@ func (v Word64Value) Minus(other NumberValue) NumberValue {
 	return v - o
 }
 
-func (v Word64Value) SaturatingMinus(_ NumberValue) NumberValue {
+func (v Word64Value) SaturatingMinus(_ *Interpreter, _ NumberValue) NumberValue {
 	panic(errors.UnreachableError{})
 }
 
-func (v Word64Value) Mod(other NumberValue) NumberValue {
+func (v Word64Value) Mod(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Word64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10518,7 +11691,7 @@ func (v Word64Value) Mod(other NumberValue) NumberValue {
 	return v % o
 }
 
-func (v Word64Value) Mul(other NumberValue) NumberValue {
+func (v Word64Value) Mul(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Word64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10531,11 +11704,11 @@ func (v Word64Value) Mul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v Word64Value) SaturatingMul(_ NumberValue) NumberValue {
+func (v Word64Value) SaturatingMul(_ *Interpreter, _ NumberValue) NumberValue {
 	panic(errors.UnreachableError{})
 }
 
-func (v Word64Value) Div(other NumberValue) NumberValue {
+func (v Word64Value) Div(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Word64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10551,7 +11724,7 @@ func (v Word64Value) Div(other NumberValue) NumberValue {
 	return v / o
 }
 
-func (v Word64Value) SaturatingDiv(_ NumberValue) NumberValue {
+func (v Word64Value) SaturatingDiv(_ *Interpreter, _ NumberValue) NumberValue {
 	panic(errors.UnreachableError{})
 }
 
@@ -10628,7 +11801,7 @@ func ConvertWord64(value Value) Word64Value {
 	return Word64Value(ConvertUInt64(value))
 }
 
-func (v Word64Value) BitwiseOr(other IntegerValue) IntegerValue {
+func (v Word64Value) BitwiseOr(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Word64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10641,7 +11814,7 @@ func (v Word64Value) BitwiseOr(other IntegerValue) IntegerValue {
 	return v | o
 }
 
-func (v Word64Value) BitwiseXor(other IntegerValue) IntegerValue {
+func (v Word64Value) BitwiseXor(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Word64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10653,7 +11826,7 @@ func (v Word64Value) BitwiseXor(other IntegerValue) IntegerValue {
 	return v ^ o
 }
 
-func (v Word64Value) BitwiseAnd(other IntegerValue) IntegerValue {
+func (v Word64Value) BitwiseAnd(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Word64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10666,7 +11839,7 @@ func (v Word64Value) BitwiseAnd(other IntegerValue) IntegerValue {
 	return v & o
 }
 
-func (v Word64Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
+func (v Word64Value) BitwiseLeftShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Word64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10679,7 +11852,7 @@ func (v Word64Value) BitwiseLeftShift(other IntegerValue) IntegerValue {
 	return v << o
 }
 
-func (v Word64Value) BitwiseRightShift(other IntegerValue) IntegerValue {
+func (v Word64Value) BitwiseRightShift(_ *Interpreter, other IntegerValue) IntegerValue {
 	o, ok := other.(Word64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10829,7 +12002,7 @@ func (v Fix64Value) ToInt() int {
 	return int(v / sema.Fix64Factor)
 }
 
-func (v Fix64Value) Negate() NumberValue {
+func (v Fix64Value) Negate(_ *Interpreter) NumberValue {
 	// INT32-C
 	if v == math.MinInt64 {
 		panic(OverflowError{})
@@ -10837,7 +12010,7 @@ func (v Fix64Value) Negate() NumberValue {
 	return -v
 }
 
-func (v Fix64Value) Plus(other NumberValue) NumberValue {
+func (v Fix64Value) Plus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Fix64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10850,7 +12023,7 @@ func (v Fix64Value) Plus(other NumberValue) NumberValue {
 	return Fix64Value(safeAddInt64(int64(v), int64(o)))
 }
 
-func (v Fix64Value) SaturatingPlus(other NumberValue) NumberValue {
+func (v Fix64Value) SaturatingPlus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Fix64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10869,7 +12042,7 @@ func (v Fix64Value) SaturatingPlus(other NumberValue) NumberValue {
 	return v + o
 }
 
-func (v Fix64Value) Minus(other NumberValue) NumberValue {
+func (v Fix64Value) Minus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Fix64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10888,7 +12061,7 @@ func (v Fix64Value) Minus(other NumberValue) NumberValue {
 	return v - o
 }
 
-func (v Fix64Value) SaturatingMinus(other NumberValue) NumberValue {
+func (v Fix64Value) SaturatingMinus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Fix64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10910,7 +12083,7 @@ func (v Fix64Value) SaturatingMinus(other NumberValue) NumberValue {
 var minInt64Big = big.NewInt(math.MinInt64)
 var maxInt64Big = big.NewInt(math.MaxInt64)
 
-func (v Fix64Value) Mul(other NumberValue) NumberValue {
+func (v Fix64Value) Mul(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Fix64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10923,6 +12096,8 @@ func (v Fix64Value) Mul(other NumberValue) NumberValue {
 	a := new(big.Int).SetInt64(int64(v))
 	b := new(big.Int).SetInt64(int64(o))
 
+	common.UseMemory(interpreter, common.NewMulBigIntMemoryUsage(a, b))
+
 	result := new(big.Int).Mul(a, b)
 	result.Div(result, sema.Fix64FactorBig)
 
@@ -10935,7 +12110,7 @@ func (v Fix64Value) Mul(other NumberValue) NumberValue {
 	return Fix64Value(result.Int64())
 }
 
-func (v Fix64Value) SaturatingMul(other NumberValue) NumberValue {
+func (v Fix64Value) SaturatingMul(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Fix64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10948,6 +12123,8 @@ func (v Fix64Value) SaturatingMul(other NumberValue) NumberValue {
 	a := new(big.Int).SetInt64(int64(v))
 	b := new(big.Int).SetInt64(int64(o))
 
+	common.UseMemory(interpreter, common.NewMulBigIntMemoryUsage(a, b))
+
 	result := new(big.Int).Mul(a, b)
 	result.Div(result, sema.Fix64FactorBig)
 
@@ -10960,7 +12137,7 @@ func (v Fix64Value) SaturatingMul(other NumberValue) NumberValue {
 	return Fix64Value(result.Int64())
 }
 
-func (v Fix64Value) Div(other NumberValue) NumberValue {
+func (v Fix64Value) Div(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Fix64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10973,6 +12150,8 @@ func (v Fix64Value) Div(other NumberValue) NumberValue {
 	a := new(big.Int).SetInt64(int64(v))
 	b := new(big.Int).SetInt64(int64(o))
 
+	common.UseMemory(interpreter, common.NewMulBigIntMemoryUsage(a, sema.Fix64FactorBig))
+
 	result := new(big.Int).Mul(a, sema.Fix64FactorBig)
 	result.Div(result, b)
 
@@ -10985,7 +12164,7 @@ func (v Fix64Value) Div(other NumberValue) NumberValue {
 	return Fix64Value(result.Int64())
 }
 
-func (v Fix64Value) SaturatingDiv(other NumberValue) NumberValue {
+func (v Fix64Value) SaturatingDiv(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Fix64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -10998,6 +12177,8 @@ func (v Fix64Value) SaturatingDiv(other NumberValue) NumberValue {
 	a := new(big.Int).SetInt64(int64(v))
 	b := new(big.Int).SetInt64(int64(o))
 
+	common.UseMemory(interpreter, common.NewMulBigIntMemoryUsage(a, sema.Fix64FactorBig))
+
 	result := new(big.Int).Mul(a, sema.Fix64FactorBig)
 	result.Div(result, b)
 
@@ -11010,7 +12191,7 @@ func (v Fix64Value) SaturatingDiv(other NumberValue) NumberValue {
 	return Fix64Value(result.Int64())
 }
 
-func (v Fix64Value) Mod(other NumberValue) NumberValue {
+func (v Fix64Value) Mod(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(Fix64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -11021,7 +12202,7 @@ func (v Fix64Value) Mod(other NumberValue) NumberValue {
 	}
 
 	// v - int(v/o) * o
-	quotient, ok := v.Div(o).(Fix64Value)
+	quotient, ok := v.Div(interpreter, o).(Fix64Value)
 	if !ok {
 		panic(InvalidOperandsError{
 			Operation: ast.OperationMod,
@@ -11030,7 +12211,7 @@ func (v Fix64Value) Mod(other NumberValue) NumberValue {
 		})
 	}
 	truncatedQuotient := (int64(quotient) / sema.Fix64Factor) * sema.Fix64Factor
-	return v.Minus(Fix64Value(truncatedQuotient).Mul(o))
+	return v.Minus(interpreter, Fix64Value(truncatedQuotient).Mul(interpreter, o))
 }
 
 func (v Fix64Value) Less(other NumberValue) BoolValue {
@@ -11269,11 +12450,11 @@ func (v UFix64Value) ToInt() int {
 	return int(v / sema.Fix64Factor)
 }
 
-func (v UFix64Value) Negate() NumberValue {
+func (v UFix64Value) Negate(_ *Interpreter) NumberValue {
 	panic(errors.NewUnreachableError())
 }
 
-func (v UFix64Value) Plus(other NumberValue) NumberValue {
+func (v UFix64Value) Plus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UFix64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -11286,7 +12467,7 @@ func (v UFix64Value) Plus(other NumberValue) NumberValue {
 	return UFix64Value(safeAddUint64(uint64(v), uint64(o)))
 }
 
-func (v UFix64Value) SaturatingPlus(other NumberValue) NumberValue {
+func (v UFix64Value) SaturatingPlus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UFix64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -11304,7 +12485,7 @@ func (v UFix64Value) SaturatingPlus(other NumberValue) NumberValue {
 	return sum
 }
 
-func (v UFix64Value) Minus(other NumberValue) NumberValue {
+func (v UFix64Value) Minus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UFix64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -11323,7 +12504,7 @@ func (v UFix64Value) Minus(other NumberValue) NumberValue {
 	return diff
 }
 
-func (v UFix64Value) SaturatingMinus(other NumberValue) NumberValue {
+func (v UFix64Value) SaturatingMinus(_ *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UFix64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -11342,7 +12523,7 @@ func (v UFix64Value) SaturatingMinus(other NumberValue) NumberValue {
 	return diff
 }
 
-func (v UFix64Value) Mul(other NumberValue) NumberValue {
+func (v UFix64Value) Mul(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UFix64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -11355,6 +12536,8 @@ func (v UFix64Value) Mul(other NumberValue) NumberValue {
 	a := new(big.Int).SetUint64(uint64(v))
 	b := new(big.Int).SetUint64(uint64(o))
 
+	common.UseMemory(interpreter, common.NewMulBigIntMemoryUsage(a, b))
+
 	result := new(big.Int).Mul(a, b)
 	result.Div(result, sema.Fix64FactorBig)
 
@@ -11365,7 +12548,7 @@ func (v UFix64Value) Mul(other NumberValue) NumberValue {
 	return UFix64Value(result.Uint64())
 }
 
-func (v UFix64Value) SaturatingMul(other NumberValue) NumberValue {
+func (v UFix64Value) SaturatingMul(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UFix64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -11378,6 +12561,8 @@ func (v UFix64Value) SaturatingMul(other NumberValue) NumberValue {
 	a := new(big.Int).SetUint64(uint64(v))
 	b := new(big.Int).SetUint64(uint64(o))
 
+	common.UseMemory(interpreter, common.NewMulBigIntMemoryUsage(a, b))
+
 	result := new(big.Int).Mul(a, b)
 	result.Div(result, sema.Fix64FactorBig)
 
@@ -11388,7 +12573,7 @@ func (v UFix64Value) SaturatingMul(other NumberValue) NumberValue {
 	return UFix64Value(result.Uint64())
 }
 
-func (v UFix64Value) Div(other NumberValue) NumberValue {
+func (v UFix64Value) Div(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UFix64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -11401,13 +12586,15 @@ func (v UFix64Value) Div(other NumberValue) NumberValue {
 	a := new(big.Int).SetUint64(uint64(v))
 	b := new(big.Int).SetUint64(uint64(o))
 
+	common.UseMemory(interpreter, common.NewMulBigIntMemoryUsage(a, sema.Fix64FactorBig))
+
 	result := new(big.Int).Mul(a, sema.Fix64FactorBig)
 	result.Div(result, b)
 
 	return UFix64Value(result.Uint64())
 }
 
-func (v UFix64Value) SaturatingDiv(other NumberValue) NumberValue {
+func (v UFix64Value) SaturatingDiv(interpreter *Interpreter, other NumberValue) NumberValue {
 	defer func() {
 		r := recover()
 		if _, ok := r.(InvalidOperandsError); ok {
@@ -11419,10 +12606,10 @@ func (v UFix64Value) SaturatingDiv(other NumberValue) NumberValue {
 		}
 	}()
 
-	return v.Div(other)
+	return v.Div(interpreter, other)
 }
 
-func (v UFix64Value) Mod(other NumberValue) NumberValue {
+func (v UFix64Value) Mod(interpreter *Interpreter, other NumberValue) NumberValue {
 	o, ok := other.(UFix64Value)
 	if !ok {
 		panic(InvalidOperandsError{
@@ -11433,7 +12620,7 @@ func (v UFix64Value) Mod(other NumberValue) NumberValue {
 	}
 
 	// v - int(v/o) * o
-	quotient, ok := v.Div(o).(UFix64Value)
+	quotient, ok := v.Div(interpreter, o).(UFix64Value)
 	if !ok {
 		panic(InvalidOperandsError{
 			Operation: ast.OperationMod,
@@ -11442,7 +12629,7 @@ func (v UFix64Value) Mod(other NumberValue) NumberValue {
 		})
 	}
 	truncatedQuotient := (uint64(quotient) / sema.Fix64Factor) * sema.Fix64Factor
-	return v.Minus(UFix64Value(truncatedQuotient).Mul(o))
+	return v.Minus(interpreter, UFix64Value(truncatedQuotient).Mul(interpreter, o))
 }
 
 func (v UFix64Value) Less(other NumberValue) BoolValue {
@@ -11648,10 +12835,15 @@ type CompositeValue struct {
 	Functions           map[string]FunctionValue
 	Destructor          FunctionValue
 	Stringer            func(value *CompositeValue, seenReferences SeenReferences) string
-	isDestroyed         bool
-	typeID              common.TypeID
-	staticType          StaticType
-	dynamicType         DynamicType
+	// TypeArguments are the type arguments of the instantiated generic composite type, if any
+	TypeArguments []StaticType
+	isDestroyed   bool
+	typeID        common.TypeID
+	staticType    StaticType
+	dynamicType   DynamicType
+	// base is the value the attachment is attached to.
+	// Only set for attachments, when accessed through the base value
+	base *CompositeValue
 }
 
 type ComputedField func(*Interpreter, func() LocationRange) Value
@@ -11669,9 +12861,35 @@ func NewCompositeValue(
 	fields []CompositeField,
 	address common.Address,
 ) *CompositeValue {
+	return NewInstantiatedCompositeValue(
+		interpreter,
+		location,
+		qualifiedIdentifier,
+		kind,
+		nil,
+		fields,
+		address,
+	)
+}
+
+// NewInstantiatedCompositeValue creates a new composite value
+// of an instantiated generic composite type, e.g. `Box<Int>`.
+// The type arguments are part of the value's static type, and are stored with the value.
+//
+func NewInstantiatedCompositeValue(
+	interpreter *Interpreter,
+	location common.Location,
+	qualifiedIdentifier string,
+	kind common.CompositeKind,
+	typeArguments []StaticType,
+	fields []CompositeField,
+	address common.Address,
+) *CompositeValue {
 
 	interpreter.ReportComputation(common.ComputationKindCreateCompositeValue, 1)
 
+	interpreter.meterCompositeValue(0)
+
 	var v *CompositeValue
 	if interpreter.tracingEnabled {
 		startTime := time.Now()
@@ -11704,6 +12922,7 @@ func NewCompositeValue(
 			location:            location,
 			qualifiedIdentifier: qualifiedIdentifier,
 			kind:                kind,
+			typeArguments:       typeArguments,
 		},
 	)
 	if err != nil {
@@ -11715,6 +12934,7 @@ func NewCompositeValue(
 		Location:            location,
 		QualifiedIdentifier: qualifiedIdentifier,
 		Kind:                kind,
+		TypeArguments:       typeArguments,
 	}
 
 	for _, field := range fields {
@@ -11764,6 +12984,8 @@ func (v *CompositeValue) DynamicType(interpreter *Interpreter, _ SeenReferences)
 		var err error
 		if v.Location == nil {
 			staticType, err = interpreter.getNativeCompositeType(v.QualifiedIdentifier)
+		} else if len(v.TypeArguments) > 0 {
+			staticType, err = interpreter.ConvertStaticToSemaType(v.StaticType())
 		} else {
 			staticType, err = interpreter.getUserCompositeType(v.Location, v.TypeID())
 		}
@@ -11785,6 +13007,7 @@ func (v *CompositeValue) StaticType() StaticType {
 			Location:            v.Location,
 			QualifiedIdentifier: v.QualifiedIdentifier,
 			TypeID:              v.TypeID(),
+			TypeArguments:       v.TypeArguments,
 		}
 	}
 	return v.staticType
@@ -11929,6 +13152,8 @@ func (v *CompositeValue) GetMember(interpreter *Interpreter, getLocationRange fu
 
 	function, ok := v.Functions[name]
 	if ok {
+		common.UseMemory(interpreter.memoryGauge, common.BoundFunctionValueMemoryUsage)
+
 		return BoundFunctionValue{
 			Self:     v,
 			Function: function,
@@ -12082,6 +13307,8 @@ func (v *CompositeValue) SetMember(
 		nil,
 	)
 
+	common.UseAtreeMapGrowthMemory(interpreter.memoryGauge, v.dictionary.Count(), atreeElementSizeEstimate)
+
 	existingStorable, err := v.dictionary.Set(
 		StringAtreeComparator,
 		StringAtreeHashInput,
@@ -12114,6 +13341,10 @@ func (v *CompositeValue) RecursiveString(seenReferences SeenReferences) string {
 	var fields []CompositeField
 
 	v.ForEachField(func(name string, value Value) {
+		if isAttachmentFieldName(name) {
+			return
+		}
+
 		fields = append(
 			fields,
 			CompositeField{
@@ -12290,12 +13521,12 @@ func (v *CompositeValue) ConformsToDynamicType(
 	compositeType, ok := compositeDynamicType.StaticType.(*sema.CompositeType)
 	if !ok ||
 		v.Kind != compositeType.Kind ||
-		v.TypeID() != compositeType.ID() {
+		!v.hasCompositeType(compositeType) {
 
 		return false
 	}
 
-	fieldsLen := int(v.dictionary.Count())
+	fieldsLen := int(v.dictionary.Count()) - v.attachmentCount()
 	if v.ComputedFields != nil {
 		fieldsLen += len(v.ComputedFields)
 	}
@@ -12343,6 +13574,67 @@ func (v *CompositeValue) ConformsToDynamicType(
 	return true
 }
 
+// GetAttachment returns the attachment of the given type, if any.
+// The returned attachment has its base set to the value.
+//
+func (v *CompositeValue) GetAttachment(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	attachmentTypeID common.TypeID,
+) *CompositeValue {
+	value := v.GetField(interpreter, getLocationRange, attachmentFieldName(attachmentTypeID))
+	if value == nil {
+		return nil
+	}
+
+	attachment, ok := value.(*CompositeValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	attachment.base = v
+
+	return attachment
+}
+
+// Attach stores the given attachment in the value.
+//
+func (v *CompositeValue) Attach(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	attachment *CompositeValue,
+) {
+	v.SetMember(
+		interpreter,
+		getLocationRange,
+		attachmentFieldName(attachment.TypeID()),
+		attachment,
+	)
+}
+
+// attachmentCount returns the number of attachments stored in the value.
+//
+func (v *CompositeValue) attachmentCount() int {
+	count := 0
+	v.ForEachField(func(name string, _ Value) {
+		if isAttachmentFieldName(name) {
+			count++
+		}
+	})
+	return count
+}
+
+// hasCompositeType returns true if the given composite type is the type of the composite value,
+// including the type arguments of an instantiated generic composite type.
+//
+func (v *CompositeValue) hasCompositeType(compositeType *sema.CompositeType) bool {
+	if len(v.TypeArguments) == 0 {
+		return v.TypeID() == compositeType.ID()
+	}
+
+	return v.StaticType().Equal(ConvertSemaCompositeTypeToStaticCompositeType(compositeType))
+}
+
 func (v *CompositeValue) IsStorable() bool {
 
 	// Only structures, resources, enums, and contracts can be stored.
@@ -12421,6 +13713,8 @@ func (v *CompositeValue) Transfer(
 	isResourceKinded := v.IsResourceKinded(interpreter)
 
 	if needsStoreTo || !isResourceKinded {
+		interpreter.meterCompositeValue(v.dictionary.Count())
+
 		iterator, err := v.dictionary.Iterator()
 		if err != nil {
 			panic(ExternalError{err})
@@ -12517,6 +13811,7 @@ func (v *CompositeValue) Transfer(
 			Functions:           v.Functions,
 			Destructor:          v.Destructor,
 			Stringer:            v.Stringer,
+			TypeArguments:       v.TypeArguments,
 			isDestroyed:         v.isDestroyed,
 			typeID:              v.typeID,
 			staticType:          v.staticType,
@@ -12550,6 +13845,8 @@ func (v *CompositeValue) ResourceUUID(interpreter *Interpreter, getLocationRange
 
 func (v *CompositeValue) Clone(interpreter *Interpreter) Value {
 
+	interpreter.meterCompositeValue(v.dictionary.Count())
+
 	iterator, err := v.dictionary.Iterator()
 	if err != nil {
 		panic(ExternalError{err})
@@ -12594,6 +13891,7 @@ func (v *CompositeValue) Clone(interpreter *Interpreter) Value {
 		Functions:           v.Functions,
 		Destructor:          v.Destructor,
 		Stringer:            v.Stringer,
+		TypeArguments:       v.TypeArguments,
 		isDestroyed:         v.isDestroyed,
 		typeID:              v.typeID,
 		staticType:          v.staticType,
@@ -12758,6 +14056,8 @@ func NewDictionaryValueWithAddress(
 
 	interpreter.ReportComputation(common.ComputationKindCreateDictionaryValue, 1)
 
+	interpreter.meterDictionaryValue(0)
+
 	var v *DictionaryValue
 
 	if interpreter.tracingEnabled {
@@ -12928,6 +14228,16 @@ func (v *DictionaryValue) Destroy(interpreter *Interpreter, getLocationRange fun
 	}
 }
 
+// checkIteration panics if the dictionary is currently iterated over by a for-in loop.
+//
+func (v *DictionaryValue) checkIteration(interpreter *Interpreter, getLocationRange func() LocationRange) {
+	if interpreter.isContainerIterated(v.StorageID()) {
+		panic(DictionaryMutatedDuringIterationError{
+			LocationRange: getLocationRange(),
+		})
+	}
+}
+
 func (v *DictionaryValue) ContainsKey(
 	interpreter *Interpreter,
 	getLocationRange func() LocationRange,
@@ -13234,6 +14544,8 @@ func (v *DictionaryValue) Remove(
 	keyValue Value,
 ) OptionalValue {
 
+	v.checkIteration(interpreter, getLocationRange)
+
 	valueComparator := newValueComparator(interpreter, getLocationRange)
 	hashInputProvider := newHashInputProvider(interpreter, getLocationRange)
 
@@ -13288,6 +14600,8 @@ func (v *DictionaryValue) Insert(
 	keyValue, value Value,
 ) OptionalValue {
 
+	v.checkIteration(interpreter, getLocationRange)
+
 	interpreter.checkContainerMutation(v.Type.KeyType, keyValue, getLocationRange)
 	interpreter.checkContainerMutation(v.Type.ValueType, value, getLocationRange)
 
@@ -13312,6 +14626,10 @@ func (v *DictionaryValue) Insert(
 	valueComparator := newValueComparator(interpreter, getLocationRange)
 	hashInputProvider := newHashInputProvider(interpreter, getLocationRange)
 
+	// NOTE: the growth is also reported if the key already exists,
+	// as it is not known before the insertion if the key is new
+	common.UseAtreeMapGrowthMemory(interpreter.memoryGauge, v.dictionary.Count(), atreeElementSizeEstimate)
+
 	// atree only calls Storable() on keyValue if needed,
 	// i.e., if the key is a new key
 	existingValueStorable, err := v.dictionary.Set(
@@ -13492,6 +14810,11 @@ func (v *DictionaryValue) Transfer(
 		v.checkInvalidatedResourceUse(interpreter, getLocationRange)
 	}
 
+	// Moving the dictionary, e.g. out of storage, removes it
+	if remove {
+		v.checkIteration(interpreter, getLocationRange)
+	}
+
 	if interpreter.tracingEnabled {
 		startTime := time.Now()
 
@@ -13517,6 +14840,8 @@ func (v *DictionaryValue) Transfer(
 
 	if needsStoreTo || !isResourceKinded {
 
+		interpreter.meterDictionaryValue(v.dictionary.Count())
+
 		valueComparator := newValueComparator(interpreter, getLocationRange)
 		hashInputProvider := newHashInputProvider(interpreter, getLocationRange)
 
@@ -13619,6 +14944,8 @@ func (v *DictionaryValue) Transfer(
 
 func (v *DictionaryValue) Clone(interpreter *Interpreter) Value {
 
+	interpreter.meterDictionaryValue(v.dictionary.Count())
+
 	valueComparator := newValueComparator(interpreter, ReturnEmptyLocationRange)
 	hashInputProvider := newHashInputProvider(interpreter, ReturnEmptyLocationRange)
 
@@ -14973,7 +16300,7 @@ func (v AddressValue) GetMember(interpreter *Interpreter, _ func() LocationRange
 	case sema.ToStringFunctionName:
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
-				return NewStringValue(v.String())
+				return newStringValueFromStringer(invocation.Interpreter, v)
 			},
 			sema.ToStringFunctionType,
 		)
@@ -15097,6 +16424,8 @@ func accountGetCapabilityFunction(
 				borrowStaticType = ConvertSemaToStaticType(borrowType)
 			}
 
+			common.UseMemory(invocation.Interpreter.memoryGauge, common.CapabilityValueMemoryUsage)
+
 			return &CapabilityValue{
 				Address:    addressValue,
 				Path:       path,
@@ -15179,7 +16508,7 @@ func (v PathValue) GetMember(_ *Interpreter, _ func() LocationRange, name string
 	case sema.ToStringFunctionName:
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
-				return NewStringValue(v.String())
+				return newStringValueFromStringer(invocation.Interpreter, v)
 			},
 			sema.ToStringFunctionType,
 		)
@@ -15344,6 +16673,9 @@ type CapabilityValue struct {
 	Address    AddressValue
 	Path       PathValue
 	BorrowType StaticType
+	// ID is the ID of the capability, if it was issued through a capability controller.
+	// It is 0 for capabilities of links, which are identified by their path
+	ID UInt64Value
 }
 
 var _ Value = &CapabilityValue{}
@@ -15390,6 +16722,15 @@ func (v *CapabilityValue) RecursiveString(seenReferences SeenReferences) string
 	if v.BorrowType != nil {
 		borrowType = v.BorrowType.String()
 	}
+
+	if v.IsControllerCapability() {
+		return format.ControllerCapability(
+			borrowType,
+			v.Address.RecursiveString(seenReferences),
+			v.ID.RecursiveString(seenReferences),
+		)
+	}
+
 	return format.Capability(
 		borrowType,
 		v.Address.RecursiveString(seenReferences),
@@ -15397,6 +16738,13 @@ func (v *CapabilityValue) RecursiveString(seenReferences SeenReferences) string
 	)
 }
 
+// IsControllerCapability returns true if the capability was issued through a capability controller,
+// and false if it is the capability of a link.
+//
+func (v *CapabilityValue) IsControllerCapability() bool {
+	return v.ID != 0
+}
+
 func (v *CapabilityValue) GetMember(interpreter *Interpreter, _ func() LocationRange, name string) Value {
 	switch name {
 	case "borrow":
@@ -15405,7 +16753,7 @@ func (v *CapabilityValue) GetMember(interpreter *Interpreter, _ func() LocationR
 			// this function will panic already if this conversion fails
 			borrowType, _ = interpreter.MustConvertStaticToSemaType(v.BorrowType).(*sema.ReferenceType)
 		}
-		return interpreter.capabilityBorrowFunction(v.Address, v.Path, borrowType)
+		return interpreter.capabilityBorrowFunction(v.Address, v.Path, v.ID, borrowType)
 
 	case "check":
 		var borrowType *sema.ReferenceType
@@ -15413,10 +16761,13 @@ func (v *CapabilityValue) GetMember(interpreter *Interpreter, _ func() LocationR
 			// this function will panic already if this conversion fails
 			borrowType, _ = interpreter.MustConvertStaticToSemaType(v.BorrowType).(*sema.ReferenceType)
 		}
-		return interpreter.capabilityCheckFunction(v.Address, v.Path, borrowType)
+		return interpreter.capabilityCheckFunction(v.Address, v.Path, v.ID, borrowType)
 
 	case "address":
 		return v.Address
+
+	case sema.CapabilityTypeIDField:
+		return v.ID
 	}
 
 	return nil
@@ -15458,7 +16809,8 @@ func (v *CapabilityValue) Equal(interpreter *Interpreter, getLocationRange func(
 		return false
 	}
 
-	return otherCapability.Address.Equal(interpreter, getLocationRange, v.Address) &&
+	return otherCapability.ID == v.ID &&
+		otherCapability.Address.Equal(interpreter, getLocationRange, v.Address) &&
 		otherCapability.Path.Equal(interpreter, getLocationRange, v.Path)
 }
 
@@ -15506,6 +16858,7 @@ func (v *CapabilityValue) Clone(interpreter *Interpreter) Value {
 		Address:    v.Address.Clone(interpreter).(AddressValue),
 		Path:       v.Path.Clone(interpreter).(PathValue),
 		BorrowType: v.BorrowType,
+		ID:         v.ID,
 	}
 }
 
@@ -15645,6 +16998,137 @@ func (v LinkValue) ChildStorables() []atree.Storable {
 	}
 }
 
+// CapabilityControllerValue is the stored state of the controller of a capability,
+// which was issued through `AuthAccount.capabilities`.
+//
+// Like links, controllers are not first-class values in programs,
+// but only stored. Programs access them through `CapabilityController` values
+//
+type CapabilityControllerValue struct {
+	BorrowType   StaticType
+	TargetPath   PathValue
+	CapabilityID UInt64Value
+	Tag          string
+}
+
+var _ Value = CapabilityControllerValue{}
+var _ atree.Value = CapabilityControllerValue{}
+var _ EquatableValue = CapabilityControllerValue{}
+
+func (CapabilityControllerValue) IsValue() {}
+
+func (v CapabilityControllerValue) Accept(interpreter *Interpreter, visitor Visitor) {
+	visitor.VisitCapabilityControllerValue(interpreter, v)
+}
+
+func (v CapabilityControllerValue) Walk(walkChild func(Value)) {
+	walkChild(v.TargetPath)
+}
+
+func (CapabilityControllerValue) DynamicType(_ *Interpreter, _ SeenReferences) DynamicType {
+	return nil
+}
+
+func (CapabilityControllerValue) StaticType() StaticType {
+	return nil
+}
+
+func (v CapabilityControllerValue) String() string {
+	return v.RecursiveString(SeenReferences{})
+}
+
+func (v CapabilityControllerValue) RecursiveString(seenReferences SeenReferences) string {
+	return format.CapabilityController(
+		v.BorrowType.String(),
+		v.CapabilityID.RecursiveString(seenReferences),
+		v.TargetPath.RecursiveString(seenReferences),
+	)
+}
+
+func (v CapabilityControllerValue) ConformsToDynamicType(
+	_ *Interpreter,
+	_ func() LocationRange,
+	_ DynamicType,
+	_ TypeConformanceResults,
+) bool {
+	// There is no dynamic type for capability controllers,
+	// as they are not first-class values in programs,
+	// but only stored
+	return false
+}
+
+func (v CapabilityControllerValue) Equal(interpreter *Interpreter, getLocationRange func() LocationRange, other Value) bool {
+	otherController, ok := other.(CapabilityControllerValue)
+	if !ok {
+		return false
+	}
+
+	return otherController.CapabilityID == v.CapabilityID &&
+		otherController.Tag == v.Tag &&
+		otherController.TargetPath.Equal(interpreter, getLocationRange, v.TargetPath) &&
+		otherController.BorrowType.Equal(v.BorrowType)
+}
+
+func (CapabilityControllerValue) IsStorable() bool {
+	return true
+}
+
+func (v CapabilityControllerValue) Storable(
+	storage atree.SlabStorage,
+	address atree.Address,
+	maxInlineSize uint64,
+) (atree.Storable, error) {
+	return maybeLargeImmutableStorable(v, storage, address, maxInlineSize)
+}
+
+func (CapabilityControllerValue) NeedsStoreTo(_ atree.Address) bool {
+	return false
+}
+
+func (CapabilityControllerValue) IsResourceKinded(_ *Interpreter) bool {
+	return false
+}
+
+func (v CapabilityControllerValue) Transfer(
+	interpreter *Interpreter,
+	_ func() LocationRange,
+	_ atree.Address,
+	remove bool,
+	storable atree.Storable,
+) Value {
+	if remove {
+		interpreter.RemoveReferencedSlab(storable)
+	}
+	return v
+}
+
+func (v CapabilityControllerValue) Clone(interpreter *Interpreter) Value {
+	return CapabilityControllerValue{
+		BorrowType:   v.BorrowType,
+		TargetPath:   v.TargetPath.Clone(interpreter).(PathValue),
+		CapabilityID: v.CapabilityID,
+		Tag:          v.Tag,
+	}
+}
+
+func (CapabilityControllerValue) DeepRemove(_ *Interpreter) {
+	// NO-OP
+}
+
+func (v CapabilityControllerValue) ByteSize() uint32 {
+	return mustStorableSize(v)
+}
+
+func (v CapabilityControllerValue) StoredValue(_ atree.SlabStorage) (atree.Value, error) {
+	return v, nil
+}
+
+func (v CapabilityControllerValue) ChildStorables() []atree.Storable {
+	return []atree.Storable{
+		v.TargetPath,
+	}
+}
+
 // NewPublicKeyValue constructs a PublicKey value.
 func NewPublicKeyValue(
 	interpreter *Interpreter,
@@ -15739,6 +17223,11 @@ var publicKeyVerifyFunction = NewHostFunctionValue(
 
 		interpreter := invocation.Interpreter
 
+		interpreter.ReportComputation(
+			common.ComputationKindSTDLIBVerifySignature,
+			uint(signedDataValue.Count()),
+		)
+
 		getLocationRange := invocation.GetLocationRange
 
 		interpreter.ExpectType(
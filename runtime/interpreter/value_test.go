@@ -409,7 +409,7 @@ func TestOwnerNewDictionary(t *testing.T) {
 
 	oldOwner := common.Address{0x1}
 
-	keyValue := NewStringValue("test")
+	keyValue := NewUnmeteredStringValue("test")
 	value := newTestCompositeValue(inter, oldOwner)
 
 	assert.Equal(t, oldOwner, value.GetOwner())
@@ -453,7 +453,7 @@ func TestOwnerDictionary(t *testing.T) {
 	oldOwner := common.Address{0x1}
 	newOwner := common.Address{0x2}
 
-	keyValue := NewStringValue("test")
+	keyValue := NewUnmeteredStringValue("test")
 	value := newTestCompositeValue(inter, oldOwner)
 
 	dictionary := NewDictionaryValueWithAddress(
@@ -508,7 +508,7 @@ func TestOwnerDictionaryCopy(t *testing.T) {
 	oldOwner := common.Address{0x1}
 	newOwner := common.Address{0x2}
 
-	keyValue := NewStringValue("test")
+	keyValue := NewUnmeteredStringValue("test")
 	value := newTestCompositeValue(inter, oldOwner)
 
 	dictionary := NewDictionaryValueWithAddress(
@@ -563,7 +563,7 @@ func TestOwnerDictionarySetSome(t *testing.T) {
 	oldOwner := common.Address{0x1}
 	newOwner := common.Address{0x2}
 
-	keyValue := NewStringValue("test")
+	keyValue := NewUnmeteredStringValue("test")
 	value := newTestCompositeValue(inter, oldOwner)
 
 	dictionary := NewDictionaryValueWithAddress(
@@ -613,7 +613,7 @@ func TestOwnerDictionaryInsertNonExisting(t *testing.T) {
 	oldOwner := common.Address{0x1}
 	newOwner := common.Address{0x2}
 
-	keyValue := NewStringValue("test")
+	keyValue := NewUnmeteredStringValue("test")
 	value := newTestCompositeValue(inter, oldOwner)
 
 	dictionary := NewDictionaryValueWithAddress(
@@ -664,7 +664,7 @@ func TestOwnerDictionaryRemove(t *testing.T) {
 	oldOwner := common.Address{0x1}
 	newOwner := common.Address{0x2}
 
-	keyValue := NewStringValue("test")
+	keyValue := NewUnmeteredStringValue("test")
 	value1 := newTestCompositeValue(inter, oldOwner)
 	value2 := newTestCompositeValue(inter, oldOwner)
 
@@ -721,7 +721,7 @@ func TestOwnerDictionaryInsertExisting(t *testing.T) {
 	oldOwner := common.Address{0x1}
 	newOwner := common.Address{0x2}
 
-	keyValue := NewStringValue("test")
+	keyValue := NewUnmeteredStringValue("test")
 	value := newTestCompositeValue(inter, oldOwner)
 
 	dictionary := NewDictionaryValueWithAddress(
@@ -933,7 +933,7 @@ func TestStringer(t *testing.T) {
 			expected: "nil",
 		},
 		"String": {
-			value:    NewStringValue("Flow ridah!"),
+			value:    NewUnmeteredStringValue("Flow ridah!"),
 			expected: "\"Flow ridah!\"",
 		},
 		"Array": {
@@ -944,7 +944,7 @@ func TestStringer(t *testing.T) {
 				},
 				common.Address{},
 				NewIntValueFromInt64(10),
-				NewStringValue("TEST"),
+				NewUnmeteredStringValue("TEST"),
 			),
 			expected: "[10, \"TEST\"]",
 		},
@@ -955,8 +955,8 @@ func TestStringer(t *testing.T) {
 					KeyType:   PrimitiveStaticTypeString,
 					ValueType: PrimitiveStaticTypeUInt8,
 				},
-				NewStringValue("a"), UInt8Value(42),
-				NewStringValue("b"), UInt8Value(99),
+				NewUnmeteredStringValue("a"), UInt8Value(42),
+				NewUnmeteredStringValue("b"), UInt8Value(99),
 			),
 			expected: `{"b": 99, "a": 42}`,
 		},
@@ -971,7 +971,7 @@ func TestStringer(t *testing.T) {
 				fields := []CompositeField{
 					{
 						Name:  "y",
-						Value: NewStringValue("bar"),
+						Value: NewUnmeteredStringValue("bar"),
 					},
 				}
 
@@ -993,7 +993,7 @@ func TestStringer(t *testing.T) {
 				fields := []CompositeField{
 					{
 						Name:  "y",
-						Value: NewStringValue("bar"),
+						Value: NewUnmeteredStringValue("bar"),
 					},
 				}
 
@@ -1126,7 +1126,7 @@ func TestVisitor(t *testing.T) {
 			KeyType:   PrimitiveStaticTypeString,
 			ValueType: PrimitiveStaticTypeAny,
 		},
-		NewStringValue("42"), value,
+		NewUnmeteredStringValue("42"), value,
 	)
 
 	fields := []CompositeField{
@@ -1410,20 +1410,20 @@ func TestGetHashInput(t *testing.T) {
 			expected: []byte{byte(HashInputTypeBool), 0},
 		},
 		"String": {
-			value: NewStringValue("Flow ridah!"),
+			value: NewUnmeteredStringValue("Flow ridah!"),
 			expected: []byte{
 				byte(HashInputTypeString),
 				0x46, 0x6c, 0x6f, 0x77, 0x20, 0x72, 0x69, 0x64, 0x61, 0x68, 0x21,
 			},
 		},
 		"String long": {
-			value: NewStringValue(strings.Repeat("a", 32)),
+			value: NewUnmeteredStringValue(strings.Repeat("a", 32)),
 			expected: append([]byte{byte(HashInputTypeString)},
 				[]byte(strings.Repeat("a", 32))...,
 			),
 		},
 		"Character": {
-			value: NewCharacterValue("ᄀᄀᄀ각ᆨᆨ"),
+			value: NewUnmeteredCharacterValue("ᄀᄀᄀ각ᆨᆨ"),
 			expected: []byte{
 				byte(HashInputTypeCharacter),
 				0xe1, 0x84, 0x80, 0xe1, 0x84, 0x80, 0xe1, 0x84, 0x80, 0xea, 0xb0, 0x81, 0xe1, 0x86, 0xa8, 0xe1, 0x86, 0xa8,
@@ -1797,7 +1797,7 @@ func TestCapabilityValue_Equal(t *testing.T) {
 			}).Equal(
 				inter,
 				ReturnEmptyLocationRange,
-				NewStringValue("test"),
+				NewUnmeteredStringValue("test"),
 			),
 		)
 	})
@@ -1929,10 +1929,10 @@ func TestStringValue_Equal(t *testing.T) {
 		inter := newTestInterpreter(t)
 
 		require.True(t,
-			NewStringValue("test").Equal(
+			NewUnmeteredStringValue("test").Equal(
 				inter,
 				ReturnEmptyLocationRange,
-				NewStringValue("test"),
+				NewUnmeteredStringValue("test"),
 			),
 		)
 	})
@@ -1944,10 +1944,10 @@ func TestStringValue_Equal(t *testing.T) {
 		inter := newTestInterpreter(t)
 
 		require.False(t,
-			NewStringValue("test").Equal(
+			NewUnmeteredStringValue("test").Equal(
 				inter,
 				ReturnEmptyLocationRange,
-				NewStringValue("foo"),
+				NewUnmeteredStringValue("foo"),
 			),
 		)
 	})
@@ -1959,7 +1959,7 @@ func TestStringValue_Equal(t *testing.T) {
 		inter := newTestInterpreter(t)
 
 		require.False(t,
-			NewStringValue("1").Equal(
+			NewUnmeteredStringValue("1").Equal(
 				inter,
 				ReturnEmptyLocationRange,
 				UInt8Value(1),
@@ -2014,10 +2014,10 @@ func TestSomeValue_Equal(t *testing.T) {
 		inter := newTestInterpreter(t)
 
 		require.True(t,
-			NewSomeValueNonCopying(NewStringValue("test")).Equal(
+			NewSomeValueNonCopying(NewUnmeteredStringValue("test")).Equal(
 				inter,
 				ReturnEmptyLocationRange,
-				NewSomeValueNonCopying(NewStringValue("test")),
+				NewSomeValueNonCopying(NewUnmeteredStringValue("test")),
 			),
 		)
 	})
@@ -2029,10 +2029,10 @@ func TestSomeValue_Equal(t *testing.T) {
 		inter := newTestInterpreter(t)
 
 		require.False(t,
-			NewSomeValueNonCopying(NewStringValue("test")).Equal(
+			NewSomeValueNonCopying(NewUnmeteredStringValue("test")).Equal(
 				inter,
 				ReturnEmptyLocationRange,
-				NewSomeValueNonCopying(NewStringValue("foo")),
+				NewSomeValueNonCopying(NewUnmeteredStringValue("foo")),
 			),
 		)
 	})
@@ -2044,7 +2044,7 @@ func TestSomeValue_Equal(t *testing.T) {
 		inter := newTestInterpreter(t)
 
 		require.False(t,
-			NewSomeValueNonCopying(NewStringValue("1")).Equal(
+			NewSomeValueNonCopying(NewUnmeteredStringValue("1")).Equal(
 				inter,
 				ReturnEmptyLocationRange,
 				UInt8Value(1),
@@ -2107,7 +2107,7 @@ func TestTypeValue_Equal(t *testing.T) {
 			}.Equal(
 				inter,
 				ReturnEmptyLocationRange,
-				NewStringValue("String"),
+				NewUnmeteredStringValue("String"),
 			),
 		)
 	})
@@ -2202,7 +2202,7 @@ func TestPathValue_Equal(t *testing.T) {
 			}.Equal(
 				inter,
 				ReturnEmptyLocationRange,
-				NewStringValue("/storage/test"),
+				NewUnmeteredStringValue("/storage/test"),
 			),
 		)
 	})
@@ -2309,7 +2309,7 @@ func TestLinkValue_Equal(t *testing.T) {
 			}.Equal(
 				inter,
 				ReturnEmptyLocationRange,
-				NewStringValue("test"),
+				NewUnmeteredStringValue("test"),
 			),
 		)
 	})
@@ -2566,9 +2566,9 @@ func TestDictionaryValue_Equal(t *testing.T) {
 				inter,
 				byteStringDictionaryType,
 				UInt8Value(1),
-				NewStringValue("1"),
+				NewUnmeteredStringValue("1"),
 				UInt8Value(2),
-				NewStringValue("2"),
+				NewUnmeteredStringValue("2"),
 			).Equal(
 				inter,
 				ReturnEmptyLocationRange,
@@ -2576,9 +2576,9 @@ func TestDictionaryValue_Equal(t *testing.T) {
 					inter,
 					byteStringDictionaryType,
 					UInt8Value(1),
-					NewStringValue("1"),
+					NewUnmeteredStringValue("1"),
 					UInt8Value(2),
-					NewStringValue("2"),
+					NewUnmeteredStringValue("2"),
 				),
 			),
 		)
@@ -2595,9 +2595,9 @@ func TestDictionaryValue_Equal(t *testing.T) {
 				inter,
 				byteStringDictionaryType,
 				UInt8Value(1),
-				NewStringValue("1"),
+				NewUnmeteredStringValue("1"),
 				UInt8Value(2),
-				NewStringValue("2"),
+				NewUnmeteredStringValue("2"),
 			).Equal(
 				inter,
 				ReturnEmptyLocationRange,
@@ -2605,9 +2605,9 @@ func TestDictionaryValue_Equal(t *testing.T) {
 					inter,
 					byteStringDictionaryType,
 					UInt8Value(2),
-					NewStringValue("1"),
+					NewUnmeteredStringValue("1"),
 					UInt8Value(3),
-					NewStringValue("2"),
+					NewUnmeteredStringValue("2"),
 				),
 			),
 		)
@@ -2624,9 +2624,9 @@ func TestDictionaryValue_Equal(t *testing.T) {
 				inter,
 				byteStringDictionaryType,
 				UInt8Value(1),
-				NewStringValue("1"),
+				NewUnmeteredStringValue("1"),
 				UInt8Value(2),
-				NewStringValue("2"),
+				NewUnmeteredStringValue("2"),
 			).Equal(
 				inter,
 				ReturnEmptyLocationRange,
@@ -2634,9 +2634,9 @@ func TestDictionaryValue_Equal(t *testing.T) {
 					inter,
 					byteStringDictionaryType,
 					UInt8Value(1),
-					NewStringValue("2"),
+					NewUnmeteredStringValue("2"),
 					UInt8Value(2),
-					NewStringValue("3"),
+					NewUnmeteredStringValue("3"),
 				),
 			),
 		)
@@ -2653,7 +2653,7 @@ func TestDictionaryValue_Equal(t *testing.T) {
 				inter,
 				byteStringDictionaryType,
 				UInt8Value(1),
-				NewStringValue("1"),
+				NewUnmeteredStringValue("1"),
 			).Equal(
 				inter,
 				ReturnEmptyLocationRange,
@@ -2661,9 +2661,9 @@ func TestDictionaryValue_Equal(t *testing.T) {
 					inter,
 					byteStringDictionaryType,
 					UInt8Value(1),
-					NewStringValue("1"),
+					NewUnmeteredStringValue("1"),
 					UInt8Value(2),
-					NewStringValue("2"),
+					NewUnmeteredStringValue("2"),
 				),
 			),
 		)
@@ -2680,9 +2680,9 @@ func TestDictionaryValue_Equal(t *testing.T) {
 				inter,
 				byteStringDictionaryType,
 				UInt8Value(1),
-				NewStringValue("1"),
+				NewUnmeteredStringValue("1"),
 				UInt8Value(2),
-				NewStringValue("2"),
+				NewUnmeteredStringValue("2"),
 			).Equal(
 				inter,
 				ReturnEmptyLocationRange,
@@ -2690,7 +2690,7 @@ func TestDictionaryValue_Equal(t *testing.T) {
 					inter,
 					byteStringDictionaryType,
 					UInt8Value(1),
-					NewStringValue("1"),
+					NewUnmeteredStringValue("1"),
 				),
 			),
 		)
@@ -2733,9 +2733,9 @@ func TestDictionaryValue_Equal(t *testing.T) {
 				inter,
 				byteStringDictionaryType,
 				UInt8Value(1),
-				NewStringValue("1"),
+				NewUnmeteredStringValue("1"),
 				UInt8Value(2),
-				NewStringValue("2"),
+				NewUnmeteredStringValue("2"),
 			).Equal(
 				inter,
 				ReturnEmptyLocationRange,
@@ -2764,14 +2764,14 @@ func TestCompositeValue_Equal(t *testing.T) {
 		fields1 := []CompositeField{
 			{
 				Name:  "a",
-				Value: NewStringValue("a"),
+				Value: NewUnmeteredStringValue("a"),
 			},
 		}
 
 		fields2 := []CompositeField{
 			{
 				Name:  "a",
-				Value: NewStringValue("a"),
+				Value: NewUnmeteredStringValue("a"),
 			},
 		}
 
@@ -2807,14 +2807,14 @@ func TestCompositeValue_Equal(t *testing.T) {
 		fields1 := []CompositeField{
 			{
 				Name:  "a",
-				Value: NewStringValue("a"),
+				Value: NewUnmeteredStringValue("a"),
 			},
 		}
 
 		fields2 := []CompositeField{
 			{
 				Name:  "a",
-				Value: NewStringValue("a"),
+				Value: NewUnmeteredStringValue("a"),
 			},
 		}
 
@@ -2850,14 +2850,14 @@ func TestCompositeValue_Equal(t *testing.T) {
 		fields1 := []CompositeField{
 			{
 				Name:  "a",
-				Value: NewStringValue("a"),
+				Value: NewUnmeteredStringValue("a"),
 			},
 		}
 
 		fields2 := []CompositeField{
 			{
 				Name:  "a",
-				Value: NewStringValue("a"),
+				Value: NewUnmeteredStringValue("a"),
 			},
 		}
 
@@ -2893,14 +2893,14 @@ func TestCompositeValue_Equal(t *testing.T) {
 		fields1 := []CompositeField{
 			{
 				Name:  "a",
-				Value: NewStringValue("a"),
+				Value: NewUnmeteredStringValue("a"),
 			},
 		}
 
 		fields2 := []CompositeField{
 			{
 				Name:  "a",
-				Value: NewStringValue("b"),
+				Value: NewUnmeteredStringValue("b"),
 			},
 		}
 
@@ -2936,18 +2936,18 @@ func TestCompositeValue_Equal(t *testing.T) {
 		fields1 := []CompositeField{
 			{
 				Name:  "a",
-				Value: NewStringValue("a"),
+				Value: NewUnmeteredStringValue("a"),
 			},
 		}
 
 		fields2 := []CompositeField{
 			{
 				Name:  "a",
-				Value: NewStringValue("a"),
+				Value: NewUnmeteredStringValue("a"),
 			},
 			{
 				Name:  "b",
-				Value: NewStringValue("b"),
+				Value: NewUnmeteredStringValue("b"),
 			},
 		}
 
@@ -2983,18 +2983,18 @@ func TestCompositeValue_Equal(t *testing.T) {
 		fields1 := []CompositeField{
 			{
 				Name:  "a",
-				Value: NewStringValue("a"),
+				Value: NewUnmeteredStringValue("a"),
 			},
 			{
 				Name:  "b",
-				Value: NewStringValue("b"),
+				Value: NewUnmeteredStringValue("b"),
 			},
 		}
 
 		fields2 := []CompositeField{
 			{
 				Name:  "a",
-				Value: NewStringValue("a"),
+				Value: NewUnmeteredStringValue("a"),
 			},
 		}
 
@@ -3030,14 +3030,14 @@ func TestCompositeValue_Equal(t *testing.T) {
 		fields1 := []CompositeField{
 			{
 				Name:  "a",
-				Value: NewStringValue("a"),
+				Value: NewUnmeteredStringValue("a"),
 			},
 		}
 
 		fields2 := []CompositeField{
 			{
 				Name:  "a",
-				Value: NewStringValue("a"),
+				Value: NewUnmeteredStringValue("a"),
 			},
 		}
 
@@ -3073,7 +3073,7 @@ func TestCompositeValue_Equal(t *testing.T) {
 		fields1 := []CompositeField{
 			{
 				Name:  "a",
-				Value: NewStringValue("a"),
+				Value: NewUnmeteredStringValue("a"),
 			},
 		}
 
@@ -3088,7 +3088,7 @@ func TestCompositeValue_Equal(t *testing.T) {
 			).Equal(
 				inter,
 				ReturnEmptyLocationRange,
-				NewStringValue("test"),
+				NewUnmeteredStringValue("test"),
 			),
 		)
 	})
@@ -56,52 +56,54 @@ type Visitor interface {
 	VisitPathValue(interpreter *Interpreter, value PathValue)
 	VisitCapabilityValue(interpreter *Interpreter, value *CapabilityValue)
 	VisitLinkValue(interpreter *Interpreter, value LinkValue)
+	VisitCapabilityControllerValue(interpreter *Interpreter, value CapabilityControllerValue)
 	VisitInterpretedFunctionValue(interpreter *Interpreter, value *InterpretedFunctionValue)
 	VisitHostFunctionValue(interpreter *Interpreter, value *HostFunctionValue)
 	VisitBoundFunctionValue(interpreter *Interpreter, value BoundFunctionValue)
 }
 
 type EmptyVisitor struct {
-	SimpleCompositeValueVisitor     func(interpreter *Interpreter, value *SimpleCompositeValue)
-	TypeValueVisitor                func(interpreter *Interpreter, value TypeValue)
-	VoidValueVisitor                func(interpreter *Interpreter, value VoidValue)
-	BoolValueVisitor                func(interpreter *Interpreter, value BoolValue)
-	CharacterValueVisitor           func(interpreter *Interpreter, value CharacterValue)
-	StringValueVisitor              func(interpreter *Interpreter, value *StringValue)
-	ArrayValueVisitor               func(interpreter *Interpreter, value *ArrayValue) bool
-	IntValueVisitor                 func(interpreter *Interpreter, value IntValue)
-	Int8ValueVisitor                func(interpreter *Interpreter, value Int8Value)
-	Int16ValueVisitor               func(interpreter *Interpreter, value Int16Value)
-	Int32ValueVisitor               func(interpreter *Interpreter, value Int32Value)
-	Int64ValueVisitor               func(interpreter *Interpreter, value Int64Value)
-	Int128ValueVisitor              func(interpreter *Interpreter, value Int128Value)
-	Int256ValueVisitor              func(interpreter *Interpreter, value Int256Value)
-	UIntValueVisitor                func(interpreter *Interpreter, value UIntValue)
-	UInt8ValueVisitor               func(interpreter *Interpreter, value UInt8Value)
-	UInt16ValueVisitor              func(interpreter *Interpreter, value UInt16Value)
-	UInt32ValueVisitor              func(interpreter *Interpreter, value UInt32Value)
-	UInt64ValueVisitor              func(interpreter *Interpreter, value UInt64Value)
-	UInt128ValueVisitor             func(interpreter *Interpreter, value UInt128Value)
-	UInt256ValueVisitor             func(interpreter *Interpreter, value UInt256Value)
-	Word8ValueVisitor               func(interpreter *Interpreter, value Word8Value)
-	Word16ValueVisitor              func(interpreter *Interpreter, value Word16Value)
-	Word32ValueVisitor              func(interpreter *Interpreter, value Word32Value)
-	Word64ValueVisitor              func(interpreter *Interpreter, value Word64Value)
-	Fix64ValueVisitor               func(interpreter *Interpreter, value Fix64Value)
-	UFix64ValueVisitor              func(interpreter *Interpreter, value UFix64Value)
-	CompositeValueVisitor           func(interpreter *Interpreter, value *CompositeValue) bool
-	DictionaryValueVisitor          func(interpreter *Interpreter, value *DictionaryValue) bool
-	NilValueVisitor                 func(interpreter *Interpreter, value NilValue)
-	SomeValueVisitor                func(interpreter *Interpreter, value *SomeValue) bool
-	StorageReferenceValueVisitor    func(interpreter *Interpreter, value *StorageReferenceValue)
-	EphemeralReferenceValueVisitor  func(interpreter *Interpreter, value *EphemeralReferenceValue)
-	AddressValueVisitor             func(interpreter *Interpreter, value AddressValue)
-	PathValueVisitor                func(interpreter *Interpreter, value PathValue)
-	CapabilityValueVisitor          func(interpreter *Interpreter, value *CapabilityValue)
-	LinkValueVisitor                func(interpreter *Interpreter, value LinkValue)
-	InterpretedFunctionValueVisitor func(interpreter *Interpreter, value *InterpretedFunctionValue)
-	HostFunctionValueVisitor        func(interpreter *Interpreter, value *HostFunctionValue)
-	BoundFunctionValueVisitor       func(interpreter *Interpreter, value BoundFunctionValue)
+	SimpleCompositeValueVisitor      func(interpreter *Interpreter, value *SimpleCompositeValue)
+	TypeValueVisitor                 func(interpreter *Interpreter, value TypeValue)
+	VoidValueVisitor                 func(interpreter *Interpreter, value VoidValue)
+	BoolValueVisitor                 func(interpreter *Interpreter, value BoolValue)
+	CharacterValueVisitor            func(interpreter *Interpreter, value CharacterValue)
+	StringValueVisitor               func(interpreter *Interpreter, value *StringValue)
+	ArrayValueVisitor                func(interpreter *Interpreter, value *ArrayValue) bool
+	IntValueVisitor                  func(interpreter *Interpreter, value IntValue)
+	Int8ValueVisitor                 func(interpreter *Interpreter, value Int8Value)
+	Int16ValueVisitor                func(interpreter *Interpreter, value Int16Value)
+	Int32ValueVisitor                func(interpreter *Interpreter, value Int32Value)
+	Int64ValueVisitor                func(interpreter *Interpreter, value Int64Value)
+	Int128ValueVisitor               func(interpreter *Interpreter, value Int128Value)
+	Int256ValueVisitor               func(interpreter *Interpreter, value Int256Value)
+	UIntValueVisitor                 func(interpreter *Interpreter, value UIntValue)
+	UInt8ValueVisitor                func(interpreter *Interpreter, value UInt8Value)
+	UInt16ValueVisitor               func(interpreter *Interpreter, value UInt16Value)
+	UInt32ValueVisitor               func(interpreter *Interpreter, value UInt32Value)
+	UInt64ValueVisitor               func(interpreter *Interpreter, value UInt64Value)
+	UInt128ValueVisitor              func(interpreter *Interpreter, value UInt128Value)
+	UInt256ValueVisitor              func(interpreter *Interpreter, value UInt256Value)
+	Word8ValueVisitor                func(interpreter *Interpreter, value Word8Value)
+	Word16ValueVisitor               func(interpreter *Interpreter, value Word16Value)
+	Word32ValueVisitor               func(interpreter *Interpreter, value Word32Value)
+	Word64ValueVisitor               func(interpreter *Interpreter, value Word64Value)
+	Fix64ValueVisitor                func(interpreter *Interpreter, value Fix64Value)
+	UFix64ValueVisitor               func(interpreter *Interpreter, value UFix64Value)
+	CompositeValueVisitor            func(interpreter *Interpreter, value *CompositeValue) bool
+	DictionaryValueVisitor           func(interpreter *Interpreter, value *DictionaryValue) bool
+	NilValueVisitor                  func(interpreter *Interpreter, value NilValue)
+	SomeValueVisitor                 func(interpreter *Interpreter, value *SomeValue) bool
+	StorageReferenceValueVisitor     func(interpreter *Interpreter, value *StorageReferenceValue)
+	EphemeralReferenceValueVisitor   func(interpreter *Interpreter, value *EphemeralReferenceValue)
+	AddressValueVisitor              func(interpreter *Interpreter, value AddressValue)
+	PathValueVisitor                 func(interpreter *Interpreter, value PathValue)
+	CapabilityValueVisitor           func(interpreter *Interpreter, value *CapabilityValue)
+	LinkValueVisitor                 func(interpreter *Interpreter, value LinkValue)
+	CapabilityControllerValueVisitor func(interpreter *Interpreter, value CapabilityControllerValue)
+	InterpretedFunctionValueVisitor  func(interpreter *Interpreter, value *InterpretedFunctionValue)
+	HostFunctionValueVisitor         func(interpreter *Interpreter, value *HostFunctionValue)
+	BoundFunctionValueVisitor        func(interpreter *Interpreter, value BoundFunctionValue)
 }
 
 var _ Visitor = &EmptyVisitor{}
@@ -365,6 +367,13 @@ func (v EmptyVisitor) VisitLinkValue(interpreter *Interpreter, value LinkValue)
 	v.LinkValueVisitor(interpreter, value)
 }
 
+func (v EmptyVisitor) VisitCapabilityControllerValue(interpreter *Interpreter, value CapabilityControllerValue) {
+	if v.CapabilityControllerValueVisitor == nil {
+		return
+	}
+	v.CapabilityControllerValueVisitor(interpreter, value)
+}
+
 func (v EmptyVisitor) VisitInterpretedFunctionValue(interpreter *Interpreter, value *InterpretedFunctionValue) {
 	if v.InterpretedFunctionValueVisitor == nil {
 		return
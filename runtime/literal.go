@@ -39,7 +39,7 @@ var LiteralExpressionTypeError = fmt.Errorf("input is not a literal")
 // or does not parse to a literal).
 //
 func ParseLiteral(literal string, ty sema.Type) (cadence.Value, error) {
-	expression, errs := parser2.ParseExpression(literal)
+	expression, errs := parser2.ParseExpression(literal, nil)
 	if len(errs) > 0 {
 		return nil, parser2.Error{
 			Code:   literal,
@@ -55,7 +55,7 @@ func ParseLiteral(literal string, ty sema.Type) (cadence.Value, error) {
 // Returns an error if the code is not a valid argument list, or the arguments are not literals.
 //
 func ParseLiteralArgumentList(argumentList string, parameterTypes []sema.Type) ([]cadence.Value, error) {
-	arguments, errs := parser2.ParseArgumentList(argumentList)
+	arguments, errs := parser2.ParseArgumentList(argumentList, nil)
 	if len(errs) > 0 {
 		return nil, parser2.Error{
 			Errors: errs,
@@ -52,6 +52,8 @@ func parseDeclarations(p *parser, endTokenType lexer.TokenType) (declarations []
 				return
 			}
 
+			common.UseMemory(p.memoryGauge, common.DeclarationMemoryUsage)
+
 			declarations = append(declarations, declaration)
 		}
 	}
@@ -77,7 +79,33 @@ func parseDeclaration(p *parser, docString string) ast.Declaration {
 				return parseVariableDeclaration(p, access, accessPos, docString)
 
 			case keywordFun:
-				return parseFunctionDeclaration(p, false, access, accessPos, docString)
+				return parseFunctionDeclaration(
+					p,
+					false,
+					access,
+					accessPos,
+					ast.FunctionPurityUnspecified,
+					nil,
+					docString,
+				)
+
+			case keywordView:
+				purityPos := p.current.StartPos
+				purity := parsePurityAnnotation(p)
+				if purity == ast.FunctionPurityUnspecified {
+					// The `view` keyword is not followed by the `fun` keyword,
+					// so it is an identifier, not a purity annotation
+					return nil
+				}
+				return parseFunctionDeclaration(
+					p,
+					false,
+					access,
+					accessPos,
+					purity,
+					&purityPos,
+					docString,
+				)
 
 			case keywordImport:
 				return parseImportDeclaration(p)
@@ -88,6 +116,12 @@ func parseDeclaration(p *parser, docString string) ast.Declaration {
 			case keywordStruct, keywordResource, keywordContract, keywordEnum:
 				return parseCompositeOrInterfaceDeclaration(p, access, accessPos, docString)
 
+			case keywordAttachment:
+				return parseAttachmentDeclaration(p, access, accessPos, docString)
+
+			case keywordTypeAlias:
+				return parseTypeAliasDeclaration(p, access, accessPos, docString)
+
 			case KeywordTransaction:
 				if access != ast.AccessNotSpecified {
 					panic(fmt.Errorf("invalid access modifier for transaction"))
@@ -262,7 +296,7 @@ func parseVariableDeclaration(
 		))
 	}
 
-	identifier := tokenToIdentifier(p.current)
+	identifier := p.tokenToIdentifier(p.current)
 
 	// Skip the identifier
 	p.next()
@@ -410,7 +444,7 @@ func parseImportDeclaration(p *parser) *ast.ImportDeclaration {
 			parseStringOrAddressLocation()
 
 		case lexer.TokenIdentifier:
-			identifier := tokenToIdentifier(p.current)
+			identifier := p.tokenToIdentifier(p.current)
 			setIdentifierLocation(identifier)
 			p.next()
 
@@ -468,7 +502,7 @@ func parseImportDeclaration(p *parser) *ast.ImportDeclaration {
 					// and process the current 'from' token as an identifier.
 				}
 
-				identifier := tokenToIdentifier(p.current)
+				identifier := p.tokenToIdentifier(p.current)
 				identifiers = append(identifiers, identifier)
 
 				expectCommaOrFrom = true
@@ -521,7 +555,7 @@ func parseImportDeclaration(p *parser) *ast.ImportDeclaration {
 		parseStringOrAddressLocation()
 
 	case lexer.TokenIdentifier:
-		identifier := tokenToIdentifier(p.current)
+		identifier := p.tokenToIdentifier(p.current)
 		// Skip the identifier
 		p.next()
 		p.skipSpaceAndComments(true)
@@ -643,7 +677,7 @@ func parseEventDeclaration(
 		))
 	}
 
-	identifier := tokenToIdentifier(p.current)
+	identifier := p.tokenToIdentifier(p.current)
 	// Skip the identifier
 	p.next()
 
@@ -675,6 +709,56 @@ func parseEventDeclaration(
 	}
 }
 
+// parseTypeAliasDeclaration parses a type alias declaration.
+//
+//     typeAliasDeclaration : 'typealias' identifier '=' typeAnnotation
+//
+func parseTypeAliasDeclaration(
+	p *parser,
+	access ast.Access,
+	accessPos *ast.Position,
+	docString string,
+) *ast.TypeAliasDeclaration {
+
+	startPos := p.current.StartPos
+	if accessPos != nil {
+		startPos = *accessPos
+	}
+
+	// Skip the `typealias` keyword
+	p.next()
+
+	p.skipSpaceAndComments(true)
+	if !p.current.Is(lexer.TokenIdentifier) {
+		panic(fmt.Errorf(
+			"expected identifier after start of type alias declaration, got %s",
+			p.current.Type,
+		))
+	}
+
+	identifier := p.tokenToIdentifier(p.current)
+	// Skip the identifier
+	p.next()
+	p.skipSpaceAndComments(true)
+
+	p.mustOne(lexer.TokenEqual)
+
+	p.skipSpaceAndComments(true)
+
+	typeAnnotation := parseTypeAnnotation(p)
+
+	return &ast.TypeAliasDeclaration{
+		Access:         access,
+		Identifier:     identifier,
+		TypeAnnotation: typeAnnotation,
+		DocString:      docString,
+		Range: ast.Range{
+			StartPos: startPos,
+			EndPos:   typeAnnotation.EndPosition(),
+		},
+	}
+}
+
 // parseCompositeKind parses a composite kind.
 //
 //     compositeKind : 'struct' | 'resource' | 'contract' | 'enum'
@@ -700,6 +784,69 @@ func parseCompositeKind(p *parser) common.CompositeKind {
 	return common.CompositeKindUnknown
 }
 
+// parseAttachmentDeclaration parses an attachment declaration.
+//
+//     attachmentDeclaration : 'attachment' identifier 'for' nominalType
+//                             '{' membersAndNestedDeclarations '}'
+//
+func parseAttachmentDeclaration(
+	p *parser,
+	access ast.Access,
+	accessPos *ast.Position,
+	docString string,
+) *ast.CompositeDeclaration {
+
+	startPos := p.current.StartPos
+	if accessPos != nil {
+		startPos = *accessPos
+	}
+
+	// Skip the `attachment` keyword
+	p.next()
+
+	p.skipSpaceAndComments(true)
+	identifier := p.tokenToIdentifier(p.mustOne(lexer.TokenIdentifier))
+
+	p.skipSpaceAndComments(true)
+	if !p.current.IsString(lexer.TokenIdentifier, keywordFor) {
+		panic(fmt.Errorf(
+			"expected keyword %q, got %s",
+			keywordFor,
+			p.current.Type,
+		))
+	}
+
+	// Skip the `for` keyword
+	p.next()
+
+	p.skipSpaceAndComments(true)
+	baseTypeIdentifier := p.mustOne(lexer.TokenIdentifier)
+	baseType := parseNominalTypeRemainder(p, baseTypeIdentifier)
+
+	p.skipSpaceAndComments(true)
+
+	p.mustOne(lexer.TokenBraceOpen)
+
+	members := parseMembersAndNestedDeclarations(p, lexer.TokenBraceClose)
+
+	p.skipSpaceAndComments(true)
+
+	endToken := p.mustOne(lexer.TokenBraceClose)
+
+	return &ast.CompositeDeclaration{
+		Access:        access,
+		CompositeKind: common.CompositeKindAttachment,
+		Identifier:    identifier,
+		BaseType:      baseType,
+		Members:       members,
+		DocString:     docString,
+		Range: ast.Range{
+			StartPos: startPos,
+			EndPos:   endToken.EndPos,
+		},
+	}
+}
+
 // parseFieldWithVariableKind parses a field which has a variable kind.
 //
 //     variableKind : 'var' | 'let'
@@ -738,7 +885,7 @@ func parseFieldWithVariableKind(
 		))
 	}
 
-	identifier := tokenToIdentifier(p.current)
+	identifier := p.tokenToIdentifier(p.current)
 	// Skip the identifier
 	p.next()
 	p.skipSpaceAndComments(true)
@@ -766,7 +913,7 @@ func parseFieldWithVariableKind(
 //
 //     conformances : ':' nominalType ( ',' nominalType )*
 //
-//     compositeDeclaration : compositeKind identifier conformances?
+//     compositeDeclaration : compositeKind identifier typeParameterList? conformances?
 //                            '{' membersAndNestedDeclarations '}'
 //
 //     interfaceDeclaration : compositeKind 'interface' identifier conformances?
@@ -816,13 +963,18 @@ func parseCompositeOrInterfaceDeclaration(
 			p.next()
 			continue
 		} else {
-			identifier = tokenToIdentifier(p.current)
+			identifier = p.tokenToIdentifier(p.current)
 			// Skip the identifier
 			p.next()
 			break
 		}
 	}
 
+	typeParameterList := parseTypeParameterList(p)
+	if isInterface && typeParameterList != nil {
+		panic(fmt.Errorf("interfaces cannot have type parameters"))
+	}
+
 	p.skipSpaceAndComments(true)
 
 	var conformances []*ast.NominalType
@@ -873,13 +1025,14 @@ func parseCompositeOrInterfaceDeclaration(
 		}
 	} else {
 		return &ast.CompositeDeclaration{
-			Access:        access,
-			CompositeKind: compositeKind,
-			Identifier:    identifier,
-			Conformances:  conformances,
-			Members:       members,
-			DocString:     docString,
-			Range:         declarationRange,
+			Access:            access,
+			CompositeKind:     compositeKind,
+			Identifier:        identifier,
+			TypeParameterList: typeParameterList,
+			Conformances:      conformances,
+			Members:           members,
+			DocString:         docString,
+			Range:             declarationRange,
 		}
 	}
 }
@@ -914,6 +1067,8 @@ func parseMembersAndNestedDeclarations(p *parser, endTokenType lexer.TokenType)
 				return ast.NewMembers(declarations)
 			}
 
+			common.UseMemory(p.memoryGauge, common.DeclarationMemoryUsage)
+
 			declarations = append(declarations, memberOrNestedDeclaration)
 		}
 	}
@@ -929,6 +1084,7 @@ func parseMembersAndNestedDeclarations(p *parser, endTokenType lexer.TokenType)
 //                               | compositeDeclaration
 //                               | eventDeclaration
 //                               | enumCase
+//                               | typeAliasDeclaration
 //
 func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration {
 
@@ -944,6 +1100,29 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration
 
 		switch p.current.Type {
 		case lexer.TokenIdentifier:
+
+			// The `view` keyword is a soft keyword:
+			// It is only a purity annotation if it is followed by the `fun` keyword,
+			// otherwise it is an identifier, e.g. the name of a field
+
+			if previousIdentifierToken == nil &&
+				p.current.Value == keywordView {
+
+				purityPos := p.current.StartPos
+				purity := parsePurityAnnotation(p)
+				if purity != ast.FunctionPurityUnspecified {
+					return parseFunctionDeclaration(
+						p,
+						functionBlockIsOptional,
+						access,
+						accessPos,
+						purity,
+						&purityPos,
+						docString,
+					)
+				}
+			}
+
 			switch p.current.Value {
 			case keywordLet, keywordVar:
 				return parseFieldWithVariableKind(p, access, accessPos, docString)
@@ -952,7 +1131,15 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration
 				return parseEnumCase(p, access, accessPos, docString)
 
 			case keywordFun:
-				return parseFunctionDeclaration(p, functionBlockIsOptional, access, accessPos, docString)
+				return parseFunctionDeclaration(
+					p,
+					functionBlockIsOptional,
+					access,
+					accessPos,
+					ast.FunctionPurityUnspecified,
+					nil,
+					docString,
+				)
 
 			case keywordEvent:
 				return parseEventDeclaration(p, access, accessPos, docString)
@@ -960,6 +1147,12 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration
 			case keywordStruct, keywordResource, keywordContract, keywordEnum:
 				return parseCompositeOrInterfaceDeclaration(p, access, accessPos, docString)
 
+			case keywordAttachment:
+				return parseAttachmentDeclaration(p, access, accessPos, docString)
+
+			case keywordTypeAlias:
+				return parseTypeAliasDeclaration(p, access, accessPos, docString)
+
 			case keywordPriv, keywordPub, keywordAccess:
 				if access != ast.AccessNotSpecified {
 					panic(fmt.Errorf("unexpected access modifier"))
@@ -986,7 +1179,7 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration
 				panic(fmt.Errorf("unexpected %s", p.current.Type))
 			}
 
-			identifier := tokenToIdentifier(*previousIdentifierToken)
+			identifier := p.tokenToIdentifier(*previousIdentifierToken)
 			return parseFieldDeclarationWithoutVariableKind(p, access, accessPos, identifier, docString)
 
 		case lexer.TokenParenOpen:
@@ -994,7 +1187,7 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration
 				panic(fmt.Errorf("unexpected %s", p.current.Type))
 			}
 
-			identifier := tokenToIdentifier(*previousIdentifierToken)
+			identifier := p.tokenToIdentifier(*previousIdentifierToken)
 			return parseSpecialFunctionDeclaration(p, functionBlockIsOptional, access, accessPos, identifier)
 		}
 
@@ -1113,7 +1306,7 @@ func parseEnumCase(
 		))
 	}
 
-	identifier := tokenToIdentifier(p.current)
+	identifier := p.tokenToIdentifier(p.current)
 	// Skip the identifier
 	p.next()
 
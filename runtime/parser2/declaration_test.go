@@ -38,7 +38,7 @@ func TestParseVariableDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations("var x = 1")
+		result, errs := ParseDeclarations("var x = 1", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -73,7 +73,7 @@ func TestParseVariableDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations(" pub var x = 1")
+		result, errs := ParseDeclarations(" pub var x = 1", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -109,7 +109,7 @@ func TestParseVariableDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations("let x = 1")
+		result, errs := ParseDeclarations("let x = 1", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -144,7 +144,7 @@ func TestParseVariableDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations("let x <- 1")
+		result, errs := ParseDeclarations("let x <- 1", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -179,7 +179,7 @@ func TestParseVariableDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations("let r2: @R <- r")
+		result, errs := ParseDeclarations("let r2: @R <- r", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -221,7 +221,7 @@ func TestParseVariableDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseStatements("var x <- y <- z")
+		result, errs := ParseStatements("var x <- y <- z", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -271,6 +271,7 @@ func TestParseParameterList(t *testing.T) {
 			func(p *parser) interface{} {
 				return parseParameterList(p)
 			},
+			nil,
 		)
 	}
 
@@ -479,7 +480,7 @@ func TestParseFunctionDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations("fun foo () { }")
+		result, errs := ParseDeclarations("fun foo () { }", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -525,7 +526,7 @@ func TestParseFunctionDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations("pub fun foo () { }")
+		result, errs := ParseDeclarations("pub fun foo () { }", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -572,7 +573,7 @@ func TestParseFunctionDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations("fun foo (): X { }")
+		result, errs := ParseDeclarations("fun foo (): X { }", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -630,7 +631,7 @@ func TestParseFunctionDeclaration(t *testing.T) {
 
               bar()
           }
-        `)
+        `, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -753,7 +754,7 @@ func TestParseFunctionDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations("/// Test\nfun foo() {}")
+		result, errs := ParseDeclarations("/// Test\nfun foo() {}", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -800,7 +801,7 @@ func TestParseFunctionDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations("\n  /// First line\n  \n/// Second line\n\n\nfun foo() {}")
+		result, errs := ParseDeclarations("\n  /// First line\n  \n/// Second line\n\n\nfun foo() {}", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -847,7 +848,7 @@ func TestParseFunctionDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations("\n    /** Cool dogs.\n\n Cool cats!! */\n\n\nfun foo() {}")
+		result, errs := ParseDeclarations("\n    /** Cool dogs.\n\n Cool cats!! */\n\n\nfun foo() {}", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -899,7 +900,7 @@ func TestParseFunctionDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations("fun main(): Int{ return 1 }")
+		result, errs := ParseDeclarations("fun main(): Int{ return 1 }", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -958,6 +959,254 @@ func TestParseFunctionDeclaration(t *testing.T) {
 	})
 }
 
+func TestParseFunctionDeclarationWithTypeParameters(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("type parameters", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("fun foo<T, U: AnyStruct>() {}", nil)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.FunctionDeclaration{
+					Identifier: ast.Identifier{
+						Identifier: "foo",
+						Pos:        ast.Position{Line: 1, Column: 4, Offset: 4},
+					},
+					TypeParameterList: &ast.TypeParameterList{
+						TypeParameters: []*ast.TypeParameter{
+							{
+								Identifier: ast.Identifier{
+									Identifier: "T",
+									Pos:        ast.Position{Line: 1, Column: 8, Offset: 8},
+								},
+							},
+							{
+								Identifier: ast.Identifier{
+									Identifier: "U",
+									Pos:        ast.Position{Line: 1, Column: 11, Offset: 11},
+								},
+								TypeBound: &ast.TypeAnnotation{
+									IsResource: false,
+									Type: &ast.NominalType{
+										Identifier: ast.Identifier{
+											Identifier: "AnyStruct",
+											Pos:        ast.Position{Line: 1, Column: 14, Offset: 14},
+										},
+									},
+									StartPos: ast.Position{Line: 1, Column: 14, Offset: 14},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 7, Offset: 7},
+							EndPos:   ast.Position{Line: 1, Column: 23, Offset: 23},
+						},
+					},
+					ParameterList: &ast.ParameterList{
+						Parameters: nil,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 24, Offset: 24},
+							EndPos:   ast.Position{Line: 1, Column: 25, Offset: 25},
+						},
+					},
+					ReturnTypeAnnotation: &ast.TypeAnnotation{
+						IsResource: false,
+						Type: &ast.NominalType{
+							Identifier: ast.Identifier{
+								Identifier: "",
+								Pos:        ast.Position{Line: 1, Column: 25, Offset: 25},
+							},
+						},
+						StartPos: ast.Position{Line: 1, Column: 25, Offset: 25},
+					},
+					FunctionBlock: &ast.FunctionBlock{
+						Block: &ast.Block{
+							Range: ast.Range{
+								StartPos: ast.Position{Line: 1, Column: 27, Offset: 27},
+								EndPos:   ast.Position{Line: 1, Column: 28, Offset: 28},
+							},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("empty type parameter list", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("fun foo<>() {}", nil)
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Message: "expected at least one type parameter",
+					Pos:     ast.Position{Offset: 8, Line: 1, Column: 8},
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("missing comma", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("fun foo<T U>() {}", nil)
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Message: "parser: expected comma, got start of type parameter",
+					Pos:     ast.Position{Offset: 10, Line: 1, Column: 10},
+				},
+			},
+			errs,
+		)
+	})
+}
+
+func TestParseViewFunctionDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("view", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("view fun foo () { }", nil)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.FunctionDeclaration{
+					Purity: ast.FunctionPurityView,
+					Identifier: ast.Identifier{
+						Identifier: "foo",
+						Pos:        ast.Position{Line: 1, Column: 9, Offset: 9},
+					},
+					ParameterList: &ast.ParameterList{
+						Parameters: nil,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 13, Offset: 13},
+							EndPos:   ast.Position{Line: 1, Column: 14, Offset: 14},
+						},
+					},
+					ReturnTypeAnnotation: &ast.TypeAnnotation{
+						IsResource: false,
+						Type: &ast.NominalType{
+							Identifier: ast.Identifier{
+								Identifier: "",
+								Pos:        ast.Position{Line: 1, Column: 14, Offset: 14},
+							},
+						},
+						StartPos: ast.Position{Line: 1, Column: 14, Offset: 14},
+					},
+					FunctionBlock: &ast.FunctionBlock{
+						Block: &ast.Block{
+							Range: ast.Range{
+								StartPos: ast.Position{Line: 1, Column: 16, Offset: 16},
+								EndPos:   ast.Position{Line: 1, Column: 18, Offset: 18},
+							},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("pub view", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("pub view fun foo () { }", nil)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.FunctionDeclaration{
+					Access: ast.AccessPublic,
+					Purity: ast.FunctionPurityView,
+					Identifier: ast.Identifier{
+						Identifier: "foo",
+						Pos:        ast.Position{Line: 1, Column: 13, Offset: 13},
+					},
+					ParameterList: &ast.ParameterList{
+						Parameters: nil,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 17, Offset: 17},
+							EndPos:   ast.Position{Line: 1, Column: 18, Offset: 18},
+						},
+					},
+					ReturnTypeAnnotation: &ast.TypeAnnotation{
+						IsResource: false,
+						Type: &ast.NominalType{
+							Identifier: ast.Identifier{
+								Identifier: "",
+								Pos:        ast.Position{Line: 1, Column: 18, Offset: 18},
+							},
+						},
+						StartPos: ast.Position{Line: 1, Column: 18, Offset: 18},
+					},
+					FunctionBlock: &ast.FunctionBlock{
+						Block: &ast.Block{
+							Range: ast.Range{
+								StartPos: ast.Position{Line: 1, Column: 20, Offset: 20},
+								EndPos:   ast.Position{Line: 1, Column: 22, Offset: 22},
+							},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("view as variable name", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("let view = 1", nil)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.VariableDeclaration{
+					IsConstant: true,
+					Identifier: ast.Identifier{
+						Identifier: "view",
+						Pos:        ast.Position{Line: 1, Column: 4, Offset: 4},
+					},
+					Transfer: &ast.Transfer{
+						Operation: ast.TransferOperationCopy,
+						Pos:       ast.Position{Line: 1, Column: 9, Offset: 9},
+					},
+					Value: &ast.IntegerExpression{
+						PositiveLiteral: "1",
+						Value:           big.NewInt(1),
+						Base:            10,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 11, Offset: 11},
+							EndPos:   ast.Position{Line: 1, Column: 11, Offset: 11},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+}
+
 func TestParseAccess(t *testing.T) {
 
 	t.Parallel()
@@ -968,6 +1217,7 @@ func TestParseAccess(t *testing.T) {
 			func(p *parser) interface{} {
 				return parseAccess(p)
 			},
+			nil,
 		)
 	}
 
@@ -1197,7 +1447,7 @@ func TestParseImportDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations(` import`)
+		result, errs := ParseDeclarations(` import`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -1220,7 +1470,7 @@ func TestParseImportDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations(` import "foo"`)
+		result, errs := ParseDeclarations(` import "foo"`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1243,7 +1493,7 @@ func TestParseImportDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations(` import 0x42`)
+		result, errs := ParseDeclarations(` import 0x42`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1268,7 +1518,7 @@ func TestParseImportDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations(` import 0x10000000000000001`)
+		result, errs := ParseDeclarations(` import 0x10000000000000001`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -1291,7 +1541,7 @@ func TestParseImportDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations(` import 1`)
+		result, errs := ParseDeclarations(` import 1`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -1316,7 +1566,7 @@ func TestParseImportDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations(` import foo from "bar"`)
+		result, errs := ParseDeclarations(` import foo from "bar"`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1344,7 +1594,7 @@ func TestParseImportDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations(` import foo "bar"`)
+		result, errs := ParseDeclarations(` import foo "bar"`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -1368,7 +1618,7 @@ func TestParseImportDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations(` import foo , bar , baz from 0x42`)
+		result, errs := ParseDeclarations(` import foo , bar , baz from 0x42`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1406,7 +1656,7 @@ func TestParseImportDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations(` import foo , bar , from 0x42`)
+		result, errs := ParseDeclarations(` import foo , bar , from 0x42`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -1429,7 +1679,7 @@ func TestParseImportDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations(` import foo`)
+		result, errs := ParseDeclarations(` import foo`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1455,7 +1705,7 @@ func TestParseImportDeclaration(t *testing.T) {
 		result, errs := ParseDeclarations(`
 			import foo, from from 0x42
 			import foo, from, bar from 0x42
-		`)
+		`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1518,7 +1768,7 @@ func TestParseEvent(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations("event E()")
+		result, errs := ParseDeclarations("event E()", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1559,7 +1809,7 @@ func TestParseEvent(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations(" priv event E2 ( a : Int , b : String )")
+		result, errs := ParseDeclarations(" priv event E2 ( a : Int , b : String )", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1653,6 +1903,7 @@ func TestParseFieldWithVariableKind(t *testing.T) {
 			func(p *parser) interface{} {
 				return parseFieldWithVariableKind(p, ast.AccessNotSpecified, nil, "")
 			},
+			nil,
 		)
 	}
 
@@ -1733,7 +1984,7 @@ func TestParseCompositeDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations(" pub struct S { }")
+		result, errs := ParseDeclarations(" pub struct S { }", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1760,7 +2011,7 @@ func TestParseCompositeDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations(" pub resource R : RI { }")
+		result, errs := ParseDeclarations(" pub resource R : RI { }", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1807,7 +2058,7 @@ func TestParseCompositeDeclaration(t *testing.T) {
                   return self.foo
               }
           }
-	    `)
+	    `, nil)
 
 		require.Empty(t, errs)
 
@@ -1983,6 +2234,129 @@ func TestParseCompositeDeclaration(t *testing.T) {
 	})
 }
 
+func TestParseCompositeDeclarationWithTypeParameters(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations(" struct Box<T> : I { }", nil)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.CompositeDeclaration{
+					CompositeKind: common.CompositeKindStructure,
+					Identifier: ast.Identifier{
+						Identifier: "Box",
+						Pos:        ast.Position{Line: 1, Column: 8, Offset: 8},
+					},
+					TypeParameterList: &ast.TypeParameterList{
+						TypeParameters: []*ast.TypeParameter{
+							{
+								Identifier: ast.Identifier{
+									Identifier: "T",
+									Pos:        ast.Position{Line: 1, Column: 12, Offset: 12},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 11, Offset: 11},
+							EndPos:   ast.Position{Line: 1, Column: 13, Offset: 13},
+						},
+					},
+					Conformances: []*ast.NominalType{
+						{
+							Identifier: ast.Identifier{
+								Identifier: "I",
+								Pos:        ast.Position{Line: 1, Column: 17, Offset: 17},
+							},
+						},
+					},
+					Members: &ast.Members{},
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+						EndPos:   ast.Position{Line: 1, Column: 21, Offset: 21},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("interface", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("struct interface I<T> {}", nil)
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Message: "interfaces cannot have type parameters",
+					Pos:     ast.Position{Offset: 21, Line: 1, Column: 21},
+				},
+			},
+			errs,
+		)
+	})
+}
+
+func TestParseAttachmentDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("simple", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations(" pub attachment A for R { }", nil)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.CompositeDeclaration{
+					Access:        ast.AccessPublic,
+					CompositeKind: common.CompositeKindAttachment,
+					Identifier: ast.Identifier{
+						Identifier: "A",
+						Pos:        ast.Position{Line: 1, Column: 16, Offset: 16},
+					},
+					BaseType: &ast.NominalType{
+						Identifier: ast.Identifier{
+							Identifier: "R",
+							Pos:        ast.Position{Line: 1, Column: 22, Offset: 22},
+						},
+					},
+					Members: &ast.Members{},
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+						EndPos:   ast.Position{Line: 1, Column: 26, Offset: 26},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("missing base type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("attachment A R {}", nil)
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Message: "expected keyword \"for\", got identifier",
+					Pos:     ast.Position{Offset: 13, Line: 1, Column: 13},
+				},
+			},
+			errs,
+		)
+	})
+}
+
 func TestParseInterfaceDeclaration(t *testing.T) {
 
 	t.Parallel()
@@ -1991,7 +2365,7 @@ func TestParseInterfaceDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations(" pub struct interface S { }")
+		result, errs := ParseDeclarations(" pub struct interface S { }", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2018,7 +2392,7 @@ func TestParseInterfaceDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations(" pub struct interface interface { }")
+		result, errs := ParseDeclarations(" pub struct interface interface { }", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -2053,7 +2427,7 @@ func TestParseInterfaceDeclaration(t *testing.T) {
 
               destroy() {}
           }
-	    `)
+	    `, nil)
 
 		require.Empty(t, errs)
 
@@ -2224,7 +2598,7 @@ func TestParseInterfaceDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations(" pub enum E { case c ; pub case d }")
+		result, errs := ParseDeclarations(" pub enum E { case c ; pub case d }", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2275,7 +2649,7 @@ func TestParseTransactionDeclaration(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations("transaction { execute {} }")
+		result, errs := ParseDeclarations("transaction { execute {} }", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2315,7 +2689,7 @@ func TestParseTransactionDeclaration(t *testing.T) {
 
 		result, errs := ParseProgram(`
 		  transaction {}
-		`)
+		`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2350,7 +2724,7 @@ func TestParseTransactionDeclaration(t *testing.T) {
 	          x = 1 + 1
 			}
 		  }
-		`)
+		`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2546,7 +2920,7 @@ func TestParseTransactionDeclaration(t *testing.T) {
 	          x == 2
 	        }
 		  }
-		`)
+		`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2786,7 +3160,7 @@ func TestParseTransactionDeclaration(t *testing.T) {
 	          x = 1 + 1
 			}
 		  }
-		`)
+		`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -3011,7 +3385,7 @@ func TestParseFunctionAndBlock(t *testing.T) {
 
 	result, errs := ParseDeclarations(`
 	    fun test() { return }
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -3066,7 +3440,7 @@ func TestParseFunctionParameterWithoutLabel(t *testing.T) {
 
 	result, errs := ParseDeclarations(`
 	    fun test(x: Int) { }
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -3136,7 +3510,7 @@ func TestParseFunctionParameterWithLabel(t *testing.T) {
 
 	result, errs := ParseDeclarations(`
 	    fun test(x y: Int) { }
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -3216,7 +3590,7 @@ func TestParseStructure(t *testing.T) {
                 return self.foo
             }
         }
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -3396,7 +3770,7 @@ func TestParseStructureWithConformances(t *testing.T) {
 
 	result, errs := ParseProgram(`
         struct Test: Foo, Bar {}
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -3447,7 +3821,7 @@ func TestParsePreAndPostConditions(t *testing.T) {
             }
             return 0
         }
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -3607,7 +3981,7 @@ func TestParseConditionMessage(t *testing.T) {
             }
             return n
         }
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -3729,7 +4103,7 @@ func TestParseInterface(t *testing.T) {
 
                 fun getFoo(): Int
             }
-	    `, kind.Keyword()))
+	    `, kind.Keyword()), nil)
 
 		require.NoError(t, err)
 
@@ -3853,7 +4227,7 @@ func TestPragmaNoArguments(t *testing.T) {
 
 	t.Parallel()
 
-	result, err := ParseProgram(`#pedantic`)
+	result, err := ParseProgram(`#pedantic`, nil)
 	require.NoError(t, err)
 
 	utils.AssertEqualWithDiff(t,
@@ -3879,7 +4253,7 @@ func TestPragmaArguments(t *testing.T) {
 
 	t.Parallel()
 
-	actual, err := ParseProgram(`#version("1.0")`)
+	actual, err := ParseProgram(`#version("1.0")`, nil)
 	require.NoError(t, err)
 
 	utils.AssertEqualWithDiff(t,
@@ -3923,7 +4297,7 @@ func TestParseImportWithString(t *testing.T) {
 
 	result, errs := ParseProgram(`
         import "test.cdc"
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -3948,7 +4322,7 @@ func TestParseImportWithAddress(t *testing.T) {
 
 	result, errs := ParseProgram(`
         import 0x1234
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -3975,7 +4349,7 @@ func TestParseImportWithIdentifiers(t *testing.T) {
 
 	result, errs := ParseProgram(`
         import A, b from 0x1
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4013,7 +4387,7 @@ func TestParseFieldWithFromIdentifier(t *testing.T) {
       struct S {
           let from: String
       }
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4066,7 +4440,7 @@ func TestParseFunctionWithFromIdentifier(t *testing.T) {
 
 	_, errs := ParseProgram(`
         fun send(from: String, to: String) {}
-	`)
+	`, nil)
 	require.Empty(t, errs)
 }
 
@@ -4076,7 +4450,7 @@ func TestParseImportWithFromIdentifier(t *testing.T) {
 
 	result, errs := ParseProgram(`
         import from from 0x1
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4109,7 +4483,7 @@ func TestParseSemicolonsBetweenDeclarations(t *testing.T) {
 	_, errs := ParseProgram(`
         import from from 0x0;
         fun foo() {};
-	`)
+	`, nil)
 	require.Empty(t, errs)
 }
 
@@ -4119,7 +4493,7 @@ func TestParseResource(t *testing.T) {
 
 	result, errs := ParseProgram(`
         resource Test {}
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4147,7 +4521,7 @@ func TestParseEventDeclaration(t *testing.T) {
 
 	result, errs := ParseProgram(`
         event Transfer(to: Address, from: Address)
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4236,7 +4610,7 @@ func TestParseEventEmitStatement(t *testing.T) {
       fun test() {
         emit Transfer(to: 1, from: 2)
       }
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4331,7 +4705,7 @@ func TestParseResourceReturnType(t *testing.T) {
 
 	result, errs := ParseProgram(`
         fun test(): @X {}
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4378,7 +4752,7 @@ func TestParseMovingVariableDeclaration(t *testing.T) {
 
 	result, errs := ParseProgram(`
         let x <- y
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4412,7 +4786,7 @@ func TestParseResourceParameterType(t *testing.T) {
 
 	result, errs := ParseProgram(`
         fun test(x: @X) {}
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4482,7 +4856,7 @@ func TestParseMovingVariableDeclarationWithTypeAnnotation(t *testing.T) {
 
 	result, errs := ParseProgram(`
         let x: @R <- y
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4526,7 +4900,7 @@ func TestParseFieldDeclarationWithMoveTypeAnnotation(t *testing.T) {
 
 	result, errs := ParseProgram(`
         struct X { x: @R }
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4581,7 +4955,7 @@ func TestParseDestructor(t *testing.T) {
         resource Test {
             destroy() {}
         }
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4636,7 +5010,7 @@ func TestParseCompositeDeclarationWithSemicolonSeparatedMembers(t *testing.T) {
 
 	result, errs := ParseProgram(`
         struct Kitty { let id: Int ; init(id: Int) { self.id = id } }
-    `)
+    `, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4842,7 +5216,7 @@ func TestParsePreconditionWithUnaryNegation(t *testing.T) {
               !false: "two"
           }
       }
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4930,7 +5304,7 @@ func TestParseInvalidAccessModifiers(t *testing.T) {
 
 		t.Parallel()
 
-		_, errs := ParseDeclarations("pub #test")
+		_, errs := ParseDeclarations("pub #test", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -4946,7 +5320,7 @@ func TestParseInvalidAccessModifiers(t *testing.T) {
 
 		t.Parallel()
 
-		_, errs := ParseDeclarations("pub transaction {}")
+		_, errs := ParseDeclarations("pub transaction {}", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -4962,7 +5336,7 @@ func TestParseInvalidAccessModifiers(t *testing.T) {
 
 		t.Parallel()
 
-		_, errs := ParseDeclarations("pub priv let x = 1")
+		_, errs := ParseDeclarations("pub priv let x = 1", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -4974,3 +5348,149 @@ func TestParseInvalidAccessModifiers(t *testing.T) {
 		)
 	})
 }
+
+func TestParseTypeAliasDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("top-level", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("typealias A = Int", nil)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.TypeAliasDeclaration{
+					Identifier: ast.Identifier{
+						Identifier: "A",
+						Pos:        ast.Position{Offset: 10, Line: 1, Column: 10},
+					},
+					TypeAnnotation: &ast.TypeAnnotation{
+						IsResource: false,
+						Type: &ast.NominalType{
+							Identifier: ast.Identifier{
+								Identifier: "Int",
+								Pos:        ast.Position{Offset: 14, Line: 1, Column: 14},
+							},
+						},
+						StartPos: ast.Position{Offset: 14, Line: 1, Column: 14},
+					},
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 0, Line: 1, Column: 0},
+						EndPos:   ast.Position{Offset: 16, Line: 1, Column: 16},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("public, resource, with doc string", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("/// R2\npub typealias R2 = @R?", nil)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.TypeAliasDeclaration{
+					Access: ast.AccessPublic,
+					Identifier: ast.Identifier{
+						Identifier: "R2",
+						Pos:        ast.Position{Offset: 21, Line: 2, Column: 14},
+					},
+					TypeAnnotation: &ast.TypeAnnotation{
+						IsResource: true,
+						Type: &ast.OptionalType{
+							Type: &ast.NominalType{
+								Identifier: ast.Identifier{
+									Identifier: "R",
+									Pos:        ast.Position{Offset: 27, Line: 2, Column: 20},
+								},
+							},
+							EndPos: ast.Position{Offset: 28, Line: 2, Column: 21},
+						},
+						StartPos: ast.Position{Offset: 26, Line: 2, Column: 19},
+					},
+					DocString: " R2",
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 7, Line: 2, Column: 0},
+						EndPos:   ast.Position{Offset: 28, Line: 2, Column: 21},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("contract C { typealias T = &R }", nil)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.CompositeDeclaration{
+					CompositeKind: common.CompositeKindContract,
+					Identifier: ast.Identifier{
+						Identifier: "C",
+						Pos:        ast.Position{Offset: 9, Line: 1, Column: 9},
+					},
+					Members: ast.NewMembers(
+						[]ast.Declaration{
+							&ast.TypeAliasDeclaration{
+								Identifier: ast.Identifier{
+									Identifier: "T",
+									Pos:        ast.Position{Offset: 23, Line: 1, Column: 23},
+								},
+								TypeAnnotation: &ast.TypeAnnotation{
+									IsResource: false,
+									Type: &ast.ReferenceType{
+										Type: &ast.NominalType{
+											Identifier: ast.Identifier{
+												Identifier: "R",
+												Pos:        ast.Position{Offset: 28, Line: 1, Column: 28},
+											},
+										},
+										StartPos: ast.Position{Offset: 27, Line: 1, Column: 27},
+									},
+									StartPos: ast.Position{Offset: 27, Line: 1, Column: 27},
+								},
+								Range: ast.Range{
+									StartPos: ast.Position{Offset: 13, Line: 1, Column: 13},
+									EndPos:   ast.Position{Offset: 28, Line: 1, Column: 28},
+								},
+							},
+						},
+					),
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 0, Line: 1, Column: 0},
+						EndPos:   ast.Position{Offset: 30, Line: 1, Column: 30},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("missing equal sign", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("typealias A Int", nil)
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Message: "expected token '='",
+					Pos:     ast.Position{Offset: 12, Line: 1, Column: 12},
+				},
+			},
+			errs,
+		)
+	})
+}
@@ -25,6 +25,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
 	"github.com/onflow/cadence/runtime/parser2/lexer"
 )
@@ -794,23 +795,72 @@ func defineIdentifierExpression() {
 				}
 
 			case keywordFun:
-				return parseFunctionExpression(p, token)
+				return parseFunctionExpression(p, token, ast.FunctionPurityUnspecified)
+
+			case keywordAttach:
+				// The `attach` keyword is a soft keyword: it only starts an attach expression
+				// if it is followed by an identifier, otherwise it is an identifier.
+
+				p.startBuffering()
+
+				p.skipSpaceAndComments(true)
+				if p.current.Is(lexer.TokenIdentifier) &&
+					p.current.Value != keywordAs {
+
+					p.acceptBuffered()
+
+					return parseAttachExpressionRemainder(p, token)
+				}
+
+				p.replayBuffered()
+
+				return &ast.IdentifierExpression{
+					Identifier: p.tokenToIdentifier(token),
+				}
+
+			case keywordView:
+				// The `view` keyword is a soft keyword: it is only a purity annotation
+				// if it is followed by the `fun` keyword, otherwise it is an identifier.
+
+				p.startBuffering()
+
+				p.skipSpaceAndComments(true)
+				if p.current.Is(lexer.TokenIdentifier) &&
+					p.current.Value == keywordFun {
+
+					p.acceptBuffered()
+
+					// Skip the `fun` keyword
+					p.next()
+					return parseFunctionExpression(p, token, ast.FunctionPurityView)
+				}
+
+				p.replayBuffered()
+
+				return &ast.IdentifierExpression{
+					Identifier: p.tokenToIdentifier(token),
+				}
 
 			default:
 				return &ast.IdentifierExpression{
-					Identifier: tokenToIdentifier(token),
+					Identifier: p.tokenToIdentifier(token),
 				}
 			}
 		},
 	})
 }
 
-func parseFunctionExpression(p *parser, token lexer.Token) *ast.FunctionExpression {
+func parseFunctionExpression(
+	p *parser,
+	token lexer.Token,
+	purity ast.FunctionPurity,
+) *ast.FunctionExpression {
 
 	parameterList, returnTypeAnnotation, functionBlock :=
 		parseFunctionParameterListAndRest(p, false)
 
 	return &ast.FunctionExpression{
+		Purity:               purity,
 		ParameterList:        parameterList,
 		ReturnTypeAnnotation: returnTypeAnnotation,
 		FunctionBlock:        functionBlock,
@@ -881,6 +931,35 @@ func parseCreateExpressionRemainder(p *parser, token lexer.Token) *ast.CreateExp
 	}
 }
 
+// parseAttachExpressionRemainder parses an attach expression,
+// after the `attach` keyword.
+//
+//     attachExpression : 'attach' nominalTypeInvocation 'to' expression
+//
+func parseAttachExpressionRemainder(p *parser, token lexer.Token) *ast.AttachExpression {
+	attachment := parseNominalTypeInvocationRemainder(p)
+
+	p.skipSpaceAndComments(true)
+	if !p.current.IsString(lexer.TokenIdentifier, keywordTo) {
+		panic(fmt.Errorf(
+			"expected keyword %q, got %s",
+			keywordTo,
+			p.current.Type,
+		))
+	}
+
+	// Skip the `to` keyword
+	p.next()
+
+	base := parseExpression(p, lowestBindingPower)
+
+	return &ast.AttachExpression{
+		Attachment: attachment,
+		Base:       base,
+		StartPos:   token.StartPos,
+	}
+}
+
 // Invocation Expression Grammar:
 //
 //     invocation : '(' ( argument ( ',' argument )* )? ')'
@@ -954,6 +1033,8 @@ func parseArgumentListRemainder(p *parser) (arguments []*ast.Argument, endPos as
 //     argument : (identifier ':' )? expression
 //
 func parseArgument(p *parser) *ast.Argument {
+	common.UseMemory(p.memoryGauge, common.ArgumentMemoryUsage)
+
 	var label string
 	var labelStartPos, labelEndPos ast.Position
 
@@ -1177,7 +1258,7 @@ func parseMemberAccess(p *parser, token lexer.Token, left ast.Expression, option
 
 	var identifier ast.Identifier
 	if p.current.Is(lexer.TokenIdentifier) {
-		identifier = tokenToIdentifier(p.current)
+		identifier = p.tokenToIdentifier(p.current)
 		p.next()
 	} else {
 		p.report(fmt.Errorf(
@@ -1329,6 +1410,7 @@ func applyExprNullDenotation(p *parser, token lexer.Token) ast.Expression {
 	if nullDenotation == nil {
 		panic(fmt.Errorf("unexpected token in expression: %s", tokenType))
 	}
+	common.UseMemory(p.memoryGauge, common.ExpressionMemoryUsage)
 	return nullDenotation(p, token)
 }
 
@@ -1337,6 +1419,7 @@ func applyExprLeftDenotation(p *parser, token lexer.Token, left ast.Expression)
 	if leftDenotation == nil {
 		panic(fmt.Errorf("unexpected token in expression: %s", token.Type))
 	}
+	common.UseMemory(p.memoryGauge, common.ExpressionMemoryUsage)
 	return leftDenotation(p, token, left)
 }
 
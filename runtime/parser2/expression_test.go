@@ -42,7 +42,7 @@ func TestParseSimpleInfixExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("1+2*3")
+		result, errs := ParseExpression("1+2*3", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -87,7 +87,7 @@ func TestParseSimpleInfixExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("  1   +   2  *   3 ")
+		result, errs := ParseExpression("  1   +   2  *   3 ", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -132,7 +132,7 @@ func TestParseSimpleInfixExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("1 + 2 + 3")
+		result, errs := ParseExpression("1 + 2 + 3", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -177,7 +177,7 @@ func TestParseSimpleInfixExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("1 ?? 2 ?? 3")
+		result, errs := ParseExpression("1 ?? 2 ?? 3", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -227,7 +227,7 @@ func TestParseAdvancedExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("1 +- 2 -- 3")
+		result, errs := ParseExpression("1 +- 2 -- 3", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -272,7 +272,7 @@ func TestParseAdvancedExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("(1 + 2) * 3")
+		result, errs := ParseExpression("(1 + 2) * 3", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -317,7 +317,7 @@ func TestParseAdvancedExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("1 < 2 > 3")
+		result, errs := ParseExpression("1 < 2 > 3", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -362,7 +362,7 @@ func TestParseAdvancedExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("a ? b : c ? d : e")
+		result, errs := ParseExpression("a ? b : c ? d : e", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -408,7 +408,7 @@ func TestParseAdvancedExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("true + false")
+		result, errs := ParseExpression("true + false", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -437,7 +437,7 @@ func TestParseAdvancedExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("(<-x)")
+		result, errs := ParseExpression("(<-x)", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -465,7 +465,7 @@ func TestParseArrayExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("[ 1,2 + 3, 4  ,  5 ]")
+		result, errs := ParseExpression("[ 1,2 + 3, 4  ,  5 ]", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -538,7 +538,7 @@ func TestParseDictionaryExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("{ 1:2 + 3, 4  :  5 }")
+		result, errs := ParseExpression("{ 1:2 + 3, 4  :  5 }", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -609,7 +609,7 @@ func TestParseDictionaryExpression(t *testing.T) {
 
 func TestParseIndexExpression(t *testing.T) {
 	t.Run("index expression", func(t *testing.T) {
-		result, errs := ParseExpression("a[0]")
+		result, errs := ParseExpression("a[0]", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -638,7 +638,7 @@ func TestParseIndexExpression(t *testing.T) {
 		)
 	})
 	t.Run("index expression with whitespace", func(t *testing.T) {
-		result, errs := ParseExpression("a [ 0 ]")
+		result, errs := ParseExpression("a [ 0 ]", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -667,7 +667,7 @@ func TestParseIndexExpression(t *testing.T) {
 		)
 	})
 	t.Run("index expression with identifier", func(t *testing.T) {
-		result, errs := ParseExpression("a [foo]")
+		result, errs := ParseExpression("a [foo]", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -702,7 +702,7 @@ func TestParseIdentifier(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("a + 3")
+		result, errs := ParseExpression("a + 3", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -733,7 +733,7 @@ func TestParsePath(t *testing.T) {
 
 	t.Parallel()
 
-	result, errs := ParseExpression("/foo/bar")
+	result, errs := ParseExpression("/foo/bar", nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -760,7 +760,7 @@ func TestParseString(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("\"\"")
+		result, errs := ParseExpression("\"\"", nil)
 		assert.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -779,7 +779,7 @@ func TestParseString(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("\"")
+		result, errs := ParseExpression("\"", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -806,7 +806,7 @@ func TestParseString(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("\"\n")
+		result, errs := ParseExpression("\"\n", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -832,7 +832,7 @@ func TestParseString(t *testing.T) {
 	t.Run("invalid, non-empty, missing end at end of file", func(t *testing.T) {
 
 		t.Parallel()
-		result, errs := ParseExpression("\"t")
+		result, errs := ParseExpression("\"t", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -859,7 +859,7 @@ func TestParseString(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("\"t\n")
+		result, errs := ParseExpression("\"t\n", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -886,7 +886,7 @@ func TestParseString(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("\"\\")
+		result, errs := ParseExpression("\"\\", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -917,7 +917,7 @@ func TestParseString(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`"te\tst\"te\u{1F3CE}\u{FE0F}xt"`)
+		result, errs := ParseExpression(`"te\tst\"te\u{1F3CE}\u{FE0F}xt"`, nil)
 		assert.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -936,7 +936,7 @@ func TestParseString(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`"te\Xst"`)
+		result, errs := ParseExpression(`"te\Xst"`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -963,7 +963,7 @@ func TestParseString(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`"te\u`)
+		result, errs := ParseExpression(`"te\u`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -994,7 +994,7 @@ func TestParseString(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`"te\us`)
+		result, errs := ParseExpression(`"te\us`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -1025,7 +1025,7 @@ func TestParseString(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`"te\u{`)
+		result, errs := ParseExpression(`"te\u{`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -1056,7 +1056,7 @@ func TestParseString(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`"te\u{}"`)
+		result, errs := ParseExpression(`"te\u{}"`, nil)
 		assert.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1076,13 +1076,14 @@ func TestParseString(t *testing.T) {
 		t.Parallel()
 
 		result, errs := ParseExpression(
-			`"te\u{73}t ` +
-				`\u{4A}J\u{4a}J ` +
-				`\u{4B}K\u{4b}K ` +
-				`\u{4C}L\u{4c}L ` +
-				`\u{4D}M\u{4d}M ` +
-				`\u{4E}N\u{4e}N ` +
+			`"te\u{73}t `+
+				`\u{4A}J\u{4a}J `+
+				`\u{4B}K\u{4b}K `+
+				`\u{4C}L\u{4c}L `+
+				`\u{4D}M\u{4d}M `+
+				`\u{4E}N\u{4e}N `+
 				`\u{4F}O\u{4f}O"`,
+			nil,
 		)
 		assert.Empty(t, errs)
 
@@ -1102,7 +1103,7 @@ func TestParseString(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`"te\u{X}st"`)
+		result, errs := ParseExpression(`"te\u{X}st"`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -1134,7 +1135,7 @@ func TestInvocation(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("f()")
+		result, errs := ParseExpression("f()", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1157,7 +1158,7 @@ func TestInvocation(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("f ()")
+		result, errs := ParseExpression("f ()", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1180,7 +1181,7 @@ func TestInvocation(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("f ( )")
+		result, errs := ParseExpression("f ( )", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1203,7 +1204,7 @@ func TestInvocation(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("f(1)")
+		result, errs := ParseExpression("f(1)", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1240,7 +1241,7 @@ func TestInvocation(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("f(label:1)")
+		result, errs := ParseExpression("f(label:1)", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1279,7 +1280,7 @@ func TestInvocation(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("f(1,2)")
+		result, errs := ParseExpression("f(1,2)", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1329,7 +1330,7 @@ func TestInvocation(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("f(a:1,b:2)")
+		result, errs := ParseExpression("f(a:1,b:2)", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1383,7 +1384,7 @@ func TestInvocation(t *testing.T) {
 
 		t.Parallel()
 
-		_, errs := ParseExpression("f(,,)")
+		_, errs := ParseExpression("f(,,)", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -1399,7 +1400,7 @@ func TestInvocation(t *testing.T) {
 
 		t.Parallel()
 
-		_, errs := ParseExpression("f(1,,)")
+		_, errs := ParseExpression("f(1,,)", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -1415,7 +1416,7 @@ func TestInvocation(t *testing.T) {
 
 		t.Parallel()
 
-		_, errs := ParseExpression("f(1 2)")
+		_, errs := ParseExpression("f(1 2)", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -1432,7 +1433,7 @@ func TestInvocation(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("f(1,g(2))")
+		result, errs := ParseExpression("f(1,g(2))", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1498,7 +1499,7 @@ func TestInvocation(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("f(1,g(\"test\"))")
+		result, errs := ParseExpression("f(1,g(\"test\"))", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1567,7 +1568,7 @@ func TestMemberExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("f.n")
+		result, errs := ParseExpression("f.n", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1592,7 +1593,7 @@ func TestMemberExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("f .n")
+		result, errs := ParseExpression("f .n", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1617,7 +1618,7 @@ func TestMemberExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("f.")
+		result, errs := ParseExpression("f.", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -1646,7 +1647,7 @@ func TestMemberExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("f.n * 3")
+		result, errs := ParseExpression("f.n * 3", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1683,7 +1684,7 @@ func TestMemberExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("3 * f.n")
+		result, errs := ParseExpression("3 * f.n", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1720,7 +1721,7 @@ func TestMemberExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("f?.n")
+		result, errs := ParseExpression("f?.n", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1751,7 +1752,7 @@ func TestParseBlockComment(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(" /* test  foo/* bar  */ asd*/ true")
+		result, errs := ParseExpression(" /* test  foo/* bar  */ asd*/ true", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1770,7 +1771,7 @@ func TestParseBlockComment(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(" /*test  foo*/ /* bar  */ true")
+		result, errs := ParseExpression(" /*test  foo*/ /* bar  */ true", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1789,7 +1790,7 @@ func TestParseBlockComment(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(" 1/*test  foo*/+/* bar  */ 2  ")
+		result, errs := ParseExpression(" 1/*test  foo*/+/* bar  */ 2  ", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1822,7 +1823,7 @@ func TestParseBlockComment(t *testing.T) {
 func BenchmarkParseInfix(b *testing.B) {
 
 	for i := 0; i < b.N; i++ {
-		_, errs := ParseExpression("(8 - 1 + 3) * 6 - ((3 + 7) * 2)")
+		_, errs := ParseExpression("(8 - 1 + 3) * 6 - ((3 + 7) * 2)", nil)
 		if len(errs) > 0 {
 			b.Fatalf("parsing expression failed: %s", errs)
 		}
@@ -1844,7 +1845,7 @@ func BenchmarkParseArray(b *testing.B) {
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		_, errs := ParseExpression(lit)
+		_, errs := ParseExpression(lit, nil)
 		if len(errs) > 0 {
 			b.Fatalf("parsing expression failed: %s", errs)
 		}
@@ -1855,7 +1856,7 @@ func TestParseReference(t *testing.T) {
 
 	t.Parallel()
 
-	result, errs := ParseExpression("& t as T")
+	result, errs := ParseExpression("& t as T", nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -1886,7 +1887,7 @@ func TestParseCasts(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(" t as T")
+		result, errs := ParseExpression(" t as T", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1916,7 +1917,7 @@ func TestParseCasts(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(" t as? T")
+		result, errs := ParseExpression(" t as? T", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1947,7 +1948,7 @@ func TestParseCasts(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(" t as! T")
+		result, errs := ParseExpression(" t as! T", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1983,7 +1984,7 @@ func TestParseForceExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("t!")
+		result, errs := ParseExpression("t!", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2004,7 +2005,7 @@ func TestParseForceExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(" t ! ")
+		result, errs := ParseExpression(" t ! ", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2025,7 +2026,7 @@ func TestParseForceExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("<-t!")
+		result, errs := ParseExpression("<-t!", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2050,7 +2051,7 @@ func TestParseForceExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("10 *  t!")
+		result, errs := ParseExpression("10 *  t!", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2082,7 +2083,7 @@ func TestParseForceExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseStatements("x\n!y")
+		result, errs := ParseStatements("x\n!y", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2116,7 +2117,7 @@ func TestParseForceExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseStatements("x\n.y!")
+		result, errs := ParseStatements("x\n.y!", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2148,7 +2149,7 @@ func TestParseForceExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseStatements("x. y")
+		result, errs := ParseStatements("x. y", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -2190,7 +2191,7 @@ func TestParseCreate(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("create T()")
+		result, errs := ParseExpression("create T()", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2216,7 +2217,7 @@ func TestParseNil(t *testing.T) {
 
 	t.Parallel()
 
-	result, errs := ParseExpression(" nil")
+	result, errs := ParseExpression(" nil", nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -2235,7 +2236,7 @@ func TestParseDestroy(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("destroy t")
+		result, errs := ParseExpression("destroy t", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2253,11 +2254,81 @@ func TestParseDestroy(t *testing.T) {
 	})
 }
 
+func TestParseAttach(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("simple", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("attach A() to b", nil)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.AttachExpression{
+				Attachment: &ast.InvocationExpression{
+					InvokedExpression: &ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "A",
+							Pos:        ast.Position{Line: 1, Column: 7, Offset: 7},
+						},
+					},
+					ArgumentsStartPos: ast.Position{Line: 1, Column: 8, Offset: 8},
+					EndPos:            ast.Position{Line: 1, Column: 9, Offset: 9},
+				},
+				Base: &ast.IdentifierExpression{
+					Identifier: ast.Identifier{
+						Identifier: "b",
+						Pos:        ast.Position{Line: 1, Column: 14, Offset: 14},
+					},
+				},
+				StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+			},
+			result,
+		)
+	})
+
+	t.Run("missing to", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseExpression("attach A() b", nil)
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Message: "expected keyword \"to\", got identifier",
+					Pos:     ast.Position{Offset: 11, Line: 1, Column: 11},
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("identifier", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("attach", nil)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.IdentifierExpression{
+				Identifier: ast.Identifier{
+					Identifier: "attach",
+					Pos:        ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+}
+
 func TestParseLineComment(t *testing.T) {
 
 	t.Parallel()
 
-	result, errs := ParseExpression(" //// // this is a comment\n 1 / 2")
+	result, errs := ParseExpression(" //// // this is a comment\n 1 / 2", nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -2294,7 +2365,7 @@ func TestParseFunctionExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("fun () { }")
+		result, errs := ParseExpression("fun () { }", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2334,7 +2405,7 @@ func TestParseFunctionExpression(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("fun (): X { }")
+		result, errs := ParseExpression("fun (): X { }", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2368,6 +2439,65 @@ func TestParseFunctionExpression(t *testing.T) {
 			result,
 		)
 	})
+
+	t.Run("view", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("view fun () { }", nil)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.FunctionExpression{
+				Purity: ast.FunctionPurityView,
+				ParameterList: &ast.ParameterList{
+					Parameters: nil,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 9, Offset: 9},
+						EndPos:   ast.Position{Line: 1, Column: 10, Offset: 10},
+					},
+				},
+				ReturnTypeAnnotation: &ast.TypeAnnotation{
+					IsResource: false,
+					Type: &ast.NominalType{
+						Identifier: ast.Identifier{
+							Identifier: "",
+							Pos:        ast.Position{Line: 1, Column: 10, Offset: 10},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 10, Offset: 10},
+				},
+				FunctionBlock: &ast.FunctionBlock{
+					Block: &ast.Block{
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 12, Offset: 12},
+							EndPos:   ast.Position{Line: 1, Column: 14, Offset: 14},
+						},
+					},
+				},
+				StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+			},
+			result,
+		)
+	})
+
+	t.Run("view as identifier", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("view", nil)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.IdentifierExpression{
+				Identifier: ast.Identifier{
+					Identifier: "view",
+					Pos:        ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
 }
 
 func TestParseIntegerLiterals(t *testing.T) {
@@ -2378,7 +2508,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`0b`)
+		result, errs := ParseExpression(`0b`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -2416,7 +2546,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`0b101010`)
+		result, errs := ParseExpression(`0b101010`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2435,7 +2565,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 	t.Run("binary with leading zeros", func(t *testing.T) {
 
-		result, errs := ParseExpression(`0b001000`)
+		result, errs := ParseExpression(`0b001000`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2456,7 +2586,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`0b101010_101010`)
+		result, errs := ParseExpression(`0b101010_101010`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2477,7 +2607,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`0b_101010_101010`)
+		result, errs := ParseExpression(`0b_101010_101010`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&InvalidIntegerLiteralError{
@@ -2511,7 +2641,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`0b101010_101010_`)
+		result, errs := ParseExpression(`0b101010_101010_`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&InvalidIntegerLiteralError{
@@ -2545,7 +2675,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`0o`)
+		result, errs := ParseExpression(`0o`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -2583,7 +2713,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`0o32`)
+		result, errs := ParseExpression(`0o32`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2604,7 +2734,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`0o32_45`)
+		result, errs := ParseExpression(`0o32_45`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2625,7 +2755,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`0o_32_45`)
+		result, errs := ParseExpression(`0o_32_45`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&InvalidIntegerLiteralError{
@@ -2659,7 +2789,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`0o32_45_`)
+		result, errs := ParseExpression(`0o32_45_`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&InvalidIntegerLiteralError{
@@ -2693,7 +2823,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`1234567890`)
+		result, errs := ParseExpression(`1234567890`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2714,7 +2844,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`1_234_567_890`)
+		result, errs := ParseExpression(`1_234_567_890`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2735,7 +2865,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`1_234_567_890_`)
+		result, errs := ParseExpression(`1_234_567_890_`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&InvalidIntegerLiteralError{
@@ -2769,7 +2899,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`0x`)
+		result, errs := ParseExpression(`0x`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -2807,7 +2937,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`0xf2`)
+		result, errs := ParseExpression(`0xf2`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2828,7 +2958,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`0xf2_09`)
+		result, errs := ParseExpression(`0xf2_09`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2849,7 +2979,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`0x_f2_09`)
+		result, errs := ParseExpression(`0x_f2_09`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&InvalidIntegerLiteralError{
@@ -2883,7 +3013,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`0xf2_09_`)
+		result, errs := ParseExpression(`0xf2_09_`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&InvalidIntegerLiteralError{
@@ -2917,7 +3047,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`0`)
+		result, errs := ParseExpression(`0`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2938,7 +3068,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`01`)
+		result, errs := ParseExpression(`01`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2959,7 +3089,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`09`)
+		result, errs := ParseExpression(`09`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -2980,7 +3110,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("00123")
+		result, errs := ParseExpression("00123", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -3001,7 +3131,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`0z123`)
+		result, errs := ParseExpression(`0z123`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -3039,7 +3169,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`0_100`)
+		result, errs := ParseExpression(`0_100`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -3060,7 +3190,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression(`1_100`)
+		result, errs := ParseExpression(`1_100`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -3083,7 +3213,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 
 		// NOTE: a leading underscore introduces an identifier
 
-		result, errs := ParseExpression(`_100`)
+		result, errs := ParseExpression(`_100`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -3106,7 +3236,7 @@ func TestParseFixedPoint(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("1234_5678_90.0009_8765_4321")
+		result, errs := ParseExpression("1234_5678_90.0009_8765_4321", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -3129,7 +3259,7 @@ func TestParseFixedPoint(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("0.1")
+		result, errs := ParseExpression("0.1", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -3152,7 +3282,7 @@ func TestParseFixedPoint(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("0.")
+		result, errs := ParseExpression("0.", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -3186,7 +3316,7 @@ func TestParseLessThanOrTypeArguments(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("1 < 2")
+		result, errs := ParseExpression("1 < 2", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -3219,7 +3349,7 @@ func TestParseLessThanOrTypeArguments(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("a < > ()")
+		result, errs := ParseExpression("a < > ()", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -3243,7 +3373,7 @@ func TestParseLessThanOrTypeArguments(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("a < { K : V } > ( 1 )")
+		result, errs := ParseExpression("a < { K : V } > ( 1 )", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -3303,7 +3433,7 @@ func TestParseLessThanOrTypeArguments(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("a < { K : V } , @R , [ S ] > ( 1 , 2 )")
+		result, errs := ParseExpression("a < { K : V } , @R , [ S ] > ( 1 , 2 )", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -3401,7 +3531,7 @@ func TestParseLessThanOrTypeArguments(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("1 + a<>()")
+		result, errs := ParseExpression("1 + a<>()", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -3437,7 +3567,7 @@ func TestParseLessThanOrTypeArguments(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("a<T<U>>()")
+		result, errs := ParseExpression("a<T<U>>()", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -3487,7 +3617,7 @@ func TestParseLessThanOrTypeArguments(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("a<T< U > >()")
+		result, errs := ParseExpression("a<T< U > >()", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -3537,7 +3667,7 @@ func TestParseLessThanOrTypeArguments(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("0 + 1 < 2")
+		result, errs := ParseExpression("0 + 1 < 2", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -3582,7 +3712,7 @@ func TestParseLessThanOrTypeArguments(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("0 + 1 << 2")
+		result, errs := ParseExpression("0 + 1 << 2", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -3627,7 +3757,7 @@ func TestParseLessThanOrTypeArguments(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("0 + 1 > 2")
+		result, errs := ParseExpression("0 + 1 > 2", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -3672,7 +3802,7 @@ func TestParseLessThanOrTypeArguments(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("0 + 1 >> 2")
+		result, errs := ParseExpression("0 + 1 >> 2", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -3720,7 +3850,7 @@ func TestParseBoolExpression(t *testing.T) {
 
 	result, errs := ParseProgram(`
 	    let a = true
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -3755,7 +3885,7 @@ func TestParseIdentifierExpression(t *testing.T) {
 
 	result, errs := ParseProgram(`
 	    let b = a
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -3789,7 +3919,7 @@ func TestParseArrayExpressionInVariableDeclaration(t *testing.T) {
 
 	result, errs := ParseProgram(`
 	    let a = [1, 2]
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -3842,7 +3972,7 @@ func TestParseDictionaryExpressionInVariableDeclaration(t *testing.T) {
 
 	result, errs := ParseProgram(`
 	    let x = {"a": 1, "b": 2}
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -3913,7 +4043,7 @@ func TestParseInvocationExpressionWithoutLabels(t *testing.T) {
 
 	result, errs := ParseProgram(`
 	    let a = b(1, 2)
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -3979,7 +4109,7 @@ func TestParseInvocationExpressionWithLabels(t *testing.T) {
 
 	result, errs := ParseProgram(`
 	    let a = b(x: 1, y: 2)
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4049,7 +4179,7 @@ func TestParseMemberExpression(t *testing.T) {
 
 	result, errs := ParseProgram(`
 	    let a = b.c
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4090,7 +4220,7 @@ func TestParseOptionalMemberExpression(t *testing.T) {
 
 	result, errs := ParseProgram(`
 	    let a = b?.c
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4132,7 +4262,7 @@ func TestParseIndexExpressionInVariableDeclaration(t *testing.T) {
 
 	result, errs := ParseProgram(`
 	    let a = b[1]
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4181,7 +4311,7 @@ func TestParseUnaryExpression(t *testing.T) {
 
 	result, errs := ParseProgram(`
 	    let foo = -boo
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4219,7 +4349,7 @@ func TestParseOrExpression(t *testing.T) {
 
 	result, errs := ParseProgram(`
         let a = false || true
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4264,7 +4394,7 @@ func TestParseAndExpression(t *testing.T) {
 
 	result, errs := ParseProgram(`
         let a = false && true
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4309,7 +4439,7 @@ func TestParseEqualityExpression(t *testing.T) {
 
 	result, errs := ParseProgram(`
         let a = false == true
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4354,7 +4484,7 @@ func TestParseRelationalExpression(t *testing.T) {
 
 	result, errs := ParseProgram(`
         let a = 1 < 2
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4403,7 +4533,7 @@ func TestParseAdditiveExpression(t *testing.T) {
 
 	result, errs := ParseProgram(`
         let a = 1 + 2
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4452,7 +4582,7 @@ func TestParseMultiplicativeExpression(t *testing.T) {
 
 	result, errs := ParseProgram(`
         let a = 1 * 2
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4501,7 +4631,7 @@ func TestParseFunctionExpressionAndReturn(t *testing.T) {
 
 	result, errs := ParseProgram(`
 	    let test = fun (): Int { return 1 }
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4573,7 +4703,7 @@ func TestParseLeftAssociativity(t *testing.T) {
 
 	result, errs := ParseProgram(`
         let a = 1 + 2 + 3
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4634,7 +4764,7 @@ func TestParseNegativeInteger(t *testing.T) {
 
 	result, errs := ParseProgram(`
       let a = -42
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4671,7 +4801,7 @@ func TestParseNegativeFixedPoint(t *testing.T) {
 
 	result, errs := ParseProgram(`
       let a = -42.3
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4712,7 +4842,7 @@ func TestParseTernaryRightAssociativity(t *testing.T) {
         let a = 2 > 1
           ? 0
           : 3 > 2 ? 1 : 2
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4814,7 +4944,7 @@ func TestParseMissingReturnType(t *testing.T) {
 	result, errs := ParseProgram(`
 		let noop: ((): Void) =
             fun () { return }
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -4897,7 +5027,7 @@ func TestParseExpression(t *testing.T) {
 
 	actual, errs := ParseExpression(`
         before(x + before(y)) + z
-	`)
+	`, nil)
 	var err error
 	if len(errs) > 0 {
 		err = Error{
@@ -4976,7 +5106,7 @@ func TestParseStringEscapes(t *testing.T) {
 
 	actual, errs := ParseExpression(`
        "test \0\n\r\t\"\'\\ xyz"
-	`)
+	`, nil)
 
 	var err error
 	if len(errs) > 0 {
@@ -5004,7 +5134,7 @@ func TestParseStringWithUnicode(t *testing.T) {
 
 	actual, errs := ParseExpression(`
       "this is a test \t\\new line and race car:\n\u{1F3CE}\u{FE0F}"
-	`)
+	`, nil)
 
 	var err error
 	if len(errs) > 0 {
@@ -5032,7 +5162,7 @@ func TestParseNilCoalescing(t *testing.T) {
 
 	result, errs := ParseProgram(`
        let x = nil ?? 1
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -5076,7 +5206,7 @@ func TestParseNilCoalescingRightAssociativity(t *testing.T) {
 	// NOTE: only syntactically, not semantically valid
 	result, errs := ParseProgram(`
        let x = 1 ?? 2 ?? 3
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -5137,7 +5267,7 @@ func TestParseFailableCasting(t *testing.T) {
 
 	result, errs := ParseProgram(`
        let x = 0 as? Int
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	failableDowncast := &ast.CastingExpression{
@@ -5193,7 +5323,7 @@ func TestParseMoveOperator(t *testing.T) {
 
 	result, errs := ParseProgram(`
       let x = foo(<-y)
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -5249,7 +5379,7 @@ func TestParseFunctionExpressionWithResourceTypeAnnotation(t *testing.T) {
 
 	result, errs := ParseProgram(`
         let f = fun (): @R { return X }
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -5319,7 +5449,7 @@ func TestParseFailableCastingResourceTypeAnnotation(t *testing.T) {
 
 	result, errs := ParseProgram(`
         let y = x as? @R
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	failableDowncast := &ast.CastingExpression{
@@ -5372,7 +5502,7 @@ func TestParseCasting(t *testing.T) {
 
 	result, errs := ParseProgram(`
         let y = x as Y
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	cast := &ast.CastingExpression{
@@ -5425,7 +5555,7 @@ func TestParseIdentifiers(t *testing.T) {
 	for _, name := range []string{"foo", "from", "create", "destroy", "for", "in"} {
 		t.Run(name, func(t *testing.T) {
 			code := fmt.Sprintf(`let %s = 1`, name)
-			_, errs := ParseProgram(code)
+			_, errs := ParseProgram(code, nil)
 			require.Empty(t, errs)
 		})
 	}
@@ -5437,7 +5567,7 @@ func TestParseReferenceInVariableDeclaration(t *testing.T) {
 
 	result, errs := ParseProgram(`
        let x = &account.storage[R] as &R
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -5502,7 +5632,7 @@ func TestParseFixedPointExpression(t *testing.T) {
 
 	result, errs := ParseProgram(`
 	    let a = -1234_5678_90.0009_8765_4321
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -5540,7 +5670,7 @@ func TestParseFixedPointExpressionZeroInteger(t *testing.T) {
 
 	result, errs := ParseProgram(`
 	    let a = -0.1
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -5578,7 +5708,7 @@ func TestParsePathLiteral(t *testing.T) {
 
 	result, errs := ParseProgram(`
 	    let a = /foo/bar
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -5616,7 +5746,7 @@ func TestParseBitwiseExpression(t *testing.T) {
 
 	result, errs := ParseProgram(`
       let a = 1 | 2 ^ 3 & 4 << 5 >> 6
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -5713,7 +5843,7 @@ func TestParseInvalidNegativeIntegerLiteralWithIncorrectPrefix(t *testing.T) {
 
 	_, err := ParseProgram(`
 	    let e = -0K0
-	`)
+	`, nil)
 
 	require.Error(t, err)
 }
@@ -177,17 +177,173 @@ func parseParameter(p *parser) *ast.Parameter {
 	}
 }
 
+// parseTypeParameterList parses an optional type parameter list.
+//
+//     typeParameterList : '<' typeParameter ( ',' typeParameter )* '>'
+//
+func parseTypeParameterList(p *parser) *ast.TypeParameterList {
+	p.skipSpaceAndComments(true)
+
+	if !p.current.Is(lexer.TokenLess) {
+		return nil
+	}
+
+	var typeParameters []*ast.TypeParameter
+
+	startPos := p.current.StartPos
+	// Skip the opening angle bracket
+	p.next()
+
+	var endPos ast.Position
+
+	expectTypeParameter := true
+
+	atEnd := false
+	for !atEnd {
+		p.skipSpaceAndComments(true)
+		switch p.current.Type {
+		case lexer.TokenIdentifier:
+			if !expectTypeParameter {
+				panic("expected comma, got start of type parameter")
+			}
+			typeParameter := parseTypeParameter(p)
+			typeParameters = append(typeParameters, typeParameter)
+			expectTypeParameter = false
+
+		case lexer.TokenComma:
+			if expectTypeParameter {
+				panic(fmt.Errorf(
+					"expected type parameter or end of type parameter list, got %s",
+					p.current.Type,
+				))
+			}
+			// Skip the comma
+			p.next()
+			expectTypeParameter = true
+
+		case lexer.TokenGreater:
+			if len(typeParameters) == 0 {
+				panic(fmt.Errorf("expected at least one type parameter"))
+			}
+			endPos = p.current.EndPos
+			// Skip the closing angle bracket
+			p.next()
+			atEnd = true
+
+		case lexer.TokenEOF:
+			panic(fmt.Errorf(
+				"missing %s at end of type parameter list",
+				lexer.TokenGreater,
+			))
+
+		default:
+			if expectTypeParameter {
+				panic(fmt.Errorf(
+					"expected type parameter or end of type parameter list, got %s",
+					p.current.Type,
+				))
+			} else {
+				panic(fmt.Errorf(
+					"expected comma or end of type parameter list, got %s",
+					p.current.Type,
+				))
+			}
+		}
+	}
+
+	return &ast.TypeParameterList{
+		TypeParameters: typeParameters,
+		Range: ast.Range{
+			StartPos: startPos,
+			EndPos:   endPos,
+		},
+	}
+}
+
+// parseTypeParameter parses a type parameter with an optional type bound.
+//
+//     typeParameter : identifier ( ':' typeAnnotation )?
+//
+func parseTypeParameter(p *parser) *ast.TypeParameter {
+	p.skipSpaceAndComments(true)
+
+	if !p.current.Is(lexer.TokenIdentifier) {
+		panic(fmt.Errorf(
+			"expected type parameter name, got %s",
+			p.current.Type,
+		))
+	}
+
+	identifier := p.tokenToIdentifier(p.current)
+
+	// Skip the identifier
+	p.next()
+
+	p.skipSpaceAndComments(true)
+
+	var typeBound *ast.TypeAnnotation
+
+	if p.current.Is(lexer.TokenColon) {
+		// Skip the colon
+		p.next()
+		p.skipSpaceAndComments(true)
+
+		typeBound = parseTypeAnnotation(p)
+	}
+
+	return &ast.TypeParameter{
+		Identifier: identifier,
+		TypeBound:  typeBound,
+	}
+}
+
+// parsePurityAnnotation parses an optional purity annotation:
+//
+//     purityAnnotation : 'view'
+//
+// The `view` keyword is a soft keyword, i.e. it is only a purity annotation
+// if it is followed by the `fun` keyword. Otherwise, it is an identifier,
+// and is left unconsumed.
+//
+func parsePurityAnnotation(p *parser) ast.FunctionPurity {
+	if !p.current.Is(lexer.TokenIdentifier) ||
+		p.current.Value != keywordView {
+
+		return ast.FunctionPurityUnspecified
+	}
+
+	p.startBuffering()
+
+	// Skip the `view` keyword
+	p.next()
+	p.skipSpaceAndComments(true)
+
+	if p.current.Is(lexer.TokenIdentifier) &&
+		p.current.Value == keywordFun {
+
+		p.acceptBuffered()
+		return ast.FunctionPurityView
+	}
+
+	p.replayBuffered()
+	return ast.FunctionPurityUnspecified
+}
+
 func parseFunctionDeclaration(
 	p *parser,
 	functionBlockIsOptional bool,
 	access ast.Access,
 	accessPos *ast.Position,
+	purity ast.FunctionPurity,
+	purityPos *ast.Position,
 	docString string,
 ) *ast.FunctionDeclaration {
 
 	startPos := p.current.StartPos
 	if accessPos != nil {
 		startPos = *accessPos
+	} else if purityPos != nil {
+		startPos = *purityPos
 	}
 
 	// Skip the `fun` keyword
@@ -201,17 +357,21 @@ func parseFunctionDeclaration(
 		))
 	}
 
-	identifier := tokenToIdentifier(p.current)
+	identifier := p.tokenToIdentifier(p.current)
 
 	// Skip the identifier
 	p.next()
 
+	typeParameterList := parseTypeParameterList(p)
+
 	parameterList, returnTypeAnnotation, functionBlock :=
 		parseFunctionParameterListAndRest(p, functionBlockIsOptional)
 
 	return &ast.FunctionDeclaration{
 		Access:               access,
+		Purity:               purity,
 		Identifier:           identifier,
+		TypeParameterList:    typeParameterList,
 		ParameterList:        parameterList,
 		ReturnTypeAnnotation: returnTypeAnnotation,
 		FunctionBlock:        functionBlock,
@@ -62,4 +62,9 @@ const (
 	keywordSwitch      = "switch"
 	keywordDefault     = "default"
 	keywordEnum        = "enum"
+	keywordTypeAlias   = "typealias"
+	keywordView        = "view"
+	keywordAttachment  = "attachment"
+	keywordAttach      = "attach"
+	keywordTo          = "to"
 )
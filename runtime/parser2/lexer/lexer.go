@@ -23,6 +23,8 @@ import (
 	"unicode/utf8"
 
 	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
 )
 
 type position struct {
@@ -52,6 +54,8 @@ type lexer struct {
 	// the tokens of the stream
 	tokens     []Token
 	tokenCount int
+	// memoryGauge is used for metering the memory of emitted tokens
+	memoryGauge common.MemoryGauge
 }
 
 var _ TokenStream = &lexer{}
@@ -60,7 +64,12 @@ func (l *lexer) Next() Token {
 	if l.cursor >= l.tokenCount {
 
 		// At the end of the token stream,
-		// emit a synthetic EOF token
+		// emit a synthetic EOF token.
+		//
+		// The cursor is still advanced, so the parser can backtrack
+		// to the token preceding the synthetic EOF token
+
+		l.cursor++
 
 		endPos := l.endPos()
 		pos := ast.Position{
@@ -95,7 +104,7 @@ func (l *lexer) Revert(cursor int) {
 	l.cursor = cursor
 }
 
-func Lex(input string) TokenStream {
+func Lex(input string, memoryGauge common.MemoryGauge) TokenStream {
 	l := &lexer{
 		input:         input,
 		startPos:      position{line: 1},
@@ -103,6 +112,7 @@ func Lex(input string) TokenStream {
 		prevEndOffset: 0,
 		current:       EOF,
 		prev:          EOF,
+		memoryGauge:   memoryGauge,
 	}
 	l.run(rootState)
 	return l
@@ -125,6 +135,10 @@ func (l *lexer) run(state stateFn) {
 		if r := recover(); r != nil {
 			var err error
 			switch r := r.(type) {
+			case errors.MemoryError:
+				// Memory errors must not be reported as lexing errors,
+				// they abort lexing
+				panic(r)
 			case error:
 				err = r
 			default:
@@ -197,6 +211,8 @@ func (l *lexer) acceptOne(r rune) bool {
 
 // emit writes a token to the channel.
 func (l *lexer) emit(ty TokenType, val interface{}, rangeStart ast.Position, consume bool) {
+	common.UseMemory(l.memoryGauge, common.TokenMemoryUsage)
+
 	endPos := l.endPos()
 
 	token := Token{
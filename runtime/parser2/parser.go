@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
 	"github.com/onflow/cadence/runtime/parser2/lexer"
 )
@@ -45,6 +46,8 @@ type parser struct {
 	backtrackingCursorStack []int
 	// bufferedErrorsStack is the stack of parsing errors encountered during buffering
 	bufferedErrorsStack [][]error
+	// memoryGauge is used for metering the memory of parsed AST elements
+	memoryGauge common.MemoryGauge
 }
 
 // Parse creates a lexer to scan the given input string,
@@ -53,17 +56,40 @@ type parser struct {
 // It can be composed with different parse functions to parse the input string into different results.
 // See "ParseExpression", "ParseStatements" as examples.
 //
-func Parse(input string, parse func(*parser) interface{}) (result interface{}, errors []error) {
+func Parse(
+	input string,
+	parse func(*parser) interface{},
+	memoryGauge common.MemoryGauge,
+) (
+	result interface{},
+	errors []error,
+) {
 	// create a lexer, which turns the input string into tokens
-	tokens := lexer.Lex(input)
-	return ParseTokenStream(tokens, parse)
+	tokens := lexer.Lex(input, memoryGauge)
+	return ParseTokenStream(tokens, parse, memoryGauge)
 }
 
-func ParseTokenStream(tokens lexer.TokenStream, parse func(*parser) interface{}) (result interface{}, errors []error) {
-	p := &parser{tokens: tokens}
+func ParseTokenStream(
+	tokens lexer.TokenStream,
+	parse func(*parser) interface{},
+	memoryGauge common.MemoryGauge,
+) (
+	result interface{},
+	errs []error,
+) {
+	p := &parser{
+		tokens:      tokens,
+		memoryGauge: memoryGauge,
+	}
 
 	defer func() {
 		if r := recover(); r != nil {
+			// Memory errors must not be reported as parsing errors,
+			// they abort parsing
+			if memoryError, ok := r.(errors.MemoryError); ok {
+				panic(memoryError)
+			}
+
 			err, ok := r.(error)
 			if !ok {
 				err = fmt.Errorf("parser: %v", r)
@@ -72,11 +98,11 @@ func ParseTokenStream(tokens lexer.TokenStream, parse func(*parser) interface{})
 			p.report(err)
 
 			result = nil
-			errors = p.errors
+			errs = p.errors
 		}
 
 		for _, bufferedErrors := range p.bufferedErrorsStack {
-			errors = append(errors, bufferedErrors...)
+			errs = append(errs, bufferedErrors...)
 		}
 	}()
 
@@ -333,21 +359,29 @@ func (p *parser) parseTrivia(options triviaOptions) (containsNewline bool, docSt
 
 func mustIdentifier(p *parser) ast.Identifier {
 	identifier := p.mustOne(lexer.TokenIdentifier)
-	return tokenToIdentifier(identifier)
+	return p.tokenToIdentifier(identifier)
 }
 
-func tokenToIdentifier(identifier lexer.Token) ast.Identifier {
+// tokenToIdentifier returns the identifier of the given identifier token,
+// and meters the memory of the identifier
+//
+func (p *parser) tokenToIdentifier(identifier lexer.Token) ast.Identifier {
+	common.UseMemory(p.memoryGauge, common.IdentifierMemoryUsage)
 	return ast.Identifier{
 		Identifier: identifier.Value.(string),
 		Pos:        identifier.StartPos,
 	}
 }
 
-func ParseExpression(input string) (expression ast.Expression, errs []error) {
+func ParseExpression(input string, memoryGauge common.MemoryGauge) (expression ast.Expression, errs []error) {
 	var res interface{}
-	res, errs = Parse(input, func(p *parser) interface{} {
-		return parseExpression(p, lowestBindingPower)
-	})
+	res, errs = Parse(
+		input,
+		func(p *parser) interface{} {
+			return parseExpression(p, lowestBindingPower)
+		},
+		memoryGauge,
+	)
 	if res == nil {
 		expression = nil
 		return
@@ -359,11 +393,15 @@ func ParseExpression(input string) (expression ast.Expression, errs []error) {
 	return
 }
 
-func ParseStatements(input string) (statements []ast.Statement, errs []error) {
+func ParseStatements(input string, memoryGauge common.MemoryGauge) (statements []ast.Statement, errs []error) {
 	var res interface{}
-	res, errs = Parse(input, func(p *parser) interface{} {
-		return parseStatements(p, nil)
-	})
+	res, errs = Parse(
+		input,
+		func(p *parser) interface{} {
+			return parseStatements(p, nil)
+		},
+		memoryGauge,
+	)
 	if res == nil {
 		statements = nil
 		return
@@ -376,11 +414,15 @@ func ParseStatements(input string) (statements []ast.Statement, errs []error) {
 	return
 }
 
-func ParseType(input string) (ty ast.Type, errs []error) {
+func ParseType(input string, memoryGauge common.MemoryGauge) (ty ast.Type, errs []error) {
 	var res interface{}
-	res, errs = Parse(input, func(p *parser) interface{} {
-		return parseType(p, lowestBindingPower)
-	})
+	res, errs = Parse(
+		input,
+		func(p *parser) interface{} {
+			return parseType(p, lowestBindingPower)
+		},
+		memoryGauge,
+	)
 	if res == nil {
 		ty = nil
 		return
@@ -393,11 +435,15 @@ func ParseType(input string) (ty ast.Type, errs []error) {
 	return
 }
 
-func ParseDeclarations(input string) (declarations []ast.Declaration, errs []error) {
+func ParseDeclarations(input string, memoryGauge common.MemoryGauge) (declarations []ast.Declaration, errs []error) {
 	var res interface{}
-	res, errs = Parse(input, func(p *parser) interface{} {
-		return parseDeclarations(p, lexer.TokenEOF)
-	})
+	res, errs = Parse(
+		input,
+		func(p *parser) interface{} {
+			return parseDeclarations(p, lexer.TokenEOF)
+		},
+		memoryGauge,
+	)
 	if res == nil {
 		declarations = nil
 		return
@@ -410,14 +456,18 @@ func ParseDeclarations(input string) (declarations []ast.Declaration, errs []err
 	return
 }
 
-func ParseArgumentList(input string) (arguments ast.Arguments, errs []error) {
+func ParseArgumentList(input string, memoryGauge common.MemoryGauge) (arguments ast.Arguments, errs []error) {
 	var res interface{}
-	res, errs = Parse(input, func(p *parser) interface{} {
-		p.skipSpaceAndComments(true)
-		p.mustOne(lexer.TokenParenOpen)
-		arguments, _ := parseArgumentListRemainder(p)
-		return arguments
-	})
+	res, errs = Parse(
+		input,
+		func(p *parser) interface{} {
+			p.skipSpaceAndComments(true)
+			p.mustOne(lexer.TokenParenOpen)
+			arguments, _ := parseArgumentListRemainder(p)
+			return arguments
+		},
+		memoryGauge,
+	)
 	if res == nil {
 		arguments = nil
 		return
@@ -431,16 +481,26 @@ func ParseArgumentList(input string) (arguments ast.Arguments, errs []error) {
 	return
 }
 
-func ParseProgram(input string) (program *ast.Program, err error) {
-	return ParseProgramFromTokenStream(lexer.Lex(input))
+func ParseProgram(input string, memoryGauge common.MemoryGauge) (program *ast.Program, err error) {
+	return ParseProgramFromTokenStream(lexer.Lex(input, memoryGauge), memoryGauge)
 }
 
-func ParseProgramFromTokenStream(input lexer.TokenStream) (program *ast.Program, err error) {
+func ParseProgramFromTokenStream(
+	input lexer.TokenStream,
+	memoryGauge common.MemoryGauge,
+) (
+	program *ast.Program,
+	err error,
+) {
 	var res interface{}
 	var errs []error
-	res, errs = ParseTokenStream(input, func(p *parser) interface{} {
-		return parseDeclarations(p, lexer.TokenEOF)
-	})
+	res, errs = ParseTokenStream(
+		input,
+		func(p *parser) interface{} {
+			return parseDeclarations(p, lexer.TokenEOF)
+		},
+		memoryGauge,
+	)
 	if len(errs) > 0 {
 		err = Error{
 			Code:   input.Input(),
@@ -457,12 +517,21 @@ func ParseProgramFromTokenStream(input lexer.TokenStream) (program *ast.Program,
 		panic(errors.NewUnreachableError())
 	}
 
+	common.UseMemory(memoryGauge, common.ProgramMemoryUsage)
+
 	program = ast.NewProgram(declarations)
 
 	return
 }
 
-func ParseProgramFromFile(filename string) (program *ast.Program, code string, err error) {
+func ParseProgramFromFile(
+	filename string,
+	memoryGauge common.MemoryGauge,
+) (
+	program *ast.Program,
+	code string,
+	err error,
+) {
 	var data []byte
 	data, err = ioutil.ReadFile(filename)
 	if err != nil {
@@ -471,7 +540,7 @@ func ParseProgramFromFile(filename string) (program *ast.Program, code string, e
 
 	code = string(data)
 
-	program, err = ParseProgram(code)
+	program, err = ParseProgram(code, memoryGauge)
 	if err != nil {
 		return nil, code, err
 	}
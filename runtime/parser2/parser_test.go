@@ -28,6 +28,8 @@ import (
 	"go.uber.org/goleak"
 
 	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
 	"github.com/onflow/cadence/runtime/parser2/lexer"
 	"github.com/onflow/cadence/runtime/tests/utils"
 )
@@ -55,7 +57,7 @@ func TestParseInvalid(t *testing.T) {
 		{missingTypeAnnotation, "#0x0<{},>()"},
 	} {
 		t.Run(test.code, func(t *testing.T) {
-			_, err := ParseProgram(test.code)
+			_, err := ParseProgram(test.code, nil)
 			require.ErrorContains(t, err, test.msg)
 		})
 	}
@@ -85,7 +87,7 @@ func TestParseBuffering(t *testing.T) {
 			p.mustOneString(lexer.TokenIdentifier, "d")
 
 			return nil
-		})
+		}, nil)
 
 		assert.Empty(t, errs)
 	})
@@ -110,7 +112,7 @@ func TestParseBuffering(t *testing.T) {
 			p.mustOneString(lexer.TokenIdentifier, "d")
 
 			return nil
-		})
+		}, nil)
 
 		utils.AssertEqualWithDiff(t,
 			[]error{
@@ -146,7 +148,7 @@ func TestParseBuffering(t *testing.T) {
 			p.mustOneString(lexer.TokenIdentifier, "d")
 
 			return nil
-		})
+		}, nil)
 
 		assert.Empty(t, errs)
 	})
@@ -190,7 +192,7 @@ func TestParseBuffering(t *testing.T) {
 			p.mustOneString(lexer.TokenIdentifier, "d")
 
 			return nil
-		})
+		}, nil)
 
 		assert.Empty(t, errs)
 	})
@@ -234,7 +236,7 @@ func TestParseBuffering(t *testing.T) {
 			p.mustOneString(lexer.TokenIdentifier, "d")
 
 			return nil
-		})
+		}, nil)
 
 		utils.AssertEqualWithDiff(t,
 			[]error{
@@ -262,7 +264,7 @@ func TestParseBuffering(t *testing.T) {
                      <                             /* ************/((TODO?{/*))************ *//
                     -x                             /* maybe it says NaNs are not negative?  */
           }
-        `)
+        `, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -285,7 +287,7 @@ func TestParseBuffering(t *testing.T) {
                                abs(-1)
               assert(sanity)
           }
-        `)
+        `, nil)
 
 		utils.AssertEqualWithDiff(t,
 			[]error{
@@ -317,7 +319,7 @@ func TestParseBuffering(t *testing.T) {
                 }
             }`
 
-		_, err := ParseProgram(src)
+		_, err := ParseProgram(src, nil)
 		assert.NoError(t, err)
 	})
 
@@ -336,7 +338,7 @@ func TestParseBuffering(t *testing.T) {
                 return g(a:A<B, C<(D>>(5)))
             }`
 
-		_, err := ParseProgram(src)
+		_, err := ParseProgram(src, nil)
 		assert.NoError(t, err)
 	})
 
@@ -378,7 +380,7 @@ func TestParseEOF(t *testing.T) {
 		)
 
 		return nil
-	})
+	}, nil)
 
 	assert.Empty(t, errs)
 }
@@ -423,7 +425,7 @@ func TestParseNames(t *testing.T) {
 
 		code := fmt.Sprintf(`let %s = 1`, name)
 
-		actual, err := ParseProgram(code)
+		actual, err := ParseProgram(code, nil)
 
 		if validExpected {
 			assert.NotNil(t, actual)
@@ -441,7 +443,7 @@ func TestParseArgumentList(t *testing.T) {
 	t.Run("invalid", func(t *testing.T) {
 		t.Parallel()
 
-		_, errs := ParseArgumentList(`xyz`)
+		_, errs := ParseArgumentList(`xyz`, nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -456,7 +458,7 @@ func TestParseArgumentList(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		t.Parallel()
 
-		result, errs := ParseArgumentList(`()`)
+		result, errs := ParseArgumentList(`()`, nil)
 		require.Empty(t, errs)
 
 		var expected ast.Arguments
@@ -470,7 +472,7 @@ func TestParseArgumentList(t *testing.T) {
 	t.Run("valid", func(t *testing.T) {
 		t.Parallel()
 
-		result, errs := ParseArgumentList(`(1, b: true)`)
+		result, errs := ParseArgumentList(`(1, b: true)`, nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -557,7 +559,7 @@ func TestParseBufferedErrors(t *testing.T) {
 	// and outside (at the top-level, after buffering of the type argument list),
 	// there is another error (missing closing parenthesis after).
 
-	_, errs := ParseExpression("a<b,>(")
+	_, errs := ParseExpression("a<b,>(", nil)
 	utils.AssertEqualWithDiff(t,
 		[]error{
 			&SyntaxError{
@@ -577,7 +579,82 @@ func TestParseInvalidSingleQuoteImport(t *testing.T) {
 
 	t.Parallel()
 
-	_, err := ParseProgram(`import 'X'`)
+	_, err := ParseProgram(`import 'X'`, nil)
 
 	require.EqualError(t, err, "Parsing failed:\nerror: unrecognized character: U+0027 '''\n --> :1:7\n  |\n1 | import 'X'\n  |        ^\n\nerror: unexpected end in import declaration: expected string, address, or identifier\n --> :1:7\n  |\n1 | import 'X'\n  |        ^\n")
 }
+
+type testMemoryGauge struct {
+	meter map[common.MemoryKind]uint64
+	limit uint64
+	total uint64
+}
+
+var memoryLimitExceededError = fmt.Errorf("memory limit exceeded")
+
+func (g *testMemoryGauge) MeterMemory(usage common.MemoryUsage) error {
+	g.total += usage.Amount
+	if g.limit > 0 && g.total > g.limit {
+		return memoryLimitExceededError
+	}
+	g.meter[usage.Kind] += usage.Amount
+	return nil
+}
+
+func TestParseMemoryMetering(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      fun test(a: Int, b: [String]): Int? {
+          let c = a + 1
+          return c
+      }
+    `
+
+	t.Run("usage", func(t *testing.T) {
+
+		t.Parallel()
+
+		gauge := &testMemoryGauge{
+			meter: map[common.MemoryKind]uint64{},
+		}
+
+		_, err := ParseProgram(code, gauge)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(1), gauge.meter[common.MemoryKindProgram])
+		assert.Equal(t, uint64(1), gauge.meter[common.MemoryKindDeclaration])
+
+		for _, kind := range []common.MemoryKind{
+			common.MemoryKindToken,
+			common.MemoryKindIdentifier,
+			common.MemoryKindStatement,
+			common.MemoryKindTypeAnnotation,
+			common.MemoryKindExpression,
+			common.MemoryKindType,
+		} {
+			assert.NotZero(t, gauge.meter[kind], kind.String())
+		}
+	})
+
+	t.Run("limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		gauge := &testMemoryGauge{
+			meter: map[common.MemoryKind]uint64{},
+			limit: 10,
+		}
+
+		defer func() {
+			r := recover()
+			require.IsType(t, errors.MemoryError{}, r)
+
+			err := r.(errors.MemoryError)
+			require.ErrorIs(t, err, memoryLimitExceededError)
+		}()
+
+		_, _ = ParseProgram(code, gauge)
+	})
+}
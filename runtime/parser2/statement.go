@@ -22,6 +22,7 @@ import (
 	"fmt"
 
 	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
 	"github.com/onflow/cadence/runtime/parser2/lexer"
 )
@@ -47,6 +48,8 @@ func parseStatements(p *parser, isEndToken func(token lexer.Token) bool) (statem
 				return
 			}
 
+			common.UseMemory(p.memoryGauge, common.StatementMemoryUsage)
+
 			statements = append(statements, statement)
 
 			// Check that the previous statement (if any) followed a semicolon
@@ -98,7 +101,19 @@ func parseStatement(p *parser) ast.Statement {
 		case keywordFun:
 			// The `fun` keyword is ambiguous: it either introduces a function expression
 			// or a function declaration, depending on if an identifier follows, or not.
-			return parseFunctionDeclarationOrFunctionExpressionStatement(p)
+			return parseFunctionDeclarationOrFunctionExpressionStatement(
+				p,
+				ast.FunctionPurityUnspecified,
+				nil,
+			)
+		case keywordView:
+			// The `view` keyword is a soft keyword: it is only a purity annotation
+			// if it is followed by the `fun` keyword, otherwise it is an identifier.
+			purityPos := p.current.StartPos
+			purity := parsePurityAnnotation(p)
+			if purity != ast.FunctionPurityUnspecified {
+				return parseFunctionDeclarationOrFunctionExpressionStatement(p, purity, &purityPos)
+			}
 		}
 	}
 
@@ -148,9 +163,16 @@ func parseStatement(p *parser) ast.Statement {
 	}
 }
 
-func parseFunctionDeclarationOrFunctionExpressionStatement(p *parser) ast.Statement {
+func parseFunctionDeclarationOrFunctionExpressionStatement(
+	p *parser,
+	purity ast.FunctionPurity,
+	purityPos *ast.Position,
+) ast.Statement {
 
 	startPos := p.current.StartPos
+	if purityPos != nil {
+		startPos = *purityPos
+	}
 
 	// Skip the `fun` keyword
 	p.next()
@@ -158,16 +180,20 @@ func parseFunctionDeclarationOrFunctionExpressionStatement(p *parser) ast.Statem
 	p.skipSpaceAndComments(true)
 
 	if p.current.Is(lexer.TokenIdentifier) {
-		identifier := tokenToIdentifier(p.current)
+		identifier := p.tokenToIdentifier(p.current)
 
 		p.next()
 
+		typeParameterList := parseTypeParameterList(p)
+
 		parameterList, returnTypeAnnotation, functionBlock :=
 			parseFunctionParameterListAndRest(p, false)
 
 		return &ast.FunctionDeclaration{
 			Access:               ast.AccessNotSpecified,
+			Purity:               purity,
 			Identifier:           identifier,
+			TypeParameterList:    typeParameterList,
 			ParameterList:        parameterList,
 			ReturnTypeAnnotation: returnTypeAnnotation,
 			FunctionBlock:        functionBlock,
@@ -179,6 +205,7 @@ func parseFunctionDeclarationOrFunctionExpressionStatement(p *parser) ast.Statem
 
 		return &ast.ExpressionStatement{
 			Expression: &ast.FunctionExpression{
+				Purity:               purity,
 				ParameterList:        parameterList,
 				ReturnTypeAnnotation: returnTypeAnnotation,
 				FunctionBlock:        functionBlock,
@@ -22,6 +22,7 @@ import (
 	"fmt"
 
 	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
 	"github.com/onflow/cadence/runtime/parser2/lexer"
 )
@@ -187,7 +188,7 @@ func parseNominalTypeRemainder(p *parser, token lexer.Token) *ast.NominalType {
 			))
 		}
 
-		nestedIdentifier := tokenToIdentifier(nestedToken)
+		nestedIdentifier := p.tokenToIdentifier(nestedToken)
 
 		// Skip the identifier
 		p.next()
@@ -200,7 +201,7 @@ func parseNominalTypeRemainder(p *parser, token lexer.Token) *ast.NominalType {
 	}
 
 	return &ast.NominalType{
-		Identifier:        tokenToIdentifier(token),
+		Identifier:        p.tokenToIdentifier(token),
 		NestedIdentifiers: nestedIdentifiers,
 	}
 }
@@ -574,6 +575,17 @@ func defineFunctionType() {
 		lexer.TokenParenOpen,
 		func(p *parser, startToken lexer.Token) ast.Type {
 
+			p.skipSpaceAndComments(true)
+
+			purity := ast.FunctionPurityUnspecified
+			if p.current.Is(lexer.TokenIdentifier) &&
+				p.current.Value == keywordView {
+
+				// Skip the `view` keyword
+				p.next()
+				purity = ast.FunctionPurityView
+			}
+
 			parameterTypeAnnotations := parseParameterTypeAnnotations(p)
 
 			p.skipSpaceAndComments(true)
@@ -586,6 +598,7 @@ func defineFunctionType() {
 			endToken := p.mustOne(lexer.TokenParenClose)
 
 			return &ast.FunctionType{
+				PurityAnnotation:         purity,
 				ParameterTypeAnnotations: parameterTypeAnnotations,
 				ReturnTypeAnnotation:     returnTypeAnnotation,
 				Range: ast.Range{
@@ -725,6 +738,8 @@ func parseTypeAnnotation(p *parser) *ast.TypeAnnotation {
 
 	ty := parseType(p, lowestBindingPower)
 
+	common.UseMemory(p.memoryGauge, common.TypeAnnotationMemoryUsage)
+
 	return &ast.TypeAnnotation{
 		IsResource: isResource,
 		Type:       ty,
@@ -738,6 +753,7 @@ func applyTypeNullDenotation(p *parser, token lexer.Token) ast.Type {
 	if nullDenotation == nil {
 		panic(fmt.Errorf("unexpected token in type: %s", tokenType))
 	}
+	common.UseMemory(p.memoryGauge, common.TypeMemoryUsage)
 	return nullDenotation(p, token)
 }
 
@@ -746,6 +762,7 @@ func applyTypeLeftDenotation(p *parser, token lexer.Token, left ast.Type) ast.Ty
 	if leftDenotation == nil {
 		panic(fmt.Errorf("unexpected token in type: %s", token.Type))
 	}
+	common.UseMemory(p.memoryGauge, common.TypeMemoryUsage)
 	return leftDenotation(p, token, left)
 }
 
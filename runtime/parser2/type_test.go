@@ -37,7 +37,7 @@ func TestParseNominalType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("Int")
+		result, errs := ParseType("Int", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -55,7 +55,7 @@ func TestParseNominalType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("Foo.Bar")
+		result, errs := ParseType("Foo.Bar", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -84,7 +84,7 @@ func TestParseArrayType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("[Int]")
+		result, errs := ParseType("[Int]", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -108,7 +108,7 @@ func TestParseArrayType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("[Int ; 2 ]")
+		result, errs := ParseType("[Int ; 2 ]", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -147,7 +147,7 @@ func TestParseOptionalType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("Int?")
+		result, errs := ParseType("Int?", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -168,7 +168,7 @@ func TestParseOptionalType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("Int??")
+		result, errs := ParseType("Int??", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -192,7 +192,7 @@ func TestParseOptionalType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("Int???")
+		result, errs := ParseType("Int???", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -224,7 +224,7 @@ func TestParseReferenceType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("&Int")
+		result, errs := ParseType("&Int", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -246,7 +246,7 @@ func TestParseReferenceType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("auth &Int")
+		result, errs := ParseType("auth &Int", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -273,7 +273,7 @@ func TestParseOptionalReferenceType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("&Int?")
+		result, errs := ParseType("&Int?", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -303,7 +303,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("T{}")
+		result, errs := ParseType("T{}", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -328,7 +328,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("T{U}")
+		result, errs := ParseType("T{U}", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -360,7 +360,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("T{U , V }")
+		result, errs := ParseType("T{U , V }", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -398,7 +398,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("{}")
+		result, errs := ParseType("{}", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -416,7 +416,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("{ T }")
+		result, errs := ParseType("{ T }", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -442,7 +442,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("{ T , }")
+		result, errs := ParseType("{ T , }", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -476,7 +476,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("{ T U }")
+		result, errs := ParseType("{ T U }", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -495,7 +495,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("{ T , U : V }")
+		result, errs := ParseType("{ T , U : V }", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -514,7 +514,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("T{U , V : W }")
+		result, errs := ParseType("T{U , V : W }", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -533,7 +533,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("{[T]}")
+		result, errs := ParseType("{[T]}", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -552,7 +552,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("T{[U]}")
+		result, errs := ParseType("T{[U]}", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -571,7 +571,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("{T, [U]}")
+		result, errs := ParseType("{T, [U]}", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -590,7 +590,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("T{U, [V]}")
+		result, errs := ParseType("T{U, [V]}", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -609,7 +609,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("{")
+		result, errs := ParseType("{", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -627,7 +627,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("T{")
+		result, errs := ParseType("T{", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -645,7 +645,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("{U")
+		result, errs := ParseType("{U", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -663,7 +663,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("T{U")
+		result, errs := ParseType("T{U", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -681,7 +681,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("{U,")
+		result, errs := ParseType("{U,", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -699,7 +699,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("T{U,")
+		result, errs := ParseType("T{U,", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -717,7 +717,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("{,}")
+		result, errs := ParseType("{,}", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -735,7 +735,7 @@ func TestParseRestrictedType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("T{,}")
+		result, errs := ParseType("T{,}", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -758,7 +758,7 @@ func TestParseDictionaryType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("{T: U}")
+		result, errs := ParseType("{T: U}", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -788,7 +788,7 @@ func TestParseDictionaryType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("{T:}")
+		result, errs := ParseType("{T:}", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -821,7 +821,7 @@ func TestParseDictionaryType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("{:}")
+		result, errs := ParseType("{:}", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -839,7 +839,7 @@ func TestParseDictionaryType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("{:U}")
+		result, errs := ParseType("{:U}", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -858,7 +858,7 @@ func TestParseDictionaryType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("{T:U,}")
+		result, errs := ParseType("{T:U,}", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -877,7 +877,7 @@ func TestParseDictionaryType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("{T:U:}")
+		result, errs := ParseType("{T:U:}", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -896,7 +896,7 @@ func TestParseDictionaryType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("{T::U}")
+		result, errs := ParseType("{T::U}", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -915,7 +915,7 @@ func TestParseDictionaryType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("{T:")
+		result, errs := ParseType("{T:", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -933,7 +933,7 @@ func TestParseDictionaryType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("{T:U")
+		result, errs := ParseType("{T:U", nil)
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
@@ -956,7 +956,7 @@ func TestParseFunctionType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("(():Void)")
+		result, errs := ParseType("(():Void)", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -985,7 +985,7 @@ func TestParseFunctionType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("( ( String , Bool , @R ) : Int)")
+		result, errs := ParseType("( ( String , Bool , @R ) : Int)", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1040,6 +1040,36 @@ func TestParseFunctionType(t *testing.T) {
 			result,
 		)
 	})
+
+	t.Run("view, no parameters, Void return type", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseType("(view ():Void)", nil)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.FunctionType{
+				PurityAnnotation:         ast.FunctionPurityView,
+				ParameterTypeAnnotations: nil,
+				ReturnTypeAnnotation: &ast.TypeAnnotation{
+					IsResource: false,
+					Type: &ast.NominalType{
+						Identifier: ast.Identifier{
+							Identifier: "Void",
+							Pos:        ast.Position{Line: 1, Column: 9, Offset: 9},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 9, Offset: 9},
+				},
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 1, Column: 13, Offset: 13},
+				},
+			},
+			result,
+		)
+	})
 }
 
 func TestParseInstantiationType(t *testing.T) {
@@ -1050,7 +1080,7 @@ func TestParseInstantiationType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("T<>")
+		result, errs := ParseType("T<>", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1072,7 +1102,7 @@ func TestParseInstantiationType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("T<U>")
+		result, errs := ParseType("T<U>", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1106,7 +1136,7 @@ func TestParseInstantiationType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("T< U >")
+		result, errs := ParseType("T< U >", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1140,7 +1170,7 @@ func TestParseInstantiationType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("T< U , @V >")
+		result, errs := ParseType("T< U , @V >", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1184,7 +1214,7 @@ func TestParseInstantiationType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("T<U>")
+		result, errs := ParseType("T<U>", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1218,7 +1248,7 @@ func TestParseInstantiationType(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseType("T< U< V >  >")
+		result, errs := ParseType("T< U< V >  >", nil)
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
@@ -1271,7 +1301,7 @@ func TestParseParametersAndArrayTypes(t *testing.T) {
 
 	result, errs := ParseProgram(`
 		pub fun test(a: Int32, b: [Int32; 2], c: [[Int32; 3]]): [[Int64]] {}
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -1428,7 +1458,7 @@ func TestParseDictionaryTypeInVariableDeclaration(t *testing.T) {
 
 	result, errs := ParseProgram(`
 	    let x: {String: Int} = {}
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -1490,7 +1520,7 @@ func TestParseIntegerTypes(t *testing.T) {
 		let f: UInt16 = 6
 		let g: UInt32 = 7
 		let h: UInt64 = 8
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	a := &ast.VariableDeclaration{
@@ -1755,7 +1785,7 @@ func TestParseFunctionTypeInVariableDeclaration(t *testing.T) {
 
 	result, errs := ParseProgram(`
 		let add: ((Int8, Int16): Int32) = nothing
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -1831,7 +1861,7 @@ func TestParseFunctionArrayType(t *testing.T) {
 
 	result, errs := ParseProgram(`
 		let test: [((Int8): Int16); 2] = []
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -1913,7 +1943,7 @@ func TestParseFunctionTypeWithArrayReturnType(t *testing.T) {
 
 	result, errs := ParseProgram(`
 		let test: ((Int8): [Int16; 2]) = nothing
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -1994,7 +2024,7 @@ func TestParseFunctionTypeWithFunctionReturnTypeInParentheses(t *testing.T) {
 
 	result, errs := ParseProgram(`
 		let test: ((Int8): ((Int16): Int32)) = nothing
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -2082,7 +2112,7 @@ func TestParseFunctionTypeWithFunctionReturnType(t *testing.T) {
 
 	result, errs := ParseProgram(`
 		let test: ((Int8): ((Int16): Int32)) = nothing
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -2170,7 +2200,7 @@ func TestParseOptionalTypeDouble(t *testing.T) {
 
 	result, errs := ParseProgram(`
        let x: Int?? = 1
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -2223,7 +2253,7 @@ func TestParseFunctionTypeWithResourceTypeAnnotation(t *testing.T) {
 
 	result, errs := ParseProgram(`
         let f: ((): @R) = g
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -2278,7 +2308,7 @@ func TestParseReferenceTypeInVariableDeclaration(t *testing.T) {
 
 	result, errs := ParseProgram(`
        let x: &[&R] = 1
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -2337,7 +2367,7 @@ func TestParseOptionalReference(t *testing.T) {
 
 	result, errs := ParseProgram(`
        let x: &R? = 1
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -2390,7 +2420,7 @@ func TestParseRestrictedReferenceTypeWithBaseType(t *testing.T) {
 
 	result, errs := ParseProgram(`
        let x: &R{I} = 1
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -2454,7 +2484,7 @@ func TestParseRestrictedReferenceTypeWithoutBaseType(t *testing.T) {
 
 	result, errs := ParseProgram(`
        let x: &{I} = 1
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -2512,7 +2542,7 @@ func TestParseOptionalRestrictedType(t *testing.T) {
 
 	result, errs := ParseProgram(`
        let x: @R{I}? = 1
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -2576,7 +2606,7 @@ func TestParseOptionalRestrictedTypeOnlyRestrictions(t *testing.T) {
 
 	result, errs := ParseProgram(`
        let x: @{I}? = 1
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -2634,7 +2664,7 @@ func TestParseAuthorizedReferenceType(t *testing.T) {
 
 	result, errs := ParseProgram(`
        let x: auth &R = 1
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -2686,7 +2716,7 @@ func TestParseInstantiationTypeInVariableDeclaration(t *testing.T) {
 
 	result, errs := ParseProgram(`
       let a: MyContract.MyStruct<Int, @R > = b
-	`)
+	`, nil)
 	require.Empty(t, errs)
 
 	utils.AssertEqualWithDiff(t,
@@ -2762,7 +2792,7 @@ func TestParseConstantSizedSizedArrayWithTrailingUnderscoreSize(t *testing.T) {
 
 	_, errs := ParseDeclarations(`
 	  let T:[d;0_]=0
-	`)
+	`, nil)
 
 	utils.AssertEqualWithDiff(t,
 		[]error{
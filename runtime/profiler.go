@@ -0,0 +1,356 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// ProfileFrame is a frame of a profiled call stack:
+// A line of a function in a location
+//
+type ProfileFrame struct {
+	Location common.Location
+	Function string
+	Line     int
+}
+
+// ProfileSample is the computation intensity and the wall time attributed to a call stack
+//
+type ProfileSample struct {
+	// Stack is the call stack, innermost frame first
+	Stack       []ProfileFrame
+	Computation uint64
+	WallTime    time.Duration
+}
+
+// topLevelFunctionName is the function name of code outside of functions
+//
+const topLevelFunctionName = "<top-level>"
+
+// Profiler attributes the computation intensity and the wall time of executions
+// to the call stacks of Cadence functions.
+//
+// The call stack is tracked using the function invocation and return handlers of the interpreter,
+// the innermost frame using the statement handler,
+// and the computation intensity using the computation metering handler.
+//
+// The profile can be written in the pprof format, e.g. to render flame graphs using `go tool pprof`.
+// A profiler is not safe for concurrent use
+//
+type Profiler struct {
+	samples        map[string]*ProfileSample
+	functionNames  map[*ast.Program]profilerFunctionNames
+	stack          []ProfileFrame
+	leaf           ProfileFrame
+	hasLeaf        bool
+	executionDepth int
+	start          time.Time
+	last           time.Time
+	duration       time.Duration
+	now            func() time.Time
+}
+
+func NewProfiler() *Profiler {
+	return &Profiler{
+		samples:       map[string]*ProfileSample{},
+		functionNames: map[*ast.Program]profilerFunctionNames{},
+		now:           time.Now,
+	}
+}
+
+// Samples returns the samples of the profile, ordered by call stack
+//
+func (p *Profiler) Samples() []ProfileSample {
+	keys := make([]string, 0, len(p.samples))
+	for key := range p.samples { //nolint:maprangecheck
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	samples := make([]ProfileSample, 0, len(keys))
+	for _, key := range keys {
+		samples = append(samples, *p.samples[key])
+	}
+	return samples
+}
+
+// Duration returns the total wall time of all profiled executions
+//
+func (p *Profiler) Duration() time.Duration {
+	return p.duration
+}
+
+// beginExecution is called when an execution begins.
+// It returns a function which must be called when the execution ends,
+// which restores the call stack, even if the execution was aborted.
+//
+// Executions may be nested, e.g. when a transaction deploys a contract
+//
+func (p *Profiler) beginExecution() (end func()) {
+	if p.executionDepth == 0 {
+		now := p.now()
+		if p.start.IsZero() {
+			p.start = now
+		}
+		p.last = now
+	} else {
+		p.advance()
+	}
+	p.executionDepth++
+
+	stackDepth := len(p.stack)
+	leaf := p.leaf
+	hasLeaf := p.hasLeaf
+
+	// The nested execution is called from the current line
+
+	if p.hasLeaf {
+		p.stack = append(p.stack, p.leaf)
+		p.hasLeaf = false
+	}
+
+	return func() {
+		p.advance()
+
+		p.stack = p.stack[:stackDepth]
+		p.leaf = leaf
+		p.hasLeaf = hasLeaf
+
+		p.executionDepth--
+	}
+}
+
+// advance attributes the wall time since the last event to the current call stack
+//
+func (p *Profiler) advance() {
+	now := p.now()
+	elapsed := now.Sub(p.last)
+	p.last = now
+
+	if elapsed <= 0 {
+		return
+	}
+
+	p.duration += elapsed
+
+	sample := p.currentSample()
+	if sample == nil {
+		return
+	}
+	sample.WallTime += elapsed
+}
+
+// currentSample returns the sample for the current call stack,
+// or nil if there is no current call stack
+//
+func (p *Profiler) currentSample() *ProfileSample {
+	frameCount := len(p.stack)
+	if p.hasLeaf {
+		frameCount++
+	}
+	if frameCount == 0 {
+		return nil
+	}
+
+	stack := make([]ProfileFrame, 0, frameCount)
+	if p.hasLeaf {
+		stack = append(stack, p.leaf)
+	}
+	for i := len(p.stack) - 1; i >= 0; i-- {
+		stack = append(stack, p.stack[i])
+	}
+
+	key := profileStackKey(stack)
+
+	sample, ok := p.samples[key]
+	if !ok {
+		sample = &ProfileSample{
+			Stack: stack,
+		}
+		p.samples[key] = sample
+	}
+
+	return sample
+}
+
+// profileStackKey returns a key which uniquely identifies the given call stack.
+// Keys of stacks with the same outermost frames sort next to each other
+//
+func profileStackKey(stack []ProfileFrame) string {
+	var builder strings.Builder
+	for i := len(stack) - 1; i >= 0; i-- {
+		frame := stack[i]
+		if frame.Location != nil {
+			builder.WriteString(string(frame.Location.ID()))
+		}
+		builder.WriteByte(0)
+		builder.WriteString(frame.Function)
+		builder.WriteByte(0)
+		builder.WriteString(strconv.Itoa(frame.Line))
+		builder.WriteByte(1)
+	}
+	return builder.String()
+}
+
+// functionName returns the name of the function declaration which contains the given statement,
+// qualified with the names of the containing composite declarations.
+// The function names of all statements of a program are determined once, on first use
+//
+func (p *Profiler) functionName(inter *interpreter.Interpreter, statement ast.Statement) string {
+	if inter.Program == nil || inter.Program.Program == nil {
+		return topLevelFunctionName
+	}
+
+	program := inter.Program.Program
+
+	functionNames, ok := p.functionNames[program]
+	if !ok {
+		functionNames = newProfilerFunctionNames(program)
+		p.functionNames[program] = functionNames
+	}
+
+	return functionNames.name(statement)
+}
+
+// profilerFunctionNames are the names of the function declarations
+// which contain the statements of a program.
+// Statements of function expressions belong to the enclosing function declaration
+//
+type profilerFunctionNames map[ast.Statement]string
+
+func newProfilerFunctionNames(program *ast.Program) profilerFunctionNames {
+	functionNames := profilerFunctionNames{}
+
+	var visit func(element ast.Element, names []string, functionName string)
+	visit = func(element ast.Element, names []string, functionName string) {
+
+		// A nested function declaration is itself a statement of the enclosing function
+
+		if statement, ok := element.(ast.Statement); ok && functionName != "" {
+			functionNames[statement] = functionName
+		}
+
+		switch element := element.(type) {
+		case *ast.CompositeDeclaration:
+			names = appendName(names, element.Identifier.Identifier)
+
+		case *ast.FunctionDeclaration:
+			names = appendName(names, element.Identifier.Identifier)
+			functionName = strings.Join(names, ".")
+
+		case *ast.SpecialFunctionDeclaration:
+			names = appendName(names, element.FunctionDeclaration.Identifier.Identifier)
+			functionName = strings.Join(names, ".")
+		}
+
+		element.Walk(func(child ast.Element) {
+			visit(child, names, functionName)
+		})
+	}
+
+	for _, declaration := range program.Declarations() {
+		visit(declaration, nil, "")
+	}
+
+	return functionNames
+}
+
+// appendName returns the given names with the given name appended,
+// without modifying the given names
+//
+func appendName(names []string, name string) []string {
+	result := make([]string, len(names), len(names)+1)
+	copy(result, names)
+	return append(result, name)
+}
+
+func (n profilerFunctionNames) name(statement ast.Statement) string {
+	name, ok := n[statement]
+	if !ok {
+		return topLevelFunctionName
+	}
+	return name
+}
+
+func (p *Profiler) onStatement(inter *interpreter.Interpreter, statement ast.Statement) {
+	p.advance()
+
+	p.leaf = ProfileFrame{
+		Location: inter.Location,
+		Function: p.functionName(inter, statement),
+		Line:     statement.StartPosition().Line,
+	}
+	p.hasLeaf = true
+}
+
+func (p *Profiler) onFunctionInvocation(inter *interpreter.Interpreter, line int) {
+	p.advance()
+
+	// The invoked function is called from the invocation's line,
+	// in the function of the statement which contains the invocation, if any.
+	// The innermost frame is unknown until the first statement of the invoked function
+
+	functionName := topLevelFunctionName
+	if p.hasLeaf {
+		functionName = p.leaf.Function
+	}
+
+	p.stack = append(
+		p.stack,
+		ProfileFrame{
+			Location: inter.Location,
+			Function: functionName,
+			Line:     line,
+		},
+	)
+	p.hasLeaf = false
+}
+
+func (p *Profiler) onInvokedFunctionReturn() {
+	p.advance()
+
+	// Continue in the calling function, at the invocation's line
+
+	lastIndex := len(p.stack) - 1
+	if lastIndex < 0 {
+		return
+	}
+
+	p.leaf = p.stack[lastIndex]
+	p.hasLeaf = true
+	p.stack = p.stack[:lastIndex]
+}
+
+func (p *Profiler) onMeterComputation(intensity uint) {
+	p.advance()
+
+	sample := p.currentSample()
+	if sample == nil {
+		return
+	}
+	sample.Computation += uint64(intensity)
+}
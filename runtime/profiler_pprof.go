@@ -0,0 +1,295 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// WritePprof writes the profile in the gzip-compressed protobuf format of pprof
+// (https://github.com/google/pprof/blob/master/proto/profile.proto).
+//
+// Functions are named by their location and their qualified name, e.g. `A.0000000000000001.Counter.increment`.
+// Each sample has two values: the computation intensity, and the wall time in nanoseconds
+//
+func (p *Profiler) WritePprof(writer io.Writer) error {
+	gzipWriter := gzip.NewWriter(writer)
+
+	_, err := gzipWriter.Write(p.encodePprof())
+	if err != nil {
+		return err
+	}
+
+	return gzipWriter.Close()
+}
+
+// Field numbers of the pprof protobuf messages
+//
+const (
+	pprofProfileSampleType        = 1
+	pprofProfileSample            = 2
+	pprofProfileLocation          = 4
+	pprofProfileFunction          = 5
+	pprofProfileStringTable       = 6
+	pprofProfileTimeNanos         = 9
+	pprofProfileDurationNanos     = 10
+	pprofProfilePeriodType        = 11
+	pprofProfilePeriod            = 12
+	pprofProfileDefaultSampleType = 14
+
+	pprofValueTypeType = 1
+	pprofValueTypeUnit = 2
+
+	pprofSampleLocationID = 1
+	pprofSampleValue      = 2
+
+	pprofLocationID   = 1
+	pprofLocationLine = 4
+
+	pprofLineFunctionID = 1
+	pprofLineLine       = 2
+
+	pprofFunctionID         = 1
+	pprofFunctionName       = 2
+	pprofFunctionSystemName = 3
+	pprofFunctionFilename   = 4
+)
+
+type pprofFunctionKey struct {
+	locationID common.LocationID
+	name       string
+}
+
+type pprofLocationKey struct {
+	functionID uint64
+	line       int
+}
+
+// pprofEncoder encodes a profile.
+// It deduplicates strings, functions, and locations
+//
+type pprofEncoder struct {
+	profile     protobufEncoder
+	strings     map[string]int64
+	functions   map[pprofFunctionKey]uint64
+	locations   map[pprofLocationKey]uint64
+	stringTable []string
+}
+
+func (e *pprofEncoder) string(s string) int64 {
+	index, ok := e.strings[s]
+	if !ok {
+		index = int64(len(e.stringTable))
+		e.strings[s] = index
+		e.stringTable = append(e.stringTable, s)
+	}
+	return index
+}
+
+func (e *pprofEncoder) valueType(field int, typ, unit string) {
+	e.profile.message(field, func(valueType *protobufEncoder) {
+		valueType.int64(pprofValueTypeType, e.string(typ))
+		valueType.int64(pprofValueTypeUnit, e.string(unit))
+	})
+}
+
+func (e *pprofEncoder) function(frame ProfileFrame) uint64 {
+	var locationID common.LocationID
+	fileName := ""
+	if frame.Location != nil {
+		locationID = frame.Location.ID()
+		fileName = string(locationID)
+		if stringLocation, ok := frame.Location.(common.StringLocation); ok {
+			fileName = string(stringLocation)
+		}
+	}
+
+	key := pprofFunctionKey{
+		locationID: locationID,
+		name:       frame.Function,
+	}
+
+	id, ok := e.functions[key]
+	if ok {
+		return id
+	}
+
+	id = uint64(len(e.functions) + 1)
+	e.functions[key] = id
+
+	name := frame.Function
+	if locationID != "" {
+		name = fmt.Sprintf("%s.%s", locationID, frame.Function)
+	}
+
+	e.profile.message(pprofProfileFunction, func(function *protobufEncoder) {
+		function.uint64(pprofFunctionID, id)
+		function.int64(pprofFunctionName, e.string(name))
+		function.int64(pprofFunctionSystemName, e.string(name))
+		function.int64(pprofFunctionFilename, e.string(fileName))
+	})
+
+	return id
+}
+
+func (e *pprofEncoder) location(frame ProfileFrame) uint64 {
+	functionID := e.function(frame)
+
+	key := pprofLocationKey{
+		functionID: functionID,
+		line:       frame.Line,
+	}
+
+	id, ok := e.locations[key]
+	if ok {
+		return id
+	}
+
+	id = uint64(len(e.locations) + 1)
+	e.locations[key] = id
+
+	e.profile.message(pprofProfileLocation, func(location *protobufEncoder) {
+		location.uint64(pprofLocationID, id)
+		location.message(pprofLocationLine, func(line *protobufEncoder) {
+			line.uint64(pprofLineFunctionID, functionID)
+			line.int64(pprofLineLine, int64(frame.Line))
+		})
+	})
+
+	return id
+}
+
+func (p *Profiler) encodePprof() []byte {
+	encoder := &pprofEncoder{
+		strings:   map[string]int64{},
+		functions: map[pprofFunctionKey]uint64{},
+		locations: map[pprofLocationKey]uint64{},
+	}
+
+	// The first string of the string table must be the empty string
+	encoder.string("")
+
+	const computationType = "computation"
+	const computationUnit = "intensity"
+
+	encoder.valueType(pprofProfileSampleType, computationType, computationUnit)
+	encoder.valueType(pprofProfileSampleType, "wall", "nanoseconds")
+
+	for _, sample := range p.Samples() {
+		locationIDs := make([]uint64, 0, len(sample.Stack))
+		for _, frame := range sample.Stack {
+			locationIDs = append(locationIDs, encoder.location(frame))
+		}
+
+		values := []int64{
+			int64(sample.Computation),
+			sample.WallTime.Nanoseconds(),
+		}
+
+		encoder.profile.message(pprofProfileSample, func(sample *protobufEncoder) {
+			sample.packedUint64s(pprofSampleLocationID, locationIDs)
+			sample.packedInt64s(pprofSampleValue, values)
+		})
+	}
+
+	if !p.start.IsZero() {
+		encoder.profile.int64(pprofProfileTimeNanos, p.start.UnixNano())
+	}
+	encoder.profile.int64(pprofProfileDurationNanos, p.duration.Nanoseconds())
+
+	encoder.valueType(pprofProfilePeriodType, computationType, computationUnit)
+	encoder.profile.int64(pprofProfilePeriod, 1)
+	encoder.profile.int64(pprofProfileDefaultSampleType, encoder.string(computationType))
+
+	// The string table is written last, as it is filled while encoding the other fields
+
+	for _, s := range encoder.stringTable {
+		encoder.profile.string(pprofProfileStringTable, s)
+	}
+
+	return encoder.profile.data
+}
+
+// protobufEncoder is a minimal encoder for the protobuf wire format
+//
+type protobufEncoder struct {
+	data []byte
+}
+
+const (
+	protobufWireTypeVarint          = 0
+	protobufWireTypeLengthDelimited = 2
+)
+
+func (e *protobufEncoder) varint(x uint64) {
+	for x >= 0x80 {
+		e.data = append(e.data, byte(x)|0x80)
+		x >>= 7
+	}
+	e.data = append(e.data, byte(x))
+}
+
+func (e *protobufEncoder) tag(field int, wireType int) {
+	e.varint(uint64(field)<<3 | uint64(wireType))
+}
+
+func (e *protobufEncoder) uint64(field int, x uint64) {
+	e.tag(field, protobufWireTypeVarint)
+	e.varint(x)
+}
+
+func (e *protobufEncoder) int64(field int, x int64) {
+	e.uint64(field, uint64(x))
+}
+
+func (e *protobufEncoder) bytes(field int, b []byte) {
+	e.tag(field, protobufWireTypeLengthDelimited)
+	e.varint(uint64(len(b)))
+	e.data = append(e.data, b...)
+}
+
+func (e *protobufEncoder) string(field int, s string) {
+	e.bytes(field, []byte(s))
+}
+
+func (e *protobufEncoder) packedUint64s(field int, xs []uint64) {
+	var packed protobufEncoder
+	for _, x := range xs {
+		packed.varint(x)
+	}
+	e.bytes(field, packed.data)
+}
+
+func (e *protobufEncoder) packedInt64s(field int, xs []int64) {
+	var packed protobufEncoder
+	for _, x := range xs {
+		packed.varint(uint64(x))
+	}
+	e.bytes(field, packed.data)
+}
+
+func (e *protobufEncoder) message(field int, encode func(*protobufEncoder)) {
+	var message protobufEncoder
+	encode(&message)
+	e.bytes(field, message.data)
+}
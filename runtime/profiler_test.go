@@ -0,0 +1,369 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser2"
+)
+
+func newProfilerTestRuntimeInterface(
+	meterComputation func(compKind common.ComputationKind, intensity uint) error,
+) *testRuntimeInterface {
+	return &testRuntimeInterface{
+		getCode: func(location Location) (bytes []byte, err error) {
+			switch location {
+			case common.StringLocation("imported"):
+				return []byte(`
+                  pub fun answer(): Int {
+                    var i = 0
+                    while i < 42 {
+                      i = i + 1
+                    }
+                    return i
+                  }
+                `), nil
+			default:
+				return nil, fmt.Errorf("unknown import location: %s", location)
+			}
+		},
+		meterComputation: meterComputation,
+	}
+}
+
+const profilerTestScript = `
+  import "imported"
+
+  pub fun main(): Int {
+      return answer()
+  }
+`
+
+func TestRuntimeProfiler(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	profiler := NewProfiler()
+	runtime.SetProfiler(profiler)
+
+	var totalComputation uint64
+
+	runtimeInterface := newProfilerTestRuntimeInterface(
+		func(_ common.ComputationKind, intensity uint) error {
+			totalComputation += uint64(intensity)
+			return nil
+		},
+	)
+
+	_, err := runtime.ExecuteScript(
+		Script{
+			Source: []byte(profilerTestScript),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  newTransactionLocationGenerator()(),
+		},
+	)
+	require.NoError(t, err)
+
+	mainFrame := ProfileFrame{
+		Location: common.TransactionLocation{0x0},
+		Function: "main",
+		Line:     5,
+	}
+
+	loopBodyFrame := ProfileFrame{
+		Location: common.StringLocation("imported"),
+		Function: "answer",
+		Line:     5,
+	}
+
+	var profiledComputation uint64
+	var loopBodySample *ProfileSample
+
+	samples := profiler.Samples()
+
+	for i, sample := range samples {
+		profiledComputation += sample.Computation
+
+		// All executed code is called from main
+		require.Equal(t, mainFrame, sample.Stack[len(sample.Stack)-1])
+
+		if len(sample.Stack) == 2 && assert.ObjectsAreEqual(loopBodyFrame, sample.Stack[0]) {
+			loopBodySample = &samples[i]
+		}
+	}
+
+	assert.Equal(t, totalComputation, profiledComputation)
+
+	require.NotNil(t, loopBodySample)
+	assert.GreaterOrEqual(t, loopBodySample.Computation, uint64(42))
+
+	// The call stack is empty after the execution
+
+	assert.Empty(t, profiler.stack)
+	assert.False(t, profiler.hasLeaf)
+}
+
+func TestRuntimeProfilerComputationLimit(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	profiler := NewProfiler()
+	runtime.SetProfiler(profiler)
+
+	limitErr := errors.New("computation limit exceeded")
+
+	var computation uint
+	const computationLimit = 50
+
+	runtimeInterface := newProfilerTestRuntimeInterface(
+		func(_ common.ComputationKind, intensity uint) error {
+			computation += intensity
+			if computation > computationLimit {
+				return limitErr
+			}
+			return nil
+		},
+	)
+
+	_, err := runtime.ExecuteScript(
+		Script{
+			Source: []byte(profilerTestScript),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  newTransactionLocationGenerator()(),
+		},
+	)
+	require.ErrorIs(t, err, limitErr)
+
+	// The computation which exceeded the limit is attributed
+
+	var profiledComputation uint64
+	for _, sample := range profiler.Samples() {
+		profiledComputation += sample.Computation
+	}
+
+	assert.Equal(t, uint64(computationLimit+1), profiledComputation)
+
+	// The call stack is restored after the aborted execution
+
+	assert.Empty(t, profiler.stack)
+	assert.False(t, profiler.hasLeaf)
+}
+
+func TestRuntimeProfilerStorage(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	signer := common.MustBytesToAddress([]byte{0x42})
+
+	computation := map[common.ComputationKind]uint64{}
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{signer}, nil
+		},
+		meterComputation: func(compKind common.ComputationKind, intensity uint) error {
+			computation[compKind] += uint64(intensity)
+			return nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: []byte(`
+              transaction {
+                 prepare(signer: AuthAccount) {
+                     signer.save("hello world", to: /storage/test)
+                 }
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	// Profile a transaction which reads from storage
+
+	profiler := NewProfiler()
+	runtime.SetProfiler(profiler)
+
+	computation = map[common.ComputationKind]uint64{}
+
+	err = runtime.ExecuteTransaction(
+		Script{
+			Source: []byte(`
+              transaction {
+                 prepare(signer: AuthAccount) {
+                     signer.copy<String>(from: /storage/test)
+                 }
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	require.NotZero(t, computation[common.ComputationKindStorageRead])
+
+	// The computation of the storage reads is attributed
+
+	var totalComputation uint64
+	for _, intensity := range computation {
+		totalComputation += intensity
+	}
+
+	var profiledComputation uint64
+	for _, sample := range profiler.Samples() {
+		profiledComputation += sample.Computation
+	}
+
+	assert.Equal(t, totalComputation, profiledComputation)
+}
+
+func TestRuntimeProfilerPprof(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	profiler := NewProfiler()
+	runtime.SetProfiler(profiler)
+
+	_, err := runtime.ExecuteScript(
+		Script{
+			Source: []byte(profilerTestScript),
+		},
+		Context{
+			Interface: newProfilerTestRuntimeInterface(nil),
+			Location:  newTransactionLocationGenerator()(),
+		},
+	)
+	require.NoError(t, err)
+
+	var buffer bytes.Buffer
+	err = profiler.WritePprof(&buffer)
+	require.NoError(t, err)
+
+	reader, err := gzip.NewReader(&buffer)
+	require.NoError(t, err)
+
+	encoded, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+
+	assert.Equal(t, profiler.encodePprof(), encoded)
+
+	// The string table contains the sample types and the qualified function names
+
+	for _, s := range []string{
+		"computation",
+		"intensity",
+		"wall",
+		"nanoseconds",
+		"t.00.main",
+		"S.imported.answer",
+	} {
+		assert.Contains(t, string(encoded), s)
+	}
+}
+
+func TestProfilerFunctionNames(t *testing.T) {
+
+	t.Parallel()
+
+	program, err := parser2.ParseProgram(`
+      pub contract C {
+
+          pub resource R {
+
+              init() {
+                  let y = 1
+              }
+
+              pub fun foo() {
+                  if true {
+                      let z = 2
+                  }
+              }
+          }
+
+          pub fun bar() { let a = 1 }; pub fun baz() { let b = 2 }
+      }
+    `, nil)
+	require.NoError(t, err)
+
+	functionNames := newProfilerFunctionNames(program)
+
+	contract := program.SoleContractDeclaration()
+	resource := contract.Members.Composites()[0]
+
+	initializer := resource.Members.Initializers()[0]
+	assert.Equal(t,
+		"C.R.init",
+		functionNames.name(initializer.FunctionDeclaration.FunctionBlock.Block.Statements[0]),
+	)
+
+	ifStatement := resource.Members.Functions()[0].FunctionBlock.Block.Statements[0]
+	assert.Equal(t, "C.R.foo", functionNames.name(ifStatement))
+	assert.Equal(t,
+		"C.R.foo",
+		functionNames.name(ifStatement.(*ast.IfStatement).Then.Statements[0]),
+	)
+
+	// Functions declared on the same line are distinguished
+
+	functions := contract.Members.Functions()
+	assert.Equal(t, "C.bar", functionNames.name(functions[0].FunctionBlock.Block.Statements[0]))
+	assert.Equal(t, "C.baz", functionNames.name(functions[1].FunctionBlock.Block.Statements[0]))
+
+	// Statements outside of function declarations have no function name
+
+	assert.Equal(t,
+		topLevelFunctionName,
+		functionNames.name(&ast.ExpressionStatement{}),
+	)
+}
@@ -0,0 +1,331 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// RegisterKey identifies a register of the ledger
+//
+type RegisterKey struct {
+	Owner string
+	Key   string
+}
+
+// IsLess returns true if the key is ordered before the other key
+//
+func (k RegisterKey) IsLess(other RegisterKey) bool {
+	if k.Owner != other.Owner {
+		return k.Owner < other.Owner
+	}
+	return k.Key < other.Key
+}
+
+// RegisterSet is a set of registers
+//
+type RegisterSet map[RegisterKey]struct{}
+
+func (s RegisterSet) Add(owner, key []byte) {
+	s[RegisterKey{
+		Owner: string(owner),
+		Key:   string(key),
+	}] = struct{}{}
+}
+
+func (s RegisterSet) Contains(key RegisterKey) bool {
+	_, ok := s[key]
+	return ok
+}
+
+// Keys returns the keys of the registers in the set, in order
+//
+func (s RegisterSet) Keys() []RegisterKey {
+	keys := make([]RegisterKey, 0, len(s))
+	for key := range s { //nolint:maprangecheck
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].IsLess(keys[j])
+	})
+	return keys
+}
+
+// RegisterAccesses records the registers of the ledger which were read and written.
+//
+// Registers which are accessed by the host itself, e.g. when it allocates a storage index,
+// updates contract code, or adds an account key, are recorded as synthetic registers,
+// e.g. the contract code of contract C of an account is recorded as register "code.C" of the account.
+// UUIDs and account addresses are generated from the synthetic registers "uuid" and "account_address_state"
+// of the empty address
+//
+type RegisterAccesses struct {
+	// ReadSet are the registers which were read, or checked for existence
+	ReadSet RegisterSet
+	// WriteSet are the registers which were written
+	WriteSet RegisterSet
+}
+
+func NewRegisterAccesses() *RegisterAccesses {
+	return &RegisterAccesses{
+		ReadSet:  RegisterSet{},
+		WriteSet: RegisterSet{},
+	}
+}
+
+// Conflicts returns the registers which are written by one of the accesses
+// and read or written by the other, in order.
+// Executions with conflicting accesses must not be executed in parallel
+//
+func (a *RegisterAccesses) Conflicts(other *RegisterAccesses) []RegisterKey {
+	conflicts := RegisterSet{}
+
+	addConflicts := func(writeSet RegisterSet, accesses *RegisterAccesses) {
+		for key := range writeSet { //nolint:maprangecheck
+			if accesses.ReadSet.Contains(key) || accesses.WriteSet.Contains(key) {
+				conflicts[key] = struct{}{}
+			}
+		}
+	}
+
+	addConflicts(a.WriteSet, other)
+	addConflicts(other.WriteSet, a)
+
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	return conflicts.Keys()
+}
+
+// ConflictsWith returns true if the accesses conflict with the other accesses.
+// See Conflicts
+//
+func (a *RegisterAccesses) ConflictsWith(other *RegisterAccesses) bool {
+	return len(a.Conflicts(other)) > 0
+}
+
+// ExecutionResult is the result of an execution
+//
+type ExecutionResult struct {
+	// Value is the result of a script execution. It is nil for transactions
+	Value cadence.Value
+	// RegisterAccesses are the registers which were read and written by the execution
+	*RegisterAccesses
+}
+
+// The registers which the host accesses internally, e.g. when it allocates a storage index,
+// are recorded as synthetic registers of the account, named like the registers of the host
+//
+const (
+	contractCodeRegisterKeyPrefix = "code."
+	contractNamesRegisterKey      = "contract_names"
+	storageIndexRegisterKey       = "storage_index"
+	storageUsedRegisterKey        = "storage_used"
+	accountKeyCountRegisterKey    = "public_key_count"
+	accountKeyRegisterKeyPrefix   = "public_key_"
+	uuidRegisterKey               = "uuid"
+	addressStateRegisterKey       = "account_address_state"
+)
+
+func contractCodeRegisterKey(name string) []byte {
+	return []byte(contractCodeRegisterKeyPrefix + name)
+}
+
+func accountKeyRegisterKey(index int) []byte {
+	return []byte(accountKeyRegisterKeyPrefix + strconv.Itoa(index))
+}
+
+// registerRecordingInterface is a runtime interface which records
+// the registers accessed through the wrapped interface:
+// The registers read from and written to the ledger, including the registers of slabs,
+// and the synthetic registers which the host accesses internally
+//
+type registerRecordingInterface struct {
+	Interface
+	accesses *RegisterAccesses
+}
+
+var _ Interface = &registerRecordingInterface{}
+var _ Metrics = &registerRecordingInterface{}
+
+func newRegisterRecordingInterface(
+	runtimeInterface Interface,
+	accesses *RegisterAccesses,
+) *registerRecordingInterface {
+	return &registerRecordingInterface{
+		Interface: runtimeInterface,
+		accesses:  accesses,
+	}
+}
+
+func (i *registerRecordingInterface) read(owner Address, key []byte) {
+	i.accesses.ReadSet.Add(owner[:], key)
+}
+
+func (i *registerRecordingInterface) write(owner Address, key []byte) {
+	i.accesses.WriteSet.Add(owner[:], key)
+}
+
+func (i *registerRecordingInterface) GetValue(owner, key []byte) ([]byte, error) {
+	i.accesses.ReadSet.Add(owner, key)
+	return i.Interface.GetValue(owner, key)
+}
+
+func (i *registerRecordingInterface) ValueExists(owner, key []byte) (bool, error) {
+	i.accesses.ReadSet.Add(owner, key)
+	return i.Interface.ValueExists(owner, key)
+}
+
+func (i *registerRecordingInterface) SetValue(owner, key, value []byte) error {
+	i.accesses.WriteSet.Add(owner, key)
+	// Every write changes the storage used by the account
+	i.accesses.WriteSet.Add(owner, []byte(storageUsedRegisterKey))
+	return i.Interface.SetValue(owner, key, value)
+}
+
+func (i *registerRecordingInterface) AllocateStorageIndex(owner []byte) (atree.StorageIndex, error) {
+	i.accesses.ReadSet.Add(owner, []byte(storageIndexRegisterKey))
+	i.accesses.WriteSet.Add(owner, []byte(storageIndexRegisterKey))
+	return i.Interface.AllocateStorageIndex(owner)
+}
+
+func (i *registerRecordingInterface) GenerateUUID() (uint64, error) {
+	i.read(Address{}, []byte(uuidRegisterKey))
+	i.write(Address{}, []byte(uuidRegisterKey))
+	return i.Interface.GenerateUUID()
+}
+
+func (i *registerRecordingInterface) CreateAccount(payer Address) (Address, error) {
+	i.read(Address{}, []byte(addressStateRegisterKey))
+	i.write(Address{}, []byte(addressStateRegisterKey))
+	return i.Interface.CreateAccount(payer)
+}
+
+func (i *registerRecordingInterface) GetCode(location Location) ([]byte, error) {
+	if addressLocation, ok := location.(common.AddressLocation); ok {
+		i.read(addressLocation.Address, contractCodeRegisterKey(addressLocation.Name))
+	}
+	return i.Interface.GetCode(location)
+}
+
+func (i *registerRecordingInterface) GetAccountContractCode(address Address, name string) ([]byte, error) {
+	i.read(address, contractCodeRegisterKey(name))
+	return i.Interface.GetAccountContractCode(address, name)
+}
+
+func (i *registerRecordingInterface) UpdateAccountContractCode(address Address, name string, code []byte) error {
+	i.write(address, contractCodeRegisterKey(name))
+	i.read(address, []byte(contractNamesRegisterKey))
+	i.write(address, []byte(contractNamesRegisterKey))
+	return i.Interface.UpdateAccountContractCode(address, name, code)
+}
+
+func (i *registerRecordingInterface) RemoveAccountContractCode(address Address, name string) error {
+	i.write(address, contractCodeRegisterKey(name))
+	i.read(address, []byte(contractNamesRegisterKey))
+	i.write(address, []byte(contractNamesRegisterKey))
+	return i.Interface.RemoveAccountContractCode(address, name)
+}
+
+func (i *registerRecordingInterface) GetAccountContractNames(address Address) ([]string, error) {
+	i.read(address, []byte(contractNamesRegisterKey))
+	return i.Interface.GetAccountContractNames(address)
+}
+
+func (i *registerRecordingInterface) AddEncodedAccountKey(address Address, publicKey []byte) error {
+	// The index of the added key is only known to the host,
+	// but any access of a key also reads the key count
+	i.read(address, []byte(accountKeyCountRegisterKey))
+	i.write(address, []byte(accountKeyCountRegisterKey))
+	return i.Interface.AddEncodedAccountKey(address, publicKey)
+}
+
+func (i *registerRecordingInterface) RevokeEncodedAccountKey(address Address, index int) ([]byte, error) {
+	i.read(address, []byte(accountKeyCountRegisterKey))
+	i.read(address, accountKeyRegisterKey(index))
+	i.write(address, accountKeyRegisterKey(index))
+	return i.Interface.RevokeEncodedAccountKey(address, index)
+}
+
+func (i *registerRecordingInterface) AddAccountKey(
+	address Address,
+	publicKey *PublicKey,
+	hashAlgo HashAlgorithm,
+	weight int,
+) (*AccountKey, error) {
+	i.read(address, []byte(accountKeyCountRegisterKey))
+	i.write(address, []byte(accountKeyCountRegisterKey))
+
+	accountKey, err := i.Interface.AddAccountKey(address, publicKey, hashAlgo, weight)
+	if accountKey != nil {
+		i.write(address, accountKeyRegisterKey(accountKey.KeyIndex))
+	}
+	return accountKey, err
+}
+
+func (i *registerRecordingInterface) GetAccountKey(address Address, index int) (*AccountKey, error) {
+	i.read(address, []byte(accountKeyCountRegisterKey))
+	i.read(address, accountKeyRegisterKey(index))
+	return i.Interface.GetAccountKey(address, index)
+}
+
+func (i *registerRecordingInterface) RevokeAccountKey(address Address, index int) (*AccountKey, error) {
+	i.read(address, []byte(accountKeyCountRegisterKey))
+	i.read(address, accountKeyRegisterKey(index))
+	i.write(address, accountKeyRegisterKey(index))
+	return i.Interface.RevokeAccountKey(address, index)
+}
+
+func (i *registerRecordingInterface) GetStorageUsed(address Address) (uint64, error) {
+	i.read(address, []byte(storageUsedRegisterKey))
+	return i.Interface.GetStorageUsed(address)
+}
+
+func (i *registerRecordingInterface) GetStorageCapacity(address Address) (uint64, error) {
+	i.read(address, []byte(storageUsedRegisterKey))
+	return i.Interface.GetStorageCapacity(address)
+}
+
+// The metrics are reported to the wrapped interface, if it supports them
+
+func (i *registerRecordingInterface) ProgramParsed(location common.Location, duration time.Duration) {
+	if metrics, ok := i.Interface.(Metrics); ok {
+		metrics.ProgramParsed(location, duration)
+	}
+}
+
+func (i *registerRecordingInterface) ProgramChecked(location common.Location, duration time.Duration) {
+	if metrics, ok := i.Interface.(Metrics); ok {
+		metrics.ProgramChecked(location, duration)
+	}
+}
+
+func (i *registerRecordingInterface) ProgramInterpreted(location common.Location, duration time.Duration) {
+	if metrics, ok := i.Interface.(Metrics); ok {
+		metrics.ProgramInterpreted(location, duration)
+	}
+}
@@ -0,0 +1,363 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+func TestRuntimeExecutionResultRegisterAccesses(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{address}, nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	storageMapRegister := RegisterKey{
+		Owner: string(address[:]),
+		Key:   "storage",
+	}
+
+	// hasSlabRegister returns true if the set contains a register of a slab of the account
+	hasSlabRegister := func(set RegisterSet) bool {
+		for _, key := range set.Keys() {
+			if key.Owner == storageMapRegister.Owner && strings.HasPrefix(key.Key, "$") {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Write
+
+	result, err := runtime.ExecuteTransactionWithResult(
+		Script{
+			Source: []byte(`
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      signer.save(42, to: /storage/answer)
+                  }
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Nil(t, result.Value)
+	assert.True(t, result.ReadSet.Contains(storageMapRegister))
+	assert.True(t, result.WriteSet.Contains(storageMapRegister))
+	assert.True(t, hasSlabRegister(result.WriteSet))
+
+	writeAccesses := result.RegisterAccesses
+
+	// Read
+
+	result, err = runtime.ExecuteScriptWithResult(
+		Script{
+			Source: []byte(`
+              pub fun main(): Int {
+                  return getAuthAccount(0x1).copy<Int>(from: /storage/answer)!
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.ScriptLocation{},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, cadence.NewInt(42), result.Value)
+	assert.True(t, result.ReadSet.Contains(storageMapRegister))
+	assert.True(t, hasSlabRegister(result.ReadSet))
+	assert.Empty(t, result.WriteSet)
+
+	readAccesses := result.RegisterAccesses
+
+	// The read depends on the write
+
+	assert.True(t, readAccesses.ConflictsWith(writeAccesses))
+	assert.Contains(t, readAccesses.Conflicts(writeAccesses), storageMapRegister)
+
+	// The accesses are also returned when the execution fails
+
+	result, err = runtime.ExecuteTransactionWithResult(
+		Script{
+			Source: []byte(`
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      signer.load<Int>(from: /storage/answer)
+                      panic("failed")
+                  }
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.Error(t, err)
+
+	require.NotNil(t, result)
+	assert.True(t, result.ReadSet.Contains(storageMapRegister))
+}
+
+func TestRuntimeExecutionResultHostRegisterAccesses(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	accountCodes := map[string][]byte{}
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{address}, nil
+		},
+		getAccountContractCode: func(_ Address, name string) ([]byte, error) {
+			return accountCodes[name], nil
+		},
+		updateAccountContractCode: func(_ Address, name string, code []byte) error {
+			accountCodes[name] = code
+			return nil
+		},
+		addAccountKey: func(
+			_ Address,
+			publicKey *PublicKey,
+			hashAlgo HashAlgorithm,
+			weight int,
+		) (*AccountKey, error) {
+			return &AccountKey{
+				PublicKey: publicKey,
+				HashAlgo:  hashAlgo,
+				Weight:    weight,
+			}, nil
+		},
+		validatePublicKey: func(_ *PublicKey) error {
+			return nil
+		},
+		emitEvent: func(_ cadence.Event) error {
+			return nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	hostRegister := func(key string) RegisterKey {
+		return RegisterKey{
+			Owner: string(address[:]),
+			Key:   key,
+		}
+	}
+
+	t.Run("contract code", func(t *testing.T) {
+
+		deployResult, err := runtime.ExecuteTransactionWithResult(
+			Script{
+				Source: []byte(fmt.Sprintf(
+					`
+                      transaction {
+                          prepare(signer: AuthAccount) {
+                              signer.contracts.add(name: "C", code: "%s".decodeHex())
+                          }
+                      }
+                    `,
+					hex.EncodeToString([]byte(`pub contract C {}`)),
+				)),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		assert.True(t, deployResult.WriteSet.Contains(hostRegister("code.C")))
+		assert.True(t, deployResult.WriteSet.Contains(hostRegister("contract_names")))
+
+		readResult, err := runtime.ExecuteScriptWithResult(
+			Script{
+				Source: []byte(`
+                  pub fun main(): Bool {
+                      return getAccount(0x1).contracts.get(name: "C") != nil
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+
+		assert.True(t, readResult.ReadSet.Contains(hostRegister("code.C")))
+		assert.Contains(t, readResult.Conflicts(deployResult.RegisterAccesses), hostRegister("code.C"))
+	})
+
+	t.Run("account keys", func(t *testing.T) {
+
+		addKey := func() *ExecutionResult {
+			result, err := runtime.ExecuteTransactionWithResult(
+				Script{
+					Source: []byte(`
+                      transaction {
+                          prepare(signer: AuthAccount) {
+                              signer.keys.add(
+                                  publicKey: PublicKey(
+                                      publicKey: "0102".decodeHex(),
+                                      signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                                  ),
+                                  hashAlgorithm: HashAlgorithm.SHA3_256,
+                                  weight: 1000.0
+                              )
+                          }
+                      }
+                    `),
+				},
+				Context{
+					Interface: runtimeInterface,
+					Location:  nextTransactionLocation(),
+				},
+			)
+			require.NoError(t, err)
+			return result
+		}
+
+		first := addKey()
+		second := addKey()
+
+		assert.True(t, first.WriteSet.Contains(hostRegister("public_key_count")))
+		assert.Contains(t, first.Conflicts(second.RegisterAccesses), hostRegister("public_key_count"))
+	})
+
+	t.Run("storage index", func(t *testing.T) {
+
+		result, err := runtime.ExecuteTransactionWithResult(
+			Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          signer.save([1, 2, 3], to: /storage/numbers)
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		assert.True(t, result.WriteSet.Contains(hostRegister("storage_index")))
+		assert.True(t, result.WriteSet.Contains(hostRegister("storage_used")))
+	})
+}
+
+func TestRegisterAccessesConflicts(t *testing.T) {
+
+	t.Parallel()
+
+	newAccesses := func(reads []string, writes []string) *RegisterAccesses {
+		accesses := NewRegisterAccesses()
+		for _, key := range reads {
+			accesses.ReadSet.Add([]byte{0x1}, []byte(key))
+		}
+		for _, key := range writes {
+			accesses.WriteSet.Add([]byte{0x1}, []byte(key))
+		}
+		return accesses
+	}
+
+	registerKey := func(key string) RegisterKey {
+		return RegisterKey{
+			Owner: string([]byte{0x1}),
+			Key:   key,
+		}
+	}
+
+	a := newAccesses([]string{"x"}, []string{"y"})
+
+	t.Run("read of write", func(t *testing.T) {
+
+		t.Parallel()
+
+		b := newAccesses([]string{"y"}, nil)
+
+		assert.Equal(t, []RegisterKey{registerKey("y")}, a.Conflicts(b))
+		assert.Equal(t, []RegisterKey{registerKey("y")}, b.Conflicts(a))
+		assert.True(t, a.ConflictsWith(b))
+	})
+
+	t.Run("write of read", func(t *testing.T) {
+
+		t.Parallel()
+
+		b := newAccesses(nil, []string{"x"})
+
+		assert.Equal(t, []RegisterKey{registerKey("x")}, a.Conflicts(b))
+		assert.True(t, b.ConflictsWith(a))
+	})
+
+	t.Run("write of write", func(t *testing.T) {
+
+		t.Parallel()
+
+		b := newAccesses(nil, []string{"z", "y"})
+
+		assert.Equal(t, []RegisterKey{registerKey("y")}, a.Conflicts(b))
+	})
+
+	t.Run("reads only", func(t *testing.T) {
+
+		t.Parallel()
+
+		b := newAccesses([]string{"x"}, []string{"z"})
+
+		assert.Nil(t, a.Conflicts(b))
+		assert.False(t, a.ConflictsWith(b))
+	})
+}
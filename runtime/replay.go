@@ -0,0 +1,1084 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onflow/atree"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// InterfaceCall is a recorded call of a function of the runtime interface:
+// The encoded arguments, the encoded results, and the error message, if the call failed
+//
+type InterfaceCall struct {
+	Function  string            `json:"function"`
+	Arguments []json.RawMessage `json:"arguments,omitempty"`
+	Results   []json.RawMessage `json:"results,omitempty"`
+	Error     *string           `json:"error,omitempty"`
+}
+
+func (c InterfaceCall) String() string {
+	arguments := make([]string, len(c.Arguments))
+	for i, argument := range c.Arguments {
+		arguments[i] = string(argument)
+	}
+	return fmt.Sprintf("%s(%s)", c.Function, strings.Join(arguments, ", "))
+}
+
+// RecordedError is an error which was returned by a recorded call,
+// and is returned again when the call is replayed
+//
+type RecordedError struct {
+	Message string
+}
+
+func (e RecordedError) Error() string {
+	return e.Message
+}
+
+// ReplayDivergenceError is returned when the runtime calls the replaying interface
+// differently than it called the recorded interface
+//
+type ReplayDivergenceError struct {
+	// Index is the index of the call in the recording
+	Index int
+	// Expected is the recorded call, or nil if the recording has no further calls
+	Expected *InterfaceCall
+	// Actual is the call the runtime performed, or nil if the runtime performed no further calls
+	Actual *InterfaceCall
+}
+
+func (e ReplayDivergenceError) Error() string {
+	expected := "no further call"
+	if e.Expected != nil {
+		expected = e.Expected.String()
+	}
+	actual := "no further call"
+	if e.Actual != nil {
+		actual = e.Actual.String()
+	}
+	return fmt.Sprintf(
+		"replay diverged from recording at call %d: expected %s, got %s",
+		e.Index,
+		expected,
+		actual,
+	)
+}
+
+// recordedLocation is the encoding of a location in a recording
+//
+type recordedLocation struct {
+	Type    string `json:"type"`
+	Address string `json:"address,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Data    string `json:"data,omitempty"`
+}
+
+func newRecordedLocation(location Location) *recordedLocation {
+	switch location := location.(type) {
+	case nil:
+		return nil
+
+	case common.AddressLocation:
+		return &recordedLocation{
+			Type:    common.AddressLocationPrefix,
+			Address: location.Address.Hex(),
+			Name:    location.Name,
+		}
+
+	case common.StringLocation:
+		return &recordedLocation{
+			Type: common.StringLocationPrefix,
+			Data: string(location),
+		}
+
+	case common.IdentifierLocation:
+		return &recordedLocation{
+			Type: common.IdentifierLocationPrefix,
+			Data: string(location),
+		}
+
+	case common.TransactionLocation:
+		return &recordedLocation{
+			Type: common.TransactionLocationPrefix,
+			Data: hex.EncodeToString(location),
+		}
+
+	case common.ScriptLocation:
+		return &recordedLocation{
+			Type: common.ScriptLocationPrefix,
+			Data: hex.EncodeToString(location),
+		}
+
+	case common.REPLLocation:
+		return &recordedLocation{
+			Type: common.REPLLocationPrefix,
+		}
+
+	default:
+		// Unknown locations can be compared, but not decoded
+		return &recordedLocation{
+			Data: string(location.ID()),
+		}
+	}
+}
+
+func (l *recordedLocation) location() (Location, error) {
+	if l == nil {
+		return nil, nil
+	}
+
+	switch l.Type {
+	case common.AddressLocationPrefix:
+		address, err := common.HexToAddress(l.Address)
+		if err != nil {
+			return nil, err
+		}
+		return common.AddressLocation{
+			Address: address,
+			Name:    l.Name,
+		}, nil
+
+	case common.StringLocationPrefix:
+		return common.StringLocation(l.Data), nil
+
+	case common.IdentifierLocationPrefix:
+		return common.IdentifierLocation(l.Data), nil
+
+	case common.TransactionLocationPrefix:
+		data, err := hex.DecodeString(l.Data)
+		if err != nil {
+			return nil, err
+		}
+		return common.TransactionLocation(data), nil
+
+	case common.ScriptLocationPrefix:
+		data, err := hex.DecodeString(l.Data)
+		if err != nil {
+			return nil, err
+		}
+		return common.ScriptLocation(data), nil
+
+	case common.REPLLocationPrefix:
+		return common.REPLLocation{}, nil
+
+	default:
+		return nil, fmt.Errorf("cannot decode recorded location: %s", l.Data)
+	}
+}
+
+// recordedResolvedLocation is the encoding of a resolved location in a recording
+//
+type recordedResolvedLocation struct {
+	Location    *recordedLocation `json:"location"`
+	Identifiers []string          `json:"identifiers"`
+}
+
+func identifierNames(identifiers []Identifier) []string {
+	names := make([]string, len(identifiers))
+	for i, identifier := range identifiers {
+		names[i] = identifier.Identifier
+	}
+	return names
+}
+
+func newRecordedResolvedLocations(resolvedLocations []ResolvedLocation) []recordedResolvedLocation {
+	if resolvedLocations == nil {
+		return nil
+	}
+
+	result := make([]recordedResolvedLocation, len(resolvedLocations))
+	for i, resolvedLocation := range resolvedLocations {
+		result[i] = recordedResolvedLocation{
+			Location:    newRecordedLocation(resolvedLocation.Location),
+			Identifiers: identifierNames(resolvedLocation.Identifiers),
+		}
+	}
+	return result
+}
+
+func decodeRecordedResolvedLocations(recorded []recordedResolvedLocation) ([]ResolvedLocation, error) {
+	if recorded == nil {
+		return nil, nil
+	}
+
+	result := make([]ResolvedLocation, len(recorded))
+	for i, recordedResolvedLocation := range recorded {
+		location, err := recordedResolvedLocation.Location.location()
+		if err != nil {
+			return nil, err
+		}
+
+		identifiers := make([]Identifier, len(recordedResolvedLocation.Identifiers))
+		for j, name := range recordedResolvedLocation.Identifiers {
+			identifiers[j] = ast.Identifier{
+				Identifier: name,
+			}
+		}
+
+		result[i] = ResolvedLocation{
+			Location:    location,
+			Identifiers: identifiers,
+		}
+	}
+	return result, nil
+}
+
+// recordedCadenceValue returns the JSON-CDC encoding of the given value
+//
+func recordedCadenceValue(value cadence.Value) json.RawMessage {
+	if value == nil {
+		return json.RawMessage("null")
+	}
+
+	encoded, err := jsoncdc.Encode(value)
+	if err != nil {
+		// The value can still be compared
+		encoded, _ = json.Marshal(value.String())
+	}
+
+	// Remove the trailing newline
+	return bytes.TrimSpace(encoded)
+}
+
+func decodeRecordedCadenceValue(encoded json.RawMessage) (cadence.Value, error) {
+	if len(encoded) == 0 || string(encoded) == "null" {
+		return nil, nil
+	}
+	return jsoncdc.Decode(encoded)
+}
+
+func recordedTypeID(ty cadence.Type) string {
+	if ty == nil {
+		return ""
+	}
+	return ty.ID()
+}
+
+func recordedArguments(arguments ...interface{}) []interface{} {
+	return arguments
+}
+
+func encodeInterfaceCall(
+	function string,
+	arguments []interface{},
+	results []interface{},
+	err error,
+) (
+	call InterfaceCall,
+	encodingErr error,
+) {
+	call.Function = function
+
+	encode := func(values []interface{}) ([]json.RawMessage, error) {
+		if len(values) == 0 {
+			return nil, nil
+		}
+
+		encoded := make([]json.RawMessage, len(values))
+		for i, value := range values {
+			if raw, ok := value.(json.RawMessage); ok {
+				encoded[i] = raw
+				continue
+			}
+
+			var err error
+			encoded[i], err = json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return encoded, nil
+	}
+
+	call.Arguments, encodingErr = encode(arguments)
+	if encodingErr != nil {
+		return
+	}
+
+	call.Results, encodingErr = encode(results)
+	if encodingErr != nil {
+		return
+	}
+
+	if err != nil {
+		message := err.Error()
+		call.Error = &message
+	}
+
+	return
+}
+
+// RecordingInterface is a runtime interface which passes all calls on to the wrapped interface,
+// and records each call with its arguments and results to a writer, one JSON object per line.
+// The recording can be replayed using a ReplayingInterface.
+//
+// Programs are cached by the recording interface itself, and are not passed on to the wrapped interface,
+// so the code of all imported programs is recorded.
+// Traces are passed on, but not recorded
+//
+type RecordingInterface struct {
+	runtimeInterface Interface
+	encoder          *json.Encoder
+	programs         map[common.LocationID]*interpreter.Program
+	// mutex protects the encoder, err, and programs, as the runtime may call the interface concurrently
+	mutex sync.Mutex
+	err   error
+}
+
+var _ Interface = &RecordingInterface{}
+
+func NewRecordingInterface(runtimeInterface Interface, writer io.Writer) *RecordingInterface {
+	return &RecordingInterface{
+		runtimeInterface: runtimeInterface,
+		encoder:          json.NewEncoder(writer),
+		programs:         map[common.LocationID]*interpreter.Program{},
+	}
+}
+
+// Err returns the first error which occurred while writing the recording, if any
+//
+func (i *RecordingInterface) Err() error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	return i.err
+}
+
+func (i *RecordingInterface) record(function string, arguments []interface{}, err error, results ...interface{}) {
+	call, encodingErr := encodeInterfaceCall(function, arguments, results, err)
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if i.err != nil {
+		return
+	}
+
+	if encodingErr != nil {
+		i.err = encodingErr
+		return
+	}
+
+	i.err = i.encoder.Encode(call)
+}
+
+func (i *RecordingInterface) ResolveLocation(identifiers []Identifier, location Location) ([]ResolvedLocation, error) {
+	result, err := i.runtimeInterface.ResolveLocation(identifiers, location)
+	i.record(
+		"ResolveLocation",
+		recordedArguments(identifierNames(identifiers), newRecordedLocation(location)),
+		err,
+		newRecordedResolvedLocations(result),
+	)
+	return result, err
+}
+
+func (i *RecordingInterface) GetCode(location Location) ([]byte, error) {
+	code, err := i.runtimeInterface.GetCode(location)
+	i.record("GetCode", recordedArguments(newRecordedLocation(location)), err, code)
+	return code, err
+}
+
+func (i *RecordingInterface) GetProgram(location Location) (*interpreter.Program, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	return i.programs[location.ID()], nil
+}
+
+func (i *RecordingInterface) SetProgram(location Location, program *interpreter.Program) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.programs[location.ID()] = program
+	return nil
+}
+
+func (i *RecordingInterface) GetValue(owner, key []byte) ([]byte, error) {
+	value, err := i.runtimeInterface.GetValue(owner, key)
+	i.record("GetValue", recordedArguments(owner, key), err, value)
+	return value, err
+}
+
+func (i *RecordingInterface) SetValue(owner, key, value []byte) error {
+	err := i.runtimeInterface.SetValue(owner, key, value)
+	i.record("SetValue", recordedArguments(owner, key, value), err)
+	return err
+}
+
+func (i *RecordingInterface) ValueExists(owner, key []byte) (bool, error) {
+	exists, err := i.runtimeInterface.ValueExists(owner, key)
+	i.record("ValueExists", recordedArguments(owner, key), err, exists)
+	return exists, err
+}
+
+func (i *RecordingInterface) AllocateStorageIndex(owner []byte) (atree.StorageIndex, error) {
+	index, err := i.runtimeInterface.AllocateStorageIndex(owner)
+	i.record("AllocateStorageIndex", recordedArguments(owner), err, index)
+	return index, err
+}
+
+func (i *RecordingInterface) CreateAccount(payer Address) (Address, error) {
+	address, err := i.runtimeInterface.CreateAccount(payer)
+	i.record("CreateAccount", recordedArguments(payer), err, address)
+	return address, err
+}
+
+func (i *RecordingInterface) AddEncodedAccountKey(address Address, publicKey []byte) error {
+	err := i.runtimeInterface.AddEncodedAccountKey(address, publicKey)
+	i.record("AddEncodedAccountKey", recordedArguments(address, publicKey), err)
+	return err
+}
+
+func (i *RecordingInterface) RevokeEncodedAccountKey(address Address, index int) ([]byte, error) {
+	publicKey, err := i.runtimeInterface.RevokeEncodedAccountKey(address, index)
+	i.record("RevokeEncodedAccountKey", recordedArguments(address, index), err, publicKey)
+	return publicKey, err
+}
+
+func (i *RecordingInterface) AddAccountKey(
+	address Address,
+	publicKey *PublicKey,
+	hashAlgo HashAlgorithm,
+	weight int,
+) (*AccountKey, error) {
+	key, err := i.runtimeInterface.AddAccountKey(address, publicKey, hashAlgo, weight)
+	i.record("AddAccountKey", recordedArguments(address, publicKey, hashAlgo, weight), err, key)
+	return key, err
+}
+
+func (i *RecordingInterface) GetAccountKey(address Address, index int) (*AccountKey, error) {
+	key, err := i.runtimeInterface.GetAccountKey(address, index)
+	i.record("GetAccountKey", recordedArguments(address, index), err, key)
+	return key, err
+}
+
+func (i *RecordingInterface) RevokeAccountKey(address Address, index int) (*AccountKey, error) {
+	key, err := i.runtimeInterface.RevokeAccountKey(address, index)
+	i.record("RevokeAccountKey", recordedArguments(address, index), err, key)
+	return key, err
+}
+
+func (i *RecordingInterface) UpdateAccountContractCode(address Address, name string, code []byte) error {
+	err := i.runtimeInterface.UpdateAccountContractCode(address, name, code)
+	i.record("UpdateAccountContractCode", recordedArguments(address, name, code), err)
+	return err
+}
+
+func (i *RecordingInterface) GetAccountContractCode(address Address, name string) ([]byte, error) {
+	code, err := i.runtimeInterface.GetAccountContractCode(address, name)
+	i.record("GetAccountContractCode", recordedArguments(address, name), err, code)
+	return code, err
+}
+
+func (i *RecordingInterface) RemoveAccountContractCode(address Address, name string) error {
+	err := i.runtimeInterface.RemoveAccountContractCode(address, name)
+	i.record("RemoveAccountContractCode", recordedArguments(address, name), err)
+	return err
+}
+
+func (i *RecordingInterface) GetSigningAccounts() ([]Address, error) {
+	addresses, err := i.runtimeInterface.GetSigningAccounts()
+	i.record("GetSigningAccounts", nil, err, addresses)
+	return addresses, err
+}
+
+func (i *RecordingInterface) ProgramLog(message string) error {
+	err := i.runtimeInterface.ProgramLog(message)
+	i.record("ProgramLog", recordedArguments(message), err)
+	return err
+}
+
+func (i *RecordingInterface) EmitEvent(event cadence.Event) error {
+	err := i.runtimeInterface.EmitEvent(event)
+	i.record("EmitEvent", recordedArguments(recordedCadenceValue(event)), err)
+	return err
+}
+
+func (i *RecordingInterface) GenerateUUID() (uint64, error) {
+	uuid, err := i.runtimeInterface.GenerateUUID()
+	i.record("GenerateUUID", nil, err, uuid)
+	return uuid, err
+}
+
+func (i *RecordingInterface) MeterComputation(operationType common.ComputationKind, intensity uint) error {
+	err := i.runtimeInterface.MeterComputation(operationType, intensity)
+	i.record("MeterComputation", recordedArguments(operationType, intensity), err)
+	return err
+}
+
+func (i *RecordingInterface) MeterMemory(usage common.MemoryUsage) error {
+	err := i.runtimeInterface.MeterMemory(usage)
+	i.record("MeterMemory", recordedArguments(usage), err)
+	return err
+}
+
+func (i *RecordingInterface) DecodeArgument(argument []byte, argumentType cadence.Type) (cadence.Value, error) {
+	value, err := i.runtimeInterface.DecodeArgument(argument, argumentType)
+	i.record(
+		"DecodeArgument",
+		recordedArguments(argument, recordedTypeID(argumentType)),
+		err,
+		recordedCadenceValue(value),
+	)
+	return value, err
+}
+
+func (i *RecordingInterface) GetCurrentBlockHeight() (uint64, error) {
+	height, err := i.runtimeInterface.GetCurrentBlockHeight()
+	i.record("GetCurrentBlockHeight", nil, err, height)
+	return height, err
+}
+
+func (i *RecordingInterface) GetBlockAtHeight(height uint64) (Block, bool, error) {
+	block, exists, err := i.runtimeInterface.GetBlockAtHeight(height)
+	i.record("GetBlockAtHeight", recordedArguments(height), err, block, exists)
+	return block, exists, err
+}
+
+func (i *RecordingInterface) UnsafeRandom() (uint64, error) {
+	random, err := i.runtimeInterface.UnsafeRandom()
+	i.record("UnsafeRandom", nil, err, random)
+	return random, err
+}
+
+func (i *RecordingInterface) VerifySignature(
+	signature []byte,
+	tag string,
+	signedData []byte,
+	publicKey []byte,
+	signatureAlgorithm SignatureAlgorithm,
+	hashAlgorithm HashAlgorithm,
+) (bool, error) {
+	valid, err := i.runtimeInterface.VerifySignature(
+		signature,
+		tag,
+		signedData,
+		publicKey,
+		signatureAlgorithm,
+		hashAlgorithm,
+	)
+	i.record(
+		"VerifySignature",
+		recordedArguments(signature, tag, signedData, publicKey, signatureAlgorithm, hashAlgorithm),
+		err,
+		valid,
+	)
+	return valid, err
+}
+
+func (i *RecordingInterface) Hash(data []byte, tag string, hashAlgorithm HashAlgorithm) ([]byte, error) {
+	hash, err := i.runtimeInterface.Hash(data, tag, hashAlgorithm)
+	i.record("Hash", recordedArguments(data, tag, hashAlgorithm), err, hash)
+	return hash, err
+}
+
+func (i *RecordingInterface) GetAccountBalance(address common.Address) (uint64, error) {
+	balance, err := i.runtimeInterface.GetAccountBalance(address)
+	i.record("GetAccountBalance", recordedArguments(address), err, balance)
+	return balance, err
+}
+
+func (i *RecordingInterface) GetAccountAvailableBalance(address common.Address) (uint64, error) {
+	balance, err := i.runtimeInterface.GetAccountAvailableBalance(address)
+	i.record("GetAccountAvailableBalance", recordedArguments(address), err, balance)
+	return balance, err
+}
+
+func (i *RecordingInterface) GetStorageUsed(address Address) (uint64, error) {
+	used, err := i.runtimeInterface.GetStorageUsed(address)
+	i.record("GetStorageUsed", recordedArguments(address), err, used)
+	return used, err
+}
+
+func (i *RecordingInterface) GetStorageCapacity(address Address) (uint64, error) {
+	capacity, err := i.runtimeInterface.GetStorageCapacity(address)
+	i.record("GetStorageCapacity", recordedArguments(address), err, capacity)
+	return capacity, err
+}
+
+func (i *RecordingInterface) ImplementationDebugLog(message string) error {
+	err := i.runtimeInterface.ImplementationDebugLog(message)
+	i.record("ImplementationDebugLog", recordedArguments(message), err)
+	return err
+}
+
+func (i *RecordingInterface) ValidatePublicKey(key *PublicKey) error {
+	err := i.runtimeInterface.ValidatePublicKey(key)
+	i.record("ValidatePublicKey", recordedArguments(key), err)
+	return err
+}
+
+func (i *RecordingInterface) GetAccountContractNames(address Address) ([]string, error) {
+	names, err := i.runtimeInterface.GetAccountContractNames(address)
+	i.record("GetAccountContractNames", recordedArguments(address), err, names)
+	return names, err
+}
+
+func (i *RecordingInterface) RecordTrace(
+	operation string,
+	location common.Location,
+	duration time.Duration,
+	logs []opentracing.LogRecord,
+) {
+	i.runtimeInterface.RecordTrace(operation, location, duration, logs)
+}
+
+func (i *RecordingInterface) BLSVerifyPOP(publicKey *PublicKey, signature []byte) (bool, error) {
+	valid, err := i.runtimeInterface.BLSVerifyPOP(publicKey, signature)
+	i.record("BLSVerifyPOP", recordedArguments(publicKey, signature), err, valid)
+	return valid, err
+}
+
+func (i *RecordingInterface) BLSAggregateSignatures(signatures [][]byte) ([]byte, error) {
+	signature, err := i.runtimeInterface.BLSAggregateSignatures(signatures)
+	i.record("BLSAggregateSignatures", recordedArguments(signatures), err, signature)
+	return signature, err
+}
+
+func (i *RecordingInterface) BLSAggregatePublicKeys(keys []*PublicKey) (*PublicKey, error) {
+	key, err := i.runtimeInterface.BLSAggregatePublicKeys(keys)
+	i.record("BLSAggregatePublicKeys", recordedArguments(keys), err, key)
+	return key, err
+}
+
+func (i *RecordingInterface) ResourceOwnerChanged(
+	inter *interpreter.Interpreter,
+	resource *interpreter.CompositeValue,
+	oldOwner common.Address,
+	newOwner common.Address,
+) {
+	i.runtimeInterface.ResourceOwnerChanged(inter, resource, oldOwner, newOwner)
+	i.record("ResourceOwnerChanged", recordedArguments(resource.TypeID(), oldOwner, newOwner), nil)
+}
+
+// ReplayingInterface is a runtime interface which replays a recording of a RecordingInterface:
+// Each call returns the recorded results of the next recorded call.
+//
+// If a call differs from the next recorded call, i.e. the function or the arguments differ,
+// a ReplayDivergenceError is returned, or raised if the function cannot return an error.
+// All further calls fail with the same error.
+//
+// Programs are cached by the replaying interface itself. Traces are ignored
+//
+type ReplayingInterface struct {
+	calls      []InterfaceCall
+	next       int
+	divergence *ReplayDivergenceError
+	programs   map[common.LocationID]*interpreter.Program
+	mutex      sync.Mutex
+}
+
+var _ Interface = &ReplayingInterface{}
+
+// NewReplayingInterface returns a new interface which replays the recording read from the given reader
+//
+func NewReplayingInterface(reader io.Reader) (*ReplayingInterface, error) {
+	var calls []InterfaceCall
+
+	decoder := json.NewDecoder(reader)
+	for {
+		var call InterfaceCall
+		err := decoder.Decode(&call)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, call)
+	}
+
+	return &ReplayingInterface{
+		calls:    calls,
+		programs: map[common.LocationID]*interpreter.Program{},
+	}, nil
+}
+
+// Done returns a ReplayDivergenceError if the replay diverged from the recording,
+// or if not all recorded calls were replayed
+//
+func (i *ReplayingInterface) Done() error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if i.divergence != nil {
+		return *i.divergence
+	}
+
+	if i.next < len(i.calls) {
+		return ReplayDivergenceError{
+			Index:    i.next,
+			Expected: &i.calls[i.next],
+		}
+	}
+
+	return nil
+}
+
+// replay checks that the call matches the next recorded call,
+// decodes the recorded results into the given pointers,
+// and returns the recorded error, if any
+//
+func (i *ReplayingInterface) replay(function string, arguments []interface{}, results ...interface{}) error {
+	actual, err := encodeInterfaceCall(function, arguments, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if i.divergence != nil {
+		return *i.divergence
+	}
+
+	index := i.next
+
+	if index >= len(i.calls) {
+		i.divergence = &ReplayDivergenceError{
+			Index:  index,
+			Actual: &actual,
+		}
+		return *i.divergence
+	}
+
+	expected := &i.calls[index]
+
+	if !interfaceCallsMatch(*expected, actual) {
+		i.divergence = &ReplayDivergenceError{
+			Index:    index,
+			Expected: expected,
+			Actual:   &actual,
+		}
+		return *i.divergence
+	}
+
+	i.next++
+
+	for j, result := range results {
+		if j >= len(expected.Results) {
+			break
+		}
+
+		err := json.Unmarshal(expected.Results[j], result)
+		if err != nil {
+			return err
+		}
+	}
+
+	if expected.Error != nil {
+		return RecordedError{
+			Message: *expected.Error,
+		}
+	}
+
+	return nil
+}
+
+func interfaceCallsMatch(expected, actual InterfaceCall) bool {
+	if expected.Function != actual.Function ||
+		len(expected.Arguments) != len(actual.Arguments) {
+
+		return false
+	}
+
+	for i, argument := range expected.Arguments {
+		if !jsonEqual(argument, actual.Arguments[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// jsonEqual returns true if the given JSON encodings are equal, ignoring insignificant whitespace
+//
+func jsonEqual(a, b json.RawMessage) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+
+	var compactA, compactB bytes.Buffer
+	if json.Compact(&compactA, a) != nil || json.Compact(&compactB, b) != nil {
+		return false
+	}
+
+	return bytes.Equal(compactA.Bytes(), compactB.Bytes())
+}
+
+func (i *ReplayingInterface) ResolveLocation(identifiers []Identifier, location Location) ([]ResolvedLocation, error) {
+	var recorded []recordedResolvedLocation
+	err := i.replay(
+		"ResolveLocation",
+		recordedArguments(identifierNames(identifiers), newRecordedLocation(location)),
+		&recorded,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return decodeRecordedResolvedLocations(recorded)
+}
+
+func (i *ReplayingInterface) GetCode(location Location) (code []byte, err error) {
+	err = i.replay("GetCode", recordedArguments(newRecordedLocation(location)), &code)
+	return
+}
+
+func (i *ReplayingInterface) GetProgram(location Location) (*interpreter.Program, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	return i.programs[location.ID()], nil
+}
+
+func (i *ReplayingInterface) SetProgram(location Location, program *interpreter.Program) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.programs[location.ID()] = program
+	return nil
+}
+
+func (i *ReplayingInterface) GetValue(owner, key []byte) (value []byte, err error) {
+	err = i.replay("GetValue", recordedArguments(owner, key), &value)
+	return
+}
+
+func (i *ReplayingInterface) SetValue(owner, key, value []byte) error {
+	return i.replay("SetValue", recordedArguments(owner, key, value))
+}
+
+func (i *ReplayingInterface) ValueExists(owner, key []byte) (exists bool, err error) {
+	err = i.replay("ValueExists", recordedArguments(owner, key), &exists)
+	return
+}
+
+func (i *ReplayingInterface) AllocateStorageIndex(owner []byte) (index atree.StorageIndex, err error) {
+	err = i.replay("AllocateStorageIndex", recordedArguments(owner), &index)
+	return
+}
+
+func (i *ReplayingInterface) CreateAccount(payer Address) (address Address, err error) {
+	err = i.replay("CreateAccount", recordedArguments(payer), &address)
+	return
+}
+
+func (i *ReplayingInterface) AddEncodedAccountKey(address Address, publicKey []byte) error {
+	return i.replay("AddEncodedAccountKey", recordedArguments(address, publicKey))
+}
+
+func (i *ReplayingInterface) RevokeEncodedAccountKey(address Address, index int) (publicKey []byte, err error) {
+	err = i.replay("RevokeEncodedAccountKey", recordedArguments(address, index), &publicKey)
+	return
+}
+
+func (i *ReplayingInterface) AddAccountKey(
+	address Address,
+	publicKey *PublicKey,
+	hashAlgo HashAlgorithm,
+	weight int,
+) (
+	key *AccountKey,
+	err error,
+) {
+	err = i.replay("AddAccountKey", recordedArguments(address, publicKey, hashAlgo, weight), &key)
+	return
+}
+
+func (i *ReplayingInterface) GetAccountKey(address Address, index int) (key *AccountKey, err error) {
+	err = i.replay("GetAccountKey", recordedArguments(address, index), &key)
+	return
+}
+
+func (i *ReplayingInterface) RevokeAccountKey(address Address, index int) (key *AccountKey, err error) {
+	err = i.replay("RevokeAccountKey", recordedArguments(address, index), &key)
+	return
+}
+
+func (i *ReplayingInterface) UpdateAccountContractCode(address Address, name string, code []byte) error {
+	return i.replay("UpdateAccountContractCode", recordedArguments(address, name, code))
+}
+
+func (i *ReplayingInterface) GetAccountContractCode(address Address, name string) (code []byte, err error) {
+	err = i.replay("GetAccountContractCode", recordedArguments(address, name), &code)
+	return
+}
+
+func (i *ReplayingInterface) RemoveAccountContractCode(address Address, name string) error {
+	return i.replay("RemoveAccountContractCode", recordedArguments(address, name))
+}
+
+func (i *ReplayingInterface) GetSigningAccounts() (addresses []Address, err error) {
+	err = i.replay("GetSigningAccounts", nil, &addresses)
+	return
+}
+
+func (i *ReplayingInterface) ProgramLog(message string) error {
+	return i.replay("ProgramLog", recordedArguments(message))
+}
+
+func (i *ReplayingInterface) EmitEvent(event cadence.Event) error {
+	return i.replay("EmitEvent", recordedArguments(recordedCadenceValue(event)))
+}
+
+func (i *ReplayingInterface) GenerateUUID() (uuid uint64, err error) {
+	err = i.replay("GenerateUUID", nil, &uuid)
+	return
+}
+
+func (i *ReplayingInterface) MeterComputation(operationType common.ComputationKind, intensity uint) error {
+	return i.replay("MeterComputation", recordedArguments(operationType, intensity))
+}
+
+func (i *ReplayingInterface) MeterMemory(usage common.MemoryUsage) error {
+	return i.replay("MeterMemory", recordedArguments(usage))
+}
+
+func (i *ReplayingInterface) DecodeArgument(argument []byte, argumentType cadence.Type) (cadence.Value, error) {
+	var recorded json.RawMessage
+	err := i.replay(
+		"DecodeArgument",
+		recordedArguments(argument, recordedTypeID(argumentType)),
+		&recorded,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return decodeRecordedCadenceValue(recorded)
+}
+
+func (i *ReplayingInterface) GetCurrentBlockHeight() (height uint64, err error) {
+	err = i.replay("GetCurrentBlockHeight", nil, &height)
+	return
+}
+
+func (i *ReplayingInterface) GetBlockAtHeight(height uint64) (block Block, exists bool, err error) {
+	err = i.replay("GetBlockAtHeight", recordedArguments(height), &block, &exists)
+	return
+}
+
+func (i *ReplayingInterface) UnsafeRandom() (random uint64, err error) {
+	err = i.replay("UnsafeRandom", nil, &random)
+	return
+}
+
+func (i *ReplayingInterface) VerifySignature(
+	signature []byte,
+	tag string,
+	signedData []byte,
+	publicKey []byte,
+	signatureAlgorithm SignatureAlgorithm,
+	hashAlgorithm HashAlgorithm,
+) (
+	valid bool,
+	err error,
+) {
+	err = i.replay(
+		"VerifySignature",
+		recordedArguments(signature, tag, signedData, publicKey, signatureAlgorithm, hashAlgorithm),
+		&valid,
+	)
+	return
+}
+
+func (i *ReplayingInterface) Hash(data []byte, tag string, hashAlgorithm HashAlgorithm) (hash []byte, err error) {
+	err = i.replay("Hash", recordedArguments(data, tag, hashAlgorithm), &hash)
+	return
+}
+
+func (i *ReplayingInterface) GetAccountBalance(address common.Address) (balance uint64, err error) {
+	err = i.replay("GetAccountBalance", recordedArguments(address), &balance)
+	return
+}
+
+func (i *ReplayingInterface) GetAccountAvailableBalance(address common.Address) (balance uint64, err error) {
+	err = i.replay("GetAccountAvailableBalance", recordedArguments(address), &balance)
+	return
+}
+
+func (i *ReplayingInterface) GetStorageUsed(address Address) (used uint64, err error) {
+	err = i.replay("GetStorageUsed", recordedArguments(address), &used)
+	return
+}
+
+func (i *ReplayingInterface) GetStorageCapacity(address Address) (capacity uint64, err error) {
+	err = i.replay("GetStorageCapacity", recordedArguments(address), &capacity)
+	return
+}
+
+func (i *ReplayingInterface) ImplementationDebugLog(message string) error {
+	return i.replay("ImplementationDebugLog", recordedArguments(message))
+}
+
+func (i *ReplayingInterface) ValidatePublicKey(key *PublicKey) error {
+	return i.replay("ValidatePublicKey", recordedArguments(key))
+}
+
+func (i *ReplayingInterface) GetAccountContractNames(address Address) (names []string, err error) {
+	err = i.replay("GetAccountContractNames", recordedArguments(address), &names)
+	return
+}
+
+func (i *ReplayingInterface) RecordTrace(_ string, _ common.Location, _ time.Duration, _ []opentracing.LogRecord) {
+	// NO-OP
+}
+
+func (i *ReplayingInterface) BLSVerifyPOP(publicKey *PublicKey, signature []byte) (valid bool, err error) {
+	err = i.replay("BLSVerifyPOP", recordedArguments(publicKey, signature), &valid)
+	return
+}
+
+func (i *ReplayingInterface) BLSAggregateSignatures(signatures [][]byte) (signature []byte, err error) {
+	err = i.replay("BLSAggregateSignatures", recordedArguments(signatures), &signature)
+	return
+}
+
+func (i *ReplayingInterface) BLSAggregatePublicKeys(keys []*PublicKey) (key *PublicKey, err error) {
+	err = i.replay("BLSAggregatePublicKeys", recordedArguments(keys), &key)
+	return
+}
+
+func (i *ReplayingInterface) ResourceOwnerChanged(
+	_ *interpreter.Interpreter,
+	resource *interpreter.CompositeValue,
+	oldOwner common.Address,
+	newOwner common.Address,
+) {
+	err := i.replay("ResourceOwnerChanged", recordedArguments(resource.TypeID(), oldOwner, newOwner))
+	if err != nil {
+		panic(err)
+	}
+}
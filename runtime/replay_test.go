@@ -0,0 +1,221 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestRuntimeRecordAndReplay(t *testing.T) {
+
+	t.Parallel()
+
+	const contract = `
+      pub contract Test {
+          pub event Hello(message: String)
+
+          pub resource R {}
+
+          pub fun hello(): String {
+              emit Hello(message: "hello")
+              return "hello"
+          }
+
+          pub fun createR(): @R {
+              return <-create R()
+          }
+      }
+    `
+
+	const transaction = `
+      import Test from 0x1
+
+      transaction {
+          prepare(signer: AuthAccount) {
+              log(Test.hello())
+              signer.save(<-Test.createR(), to: /storage/r)
+          }
+      }
+    `
+
+	setup := func(t *testing.T) *InMemoryInterface {
+		runtime := newTestInterpreterRuntime()
+		runtimeInterface := NewInMemoryInterface()
+
+		address, err := runtimeInterface.CreateAccount(common.Address{})
+		require.NoError(t, err)
+
+		runtimeInterface.SetSigningAccounts(address)
+
+		err = runtime.ExecuteTransaction(
+			Script{
+				Source: utils.DeploymentTransaction("Test", []byte(contract)),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{0},
+			},
+		)
+		require.NoError(t, err)
+
+		return runtimeInterface
+	}
+
+	record := func(t *testing.T, source string) []byte {
+		runtimeInterface := setup(t)
+
+		var recording bytes.Buffer
+		recordingInterface := NewRecordingInterface(runtimeInterface, &recording)
+
+		err := newTestInterpreterRuntime().ExecuteTransaction(
+			Script{
+				Source: []byte(source),
+			},
+			Context{
+				Interface: recordingInterface,
+				Location:  common.TransactionLocation{1},
+			},
+		)
+		require.NoError(t, err)
+		require.NoError(t, recordingInterface.Err())
+
+		return recording.Bytes()
+	}
+
+	t.Run("replay", func(t *testing.T) {
+
+		t.Parallel()
+
+		recording := record(t, transaction)
+
+		replayingInterface, err := NewReplayingInterface(bytes.NewReader(recording))
+		require.NoError(t, err)
+
+		err = newTestInterpreterRuntime().ExecuteTransaction(
+			Script{
+				Source: []byte(transaction),
+			},
+			Context{
+				Interface: replayingInterface,
+				Location:  common.TransactionLocation{1},
+			},
+		)
+		require.NoError(t, err)
+		require.NoError(t, replayingInterface.Done())
+	})
+
+	t.Run("recorded results", func(t *testing.T) {
+
+		t.Parallel()
+
+		const script = `
+          import Test from 0x1
+
+          pub fun main(): [AnyStruct] {
+              return [Test.hello(), getCurrentBlock().height, unsafeRandom()]
+          }
+        `
+
+		runtimeInterface := setup(t)
+
+		var recording bytes.Buffer
+		recordingInterface := NewRecordingInterface(runtimeInterface, &recording)
+
+		runtime := newTestInterpreterRuntime()
+
+		recordedResult, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(script),
+			},
+			Context{
+				Interface: recordingInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+		require.NoError(t, recordingInterface.Err())
+
+		replayingInterface, err := NewReplayingInterface(&recording)
+		require.NoError(t, err)
+
+		replayedResult, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(script),
+			},
+			Context{
+				Interface: replayingInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+		require.NoError(t, replayingInterface.Done())
+
+		assert.Equal(t, recordedResult, replayedResult)
+		assert.Equal(t, cadence.String("hello"), replayedResult.(cadence.Array).Values[0])
+	})
+
+	t.Run("divergence", func(t *testing.T) {
+
+		t.Parallel()
+
+		recording := record(t, transaction)
+
+		replayingInterface, err := NewReplayingInterface(bytes.NewReader(recording))
+		require.NoError(t, err)
+
+		err = newTestInterpreterRuntime().ExecuteTransaction(
+			Script{
+				Source: []byte(fmt.Sprintf(
+					`
+                      import Test from 0x1
+
+                      transaction {
+                          prepare(signer: AuthAccount) {
+                              log(Test.hello())
+                              signer.save(<-Test.createR(), to: /storage/%s)
+                          }
+                      }
+                    `,
+					"other",
+				)),
+			},
+			Context{
+				Interface: replayingInterface,
+				Location:  common.TransactionLocation{1},
+			},
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "replay diverged from recording")
+
+		var divergenceErr ReplayDivergenceError
+		require.ErrorAs(t, replayingInterface.Done(), &divergenceErr)
+		require.NotNil(t, divergenceErr.Expected)
+		require.NotNil(t, divergenceErr.Actual)
+		assert.NotEqual(t, divergenceErr.Expected.String(), divergenceErr.Actual.String())
+	})
+}
@@ -52,12 +52,32 @@ type Runtime interface {
 	// or if the execution fails.
 	ExecuteScript(Script, Context) (cadence.Value, error)
 
+	// ExecuteScriptWithResult executes the given script like ExecuteScript,
+	// and also returns the registers the execution read and wrote.
+	//
+	// The result is also returned if the execution fails.
+	ExecuteScriptWithResult(Script, Context) (*ExecutionResult, error)
+
 	// ExecuteTransaction executes the given transaction.
 	//
 	// This function returns an error if the program has errors (e.g syntax errors, type errors),
 	// or if the execution fails.
 	ExecuteTransaction(Script, Context) error
 
+	// ExecuteTransactionWithResult executes the given transaction like ExecuteTransaction,
+	// and also returns the registers the execution read and wrote.
+	//
+	// The result is also returned if the execution fails.
+	ExecuteTransactionWithResult(Script, Context) (*ExecutionResult, error)
+
+	// SimulateTransaction executes the given transaction without committing its effects,
+	// and returns the effects the transaction would have: the storage changes,
+	// the contract code changes, the emitted events, and the computation used.
+	//
+	// This function returns an error if the program has errors (e.g syntax errors, type errors),
+	// or if the execution fails.
+	SimulateTransaction(Script, Context) (*TransactionSimulation, error)
+
 	// InvokeContractFunction invokes a contract function with the given arguments.
 	//
 	// This function returns an error if the execution fails.
@@ -81,6 +101,11 @@ type Runtime interface {
 	//
 	SetCoverageReport(coverageReport *CoverageReport)
 
+	// SetProfiler activates profiling executions with the given profiler.
+	// Passing nil disables profiling (default).
+	//
+	SetProfiler(profiler *Profiler)
+
 	// SetContractUpdateValidationEnabled configures if contract update validation is enabled.
 	//
 	SetContractUpdateValidationEnabled(enabled bool)
@@ -159,11 +184,13 @@ func reportMetric(
 // interpreterRuntime is a interpreter-based version of the Flow runtime.
 type interpreterRuntime struct {
 	coverageReport                       *CoverageReport
+	profiler                             *Profiler
 	contractUpdateValidationEnabled      bool
 	atreeValidationEnabled               bool
 	tracingEnabled                       bool
 	resourceOwnerChangeHandlerEnabled    bool
 	invalidatedResourceValidationEnabled bool
+	viewFunctionValidationEnabled        bool
 }
 
 type Option func(Runtime)
@@ -213,6 +240,20 @@ func WithResourceOwnerChangeCallbackEnabled(enabled bool) Option {
 	}
 }
 
+// WithViewFunctionValidationEnabled returns a runtime option
+// that configures if view function validation is enabled,
+// i.e. if scripts must be view functions and if conditions must be free of side effects.
+//
+// The option only applies to the interpreter-based runtime
+//
+func WithViewFunctionValidationEnabled(enabled bool) Option {
+	return func(runtime Runtime) {
+		if runtime, ok := runtime.(*interpreterRuntime); ok {
+			runtime.viewFunctionValidationEnabled = enabled
+		}
+	}
+}
+
 // NewInterpreterRuntime returns a interpreter-based version of the Flow runtime.
 func NewInterpreterRuntime(options ...Option) Runtime {
 	runtime := &interpreterRuntime{}
@@ -244,6 +285,10 @@ func (r *interpreterRuntime) SetCoverageReport(coverageReport *CoverageReport) {
 	r.coverageReport = coverageReport
 }
 
+func (r *interpreterRuntime) SetProfiler(profiler *Profiler) {
+	r.profiler = profiler
+}
+
 func (r *interpreterRuntime) SetContractUpdateValidationEnabled(enabled bool) {
 	r.contractUpdateValidationEnabled = enabled
 }
@@ -272,9 +317,47 @@ func (r *interpreterRuntime) ExecuteScript(script Script, context Context) (val
 		context,
 	)
 
+	return r.executeScript(script, context, nil)
+}
+
+func (r *interpreterRuntime) ExecuteScriptWithResult(script Script, context Context) (
+	result *ExecutionResult,
+	err error,
+) {
+	accesses := NewRegisterAccesses()
+	result = &ExecutionResult{
+		RegisterAccesses: accesses,
+	}
+
+	defer r.Recover(
+		func(internalErr error) {
+			err = internalErr
+		},
+		context,
+	)
+
+	result.Value, err = r.executeScript(script, context, accesses)
+	return result, err
+}
+
+// executeScript executes the given script.
+// If accesses is not nil, the registers accessed by the execution are recorded in it
+//
+func (r *interpreterRuntime) executeScript(
+	script Script,
+	context Context,
+	accesses *RegisterAccesses,
+) (
+	cadence.Value,
+	error,
+) {
 	context.InitializeCodesAndPrograms()
 
-	storage := NewStorage(context.Interface)
+	if accesses != nil {
+		context.Interface = newRegisterRecordingInterface(context.Interface, accesses)
+	}
+
+	storage := NewStorage(context.Interface, r.computationMeter(context.Interface))
 
 	var checkerOptions []sema.Option
 	var interpreterOptions []interpreter.Option
@@ -304,6 +387,14 @@ func (r *interpreterRuntime) ExecuteScript(script Script, context Context) (val
 		return nil, newError(err, context)
 	}
 
+	// Ensure the entry point is a view function, if required
+	if r.viewFunctionValidationEnabled &&
+		!functionEntryPointType.Purity.IsView() {
+
+		err = &InvalidScriptEntryPointPurityError{}
+		return nil, newError(err, context)
+	}
+
 	// Ensure the entry point's parameter types are importable
 	if len(functionEntryPointType.Parameters) > 0 {
 		for _, param := range functionEntryPointType.Parameters {
@@ -438,6 +529,10 @@ func (r *interpreterRuntime) interpret(
 		return exportableValue{}, nil, err
 	}
 
+	if r.profiler != nil {
+		defer r.profiler.beginExecution()()
+	}
+
 	var result interpreter.Value
 
 	reportMetric(
@@ -518,7 +613,7 @@ func (r *interpreterRuntime) InvokeContractFunction(
 
 	context.InitializeCodesAndPrograms()
 
-	storage := NewStorage(context.Interface)
+	storage := NewStorage(context.Interface, r.computationMeter(context.Interface))
 
 	var interpreterOptions []interpreter.Option
 	var checkerOptions []sema.Option
@@ -650,9 +745,70 @@ func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context)
 		context,
 	)
 
+	return r.executeAndCommitTransaction(script, context, nil)
+}
+
+func (r *interpreterRuntime) ExecuteTransactionWithResult(script Script, context Context) (
+	result *ExecutionResult,
+	err error,
+) {
+	accesses := NewRegisterAccesses()
+	result = &ExecutionResult{
+		RegisterAccesses: accesses,
+	}
+
+	defer r.Recover(
+		func(internalErr error) {
+			err = internalErr
+		},
+		context,
+	)
+
+	err = r.executeAndCommitTransaction(script, context, accesses)
+	return result, err
+}
+
+// executeAndCommitTransaction executes the given transaction and commits the storage.
+// If accesses is not nil, the registers accessed by the execution are recorded in it
+//
+func (r *interpreterRuntime) executeAndCommitTransaction(
+	script Script,
+	context Context,
+	accesses *RegisterAccesses,
+) error {
+	storage, inter, err := r.executeTransaction(script, context, accesses)
+	if err != nil {
+		return err
+	}
+
+	// Write back all stored values, which were actually just cached, back into storage
+	err = r.commitStorage(storage, inter)
+	if err != nil {
+		return newError(err, context)
+	}
+
+	return nil
+}
+
+// executeTransaction executes the given transaction, without committing the storage.
+// If accesses is not nil, the registers accessed by the execution are recorded in it
+//
+func (r *interpreterRuntime) executeTransaction(
+	script Script,
+	context Context,
+	accesses *RegisterAccesses,
+) (
+	*Storage,
+	*interpreter.Interpreter,
+	error,
+) {
 	context.InitializeCodesAndPrograms()
 
-	storage := NewStorage(context.Interface)
+	if accesses != nil {
+		context.Interface = newRegisterRecordingInterface(context.Interface, accesses)
+	}
+
+	storage := NewStorage(context.Interface, r.computationMeter(context.Interface))
 
 	var interpreterOptions []interpreter.Option
 	var checkerOptions []sema.Option
@@ -674,7 +830,7 @@ func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context)
 		importResolutionResults{},
 	)
 	if err != nil {
-		return newError(err, context)
+		return nil, nil, newError(err, context)
 	}
 
 	transactions := program.Elaboration.TransactionTypes
@@ -683,7 +839,7 @@ func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context)
 		err = InvalidTransactionCountError{
 			Count: transactionCount,
 		}
-		return newError(err, context)
+		return nil, nil, newError(err, context)
 	}
 
 	transactionType := transactions[0]
@@ -693,7 +849,7 @@ func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context)
 		authorizers, err = context.Interface.GetSigningAccounts()
 	})
 	if err != nil {
-		return newError(err, context)
+		return nil, nil, newError(err, context)
 	}
 	// check parameter count
 
@@ -706,7 +862,7 @@ func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context)
 			Expected: transactionParameterCount,
 			Actual:   argumentCount,
 		}
-		return newError(err, context)
+		return nil, nil, newError(err, context)
 	}
 
 	transactionAuthorizerCount := len(transactionType.PrepareParameters)
@@ -715,7 +871,7 @@ func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context)
 			Expected: transactionAuthorizerCount,
 			Actual:   authorizerCount,
 		}
-		return newError(err, context)
+		return nil, nil, newError(err, context)
 	}
 
 	// gather authorizers
@@ -753,16 +909,10 @@ func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context)
 		),
 	)
 	if err != nil {
-		return newError(err, context)
-	}
-
-	// Write back all stored values, which were actually just cached, back into storage
-	err = r.commitStorage(storage, inter)
-	if err != nil {
-		return newError(err, context)
+		return nil, nil, newError(err, context)
 	}
 
-	return nil
+	return storage, inter, nil
 }
 
 func wrapPanic(f func()) {
@@ -997,7 +1147,7 @@ func (r *interpreterRuntime) ParseAndCheckProgram(
 
 	context.InitializeCodesAndPrograms()
 
-	storage := NewStorage(context.Interface)
+	storage := NewStorage(context.Interface, r.computationMeter(context.Interface))
 
 	var interpreterOptions []interpreter.Option
 	var checkerOptions []sema.Option
@@ -1053,7 +1203,7 @@ func (r *interpreterRuntime) parseAndCheckProgram(
 	var parse *ast.Program
 	reportMetric(
 		func() {
-			parse, err = parser2.ParseProgram(string(code))
+			parse, err = parser2.ParseProgram(string(code), newMemoryGauge(context.Interface))
 		},
 		context.Interface,
 		func(metrics Metrics, duration time.Duration) {
@@ -1075,6 +1225,13 @@ func (r *interpreterRuntime) parseAndCheckProgram(
 		return nil, wrapError(err)
 	}
 
+	// Record the coverable statements and branches,
+	// so lines which are never executed are reported
+
+	if r.coverageReport != nil {
+		r.coverageReport.InspectProgram(context.Location, parse)
+	}
+
 	// Return
 
 	program = &interpreter.Program{
@@ -1121,6 +1278,8 @@ func (r *interpreterRuntime) check(
 				sema.WithPredeclaredValues(valueDeclarations),
 				sema.WithPredeclaredTypes(typeDeclarations),
 				sema.WithValidTopLevelDeclarationsHandler(validTopLevelDeclarations),
+				sema.WithConditionPurityEnforced(r.viewFunctionValidationEnabled),
+				sema.WithMemoryGauge(newMemoryGauge(startContext.Interface)),
 				sema.WithLocationHandler(
 					func(identifiers []Identifier, location Location) (res []ResolvedLocation, err error) {
 						wrapPanic(func() {
@@ -1272,6 +1431,12 @@ func (r *interpreterRuntime) newInterpreter(
 		interpreter.WithOnStatementHandler(
 			r.onStatementHandler(),
 		),
+		interpreter.WithOnStatementStartHandler(
+			r.onStatementStartHandler(),
+		),
+		interpreter.WithOnBranchHandler(
+			r.onBranchHandler(),
+		),
 		interpreter.WithPublicAccountHandler(
 			func(_ *interpreter.Interpreter, address interpreter.AddressValue) interpreter.Value {
 				return r.getPublicAccount(
@@ -1375,6 +1540,7 @@ func (r *interpreterRuntime) newInterpreter(
 		interpreter.WithAtreeStorageValidationEnabled(false),
 		interpreter.WithOnResourceOwnerChangeHandler(r.resourceOwnerChangedHandler(context.Interface)),
 		interpreter.WithInvalidatedResourceValidationEnabled(r.invalidatedResourceValidationEnabled),
+		interpreter.WithContext(context.GoContext),
 	}
 
 	defaultOptions = append(defaultOptions,
@@ -1542,30 +1708,74 @@ func (r *interpreterRuntime) meteringInterpreterOptions(runtimeInterface Interfa
 
 	return []interpreter.Option{
 		interpreter.WithOnFunctionInvocationHandler(
-			func(_ *interpreter.Interpreter, _ int) {
+			func(inter *interpreter.Interpreter, line int) {
+				if r.profiler != nil {
+					r.profiler.onFunctionInvocation(inter, line)
+				}
+
 				callStackDepth++
 				checkCallStackDepth()
 			},
 		),
 		interpreter.WithOnInvokedFunctionReturnHandler(
 			func(_ *interpreter.Interpreter, _ int) {
+				if r.profiler != nil {
+					r.profiler.onInvokedFunctionReturn()
+				}
+
 				callStackDepth--
 			},
 		),
 		interpreter.WithOnMeterComputationFuncHandler(
-			func(compKind common.ComputationKind, intensity uint) {
-				var err error
-				wrapPanic(func() {
-					err = runtimeInterface.MeterComputation(compKind, intensity)
-				})
-				if err != nil {
-					panic(err)
-				}
-			},
+			r.computationMeter(runtimeInterface),
 		),
+		interpreter.WithMemoryGauge(newMemoryGauge(runtimeInterface)),
 	}
 }
 
+// computationMeter returns a function which meters computation through the runtime interface.
+// It is used by both the interpreter and the storage,
+// so the profiler sees all metered computation
+//
+func (r *interpreterRuntime) computationMeter(runtimeInterface Interface) interpreter.OnMeterComputationFunc {
+	return func(compKind common.ComputationKind, intensity uint) {
+		// Profile before metering,
+		// so computation which exceeds the limit is attributed
+		if r.profiler != nil {
+			r.profiler.onMeterComputation(intensity)
+		}
+
+		var err error
+		wrapPanic(func() {
+			err = runtimeInterface.MeterComputation(compKind, intensity)
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// memoryGauge is a memory gauge which reports memory usage to the runtime interface
+//
+type memoryGauge struct {
+	runtimeInterface Interface
+}
+
+var _ common.MemoryGauge = memoryGauge{}
+
+func newMemoryGauge(runtimeInterface Interface) memoryGauge {
+	return memoryGauge{
+		runtimeInterface: runtimeInterface,
+	}
+}
+
+func (g memoryGauge) MeterMemory(usage common.MemoryUsage) (err error) {
+	wrapPanic(func() {
+		err = g.runtimeInterface.MeterMemory(usage)
+	})
+	return
+}
+
 var getAuthAccountFunctionType = &sema.FunctionType{
 	Parameters: []*sema.Parameter{{
 		Label:          sema.ArgumentLabelNotRequired,
@@ -1998,6 +2208,7 @@ func (r *interpreterRuntime) loadContract(
 			storageMap := storage.GetStorageMap(
 				location.Address,
 				StorageDomainContract,
+				true,
 			)
 			storedValue = storageMap.ReadValue(location.Name)
 		}
@@ -2604,7 +2815,7 @@ func (r *interpreterRuntime) newAuthAccountContractsChangeFunction(
 				if cachedProgram != nil {
 					oldProgram = cachedProgram.Program
 				} else {
-					oldProgram, err = parser2.ParseProgram(string(existingCode))
+					oldProgram, err = parser2.ParseProgram(string(existingCode), invocation.Interpreter)
 					handleContractUpdateError(err)
 				}
 
@@ -2853,7 +3064,7 @@ func (r *interpreterRuntime) newAuthAccountContractsRemoveFunction(
 				// the existing code contains enums.
 				if r.contractUpdateValidationEnabled {
 
-					existingProgram, err := parser2.ParseProgram(string(code))
+					existingProgram, err := parser2.ParseProgram(string(code), invocation.Interpreter)
 
 					// If the existing code is not parsable (i.e: `err != nil`), that shouldn't be a reason to
 					// fail the contract removal. Therefore, validate only if the code is a valid one.
@@ -2932,7 +3143,14 @@ func (r *interpreterRuntime) newAccountContractsGetNamesFunction(
 
 		values := make([]interpreter.Value, len(names))
 		for i, name := range names {
-			values[i] = interpreter.NewStringValue(name)
+			memoryUsage := common.NewStringMemoryUsage(len(name))
+			values[i] = interpreter.NewStringValue(
+				inter,
+				memoryUsage,
+				func() string {
+					return name
+				},
+			)
 		}
 
 		return interpreter.NewArrayValue(
@@ -2958,12 +3176,34 @@ func (r *interpreterRuntime) onStatementHandler() interpreter.OnStatementFunc {
 	}
 }
 
+// onStatementStartHandler returns the statement start handler,
+// which sets the profiler's current location before the statement's computation is metered,
+// so the computation is attributed to the statement
+//
+func (r *interpreterRuntime) onStatementStartHandler() interpreter.OnStatementStartFunc {
+	if r.profiler == nil {
+		return nil
+	}
+
+	return r.profiler.onStatement
+}
+
+func (r *interpreterRuntime) onBranchHandler() interpreter.OnBranchFunc {
+	if r.coverageReport == nil {
+		return nil
+	}
+
+	return func(inter *interpreter.Interpreter, element ast.Element, branch int) {
+		r.coverageReport.AddBranchHit(inter.Location, element, branch)
+	}
+}
+
 func (r *interpreterRuntime) executeNonProgram(interpret interpretFunc, context Context) (cadence.Value, error) {
 	context.InitializeCodesAndPrograms()
 
 	var program *interpreter.Program
 
-	storage := NewStorage(context.Interface)
+	storage := NewStorage(context.Interface, r.computationMeter(context.Interface))
 
 	var functions stdlib.StandardLibraryFunctions
 	var values stdlib.StandardLibraryValues
@@ -20,6 +20,7 @@ package runtime
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"encoding/hex"
 	"errors"
@@ -157,6 +158,7 @@ type testRuntimeInterface struct {
 	)
 	generateUUID       func() (uint64, error)
 	meterComputation   func(compKind common.ComputationKind, intensity uint) error
+	meterMemory        func(usage common.MemoryUsage) error
 	decodeArgument     func(b []byte, t cadence.Type) (cadence.Value, error)
 	programParsed      func(location common.Location, duration time.Duration)
 	programChecked     func(location common.Location, duration time.Duration)
@@ -368,6 +370,13 @@ func (i *testRuntimeInterface) MeterComputation(compKind common.ComputationKind,
 	return i.meterComputation(compKind, intensity)
 }
 
+func (i *testRuntimeInterface) MeterMemory(usage common.MemoryUsage) error {
+	if i.meterMemory == nil {
+		return nil
+	}
+	return i.meterMemory(usage)
+}
+
 func (i *testRuntimeInterface) DecodeArgument(b []byte, t cadence.Type) (cadence.Value, error) {
 	return i.decodeArgument(b, t)
 }
@@ -2683,6 +2692,101 @@ func TestRuntimeScriptParameterTypeNotImportableError(t *testing.T) {
 	require.ErrorAs(t, err, &subErr)
 }
 
+func TestRuntimeScriptViewFunctionValidation(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime(
+		WithViewFunctionValidationEnabled(true),
+	)
+
+	runtimeInterface := &testRuntimeInterface{
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{{42}}, nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	t.Run("view entry point", func(t *testing.T) {
+
+		script := []byte(`
+          pub view fun main(): Int {
+              return 1
+          }
+        `)
+
+		value, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewInt(1), value)
+	})
+
+	t.Run("non-view entry point", func(t *testing.T) {
+
+		script := []byte(`
+          pub fun main(): Int {
+              return 1
+          }
+        `)
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+
+		var subErr *InvalidScriptEntryPointPurityError
+		require.ErrorAs(t, err, &subErr)
+	})
+
+	t.Run("impure condition", func(t *testing.T) {
+
+		script := []byte(`
+          pub fun check(): Bool {
+              return true
+          }
+
+          pub fun test() {
+              pre { check() }
+          }
+
+          pub view fun main(): Int {
+              return 1
+          }
+        `)
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, err, &checkerErr)
+
+		errs := checker.ExpectCheckerErrors(t, checkerErr, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+}
+
 func TestRuntimeSyntaxError(t *testing.T) {
 
 	t.Parallel()
@@ -3260,7 +3364,7 @@ func TestRuntimeInvokeContractFunction(t *testing.T) {
 			},
 			"helloArg",
 			[]interpreter.Value{
-				interpreter.NewStringValue("there!"),
+				interpreter.NewUnmeteredStringValue("there!"),
 			},
 			[]sema.Type{
 				sema.StringType,
@@ -3282,7 +3386,7 @@ func TestRuntimeInvokeContractFunction(t *testing.T) {
 			},
 			"helloReturn",
 			[]interpreter.Value{
-				interpreter.NewStringValue("there!"),
+				interpreter.NewUnmeteredStringValue("there!"),
 			},
 			[]sema.Type{
 				sema.StringType,
@@ -3305,7 +3409,7 @@ func TestRuntimeInvokeContractFunction(t *testing.T) {
 			},
 			"helloMultiArg",
 			[]interpreter.Value{
-				interpreter.NewStringValue("number"),
+				interpreter.NewUnmeteredStringValue("number"),
 				interpreter.NewIntValueFromInt64(42),
 				interpreter.AddressValue(addressValue),
 			},
@@ -3332,7 +3436,7 @@ func TestRuntimeInvokeContractFunction(t *testing.T) {
 			},
 			"helloMultiArg",
 			[]interpreter.Value{
-				interpreter.NewStringValue("number"),
+				interpreter.NewUnmeteredStringValue("number"),
 				interpreter.NewIntValueFromInt64(42),
 			},
 			[]sema.Type{
@@ -7246,3 +7350,201 @@ func TestRuntimeComputationMetring(t *testing.T) {
 		})
 	}
 }
+
+func TestRuntimeMemoryMetering(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+      pub fun main(): Int {
+          let xs: [String] = []
+          var i = 0
+          while i < 100 {
+              xs.append("hello world")
+              i = i + 1
+          }
+          return xs.length
+      }
+    `
+
+	t.Run("usage", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		meter := map[common.MemoryKind]uint64{}
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			meterMemory: func(usage common.MemoryUsage) error {
+				meter[usage.Kind] += usage.Amount
+				return nil
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		result, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(script),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewInt(100), result)
+
+		for _, kind := range []common.MemoryKind{
+			// parser
+			common.MemoryKindToken,
+			common.MemoryKindProgram,
+			// checker
+			common.MemoryKindVariable,
+			common.MemoryKindVariableSizedSemaType,
+			// interpreter
+			common.MemoryKindStringValue,
+			common.MemoryKindArrayValueBase,
+			common.MemoryKindAtreeArrayElementOverhead,
+		} {
+			assert.NotZero(t, meter[kind], kind.String())
+		}
+
+		assert.GreaterOrEqual(t, meter[common.MemoryKindStringValue], uint64(100*12))
+	})
+
+	t.Run("limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		memoryErr := errors.New("memory exceeded limit")
+
+		var memoryUsed uint64
+		const memoryLimit = 1000
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			meterMemory: func(usage common.MemoryUsage) error {
+				memoryUsed += usage.Amount
+				if memoryUsed > memoryLimit {
+					return memoryErr
+				}
+				return nil
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(script),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+
+		var executionErr Error
+		require.ErrorAs(t, err, &executionErr)
+		require.ErrorIs(t, err, memoryErr)
+	})
+}
+
+func TestRuntimeExecutionCancellation(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	t.Run("script, canceled in host function", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := []byte(`
+          pub fun main() {
+              while true {
+                  log("hello")
+              }
+          }
+        `)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var loggedMessages []string
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			log: func(message string) {
+				loggedMessages = append(loggedMessages, message)
+				if len(loggedMessages) == 3 {
+					cancel()
+				}
+			},
+		}
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+				GoContext: ctx,
+			},
+		)
+		require.Error(t, err)
+
+		var canceledErr ExecutionCanceledError
+		require.ErrorAs(t, err, &canceledErr)
+		require.ErrorIs(t, err, context.Canceled)
+
+		assert.Len(t, loggedMessages, 3)
+	})
+
+	t.Run("transaction, deadline exceeded", func(t *testing.T) {
+
+		t.Parallel()
+
+		tx := []byte(`
+          transaction {
+              execute {
+                  while true {}
+              }
+          }
+        `)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return nil, nil
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: tx,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+				GoContext: ctx,
+			},
+		)
+		require.Error(t, err)
+
+		var canceledErr ExecutionCanceledError
+		require.ErrorAs(t, err, &canceledErr)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
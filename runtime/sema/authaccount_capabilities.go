@@ -0,0 +1,186 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+)
+
+const AuthAccountCapabilitiesTypeName = "Capabilities"
+const AuthAccountCapabilitiesTypeIssueFunctionName = "issue"
+const AuthAccountCapabilitiesTypeGetControllerFunctionName = "getController"
+const AuthAccountCapabilitiesTypeGetControllersFunctionName = "getControllers"
+const AuthAccountCapabilitiesTypePublishFunctionName = "publish"
+
+// AuthAccountCapabilitiesType represents the type `AuthAccount.Capabilities`
+//
+var AuthAccountCapabilitiesType = func() *CompositeType {
+
+	authAccountCapabilitiesType := &CompositeType{
+		Identifier: AuthAccountCapabilitiesTypeName,
+		Kind:       common.CompositeKindStructure,
+		importable: false,
+	}
+
+	var members = []*Member{
+		NewPublicFunctionMember(
+			authAccountCapabilitiesType,
+			AuthAccountCapabilitiesTypeIssueFunctionName,
+			AuthAccountCapabilitiesTypeIssueFunctionType,
+			authAccountCapabilitiesTypeIssueFunctionDocString,
+		),
+		NewPublicFunctionMember(
+			authAccountCapabilitiesType,
+			AuthAccountCapabilitiesTypeGetControllerFunctionName,
+			AuthAccountCapabilitiesTypeGetControllerFunctionType,
+			authAccountCapabilitiesTypeGetControllerFunctionDocString,
+		),
+		NewPublicFunctionMember(
+			authAccountCapabilitiesType,
+			AuthAccountCapabilitiesTypeGetControllersFunctionName,
+			AuthAccountCapabilitiesTypeGetControllersFunctionType,
+			authAccountCapabilitiesTypeGetControllersFunctionDocString,
+		),
+		NewPublicFunctionMember(
+			authAccountCapabilitiesType,
+			AuthAccountCapabilitiesTypePublishFunctionName,
+			AuthAccountCapabilitiesTypePublishFunctionType,
+			authAccountCapabilitiesTypePublishFunctionDocString,
+		),
+	}
+
+	authAccountCapabilitiesType.Members = GetMembersAsMap(members)
+	authAccountCapabilitiesType.Fields = getFieldNames(members)
+	return authAccountCapabilitiesType
+}()
+
+func init() {
+	// Set the container type after initializing the `AuthAccountCapabilitiesType`, to avoid initializing loop.
+	AuthAccountCapabilitiesType.SetContainerType(AuthAccountType)
+}
+
+const authAccountCapabilitiesTypeIssueFunctionDocString = `
+Issues a new capability which targets the given storage path,
+and creates a new controller for it.
+
+The capability has a unique ID, which never changes and is never reused.
+The capability can be borrowed using the given type.
+
+Like links, the target path does not have to lead to a stored object when the capability is issued.
+`
+
+var AuthAccountCapabilitiesTypeIssueFunctionType = func() *FunctionType {
+
+	typeParameter := &TypeParameter{
+		TypeBound: &ReferenceType{
+			Type: AnyType,
+		},
+		Name: "T",
+	}
+
+	return &FunctionType{
+		TypeParameters: []*TypeParameter{
+			typeParameter,
+		},
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "target",
+				TypeAnnotation: NewTypeAnnotation(StoragePathType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&CapabilityType{
+				BorrowType: &GenericType{
+					TypeParameter: typeParameter,
+				},
+			},
+		),
+	}
+}()
+
+const authAccountCapabilitiesTypeGetControllerFunctionDocString = `
+Returns the controller of the capability with the given ID,
+or nil if no capability with the given ID was issued by this account,
+or if the capability was revoked
+`
+
+var AuthAccountCapabilitiesTypeGetControllerFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
+	Parameters: []*Parameter{
+		{
+			Label:          "byCapabilityID",
+			Identifier:     "capabilityID",
+			TypeAnnotation: NewTypeAnnotation(UInt64Type),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&OptionalType{
+			Type: CapabilityControllerType,
+		},
+	),
+}
+
+const authAccountCapabilitiesTypeGetControllersFunctionDocString = `
+Returns the controllers of all capabilities which target the given storage path.
+
+The controllers are not indexed by target path:
+The cost of the function grows with the number of all controllers of the account, not only the returned ones
+`
+
+var AuthAccountCapabilitiesTypeGetControllersFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
+	Parameters: []*Parameter{
+		{
+			Label:          "forPath",
+			Identifier:     "path",
+			TypeAnnotation: NewTypeAnnotation(StoragePathType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&VariableSizedType{
+			Type: CapabilityControllerType,
+		},
+	),
+}
+
+const authAccountCapabilitiesTypePublishFunctionDocString = `
+Publishes the given capability at the given public path,
+so it can be obtained using ` + "`getCapability`" + `.
+
+The capability must have been issued by this account.
+
+If there is already a capability or link at the given path, the program aborts.
+`
+
+var AuthAccountCapabilitiesTypePublishFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "capability",
+			TypeAnnotation: NewTypeAnnotation(&CapabilityType{}),
+		},
+		{
+			Label:          "at",
+			Identifier:     "path",
+			TypeAnnotation: NewTypeAnnotation(PublicPathType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(VoidType),
+}
@@ -169,6 +169,7 @@ Returns nil if no contract/contract interface with the given name exists in the
 `
 
 var AuthAccountContractsTypeGetFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
 	Parameters: []*Parameter{
 		{
 			Identifier: "name",
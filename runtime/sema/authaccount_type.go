@@ -41,6 +41,10 @@ const AuthAccountGetCapabilityField = "getCapability"
 const AuthAccountGetLinkTargetField = "getLinkTarget"
 const AuthAccountContractsField = "contracts"
 const AuthAccountKeysField = "keys"
+const AuthAccountCapabilitiesField = "capabilities"
+const AuthAccountForEachStoredField = "forEachStored"
+const AuthAccountForEachPublicField = "forEachPublic"
+const AuthAccountForEachPrivateField = "forEachPrivate"
 
 // AuthAccountType represents the authorized access to an account.
 // Access to an AuthAccount means having full access to its storage, public keys, and code.
@@ -58,6 +62,7 @@ var AuthAccountType = func() *CompositeType {
 			nestedTypes := NewStringTypeOrderedMap()
 			nestedTypes.Set(AuthAccountContractsTypeName, AuthAccountContractsType)
 			nestedTypes.Set(AccountKeysTypeName, AuthAccountKeysType)
+			nestedTypes.Set(AuthAccountCapabilitiesTypeName, AuthAccountCapabilitiesType)
 			return nestedTypes
 		}(),
 	}
@@ -171,6 +176,30 @@ var AuthAccountType = func() *CompositeType {
 			AuthAccountKeysType,
 			accountTypeKeysFieldDocString,
 		),
+		NewPublicConstantFieldMember(
+			authAccountType,
+			AuthAccountCapabilitiesField,
+			AuthAccountCapabilitiesType,
+			authAccountTypeCapabilitiesFieldDocString,
+		),
+		NewPublicFunctionMember(
+			authAccountType,
+			AuthAccountForEachStoredField,
+			AuthAccountForEachStoredFunctionType,
+			authAccountForEachStoredFunctionDocString,
+		),
+		NewPublicFunctionMember(
+			authAccountType,
+			AuthAccountForEachPublicField,
+			AccountForEachPublicFunctionType,
+			accountForEachPublicFunctionDocString,
+		),
+		NewPublicFunctionMember(
+			authAccountType,
+			AuthAccountForEachPrivateField,
+			AuthAccountForEachPrivateFunctionType,
+			authAccountForEachPrivateFunctionDocString,
+		),
 	}
 
 	authAccountType.Members = GetMembersAsMap(members)
@@ -293,6 +322,7 @@ The path must be a storage path, i.e., only the domain ` + "`storage`" + ` is al
 `
 
 var AuthAccountTypeTypeFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
 	Parameters: []*Parameter{
 		{
 			Label:          "at",
@@ -329,6 +359,7 @@ var AuthAccountTypeCopyFunctionType = func() *FunctionType {
 	}
 
 	return &FunctionType{
+		Purity: FunctionPurityBuiltinView,
 		TypeParameters: []*TypeParameter{
 			typeParameter,
 		},
@@ -372,6 +403,7 @@ var AuthAccountTypeBorrowFunctionType = func() *FunctionType {
 	}
 
 	return &FunctionType{
+		Purity: FunctionPurityBuiltinView,
 		TypeParameters: []*TypeParameter{
 			typeParameter,
 		},
@@ -482,6 +514,7 @@ var AuthAccountTypeGetCapabilityFunctionType = func() *FunctionType {
 	}
 
 	return &FunctionType{
+		Purity: FunctionPurityBuiltinView,
 		TypeParameters: []*TypeParameter{
 			typeParameter,
 		},
@@ -507,6 +540,7 @@ Returns the capability at the given private or public path, or nil if it does no
 `
 
 var AccountTypeGetLinkTargetFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
 	Parameters: []*Parameter{
 		{
 			Label:          ArgumentLabelNotRequired,
@@ -576,6 +610,7 @@ var AuthAccountKeysTypeAddFunctionType = &FunctionType{
 }
 
 var AccountKeysTypeGetFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
 	Parameters: []*Parameter{
 		{
 			Identifier:     AccountKeyKeyIndexField,
@@ -597,6 +632,69 @@ var AuthAccountKeysTypeRevokeFunctionType = &FunctionType{
 	RequiredArgumentCount: RequiredArgumentCount(1),
 }
 
+// AccountForEachFunctionType returns the type of an account's function
+// which iterates over the paths of the given path type,
+// e.g. `forEachStored`, `forEachPublic`, and `forEachPrivate`.
+//
+func AccountForEachFunctionType(pathType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:      ArgumentLabelNotRequired,
+				Identifier: "function",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "path",
+								TypeAnnotation: NewTypeAnnotation(pathType),
+							},
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "type",
+								TypeAnnotation: NewTypeAnnotation(MetaType),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(BoolType),
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(VoidType),
+	}
+}
+
+var AuthAccountForEachStoredFunctionType = AccountForEachFunctionType(StoragePathType)
+
+var AccountForEachPublicFunctionType = AccountForEachFunctionType(PublicPathType)
+
+var AuthAccountForEachPrivateFunctionType = AccountForEachFunctionType(PrivatePathType)
+
+const authAccountForEachStoredFunctionDocString = `
+Iterates over all the objects stored in the account's storage, calling the given function with the path and the type of each object.
+
+The iteration stops when the function returns false.
+
+The account's storage must not be modified while iterating over it.
+`
+
+const accountForEachPublicFunctionDocString = `
+Iterates over all the capabilities linked in the account's public domain, calling the given function with the path and the type of each capability.
+
+The iteration stops when the function returns false.
+
+The account's public domain must not be modified while iterating over it.
+`
+
+const authAccountForEachPrivateFunctionDocString = `
+Iterates over all the capabilities linked in the account's private domain, calling the given function with the path and the type of each capability.
+
+The iteration stops when the function returns false.
+
+The account's private domain must not be modified while iterating over it.
+`
+
 func init() {
 	// Set the container type after initializing the AccountKeysTypes, to avoid initializing loop.
 	AuthAccountKeysType.SetContainerType(AuthAccountType)
@@ -639,6 +737,10 @@ const accountTypeKeysFieldDocString = `
 The keys associated with the account
 `
 
+const authAccountTypeCapabilitiesFieldDocString = `
+The capabilities issued by the account, and their controllers
+`
+
 const authAccountKeysTypeAddFunctionDocString = `
 Adds the given key to the keys list of the account.
 `
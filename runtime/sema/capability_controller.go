@@ -0,0 +1,134 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+)
+
+const CapabilityControllerTypeName = "CapabilityController"
+const CapabilityControllerTypeCapabilityIDField = "capabilityID"
+const CapabilityControllerTypeBorrowTypeField = "borrowType"
+const CapabilityControllerTypeTagField = "tag"
+const CapabilityControllerTypeSetTagFunctionName = "setTag"
+const CapabilityControllerTypeTargetFunctionName = "target"
+const CapabilityControllerTypeRevokeFunctionName = "revoke"
+
+// CapabilityControllerType represents the type `CapabilityController`,
+// the controller of a capability issued through `AuthAccount.capabilities`
+//
+var CapabilityControllerType = func() *CompositeType {
+
+	capabilityControllerType := &CompositeType{
+		Identifier:         CapabilityControllerTypeName,
+		Kind:               common.CompositeKindStructure,
+		hasComputedMembers: true,
+		importable:         false,
+	}
+
+	var members = []*Member{
+		NewPublicConstantFieldMember(
+			capabilityControllerType,
+			CapabilityControllerTypeCapabilityIDField,
+			UInt64Type,
+			capabilityControllerTypeCapabilityIDFieldDocString,
+		),
+		NewPublicConstantFieldMember(
+			capabilityControllerType,
+			CapabilityControllerTypeBorrowTypeField,
+			MetaType,
+			capabilityControllerTypeBorrowTypeFieldDocString,
+		),
+		NewPublicConstantFieldMember(
+			capabilityControllerType,
+			CapabilityControllerTypeTagField,
+			StringType,
+			capabilityControllerTypeTagFieldDocString,
+		),
+		NewPublicFunctionMember(
+			capabilityControllerType,
+			CapabilityControllerTypeSetTagFunctionName,
+			CapabilityControllerTypeSetTagFunctionType,
+			capabilityControllerTypeSetTagFunctionDocString,
+		),
+		NewPublicFunctionMember(
+			capabilityControllerType,
+			CapabilityControllerTypeTargetFunctionName,
+			CapabilityControllerTypeTargetFunctionType,
+			capabilityControllerTypeTargetFunctionDocString,
+		),
+		NewPublicFunctionMember(
+			capabilityControllerType,
+			CapabilityControllerTypeRevokeFunctionName,
+			CapabilityControllerTypeRevokeFunctionType,
+			capabilityControllerTypeRevokeFunctionDocString,
+		),
+	}
+
+	capabilityControllerType.Members = GetMembersAsMap(members)
+	capabilityControllerType.Fields = getFieldNames(members)
+	return capabilityControllerType
+}()
+
+const capabilityControllerTypeCapabilityIDFieldDocString = `
+The ID of the controlled capability
+`
+
+const capabilityControllerTypeBorrowTypeFieldDocString = `
+The type of the controlled capability, i.e. the reference type the capability can be borrowed as
+`
+
+const capabilityControllerTypeTagFieldDocString = `
+An arbitrary tag for the controller, e.g. to describe the purpose of the capability.
+Empty by default
+`
+
+const capabilityControllerTypeSetTagFunctionDocString = `
+Updates the tag of the controller
+`
+
+var CapabilityControllerTypeSetTagFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "tag",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(VoidType),
+}
+
+const capabilityControllerTypeTargetFunctionDocString = `
+Returns the storage path the controlled capability targets
+`
+
+var CapabilityControllerTypeTargetFunctionType = &FunctionType{
+	Purity:               FunctionPurityBuiltinView,
+	ReturnTypeAnnotation: NewTypeAnnotation(StoragePathType),
+}
+
+const capabilityControllerTypeRevokeFunctionDocString = `
+Revokes the controlled capability and removes the controller.
+
+Borrowing the capability fails afterwards, but the capabilities of other controllers, even if they target the same path, are not affected.
+`
+
+var CapabilityControllerTypeRevokeFunctionType = &FunctionType{
+	ReturnTypeAnnotation: NewTypeAnnotation(VoidType),
+}
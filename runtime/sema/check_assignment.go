@@ -203,6 +203,13 @@ func (checker *Checker) visitIdentifierExpressionAssignment(
 		)
 	}
 
+	// Assigning to a variable declared outside of the current function
+	// is a side effect
+
+	if !checker.isLocalVariable(variable) {
+		checker.observeImpureOperation("assignment to non-local variable", target)
+	}
+
 	return variable.Type
 }
 
@@ -212,6 +219,8 @@ func (checker *Checker) visitIndexExpressionAssignment(
 
 	elementType = checker.visitIndexExpression(target, true)
 
+	checker.observeImpureOperation("assignment to element", target)
+
 	targetExpression := target.TargetExpression
 	switch targetExpression := targetExpression.(type) {
 	case *ast.MemberExpression:
@@ -246,6 +255,8 @@ func (checker *Checker) visitMemberExpressionAssignment(
 		return InvalidType
 	}
 
+	checker.observeImpureOperation("assignment to member", target)
+
 	if isOptional {
 		checker.report(
 			&UnsupportedOptionalChainingAssignmentError{
@@ -0,0 +1,76 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+func (checker *Checker) VisitAttachExpression(expression *ast.AttachExpression) ast.Repr {
+
+	// NOTE: check the base first, as it is evaluated first
+
+	baseExpression := expression.Base
+
+	baseType := checker.VisitExpression(baseExpression, nil)
+
+	checker.checkVariableMove(baseExpression)
+	checker.checkResourceMoveOperation(baseExpression, baseType)
+
+	invocation := expression.Attachment
+
+	ty := checker.checkInvocationExpression(invocation)
+
+	if ty.IsInvalidType() {
+		return baseType
+	}
+
+	// Check that the attached expression is an attachment
+
+	attachmentType, isCompositeType := ty.(*CompositeType)
+	if !isCompositeType || attachmentType.Kind != common.CompositeKindAttachment {
+		checker.report(
+			&AttachNonAttachmentError{
+				Type:  ty,
+				Range: ast.NewRangeFromPositioned(invocation),
+			},
+		)
+		return baseType
+	}
+
+	checker.Elaboration.AttachExpressionTypes[expression] = attachmentType
+
+	// Check that the base is a subtype of the type the attachment is declared for
+
+	if !baseType.IsInvalidType() &&
+		!attachmentType.AttachmentBaseType.IsInvalidType() &&
+		!IsSubType(baseType, attachmentType.AttachmentBaseType) {
+
+		checker.report(
+			&TypeMismatchError{
+				ExpectedType: attachmentType.AttachmentBaseType,
+				ActualType:   baseType,
+				Range:        ast.NewRangeFromPositioned(baseExpression),
+			},
+		)
+	}
+
+	return baseType
+}
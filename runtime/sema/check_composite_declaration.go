@@ -76,6 +76,11 @@ func (checker *Checker) visitCompositeDeclaration(declaration *ast.CompositeDecl
 
 	checker.declareCompositeNestedTypes(declaration, kind, true)
 
+	// The type parameters of a generic composite type are in scope
+	// for the initializers and functions
+
+	checker.declareTypeParameters(compositeType.TypeParameters(), declaration.TypeParameterList)
+
 	var initializationInfo *InitializationInfo
 
 	if kind == ContainerKindComposite {
@@ -197,6 +202,10 @@ func (checker *Checker) visitCompositeDeclaration(declaration *ast.CompositeDecl
 	for _, nestedComposite := range declaration.Members.Composites() {
 		nestedComposite.Accept(checker)
 	}
+
+	for _, nestedTypeAlias := range declaration.Members.TypeAliases() {
+		nestedTypeAlias.Accept(checker)
+	}
 }
 
 // declareCompositeNestedTypes declares the types nested in a composite,
@@ -283,6 +292,8 @@ func (checker *Checker) declareCompositeNestedTypes(
 			}
 		}
 	})
+
+	checker.declareNestedTypeAliasVariables(declaration.Members.TypeAliases())
 }
 
 func (checker *Checker) declareNestedDeclarations(
@@ -345,7 +356,8 @@ func (checker *Checker) declareNestedDeclarations(
 			case common.CompositeKindResource,
 				common.CompositeKindStructure,
 				common.CompositeKindEvent,
-				common.CompositeKindEnum:
+				common.CompositeKindEnum,
+				common.CompositeKindAttachment:
 				break
 
 			default:
@@ -416,11 +428,14 @@ func (checker *Checker) declareCompositeType(declaration *ast.CompositeDeclarati
 
 	identifier := declaration.Identifier
 
+	common.UseMemory(checker.memoryGauge, common.CompositeSemaTypeMemoryUsage)
+
 	compositeType := &CompositeType{
 		Location:    checker.Location,
 		Kind:        declaration.CompositeKind,
 		Identifier:  identifier.Identifier,
 		nestedTypes: NewStringTypeOrderedMap(),
+		typeAliases: NewStringTypeOrderedMap(),
 		Members:     NewStringMemberOrderedMap(),
 	}
 
@@ -441,6 +456,24 @@ func (checker *Checker) declareCompositeType(declaration *ast.CompositeDeclarati
 		)
 	}
 
+	// Resolve type parameters.
+	// Only structures and resources can be generic
+
+	switch declaration.CompositeKind {
+	case common.CompositeKindStructure,
+		common.CompositeKindResource:
+
+		compositeType.SetTypeParameters(
+			checker.typeParameters(declaration.TypeParameterList),
+		)
+
+	default:
+		checker.checkTypeParametersSupported(
+			declaration.TypeParameterList,
+			declaration.DeclarationKind(),
+		)
+	}
+
 	// Resolve conformances
 
 	if declaration.CompositeKind == common.CompositeKindEnum {
@@ -485,6 +518,14 @@ func (checker *Checker) declareCompositeType(declaration *ast.CompositeDeclarati
 		nestedCompositeType.SetContainerType(compositeType)
 	}
 
+	// Declare nested type aliases
+
+	checker.declareNestedTypeAliases(
+		declaration,
+		declaration.CompositeKind,
+		declaration.Members.TypeAliases(),
+	)
+
 	return compositeType
 }
 
@@ -517,6 +558,18 @@ func (checker *Checker) declareCompositeMembersAndValue(
 
 		checker.declareCompositeNestedTypes(declaration, kind, false)
 
+		// The type parameters of a generic composite type are in scope
+		// for the initializer parameter types and the members
+
+		checker.declareTypeParameters(compositeType.TypeParameters(), declaration.TypeParameterList)
+
+		// NOTE: determine the base type of an attachment after all types are declared,
+		// as the base type may be declared after the attachment
+
+		if declaration.CompositeKind == common.CompositeKindAttachment {
+			compositeType.AttachmentBaseType = checker.attachmentBaseType(declaration)
+		}
+
 		// NOTE: determine initializer parameter types while nested types are in scope,
 		// and after declaring nested types as the initializer may use nested type in parameters
 
@@ -631,6 +684,13 @@ func (checker *Checker) declareCompositeMembersAndValue(
 			)
 		}
 
+		// Inherit the default functions of the interfaces the composite conforms to.
+		// Composites nested in interfaces are type requirements, which do not inherit
+
+		if kind == ContainerKindComposite {
+			checker.inheritDefaultFunctions(declaration, compositeType, members)
+		}
+
 		if compositeType.Kind == common.CompositeKindContract {
 			checker.checkMemberStorability(members)
 		}
@@ -640,6 +700,12 @@ func (checker *Checker) declareCompositeMembersAndValue(
 		if checker.positionInfoEnabled {
 			checker.memberOrigins[compositeType] = origins
 		}
+
+		// Instantiations of the generic composite type
+		// which were created before its members were declared
+		// can be completed now
+
+		compositeType.CompleteInstantiations()
 	})()
 
 	// Always determine composite constructor type
@@ -681,6 +747,37 @@ func (checker *Checker) declareCompositeMembersAndValue(
 	}
 }
 
+// attachmentBaseType returns the type the given attachment declaration is declared for.
+// Only structures and resources can be extended with attachments.
+//
+func (checker *Checker) attachmentBaseType(declaration *ast.CompositeDeclaration) Type {
+	if declaration.BaseType == nil {
+		return InvalidType
+	}
+
+	baseType := checker.ConvertType(declaration.BaseType)
+	if baseType.IsInvalidType() {
+		return baseType
+	}
+
+	compositeType, ok := baseType.(*CompositeType)
+	if !ok ||
+		(compositeType.Kind != common.CompositeKindStructure &&
+			compositeType.Kind != common.CompositeKindResource) {
+
+		checker.report(
+			&InvalidAttachmentBaseTypeError{
+				Type:  baseType,
+				Range: ast.NewRangeFromPositioned(declaration.BaseType),
+			},
+		)
+
+		return InvalidType
+	}
+
+	return compositeType
+}
+
 func (checker *Checker) declareCompositeConstructor(
 	declaration *ast.CompositeDeclaration,
 	constructorType *FunctionType,
@@ -804,6 +901,7 @@ func (checker *Checker) declareEnumConstructor(
 func EnumConstructorType(compositeType *CompositeType) *FunctionType {
 	return &FunctionType{
 		IsConstructor: true,
+		Purity:        FunctionPurityBuiltinView,
 		Parameters: []*Parameter{
 			{
 				Identifier:     EnumRawValueFieldName,
@@ -1083,6 +1181,66 @@ func (checker *Checker) checkCompositeConformance(
 	}
 }
 
+// inheritDefaultFunctions declares the default functions of the interfaces
+// the given composite type conforms to as members of the composite type,
+// unless the composite declares a member with the same name itself.
+//
+// It is invalid for multiple interfaces to provide a default function
+// with the same name, unless the composite implements the function itself.
+//
+func (checker *Checker) inheritDefaultFunctions(
+	declaration *ast.CompositeDeclaration,
+	compositeType *CompositeType,
+	members *StringMemberOrderedMap,
+) {
+	inheritedFrom := map[string]*InterfaceType{}
+
+	for _, conformance := range compositeType.ExplicitInterfaceConformances {
+		conformance.Members.Foreach(func(name string, interfaceMember *Member) {
+			if !interfaceMember.HasImplementation {
+				return
+			}
+
+			otherConformance, ok := inheritedFrom[name]
+			if ok {
+				checker.report(
+					&DefaultFunctionConflictError{
+						CompositeType:       compositeType,
+						FunctionName:        name,
+						FirstInterfaceType:  otherConformance,
+						SecondInterfaceType: conformance,
+						Range:               ast.NewRangeFromPositioned(declaration.Identifier),
+					},
+				)
+				return
+			}
+
+			// The composite's own member overrides the default function
+
+			if _, ok := members.Get(name); ok {
+				return
+			}
+
+			inheritedFrom[name] = conformance
+
+			members.Set(
+				name,
+				&Member{
+					ContainerType:     compositeType,
+					Access:            checker.effectiveInterfaceMemberAccess(interfaceMember.Access),
+					Identifier:        interfaceMember.Identifier,
+					DeclarationKind:   interfaceMember.DeclarationKind,
+					TypeAnnotation:    interfaceMember.TypeAnnotation,
+					VariableKind:      interfaceMember.VariableKind,
+					ArgumentLabels:    interfaceMember.ArgumentLabels,
+					DocString:         interfaceMember.DocString,
+					HasImplementation: true,
+				},
+			)
+		})
+	}
+}
+
 // TODO: return proper error
 func (checker *Checker) memberSatisfied(compositeMember, interfaceMember *Member) bool {
 
@@ -1129,6 +1287,15 @@ func (checker *Checker) memberSatisfied(compositeMember, interfaceMember *Member
 				return false
 			}
 
+			// A view function requirement must be implemented by a view function.
+			// An impure function requirement may be implemented by a view function
+
+			if interfaceMemberFunctionType.Purity.IsView() &&
+				!compositeMemberFunctionType.Purity.IsView() {
+
+				return false
+			}
+
 			// Functions are invariant in their parameter types
 
 			for i, subParameter := range compositeMemberFunctionType.Parameters {
@@ -1311,8 +1478,13 @@ func (checker *Checker) compositeConstructorType(
 	argumentLabels []string,
 ) {
 
+	// The constructor of a generic composite type is generic:
+	// The type arguments of the instantiation are inferred from the arguments,
+	// or are given explicitly, e.g. `Box<Int>(value: 1)`
+
 	constructorFunctionType = &FunctionType{
 		IsConstructor:        true,
+		TypeParameters:       compositeType.TypeParameters(),
 		ReturnTypeAnnotation: NewTypeAnnotation(compositeType),
 	}
 
@@ -1478,7 +1650,21 @@ func (checker *Checker) defaultMembersAndOrigins(
 
 		identifier := function.Identifier.Identifier
 
-		functionType := checker.functionType(function.ParameterList, function.ReturnTypeAnnotation)
+		if containerKind == ContainerKindInterface {
+			checker.checkTypeParametersSupported(
+				function.TypeParameterList,
+				common.DeclarationKindFunction,
+			)
+		}
+
+		functionType := checker.functionDeclarationType(function)
+
+		// NOTE: record the function type of a generic function,
+		// so the function is checked with the same type parameters as the member
+
+		if len(functionType.TypeParameters) > 0 {
+			checker.Elaboration.FunctionDeclarationFunctionTypes[function] = functionType
+		}
 
 		argumentLabels := function.ParameterList.EffectiveArgumentLabels()
 
@@ -1501,17 +1687,25 @@ func (checker *Checker) defaultMembersAndOrigins(
 			)
 		}
 
+		// Functions of interfaces (but not of type requirements)
+		// may have a default implementation
+
+		_, isInterfaceType := containerType.(*InterfaceType)
+		hasImplementation := isInterfaceType &&
+			function.FunctionBlock.HasStatements()
+
 		members.Set(
 			identifier,
 			&Member{
-				ContainerType:   containerType,
-				Access:          function.Access,
-				Identifier:      function.Identifier,
-				DeclarationKind: declarationKind,
-				TypeAnnotation:  fieldTypeAnnotation,
-				VariableKind:    ast.VariableKindConstant,
-				ArgumentLabels:  argumentLabels,
-				DocString:       function.DocString,
+				ContainerType:     containerType,
+				Access:            function.Access,
+				Identifier:        function.Identifier,
+				DeclarationKind:   declarationKind,
+				TypeAnnotation:    fieldTypeAnnotation,
+				VariableKind:      ast.VariableKindConstant,
+				ArgumentLabels:    argumentLabels,
+				DocString:         function.DocString,
+				HasImplementation: hasImplementation,
 			})
 
 		if checker.positionInfoEnabled && origins != nil {
@@ -1802,6 +1996,13 @@ func (checker *Checker) checkCompositeFunctions(
 
 			checker.declareSelfValue(selfType, selfDocString)
 
+			// The functions of an attachment can access the value
+			// the attachment is attached to through `base`
+
+			if selfType.Kind == common.CompositeKindAttachment {
+				checker.declareBaseValue(selfType.AttachmentBaseType)
+			}
+
 			checker.visitFunctionDeclaration(
 				function,
 				functionDeclarationOptions{
@@ -1845,6 +2046,36 @@ func (checker *Checker) declareSelfValue(selfType Type, selfDocString string) {
 	}
 }
 
+// declareBaseValue declares `base`, a reference to the value an attachment is attached to.
+//
+func (checker *Checker) declareBaseValue(baseType Type) {
+
+	// NOTE: declare `base` one depth lower ("inside" function),
+	// so it can't be re-declared by the function's parameters
+
+	depth := checker.valueActivations.Depth() + 1
+
+	if !baseType.IsInvalidType() {
+		baseType = &ReferenceType{
+			Type: baseType,
+		}
+	}
+
+	base := &Variable{
+		Identifier:      BaseIdentifier,
+		Access:          ast.AccessPublic,
+		DeclarationKind: common.DeclarationKindConstant,
+		Type:            baseType,
+		IsConstant:      true,
+		ActivationDepth: depth,
+		Pos:             nil,
+	}
+	checker.valueActivations.Set(BaseIdentifier, base)
+	if checker.positionInfoEnabled {
+		checker.recordVariableDeclarationOccurrence(BaseIdentifier, base)
+	}
+}
+
 // checkNestedIdentifiers checks that nested identifiers, i.e. fields, functions,
 // and nested interfaces and composites, are unique and aren't named `init` or `destroy`
 //
@@ -26,6 +26,8 @@ import (
 func (checker *Checker) VisitEmitStatement(statement *ast.EmitStatement) ast.Repr {
 	invocation := statement.InvocationExpression
 
+	checker.observeImpureOperation("emit", statement)
+
 	ty := checker.checkInvocationExpression(invocation)
 
 	if ty.IsInvalidType() {
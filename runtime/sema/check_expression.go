@@ -255,6 +255,20 @@ func (checker *Checker) visitIndexExpression(
 		return InvalidType
 	}
 
+	// Composites (and references to them) are indexed by attachment types
+
+	if baseType := attachmentAccessBaseType(targetType); baseType != nil {
+		indexingType := ast.ExpressionAsType(indexExpression.IndexingExpression)
+		if indexingType != nil {
+			return checker.visitAttachmentAccessExpression(
+				indexExpression,
+				baseType,
+				indexingType,
+				isAssignment,
+			)
+		}
+	}
+
 	// Check if the type instance is actually indexable. For most types (e.g. arrays and dictionaries)
 	// this is known statically (in the sense of this host language (Go), not the implemented language),
 	// i.e. a Go type switch would be sufficient.
@@ -292,6 +306,89 @@ func (checker *Checker) visitIndexExpression(
 	return elementType
 }
 
+// attachmentAccessBaseType returns the composite type of the given indexed type,
+// if attachments of the indexed type can be accessed, or nil otherwise.
+//
+func attachmentAccessBaseType(indexedType Type) *CompositeType {
+	if referenceType, ok := indexedType.(*ReferenceType); ok {
+		indexedType = referenceType.Type
+	}
+
+	compositeType, ok := indexedType.(*CompositeType)
+	if !ok {
+		return nil
+	}
+
+	switch compositeType.Kind {
+	case common.CompositeKindStructure,
+		common.CompositeKindResource:
+
+		return compositeType
+	}
+
+	return nil
+}
+
+// visitAttachmentAccessExpression checks an access of an attachment,
+// e.g. `r[A]`, where the indexing expression must be an attachment type
+// declared for the indexed composite's type.
+//
+// The result is an optional reference to the attachment.
+//
+func (checker *Checker) visitAttachmentAccessExpression(
+	indexExpression *ast.IndexExpression,
+	baseType *CompositeType,
+	indexingType ast.Type,
+	isAssignment bool,
+) Type {
+	indexingExpression := indexExpression.IndexingExpression
+
+	if isAssignment {
+		checker.report(
+			&NotIndexingAssignableTypeError{
+				Type:  baseType,
+				Range: ast.NewRangeFromPositioned(indexExpression.TargetExpression),
+			},
+		)
+	}
+
+	ty := checker.ConvertType(indexingType)
+	if ty.IsInvalidType() {
+		return InvalidType
+	}
+
+	attachmentType, ok := ty.(*CompositeType)
+	if !ok || attachmentType.Kind != common.CompositeKindAttachment {
+		checker.report(
+			&InvalidAttachmentAccessError{
+				Type:  ty,
+				Range: ast.NewRangeFromPositioned(indexingExpression),
+			},
+		)
+		return InvalidType
+	}
+
+	if !attachmentType.AttachmentBaseType.IsInvalidType() &&
+		!IsSubType(baseType, attachmentType.AttachmentBaseType) {
+
+		checker.report(
+			&TypeMismatchError{
+				ExpectedType: attachmentType.AttachmentBaseType,
+				ActualType:   baseType,
+				Range:        ast.NewRangeFromPositioned(indexExpression.TargetExpression),
+			},
+		)
+	}
+
+	checker.Elaboration.AttachmentAccessTypes[indexExpression] = attachmentType
+
+	return &OptionalType{
+		Type: &ReferenceType{
+			Type: attachmentType,
+		},
+	}
+}
+
 func (checker *Checker) visitValueIndexingExpression(
 	indexedType ValueIndexableType,
 	indexingExpression ast.Expression,
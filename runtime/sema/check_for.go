@@ -30,7 +30,8 @@ func (checker *Checker) VisitForStatement(statement *ast.ForStatement) ast.Repr
 
 	valueExpression := statement.Value
 
-	// iterations are only supported for non-resource arrays.
+	// iterations are only supported for non-resource arrays and dictionaries,
+	// and references to arrays and dictionaries.
 	// Hence, if the array is empty and no context type is available,
 	// then default it to [AnyStruct].
 	var expectedType Type
@@ -44,6 +45,7 @@ func (checker *Checker) VisitForStatement(statement *ast.ForStatement) ast.Repr
 	valueType := checker.VisitExpression(valueExpression, expectedType)
 
 	var elementType Type = InvalidType
+	var indexType Type = IntType
 
 	if !valueType.IsInvalidType() {
 
@@ -58,16 +60,10 @@ func (checker *Checker) VisitForStatement(statement *ast.ForStatement) ast.Repr
 					Range: ast.NewRangeFromPositioned(valueExpression),
 				},
 			)
-		} else if arrayType, ok := valueType.(ArrayType); ok {
-			elementType = arrayType.ElementType(false)
 		} else {
-			checker.report(
-				&TypeMismatchWithDescriptionError{
-					ExpectedTypeDescription: "array",
-					ActualType:              valueType,
-					Range:                   ast.NewRangeFromPositioned(valueExpression),
-				},
-			)
+			elementType, indexType = checker.forStatementVariableTypes(statement, valueType)
+
+			checker.Elaboration.ForStatementValueTypes[statement] = valueType
 		}
 	}
 
@@ -91,7 +87,7 @@ func (checker *Checker) VisitForStatement(statement *ast.ForStatement) ast.Repr
 		index := statement.Index.Identifier
 		indexVariable, err := checker.valueActivations.Declare(variableDeclaration{
 			identifier:               index,
-			ty:                       IntType,
+			ty:                       indexType,
 			kind:                     common.DeclarationKindConstant,
 			pos:                      statement.Index.Pos,
 			isConstant:               true,
@@ -121,3 +117,63 @@ func (checker *Checker) VisitForStatement(statement *ast.ForStatement) ast.Repr
 
 	return nil
 }
+
+// forStatementVariableTypes returns the types of the loop variable
+// and the index variable of the given for-in statement.
+//
+// For arrays, the loop variable is bound to the elements, and the index variable to the indices.
+// For dictionaries, the loop variable is bound to the keys if there is no index variable.
+// Otherwise the index variable is bound to the keys, and the loop variable to the values.
+//
+// References to arrays and dictionaries are iterated in place.
+// If the elements are resources, the loop variable is a reference to the element.
+//
+func (checker *Checker) forStatementVariableTypes(
+	statement *ast.ForStatement,
+	valueType Type,
+) (
+	elementType Type,
+	indexType Type,
+) {
+	elementType = InvalidType
+	indexType = IntType
+
+	iteratedType := valueType
+	referenceType, isReference := valueType.(*ReferenceType)
+	if isReference {
+		iteratedType = referenceType.Type
+	}
+
+	switch iteratedType := iteratedType.(type) {
+	case ArrayType:
+		elementType = iteratedType.ElementType(false)
+
+	case *DictionaryType:
+		if statement.Index == nil {
+			elementType = iteratedType.KeyType
+		} else {
+			indexType = iteratedType.KeyType
+			elementType = iteratedType.ValueType
+		}
+
+	default:
+		checker.report(
+			&TypeMismatchWithDescriptionError{
+				ExpectedTypeDescription: "array or dictionary, or reference to array or dictionary",
+				ActualType:              valueType,
+				Range:                   ast.NewRangeFromPositioned(statement.Value),
+			},
+		)
+
+		return
+	}
+
+	if isReference && elementType.IsResourceType() {
+		elementType = &ReferenceType{
+			Authorized: referenceType.Authorized,
+			Type:       elementType,
+		}
+	}
+
+	return
+}
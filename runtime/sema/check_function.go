@@ -65,7 +65,7 @@ func (checker *Checker) visitFunctionDeclaration(
 
 	functionType := checker.Elaboration.FunctionDeclarationFunctionTypes[declaration]
 	if functionType == nil {
-		functionType = checker.functionType(declaration.ParameterList, declaration.ReturnTypeAnnotation)
+		functionType = checker.functionDeclarationType(declaration)
 
 		if options.declareFunction {
 			checker.declareFunctionDeclaration(declaration, functionType)
@@ -74,14 +74,22 @@ func (checker *Checker) visitFunctionDeclaration(
 
 	checker.Elaboration.FunctionDeclarationFunctionTypes[declaration] = functionType
 
-	checker.checkFunction(
-		declaration.ParameterList,
-		declaration.ReturnTypeAnnotation,
-		functionType,
-		declaration.FunctionBlock,
-		options.mustExit,
-		nil,
-		options.checkResourceLoss,
+	// The type parameters of a generic function are in scope in the function body
+
+	checker.withTypeParameters(
+		functionType.TypeParameters,
+		declaration.TypeParameterList,
+		func() {
+			checker.checkFunction(
+				declaration.ParameterList,
+				declaration.ReturnTypeAnnotation,
+				functionType,
+				declaration.FunctionBlock,
+				options.mustExit,
+				nil,
+				options.checkResourceLoss,
+			)
+		},
 	)
 
 	return nil
@@ -419,7 +427,11 @@ func (checker *Checker) declareBefore() {
 func (checker *Checker) VisitFunctionExpression(expression *ast.FunctionExpression) ast.Repr {
 
 	// TODO: infer
-	functionType := checker.functionType(expression.ParameterList, expression.ReturnTypeAnnotation)
+	functionType := checker.functionType(
+		expression.Purity,
+		expression.ParameterList,
+		expression.ReturnTypeAnnotation,
+	)
 
 	checker.Elaboration.FunctionExpressionFunctionType[expression] = functionType
 
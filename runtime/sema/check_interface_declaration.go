@@ -120,6 +120,10 @@ func (checker *Checker) VisitInterfaceDeclaration(declaration *ast.InterfaceDecl
 		checker.visitCompositeDeclaration(nestedComposite, kind)
 	}
 
+	for _, nestedTypeAlias := range declaration.Members.TypeAliases() {
+		nestedTypeAlias.Accept(checker)
+	}
+
 	return nil
 }
 
@@ -158,6 +162,8 @@ func (checker *Checker) declareInterfaceNestedTypes(
 		})
 		checker.report(err)
 	})
+
+	checker.declareNestedTypeAliasVariables(declaration.Members.TypeAliases())
 }
 
 func (checker *Checker) checkInterfaceFunctions(
@@ -166,27 +172,35 @@ func (checker *Checker) checkInterfaceFunctions(
 	declarationKind common.DeclarationKind,
 	selfDocString string,
 ) {
+	// Functions of interfaces may have a default implementation.
+	// Functions of type requirements may not
+
+	_, isInterfaceType := selfType.(*InterfaceType)
+
 	for _, function := range functions {
 		// NOTE: new activation, as function declarations
 		// shouldn't be visible in other function declarations,
 		// and `self` is is only visible inside function
 
+		hasImplementation := isInterfaceType &&
+			function.FunctionBlock.HasStatements()
+
 		func() {
 			checker.enterValueScope()
-			defer checker.leaveValueScope(function.EndPosition, false)
+			defer checker.leaveValueScope(function.EndPosition, hasImplementation)
 
 			checker.declareSelfValue(selfType, selfDocString)
 
 			checker.visitFunctionDeclaration(
 				function,
 				functionDeclarationOptions{
-					mustExit:          false,
+					mustExit:          hasImplementation,
 					declareFunction:   false,
-					checkResourceLoss: false,
+					checkResourceLoss: hasImplementation,
 				},
 			)
 
-			if function.FunctionBlock != nil {
+			if function.FunctionBlock != nil && !hasImplementation {
 				checker.checkInterfaceSpecialFunctionBlock(
 					function.FunctionBlock,
 					declarationKind,
@@ -210,11 +224,14 @@ func (checker *Checker) declareInterfaceType(declaration *ast.InterfaceDeclarati
 
 	identifier := declaration.Identifier
 
+	common.UseMemory(checker.memoryGauge, common.InterfaceSemaTypeMemoryUsage)
+
 	interfaceType := &InterfaceType{
 		Location:      checker.Location,
 		Identifier:    identifier.Identifier,
 		CompositeKind: declaration.CompositeKind,
 		nestedTypes:   NewStringTypeOrderedMap(),
+		typeAliases:   NewStringTypeOrderedMap(),
 		Members:       NewStringMemberOrderedMap(),
 	}
 
@@ -274,6 +291,14 @@ func (checker *Checker) declareInterfaceType(declaration *ast.InterfaceDeclarati
 		nestedCompositeType.SetContainerType(interfaceType)
 	}
 
+	// Declare nested type aliases
+
+	checker.declareNestedTypeAliases(
+		declaration,
+		declaration.CompositeKind,
+		declaration.Members.TypeAliases(),
+	)
+
 	return interfaceType
 }
 
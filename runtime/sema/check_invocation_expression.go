@@ -26,17 +26,27 @@ import (
 func (checker *Checker) VisitInvocationExpression(invocationExpression *ast.InvocationExpression) ast.Repr {
 	ty := checker.checkInvocationExpression(invocationExpression)
 
-	// Events cannot be invoked without an emit statement
+	// Events cannot be invoked without an emit statement,
+	// and attachments cannot be created without an attach expression
 
-	if compositeType, ok := ty.(*CompositeType); ok &&
-		compositeType.Kind == common.CompositeKindEvent {
+	if compositeType, ok := ty.(*CompositeType); ok {
+		switch compositeType.Kind {
+		case common.CompositeKindEvent:
+			checker.report(
+				&InvalidEventUsageError{
+					Range: ast.NewRangeFromPositioned(invocationExpression),
+				},
+			)
+			return InvalidType
 
-		checker.report(
-			&InvalidEventUsageError{
-				Range: ast.NewRangeFromPositioned(invocationExpression),
-			},
-		)
-		return InvalidType
+		case common.CompositeKindAttachment:
+			checker.report(
+				&InvalidAttachmentUsageError{
+					Range: ast.NewRangeFromPositioned(invocationExpression),
+				},
+			)
+			return InvalidType
+		}
 	}
 
 	return ty
@@ -107,6 +117,16 @@ func (checker *Checker) checkInvocationExpression(invocationExpression *ast.Invo
 		return InvalidType
 	}
 
+	// Invoking a non-view function is a side effect.
+	// Event constructors are only invoked in emit statements,
+	// which are reported separately
+
+	if !functionType.Purity.IsView() &&
+		!isEventConstructor(functionType) {
+
+		checker.observeImpureOperation("invocation of non-view function", invocationExpression)
+	}
+
 	// The invoked expression has a function type,
 	// check the invocation including all arguments.
 	//
@@ -245,6 +265,15 @@ func (checker *Checker) checkMemberInvocationResourceInvalidation(invokedExpress
 	)
 }
 
+func isEventConstructor(functionType *FunctionType) bool {
+	if !functionType.IsConstructor {
+		return false
+	}
+
+	compositeType, ok := functionType.ReturnTypeAnnotation.Type.(*CompositeType)
+	return ok && compositeType.Kind == common.CompositeKindEvent
+}
+
 func (checker *Checker) checkConstructorInvocationWithResourceResult(
 	invocationExpression *ast.InvocationExpression,
 	functionType *FunctionType,
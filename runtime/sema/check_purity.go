@@ -0,0 +1,62 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import "github.com/onflow/cadence/runtime/ast"
+
+// enforcesPurity returns true if the currently checked code
+// must not have side effects, i.e. if it is in the body of a view function,
+// or in a condition and the purity of conditions is enforced.
+//
+func (checker *Checker) enforcesPurity() bool {
+	if checker.inCondition && checker.conditionPurityEnforced {
+		return true
+	}
+
+	functionActivation := checker.functionActivations.Current()
+	return functionActivation != nil && functionActivation.IsView()
+}
+
+// observeImpureOperation reports an error
+// if the given impure operation is performed in a view context.
+//
+func (checker *Checker) observeImpureOperation(operation string, element ast.HasPosition) {
+	if !checker.enforcesPurity() {
+		return
+	}
+
+	checker.report(
+		&PurityError{
+			Operation: operation,
+			Range:     ast.NewRangeFromPositioned(element),
+		},
+	)
+}
+
+// isLocalVariable returns true if the given variable
+// was declared in the currently checked function,
+// i.e. assigning to it has no effect outside of the function.
+//
+func (checker *Checker) isLocalVariable(variable *Variable) bool {
+	functionActivation := checker.functionActivations.Current()
+	if functionActivation == nil {
+		return false
+	}
+	return variable.ActivationDepth > functionActivation.ValueActivationDepth
+}
@@ -0,0 +1,297 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+func (checker *Checker) VisitTypeAliasDeclaration(declaration *ast.TypeAliasDeclaration) ast.Repr {
+
+	checker.checkDeclarationAccessModifier(
+		declaration.Access,
+		declaration.DeclarationKind(),
+		declaration.StartPos,
+		true,
+	)
+
+	// NOTE: the aliased type was already resolved in `resolveTypeAliases`
+
+	ty := checker.typeAliasType(declaration)
+
+	checker.checkTypeAnnotation(
+		&TypeAnnotation{
+			IsResource: declaration.TypeAnnotation.IsResource,
+			Type:       ty,
+		},
+		declaration.TypeAnnotation,
+	)
+
+	return nil
+}
+
+// declareTypeAlias declares the given top-level type alias declaration.
+//
+// NOTE: The aliased type is only resolved later, in `resolveTypeAliases`,
+// because it may refer to types that are declared after the type alias.
+//
+func (checker *Checker) declareTypeAlias(declaration *ast.TypeAliasDeclaration) {
+
+	variable := checker.declareTypeAliasVariable(declaration, false)
+
+	if checker.positionInfoEnabled {
+		checker.recordVariableDeclarationOccurrence(
+			declaration.Identifier.Identifier,
+			variable,
+		)
+	}
+
+	checker.typeAliasDeclarations = append(checker.typeAliasDeclarations, declaration)
+}
+
+// declareNestedTypeAliases declares the given type alias declarations,
+// which are nested in the given container declaration.
+//
+// NOTE: Like for top-level type aliases, the aliased types are only resolved later,
+// in `resolveTypeAliases`.
+//
+func (checker *Checker) declareNestedTypeAliases(
+	containerDeclaration ast.Declaration,
+	containerCompositeKind common.CompositeKind,
+	nestedTypeAliasDeclarations []*ast.TypeAliasDeclaration,
+) {
+	// Only contracts and contract interfaces support nested type aliases
+
+	if containerCompositeKind != common.CompositeKindContract &&
+		len(nestedTypeAliasDeclarations) > 0 {
+
+		firstNestedTypeAliasDeclaration := nestedTypeAliasDeclarations[0]
+
+		checker.report(
+			&InvalidNestedDeclarationError{
+				NestedDeclarationKind:    firstNestedTypeAliasDeclaration.DeclarationKind(),
+				ContainerDeclarationKind: containerDeclaration.DeclarationKind(),
+				Range:                    ast.NewRangeFromPositioned(firstNestedTypeAliasDeclaration.Identifier),
+			},
+		)
+
+		// NOTE: don't return, so the type aliases are still resolved
+	}
+
+	for _, nestedDeclaration := range nestedTypeAliasDeclarations {
+		checker.typeAliasContainerDeclarations[nestedDeclaration] = containerDeclaration
+		checker.typeAliasDeclarations = append(checker.typeAliasDeclarations, nestedDeclaration)
+	}
+}
+
+// declareTypeAliasVariable declares a type variable for the given type alias declaration
+// in the current scope.
+//
+// If the aliased type is not resolved yet, the variable is pending,
+// and the aliased type gets resolved when the variable is referred to.
+//
+func (checker *Checker) declareTypeAliasVariable(
+	declaration *ast.TypeAliasDeclaration,
+	allowOuterScopeShadowing bool,
+) *Variable {
+
+	ty, resolved := checker.Elaboration.TypeAliasDeclarationTypes[declaration]
+
+	variable, err := checker.typeActivations.DeclareType(typeDeclaration{
+		identifier:               declaration.Identifier,
+		ty:                       ty,
+		declarationKind:          declaration.DeclarationKind(),
+		access:                   declaration.Access,
+		docString:                declaration.DocString,
+		allowOuterScopeShadowing: allowOuterScopeShadowing,
+	})
+	checker.report(err)
+
+	if !resolved {
+		checker.pendingTypeAliasVariables[variable] = declaration
+	}
+
+	return variable
+}
+
+// declareNestedTypeAliasVariables declares type variables for the given nested type aliases
+// in the current scope.
+//
+func (checker *Checker) declareNestedTypeAliasVariables(declarations []*ast.TypeAliasDeclaration) {
+
+	depth := checker.typeActivations.Depth()
+
+	for _, declaration := range declarations {
+
+		// NOTE: Skip type aliases which conflict with other nested declarations
+		// and allow the shadowing of types here:
+		// Conflicts are already reported by `checkNestedIdentifiers`.
+		// This avoids a duplicate error message.
+
+		existingVariable := checker.typeActivations.Find(declaration.Identifier.Identifier)
+		if existingVariable != nil && existingVariable.ActivationDepth == depth {
+			continue
+		}
+
+		checker.declareTypeAliasVariable(declaration, true)
+	}
+}
+
+// resolveTypeAliases resolves the aliased types of all declared type aliases,
+// both top-level and nested, and registers the nested type aliases in their container types.
+//
+// NOTE: This function must be called *after* all interface and composite types are declared,
+// as type aliases may refer to them, and *before* their members are declared,
+// as members may refer to type aliases.
+//
+func (checker *Checker) resolveTypeAliases() {
+
+	for _, declaration := range checker.typeAliasDeclarations {
+		ty := checker.typeAliasType(declaration)
+
+		containerDeclaration, ok := checker.typeAliasContainerDeclarations[declaration]
+		if !ok {
+			continue
+		}
+
+		var typeAliases *StringTypeOrderedMap
+
+		switch containerDeclaration := containerDeclaration.(type) {
+		case *ast.CompositeDeclaration:
+			typeAliases = checker.Elaboration.CompositeDeclarationTypes[containerDeclaration].typeAliases
+		case *ast.InterfaceDeclaration:
+			typeAliases = checker.Elaboration.InterfaceDeclarationTypes[containerDeclaration].typeAliases
+		}
+
+		typeAliases.Set(declaration.Identifier.Identifier, ty)
+	}
+
+	// All type aliases are resolved now, so all pending type alias variables can be completed
+
+	for variable, declaration := range checker.pendingTypeAliasVariables {
+		variable.Type = checker.Elaboration.TypeAliasDeclarationTypes[declaration]
+	}
+
+	checker.pendingTypeAliasVariables = map[*Variable]*ast.TypeAliasDeclaration{}
+}
+
+// typeAliasType returns the aliased type of the given type alias declaration,
+// resolving it if necessary.
+//
+// Type aliases may refer to other type aliases, including ones that are declared later,
+// so the aliased type is resolved on demand. Cyclic type aliases are reported.
+//
+func (checker *Checker) typeAliasType(declaration *ast.TypeAliasDeclaration) Type {
+
+	if ty, ok := checker.Elaboration.TypeAliasDeclarationTypes[declaration]; ok {
+		return ty
+	}
+
+	if checker.resolvingTypeAliases[declaration] {
+		checker.report(
+			&CyclicTypeAliasError{
+				Name:  declaration.Identifier.Identifier,
+				Range: ast.NewRangeFromPositioned(declaration.Identifier),
+			},
+		)
+
+		return InvalidType
+	}
+
+	checker.resolvingTypeAliases[declaration] = true
+	defer delete(checker.resolvingTypeAliases, declaration)
+
+	// The aliased type might be resolved on demand, while checking another declaration,
+	// so resolve it in the scope of the type alias declaration,
+	// instead of the current scope
+
+	checker.typeActivations.EnterGlobal()
+
+	switch containerDeclaration := checker.typeAliasContainerDeclarations[declaration].(type) {
+	case *ast.CompositeDeclaration:
+		checker.declareCompositeNestedTypes(containerDeclaration, ContainerKindComposite, false)
+	case *ast.InterfaceDeclaration:
+		checker.declareInterfaceNestedTypes(containerDeclaration)
+	}
+
+	ty := checker.ConvertType(declaration.TypeAnnotation.Type)
+
+	checker.typeActivations.Leave(declaration.EndPosition)
+
+	checker.Elaboration.TypeAliasDeclarationTypes[declaration] = ty
+
+	return ty
+}
+
+// nestedType returns the type with the given name that is nested in the given container type,
+// or nil if there is no such nested type.
+//
+// Type aliases declared in the container type are also considered.
+//
+func (checker *Checker) nestedType(containerType ContainerType, name string) Type {
+
+	if ty, ok := containerType.GetNestedTypes().Get(name); ok {
+		return ty
+	}
+
+	typeAliasContainerType, ok := containerType.(TypeAliasContainerType)
+	if !ok {
+		return nil
+	}
+
+	typeAliases := typeAliasContainerType.GetTypeAliases()
+	if typeAliases != nil {
+		if ty, ok := typeAliases.Get(name); ok {
+			return ty
+		}
+	}
+
+	// The type alias might be declared in the checked program,
+	// but not be resolved yet
+
+	var members *ast.Members
+
+	switch typeAliasContainerType := typeAliasContainerType.(type) {
+	case *CompositeType:
+		declaration, ok := checker.Elaboration.CompositeTypeDeclarations[typeAliasContainerType]
+		if !ok {
+			return nil
+		}
+		members = declaration.Members
+
+	case *InterfaceType:
+		declaration, ok := checker.Elaboration.InterfaceTypeDeclarations[typeAliasContainerType]
+		if !ok {
+			return nil
+		}
+		members = declaration.Members
+
+	default:
+		return nil
+	}
+
+	for _, declaration := range members.TypeAliases() {
+		if declaration.Identifier.Identifier == name {
+			return checker.typeAliasType(declaration)
+		}
+	}
+
+	return nil
+}
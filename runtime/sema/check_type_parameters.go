@@ -0,0 +1,167 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// typeParameters converts the given type parameter list of a generic function
+// or generic composite declaration to type parameters.
+//
+// Type parameters without an explicit type bound are bounded by `AnyStruct`,
+// i.e. resource type parameters must be declared explicitly, e.g. `T: @AnyResource`.
+//
+func (checker *Checker) typeParameters(typeParameterList *ast.TypeParameterList) []*TypeParameter {
+	if typeParameterList.IsEmpty() {
+		return nil
+	}
+
+	typeParameters := make([]*TypeParameter, len(typeParameterList.TypeParameters))
+
+	for i, typeParameter := range typeParameterList.TypeParameters {
+
+		var typeBound Type = AnyStructType
+
+		if typeParameter.TypeBound != nil {
+			typeBoundAnnotation := checker.ConvertTypeAnnotation(typeParameter.TypeBound)
+			checker.checkTypeAnnotation(typeBoundAnnotation, typeParameter.TypeBound)
+			typeBound = typeBoundAnnotation.Type
+		}
+
+		typeParameters[i] = &TypeParameter{
+			Name:      typeParameter.Identifier.Identifier,
+			TypeBound: typeBound,
+			Declared:  true,
+		}
+	}
+
+	return typeParameters
+}
+
+// declareTypeParameters declares the given type parameters as generic types in the current scope.
+//
+// The type parameters are expected to have been converted from the given type parameter list,
+// using `typeParameters`.
+//
+func (checker *Checker) declareTypeParameters(
+	typeParameters []*TypeParameter,
+	typeParameterList *ast.TypeParameterList,
+) {
+	for i, typeParameter := range typeParameters {
+		identifier := typeParameterList.TypeParameters[i].Identifier
+
+		variable, err := checker.typeActivations.DeclareType(typeDeclaration{
+			identifier: identifier,
+			ty: &GenericType{
+				TypeParameter: typeParameter,
+			},
+			declarationKind:          common.DeclarationKindTypeParameter,
+			access:                   ast.AccessNotSpecified,
+			allowOuterScopeShadowing: true,
+		})
+		checker.report(err)
+
+		if checker.positionInfoEnabled && variable != nil {
+			checker.recordVariableDeclarationOccurrence(
+				identifier.Identifier,
+				variable,
+			)
+		}
+	}
+}
+
+// withTypeParameters declares the given type parameters in a new type scope
+// and calls the given function. The scope is left after the function returns.
+//
+func (checker *Checker) withTypeParameters(
+	typeParameters []*TypeParameter,
+	typeParameterList *ast.TypeParameterList,
+	f func(),
+) {
+	if len(typeParameters) == 0 {
+		f()
+		return
+	}
+
+	checker.typeActivations.Enter()
+	defer checker.typeActivations.Leave(typeParameterList.EndPosition)
+
+	checker.declareTypeParameters(typeParameters, typeParameterList)
+
+	f()
+}
+
+// checkTypeParametersSupported reports an error if the given type parameter list is not empty.
+// Type parameters are only supported for functions and for structure and resource declarations.
+//
+func (checker *Checker) checkTypeParametersSupported(
+	typeParameterList *ast.TypeParameterList,
+	declarationKind common.DeclarationKind,
+) {
+	if typeParameterList.IsEmpty() {
+		return
+	}
+
+	checker.report(
+		&UnsupportedTypeParametersError{
+			DeclarationKind: declarationKind,
+			Range:           typeParameterList.Range,
+		},
+	)
+}
+
+// checkGenericCompositeTypeInstantiated reports an error if the given type is a generic composite type
+// that is used without type arguments, and returns false in that case.
+//
+// Inside of the declaration of the generic composite type, i.e. when its type parameters are in scope,
+// the generic composite type may be used without type arguments, and refers to the instantiation
+// with its own type parameters.
+//
+func (checker *Checker) checkGenericCompositeTypeInstantiated(ty Type, pos ast.HasPosition) bool {
+	compositeType, ok := ty.(*CompositeType)
+	if !ok {
+		return true
+	}
+
+	typeParameters := compositeType.TypeParameters()
+	if len(typeParameters) == 0 {
+		return true
+	}
+
+	variable := checker.typeActivations.Find(typeParameters[0].Name)
+	if variable != nil {
+		if genericType, ok := variable.Type.(*GenericType); ok &&
+			genericType.TypeParameter == typeParameters[0] {
+
+			return true
+		}
+	}
+
+	checker.report(
+		&MissingTypeArgumentsError{
+			Type:               compositeType,
+			TypeParameterCount: len(typeParameters),
+			Range:              ast.NewRangeFromPositioned(pos),
+		},
+	)
+
+	return false
+}
@@ -32,6 +32,7 @@ import (
 
 const ArgumentLabelNotRequired = "_"
 const SelfIdentifier = "self"
+const BaseIdentifier = "base"
 const BeforeIdentifier = "before"
 const ResultIdentifier = "result"
 
@@ -49,6 +50,7 @@ var beforeType = func() *FunctionType {
 	)
 
 	return &FunctionType{
+		Purity: FunctionPurityBuiltinView,
 		TypeParameters: []*TypeParameter{
 			typeParameter,
 		},
@@ -94,6 +96,7 @@ type Checker struct {
 	containerTypes                     map[Type]bool
 	functionActivations                *FunctionActivations
 	inCondition                        bool
+	conditionPurityEnforced            bool
 	positionInfoEnabled                bool
 	Occurrences                        *Occurrences
 	variableOrigins                    map[*Variable]*Origin
@@ -116,6 +119,11 @@ type Checker struct {
 	expectedType                       Type
 	memberAccountAccessHandler         MemberAccountAccessHandlerFunc
 	lintEnabled                        bool
+	typeAliasDeclarations              []*ast.TypeAliasDeclaration
+	typeAliasContainerDeclarations     map[*ast.TypeAliasDeclaration]ast.Declaration
+	resolvingTypeAliases               map[*ast.TypeAliasDeclaration]bool
+	pendingTypeAliasVariables          map[*Variable]*ast.TypeAliasDeclaration
+	memoryGauge                        common.MemoryGauge
 }
 
 type Option func(*Checker) error
@@ -246,6 +254,30 @@ func WithLintingEnabled(enabled bool) Option {
 	}
 }
 
+// WithConditionPurityEnforced returns a checker option which enables/disables
+// the enforcement of purity in pre- and post-conditions.
+// When enabled, conditions may only perform view operations,
+// just like the body of a view function.
+//
+func WithConditionPurityEnforced(enforced bool) Option {
+	return func(checker *Checker) error {
+		checker.conditionPurityEnforced = enforced
+		return nil
+	}
+}
+
+// WithMemoryGauge returns a checker option which sets
+// the given gauge as the gauge for metering the memory of types and variables.
+//
+func WithMemoryGauge(memoryGauge common.MemoryGauge) Option {
+	return func(checker *Checker) error {
+		checker.memoryGauge = memoryGauge
+		checker.valueActivations.memoryGauge = memoryGauge
+		checker.typeActivations.memoryGauge = memoryGauge
+		return nil
+	}
+}
+
 func NewChecker(program *ast.Program, location common.Location, options ...Option) (*Checker, error) {
 
 	if location == nil {
@@ -269,6 +301,10 @@ func NewChecker(program *ast.Program, location common.Location, options ...Optio
 		functionActivations: functionActivations,
 		containerTypes:      map[Type]bool{},
 		Elaboration:         NewElaboration(),
+
+		typeAliasContainerDeclarations: map[*ast.TypeAliasDeclaration]ast.Declaration{},
+		resolvingTypeAliases:           map[*ast.TypeAliasDeclaration]bool{},
+		pendingTypeAliasVariables:      map[*Variable]*ast.TypeAliasDeclaration{},
 	}
 
 	checker.beforeExtractor = NewBeforeExtractor(checker.report)
@@ -299,6 +335,7 @@ func (checker *Checker) SubChecker(program *ast.Program, location common.Locatio
 		WithCheckHandler(checker.checkHandler),
 		WithImportHandler(checker.importHandler),
 		WithLocationHandler(checker.locationHandler),
+		WithMemoryGauge(checker.memoryGauge),
 	)
 }
 
@@ -476,6 +513,16 @@ func (checker *Checker) VisitProgram(program *ast.Program) ast.Repr {
 		VisitThisAndNested(compositeType, registerInElaboration)
 	}
 
+	// Declare and resolve type aliases.
+	// NOTE: *after* interface and composite types are declared, as type aliases may refer to them,
+	// and *before* their members are declared, as members may refer to type aliases
+
+	for _, declaration := range program.TypeAliasDeclarations() {
+		checker.declareTypeAlias(declaration)
+	}
+
+	checker.resolveTypeAliases()
+
 	// Declare interfaces' and composites' members
 
 	for _, declaration := range program.InterfaceDeclarations() {
@@ -561,7 +608,7 @@ func (checker *Checker) checkTopLevelDeclarationValidity(declarations []ast.Decl
 }
 
 func (checker *Checker) declareGlobalFunctionDeclaration(declaration *ast.FunctionDeclaration) {
-	functionType := checker.functionType(declaration.ParameterList, declaration.ReturnTypeAnnotation)
+	functionType := checker.functionDeclarationType(declaration)
 	checker.Elaboration.FunctionDeclarationFunctionTypes[declaration] = functionType
 	checker.declareFunctionDeclaration(declaration, functionType)
 }
@@ -903,7 +950,11 @@ func (checker *Checker) findAndCheckValueVariable(identifierExpression *ast.Iden
 func (checker *Checker) ConvertType(t ast.Type) Type {
 	switch t := t.(type) {
 	case *ast.NominalType:
-		return checker.convertNominalType(t)
+		ty := checker.convertNominalType(t)
+		if !checker.checkGenericCompositeTypeInstantiated(ty, t) {
+			return InvalidType
+		}
+		return ty
 
 	case *ast.VariableSizedType:
 		return checker.convertVariableSizedType(t)
@@ -1149,6 +1200,8 @@ func (checker *Checker) convertRestrictedType(t *ast.RestrictedType) Type {
 		restrictions = append(restrictions, restrictionInterfaceType)
 	}
 
+	common.UseMemory(checker.memoryGauge, common.RestrictedSemaTypeMemoryUsage)
+
 	restrictedType = CheckRestrictedType(
 		restrictedType,
 		restrictions,
@@ -1166,6 +1219,8 @@ func (checker *Checker) convertRestrictedType(t *ast.RestrictedType) Type {
 func (checker *Checker) convertReferenceType(t *ast.ReferenceType) Type {
 	ty := checker.ConvertType(t.Type)
 
+	common.UseMemory(checker.memoryGauge, common.ReferenceSemaTypeMemoryUsage)
+
 	return &ReferenceType{
 		Authorized: t.Authorized,
 		Type:       ty,
@@ -1185,6 +1240,8 @@ func (checker *Checker) convertDictionaryType(t *ast.DictionaryType) Type {
 		)
 	}
 
+	common.UseMemory(checker.memoryGauge, common.DictionarySemaTypeMemoryUsage)
+
 	return &DictionaryType{
 		KeyType:   keyType,
 		ValueType: valueType,
@@ -1193,6 +1250,9 @@ func (checker *Checker) convertDictionaryType(t *ast.DictionaryType) Type {
 
 func (checker *Checker) convertOptionalType(t *ast.OptionalType) Type {
 	ty := checker.ConvertType(t.Type)
+
+	common.UseMemory(checker.memoryGauge, common.OptionalSemaTypeMemoryUsage)
+
 	return &OptionalType{
 		Type: ty,
 	}
@@ -1216,7 +1276,10 @@ func (checker *Checker) convertFunctionType(t *ast.FunctionType) Type {
 
 	returnTypeAnnotation := checker.ConvertTypeAnnotation(t.ReturnTypeAnnotation)
 
+	common.UseMemory(checker.memoryGauge, common.FunctionSemaTypeMemoryUsage)
+
 	return &FunctionType{
+		Purity:               PurityFromAnnotation(t.PurityAnnotation),
 		Parameters:           parameters,
 		ReturnTypeAnnotation: returnTypeAnnotation,
 	}
@@ -1262,6 +1325,8 @@ func (checker *Checker) convertConstantSizedType(t *ast.ConstantSizedType) Type
 		)
 	}
 
+	common.UseMemory(checker.memoryGauge, common.ConstantSizedSemaTypeMemoryUsage)
+
 	return &ConstantSizedType{
 		Type: elementType,
 		Size: finalSize,
@@ -1270,6 +1335,9 @@ func (checker *Checker) convertConstantSizedType(t *ast.ConstantSizedType) Type
 
 func (checker *Checker) convertVariableSizedType(t *ast.VariableSizedType) Type {
 	elementType := checker.ConvertType(t.Type)
+
+	common.UseMemory(checker.memoryGauge, common.VariableSizedSemaTypeMemoryUsage)
+
 	return &VariableSizedType{
 		Type: elementType,
 	}
@@ -1308,11 +1376,17 @@ func (checker *Checker) convertNominalType(t *ast.NominalType) Type {
 
 	ty := variable.Type
 
+	// The variable might be declared for a type alias that is not resolved yet
+
+	if declaration, ok := checker.pendingTypeAliasVariables[variable]; ok {
+		ty = checker.typeAliasType(declaration)
+	}
+
 	var resolvedIdentifiers []ast.Identifier
 
 	for _, identifier := range t.NestedIdentifiers {
 		if containerType, ok := ty.(ContainerType); ok && containerType.IsContainerType() {
-			ty, _ = containerType.GetNestedTypes().Get(identifier.Identifier)
+			ty = checker.nestedType(containerType, identifier.Identifier)
 		} else {
 			if !ty.IsInvalidType() {
 				checker.report(
@@ -1362,7 +1436,19 @@ func (checker *Checker) ConvertTypeAnnotation(typeAnnotation *ast.TypeAnnotation
 	}
 }
 
+// PurityFromAnnotation returns the purity of a function
+// with the given purity annotation.
+// Functions without a purity annotation are impure.
+//
+func PurityFromAnnotation(purity ast.FunctionPurity) FunctionPurity {
+	if purity == ast.FunctionPurityView {
+		return FunctionPurityView
+	}
+	return FunctionPurityImpure
+}
+
 func (checker *Checker) functionType(
+	purity ast.FunctionPurity,
 	parameterList *ast.ParameterList,
 	returnTypeAnnotation *ast.TypeAnnotation,
 ) *FunctionType {
@@ -1372,11 +1458,38 @@ func (checker *Checker) functionType(
 		checker.ConvertTypeAnnotation(returnTypeAnnotation)
 
 	return &FunctionType{
+		Purity:               PurityFromAnnotation(purity),
 		Parameters:           convertedParameters,
 		ReturnTypeAnnotation: convertedReturnTypeAnnotation,
 	}
 }
 
+// functionDeclarationType returns the function type for the given function declaration.
+// The type parameters of a generic function are in scope
+// for the parameter types and the return type.
+//
+func (checker *Checker) functionDeclarationType(declaration *ast.FunctionDeclaration) *FunctionType {
+	typeParameters := checker.typeParameters(declaration.TypeParameterList)
+
+	var functionType *FunctionType
+
+	checker.withTypeParameters(
+		typeParameters,
+		declaration.TypeParameterList,
+		func() {
+			functionType = checker.functionType(
+				declaration.Purity,
+				declaration.ParameterList,
+				declaration.ReturnTypeAnnotation,
+			)
+		},
+	)
+
+	functionType.TypeParameters = typeParameters
+
+	return functionType
+}
+
 func (checker *Checker) parameters(parameterList *ast.ParameterList) []*Parameter {
 
 	parameters := make([]*Parameter, len(parameterList.Parameters))
@@ -2281,7 +2394,15 @@ func (checker *Checker) effectiveCompositeMemberAccess(access ast.Access) ast.Ac
 
 func (checker *Checker) convertInstantiationType(t *ast.InstantiationType) Type {
 
-	ty := checker.ConvertType(t.Type)
+	// NOTE: a generic composite type is instantiated here,
+	// so it must not be reported as missing type arguments
+
+	var ty Type
+	if nominalType, ok := t.Type.(*ast.NominalType); ok {
+		ty = checker.convertNominalType(nominalType)
+	} else {
+		ty = checker.ConvertType(t.Type)
+	}
 
 	// Always convert (check) the type arguments,
 	// even if the instantiated type
@@ -2296,7 +2417,7 @@ func (checker *Checker) convertInstantiationType(t *ast.InstantiationType) Type
 	}
 
 	parameterizedType, ok := ty.(ParameterizedType)
-	if !ok {
+	if !ok || len(parameterizedType.TypeParameters()) == 0 {
 
 		// The type is not parameterized,
 		// report an error for all type arguments
@@ -2354,7 +2475,13 @@ func (checker *Checker) convertInstantiationType(t *ast.InstantiationType) Type
 			},
 		)
 
-		// Just return the converted instantiated type as-is
+		// A generic composite type cannot be used without all of its type arguments,
+		// so return the invalid type to avoid follow-on errors.
+		// Otherwise, just return the converted instantiated type as-is
+
+		if _, ok := ty.(*CompositeType); ok {
+			return InvalidType
+		}
 
 		return ty
 	}
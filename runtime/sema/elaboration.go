@@ -66,6 +66,7 @@ type Elaboration struct {
 	TransactionDeclarationTypes         map[*ast.TransactionDeclaration]*TransactionType
 	SwapStatementLeftTypes              map[*ast.SwapStatement]Type
 	SwapStatementRightTypes             map[*ast.SwapStatement]Type
+	ForStatementValueTypes              map[*ast.ForStatement]Type
 	// IsNestedResourceMoveExpression indicates if the access the index or member expression
 	// is implicitly moving a resource out of the container, e.g. in a shift or swap statement.
 	IsNestedResourceMoveExpression      map[ast.Expression]struct{}
@@ -73,6 +74,8 @@ type Elaboration struct {
 	InterfaceNestedDeclarations         map[*ast.InterfaceDeclaration]map[string]ast.Declaration
 	PostConditionsRewrite               map[*ast.Conditions]PostConditionsRewrite
 	EmitStatementEventTypes             map[*ast.EmitStatement]*CompositeType
+	AttachExpressionTypes               map[*ast.AttachExpression]*CompositeType
+	AttachmentAccessTypes               map[*ast.IndexExpression]*CompositeType
 	CompositeTypes                      map[TypeID]*CompositeType
 	InterfaceTypes                      map[TypeID]*InterfaceType
 	IdentifierInInvocationTypes         map[*ast.IdentifierExpression]Type
@@ -84,6 +87,7 @@ type Elaboration struct {
 	EffectivePredeclaredTypes           map[string]TypeDeclaration
 	isChecking                          bool
 	ReferenceExpressionBorrowTypes      map[*ast.ReferenceExpression]Type
+	TypeAliasDeclarationTypes           map[*ast.TypeAliasDeclaration]Type
 }
 
 func NewElaboration() *Elaboration {
@@ -123,11 +127,14 @@ func NewElaboration() *Elaboration {
 		TransactionDeclarationTypes:         map[*ast.TransactionDeclaration]*TransactionType{},
 		SwapStatementLeftTypes:              map[*ast.SwapStatement]Type{},
 		SwapStatementRightTypes:             map[*ast.SwapStatement]Type{},
+		ForStatementValueTypes:              map[*ast.ForStatement]Type{},
 		IsNestedResourceMoveExpression:      map[ast.Expression]struct{}{},
 		CompositeNestedDeclarations:         map[*ast.CompositeDeclaration]map[string]ast.Declaration{},
 		InterfaceNestedDeclarations:         map[*ast.InterfaceDeclaration]map[string]ast.Declaration{},
 		PostConditionsRewrite:               map[*ast.Conditions]PostConditionsRewrite{},
 		EmitStatementEventTypes:             map[*ast.EmitStatement]*CompositeType{},
+		AttachExpressionTypes:               map[*ast.AttachExpression]*CompositeType{},
+		AttachmentAccessTypes:               map[*ast.IndexExpression]*CompositeType{},
 		CompositeTypes:                      map[TypeID]*CompositeType{},
 		InterfaceTypes:                      map[TypeID]*InterfaceType{},
 		IdentifierInInvocationTypes:         map[*ast.IdentifierExpression]Type{},
@@ -137,6 +144,7 @@ func NewElaboration() *Elaboration {
 		EffectivePredeclaredValues:          map[string]ValueDeclaration{},
 		EffectivePredeclaredTypes:           map[string]TypeDeclaration{},
 		ReferenceExpressionBorrowTypes:      map[*ast.ReferenceExpression]Type{},
+		TypeAliasDeclarationTypes:           map[*ast.TypeAliasDeclaration]Type{},
 	}
 }
 
@@ -817,6 +817,22 @@ func (e *FunctionExpressionInConditionError) Error() string {
 
 func (*FunctionExpressionInConditionError) isSemanticError() {}
 
+// PurityError
+
+type PurityError struct {
+	Operation string
+	ast.Range
+}
+
+func (e *PurityError) Error() string {
+	return fmt.Sprintf(
+		"cannot perform impure operation in view context: %s",
+		e.Operation,
+	)
+}
+
+func (*PurityError) isSemanticError() {}
+
 // MissingReturnValueError
 
 type MissingReturnValueError struct {
@@ -866,6 +882,36 @@ func (e *InvalidImplementationError) EndPosition() ast.Position {
 	return e.Pos
 }
 
+// DefaultFunctionConflictError
+
+type DefaultFunctionConflictError struct {
+	CompositeType       *CompositeType
+	FunctionName        string
+	FirstInterfaceType  *InterfaceType
+	SecondInterfaceType *InterfaceType
+	ast.Range
+}
+
+func (e *DefaultFunctionConflictError) Error() string {
+	return fmt.Sprintf(
+		"`%s` conforms to `%s` and `%s`, which both provide a default implementation for function `%s`",
+		e.CompositeType.QualifiedString(),
+		e.FirstInterfaceType.QualifiedString(),
+		e.SecondInterfaceType.QualifiedString(),
+		e.FunctionName,
+	)
+}
+
+func (e *DefaultFunctionConflictError) SecondaryError() string {
+	return fmt.Sprintf(
+		"`%s` must implement function `%s`",
+		e.CompositeType.QualifiedString(),
+		e.FunctionName,
+	)
+}
+
+func (*DefaultFunctionConflictError) isSemanticError() {}
+
 // InvalidConformanceError
 
 type InvalidConformanceError struct {
@@ -1784,6 +1830,70 @@ func (e *EmitNonEventError) Error() string {
 
 func (*EmitNonEventError) isSemanticError() {}
 
+// InvalidAttachmentUsageError
+
+type InvalidAttachmentUsageError struct {
+	ast.Range
+}
+
+func (e *InvalidAttachmentUsageError) Error() string {
+	return "attachments can only be created in an `attach` expression"
+}
+
+func (*InvalidAttachmentUsageError) isSemanticError() {}
+
+// AttachNonAttachmentError
+
+type AttachNonAttachmentError struct {
+	Type Type
+	ast.Range
+}
+
+func (e *AttachNonAttachmentError) Error() string {
+	return fmt.Sprintf(
+		"cannot attach non-attachment type: `%s`",
+		e.Type.QualifiedString(),
+	)
+}
+
+func (*AttachNonAttachmentError) isSemanticError() {}
+
+// InvalidAttachmentAccessError
+
+type InvalidAttachmentAccessError struct {
+	Type Type
+	ast.Range
+}
+
+func (e *InvalidAttachmentAccessError) Error() string {
+	return fmt.Sprintf(
+		"cannot access attachment: expected attachment type, got `%s`",
+		e.Type.QualifiedString(),
+	)
+}
+
+func (*InvalidAttachmentAccessError) isSemanticError() {}
+
+// InvalidAttachmentBaseTypeError
+
+type InvalidAttachmentBaseTypeError struct {
+	Type Type
+	ast.Range
+}
+
+func (e *InvalidAttachmentBaseTypeError) Error() string {
+	return fmt.Sprintf(
+		"invalid attachment base type: `%s`",
+		e.Type.QualifiedString(),
+	)
+}
+
+func (e *InvalidAttachmentBaseTypeError) SecondaryError() string {
+	return "attachments can only be declared for structures and resources"
+}
+
+func (*InvalidAttachmentBaseTypeError) isSemanticError() {}
+
 // EmitImportedEventError
 
 type EmitImportedEventError struct {
@@ -2775,6 +2885,46 @@ func (e *TypeParameterTypeInferenceError) Error() string {
 
 func (e *TypeParameterTypeInferenceError) isSemanticError() {}
 
+// UnsupportedTypeParametersError
+
+type UnsupportedTypeParametersError struct {
+	DeclarationKind common.DeclarationKind
+	ast.Range
+}
+
+func (e *UnsupportedTypeParametersError) Error() string {
+	return fmt.Sprintf(
+		"%s cannot have type parameters",
+		e.DeclarationKind.Name(),
+	)
+}
+
+func (*UnsupportedTypeParametersError) isSemanticError() {}
+
+// MissingTypeArgumentsError
+
+type MissingTypeArgumentsError struct {
+	Type               Type
+	TypeParameterCount int
+	ast.Range
+}
+
+func (e *MissingTypeArgumentsError) Error() string {
+	return fmt.Sprintf(
+		"missing type arguments for generic type `%s`",
+		e.Type.QualifiedString(),
+	)
+}
+
+func (e *MissingTypeArgumentsError) SecondaryError() string {
+	return fmt.Sprintf(
+		"expected %d, got 0",
+		e.TypeParameterCount,
+	)
+}
+
+func (*MissingTypeArgumentsError) isSemanticError() {}
+
 // InvalidConstantSizedTypeBaseError
 
 type InvalidConstantSizedTypeBaseError struct {
@@ -2917,6 +3067,19 @@ func (e *CyclicImportsError) Error() string {
 
 func (*CyclicImportsError) isSemanticError() {}
 
+// CyclicTypeAliasError
+
+type CyclicTypeAliasError struct {
+	Name string
+	ast.Range
+}
+
+func (e *CyclicTypeAliasError) Error() string {
+	return fmt.Sprintf("cyclic type alias `%s`", e.Name)
+}
+
+func (*CyclicTypeAliasError) isSemanticError() {}
+
 // SwitchDefaultPositionError
 
 type SwitchDefaultPositionError struct {
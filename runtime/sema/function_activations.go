@@ -20,6 +20,7 @@ package sema
 
 type FunctionActivation struct {
 	ReturnType           Type
+	Purity               FunctionPurity
 	Loops                int
 	Switches             int
 	ValueActivationDepth int
@@ -35,6 +36,10 @@ func (a FunctionActivation) InSwitch() bool {
 	return a.Switches > 0
 }
 
+func (a FunctionActivation) IsView() bool {
+	return a.Purity.IsView()
+}
+
 type FunctionActivations struct {
 	activations []*FunctionActivation
 }
@@ -53,6 +58,7 @@ func (a *FunctionActivations) IsLocal() bool {
 func (a *FunctionActivations) EnterFunction(functionType *FunctionType, valueActivationDepth int) *FunctionActivation {
 	activation := &FunctionActivation{
 		ReturnType:           functionType.ReturnTypeAnnotation.Type,
+		Purity:               functionType.Purity,
 		ValueActivationDepth: valueActivationDepth,
 		ReturnInfo:           &ReturnInfo{},
 	}
@@ -49,6 +49,7 @@ var MetaType = &SimpleType{
 }
 
 var MetaTypeIsSubtypeFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
 	Parameters: []*Parameter{
 		{
 			Label:          "of",
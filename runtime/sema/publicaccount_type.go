@@ -32,6 +32,7 @@ const PublicAccountGetCapabilityField = "getCapability"
 const PublicAccountGetTargetLinkField = "getLinkTarget"
 const PublicAccountKeysField = "keys"
 const PublicAccountContractsField = "contracts"
+const PublicAccountForEachPublicField = "forEachPublic"
 
 // PublicAccountType represents the publicly accessible portion of an account.
 //
@@ -106,6 +107,12 @@ var PublicAccountType = func() *CompositeType {
 			PublicAccountContractsType,
 			accountTypeContractsFieldDocString,
 		),
+		NewPublicFunctionMember(
+			publicAccountType,
+			PublicAccountForEachPublicField,
+			AccountForEachPublicFunctionType,
+			accountForEachPublicFunctionDocString,
+		),
 	}
 
 	publicAccountType.Members = GetMembersAsMap(members)
@@ -152,6 +159,7 @@ var PublicAccountTypeGetCapabilityFunctionType = func() *FunctionType {
 	}
 
 	return &FunctionType{
+		Purity: FunctionPurityBuiltinView,
 		TypeParameters: []*TypeParameter{
 			typeParameter,
 		},
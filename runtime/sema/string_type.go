@@ -28,6 +28,11 @@ const StringTypeEncodeHexFunctionDocString = `
 Returns a hexadecimal string for the given byte array
 `
 
+const StringTypeJoinFunctionName = "join"
+const StringTypeJoinFunctionDocString = `
+Returns a string which contains the given strings, separated by the given separator
+`
+
 // StringType represents the string type
 //
 var StringType = &SimpleType{
@@ -120,11 +125,78 @@ func init() {
 					)
 				},
 			},
+			"toUpper": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeToUpperFunctionType,
+						stringTypeToUpperFunctionDocString,
+					)
+				},
+			},
+			"trim": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeTrimFunctionType,
+						stringTypeTrimFunctionDocString,
+					)
+				},
+			},
+			"split": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeSplitFunctionType,
+						stringTypeSplitFunctionDocString,
+					)
+				},
+			},
+			"replaceAll": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeReplaceAllFunctionType,
+						stringTypeReplaceAllFunctionDocString,
+					)
+				},
+			},
+			"contains": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeContainsFunctionType,
+						stringTypeContainsFunctionDocString,
+					)
+				},
+			},
+			"index": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeIndexFunctionType,
+						stringTypeIndexFunctionDocString,
+					)
+				},
+			},
 		}
 	}
 }
 
 var StringTypeConcatFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
 	Parameters: []*Parameter{
 		{
 			Label:          ArgumentLabelNotRequired,
@@ -142,6 +214,7 @@ Returns a new string which contains the given string concatenated to the end of
 `
 
 var StringTypeSliceFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
 	Parameters: []*Parameter{
 		{
 			Identifier:     "from",
@@ -176,6 +249,7 @@ var ByteArrayArrayType = &VariableSizedType{
 }
 
 var StringTypeDecodeHexFunctionType = &FunctionType{
+	Purity:               FunctionPurityBuiltinView,
 	ReturnTypeAnnotation: NewTypeAnnotation(ByteArrayType),
 }
 
@@ -195,9 +269,132 @@ The byte array of the UTF-8 encoding
 `
 
 var StringTypeToLowerFunctionType = &FunctionType{
+	Purity:               FunctionPurityBuiltinView,
 	ReturnTypeAnnotation: NewTypeAnnotation(StringType),
 }
 
 const stringTypeToLowerFunctionDocString = `
 Returns the string with upper case letters replaced with lowercase
 `
+
+var StringTypeToUpperFunctionType = &FunctionType{
+	Purity:               FunctionPurityBuiltinView,
+	ReturnTypeAnnotation: NewTypeAnnotation(StringType),
+}
+
+const stringTypeToUpperFunctionDocString = `
+Returns the string with lowercase letters replaced with uppercase
+`
+
+var StringTypeTrimFunctionType = &FunctionType{
+	Purity:               FunctionPurityBuiltinView,
+	ReturnTypeAnnotation: NewTypeAnnotation(StringType),
+}
+
+const stringTypeTrimFunctionDocString = `
+Returns the string without leading and trailing whitespace characters
+`
+
+// StringArrayType represents the type [String]
+var StringArrayType = &VariableSizedType{
+	Type: StringType,
+}
+
+var StringTypeSplitFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
+	Parameters: []*Parameter{
+		{
+			Identifier:     "separator",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		StringArrayType,
+	),
+}
+
+const stringTypeSplitFunctionDocString = `
+Returns a variable-sized array of strings, which contains the substrings of the string that are separated by the given separator.
+
+If the separator is empty, the string is split into its characters.
+The separator only matches whole characters.
+It does not modify the original string
+`
+
+var StringTypeReplaceAllFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
+	Parameters: []*Parameter{
+		{
+			Identifier:     "of",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+		{
+			Identifier:     "with",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		StringType,
+	),
+}
+
+const stringTypeReplaceAllFunctionDocString = `
+Returns a new string in which all occurrences of the string ` + "`of`" + ` are replaced with the string ` + "`with`" + `.
+
+If ` + "`of`" + ` is empty, ` + "`with`" + ` is inserted before each character and at the end of the string.
+Only occurrences of whole characters are replaced.
+It does not modify the original string
+`
+
+var StringTypeContainsFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "other",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		BoolType,
+	),
+}
+
+const stringTypeContainsFunctionDocString = `
+Returns true if the given string is contained in the string, i.e. it occurs as a sequence of whole characters
+`
+
+var StringTypeIndexFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
+	Parameters: []*Parameter{
+		{
+			Identifier:     "of",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&OptionalType{Type: IntType},
+	),
+}
+
+const stringTypeIndexFunctionDocString = `
+Returns the character index of the first occurrence of the given string in the string, nil if there is no occurrence
+`
+
+var StringTypeJoinFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "strings",
+			TypeAnnotation: NewTypeAnnotation(StringArrayType),
+		},
+		{
+			Identifier:     "separator",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		StringType,
+	),
+}
@@ -203,6 +203,13 @@ type ContainerType interface {
 	GetNestedTypes() *StringTypeOrderedMap
 }
 
+// TypeAliasContainerType is a container type which may declare type aliases
+//
+type TypeAliasContainerType interface {
+	ContainerType
+	GetTypeAliases() *StringTypeOrderedMap
+}
+
 func VisitThisAndNested(t Type, visit func(ty Type)) {
 	visit(t)
 
@@ -310,6 +317,7 @@ func NewTypeAnnotation(ty Type) *TypeAnnotation {
 const IsInstanceFunctionName = "isInstance"
 
 var IsInstanceFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
 	Parameters: []*Parameter{
 		{
 			Label:      ArgumentLabelNotRequired,
@@ -333,6 +341,7 @@ Returns true if the object conforms to the given type at runtime
 const GetTypeFunctionName = "getType"
 
 var GetTypeFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
 	ReturnTypeAnnotation: NewTypeAnnotation(
 		MetaType,
 	),
@@ -347,6 +356,7 @@ Returns the type of the value
 const ToStringFunctionName = "toString"
 
 var ToStringFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
 	ReturnTypeAnnotation: NewTypeAnnotation(
 		StringType,
 	),
@@ -361,6 +371,7 @@ A textual representation of this object
 const ToBigEndianBytesFunctionName = "toBigEndianBytes"
 
 var toBigEndianBytesFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
 	ReturnTypeAnnotation: NewTypeAnnotation(
 		ByteArrayType,
 	),
@@ -663,24 +674,31 @@ func (t *GenericType) Equal(other Type) bool {
 	return t.TypeParameter == otherType.TypeParameter
 }
 
-func (*GenericType) IsResourceType() bool {
-	return false
+// IsResourceType returns true if the type parameter's type bound is a resource type,
+// i.e. if the type parameter can only be instantiated with resource types.
+//
+func (t *GenericType) IsResourceType() bool {
+	typeBound := t.TypeParameter.TypeBound
+	return typeBound != nil && typeBound.IsResourceType()
 }
 
 func (*GenericType) IsInvalidType() bool {
 	return false
 }
 
-func (*GenericType) IsStorable(_ map[*Member]bool) bool {
-	return false
+func (t *GenericType) IsStorable(results map[*Member]bool) bool {
+	typeBound := t.TypeParameter.TypeBound
+	return typeBound != nil && typeBound.IsStorable(results)
 }
 
-func (*GenericType) IsExternallyReturnable(_ map[*Member]bool) bool {
-	return false
+func (t *GenericType) IsExternallyReturnable(results map[*Member]bool) bool {
+	typeBound := t.TypeParameter.TypeBound
+	return typeBound != nil && typeBound.IsExternallyReturnable(results)
 }
 
-func (t *GenericType) IsImportable(_ map[*Member]bool) bool {
-	return false
+func (t *GenericType) IsImportable(results map[*Member]bool) bool {
+	typeBound := t.TypeParameter.TypeBound
+	return typeBound != nil && typeBound.IsImportable(results)
 }
 
 func (*GenericType) IsEquatable() bool {
@@ -744,7 +762,15 @@ func (t *GenericType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type {
 	return ty
 }
 
+// GetMembers returns the members of the type parameter's type bound, if any.
+// The type parameter can only be instantiated with subtypes of the type bound,
+// so all members of the type bound are available.
+//
 func (t *GenericType) GetMembers() map[string]MemberResolver {
+	typeBound := t.TypeParameter.TypeBound
+	if typeBound != nil {
+		return typeBound.GetMembers()
+	}
 	return withBuiltinMembers(t, nil)
 }
 
@@ -796,6 +822,7 @@ self / other, saturating at the numeric bounds instead of overflowing.
 func addSaturatingArithmeticFunctions(t SaturatingArithmeticType, members map[string]MemberResolver) {
 
 	arithmeticFunctionType := &FunctionType{
+		Purity: FunctionPurityBuiltinView,
 		Parameters: []*Parameter{
 			{
 				Label:          ArgumentLabelNotRequired,
@@ -1602,6 +1629,44 @@ It does not modify the original array.
 If either of the parameters are out of the bounds of the array, or the indices are invalid (` + "`from > upTo`" + `), then the function will fail.
 `
 
+const arrayTypeMapFunctionDocString = `
+Returns a new array which contains the results of calling the given transform function with each element of the array.
+
+The resulting array has the same number of elements as the original array.
+It does not modify the original array.
+Available if the array element type is not resource-kinded.
+`
+
+const arrayTypeFilterFunctionDocString = `
+Returns a new variable-sized array which contains only the elements of the array for which the given predicate function returns true.
+
+It does not modify the original array.
+Available if the array element type is not resource-kinded.
+`
+
+const arrayTypeReduceFunctionDocString = `
+Returns the result of combining the elements of the array using the given function.
+
+The function is called with the partial result, which initially is the given initial result, and each element of the array, in order.
+The result of the last call is returned. If the array is empty, the initial result is returned.
+Available if the array element type is not resource-kinded.
+`
+
+const arrayTypeReverseFunctionDocString = `
+Returns a new array which contains the elements of the array in reverse order.
+
+It does not modify the original array.
+Available if the array element type is not resource-kinded.
+`
+
+const arrayTypeSortFunctionDocString = `
+Sorts the array in-place, using the given function to compare elements.
+
+The function must return true if the first argument should be ordered before the second argument.
+The sort is stable, i.e. elements which are equal keep their original order.
+Available if the array element type is not resource-kinded.
+`
+
 func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 
 	members := map[string]MemberResolver{
@@ -1692,6 +1757,86 @@ func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 				)
 			},
 		},
+		"map": {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				reportInvalidResourceArrayMember(arrayType, identifier, targetRange, report)
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArrayMapFunctionType(arrayType),
+					arrayTypeMapFunctionDocString,
+				)
+			},
+		},
+		"filter": {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				elementType := arrayType.ElementType(false)
+
+				reportInvalidResourceArrayMember(arrayType, identifier, targetRange, report)
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArrayFilterFunctionType(elementType),
+					arrayTypeFilterFunctionDocString,
+				)
+			},
+		},
+		"reduce": {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				elementType := arrayType.ElementType(false)
+
+				reportInvalidResourceArrayMember(arrayType, identifier, targetRange, report)
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArrayReduceFunctionType(elementType),
+					arrayTypeReduceFunctionDocString,
+				)
+			},
+		},
+		"reverse": {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				reportInvalidResourceArrayMember(arrayType, identifier, targetRange, report)
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArrayReverseFunctionType(arrayType),
+					arrayTypeReverseFunctionDocString,
+				)
+			},
+		},
+		"sort": {
+			Kind:     common.DeclarationKindFunction,
+			Mutating: true,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				elementType := arrayType.ElementType(false)
+
+				// Sorting an array of resources would require the comparator function
+				// to be able to access the resources without moving them
+
+				reportInvalidResourceArrayMember(arrayType, identifier, targetRange, report)
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArraySortFunctionType(elementType),
+					arrayTypeSortFunctionDocString,
+				)
+			},
+		},
 	}
 
 	// TODO: maybe still return members but report a helpful error?
@@ -1859,6 +2004,28 @@ func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 	return withBuiltinMembers(arrayType, members)
 }
 
+// reportInvalidResourceArrayMember reports an error
+// if the element type of the given array type is resource-kinded
+//
+func reportInvalidResourceArrayMember(
+	arrayType ArrayType,
+	identifier string,
+	targetRange ast.Range,
+	report func(error),
+) {
+	if !arrayType.ElementType(false).IsResourceType() {
+		return
+	}
+
+	report(
+		&InvalidResourceArrayMemberError{
+			Name:            identifier,
+			DeclarationKind: common.DeclarationKindFunction,
+			Range:           targetRange,
+		},
+	)
+}
+
 func ArrayRemoveLastFunctionType(elementType Type) *FunctionType {
 	return &FunctionType{
 		ReturnTypeAnnotation: NewTypeAnnotation(
@@ -1911,6 +2078,7 @@ func ArrayInsertFunctionType(elementType Type) *FunctionType {
 func ArrayConcatFunctionType(arrayType Type) *FunctionType {
 	typeAnnotation := NewTypeAnnotation(arrayType)
 	return &FunctionType{
+		Purity: FunctionPurityBuiltinView,
 		Parameters: []*Parameter{
 			{
 				Label:          ArgumentLabelNotRequired,
@@ -1924,6 +2092,7 @@ func ArrayConcatFunctionType(arrayType Type) *FunctionType {
 
 func ArrayFirstIndexFunctionType(elementType Type) *FunctionType {
 	return &FunctionType{
+		Purity: FunctionPurityBuiltinView,
 		Parameters: []*Parameter{
 			{
 				Identifier:     "of",
@@ -1937,6 +2106,7 @@ func ArrayFirstIndexFunctionType(elementType Type) *FunctionType {
 }
 func ArrayContainsFunctionType(elementType Type) *FunctionType {
 	return &FunctionType{
+		Purity: FunctionPurityBuiltinView,
 		Parameters: []*Parameter{
 			{
 				Label:          ArgumentLabelNotRequired,
@@ -1980,6 +2150,7 @@ func ArrayAppendFunctionType(elementType Type) *FunctionType {
 
 func ArraySliceFunctionType(elementType Type) *FunctionType {
 	return &FunctionType{
+		Purity: FunctionPurityBuiltinView,
 		Parameters: []*Parameter{
 			{
 				Identifier:     "from",
@@ -1996,6 +2167,160 @@ func ArraySliceFunctionType(elementType Type) *FunctionType {
 	}
 }
 
+func ArrayMapFunctionType(arrayType ArrayType) *FunctionType {
+	typeParameter := &TypeParameter{
+		Name: "T",
+	}
+
+	resultElementType := &GenericType{
+		TypeParameter: typeParameter,
+	}
+
+	var resultType Type
+	switch arrayType := arrayType.(type) {
+	case *ConstantSizedType:
+		resultType = &ConstantSizedType{
+			Type: resultElementType,
+			Size: arrayType.Size,
+		}
+	default:
+		resultType = &VariableSizedType{
+			Type: resultElementType,
+		}
+	}
+
+	return &FunctionType{
+		TypeParameters: []*TypeParameter{
+			typeParameter,
+		},
+		Parameters: []*Parameter{
+			{
+				Label:      ArgumentLabelNotRequired,
+				Identifier: "transform",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "element",
+								TypeAnnotation: NewTypeAnnotation(arrayType.ElementType(false)),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(resultElementType),
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(resultType),
+	}
+}
+
+func ArrayFilterFunctionType(elementType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:      ArgumentLabelNotRequired,
+				Identifier: "predicate",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "element",
+								TypeAnnotation: NewTypeAnnotation(elementType),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(BoolType),
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(&VariableSizedType{
+			Type: elementType,
+		}),
+	}
+}
+
+func ArrayReduceFunctionType(elementType Type) *FunctionType {
+	typeParameter := &TypeParameter{
+		Name: "T",
+	}
+
+	resultType := &GenericType{
+		TypeParameter: typeParameter,
+	}
+
+	return &FunctionType{
+		TypeParameters: []*TypeParameter{
+			typeParameter,
+		},
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "initialResult",
+				TypeAnnotation: NewTypeAnnotation(resultType),
+			},
+			{
+				Label:      ArgumentLabelNotRequired,
+				Identifier: "nextPartialResult",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "partialResult",
+								TypeAnnotation: NewTypeAnnotation(resultType),
+							},
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "element",
+								TypeAnnotation: NewTypeAnnotation(elementType),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(resultType),
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(resultType),
+	}
+}
+
+func ArrayReverseFunctionType(arrayType ArrayType) *FunctionType {
+	return &FunctionType{
+		Purity:               FunctionPurityBuiltinView,
+		ReturnTypeAnnotation: NewTypeAnnotation(arrayType),
+	}
+}
+
+func ArraySortFunctionType(elementType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Identifier: "by",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "first",
+								TypeAnnotation: NewTypeAnnotation(elementType),
+							},
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "second",
+								TypeAnnotation: NewTypeAnnotation(elementType),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(BoolType),
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(VoidType),
+	}
+}
+
 // VariableSizedType is a variable sized array type
 type VariableSizedType struct {
 	Type                Type
@@ -2329,6 +2654,10 @@ type TypeParameter struct {
 	Name      string
 	TypeBound Type
 	Optional  bool
+	// Declared is true if the type parameter is declared in a program,
+	// i.e. it is a type parameter of a user-defined generic function or composite type.
+	// Declared type parameters always have a type bound
+	Declared bool
 }
 
 func (p TypeParameter) string(typeFormatter func(Type) string) string {
@@ -2396,6 +2725,7 @@ func (p TypeParameter) checkTypeBound(ty Type, typeRange ast.Range) error {
 
 func formatFunctionType(
 	spaces bool,
+	purity FunctionPurity,
 	typeParameters []string,
 	parameters []string,
 	returnTypeAnnotation string,
@@ -2404,6 +2734,13 @@ func formatFunctionType(
 	var builder strings.Builder
 	builder.WriteRune('(')
 
+	// Only functions declared with the `view` modifier are printed with it,
+	// see FunctionPurityBuiltinView
+
+	if purity == FunctionPurityView {
+		builder.WriteString("view ")
+	}
+
 	if len(typeParameters) > 0 {
 		builder.WriteRune('<')
 		for i, typeParameter := range typeParameters {
@@ -2436,10 +2773,34 @@ func formatFunctionType(
 	return builder.String()
 }
 
+// FunctionPurity is the purity of a function.
+// View functions may not have side effects,
+// i.e. they may not mutate state and may only call other view functions.
+//
+type FunctionPurity int
+
+const (
+	FunctionPurityImpure FunctionPurity = iota
+	// FunctionPurityView is the purity of functions declared with the `view` modifier
+	FunctionPurityView
+	// FunctionPurityBuiltinView is the purity of built-in functions which have no side effects.
+	// Unlike FunctionPurityView, it is not part of the ID and the string representation of the function type,
+	// so the types of built-in functions keep their existing IDs
+	FunctionPurityBuiltinView
+)
+
+// IsView returns true if functions with the purity may not have side effects
+//
+func (p FunctionPurity) IsView() bool {
+	return p == FunctionPurityView ||
+		p == FunctionPurityBuiltinView
+}
+
 // FunctionType
 //
 type FunctionType struct {
 	IsConstructor            bool
+	Purity                   FunctionPurity
 	TypeParameters           []*TypeParameter
 	Parameters               []*Parameter
 	ReturnTypeAnnotation     *TypeAnnotation
@@ -2487,6 +2848,7 @@ func (t *FunctionType) String() string {
 
 	return formatFunctionType(
 		true,
+		t.Purity,
 		typeParameters,
 		parameters,
 		returnTypeAnnotation,
@@ -2511,6 +2873,7 @@ func (t *FunctionType) QualifiedString() string {
 
 	return formatFunctionType(
 		true,
+		t.Purity,
 		typeParameters,
 		parameters,
 		returnTypeAnnotation,
@@ -2537,6 +2900,7 @@ func (t *FunctionType) ID() TypeID {
 	return TypeID(
 		formatFunctionType(
 			false,
+			t.Purity,
 			typeParameters,
 			parameters,
 			returnTypeAnnotation,
@@ -2597,6 +2961,12 @@ func (t *FunctionType) Equal(other Type) bool {
 		return false
 	}
 
+	// purity
+
+	if t.Purity != otherFunction.Purity {
+		return false
+	}
+
 	return true
 }
 
@@ -2724,6 +3094,7 @@ func (t *FunctionType) RewriteWithRestrictedTypes() (Type, bool) {
 						Name:      typeParameter.Name,
 						TypeBound: rewrittenTypeBound,
 						Optional:  typeParameter.Optional,
+						Declared:  typeParameter.Declared,
 					}
 				} else {
 					rewrittenTypeParameters[i] = typeParameter
@@ -2749,6 +3120,7 @@ func (t *FunctionType) RewriteWithRestrictedTypes() (Type, bool) {
 		}
 
 		return &FunctionType{
+			Purity:                t.Purity,
 			TypeParameters:        rewrittenTypeParameters,
 			Parameters:            rewrittenParameters,
 			ReturnTypeAnnotation:  NewTypeAnnotation(rewrittenReturnType),
@@ -2831,7 +3203,28 @@ func (t *FunctionType) Unify(
 
 func (t *FunctionType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type {
 
-	// TODO: type parameters ?
+	// The function's own type parameters which are not resolved
+	// by the given type arguments remain type parameters of the resolved function type.
+	// For example, a generic member function of a generic composite type
+
+	var newTypeParameters []*TypeParameter
+
+	for _, typeParameter := range t.TypeParameters {
+		if _, ok := typeArguments.Get(typeParameter); ok {
+			continue
+		}
+
+		if newTypeParameters == nil {
+			resolvedTypeArguments := NewTypeParameterTypeOrderedMap()
+			typeArguments.Foreach(func(key *TypeParameter, value Type) {
+				resolvedTypeArguments.Set(key, value)
+			})
+			typeArguments = resolvedTypeArguments
+		}
+
+		newTypeParameters = append(newTypeParameters, typeParameter)
+		typeArguments.Set(typeParameter, &GenericType{TypeParameter: typeParameter})
+	}
 
 	// parameters
 
@@ -2860,6 +3253,9 @@ func (t *FunctionType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type
 	}
 
 	return &FunctionType{
+		IsConstructor:         t.IsConstructor,
+		Purity:                t.Purity,
+		TypeParameters:        newTypeParameters,
 		Parameters:            newParameters,
 		ReturnTypeAnnotation:  NewTypeAnnotation(newReturnType),
 		RequiredArgumentCount: t.RequiredArgumentCount,
@@ -2926,6 +3322,7 @@ func init() {
 		PublicKeyType,
 		SignatureAlgorithmType,
 		HashAlgorithmType,
+		CapabilityControllerType,
 	)
 
 	for _, ty := range types {
@@ -3142,6 +3539,7 @@ func init() {
 
 func NumberConversionFunctionType(numberType Type) *FunctionType {
 	return &FunctionType{
+		Purity: FunctionPurityBuiltinView,
 		Parameters: []*Parameter{
 			{
 				Label:          ArgumentLabelNotRequired,
@@ -3176,6 +3574,7 @@ func baseFunctionVariable(name string, ty *FunctionType, docString string) *Vari
 }
 
 var AddressConversionFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
 	Parameters: []*Parameter{
 		{
 			Label:          ArgumentLabelNotRequired,
@@ -3259,6 +3658,7 @@ func init() {
 	}
 
 	functionType := &FunctionType{
+		Purity:               FunctionPurityBuiltinView,
 		ReturnTypeAnnotation: NewTypeAnnotation(StringType),
 	}
 
@@ -3281,6 +3681,13 @@ func init() {
 		StringTypeEncodeHexFunctionDocString,
 	))
 
+	addMember(NewPublicFunctionMember(
+		functionType,
+		StringTypeJoinFunctionName,
+		StringTypeJoinFunctionType,
+		StringTypeJoinFunctionDocString,
+	))
+
 	BaseValueActivation.Set(
 		typeName,
 		baseFunctionVariable(
@@ -3292,6 +3699,7 @@ func init() {
 }
 
 var StringTypeEncodeHexFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
 	Parameters: []*Parameter{
 		{
 			Label:      ArgumentLabelNotRequired,
@@ -3418,6 +3826,7 @@ func suggestFixedPointLiteralConversionReplacement(
 
 func pathConversionFunctionType(pathType Type) *FunctionType {
 	return &FunctionType{
+		Purity: FunctionPurityBuiltinView,
 		Parameters: []*Parameter{
 			{
 				Identifier:     "identifier",
@@ -3521,9 +3930,13 @@ type CompositeType struct {
 	// TODO: add support for overloaded initializers
 	ConstructorParameters []*Parameter
 	nestedTypes           *StringTypeOrderedMap
+	typeAliases           *StringTypeOrderedMap
 	containerType         Type
 	EnumRawType           Type
-	hasComputedMembers    bool
+	// AttachmentBaseType is the type the attachment is declared for.
+	// Only applicable for attachment types.
+	AttachmentBaseType Type
+	hasComputedMembers bool
 
 	// Only applicable for native composite types.
 	importable bool
@@ -3533,8 +3946,19 @@ type CompositeType struct {
 		QualifiedIdentifier string
 	}
 	cachedIdentifiersLock sync.RWMutex
+
+	// Only applicable for generic composite types.
+	typeParameters     []*TypeParameter
+	instantiations     map[TypeID]*CompositeType
+	instantiationsLock sync.Mutex
+
+	// Only applicable for instantiated generic composite types.
+	genericType   *CompositeType
+	typeArguments []Type
 }
 
+var _ ParameterizedType = &CompositeType{}
+
 func (t *CompositeType) Tag() TypeTag {
 	return CompositeTypeTag
 }
@@ -3564,11 +3988,17 @@ func (t *CompositeType) addImplicitTypeRequirementConformance(typeRequirement *C
 func (*CompositeType) IsType() {}
 
 func (t *CompositeType) String() string {
-	return t.Identifier
+	if t.genericType == nil {
+		return t.Identifier
+	}
+	return formatInstantiatedType(t.Identifier, t.typeArguments, Type.String)
 }
 
 func (t *CompositeType) QualifiedString() string {
-	return t.QualifiedIdentifier()
+	if t.genericType == nil {
+		return t.QualifiedIdentifier()
+	}
+	return formatInstantiatedType(t.QualifiedIdentifier(), t.typeArguments, Type.QualifiedString)
 }
 
 func (t *CompositeType) GetContainerType() Type {
@@ -3637,6 +4067,10 @@ func (t *CompositeType) initializeIdentifiers() {
 		typeID = t.Location.TypeID(identifier)
 	}
 
+	if t.genericType != nil {
+		typeID = InstantiatedCompositeTypeID(typeID, t.typeArguments)
+	}
+
 	t.cachedIdentifiers = &struct {
 		TypeID              TypeID
 		QualifiedIdentifier string
@@ -3777,6 +4211,7 @@ func (t *CompositeType) InterfaceType() *InterfaceType {
 		InitializerParameters: t.ConstructorParameters,
 		containerType:         t.containerType,
 		nestedTypes:           t.nestedTypes,
+		typeAliases:           t.typeAliases,
 	}
 }
 
@@ -3803,15 +4238,300 @@ func (t *CompositeType) TypeRequirements() []*CompositeType {
 	return typeRequirements
 }
 
-func (*CompositeType) Unify(_ Type, _ *TypeParameterTypeOrderedMap, _ func(err error), _ ast.Range) bool {
-	// TODO:
-	return false
+func (t *CompositeType) Unify(
+	other Type,
+	typeParameters *TypeParameterTypeOrderedMap,
+	report func(err error),
+	outerRange ast.Range,
+) (
+	result bool,
+) {
+
+	otherComposite, ok := other.(*CompositeType)
+	if !ok || otherComposite.genericBaseType() != t.genericBaseType() {
+		return false
+	}
+
+	typeArguments := t.instantiationTypeArguments()
+	otherTypeArguments := otherComposite.instantiationTypeArguments()
+
+	if len(typeArguments) != len(otherTypeArguments) {
+		return false
+	}
+
+	for i, typeArgument := range typeArguments {
+		typeArgumentUnified := typeArgument.Unify(
+			otherTypeArguments[i],
+			typeParameters,
+			report,
+			outerRange,
+		)
+		result = result || typeArgumentUnified
+	}
+
+	return
+}
+
+func (t *CompositeType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type {
+	if len(t.typeParameters) == 0 && t.genericType == nil {
+		return t
+	}
+
+	// A generic composite type is resolved by resolving the type arguments
+	// of the instantiation, and instantiating the generic composite type
+	// with the resolved type arguments.
+	//
+	// Inside of the generic composite type's declaration,
+	// the generic composite type itself is the instantiation
+	// with its own type parameters, which might not be resolved.
+
+	instantiationTypeArguments := t.instantiationTypeArguments()
+	newTypeArguments := make([]Type, len(instantiationTypeArguments))
+
+	for i, typeArgument := range instantiationTypeArguments {
+		newTypeArgument := typeArgument.Resolve(typeArguments)
+		if newTypeArgument == nil {
+			if t.genericType != nil {
+				return nil
+			}
+			newTypeArgument = typeArgument
+		}
+		newTypeArguments[i] = newTypeArgument
+	}
+
+	return t.genericBaseType().Instantiate(newTypeArguments, nil)
+}
+
+// TypeParameters returns the type parameters of the generic composite type.
+// The type parameters of an instantiated composite type are the type parameters
+// of its generic composite type, but the instantiated type is not parameterized anymore.
+//
+func (t *CompositeType) TypeParameters() []*TypeParameter {
+	if t.genericType != nil {
+		return nil
+	}
+	return t.typeParameters
+}
+
+// SetTypeParameters sets the type parameters of a generic composite type.
+// It must be called before the composite type is instantiated.
+//
+func (t *CompositeType) SetTypeParameters(typeParameters []*TypeParameter) {
+	t.typeParameters = typeParameters
+}
+
+func (t *CompositeType) TypeArguments() []Type {
+	return t.typeArguments
+}
+
+// BaseType returns the generic composite type of an instantiated composite type,
+// or nil if the composite type is not instantiated.
+//
+func (t *CompositeType) BaseType() Type {
+	if t.genericType == nil {
+		return nil
+	}
+	return t.genericType
 }
 
-func (t *CompositeType) Resolve(_ *TypeParameterTypeOrderedMap) Type {
+func (t *CompositeType) genericBaseType() *CompositeType {
+	if t.genericType != nil {
+		return t.genericType
+	}
 	return t
 }
 
+// instantiationTypeArguments returns the type arguments of an instantiated composite type.
+// A generic composite type is considered to be instantiated with its own type parameters.
+//
+func (t *CompositeType) instantiationTypeArguments() []Type {
+	if t.genericType != nil {
+		return t.typeArguments
+	}
+
+	typeArguments := make([]Type, len(t.typeParameters))
+	for i, typeParameter := range t.typeParameters {
+		typeArguments[i] = &GenericType{
+			TypeParameter: typeParameter,
+		}
+	}
+	return typeArguments
+}
+
+// Instantiate returns the instantiation of the generic composite type
+// with the given type arguments.
+//
+// The type arguments are expected to have already been checked against the type bounds.
+// Instantiations are memoized, so instantiating the same generic composite type
+// with the same type arguments results in the same instantiated composite type.
+// Instantiating the generic composite type with its own type parameters
+// results in the generic composite type itself.
+//
+func (t *CompositeType) Instantiate(typeArguments []Type, _ func(err error)) Type {
+	if t.genericType != nil {
+		return t.genericType.Instantiate(typeArguments, nil)
+	}
+
+	if len(typeArguments) != len(t.typeParameters) {
+		panic(errors.NewUnreachableError())
+	}
+
+	if t.isInstantiatedWithOwnTypeParameters(typeArguments) {
+		return t
+	}
+
+	typeID := InstantiatedCompositeTypeID(t.ID(), typeArguments)
+
+	t.instantiationsLock.Lock()
+
+	if instantiation, ok := t.instantiations[typeID]; ok {
+		t.instantiationsLock.Unlock()
+		return instantiation
+	}
+
+	instantiation := &CompositeType{
+		Location:                            t.Location,
+		Identifier:                          t.Identifier,
+		Kind:                                t.Kind,
+		ExplicitInterfaceConformances:       t.ExplicitInterfaceConformances,
+		ImplicitTypeRequirementConformances: t.ImplicitTypeRequirementConformances,
+		nestedTypes:                         t.nestedTypes,
+		typeAliases:                         t.typeAliases,
+		containerType:                       t.containerType,
+		EnumRawType:                         t.EnumRawType,
+		hasComputedMembers:                  t.hasComputedMembers,
+		genericType:                         t,
+		typeArguments:                       typeArguments,
+	}
+
+	if t.instantiations == nil {
+		t.instantiations = map[TypeID]*CompositeType{}
+	}
+	t.instantiations[typeID] = instantiation
+
+	t.instantiationsLock.Unlock()
+
+	// The members of the generic composite type might not be declared yet,
+	// e.g. when the instantiated type is used in the declaration of another composite type.
+	// In that case the instantiation is completed again once the members are declared,
+	// see CompleteInstantiations.
+	//
+	// NOTE: The instantiation is completed after it was memoized and the lock was released,
+	// as the members of the generic composite type may refer to the instantiation itself.
+
+	t.completeInstantiation(instantiation)
+
+	return instantiation
+}
+
+func (t *CompositeType) isInstantiatedWithOwnTypeParameters(typeArguments []Type) bool {
+	for i, typeArgument := range typeArguments {
+		genericType, ok := typeArgument.(*GenericType)
+		if !ok || genericType.TypeParameter != t.typeParameters[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// CompleteInstantiations completes all instantiations of the generic composite type.
+// It must be called once the members of the generic composite type are declared,
+// as instantiations may have been created before.
+//
+func (t *CompositeType) CompleteInstantiations() {
+	t.instantiationsLock.Lock()
+
+	instantiations := make([]*CompositeType, 0, len(t.instantiations))
+	for _, instantiation := range t.instantiations { //nolint:maprangecheck
+		instantiations = append(instantiations, instantiation)
+	}
+
+	t.instantiationsLock.Unlock()
+
+	for _, instantiation := range instantiations {
+		t.completeInstantiation(instantiation)
+	}
+}
+
+// completeInstantiation resolves the members and the constructor parameters
+// of the given instantiation of the generic composite type
+//
+func (t *CompositeType) completeInstantiation(instantiation *CompositeType) {
+
+	typeArguments := NewTypeParameterTypeOrderedMap()
+	for i, typeParameter := range t.typeParameters {
+		typeArguments.Set(typeParameter, instantiation.typeArguments[i])
+	}
+
+	resolveType := func(ty Type) Type {
+		resolvedType := ty.Resolve(typeArguments)
+		if resolvedType == nil {
+			return ty
+		}
+		return resolvedType
+	}
+
+	members := NewStringMemberOrderedMap()
+
+	t.Members.Foreach(func(name string, member *Member) {
+		members.Set(name, &Member{
+			ContainerType: instantiation,
+			Access:        member.Access,
+			Identifier:    member.Identifier,
+			TypeAnnotation: &TypeAnnotation{
+				IsResource: member.TypeAnnotation.IsResource,
+				Type:       resolveType(member.TypeAnnotation.Type),
+			},
+			DeclarationKind:       member.DeclarationKind,
+			VariableKind:          member.VariableKind,
+			ArgumentLabels:        member.ArgumentLabels,
+			Predeclared:           member.Predeclared,
+			IgnoreInSerialization: member.IgnoreInSerialization,
+			DocString:             member.DocString,
+		})
+	})
+
+	constructorParameters := make([]*Parameter, len(t.ConstructorParameters))
+	for i, parameter := range t.ConstructorParameters {
+		constructorParameters[i] = &Parameter{
+			Label:      parameter.Label,
+			Identifier: parameter.Identifier,
+			TypeAnnotation: &TypeAnnotation{
+				IsResource: parameter.TypeAnnotation.IsResource,
+				Type:       resolveType(parameter.TypeAnnotation.Type),
+			},
+		}
+	}
+
+	instantiation.Members = members
+	instantiation.Fields = t.Fields
+	instantiation.ConstructorParameters = constructorParameters
+}
+
+// InstantiatedCompositeTypeID returns the type ID of the instantiation
+// of the generic composite type with the given type ID
+// with the given type arguments, e.g. `S.test.Box<Int>`
+//
+func InstantiatedCompositeTypeID(typeID TypeID, typeArguments []Type) TypeID {
+	return TypeID(formatInstantiatedType(string(typeID), typeArguments, func(ty Type) string {
+		return string(ty.ID())
+	}))
+}
+
+func formatInstantiatedType(identifier string, typeArguments []Type, typeFormatter func(Type) string) string {
+	var builder strings.Builder
+	builder.WriteString(identifier)
+	builder.WriteRune('<')
+	for i, typeArgument := range typeArguments {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(typeFormatter(typeArgument))
+	}
+	builder.WriteRune('>')
+	return builder.String()
+}
+
 func (t *CompositeType) IsContainerType() bool {
 	return t.nestedTypes != nil
 }
@@ -3820,6 +4540,10 @@ func (t *CompositeType) GetNestedTypes() *StringTypeOrderedMap {
 	return t.nestedTypes
 }
 
+func (t *CompositeType) GetTypeAliases() *StringTypeOrderedMap {
+	return t.typeAliases
+}
+
 func (t *CompositeType) initializeMemberResolvers() {
 	t.memberResolversOnce.Do(func() {
 		members := make(map[string]MemberResolver, t.Members.Len())
@@ -3884,6 +4608,8 @@ type Member struct {
 	// IgnoreInSerialization fields are ignored in serialization
 	IgnoreInSerialization bool
 	DocString             string
+	// HasImplementation is true for interface functions which have a default implementation
+	HasImplementation bool
 }
 
 func NewPublicFunctionMember(
@@ -4009,6 +4735,7 @@ type InterfaceType struct {
 	InitializerParameters []*Parameter
 	containerType         Type
 	nestedTypes           *StringTypeOrderedMap
+	typeAliases           *StringTypeOrderedMap
 	cachedIdentifiers     *struct {
 		TypeID              TypeID
 		QualifiedIdentifier string
@@ -4231,6 +4958,10 @@ func (t *InterfaceType) GetNestedTypes() *StringTypeOrderedMap {
 	return t.nestedTypes
 }
 
+func (t *InterfaceType) GetTypeAliases() *StringTypeOrderedMap {
+	return t.typeAliases
+}
+
 func (t *InterfaceType) FieldPosition(name string, declaration *ast.InterfaceDeclaration) ast.Position {
 	return declaration.Members.FieldPosition(name, declaration.CompositeKind)
 }
@@ -4479,6 +5210,7 @@ func (t *DictionaryType) initializeMemberResolvers() {
 
 func DictionaryContainsKeyFunctionType(t *DictionaryType) *FunctionType {
 	return &FunctionType{
+		Purity: FunctionPurityBuiltinView,
 		Parameters: []*Parameter{
 			{
 				Label:          ArgumentLabelNotRequired,
@@ -4718,14 +5450,31 @@ func (t *ReferenceType) IndexingType() Type {
 	return referencedType.IndexingType()
 }
 
-func (*ReferenceType) Unify(_ Type, _ *TypeParameterTypeOrderedMap, _ func(err error), _ ast.Range) bool {
-	// TODO:
-	return false
+func (t *ReferenceType) Unify(
+	other Type,
+	typeParameters *TypeParameterTypeOrderedMap,
+	report func(err error),
+	outerRange ast.Range,
+) bool {
+
+	otherReference, ok := other.(*ReferenceType)
+	if !ok || otherReference.Authorized != t.Authorized {
+		return false
+	}
+
+	return t.Type.Unify(otherReference.Type, typeParameters, report, outerRange)
 }
 
-func (t *ReferenceType) Resolve(_ *TypeParameterTypeOrderedMap) Type {
-	// TODO:
-	return t
+func (t *ReferenceType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type {
+	newInnerType := t.Type.Resolve(typeArguments)
+	if newInnerType == nil {
+		return nil
+	}
+
+	return &ReferenceType{
+		Authorized: t.Authorized,
+		Type:       newInnerType,
+	}
 }
 
 const AddressTypeName = "Address"
@@ -4816,6 +5565,7 @@ func (t *AddressType) Resolve(_ *TypeParameterTypeOrderedMap) Type {
 const AddressTypeToBytesFunctionName = `toBytes`
 
 var AddressTypeToBytesFunctionType = &FunctionType{
+	Purity: FunctionPurityBuiltinView,
 	ReturnTypeAnnotation: NewTypeAnnotation(
 		ByteArrayType,
 	),
@@ -4914,6 +5664,19 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 		return true
 	}
 
+	// A generic type `T` of a declared type parameter is a subtype of a type `V`,
+	// if the type bound of `T` is a subtype of `V`:
+	// The type parameter can only be instantiated with subtypes of its type bound.
+	//
+	// Generic types of the type parameters of built-in functions
+	// are handled by the general rules below
+
+	if genericSubType, ok := subType.(*GenericType); ok &&
+		genericSubType.TypeParameter.Declared {
+
+		return IsSubType(genericSubType.TypeParameter.TypeBound, superType)
+	}
+
 	switch superType {
 	case AnyType:
 		return true
@@ -5265,6 +6028,15 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 			return false
 		}
 
+		// View functions are subtypes of impure functions,
+		// but impure functions are not subtypes of view functions
+
+		if typedSuperType.Purity.IsView() &&
+			!typedSubType.Purity.IsView() {
+
+			return false
+		}
+
 		return true
 
 	case *RestrictedType:
@@ -5421,6 +6193,11 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 			}
 		}
 
+		// Instantiated composite types are invariant in their type arguments,
+		// so the rule for parameterized types below does not apply
+
+		return false
+
 	case *InterfaceType:
 
 		switch typedSubType := subType.(type) {
@@ -5882,9 +6659,20 @@ func (*RestrictedType) Unify(_ Type, _ *TypeParameterTypeOrderedMap, _ func(err
 	return false
 }
 
-func (t *RestrictedType) Resolve(_ *TypeParameterTypeOrderedMap) Type {
-	// TODO:
-	return t
+func (t *RestrictedType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type {
+	newRestrictedType := t.Type.Resolve(typeArguments)
+	if newRestrictedType == nil {
+		return nil
+	}
+
+	if newRestrictedType == t.Type {
+		return t
+	}
+
+	return &RestrictedType{
+		Type:         newRestrictedType,
+		Restrictions: t.Restrictions,
+	}
 }
 
 // CapabilityType
@@ -6075,6 +6863,7 @@ func CapabilityTypeBorrowFunctionType(borrowType Type) *FunctionType {
 	}
 
 	return &FunctionType{
+		Purity:         FunctionPurityBuiltinView,
 		TypeParameters: typeParameters,
 		ReturnTypeAnnotation: NewTypeAnnotation(
 			&OptionalType{
@@ -6095,6 +6884,7 @@ func CapabilityTypeCheckFunctionType(borrowType Type) *FunctionType {
 	}
 
 	return &FunctionType{
+		Purity:               FunctionPurityBuiltinView,
 		TypeParameters:       typeParameters,
 		ReturnTypeAnnotation: NewTypeAnnotation(BoolType),
 	}
@@ -6112,6 +6902,12 @@ const addressTypeCheckFunctionDocString = `
 The address of the capability
 `
 
+const CapabilityTypeIDField = "id"
+
+const capabilityTypeIDFieldDocString = `
+The ID of the capability, if it was issued through ` + "`AuthAccount.capabilities`" + `, or 0 if it is a capability for a link
+`
+
 func (t *CapabilityType) GetMembers() map[string]MemberResolver {
 	t.initializeMemberResolvers()
 	return t.memberResolvers
@@ -6153,6 +6949,17 @@ func (t *CapabilityType) initializeMemberResolvers() {
 					)
 				},
 			},
+			CapabilityTypeIDField: {
+				Kind: common.DeclarationKindField,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicConstantFieldMember(
+						t,
+						identifier,
+						UInt64Type,
+						capabilityTypeIDFieldDocString,
+					)
+				},
+			},
 		})
 	})
 }
@@ -6171,6 +6978,8 @@ func init() {
 		PublicAccountType,
 		PublicAccountKeysType,
 		PublicAccountContractsType,
+		AuthAccountCapabilitiesType,
+		CapabilityControllerType,
 	}
 
 	for _, semaType := range types {
@@ -6311,6 +7120,7 @@ var PublicKeyArrayType = &VariableSizedType{
 }
 
 var PublicKeyVerifyFunctionType = &FunctionType{
+	Purity:         FunctionPurityBuiltinView,
 	TypeParameters: []*TypeParameter{},
 	Parameters: []*Parameter{
 		{
@@ -6338,6 +7148,7 @@ var PublicKeyVerifyFunctionType = &FunctionType{
 }
 
 var PublicKeyVerifyPoPFunctionType = &FunctionType{
+	Purity:         FunctionPurityBuiltinView,
 	TypeParameters: []*TypeParameter{},
 	Parameters: []*Parameter{
 		{
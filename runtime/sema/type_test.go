@@ -635,7 +635,7 @@ func TestIdentifierCacheUpdate(t *testing.T) {
           }
 	`
 
-	program, err := parser2.ParseProgram(code)
+	program, err := parser2.ParseProgram(code, nil)
 	require.NoError(t, err)
 
 	checker, err := NewChecker(
@@ -1677,3 +1677,111 @@ func TestTypeInclusions(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestIsSubType_GenericType(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("built-in, without type bound", func(t *testing.T) {
+
+		t.Parallel()
+
+		// Generic types of built-in type parameters are handled by the general rules,
+		// independent of their type bound
+
+		genericType := &GenericType{
+			TypeParameter: &TypeParameter{
+				Name: "T",
+			},
+		}
+
+		assert.True(t, IsSubType(genericType, AnyType))
+		assert.True(t, IsSubType(genericType, AnyStructType))
+		assert.False(t, IsSubType(genericType, AnyResourceType))
+		assert.False(t, IsSubType(genericType, IntType))
+	})
+
+	t.Run("built-in, with type bound", func(t *testing.T) {
+
+		t.Parallel()
+
+		genericType := &GenericType{
+			TypeParameter: &TypeParameter{
+				Name:      "T",
+				TypeBound: IntType,
+			},
+		}
+
+		assert.True(t, IsSubType(genericType, AnyStructType))
+		assert.False(t, IsSubType(genericType, IntType))
+	})
+
+	t.Run("declared", func(t *testing.T) {
+
+		t.Parallel()
+
+		genericType := &GenericType{
+			TypeParameter: &TypeParameter{
+				Name:      "T",
+				TypeBound: IntType,
+				Declared:  true,
+			},
+		}
+
+		assert.True(t, IsSubType(genericType, AnyType))
+		assert.True(t, IsSubType(genericType, AnyStructType))
+		assert.True(t, IsSubType(genericType, IntType))
+		assert.True(t, IsSubType(genericType, NumberType))
+		assert.False(t, IsSubType(genericType, StringType))
+		assert.False(t, IsSubType(genericType, AnyResourceType))
+	})
+}
+
+func TestFunctionType_Purity(t *testing.T) {
+
+	t.Parallel()
+
+	newFunctionType := func(purity FunctionPurity) *FunctionType {
+		return &FunctionType{
+			Purity: purity,
+			Parameters: []*Parameter{
+				{
+					TypeAnnotation: NewTypeAnnotation(IntType),
+				},
+			},
+			ReturnTypeAnnotation: NewTypeAnnotation(StringType),
+		}
+	}
+
+	t.Run("impure", func(t *testing.T) {
+
+		t.Parallel()
+
+		functionType := newFunctionType(FunctionPurityImpure)
+
+		assert.Equal(t, TypeID("((Int):String)"), functionType.ID())
+		assert.Equal(t, "((Int): String)", functionType.String())
+	})
+
+	t.Run("view", func(t *testing.T) {
+
+		t.Parallel()
+
+		functionType := newFunctionType(FunctionPurityView)
+
+		assert.Equal(t, TypeID("(view (Int):String)"), functionType.ID())
+		assert.Equal(t, "(view (Int): String)", functionType.String())
+	})
+
+	t.Run("built-in view", func(t *testing.T) {
+
+		t.Parallel()
+
+		functionType := newFunctionType(FunctionPurityBuiltinView)
+
+		assert.Equal(t, TypeID("((Int):String)"), functionType.ID())
+		assert.Equal(t, "((Int): String)", functionType.String())
+
+		assert.Equal(t, TypeID("((String):String)"), StringTypeConcatFunctionType.ID())
+	})
+}
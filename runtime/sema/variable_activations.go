@@ -149,6 +149,7 @@ func getVariableActivation() *VariableActivation {
 //
 type VariableActivations struct {
 	activations []*VariableActivation
+	memoryGauge common.MemoryGauge
 }
 
 func NewVariableActivations(parent *VariableActivation) *VariableActivations {
@@ -186,6 +187,15 @@ func (a *VariableActivations) Enter() {
 	a.pushNewWithParent(a.Current())
 }
 
+// EnterGlobal pushes a new empty activation
+// to the top of the activation stack.
+// The new activation has the global (i.e. bottom-most) activation as its parent,
+// so none of the activations in between are visible.
+//
+func (a *VariableActivations) EnterGlobal() {
+	a.pushNewWithParent(a.activations[0])
+}
+
 // Leave pops the top-most (current) activation
 // from the top of the activation stack.
 //
@@ -275,6 +285,8 @@ func (a *VariableActivations) Declare(declaration variableDeclaration) (variable
 	// A variable with this name is not yet declared in the current scope,
 	// declare it.
 
+	common.UseMemory(a.memoryGauge, common.VariableMemoryUsage)
+
 	variable = &Variable{
 		Identifier:      declaration.identifier,
 		Access:          declaration.access,
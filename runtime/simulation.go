@@ -0,0 +1,752 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+)
+
+// TransactionSimulation is the result of a simulated transaction execution:
+// The effects the transaction would have, if it was executed
+//
+type TransactionSimulation struct {
+	// StorageChanges are the changes to account storage, ordered by address and path
+	StorageChanges []StorageChange
+	// ContractChanges are the changes to contract code, ordered by address and name
+	ContractChanges []ContractChange
+	// Events are the emitted events, in emission order
+	Events []cadence.Event
+	// Logs are the logged messages, in logging order
+	Logs []string
+	// CreatedAccounts are the placeholder addresses of the created accounts, in creation order.
+	// The addresses are not the ones the accounts would get, if the transaction was executed
+	CreatedAccounts []common.Address
+	// Computation is the computation used, per kind
+	Computation map[common.ComputationKind]uint
+}
+
+// StorageChange is a change of the value stored at a path of an account.
+// Before is nil if the value was added, After is nil if the value was removed
+//
+type StorageChange struct {
+	Address common.Address
+	Path    cadence.Path
+	Before  cadence.Value
+	After   cadence.Value
+}
+
+// ContractChange is a change of the code of a contract.
+// Before is nil if the contract was added, After is nil if the contract was removed
+//
+type ContractChange struct {
+	Address common.Address
+	Name    string
+	Before  []byte
+	After   []byte
+}
+
+type simulatedContractCode struct {
+	before []byte
+	after  []byte
+}
+
+// simulatedAccountKeys are the keys of an account, as changed by the simulation.
+// Keys which existed before the execution are read from the wrapped interface,
+// keys which were added by the execution are kept in memory
+//
+type simulatedAccountKeys struct {
+	// count is the number of keys, including the keys added by the execution
+	count        int
+	added        map[int]*AccountKey
+	addedEncoded map[int][]byte
+	revoked      map[int]bool
+}
+
+// simulationInterface is a runtime interface which records the effects of an execution,
+// instead of passing them on to the wrapped interface:
+// Ledger writes, contract code updates, programs, emitted events, and logs are kept,
+// and computation is recorded before it is metered by the wrapped interface.
+//
+// The wrapped interface is only ever read from.
+// Storage indices, UUIDs, and the addresses of created accounts are issued
+// from simulation-local counters, which count down from the maximum value,
+// so they do not collide with the ones issued by the wrapped interface.
+// Created accounts and added or revoked keys are kept in memory
+//
+type simulationInterface struct {
+	Interface
+	values          map[interpreter.StorageKey][]byte
+	contractCodes   map[interpreter.StorageKey]*simulatedContractCode
+	programs        map[common.LocationID]*interpreter.Program
+	events          []cadence.Event
+	logs            []string
+	computation     map[common.ComputationKind]uint
+	storageIndices  map[string]uint64
+	nextUUID        uint64
+	nextAddress     uint64
+	createdAccounts []Address
+	keys            map[Address]*simulatedAccountKeys
+	// finished is true once the execution finished.
+	// Any further computation, e.g. from reading storage when determining the changes, is not recorded
+	finished bool
+}
+
+var _ Interface = &simulationInterface{}
+
+func newSimulationInterface(runtimeInterface Interface) *simulationInterface {
+	return &simulationInterface{
+		Interface:      runtimeInterface,
+		values:         map[interpreter.StorageKey][]byte{},
+		contractCodes:  map[interpreter.StorageKey]*simulatedContractCode{},
+		programs:       map[common.LocationID]*interpreter.Program{},
+		computation:    map[common.ComputationKind]uint{},
+		storageIndices: map[string]uint64{},
+		nextUUID:       math.MaxUint64,
+		nextAddress:    math.MaxUint64,
+		keys:           map[Address]*simulatedAccountKeys{},
+	}
+}
+
+func simulatedValueKey(owner, key []byte) interpreter.StorageKey {
+	return interpreter.StorageKey{
+		Address: common.MustBytesToAddress(owner),
+		Key:     string(key),
+	}
+}
+
+func (i *simulationInterface) GetValue(owner, key []byte) ([]byte, error) {
+	value, ok := i.values[simulatedValueKey(owner, key)]
+	if ok {
+		return value, nil
+	}
+	return i.Interface.GetValue(owner, key)
+}
+
+func (i *simulationInterface) SetValue(owner, key, value []byte) error {
+	i.values[simulatedValueKey(owner, key)] = value
+	return nil
+}
+
+func (i *simulationInterface) ValueExists(owner, key []byte) (bool, error) {
+	value, ok := i.values[simulatedValueKey(owner, key)]
+	if ok {
+		return len(value) > 0, nil
+	}
+	return i.Interface.ValueExists(owner, key)
+}
+
+func (i *simulationInterface) AllocateStorageIndex(owner []byte) (result atree.StorageIndex, err error) {
+	index, ok := i.storageIndices[string(owner)]
+	if !ok {
+		index = math.MaxUint64
+	}
+	i.storageIndices[string(owner)] = index - 1
+	binary.BigEndian.PutUint64(result[:], index)
+	return
+}
+
+func (i *simulationInterface) GenerateUUID() (uint64, error) {
+	uuid := i.nextUUID
+	i.nextUUID--
+	return uuid, nil
+}
+
+func (i *simulationInterface) CreateAccount(_ Address) (address Address, err error) {
+	binary.BigEndian.PutUint64(address[:], i.nextAddress)
+	i.nextAddress--
+
+	i.createdAccounts = append(i.createdAccounts, address)
+	i.keys[address] = &simulatedAccountKeys{
+		added:        map[int]*AccountKey{},
+		addedEncoded: map[int][]byte{},
+		revoked:      map[int]bool{},
+	}
+
+	return address, nil
+}
+
+// isCreatedAccount returns true if the account with the given address was created by the execution,
+// i.e. it does not exist in the wrapped interface
+//
+func (i *simulationInterface) isCreatedAccount(address Address) bool {
+	for _, createdAccount := range i.createdAccounts {
+		if createdAccount == address {
+			return true
+		}
+	}
+	return false
+}
+
+// accountKeys returns the keys of the account with the given address.
+// The keys of an existing account are counted by reading them from the wrapped interface,
+// until it reports that there is no key at the next index
+//
+func (i *simulationInterface) accountKeys(address Address) (*simulatedAccountKeys, error) {
+	keys, ok := i.keys[address]
+	if ok {
+		return keys, nil
+	}
+
+	keys = &simulatedAccountKeys{
+		added:        map[int]*AccountKey{},
+		addedEncoded: map[int][]byte{},
+		revoked:      map[int]bool{},
+	}
+
+	for {
+		key, err := i.Interface.GetAccountKey(address, keys.count)
+		if err != nil {
+			return nil, err
+		}
+		if key == nil {
+			break
+		}
+		keys.count++
+	}
+
+	i.keys[address] = keys
+
+	return keys, nil
+}
+
+func (i *simulationInterface) AddEncodedAccountKey(address Address, publicKey []byte) error {
+	keys, err := i.accountKeys(address)
+	if err != nil {
+		return err
+	}
+
+	keys.addedEncoded[keys.count] = publicKey
+	keys.count++
+
+	return nil
+}
+
+func (i *simulationInterface) RevokeEncodedAccountKey(address Address, index int) (publicKey []byte, err error) {
+	keys, err := i.accountKeys(address)
+	if err != nil {
+		return nil, err
+	}
+
+	// The encoding of keys which existed before the execution is only known to the wrapped interface,
+	// so only keys which were added by the execution can be revoked
+
+	publicKey, ok := keys.addedEncoded[index]
+	if !ok {
+		return nil, fmt.Errorf("cannot simulate revocation of encoded key: %d", index)
+	}
+
+	keys.revoked[index] = true
+
+	return publicKey, nil
+}
+
+func (i *simulationInterface) AddAccountKey(
+	address Address,
+	publicKey *PublicKey,
+	hashAlgo HashAlgorithm,
+	weight int,
+) (*AccountKey, error) {
+	keys, err := i.accountKeys(address)
+	if err != nil {
+		return nil, err
+	}
+
+	accountKey := &AccountKey{
+		KeyIndex:  keys.count,
+		PublicKey: publicKey,
+		HashAlgo:  hashAlgo,
+		Weight:    weight,
+	}
+	keys.added[keys.count] = accountKey
+	keys.count++
+
+	keyCopy := *accountKey
+	return &keyCopy, nil
+}
+
+// GetAccountKey returns the key at the given index,
+// or nil if the account has no key at the index.
+// Encoded keys which were added by the execution are also reported as missing,
+// as they cannot be decoded without the wrapped interface
+//
+func (i *simulationInterface) GetAccountKey(address Address, index int) (*AccountKey, error) {
+	keys, err := i.accountKeys(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if index < 0 || index >= keys.count {
+		return nil, nil
+	}
+
+	var accountKey *AccountKey
+	if addedKey, ok := keys.added[index]; ok {
+		keyCopy := *addedKey
+		accountKey = &keyCopy
+	} else if _, ok := keys.addedEncoded[index]; ok {
+		return nil, nil
+	} else {
+		existingKey, err := i.Interface.GetAccountKey(address, index)
+		if err != nil || existingKey == nil {
+			return nil, err
+		}
+		keyCopy := *existingKey
+		accountKey = &keyCopy
+	}
+
+	if keys.revoked[index] {
+		accountKey.IsRevoked = true
+	}
+
+	return accountKey, nil
+}
+
+// RevokeAccountKey marks the key at the given index as revoked and returns it,
+// or returns nil if the account has no key at the index
+//
+func (i *simulationInterface) RevokeAccountKey(address Address, index int) (*AccountKey, error) {
+	accountKey, err := i.GetAccountKey(address, index)
+	if err != nil || accountKey == nil {
+		return nil, err
+	}
+
+	i.keys[address].revoked[index] = true
+	accountKey.IsRevoked = true
+
+	return accountKey, nil
+}
+
+// GetCode returns the code of the given location.
+// The code of contracts which were deployed, updated, or removed by the execution
+// is the staged code, instead of the code of the wrapped interface
+//
+func (i *simulationInterface) GetCode(location Location) ([]byte, error) {
+	addressLocation, ok := location.(common.AddressLocation)
+	if ok {
+		key := interpreter.StorageKey{
+			Address: addressLocation.Address,
+			Key:     addressLocation.Name,
+		}
+
+		contractCode, ok := i.contractCodes[key]
+		if ok {
+			return contractCode.after, nil
+		}
+		if i.isCreatedAccount(addressLocation.Address) {
+			return nil, nil
+		}
+	}
+	return i.Interface.GetCode(location)
+}
+
+func (i *simulationInterface) GetProgram(location Location) (*interpreter.Program, error) {
+	program, ok := i.programs[location.ID()]
+	if ok {
+		return program, nil
+	}
+	return i.Interface.GetProgram(location)
+}
+
+func (i *simulationInterface) SetProgram(location Location, program *interpreter.Program) error {
+	i.programs[location.ID()] = program
+	return nil
+}
+
+func (i *simulationInterface) contractCode(address Address, name string) (*simulatedContractCode, error) {
+	key := interpreter.StorageKey{
+		Address: address,
+		Key:     name,
+	}
+
+	contractCode, ok := i.contractCodes[key]
+	if !ok {
+		var before []byte
+		if !i.isCreatedAccount(address) {
+			var err error
+			before, err = i.Interface.GetAccountContractCode(address, name)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		contractCode = &simulatedContractCode{
+			before: before,
+			after:  before,
+		}
+		i.contractCodes[key] = contractCode
+	}
+
+	return contractCode, nil
+}
+
+func (i *simulationInterface) GetAccountContractCode(address Address, name string) ([]byte, error) {
+	key := interpreter.StorageKey{
+		Address: address,
+		Key:     name,
+	}
+
+	contractCode, ok := i.contractCodes[key]
+	if ok {
+		return contractCode.after, nil
+	}
+	if i.isCreatedAccount(address) {
+		return nil, nil
+	}
+	return i.Interface.GetAccountContractCode(address, name)
+}
+
+func (i *simulationInterface) UpdateAccountContractCode(address Address, name string, code []byte) error {
+	contractCode, err := i.contractCode(address, name)
+	if err != nil {
+		return err
+	}
+	contractCode.after = code
+	return nil
+}
+
+func (i *simulationInterface) RemoveAccountContractCode(address Address, name string) error {
+	contractCode, err := i.contractCode(address, name)
+	if err != nil {
+		return err
+	}
+	contractCode.after = nil
+	return nil
+}
+
+func (i *simulationInterface) GetAccountContractNames(address Address) ([]string, error) {
+	var names []string
+	if !i.isCreatedAccount(address) {
+		var err error
+		names, err = i.Interface.GetAccountContractNames(address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Apply the simulated contract code changes
+
+	exists := make(map[string]bool, len(names))
+	for _, name := range names {
+		exists[name] = true
+	}
+
+	for key, contractCode := range i.contractCodes { //nolint:maprangecheck
+		if key.Address == address {
+			exists[key.Key] = len(contractCode.after) > 0
+		}
+	}
+
+	result := make([]string, 0, len(exists))
+	for name, exists := range exists { //nolint:maprangecheck
+		if exists {
+			result = append(result, name)
+		}
+	}
+
+	sort.Strings(result)
+
+	return result, nil
+}
+
+func (i *simulationInterface) GetAccountBalance(address Address) (uint64, error) {
+	if i.isCreatedAccount(address) {
+		return 0, nil
+	}
+	return i.Interface.GetAccountBalance(address)
+}
+
+func (i *simulationInterface) GetAccountAvailableBalance(address Address) (uint64, error) {
+	if i.isCreatedAccount(address) {
+		return 0, nil
+	}
+	return i.Interface.GetAccountAvailableBalance(address)
+}
+
+func (i *simulationInterface) GetStorageUsed(address Address) (uint64, error) {
+	if i.isCreatedAccount(address) {
+		return 0, nil
+	}
+	return i.Interface.GetStorageUsed(address)
+}
+
+func (i *simulationInterface) GetStorageCapacity(address Address) (uint64, error) {
+	if i.isCreatedAccount(address) {
+		return 0, nil
+	}
+	return i.Interface.GetStorageCapacity(address)
+}
+
+func (i *simulationInterface) EmitEvent(event cadence.Event) error {
+	i.events = append(i.events, event)
+	return nil
+}
+
+func (i *simulationInterface) ProgramLog(message string) error {
+	i.logs = append(i.logs, message)
+	return nil
+}
+
+func (i *simulationInterface) ResourceOwnerChanged(
+	_ *interpreter.Interpreter,
+	_ *interpreter.CompositeValue,
+	_ common.Address,
+	_ common.Address,
+) {
+	// NO-OP: the owner changes are not committed
+}
+
+func (i *simulationInterface) MeterComputation(kind common.ComputationKind, intensity uint) error {
+	if i.finished {
+		return nil
+	}
+
+	i.computation[kind] += intensity
+
+	return i.Interface.MeterComputation(kind, intensity)
+}
+
+// contractChanges returns the changes of contract code, ordered by address and name
+//
+func (i *simulationInterface) contractChanges() []ContractChange {
+	keys := make([]interpreter.StorageKey, 0, len(i.contractCodes))
+	for key := range i.contractCodes { //nolint:maprangecheck
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(a, b int) bool {
+		return keys[a].IsLess(keys[b])
+	})
+
+	var changes []ContractChange
+
+	for _, key := range keys {
+		contractCode := i.contractCodes[key]
+
+		if bytes.Equal(contractCode.before, contractCode.after) {
+			continue
+		}
+
+		change := ContractChange{
+			Address: key.Address,
+			Name:    key.Key,
+		}
+		if len(contractCode.before) > 0 {
+			change.Before = contractCode.before
+		}
+		if len(contractCode.after) > 0 {
+			change.After = contractCode.after
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+func (r *interpreterRuntime) SimulateTransaction(script Script, context Context) (
+	simulation *TransactionSimulation,
+	err error,
+) {
+	defer r.Recover(
+		func(internalErr error) {
+			err = internalErr
+		},
+		context,
+	)
+
+	simulationInterface := newSimulationInterface(context.Interface)
+	context.Interface = simulationInterface
+
+	storage, inter, err := r.executeTransaction(script, context, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	simulationInterface.finished = true
+
+	storageChanges, err := r.storageChanges(storage, inter, context)
+	if err != nil {
+		return nil, newError(err, context)
+	}
+
+	return &TransactionSimulation{
+		StorageChanges:  storageChanges,
+		ContractChanges: simulationInterface.contractChanges(),
+		Events:          simulationInterface.events,
+		Logs:            simulationInterface.logs,
+		CreatedAccounts: simulationInterface.createdAccounts,
+		Computation:     simulationInterface.computation,
+	}, nil
+}
+
+// storageChanges returns the changes of the paths of all storage maps which were loaded by the execution,
+// by comparing the values in the uncommitted storage of the execution
+// with the values in the storage of the wrapped interface
+//
+func (r *interpreterRuntime) storageChanges(
+	storage *Storage,
+	inter *interpreter.Interpreter,
+	context Context,
+) (
+	changes []StorageChange,
+	err error,
+) {
+	keys := make([]interpreter.StorageKey, 0, len(storage.storageMaps))
+	for key := range storage.storageMaps { //nolint:maprangecheck
+		// Contract values are reported as contract code changes
+		if common.PathDomainFromIdentifier(key.Key) == common.PathDomainUnknown {
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].IsLess(keys[j])
+	})
+
+	// Read the values before the execution from the storage of the wrapped interface.
+	// The storage is not metered, and storage maps which do not exist yet are not created
+
+	simulationInterface := context.Interface.(*simulationInterface)
+	beforeStorage := NewStorage(simulationInterface.Interface, nil)
+
+	var functions stdlib.StandardLibraryFunctions
+	var values stdlib.StandardLibraryValues
+	var interpreterOptions []interpreter.Option
+	var checkerOptions []sema.Option
+
+	_, _, err = r.interpret(
+		nil,
+		context,
+		beforeStorage,
+		functions,
+		values,
+		interpreterOptions,
+		checkerOptions,
+		func(beforeInter *interpreter.Interpreter) (interpreter.Value, error) {
+			for _, key := range keys {
+				afterMap := storage.storageMaps[key]
+				beforeMap := beforeStorage.GetStorageMap(key.Address, key.Key, false)
+
+				for _, identifier := range storageMapIdentifiers(beforeMap, afterMap) {
+
+					before, err := exportStoredValue(beforeMap, identifier, beforeInter)
+					if err != nil {
+						return nil, err
+					}
+
+					after, err := exportStoredValue(afterMap, identifier, inter)
+					if err != nil {
+						return nil, err
+					}
+
+					if reflect.DeepEqual(before, after) {
+						continue
+					}
+
+					changes = append(changes, StorageChange{
+						Address: key.Address,
+						Path: cadence.Path{
+							Domain:     key.Key,
+							Identifier: identifier,
+						},
+						Before: before,
+						After:  after,
+					})
+				}
+			}
+
+			return nil, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// storageMapIdentifiers returns the sorted union of the keys of the given storage maps.
+// Storage maps which do not exist are nil
+//
+func storageMapIdentifiers(storageMaps ...*interpreter.StorageMap) []string {
+	seen := map[string]struct{}{}
+	var identifiers []string
+
+	for _, storageMap := range storageMaps {
+		if storageMap == nil {
+			continue
+		}
+
+		iterator := storageMap.Iterator()
+		for {
+			identifier := iterator.NextKey()
+			if identifier == "" {
+				break
+			}
+
+			if _, ok := seen[identifier]; ok {
+				continue
+			}
+			seen[identifier] = struct{}{}
+
+			identifiers = append(identifiers, identifier)
+		}
+	}
+
+	sort.Strings(identifiers)
+
+	return identifiers
+}
+
+func exportStoredValue(
+	storageMap *interpreter.StorageMap,
+	identifier string,
+	inter *interpreter.Interpreter,
+) (
+	cadence.Value,
+	error,
+) {
+	if storageMap == nil {
+		return nil, nil
+	}
+
+	value := storageMap.ReadValue(identifier)
+	if value == nil {
+		return nil, nil
+	}
+	return ExportValue(value, inter)
+}
@@ -0,0 +1,409 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/onflow/atree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+func TestRuntimeSimulateTransaction(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	signerAddress := common.MustBytesToAddress([]byte{0x1})
+
+	accountCodes := map[common.LocationID][]byte{}
+	var events []cadence.Event
+
+	ledger := newTestLedger(nil, nil)
+
+	runtimeInterface := &testRuntimeInterface{
+		getCode: func(location Location) (bytes []byte, err error) {
+			return accountCodes[location.ID()], nil
+		},
+		storage: ledger,
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{signerAddress}, nil
+		},
+		getAccountContractCode: func(address Address, name string) (code []byte, err error) {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			return accountCodes[location.ID()], nil
+		},
+		updateAccountContractCode: func(address Address, name string, code []byte) (err error) {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			accountCodes[location.ID()] = code
+			return nil
+		},
+		emitEvent: func(event cadence.Event) error {
+			events = append(events, event)
+			return nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	// Set up the storage
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: []byte(`
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      signer.save(1, to: /storage/a)
+                      signer.save("x", to: /storage/b)
+                  }
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	storedValues := make(map[string][]byte, len(ledger.storedValues))
+	for key, value := range ledger.storedValues {
+		storedValues[key] = value
+	}
+
+	// Simulate a transaction which changes the storage and deploys a contract
+
+	contractCode := []byte(`
+      pub contract C {
+
+          pub event Deployed()
+
+          init() {
+              emit Deployed()
+          }
+      }
+    `)
+
+	simulation, err := runtime.SimulateTransaction(
+		Script{
+			Source: []byte(fmt.Sprintf(
+				`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          signer.load<Int>(from: /storage/a)
+                          signer.load<String>(from: /storage/b)
+                          signer.save("y", to: /storage/b)
+                          signer.save(2, to: /storage/c)
+                          signer.contracts.add(name: "C", code: "%s".decodeHex())
+                      }
+                  }
+                `,
+				hex.EncodeToString(contractCode),
+			)),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]StorageChange{
+			{
+				Address: signerAddress,
+				Path: cadence.Path{
+					Domain:     "storage",
+					Identifier: "a",
+				},
+				Before: cadence.NewInt(1),
+				After:  nil,
+			},
+			{
+				Address: signerAddress,
+				Path: cadence.Path{
+					Domain:     "storage",
+					Identifier: "b",
+				},
+				Before: cadence.String("x"),
+				After:  cadence.String("y"),
+			},
+			{
+				Address: signerAddress,
+				Path: cadence.Path{
+					Domain:     "storage",
+					Identifier: "c",
+				},
+				Before: nil,
+				After:  cadence.NewInt(2),
+			},
+		},
+		simulation.StorageChanges,
+	)
+
+	assert.Equal(t,
+		[]ContractChange{
+			{
+				Address: signerAddress,
+				Name:    "C",
+				Before:  nil,
+				After:   contractCode,
+			},
+		},
+		simulation.ContractChanges,
+	)
+
+	require.Len(t, simulation.Events, 2)
+	assert.Equal(t,
+		"A.0000000000000001.C.Deployed",
+		simulation.Events[0].EventType.ID(),
+	)
+	assert.Equal(t,
+		"flow.AccountContractAdded",
+		simulation.Events[1].EventType.ID(),
+	)
+
+	assert.Greater(t, simulation.Computation[common.ComputationKindStatement], uint(0))
+
+	// Nothing was committed
+
+	assert.Equal(t, storedValues, ledger.storedValues)
+	assert.Empty(t, accountCodes)
+	assert.Empty(t, events)
+}
+
+func TestRuntimeSimulateTransactionFailure(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return nil, nil
+		},
+	}
+
+	simulation, err := runtime.SimulateTransaction(
+		Script{
+			Source: []byte(`
+              transaction {
+                  execute {
+                      panic("simulated")
+                  }
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  newTransactionLocationGenerator()(),
+		},
+	)
+	require.Error(t, err)
+	assert.Nil(t, simulation)
+}
+
+func TestRuntimeSimulateTransactionWithoutSideEffects(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	signerAddress := common.MustBytesToAddress([]byte{0x1})
+
+	accountCodes := map[common.LocationID][]byte{}
+
+	ledger := newTestLedger(nil, nil)
+
+	runtimeInterface := &testRuntimeInterface{
+		getCode: func(location Location) (bytes []byte, err error) {
+			return accountCodes[location.ID()], nil
+		},
+		storage: ledger,
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{signerAddress}, nil
+		},
+		resolveLocation: singleIdentifierLocationResolver(t),
+		getAccountContractCode: func(address Address, name string) (code []byte, err error) {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			return accountCodes[location.ID()], nil
+		},
+		updateAccountContractCode: func(address Address, name string, code []byte) (err error) {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			accountCodes[location.ID()] = code
+			return nil
+		},
+		emitEvent: func(event cadence.Event) error {
+			return nil
+		},
+		validatePublicKey: func(publicKey *PublicKey) error {
+			return nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	// Deploy a contract which declares a resource
+
+	contractCode := []byte(`
+      pub contract C {
+
+          pub resource R {}
+
+          pub fun createR(): @R {
+              return <-create R()
+          }
+      }
+    `)
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: []byte(fmt.Sprintf(
+				`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          signer.contracts.add(name: "C", code: "%s".decodeHex())
+                      }
+                  }
+                `,
+				hex.EncodeToString(contractCode),
+			)),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	storedValues := make(map[string][]byte, len(ledger.storedValues))
+	for key, value := range ledger.storedValues {
+		storedValues[key] = value
+	}
+
+	// Any mutating call of the wrapped interface fails the test
+
+	ledger.allocateStorageIndex = func(owner []byte) (atree.StorageIndex, error) {
+		t.Error("unexpected storage index allocation")
+		return atree.StorageIndex{}, nil
+	}
+	runtimeInterface.generateUUID = func() (uint64, error) {
+		t.Error("unexpected UUID generation")
+		return 0, nil
+	}
+	runtimeInterface.createAccount = func(payer Address) (address Address, err error) {
+		t.Error("unexpected account creation")
+		return Address{}, nil
+	}
+	runtimeInterface.addAccountKey = func(
+		address Address,
+		publicKey *PublicKey,
+		hashAlgo HashAlgorithm,
+		weight int,
+	) (*AccountKey, error) {
+		t.Error("unexpected account key addition")
+		return nil, nil
+	}
+
+	simulation, err := runtime.SimulateTransaction(
+		Script{
+			Source: []byte(`
+              import C from 0x1
+
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      signer.save(<-C.createR(), to: /storage/r)
+
+                      let account = AuthAccount(payer: signer)
+                      let key = account.keys.add(
+                          publicKey: PublicKey(
+                              publicKey: "0102".decodeHex(),
+                              signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                          ),
+                          hashAlgorithm: HashAlgorithm.SHA3_256,
+                          weight: 1000.0
+                      )
+                      assert(key.keyIndex == 0)
+                      assert(account.keys.get(keyIndex: 0) != nil)
+
+                      account.save(1, to: /storage/x)
+
+                      log(account.address)
+                  }
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	require.Len(t, simulation.CreatedAccounts, 1)
+	createdAddress := simulation.CreatedAccounts[0]
+
+	assert.Equal(t,
+		[]string{createdAddress.HexWithPrefix()},
+		simulation.Logs,
+	)
+
+	require.Len(t, simulation.StorageChanges, 2)
+
+	assert.Equal(t, signerAddress, simulation.StorageChanges[0].Address)
+	assert.Equal(t, "r", simulation.StorageChanges[0].Path.Identifier)
+	assert.Nil(t, simulation.StorageChanges[0].Before)
+
+	assert.Equal(t,
+		StorageChange{
+			Address: createdAddress,
+			Path: cadence.Path{
+				Domain:     "storage",
+				Identifier: "x",
+			},
+			Before: nil,
+			After:  cadence.NewInt(1),
+		},
+		simulation.StorageChanges[1],
+	)
+
+	// Nothing was committed
+
+	assert.Equal(t, storedValues, ledger.storedValues)
+}
@@ -34,6 +34,7 @@ The message argument is optional.
 `
 
 var assertFunctionType = &sema.FunctionType{
+	Purity: sema.FunctionPurityBuiltinView,
 	Parameters: []*sema.Parameter{
 		{
 			Label:          sema.ArgumentLabelNotRequired,
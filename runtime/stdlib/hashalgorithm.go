@@ -49,6 +49,8 @@ var hashAlgorithmHashFunction = interpreter.NewHostFunctionValue(
 
 		inter := invocation.Interpreter
 
+		inter.ReportComputation(common.ComputationKindSTDLIBHash, uint(dataValue.Count()))
+
 		getLocationRange := invocation.GetLocationRange
 
 		inter.ExpectType(
@@ -84,6 +86,11 @@ var hashAlgorithmHashWithTagFunction = interpreter.NewHostFunctionValue(
 
 		inter := invocation.Interpreter
 
+		inter.ReportComputation(
+			common.ComputationKindSTDLIBHash,
+			uint(dataValue.Count()+len(tagValue.Str)),
+		)
+
 		getLocationRange := invocation.GetLocationRange
 
 		inter.ExpectType(
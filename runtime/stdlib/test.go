@@ -0,0 +1,976 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib/contracts"
+)
+
+// TestFramework is the host of the Test contract.
+// It provides the blockchains tests are run against,
+// and access to the files of the tested project.
+//
+type TestFramework interface {
+	// NewEmulatorBackend returns a new, empty blockchain.
+	NewEmulatorBackend() TestBlockchainBackend
+	// ReadFile returns the content of the file at the given path.
+	ReadFile(path string) (string, error)
+}
+
+// TestBlockchainBackend is a blockchain tests deploy contracts to,
+// and execute scripts and transactions against.
+//
+// Values are passed from and to the test program's interpreter,
+// which is passed to every function.
+//
+type TestBlockchainBackend interface {
+	// CreateAccount creates a new account.
+	CreateAccount() (common.Address, error)
+	// ExecuteScript executes the given script and returns its result.
+	ExecuteScript(
+		inter *interpreter.Interpreter,
+		script string,
+		arguments []interpreter.Value,
+	) (interpreter.Value, error)
+	// ExecuteTransaction executes the given transaction, signed and authorized by the given accounts.
+	ExecuteTransaction(
+		inter *interpreter.Interpreter,
+		transaction string,
+		signers []common.Address,
+		arguments []interpreter.Value,
+	) error
+	// DeployContract deploys the given contract code to the given account.
+	DeployContract(
+		inter *interpreter.Interpreter,
+		name string,
+		code string,
+		account common.Address,
+		arguments []interpreter.Value,
+	) error
+	// Events returns all events emitted so far, in order.
+	Events(inter *interpreter.Interpreter) ([]TestEvent, error)
+	// CommitBlock commits the current block and starts a new one.
+	CommitBlock()
+}
+
+// TestEvent is an event emitted on a TestBlockchainBackend.
+//
+type TestEvent struct {
+	TypeID string
+	Fields []TestEventField
+}
+
+type TestEventField struct {
+	Name  string
+	Value interpreter.Value
+}
+
+// TestContractLocation is the location of the Test contract.
+//
+var TestContractLocation = common.IdentifierLocation("Test")
+
+var TestChecker = func() *sema.Checker {
+
+	program, err := parser2.ParseProgram(contracts.Test, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	var checker *sema.Checker
+	checker, err = sema.NewChecker(
+		program,
+		TestContractLocation,
+		sema.WithPredeclaredValues(BuiltinFunctions.ToSemaValueDeclarations()),
+		sema.WithPredeclaredTypes(BuiltinTypes.ToTypeDeclarations()),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	err = checker.Check()
+	if err != nil {
+		panic(err)
+	}
+
+	return checker
+}()
+
+const testContractTypeName = "Test"
+const testBlockchainTypeName = "Blockchain"
+const testBlockchainBackendTypeName = "BlockchainBackend"
+const testEmulatorBackendTypeName = "EmulatorBackend"
+const testScriptResultTypeName = "ScriptResult"
+const testTransactionResultTypeName = "TransactionResult"
+const testErrorTypeName = "Error"
+const testAccountTypeName = "Account"
+const testEventTypeName = "Event"
+
+var testContractType = func() *sema.CompositeType {
+	variable, ok := TestChecker.Elaboration.GlobalTypes.Get(testContractTypeName)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+	return variable.Type.(*sema.CompositeType)
+}()
+
+func init() {
+	// Declare the native functions of the Test contract.
+	//
+	// NOTE: The functions are added to the type after the contract was checked,
+	// so the contract itself must not access its own members,
+	// as the members of a type are only resolved once.
+
+	for _, function := range testFunctions {
+		testContractType.Members.Set(
+			function.name,
+			sema.NewPublicFunctionMember(
+				testContractType,
+				function.name,
+				function.functionType,
+				function.docString,
+			),
+		)
+	}
+}
+
+var testContractInitializerTypes = func() (result []sema.Type) {
+	result = make([]sema.Type, len(testContractType.ConstructorParameters))
+	for i, parameter := range testContractType.ConstructorParameters {
+		result[i] = parameter.TypeAnnotation.Type
+	}
+	return result
+}()
+
+func testNestedType(name string) sema.Type {
+	nestedType, ok := testContractType.GetNestedTypes().Get(name)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+	return nestedType
+}
+
+func testNestedCompositeType(name string) *sema.CompositeType {
+	return testNestedType(name).(*sema.CompositeType)
+}
+
+var testBlockchainBackendType = testNestedType(testBlockchainBackendTypeName).(*sema.InterfaceType)
+
+var testBlockchainBackendFunctionNames = []string{
+	"createAccount",
+	"executeScript",
+	"executeTransaction",
+	"deployContract",
+	"events",
+	"commitBlock",
+}
+
+// testEmulatorBackendType is the type of the native implementation
+// of the BlockchainBackend interface, `Test.EmulatorBackend`.
+//
+var testEmulatorBackendType = func() *sema.CompositeType {
+	ty := &sema.CompositeType{
+		Location:                      TestContractLocation,
+		Identifier:                    testEmulatorBackendTypeName,
+		Kind:                          common.CompositeKindStructure,
+		ExplicitInterfaceConformances: []*sema.InterfaceType{testBlockchainBackendType},
+	}
+	ty.SetContainerType(testContractType)
+
+	members := make([]*sema.Member, len(testBlockchainBackendFunctionNames))
+	for i, name := range testBlockchainBackendFunctionNames {
+		members[i] = sema.NewPublicFunctionMember(
+			ty,
+			name,
+			testBlockchainBackendFunctionType(name),
+			"",
+		)
+	}
+	ty.Members = sema.GetMembersAsMap(members)
+
+	// Declare the type, so it can be loaded by the interpreter
+
+	testContractType.GetNestedTypes().Set(testEmulatorBackendTypeName, ty)
+	TestChecker.Elaboration.CompositeTypes[ty.ID()] = ty
+
+	return ty
+}()
+
+func testBlockchainBackendFunctionType(name string) *sema.FunctionType {
+	member, ok := testBlockchainBackendType.Members.Get(name)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+	return member.TypeAnnotation.Type.(*sema.FunctionType)
+}
+
+type testFunction struct {
+	name         string
+	functionType *sema.FunctionType
+	docString    string
+}
+
+var testFunctions = []testFunction{
+	{
+		name:         testNewEmulatorBlockchainFunctionName,
+		functionType: testNewEmulatorBlockchainFunctionType,
+		docString:    testNewEmulatorBlockchainFunctionDocString,
+	},
+	{
+		name:         testAssertFunctionName,
+		functionType: testAssertFunctionType,
+		docString:    testAssertFunctionDocString,
+	},
+	{
+		name:         testFailFunctionName,
+		functionType: testFailFunctionType,
+		docString:    testFailFunctionDocString,
+	},
+	{
+		name:         testAssertEqualFunctionName,
+		functionType: testAssertEqualFunctionType,
+		docString:    testAssertEqualFunctionDocString,
+	},
+	{
+		name:         testExpectFailureFunctionName,
+		functionType: testExpectFailureFunctionType,
+		docString:    testExpectFailureFunctionDocString,
+	},
+	{
+		name:         testReadFileFunctionName,
+		functionType: testReadFileFunctionType,
+		docString:    testReadFileFunctionDocString,
+	},
+}
+
+// NewTestContract instantiates the Test contract
+// and links in its native functions, which are backed by the given framework.
+//
+func NewTestContract(
+	inter *interpreter.Interpreter,
+	framework TestFramework,
+	constructor interpreter.FunctionValue,
+	invocationRange ast.Range,
+) (
+	*interpreter.CompositeValue,
+	error,
+) {
+	value, err := inter.InvokeFunctionValue(
+		constructor,
+		nil,
+		testContractInitializerTypes,
+		testContractInitializerTypes,
+		invocationRange,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	compositeValue := value.(*interpreter.CompositeValue)
+
+	// NOTE: copy the functions, they are shared by all values of the type
+
+	functions := make(map[string]interpreter.FunctionValue, len(compositeValue.Functions)+len(testFunctions))
+	for name, function := range compositeValue.Functions {
+		functions[name] = function
+	}
+
+	functions[testNewEmulatorBlockchainFunctionName] =
+		newTestNewEmulatorBlockchainFunction(framework, compositeValue)
+	functions[testAssertFunctionName] = testAssertFunction
+	functions[testFailFunctionName] = testFailFunction
+	functions[testAssertEqualFunctionName] = testAssertEqualFunction
+	functions[testExpectFailureFunctionName] = testExpectFailureFunction
+	functions[testReadFileFunctionName] = newTestReadFileFunction(framework)
+
+	compositeValue.Functions = functions
+
+	return compositeValue, nil
+}
+
+// newTestStructValue constructs a value of the struct type with the given name,
+// which is nested in the Test contract, by invoking its constructor.
+//
+func newTestStructValue(
+	inter *interpreter.Interpreter,
+	getLocationRange func() interpreter.LocationRange,
+	contract *interpreter.CompositeValue,
+	typeName string,
+	arguments ...interpreter.Value,
+) interpreter.Value {
+
+	variable, ok := contract.NestedVariables[typeName]
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	constructor, ok := variable.GetValue().(interpreter.FunctionValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	compositeType := testNestedCompositeType(typeName)
+
+	parameterTypes := make([]sema.Type, len(compositeType.ConstructorParameters))
+	for i, parameter := range compositeType.ConstructorParameters {
+		parameterTypes[i] = parameter.TypeAnnotation.Type
+	}
+
+	value, err := inter.InvokeFunctionValue(
+		constructor,
+		arguments,
+		parameterTypes,
+		parameterTypes,
+		getLocationRange(),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return value
+}
+
+func newTestErrorValue(
+	inter *interpreter.Interpreter,
+	getLocationRange func() interpreter.LocationRange,
+	contract *interpreter.CompositeValue,
+	err error,
+) interpreter.OptionalValue {
+
+	if err == nil {
+		return interpreter.NilValue{}
+	}
+
+	return interpreter.NewSomeValueNonCopying(
+		newTestStructValue(
+			inter,
+			getLocationRange,
+			contract,
+			testErrorTypeName,
+			interpreter.NewUnmeteredStringValue(err.Error()),
+		),
+	)
+}
+
+// Test.newEmulatorBlockchain
+
+const testNewEmulatorBlockchainFunctionName = "newEmulatorBlockchain"
+
+const testNewEmulatorBlockchainFunctionDocString = `
+Returns a new, empty blockchain, which is backed by an emulator.
+`
+
+var testNewEmulatorBlockchainFunctionType = &sema.FunctionType{
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		testNestedType(testBlockchainTypeName),
+	),
+}
+
+func newTestNewEmulatorBlockchainFunction(
+	framework TestFramework,
+	contract *interpreter.CompositeValue,
+) *interpreter.HostFunctionValue {
+	return interpreter.NewHostFunctionValue(
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			getLocationRange := invocation.GetLocationRange
+
+			backend := newTestEmulatorBackendValue(
+				contract,
+				framework.NewEmulatorBackend(),
+			)
+
+			return newTestStructValue(
+				inter,
+				getLocationRange,
+				contract,
+				testBlockchainTypeName,
+				backend,
+			)
+		},
+		testNewEmulatorBlockchainFunctionType,
+	)
+}
+
+// Test.assert
+
+const testAssertFunctionName = "assert"
+
+const testAssertFunctionDocString = `
+Fails the test if the given condition is false, and reports a message which explains how the condition is false.
+
+The message argument is optional.
+`
+
+var testAssertFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "condition",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.BoolType),
+		},
+		{
+			Identifier:     "message",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.VoidType,
+	),
+	RequiredArgumentCount: sema.RequiredArgumentCount(1),
+}
+
+var testAssertFunction = interpreter.NewHostFunctionValue(
+	func(invocation interpreter.Invocation) interpreter.Value {
+		condition, ok := invocation.Arguments[0].(interpreter.BoolValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		if !condition {
+			panic(AssertionError{
+				Message:       testOptionalMessage(invocation, 1),
+				LocationRange: invocation.GetLocationRange(),
+			})
+		}
+
+		return interpreter.VoidValue{}
+	},
+	testAssertFunctionType,
+)
+
+func testOptionalMessage(invocation interpreter.Invocation, index int) string {
+	if len(invocation.Arguments) <= index {
+		return ""
+	}
+
+	messageValue, ok := invocation.Arguments[index].(*interpreter.StringValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	return messageValue.Str
+}
+
+// Test.fail
+
+const testFailFunctionName = "fail"
+
+const testFailFunctionDocString = `
+Fails the test, and reports a message which explains why the test failed.
+
+The message argument is optional.
+`
+
+var testFailFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Identifier:     "message",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.VoidType,
+	),
+	RequiredArgumentCount: sema.RequiredArgumentCount(0),
+}
+
+var testFailFunction = interpreter.NewHostFunctionValue(
+	func(invocation interpreter.Invocation) interpreter.Value {
+		panic(AssertionError{
+			Message:       testOptionalMessage(invocation, 0),
+			LocationRange: invocation.GetLocationRange(),
+		})
+	},
+	testFailFunctionType,
+)
+
+// Test.assertEqual
+
+const testAssertEqualFunctionName = "assertEqual"
+
+const testAssertEqualFunctionDocString = `
+Fails the test if the given values are not equal.
+Values of different types are never equal.
+`
+
+var testAssertEqualFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "expected",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.AnyStructType),
+		},
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "actual",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.AnyStructType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.VoidType,
+	),
+}
+
+var testAssertEqualFunction = interpreter.NewHostFunctionValue(
+	func(invocation interpreter.Invocation) interpreter.Value {
+		expected := invocation.Arguments[0]
+		actual := invocation.Arguments[1]
+
+		getLocationRange := invocation.GetLocationRange
+
+		equatableExpected, ok := expected.(interpreter.EquatableValue)
+		if !ok || !equatableExpected.Equal(invocation.Interpreter, getLocationRange, actual) {
+			panic(AssertionError{
+				Message: fmt.Sprintf(
+					"not equal: expected: %s, actual: %s",
+					expected,
+					actual,
+				),
+				LocationRange: getLocationRange(),
+			})
+		}
+
+		return interpreter.VoidValue{}
+	},
+	testAssertEqualFunctionType,
+)
+
+// Test.expectFailure
+
+const testExpectFailureFunctionName = "expectFailure"
+
+const testExpectFailureFunctionDocString = `
+Fails the test if calling the given function does not fail,
+or if the error message of the failure does not contain the given substring.
+`
+
+var testExpectFailureFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:      sema.ArgumentLabelNotRequired,
+			Identifier: "functionWrapper",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.FunctionType{
+					ReturnTypeAnnotation: sema.NewTypeAnnotation(
+						sema.VoidType,
+					),
+				},
+			),
+		},
+		{
+			Identifier:     "errorMessageSubstring",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.VoidType,
+	),
+}
+
+var testExpectFailureFunction = interpreter.NewHostFunctionValue(
+	func(invocation interpreter.Invocation) interpreter.Value {
+		functionValue, ok := invocation.Arguments[0].(interpreter.FunctionValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		substringValue, ok := invocation.Arguments[1].(*interpreter.StringValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		getLocationRange := invocation.GetLocationRange
+
+		_, err := invocation.Interpreter.InvokeFunctionValue(
+			functionValue,
+			nil,
+			nil,
+			nil,
+			getLocationRange(),
+		)
+
+		if err == nil {
+			panic(AssertionError{
+				Message:       "expected a failure, but none occurred",
+				LocationRange: getLocationRange(),
+			})
+		}
+
+		if !strings.Contains(err.Error(), substringValue.Str) {
+			panic(AssertionError{
+				Message: fmt.Sprintf(
+					"expected the error message to contain %q, got: %s",
+					substringValue.Str,
+					err.Error(),
+				),
+				LocationRange: getLocationRange(),
+			})
+		}
+
+		return interpreter.VoidValue{}
+	},
+	testExpectFailureFunctionType,
+)
+
+// Test.readFile
+
+const testReadFileFunctionName = "readFile"
+
+const testReadFileFunctionDocString = `
+Returns the content of the file at the given path.
+`
+
+var testReadFileFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "path",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.StringType,
+	),
+}
+
+func newTestReadFileFunction(framework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewHostFunctionValue(
+		func(invocation interpreter.Invocation) interpreter.Value {
+			pathValue, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			content, err := framework.ReadFile(pathValue.Str)
+			if err != nil {
+				panic(err)
+			}
+
+			return interpreter.NewUnmeteredStringValue(content)
+		},
+		testReadFileFunctionType,
+	)
+}
+
+// Test.EmulatorBackend
+
+var testEmulatorBackendStaticType = interpreter.ConvertSemaCompositeTypeToStaticCompositeType(testEmulatorBackendType)
+
+var testEmulatorBackendDynamicType interpreter.DynamicType = interpreter.CompositeDynamicType{
+	StaticType: testEmulatorBackendType,
+}
+
+// newTestEmulatorBackendValue constructs the native implementation of the `BlockchainBackend`
+// interface, `Test.EmulatorBackend`.
+//
+// The value is a simple composite value, so its host functions are kept
+// when the value is transferred, e.g. into the `backend` field of a `Blockchain`.
+//
+func newTestEmulatorBackendValue(
+	contract *interpreter.CompositeValue,
+	backend TestBlockchainBackend,
+) *interpreter.SimpleCompositeValue {
+
+	fields := map[string]interpreter.Value{
+		"createAccount":      newTestEmulatorBackendCreateAccountFunction(contract, backend),
+		"executeScript":      newTestEmulatorBackendExecuteScriptFunction(contract, backend),
+		"executeTransaction": newTestEmulatorBackendExecuteTransactionFunction(contract, backend),
+		"deployContract":     newTestEmulatorBackendDeployContractFunction(contract, backend),
+		"events":             newTestEmulatorBackendEventsFunction(contract, backend),
+		"commitBlock":        newTestEmulatorBackendCommitBlockFunction(backend),
+	}
+
+	return interpreter.NewSimpleCompositeValue(
+		testEmulatorBackendType.ID(),
+		testEmulatorBackendStaticType,
+		testEmulatorBackendDynamicType,
+		nil,
+		fields,
+		nil,
+		nil,
+		nil,
+	)
+}
+
+func testArrayElements(value interpreter.Value) []interpreter.Value {
+	arrayValue, ok := value.(*interpreter.ArrayValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	elements := make([]interpreter.Value, 0, arrayValue.Count())
+	arrayValue.Iterate(func(element interpreter.Value) (resume bool) {
+		elements = append(elements, element)
+
+		// Continue iteration
+		return true
+	})
+	return elements
+}
+
+func testStringField(
+	inter *interpreter.Interpreter,
+	getLocationRange func() interpreter.LocationRange,
+	value *interpreter.CompositeValue,
+	name string,
+) string {
+	stringValue, ok := value.GetField(inter, getLocationRange, name).(*interpreter.StringValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+	return stringValue.Str
+}
+
+func testAccountAddress(
+	inter *interpreter.Interpreter,
+	getLocationRange func() interpreter.LocationRange,
+	value interpreter.Value,
+) common.Address {
+	accountValue, ok := value.(*interpreter.CompositeValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	addressValue, ok := accountValue.GetField(inter, getLocationRange, "address").(interpreter.AddressValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	return addressValue.ToAddress()
+}
+
+func newTestEmulatorBackendCreateAccountFunction(
+	contract *interpreter.CompositeValue,
+	backend TestBlockchainBackend,
+) *interpreter.HostFunctionValue {
+	return interpreter.NewHostFunctionValue(
+		func(invocation interpreter.Invocation) interpreter.Value {
+			address, err := backend.CreateAccount()
+			if err != nil {
+				panic(err)
+			}
+
+			return newTestStructValue(
+				invocation.Interpreter,
+				invocation.GetLocationRange,
+				contract,
+				testAccountTypeName,
+				interpreter.NewAddressValue(address),
+			)
+		},
+		testBlockchainBackendFunctionType("createAccount"),
+	)
+}
+
+func newTestEmulatorBackendExecuteScriptFunction(
+	contract *interpreter.CompositeValue,
+	backend TestBlockchainBackend,
+) *interpreter.HostFunctionValue {
+	return interpreter.NewHostFunctionValue(
+		func(invocation interpreter.Invocation) interpreter.Value {
+			scriptValue, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			arguments := testArrayElements(invocation.Arguments[1])
+
+			inter := invocation.Interpreter
+			getLocationRange := invocation.GetLocationRange
+
+			value, err := backend.ExecuteScript(inter, scriptValue.Str, arguments)
+
+			var returnValue interpreter.OptionalValue = interpreter.NilValue{}
+			if err == nil {
+				returnValue = interpreter.NewSomeValueNonCopying(value)
+			}
+
+			return newTestStructValue(
+				inter,
+				getLocationRange,
+				contract,
+				testScriptResultTypeName,
+				returnValue,
+				newTestErrorValue(inter, getLocationRange, contract, err),
+			)
+		},
+		testBlockchainBackendFunctionType("executeScript"),
+	)
+}
+
+func newTestEmulatorBackendExecuteTransactionFunction(
+	contract *interpreter.CompositeValue,
+	backend TestBlockchainBackend,
+) *interpreter.HostFunctionValue {
+	return interpreter.NewHostFunctionValue(
+		func(invocation interpreter.Invocation) interpreter.Value {
+			transactionValue, ok := invocation.Arguments[0].(*interpreter.CompositeValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+			getLocationRange := invocation.GetLocationRange
+
+			code := testStringField(inter, getLocationRange, transactionValue, "code")
+
+			signerValues := testArrayElements(
+				transactionValue.GetField(inter, getLocationRange, "signers"),
+			)
+			signers := make([]common.Address, len(signerValues))
+			for i, signerValue := range signerValues {
+				signers[i] = testAccountAddress(inter, getLocationRange, signerValue)
+			}
+
+			arguments := testArrayElements(
+				transactionValue.GetField(inter, getLocationRange, "arguments"),
+			)
+
+			err := backend.ExecuteTransaction(inter, code, signers, arguments)
+
+			return newTestStructValue(
+				inter,
+				getLocationRange,
+				contract,
+				testTransactionResultTypeName,
+				newTestErrorValue(inter, getLocationRange, contract, err),
+			)
+		},
+		testBlockchainBackendFunctionType("executeTransaction"),
+	)
+}
+
+func newTestEmulatorBackendDeployContractFunction(
+	contract *interpreter.CompositeValue,
+	backend TestBlockchainBackend,
+) *interpreter.HostFunctionValue {
+	return interpreter.NewHostFunctionValue(
+		func(invocation interpreter.Invocation) interpreter.Value {
+			nameValue, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			codeValue, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+			getLocationRange := invocation.GetLocationRange
+
+			account := testAccountAddress(inter, getLocationRange, invocation.Arguments[2])
+
+			arguments := testArrayElements(invocation.Arguments[3])
+
+			err := backend.DeployContract(
+				inter,
+				nameValue.Str,
+				codeValue.Str,
+				account,
+				arguments,
+			)
+
+			return newTestErrorValue(inter, getLocationRange, contract, err)
+		},
+		testBlockchainBackendFunctionType("deployContract"),
+	)
+}
+
+func newTestEmulatorBackendEventsFunction(
+	contract *interpreter.CompositeValue,
+	backend TestBlockchainBackend,
+) *interpreter.HostFunctionValue {
+	return interpreter.NewHostFunctionValue(
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			getLocationRange := invocation.GetLocationRange
+
+			events, err := backend.Events(inter)
+			if err != nil {
+				panic(err)
+			}
+
+			eventValues := make([]interpreter.Value, len(events))
+
+			for i, event := range events {
+
+				keysAndValues := make([]interpreter.Value, 0, len(event.Fields)*2)
+				for _, field := range event.Fields {
+					keysAndValues = append(
+						keysAndValues,
+						interpreter.NewUnmeteredStringValue(field.Name),
+						field.Value,
+					)
+				}
+
+				fieldsValue := interpreter.NewDictionaryValue(
+					inter,
+					interpreter.DictionaryStaticType{
+						KeyType:   interpreter.PrimitiveStaticTypeString,
+						ValueType: interpreter.PrimitiveStaticTypeAnyStruct,
+					},
+					keysAndValues...,
+				)
+
+				eventValues[i] = newTestStructValue(
+					inter,
+					getLocationRange,
+					contract,
+					testEventTypeName,
+					interpreter.NewUnmeteredStringValue(event.TypeID),
+					fieldsValue,
+				)
+			}
+
+			return interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.ConvertSemaToStaticType(
+						testNestedType(testEventTypeName),
+					),
+				},
+				common.Address{},
+				eventValues...,
+			)
+		},
+		testBlockchainBackendFunctionType("events"),
+	)
+}
+
+func newTestEmulatorBackendCommitBlockFunction(
+	backend TestBlockchainBackend,
+) *interpreter.HostFunctionValue {
+	return interpreter.NewHostFunctionValue(
+		func(invocation interpreter.Invocation) interpreter.Value {
+			backend.CommitBlock()
+			return interpreter.VoidValue{}
+		},
+		testBlockchainBackendFunctionType("commitBlock"),
+	)
+}
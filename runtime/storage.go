@@ -42,7 +42,45 @@ type Storage struct {
 var _ atree.SlabStorage = &Storage{}
 var _ interpreter.Storage = &Storage{}
 
-func NewStorage(ledger atree.Ledger) *Storage {
+// meteringLedger is a ledger which reports the number of bytes read and written
+// as computation to the given function
+//
+type meteringLedger struct {
+	atree.Ledger
+	meterComputation interpreter.OnMeterComputationFunc
+}
+
+var _ atree.Ledger = meteringLedger{}
+
+func (l meteringLedger) GetValue(owner, key []byte) ([]byte, error) {
+	value, err := l.Ledger.GetValue(owner, key)
+	if err != nil {
+		return nil, err
+	}
+
+	l.meterComputation(common.ComputationKindStorageRead, uint(len(value)))
+
+	return value, nil
+}
+
+func (l meteringLedger) SetValue(owner, key, value []byte) error {
+	l.meterComputation(common.ComputationKindStorageWrite, uint(len(value)))
+
+	return l.Ledger.SetValue(owner, key, value)
+}
+
+// NewStorage returns a new storage which is backed by the given ledger.
+// If meterComputation is not nil, the number of bytes read from and written to the ledger
+// is reported to it as computation, like the interpreter reports its computation
+//
+func NewStorage(ledger atree.Ledger, meterComputation interpreter.OnMeterComputationFunc) *Storage {
+	if meterComputation != nil {
+		ledger = meteringLedger{
+			Ledger:           ledger,
+			meterComputation: meterComputation,
+		}
+	}
+
 	ledgerStorage := atree.NewLedgerBaseStorage(ledger)
 	persistentSlabStorage := atree.NewPersistentSlabStorage(
 		ledgerStorage,
@@ -62,7 +100,17 @@ func NewStorage(ledger atree.Ledger) *Storage {
 
 const storageIndexLength = 8
 
-func (s *Storage) GetStorageMap(address common.Address, domain string) (storageMap *interpreter.StorageMap) {
+// GetStorageMap returns the storage map for the given domain of the given account.
+// If the account has no storage map for the domain yet,
+// a new one is created if createIfNotExists is true, otherwise nil is returned
+//
+func (s *Storage) GetStorageMap(
+	address common.Address,
+	domain string,
+	createIfNotExists bool,
+) (
+	storageMap *interpreter.StorageMap,
+) {
 	key := interpreter.StorageKey{
 		Address: address,
 		Key:     domain,
@@ -100,11 +148,13 @@ func (s *Storage) GetStorageMap(address common.Address, domain string) (storageM
 			var storageIndex atree.StorageIndex
 			copy(storageIndex[:], data[:])
 			storageMap = s.loadExistingStorageMap(atreeAddress, storageIndex)
-		} else {
+		} else if createIfNotExists {
 			storageMap = s.storeNewStorageMap(atreeAddress, domain)
 		}
 
-		s.storageMaps[key] = storageMap
+		if storageMap != nil {
+			s.storageMaps[key] = storageMap
+		}
 	}
 
 	return storageMap
@@ -213,7 +263,7 @@ func (s *Storage) writeContractUpdate(
 	key interpreter.StorageKey,
 	contractValue *interpreter.CompositeValue,
 ) {
-	storageMap := s.GetStorageMap(key.Address, StorageDomainContract)
+	storageMap := s.GetStorageMap(key.Address, StorageDomainContract, true)
 	// NOTE: pass nil instead of allocating a Value-typed  interface that points to nil
 	if contractValue == nil {
 		storageMap.WriteValue(inter, key.Key, nil)
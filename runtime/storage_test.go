@@ -46,7 +46,7 @@ func withWritesToStorage(
 	handler func(*Storage, *interpreter.Interpreter),
 ) {
 	ledger := newTestLedger(nil, onWrite)
-	storage := NewStorage(ledger)
+	storage := NewStorage(ledger, nil)
 
 	inter := newTestInterpreter(tb)
 
@@ -732,6 +732,76 @@ func TestRuntimeStorageReadAndBorrow(t *testing.T) {
 	})
 }
 
+func TestRuntimeStorageMetering(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	storage := newTestLedger(nil, nil)
+
+	signer := common.MustBytesToAddress([]byte{0x42})
+
+	computation := map[common.ComputationKind]uint{}
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: storage,
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{signer}, nil
+		},
+		meterComputation: func(compKind common.ComputationKind, intensity uint) error {
+			computation[compKind] += intensity
+			return nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	// Store a value
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: []byte(`
+              transaction {
+                 prepare(signer: AuthAccount) {
+                     signer.save("hello world", to: /storage/test)
+                 }
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	assert.NotZero(t, computation[common.ComputationKindStorageWrite])
+
+	// Load the value
+
+	computation = map[common.ComputationKind]uint{}
+
+	err = runtime.ExecuteTransaction(
+		Script{
+			Source: []byte(`
+              transaction {
+                 prepare(signer: AuthAccount) {
+                     signer.load<String>(from: /storage/test)
+                 }
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	assert.NotZero(t, computation[common.ComputationKindStorageRead])
+}
+
 func TestRuntimeTopShotContractDeployment(t *testing.T) {
 
 	t.Parallel()
@@ -1200,6 +1200,87 @@ func TestCheckAccount_getLinkTarget(t *testing.T) {
 	}
 }
 
+func TestCheckAccount_forEach(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(accountVariable string, functionName string, pathType sema.Type, valid bool) {
+
+		testName := fmt.Sprintf(
+			"%s.%s",
+			accountVariable,
+			functionName,
+		)
+
+		t.Run(testName, func(t *testing.T) {
+
+			t.Parallel()
+
+			_, err := ParseAndCheckAccount(t,
+				fmt.Sprintf(
+					`
+                      fun test() {
+                          %s.%s(fun (path: %s, type: Type): Bool {
+                              return true
+                          })
+                      }
+                    `,
+					accountVariable,
+					functionName,
+					pathType,
+				),
+			)
+
+			if valid {
+				require.NoError(t, err)
+			} else {
+				errs := ExpectCheckerErrors(t, err, 1)
+
+				require.IsType(t, &sema.NotDeclaredMemberError{}, errs[0])
+			}
+		})
+	}
+
+	test("authAccount", "forEachStored", sema.StoragePathType, true)
+	test("authAccount", "forEachPublic", sema.PublicPathType, true)
+	test("authAccount", "forEachPrivate", sema.PrivatePathType, true)
+	test("publicAccount", "forEachStored", sema.StoragePathType, false)
+	test("publicAccount", "forEachPublic", sema.PublicPathType, true)
+	test("publicAccount", "forEachPrivate", sema.PrivatePathType, false)
+
+	t.Run("invalid path type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t, `
+          fun test() {
+              authAccount.forEachStored(fun (path: PublicPath, type: Type): Bool {
+                  return true
+              })
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("invalid return type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t, `
+          fun test() {
+              authAccount.forEachStored(fun (path: StoragePath, type: Type) {})
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+}
+
 func TestCheckAccount_getCapability(t *testing.T) {
 
 	t.Parallel()
@@ -1508,3 +1589,117 @@ func TestPublicAccountContracts(t *testing.T) {
 	})
 
 }
+
+func TestCheckAccount_capabilities(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("issue, get controllers, publish", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t, `
+            resource R {}
+
+            fun test(): [CapabilityController] {
+                let cap: Capability<&R> = authAccount.capabilities.issue<&R>(/storage/r)
+                let id: UInt64 = cap.id
+
+                let controller: CapabilityController? = authAccount.capabilities.getController(byCapabilityID: id)
+                controller!.setTag("test")
+                let tag: String = controller!.tag
+                let target: StoragePath = controller!.target()
+                let borrowType: Type = controller!.borrowType
+                controller!.revoke()
+
+                authAccount.capabilities.publish(cap, at: /public/r)
+
+                return authAccount.capabilities.getControllers(forPath: /storage/r)
+            }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("issue, non-storage path", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t, `
+            resource R {}
+
+            fun test() {
+                authAccount.capabilities.issue<&R>(/public/r)
+            }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("issue, non-reference type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t, `
+            resource R {}
+
+            fun test() {
+                authAccount.capabilities.issue<@R>(/storage/r)
+            }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("publish, non-public path", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t, `
+            resource R {}
+
+            fun test() {
+                let cap = authAccount.capabilities.issue<&R>(/storage/r)
+                authAccount.capabilities.publish(cap, at: /private/r)
+            }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("tag is not assignable", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t, `
+            fun test(controller: CapabilityController) {
+                controller.tag = "test"
+            }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		assert.IsType(t, &sema.InvalidAssignmentAccessError{}, errs[0])
+		assert.IsType(t, &sema.AssignmentToConstantMemberError{}, errs[1])
+	})
+
+	t.Run("public account", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t, `
+            fun test() {
+                publicAccount.capabilities
+            }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.NotDeclaredMemberError{}, errs[0])
+	})
+}
@@ -864,6 +864,322 @@ func TestCheckInvalidArrayContainsNotEquatable(t *testing.T) {
 	assert.IsType(t, &sema.NotEquatableTypeError{}, errs[0])
 }
 
+func TestCheckArrayMap(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("variable-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(): [String] {
+              let xs = [1, 2, 3]
+              return xs.map(fun (x: Int): String {
+                  return x.toString()
+              })
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("constant-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(): [Bool; 3] {
+              let xs: [Int; 3] = [1, 2, 3]
+              return xs.map(fun (x: Int): Bool {
+                  return x > 1
+              })
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid transform function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(): [String] {
+              let xs = [1, 2, 3]
+              return xs.map(fun (x: String): String {
+                  return x
+              })
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("invalid result type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(): [String] {
+              let xs = [1, 2, 3]
+              return xs.map(fun (x: Int): Int {
+                  return x
+              })
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+}
+
+func TestCheckArrayFilter(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("variable-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(): [Int] {
+              let xs = [1, 2, 3]
+              return xs.filter(fun (x: Int): Bool {
+                  return x > 1
+              })
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("constant-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(): [Int] {
+              let xs: [Int; 3] = [1, 2, 3]
+              return xs.filter(fun (x: Int): Bool {
+                  return x > 1
+              })
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid predicate function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(): [Int] {
+              let xs = [1, 2, 3]
+              return xs.filter(fun (x: Int): Int {
+                  return x
+              })
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+}
+
+func TestCheckArrayReduce(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(): String {
+              let xs = [1, 2, 3]
+              return xs.reduce("", fun (partialResult: String, x: Int): String {
+                  return partialResult.concat(x.toString())
+              })
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("mismatching initial result", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              let xs = [1, 2, 3]
+              xs.reduce(true, fun (partialResult: String, x: Int): String {
+                  return partialResult.concat(x.toString())
+              })
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 3)
+
+		assert.IsType(t, &sema.TypeParameterTypeMismatchError{}, errs[0])
+		assert.IsType(t, &sema.TypeParameterTypeMismatchError{}, errs[1])
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[2])
+	})
+}
+
+func TestCheckArrayReverse(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("variable-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(): [Int] {
+              let xs = [1, 2, 3]
+              return xs.reverse()
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("constant-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(): [Int; 3] {
+              let xs: [Int; 3] = [1, 2, 3]
+              return xs.reverse()
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+}
+
+func TestCheckArraySort(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              let xs = [3, 1, 2]
+              xs.sort(by: fun (a: Int, b: Int): Bool {
+                  return a < b
+              })
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing argument label", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              let xs = [3, 1, 2]
+              xs.sort(fun (a: Int, b: Int): Bool {
+                  return a < b
+              })
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.MissingArgumentLabelError{}, errs[0])
+	})
+
+	t.Run("invalid comparator function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              let xs = [3, 1, 2]
+              xs.sort(by: fun (a: Int, b: Int): Int {
+                  return a - b
+              })
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+}
+
+func TestCheckInvalidResourceArrayHigherOrderFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(name string, code string) {
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			_, err := ParseAndCheck(t, fmt.Sprintf(
+				`
+                  resource R {}
+
+                  fun count(_ r: @R): Int {
+                      destroy r
+                      return 1
+                  }
+
+                  fun keep(_ r: @R): Bool {
+                      destroy r
+                      return true
+                  }
+
+                  fun add(_ total: Int, _ r: @R): Int {
+                      destroy r
+                      return total + 1
+                  }
+
+                  fun less(_ a: @R, _ b: @R): Bool {
+                      destroy a
+                      destroy b
+                      return true
+                  }
+
+                  fun test() {
+                      let rs <- [<-create R()]
+                      %s
+                      destroy rs
+                  }
+                `,
+				code,
+			))
+
+			errs := ExpectCheckerErrors(t, err, 1)
+
+			assert.IsType(t, &sema.InvalidResourceArrayMemberError{}, errs[0])
+		})
+	}
+
+	test("map", `let counts = rs.map(count)`)
+	test("filter", `let filtered <- rs.filter(keep); destroy filtered`)
+	test("reduce", `let total = rs.reduce(0, add)`)
+	test("reverse", `let reversed <- rs.reverse(); destroy reversed`)
+	test("sort", `rs.sort(by: less)`)
+}
+
 func TestCheckEmptyArray(t *testing.T) {
 
 	t.Parallel()
@@ -0,0 +1,478 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestCheckAttachmentDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("resource base", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          resource R {}
+
+          attachment A for R {
+              pub let x: Int
+
+              init(x: Int) {
+                  self.x = x
+              }
+          }
+        `)
+		require.NoError(t, err)
+
+		attachmentType := RequireGlobalType(t, checker.Elaboration, "A")
+		require.IsType(t, &sema.CompositeType{}, attachmentType)
+
+		compositeType := attachmentType.(*sema.CompositeType)
+		assert.Equal(t, common.CompositeKindAttachment, compositeType.Kind)
+		assert.Equal(t,
+			RequireGlobalType(t, checker.Elaboration, "R"),
+			compositeType.AttachmentBaseType,
+		)
+	})
+
+	t.Run("struct base", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          attachment A for S {}
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("nested in contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C {
+              resource R {}
+
+              attachment A for R {}
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid base type: non-composite", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          attachment A for Int {}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAttachmentBaseTypeError{}, errs[0])
+	})
+
+	t.Run("invalid base type: contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C {}
+
+          attachment A for C {}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAttachmentBaseTypeError{}, errs[0])
+	})
+
+	t.Run("invalid base type: undeclared", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          attachment A for X {}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+	})
+
+	t.Run("invalid resource field", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          attachment A for R {
+              let r: @R
+
+              init(r: @R) {
+                  self.r <- r
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidResourceFieldError{}, errs[0])
+	})
+}
+
+func TestCheckAttachmentBase(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("function", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          resource R {
+              pub let id: Int
+
+              init(id: Int) {
+                  self.id = id
+              }
+          }
+
+          attachment A for R {
+              pub fun baseID(): Int {
+                  return base.id
+              }
+          }
+        `)
+		require.NoError(t, err)
+
+		assert.NotNil(t, RequireGlobalType(t, checker.Elaboration, "A"))
+	})
+
+	t.Run("initializer", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              pub let id: Int
+
+              init(id: Int) {
+                  self.id = id
+              }
+          }
+
+          attachment A for S {
+              pub let id: Int
+
+              init() {
+                  self.id = base.id
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+	})
+
+	t.Run("outside of attachment", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              fun test() {
+                  base
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+	})
+}
+
+func TestCheckAttachExpression(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          resource R {}
+
+          attachment A for R {}
+
+          fun test() {
+              let r <- attach A() to <-create R()
+              destroy r
+          }
+        `)
+		require.NoError(t, err)
+
+		assert.Len(t, checker.Elaboration.AttachExpressionTypes, 1)
+	})
+
+	t.Run("struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          attachment A for S {
+              pub let x: Int
+
+              init(x: Int) {
+                  self.x = x
+              }
+          }
+
+          let s: S = attach A(x: 1) to S()
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("missing move", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          attachment A for R {}
+
+          fun test() {
+              let r <- attach A() to create R()
+              destroy r
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.MissingMoveOperationError{}, errs[0])
+	})
+
+	t.Run("resource loss", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          attachment A for R {}
+
+          fun test() {
+              attach A() to <-create R()
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.ResourceLossError{}, errs[0])
+	})
+
+	t.Run("non-attachment", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          struct T {}
+
+          let s = attach T() to S()
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.AttachNonAttachmentError{}, errs[0])
+	})
+
+	t.Run("wrong base type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          struct T {}
+
+          attachment A for S {}
+
+          let t = attach A() to T()
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("construction outside of attach", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          let a = A()
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAttachmentUsageError{}, errs[0])
+	})
+}
+
+func TestCheckAttachmentAccess(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          resource R {}
+
+          attachment A for R {
+              pub let x: Int
+
+              init() {
+                  self.x = 1
+              }
+          }
+
+          fun test(): Int? {
+              let r <- attach A() to <-create R()
+              let a = r[A]
+              let x = a?.x
+              destroy r
+              return x
+          }
+        `)
+		require.NoError(t, err)
+
+		assert.Len(t, checker.Elaboration.AttachmentAccessTypes, 1)
+	})
+
+	t.Run("reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          let s = attach A() to S()
+          let ref = &s as &S
+          let a = ref[A]
+        `)
+		require.NoError(t, err)
+
+		aType := RequireGlobalValue(t, checker.Elaboration, "a")
+		attachmentType := RequireGlobalType(t, checker.Elaboration, "A")
+
+		assert.Equal(t,
+			&sema.OptionalType{
+				Type: &sema.ReferenceType{
+					Type: attachmentType,
+				},
+			},
+			aType,
+		)
+	})
+
+	t.Run("non-attachment", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          struct T {}
+
+          let s = S()
+          let t = s[T]
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAttachmentAccessError{}, errs[0])
+	})
+
+	t.Run("wrong base type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          struct T {}
+
+          attachment A for S {}
+
+          let t = T()
+          let a = t[A]
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("assignment", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          fun test() {
+              let s = S()
+              s[A] = nil
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotIndexingAssignableTypeError{}, errs[0])
+	})
+}
@@ -105,6 +105,167 @@ func TestCheckInvalidForValueResource(t *testing.T) {
 	assert.IsType(t, &sema.UnsupportedResourceForLoopError{}, errs[0])
 }
 
+func TestCheckForDictionary(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("keys", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              let xs: {String: Int} = {"a": 1, "b": 2}
+              for key in xs {
+                  let k: String = key
+              }
+          }
+        `)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("keys and values", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              let xs: {String: Int} = {"a": 1, "b": 2}
+              for key, value in xs {
+                  let k: String = key
+                  let v: Int = value
+              }
+          }
+        `)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid key type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              let xs: {String: Int} = {"a": 1, "b": 2}
+              for key, value in xs {
+                  let k: Int = key
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+}
+
+func TestCheckForReference(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("array", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              let xs = [1, 2, 3]
+              for index, x in &xs as &[Int] {
+                  let i: Int = index
+                  let y: Int = x
+              }
+          }
+        `)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("resource array", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {
+              let value: Int
+
+              init(value: Int) {
+                  self.value = value
+              }
+          }
+
+          fun test() {
+              let rs <- [<-create R(value: 1), <-create R(value: 2)]
+              for r in &rs as &[R] {
+                  let ref: &R = r
+                  let value = r.value
+              }
+              destroy rs
+          }
+        `)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("authorized resource dictionary", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          fun test() {
+              let rs <- {"a": <-create R()}
+              for key, r in &rs as auth &{String: R} {
+                  let k: String = key
+                  let ref: auth &R = r
+              }
+              destroy rs
+          }
+        `)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid reference to non-container", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          fun test() {
+              let s = S()
+              for x in &s as &S { }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchWithDescriptionError{}, errs[0])
+	})
+}
+
+func TestCheckInvalidForValueResourceDictionary(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      resource R {}
+
+      fun test() {
+          let xs <- {"a": <-create R()}
+          for key in xs { }
+          destroy xs
+      }
+    `)
+
+	errs := ExpectCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.UnsupportedResourceForLoopError{}, errs[0])
+}
+
 func TestCheckInvalidForBlock(t *testing.T) {
 
 	t.Parallel()
@@ -0,0 +1,539 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestCheckGenericFunctionDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("inferred type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          fun identity<T>(_ value: T): T {
+              return value
+          }
+
+          let x = identity(1)
+          let y = identity("hello")
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.IntType,
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+		assert.Equal(t,
+			sema.StringType,
+			RequireGlobalValue(t, checker.Elaboration, "y"),
+		)
+	})
+
+	t.Run("explicit type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          fun identity<T>(_ value: T): T {
+              return value
+          }
+
+          let x = identity<UInt8>(1 as UInt8)
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.UInt8Type,
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun identity<T>(_ value: T): T {
+              return value
+          }
+
+          let x = identity<String>(1)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		assert.IsType(t, &sema.TypeParameterTypeMismatchError{}, errs[0])
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[1])
+	})
+
+	t.Run("multiple type parameters", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          fun second<A, B>(_ a: A, _ b: B): B {
+              return b
+          }
+
+          let x = second(1, true)
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.BoolType,
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("type parameter in container type", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          fun wrap<T>(_ value: T): [T] {
+              return [value]
+          }
+
+          let xs = wrap(1)
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.VariableSizedType{
+				Type: sema.IntType,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "xs"),
+		)
+	})
+
+	t.Run("type bound, members", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct interface HasName {
+              fun getName(): String
+          }
+
+          struct Named: HasName {
+              fun getName(): String {
+                  return "named"
+              }
+          }
+
+          fun nameOf<T: AnyStruct{HasName}>(_ value: T): String {
+              return value.getName()
+          }
+
+          let name = nameOf(Named())
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("type bound, invalid argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct interface HasName {}
+
+          struct Unnamed {}
+
+          fun test<T: AnyStruct{HasName}>(_ value: T) {}
+
+          let x = test(Unnamed())
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("type parameter without type bound is subtype of AnyStruct", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test<T>(_ value: T): AnyStruct {
+              return value
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("type parameter is not subtype of other types", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test<T>(_ value: T): Int {
+              return value
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("resource type parameter", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          fun consume<T: @AnyResource>(_ value: @T) {
+              destroy value
+          }
+
+          fun test() {
+              consume(<-create R())
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("type parameter not in scope outside of function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test<T>(_ value: T) {}
+
+          let x: T = 1
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.NotDeclaredError{}, errs[0])
+	})
+
+	t.Run("nested function", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          fun test(): Int {
+              fun identity<T>(_ value: T): T {
+                  return value
+              }
+              return identity(1)
+          }
+
+          let x = test()
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.IntType,
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("composite function", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct S {
+              fun identity<T>(_ value: T): T {
+                  return value
+              }
+          }
+
+          let x = S().identity("hello")
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.StringType,
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("interface function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct interface I {
+              fun identity<T>(_ value: T): T
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.UnsupportedTypeParametersError{}, errs[0])
+	})
+}
+
+func TestCheckGenericCompositeDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	const boxDeclaration = `
+      struct Box<T> {
+          let value: T
+
+          init(_ value: T) {
+              self.value = value
+          }
+
+          fun get(): T {
+              return self.value
+          }
+      }
+    `
+
+	t.Run("explicit type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t,
+			boxDeclaration+`
+              let box: Box<Int> = Box<Int>(1)
+              let value = box.get()
+            `,
+		)
+		require.NoError(t, err)
+
+		boxType := RequireGlobalValue(t, checker.Elaboration, "box")
+
+		require.IsType(t, &sema.CompositeType{}, boxType)
+		compositeType := boxType.(*sema.CompositeType)
+
+		assert.Equal(t,
+			RequireGlobalType(t, checker.Elaboration, "Box"),
+			compositeType.BaseType(),
+		)
+		assert.Equal(t,
+			[]sema.Type{sema.IntType},
+			compositeType.TypeArguments(),
+		)
+		assert.Equal(t,
+			sema.TypeID("S.test.Box<Int>"),
+			compositeType.ID(),
+		)
+
+		assert.Equal(t,
+			sema.IntType,
+			RequireGlobalValue(t, checker.Elaboration, "value"),
+		)
+	})
+
+	t.Run("inferred type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t,
+			boxDeclaration+`
+              let box = Box("hello")
+              let value = box.value
+            `,
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.StringType,
+			RequireGlobalValue(t, checker.Elaboration, "value"),
+		)
+	})
+
+	t.Run("instantiations are memoized", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t,
+			boxDeclaration+`
+              let a: Box<Int> = Box(1)
+              let b: Box<Int> = Box(2)
+            `,
+		)
+		require.NoError(t, err)
+
+		assert.Same(t,
+			RequireGlobalValue(t, checker.Elaboration, "a"),
+			RequireGlobalValue(t, checker.Elaboration, "b"),
+		)
+	})
+
+	t.Run("instantiations are invariant", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t,
+			boxDeclaration+`
+              let box: Box<AnyStruct> = Box<Int>(1)
+            `,
+		)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("mismatching type arguments", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t,
+			boxDeclaration+`
+              let box: Box<String> = Box(1)
+            `,
+		)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("missing type arguments", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t,
+			boxDeclaration+`
+              let box: Box = Box(1)
+            `,
+		)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.MissingTypeArgumentsError{}, errs[0])
+	})
+
+	t.Run("too many type arguments", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t,
+			boxDeclaration+`
+              let box: Box<Int, Int> = Box(1)
+            `,
+		)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.InvalidTypeArgumentCountError{}, errs[0])
+	})
+
+	t.Run("self type inside declaration", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct Box<T> {
+              let value: T
+
+              init(_ value: T) {
+                  self.value = value
+              }
+
+              fun copy(): Box<T> {
+                  return Box<T>(self.value)
+              }
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("type bound", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct Box<T: Integer> {
+              let value: T
+
+              init(_ value: T) {
+                  self.value = value
+              }
+          }
+
+          let box = Box<String>("hello")
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource Vault<T: @AnyResource> {
+              let contents: @T
+
+              init(_ contents: @T) {
+                  self.contents <- contents
+              }
+
+              destroy() {
+                  destroy self.contents
+              }
+          }
+
+          resource R {}
+
+          fun test() {
+              let vault <- create Vault(<-create R())
+              destroy vault
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	for _, kind := range []common.CompositeKind{
+		common.CompositeKindContract,
+		common.CompositeKindEnum,
+	} {
+
+		kind := kind
+
+		t.Run(kind.Keyword(), func(t *testing.T) {
+
+			t.Parallel()
+
+			body := "{}"
+			if kind == common.CompositeKindEnum {
+				body = ": UInt8 {}"
+			}
+
+			_, err := ParseAndCheck(t, kind.Keyword()+" Test<T>"+body)
+
+			errs := ExpectCheckerErrors(t, err, 1)
+
+			require.IsType(t, &sema.UnsupportedTypeParametersError{}, errs[0])
+		})
+	}
+}
@@ -133,7 +133,7 @@ func TestCheckInterfaceWithFunctionImplementationAndConditions(t *testing.T) {
 	}
 }
 
-func TestCheckInvalidInterfaceWithFunctionImplementation(t *testing.T) {
+func TestCheckInterfaceWithFunctionImplementation(t *testing.T) {
 
 	t.Parallel()
 
@@ -153,13 +153,28 @@ func TestCheckInvalidInterfaceWithFunctionImplementation(t *testing.T) {
 				),
 			)
 
-			errs := ExpectCheckerErrors(t, err, 1)
-
-			assert.IsType(t, &sema.InvalidImplementationError{}, errs[0])
+			require.NoError(t, err)
 		})
 	}
 }
 
+func TestCheckInvalidInterfaceWithFunctionImplementationMissingReturn(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      struct interface Test {
+          fun test(): Int {
+              let x = 1
+          }
+      }
+    `)
+
+	errs := ExpectCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.MissingReturnStatementError{}, errs[0])
+}
+
 func TestCheckInvalidInterfaceWithFunctionImplementationNoConditions(t *testing.T) {
 
 	t.Parallel()
@@ -1855,7 +1870,7 @@ func BenchmarkContractInterfaceFungibleToken(b *testing.B) {
 
 	const code = examples.FungibleTokenContractInterface
 
-	program, err := parser2.ParseProgram(code)
+	program, err := parser2.ParseProgram(code, nil)
 	if err != nil {
 		b.Fatal(err)
 	}
@@ -1883,7 +1898,7 @@ func BenchmarkCheckContractInterfaceFungibleTokenConformance(b *testing.B) {
 
 	code := examples.FungibleTokenContractInterface + "\n" + examples.ExampleFungibleTokenContract
 
-	program, err := parser2.ParseProgram(code)
+	program, err := parser2.ParseProgram(code, nil)
 	if err != nil {
 		b.Fatal(err)
 	}
@@ -2012,3 +2027,172 @@ func TestCheckBadStructInterface(t *testing.T) {
 	assert.IsType(t, &sema.CompositeKindMismatchError{}, errs[10])
 	assert.IsType(t, &sema.RedeclarationError{}, errs[11])
 }
+
+func TestCheckInterfaceDefaultFunction(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("inherited", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct interface I {
+              fun test(): Int {
+                  return 1
+              }
+          }
+
+          struct S: I {}
+
+          let x = S().test()
+        `)
+
+		require.NoError(t, err)
+
+		xType := RequireGlobalValue(t, checker.Elaboration, "x")
+		assert.Equal(t, sema.IntType, xType)
+	})
+
+	t.Run("overridden", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct interface I {
+              fun test(): Int {
+                  return 1
+              }
+          }
+
+          struct S: I {
+              fun test(): Int {
+                  return 2
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("uses field requirement", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource interface I {
+              pub let x: Int
+
+              pub fun double(): Int {
+                  return self.x * 2
+              }
+          }
+
+          resource R: I {
+              pub let x: Int
+
+              init() {
+                  self.x = 1
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("conflicting defaults", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct interface I {
+              fun test(): Int {
+                  return 1
+              }
+          }
+
+          struct interface J {
+              fun test(): Int {
+                  return 2
+              }
+          }
+
+          struct S: I, J {}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.DefaultFunctionConflictError{}, errs[0])
+	})
+
+	t.Run("conflicting defaults, implemented", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct interface I {
+              fun test(): Int {
+                  return 1
+              }
+          }
+
+          struct interface J {
+              fun test(): Int {
+                  return 2
+              }
+          }
+
+          struct S: I, J {
+              fun test(): Int {
+                  return 3
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("default satisfies other requirement", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct interface I {
+              fun test(): Int {
+                  return 1
+              }
+          }
+
+          struct interface J {
+              fun test(): Int
+          }
+
+          struct S: I, J {}
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("default does not satisfy other requirement", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct interface I {
+              fun test(): Int {
+                  return 1
+              }
+          }
+
+          struct interface J {
+              fun test(): String
+          }
+
+          struct S: I, J {}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.ConformanceError{}, errs[0])
+	})
+}
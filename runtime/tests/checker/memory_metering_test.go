@@ -0,0 +1,84 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+type testMemoryGauge struct {
+	meter map[common.MemoryKind]uint64
+}
+
+func (g *testMemoryGauge) MeterMemory(usage common.MemoryUsage) error {
+	g.meter[usage.Kind] += usage.Amount
+	return nil
+}
+
+func TestCheckMemoryMetering(t *testing.T) {
+
+	t.Parallel()
+
+	gauge := &testMemoryGauge{
+		meter: map[common.MemoryKind]uint64{},
+	}
+
+	_, err := ParseAndCheckWithOptions(t,
+		`
+          struct interface I {}
+
+          struct S: I {}
+
+          let a: {String: Int?} = {}
+          let b: [Int; 2] = [1, 2]
+          let c: [S] = []
+          let d: ((Int): Int)? = nil
+          fun g(r: &S) {}
+          let f: S{I}? = nil
+        `,
+		ParseAndCheckOptions{
+			Options: []sema.Option{
+				sema.WithMemoryGauge(gauge),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(1), gauge.meter[common.MemoryKindCompositeSemaType])
+	assert.Equal(t, uint64(1), gauge.meter[common.MemoryKindInterfaceSemaType])
+
+	for _, kind := range []common.MemoryKind{
+		common.MemoryKindDictionarySemaType,
+		common.MemoryKindConstantSizedSemaType,
+		common.MemoryKindVariableSizedSemaType,
+		common.MemoryKindFunctionSemaType,
+		common.MemoryKindReferenceSemaType,
+		common.MemoryKindRestrictedSemaType,
+		common.MemoryKindOptionalSemaType,
+		common.MemoryKindVariable,
+	} {
+		assert.NotZero(t, gauge.meter[kind], kind.String())
+	}
+}
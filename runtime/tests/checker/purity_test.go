@@ -0,0 +1,483 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestCheckViewFunction(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("empty", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          view fun test() {}
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("function type", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          view fun test(): Int {
+              return 1
+          }
+
+          let f = test
+        `)
+
+		require.NoError(t, err)
+
+		fType := RequireGlobalValue(t, checker.Elaboration, "f")
+		require.IsType(t, &sema.FunctionType{}, fType)
+		assert.Equal(t, sema.FunctionPurityView, fType.(*sema.FunctionType).Purity)
+		assert.Equal(t, "(view (): Int)", fType.String())
+	})
+
+	t.Run("local variable assignment", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          view fun test(): Int {
+              var x = 1
+              x = 2
+              return x
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("global variable assignment", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          var x = 1
+
+          view fun test() {
+              x = 2
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("outer variable assignment in nested view function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          view fun test() {
+              var x = 1
+              let f = view fun () {
+                  x = 2
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("member assignment", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              var x: Int
+
+              init() {
+                  self.x = 1
+              }
+
+              view fun test() {
+                  self.x = 2
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("index assignment", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let xs = [1]
+
+          view fun test() {
+              xs[0] = 2
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("swap", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          var x = 1
+          var y = 2
+
+          view fun test() {
+              x <-> y
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+		assert.IsType(t, &sema.PurityError{}, errs[1])
+	})
+
+	t.Run("invocation of view function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          view fun foo(): Int {
+              return 1
+          }
+
+          view fun test(): Int {
+              return foo()
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("invocation of non-view function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun foo(): Int {
+              return 1
+          }
+
+          view fun test(): Int {
+              return foo()
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("invocation of non-view function in nested non-view function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun foo() {}
+
+          view fun test() {
+              let f = fun () {
+                  foo()
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("invocation of view built-in functions", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          view fun test(xs: [Int], s: String): Bool {
+              return xs.contains(1) && s.concat("!").length > 0
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("invocation of non-view built-in function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          view fun test(xs: [Int]) {
+              xs.append(1)
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("account save", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t, `
+          view fun test() {
+              authAccount.save(1, to: /storage/one)
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("account borrow", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t, `
+          view fun test(): &Int? {
+              return authAccount.borrow<&Int>(from: /storage/one)
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("emit", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          event E()
+
+          view fun test() {
+              emit E()
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("destroy", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          view fun test(r: @R) {
+              destroy r
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+
+	t.Run("condition of view function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun foo(): Bool {
+              return true
+          }
+
+          view fun test() {
+              pre { foo() }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+}
+
+func TestCheckViewFunctionSubtyping(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("view function is subtype of non-view function type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let f: ((): Void) = view fun () {}
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("non-view function is not subtype of view function type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let f: (view (): Void) = fun () {}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("invocation of view function typed value", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          view fun test(f: (view (): Int)): Int {
+              return f()
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+}
+
+func TestCheckViewFunctionConformance(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("view implementation of view requirement", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct interface I {
+              view fun test(): Int
+          }
+
+          struct S: I {
+              view fun test(): Int {
+                  return 1
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("view implementation of non-view requirement", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct interface I {
+              fun test(): Int
+          }
+
+          struct S: I {
+              view fun test(): Int {
+                  return 1
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("non-view implementation of view requirement", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct interface I {
+              view fun test(): Int
+          }
+
+          struct S: I {
+              fun test(): Int {
+                  return 1
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.ConformanceError{}, errs[0])
+	})
+}
+
+func TestCheckConditionPurityEnforcement(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      fun foo(): Bool {
+          return true
+      }
+
+      fun test() {
+          pre { foo() }
+      }
+    `
+
+	t.Run("not enforced", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, code)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("enforced", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckWithOptions(t,
+			code,
+			ParseAndCheckOptions{
+				Options: []sema.Option{
+					sema.WithConditionPurityEnforced(true),
+				},
+			},
+		)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.PurityError{}, errs[0])
+	})
+}
@@ -0,0 +1,413 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestCheckTypeAlias(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      typealias Balances = {Address: UFix64}
+
+      let balances: Balances = {0x1: 1.0}
+    `)
+	require.NoError(t, err)
+
+	balancesType := RequireGlobalValue(t, checker.Elaboration, "balances")
+
+	assert.Equal(t,
+		&sema.DictionaryType{
+			KeyType:   &sema.AddressType{},
+			ValueType: sema.UFix64Type,
+		},
+		balancesType,
+	)
+
+	assert.Equal(t,
+		balancesType,
+		RequireGlobalType(t, checker.Elaboration, "Balances"),
+	)
+}
+
+func TestCheckTypeAliasRestrictedReference(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      resource interface Receiver {}
+
+      resource Vault: Receiver {}
+
+      typealias ReceiverRef = &Vault{Receiver}
+
+      fun test(ref: ReceiverRef): &Vault{Receiver} {
+          return ref
+      }
+    `)
+	require.NoError(t, err)
+
+	aliasType := RequireGlobalType(t, checker.Elaboration, "ReceiverRef")
+
+	require.IsType(t, &sema.ReferenceType{}, aliasType)
+	assert.IsType(t,
+		&sema.RestrictedType{},
+		aliasType.(*sema.ReferenceType).Type,
+	)
+}
+
+func TestCheckTypeAliasForwardReference(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      typealias A = [B]
+
+      typealias B = S?
+
+      struct S {
+          let a: A
+
+          init() {
+              self.a = []
+          }
+      }
+    `)
+	require.NoError(t, err)
+
+	sType := RequireGlobalType(t, checker.Elaboration, "S")
+
+	assert.Equal(t,
+		&sema.VariableSizedType{
+			Type: &sema.OptionalType{
+				Type: sType,
+			},
+		},
+		RequireGlobalType(t, checker.Elaboration, "A"),
+	)
+}
+
+func TestCheckInvalidTypeAlias(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("cyclic", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias A = [B]
+          typealias B = A?
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.CyclicTypeAliasError{}, errs[0])
+	})
+
+	t.Run("self-referential", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias A = {String: A}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.CyclicTypeAliasError{}, errs[0])
+	})
+
+	t.Run("undeclared type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias A = B
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+	})
+
+	t.Run("redeclaration", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+          typealias S = Int
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.RedeclarationError{}, errs[0])
+	})
+
+	t.Run("missing resource annotation", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+          typealias A = R
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.MissingResourceAnnotationError{}, errs[0])
+	})
+
+	t.Run("invalid resource annotation", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias A = @Int
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidResourceAnnotationError{}, errs[0])
+	})
+}
+
+func TestCheckTypeAliasResource(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          typealias Rs = @[R]
+
+          fun test(rs: @Rs): @Rs {
+              return <-rs
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("missing resource annotation", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          typealias Rs = @[R]
+
+          fun test(rs: Rs) {
+              destroy rs
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.MissingResourceAnnotationError{}, errs[0])
+	})
+}
+
+func TestCheckNestedTypeAlias(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          contract C {
+
+              pub typealias Ids = [UInt64]
+
+              pub struct S {
+                  pub let ids: Ids
+
+                  init() {
+                      self.ids = []
+                  }
+              }
+
+              pub fun ids(): Ids {
+                  return []
+              }
+          }
+
+          let ids: C.Ids = C.ids()
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.VariableSizedType{
+				Type: sema.UInt64Type,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "ids"),
+		)
+	})
+
+	t.Run("contract interface", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract interface CI {
+
+              pub typealias Ids = [UInt64]
+
+              pub fun ids(): Ids
+          }
+
+          contract C: CI {
+
+              pub fun ids(): CI.Ids {
+                  return []
+              }
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("referring to sibling type alias declared later", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias T = C.A
+
+          contract C {
+              pub typealias A = [B]
+              pub typealias B = S
+              pub struct S {}
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              pub typealias A = Int
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidNestedDeclarationError{}, errs[0])
+	})
+
+	t.Run("not public", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C {
+              priv typealias A = Int
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAccessModifierError{}, errs[0])
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C {
+              pub struct S {}
+              pub typealias S = Int
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.RedeclarationError{}, errs[0])
+	})
+}
+
+func TestCheckImportedTypeAlias(t *testing.T) {
+
+	t.Parallel()
+
+	importedChecker, err := ParseAndCheckWithOptions(t,
+		`
+          pub contract C {
+
+              pub typealias Ids = [UInt64]
+
+              pub fun ids(): Ids {
+                  return []
+              }
+          }
+        `,
+		ParseAndCheckOptions{
+			Location: utils.ImportedLocation,
+		},
+	)
+	require.NoError(t, err)
+
+	checker, err := ParseAndCheckWithOptions(t,
+		`
+          import C from "imported"
+
+          let ids: C.Ids = C.ids()
+        `,
+		ParseAndCheckOptions{
+			Options: []sema.Option{
+				sema.WithImportHandler(
+					func(_ *sema.Checker, _ common.Location, _ ast.Range) (sema.Import, error) {
+						return sema.ElaborationImport{
+							Elaboration: importedChecker.Elaboration,
+						}, nil
+					},
+				),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		&sema.VariableSizedType{
+			Type: sema.UInt64Type,
+		},
+		RequireGlobalValue(t, checker.Elaboration, "ids"),
+	)
+}
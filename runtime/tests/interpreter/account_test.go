@@ -89,14 +89,30 @@ func testAccount(
 	accountValueDeclaration.Name = "account"
 	valueDeclarations = append(valueDeclarations, accountValueDeclaration)
 
+	// `assert`
+
+	functionDeclarations := stdlib.StandardLibraryFunctions{
+		stdlib.AssertFunction,
+	}
+
+	semaValueDeclarations := append(
+		valueDeclarations.ToSemaValueDeclarations(),
+		functionDeclarations.ToSemaValueDeclarations()...,
+	)
+
+	interpreterValueDeclarations := append(
+		valueDeclarations.ToInterpreterValueDeclarations(),
+		functionDeclarations.ToInterpreterValueDeclarations()...,
+	)
+
 	inter, err := parseCheckAndInterpretWithOptions(t,
 		code,
 		ParseCheckAndInterpretOptions{
 			CheckerOptions: []sema.Option{
-				sema.WithPredeclaredValues(valueDeclarations.ToSemaValueDeclarations()),
+				sema.WithPredeclaredValues(semaValueDeclarations),
 			},
 			Options: []interpreter.Option{
-				interpreter.WithPredeclaredValues(valueDeclarations.ToInterpreterValueDeclarations()),
+				interpreter.WithPredeclaredValues(interpreterValueDeclarations),
 			},
 		},
 	)
@@ -1779,6 +1795,303 @@ func TestInterpretAccount_getCapability(t *testing.T) {
 	}
 }
 
+func TestInterpretAuthAccount_capabilities(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      resource R {
+          let id: Int
+
+          init(id: Int) {
+              self.id = id
+          }
+      }
+
+      fun save() {
+          account.save(<-create R(id: 1), to: /storage/r)
+      }
+
+      fun issue(): Capability<&R> {
+          return account.capabilities.issue<&R>(/storage/r)
+      }
+
+      fun borrow(_ cap: Capability<&R>): Int? {
+          return cap.borrow()?.id
+      }
+
+      fun revoke(_ id: UInt64) {
+          account.capabilities.getController(byCapabilityID: id)!.revoke()
+      }
+    `
+
+	t.Run("issue and borrow", func(t *testing.T) {
+
+		t.Parallel()
+
+		address := interpreter.NewAddressValueFromBytes([]byte{42})
+
+		inter, _ := testAccount(
+			t,
+			address,
+			true,
+			code+`
+              fun test(): [UInt64] {
+                  save()
+                  let cap1 = issue()
+                  let cap2 = issue()
+                  assert(borrow(cap1) == 1)
+                  assert(borrow(cap2) == 1)
+                  assert(cap1.check())
+                  assert(cap1.address == account.address)
+                  return [cap1.id, cap2.id]
+              }
+            `,
+		)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeUInt64,
+				},
+				common.Address{},
+				interpreter.UInt64Value(1),
+				interpreter.UInt64Value(2),
+			),
+			value,
+		)
+	})
+
+	t.Run("borrow with wider type", func(t *testing.T) {
+
+		t.Parallel()
+
+		address := interpreter.NewAddressValueFromBytes([]byte{42})
+
+		inter, _ := testAccount(
+			t,
+			address,
+			true,
+			code+`
+              fun test(): Bool {
+                  save()
+                  let cap = account.capabilities.issue<&AnyResource>(/storage/r)
+                  assert(cap.borrow() != nil)
+
+                  // The controller only permits borrowing with the issued borrow type or a supertype of it
+
+                  account.capabilities.publish(cap, at: /public/r)
+                  return pubAccount.getCapability<&R>(/public/r).borrow() == nil
+              }
+            `,
+		)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(t, inter, interpreter.BoolValue(true), value)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+
+		t.Parallel()
+
+		address := interpreter.NewAddressValueFromBytes([]byte{42})
+
+		inter, _ := testAccount(
+			t,
+			address,
+			true,
+			code+`
+              fun test(): Bool {
+                  save()
+                  let cap1 = issue()
+                  let cap2 = issue()
+                  revoke(cap1.id)
+                  assert(account.capabilities.getController(byCapabilityID: cap1.id) == nil)
+                  assert(!cap1.check())
+                  assert(borrow(cap2) == 1)
+                  return borrow(cap1) == nil
+              }
+
+              fun revokeTwice() {
+                  let cap = issue()
+                  let controller = account.capabilities.getController(byCapabilityID: cap.id)!
+                  controller.revoke()
+                  controller.revoke()
+              }
+            `,
+		)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(t, inter, interpreter.BoolValue(true), value)
+
+		_, err = inter.Invoke("revokeTwice")
+		require.ErrorAs(t, err, &interpreter.RevokedCapabilityControllerError{})
+	})
+
+	t.Run("controller", func(t *testing.T) {
+
+		t.Parallel()
+
+		address := interpreter.NewAddressValueFromBytes([]byte{42})
+
+		inter, _ := testAccount(
+			t,
+			address,
+			true,
+			code+`
+              fun test(): String {
+                  let cap = issue()
+                  let controller = account.capabilities.getController(byCapabilityID: cap.id)!
+                  assert(controller.capabilityID == cap.id)
+                  assert(controller.borrowType == Type<&R>())
+                  assert(controller.target().toString() == "/storage/r")
+                  assert(controller.tag == "")
+
+                  controller.setTag("test")
+
+                  return account.capabilities.getController(byCapabilityID: cap.id)!.tag
+              }
+            `,
+		)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(t, inter, interpreter.NewUnmeteredStringValue("test"), value)
+	})
+
+	t.Run("get controllers", func(t *testing.T) {
+
+		t.Parallel()
+
+		address := interpreter.NewAddressValueFromBytes([]byte{42})
+
+		inter, _ := testAccount(
+			t,
+			address,
+			true,
+			code+`
+              fun test(): [UInt64] {
+                  issue()
+                  account.capabilities.issue<&R>(/storage/other)
+                  issue()
+                  let cap = issue()
+                  revoke(cap.id)
+
+                  let ids: [UInt64] = []
+                  for controller in account.capabilities.getControllers(forPath: /storage/r) {
+                      ids.append(controller.capabilityID)
+                  }
+                  return ids
+              }
+            `,
+		)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeUInt64,
+				},
+				common.Address{},
+				interpreter.UInt64Value(1),
+				interpreter.UInt64Value(3),
+			),
+			value,
+		)
+	})
+
+	t.Run("publish", func(t *testing.T) {
+
+		t.Parallel()
+
+		address := interpreter.NewAddressValueFromBytes([]byte{42})
+
+		inter, _ := testAccount(
+			t,
+			address,
+			true,
+			code+`
+              fun test(): Bool {
+                  save()
+                  let cap = issue()
+                  account.capabilities.publish(cap, at: /public/r)
+
+                  let publicCap = pubAccount.getCapability<&R>(/public/r)
+                  assert(publicCap.borrow()?.id == 1)
+
+                  revoke(cap.id)
+
+                  return publicCap.borrow() == nil
+              }
+
+              fun publishTwice() {
+                  let cap = issue()
+                  account.capabilities.publish(cap, at: /public/r)
+                  account.capabilities.publish(cap, at: /public/r)
+              }
+
+              fun publishLinked() {
+                  let cap = account.link<&R>(/private/r, target: /storage/r)!
+                  account.capabilities.publish(cap, at: /public/r)
+              }
+            `,
+		)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(t, inter, interpreter.BoolValue(true), value)
+
+		_, err = inter.Invoke("publishTwice")
+		require.ErrorAs(t, err, &interpreter.OverwriteError{})
+
+		_, err = inter.Invoke("publishLinked")
+		require.ErrorAs(t, err, &interpreter.InvalidCapabilityPublicationError{})
+	})
+
+	t.Run("unlink published", func(t *testing.T) {
+
+		t.Parallel()
+
+		address := interpreter.NewAddressValueFromBytes([]byte{42})
+
+		inter, _ := testAccount(
+			t,
+			address,
+			true,
+			code+`
+              fun test(): Bool {
+                  save()
+                  account.capabilities.publish(issue(), at: /public/r)
+                  account.unlink(/public/r)
+                  return pubAccount.getCapability<&R>(/public/r).borrow() == nil
+              }
+            `,
+		)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(t, inter, interpreter.BoolValue(true), value)
+	})
+}
+
 func TestInterpretAccount_BalanceFields(t *testing.T) {
 	t.Parallel()
 
@@ -1883,3 +2196,322 @@ func TestInterpretAccount_StorageFields(t *testing.T) {
 		}
 	}
 }
+
+func TestInterpretAccount_forEach(t *testing.T) {
+
+	t.Parallel()
+
+	const setupCode = `
+      resource R {}
+
+      struct S {}
+
+      fun setup() {
+          account.save(<-create R(), to: /storage/r)
+          account.save(S(), to: /storage/s)
+          account.link<&R>(/public/r, target: /storage/r)
+          account.link<&S>(/public/s, target: /storage/s)
+          account.link<&S>(/private/s, target: /storage/s)
+      }
+    `
+
+	t.Run("forEachStored", func(t *testing.T) {
+
+		t.Parallel()
+
+		address := interpreter.NewAddressValueFromBytes([]byte{42})
+
+		inter, _ := testAccount(
+			t,
+			address,
+			true,
+			setupCode+`
+              fun test(): [Bool] {
+                  var count = 0
+                  var foundR = false
+                  account.forEachStored(fun (path: StoragePath, type: Type): Bool {
+                      count = count + 1
+                      if path.toString() == "/storage/r" {
+                          foundR = type == Type<@R>()
+                      }
+                      return true
+                  })
+                  return [count == 2, foundR]
+              }
+            `,
+		)
+
+		_, err := inter.Invoke("setup")
+		require.NoError(t, err)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeBool,
+				},
+				common.Address{},
+				interpreter.BoolValue(true),
+				interpreter.BoolValue(true),
+			),
+			value,
+		)
+	})
+
+	t.Run("forEachPublic, public account", func(t *testing.T) {
+
+		t.Parallel()
+
+		address := interpreter.NewAddressValueFromBytes([]byte{42})
+
+		inter, _ := testAccount(
+			t,
+			address,
+			true,
+			setupCode+`
+              fun test(): [Bool] {
+                  var count = 0
+                  var foundR = false
+                  pubAccount.forEachPublic(fun (path: PublicPath, type: Type): Bool {
+                      count = count + 1
+                      if path.toString() == "/public/r" {
+                          foundR = type == Type<Capability<&R>>()
+                      }
+                      return true
+                  })
+                  return [count == 2, foundR]
+              }
+            `,
+		)
+
+		_, err := inter.Invoke("setup")
+		require.NoError(t, err)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeBool,
+				},
+				common.Address{},
+				interpreter.BoolValue(true),
+				interpreter.BoolValue(true),
+			),
+			value,
+		)
+	})
+
+	t.Run("forEachPrivate", func(t *testing.T) {
+
+		t.Parallel()
+
+		address := interpreter.NewAddressValueFromBytes([]byte{42})
+
+		inter, _ := testAccount(
+			t,
+			address,
+			true,
+			setupCode+`
+              fun test(): Int {
+                  var count = 0
+                  account.forEachPrivate(fun (path: PrivatePath, type: Type): Bool {
+                      count = count + 1
+                      return true
+                  })
+                  return count
+              }
+            `,
+		)
+
+		_, err := inter.Invoke("setup")
+		require.NoError(t, err)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(1),
+			value,
+		)
+	})
+
+	t.Run("stop iteration", func(t *testing.T) {
+
+		t.Parallel()
+
+		address := interpreter.NewAddressValueFromBytes([]byte{42})
+
+		inter, _ := testAccount(
+			t,
+			address,
+			true,
+			setupCode+`
+              fun test(): Int {
+                  var count = 0
+                  account.forEachStored(fun (path: StoragePath, type: Type): Bool {
+                      count = count + 1
+                      return false
+                  })
+                  return count
+              }
+            `,
+		)
+
+		_, err := inter.Invoke("setup")
+		require.NoError(t, err)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(1),
+			value,
+		)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+
+		t.Parallel()
+
+		address := interpreter.NewAddressValueFromBytes([]byte{42})
+
+		inter, _ := testAccount(
+			t,
+			address,
+			true,
+			`
+              fun test(): Int {
+                  var count = 0
+                  account.forEachStored(fun (path: StoragePath, type: Type): Bool {
+                      count = count + 1
+                      return true
+                  })
+                  return count
+              }
+            `,
+		)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(0),
+			value,
+		)
+	})
+
+	t.Run("modification during iteration", func(t *testing.T) {
+
+		t.Parallel()
+
+		address := interpreter.NewAddressValueFromBytes([]byte{42})
+
+		inter, _ := testAccount(
+			t,
+			address,
+			true,
+			setupCode+`
+              fun test() {
+                  account.forEachStored(fun (path: StoragePath, type: Type): Bool {
+                      account.save(S(), to: /storage/other)
+                      return true
+                  })
+              }
+            `,
+		)
+
+		_, err := inter.Invoke("setup")
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &interpreter.StorageMutatedDuringIterationError{})
+	})
+
+	t.Run("modification of other domain during iteration", func(t *testing.T) {
+
+		t.Parallel()
+
+		address := interpreter.NewAddressValueFromBytes([]byte{42})
+
+		inter, _ := testAccount(
+			t,
+			address,
+			true,
+			setupCode+`
+              fun test() {
+                  account.forEachStored(fun (path: StoragePath, type: Type): Bool {
+                      account.unlink(/public/r)
+                      return true
+                  })
+              }
+            `,
+		)
+
+		_, err := inter.Invoke("setup")
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("empty account, no storage maps created", func(t *testing.T) {
+
+		t.Parallel()
+
+		address := interpreter.NewAddressValueFromBytes([]byte{42})
+
+		inter, _ := testAccount(
+			t,
+			address,
+			true,
+			`
+              fun test(): Int {
+                  var count = 0
+                  account.forEachStored(fun (path: StoragePath, type: Type): Bool {
+                      count = count + 1
+                      return true
+                  })
+                  account.forEachPublic(fun (path: PublicPath, type: Type): Bool {
+                      count = count + 1
+                      return true
+                  })
+                  account.forEachPrivate(fun (path: PrivatePath, type: Type): Bool {
+                      count = count + 1
+                      return true
+                  })
+                  return count
+              }
+            `,
+		)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(0),
+			value,
+		)
+
+		require.Empty(t, inter.Storage.(interpreter.InMemoryStorage).StorageMaps)
+	})
+}
@@ -19,7 +19,14 @@
 package interpreter_test
 
 import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
+	. "github.com/onflow/cadence/runtime/tests/utils"
 )
 
 func arrayElements(inter *interpreter.Interpreter, array *interpreter.ArrayValue) []interpreter.Value {
@@ -44,3 +51,408 @@ func dictionaryKeyValues(dict *interpreter.DictionaryValue) []interpreter.Value
 	})
 	return result
 }
+
+func TestInterpretArrayMap(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("variable-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          let xs = [1, 2, 3]
+
+          fun test(): [String] {
+              return xs.map(fun (x: Int): String {
+                  return x.toString()
+              })
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		require.IsType(t, &interpreter.ArrayValue{}, value)
+		arrayValue := value.(*interpreter.ArrayValue)
+
+		assert.Equal(t,
+			interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeString,
+			},
+			arrayValue.Type,
+		)
+
+		AssertValueSlicesEqual(
+			t,
+			inter,
+			[]interpreter.Value{
+				interpreter.NewUnmeteredStringValue("1"),
+				interpreter.NewUnmeteredStringValue("2"),
+				interpreter.NewUnmeteredStringValue("3"),
+			},
+			arrayElements(inter, arrayValue),
+		)
+
+		// The original array is not modified
+
+		AssertValueSlicesEqual(
+			t,
+			inter,
+			[]interpreter.Value{
+				interpreter.NewIntValueFromInt64(1),
+				interpreter.NewIntValueFromInt64(2),
+				interpreter.NewIntValueFromInt64(3),
+			},
+			arrayElements(inter, inter.Globals["xs"].GetValue().(*interpreter.ArrayValue)),
+		)
+	})
+
+	t.Run("constant-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): [Bool; 3] {
+              let xs: [Int; 3] = [1, 2, 3]
+              return xs.map(fun (x: Int): Bool {
+                  return x > 1
+              })
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		require.IsType(t, &interpreter.ArrayValue{}, value)
+		arrayValue := value.(*interpreter.ArrayValue)
+
+		assert.Equal(t,
+			interpreter.ConstantSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeBool,
+				Size: 3,
+			},
+			arrayValue.Type,
+		)
+
+		AssertValueSlicesEqual(
+			t,
+			inter,
+			[]interpreter.Value{
+				interpreter.BoolValue(false),
+				interpreter.BoolValue(true),
+				interpreter.BoolValue(true),
+			},
+			arrayElements(inter, arrayValue),
+		)
+	})
+
+	t.Run("optional parameter", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): [Int] {
+              let xs = [1, 2]
+              return xs.map(fun (x: Int?): Int {
+                  return x ?? 0
+              })
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		require.IsType(t, &interpreter.ArrayValue{}, value)
+
+		AssertValueSlicesEqual(
+			t,
+			inter,
+			[]interpreter.Value{
+				interpreter.NewIntValueFromInt64(1),
+				interpreter.NewIntValueFromInt64(2),
+			},
+			arrayElements(inter, value.(*interpreter.ArrayValue)),
+		)
+	})
+}
+
+func TestInterpretArrayFilter(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      fun test(): [[Int]] {
+          let xs: [[Int]] = [[1], [2, 3], [], [4, 5]]
+          return xs.filter(fun (x: [Int]): Bool {
+              return x.length > 1
+          })
+      }
+    `)
+
+	value, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	require.IsType(t, &interpreter.ArrayValue{}, value)
+	arrayValue := value.(*interpreter.ArrayValue)
+
+	assert.Equal(t, "[[2, 3], [4, 5]]", arrayValue.String())
+}
+
+func TestInterpretArrayReduce(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      fun sum(_ xs: [Int]): Int {
+          return xs.reduce(0, fun (sum: Int, x: Int): Int {
+              return sum + x
+          })
+      }
+
+      fun test(): Int {
+          return sum([1, 2, 3, 4])
+      }
+
+      fun testEmpty(): Int {
+          return sum([])
+      }
+    `)
+
+	value, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewIntValueFromInt64(10),
+		value,
+	)
+
+	value, err = inter.Invoke("testEmpty")
+	require.NoError(t, err)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewIntValueFromInt64(0),
+		value,
+	)
+}
+
+func TestInterpretArrayReverse(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs = [1, 2, 3]
+
+      fun test(): [Int] {
+          return xs.reverse()
+      }
+
+      fun testEmpty(): [Int] {
+          let empty: [Int] = []
+          return empty.reverse()
+      }
+    `)
+
+	value, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	require.IsType(t, &interpreter.ArrayValue{}, value)
+
+	AssertValueSlicesEqual(
+		t,
+		inter,
+		[]interpreter.Value{
+			interpreter.NewIntValueFromInt64(3),
+			interpreter.NewIntValueFromInt64(2),
+			interpreter.NewIntValueFromInt64(1),
+		},
+		arrayElements(inter, value.(*interpreter.ArrayValue)),
+	)
+
+	// The original array is not modified
+
+	AssertValueSlicesEqual(
+		t,
+		inter,
+		[]interpreter.Value{
+			interpreter.NewIntValueFromInt64(1),
+			interpreter.NewIntValueFromInt64(2),
+			interpreter.NewIntValueFromInt64(3),
+		},
+		arrayElements(inter, inter.Globals["xs"].GetValue().(*interpreter.ArrayValue)),
+	)
+
+	value, err = inter.Invoke("testEmpty")
+	require.NoError(t, err)
+
+	require.IsType(t, &interpreter.ArrayValue{}, value)
+	assert.Equal(t, 0, value.(*interpreter.ArrayValue).Count())
+}
+
+func TestInterpretArraySort(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("ascending", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): [Int] {
+              let xs = [5, 3, 1, 4, 2]
+              xs.sort(by: fun (a: Int, b: Int): Bool {
+                  return a < b
+              })
+              return xs
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		require.IsType(t, &interpreter.ArrayValue{}, value)
+
+		assert.Equal(t, "[1, 2, 3, 4, 5]", value.String())
+	})
+
+	t.Run("stable", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): [String] {
+              let xs = ["bb", "a", "cc", "d", "eee", "f"]
+              xs.sort(by: fun (a: String, b: String): Bool {
+                  return a.length < b.length
+              })
+              return xs
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		require.IsType(t, &interpreter.ArrayValue{}, value)
+
+		assert.Equal(t, `["a", "d", "f", "bb", "cc", "eee"]`, value.String())
+	})
+
+	t.Run("nested arrays", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): [[Int]] {
+              let xs = [[3, 3, 3], [1], [2, 2]]
+              xs.sort(by: fun (a: [Int], b: [Int]): Bool {
+                  let less = a.length < b.length
+                  // Modifications of the arguments must not affect the sorted array
+                  a.append(0)
+                  b.append(0)
+                  return less
+              })
+              return xs
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		require.IsType(t, &interpreter.ArrayValue{}, value)
+
+		assert.Equal(t, "[[1], [2, 2], [3, 3, 3]]", value.String())
+	})
+}
+
+func TestInterpretArrayHigherOrderFunctionMutation(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(name string, code string) {
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			inter := parseCheckAndInterpret(t, `
+              let xs = [1, 2, 3]
+
+              fun test() {
+                  `+code+`
+              }
+            `)
+
+			_, err := inter.Invoke("test")
+			require.Error(t, err)
+
+			require.ErrorAs(t, err, &interpreter.ArrayMutatedDuringIterationError{})
+		})
+	}
+
+	test("map", `
+      xs.map(fun (x: Int): Int {
+          xs.append(x)
+          return x
+      })
+    `)
+
+	test("filter", `
+      xs.filter(fun (x: Int): Bool {
+          xs.remove(at: 0)
+          return true
+      })
+    `)
+
+	test("reduce", `
+      xs.reduce(0, fun (sum: Int, x: Int): Int {
+          xs.insert(at: 0, x)
+          return sum + x
+      })
+    `)
+
+	test("sort", `
+      xs.sort(by: fun (a: Int, b: Int): Bool {
+          xs[0] = 0
+          return a < b
+      })
+    `)
+}
+
+func TestInterpretArrayHigherOrderFunctionMetering(t *testing.T) {
+
+	t.Parallel()
+
+	var loopIterations uint
+
+	inter, err := parseCheckAndInterpretWithOptions(t,
+		`
+          fun test(): [Int] {
+              let xs = [1, 2, 3, 4]
+              return xs.filter(fun (x: Int): Bool {
+                  return x % 2 == 0
+              })
+          }
+        `,
+		ParseCheckAndInterpretOptions{
+			Options: []interpreter.Option{
+				interpreter.WithOnMeterComputationFuncHandler(
+					func(compKind common.ComputationKind, intensity uint) {
+						if compKind == common.ComputationKindLoop {
+							loopIterations += intensity
+						}
+					},
+				),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+
+	assert.Equal(t, uint(4), loopIterations)
+}
@@ -0,0 +1,191 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/onflow/cadence/runtime/tests/utils"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+func TestInterpretAttachments(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("field", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          resource R {}
+
+          attachment A for R {
+              pub let x: Int
+
+              init(x: Int) {
+                  self.x = x
+              }
+          }
+
+          fun test(): Int {
+              let r <- attach A(x: 42) to <-create R()
+              let x = r[A]!.x
+              destroy r
+              return x
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(42),
+			value,
+		)
+	})
+
+	t.Run("base", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          resource R {
+              pub let id: Int
+
+              init(id: Int) {
+                  self.id = id
+              }
+          }
+
+          attachment A for R {
+              pub fun baseID(): Int {
+                  return base.id
+              }
+          }
+
+          fun test(): Int {
+              let r <- attach A() to <-create R(id: 7)
+              let ref = &r as &R
+              let id = ref[A]!.baseID()
+              destroy r
+              return id
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(7),
+			value,
+		)
+	})
+
+	t.Run("not attached", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          fun test(): Bool {
+              let s = S()
+              return s[A] == nil
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.BoolValue(true),
+			value,
+		)
+	})
+
+	t.Run("struct is copied", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          fun test(): [Bool] {
+              let s = S()
+              let s2 = attach A() to s
+              return [s[A] == nil, s2[A] == nil]
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeBool,
+				},
+				common.Address{},
+				interpreter.BoolValue(true),
+				interpreter.BoolValue(false),
+			),
+			value,
+		)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          resource R {}
+
+          attachment A for R {}
+
+          fun test() {
+              let r <- attach A() to <-create R()
+              let r2 <- attach A() to <-r
+              destroy r2
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &interpreter.DuplicateAttachmentError{})
+	})
+}
@@ -0,0 +1,256 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+const debuggerTestCode = `
+  fun add(_ a: Int, _ b: Int): Int {
+      let sum = a + b
+      return sum
+  }
+
+  fun test(): Int {
+      var x = 0
+      var i = 0
+      while i < 3 {
+          x = add(x, i)
+          i = i + 1
+      }
+      return x
+  }
+`
+
+func startDebuggerTest(t *testing.T, debugger *interpreter.Debugger) <-chan interpreter.Value {
+
+	inter, err := parseCheckAndInterpretWithOptions(t,
+		debuggerTestCode,
+		ParseCheckAndInterpretOptions{
+			Options: []interpreter.Option{
+				interpreter.WithDebugger(debugger),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	results := make(chan interpreter.Value, 1)
+
+	go func() {
+		result, err := inter.Invoke("test")
+		assert.NoError(t, err)
+		results <- result
+	}()
+
+	return results
+}
+
+func parseDebuggerExpression(t *testing.T, code string) ast.Expression {
+	expression, errs := parser2.ParseExpression(code, nil)
+	require.Empty(t, errs)
+	return expression
+}
+
+func stopLine(stop interpreter.Stop) int {
+	return stop.Statement.StartPosition().Line
+}
+
+func TestInterpretDebuggerBreakpoints(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("conditional", func(t *testing.T) {
+
+		t.Parallel()
+
+		debugger := interpreter.NewDebugger()
+
+		breakpoint := debugger.AddBreakpoint(
+			utils.TestLocation,
+			12,
+			parseDebuggerExpression(t, "i == 2"),
+		)
+
+		results := startDebuggerTest(t, debugger)
+
+		stop := <-debugger.Stops()
+
+		assert.Equal(t, 12, stopLine(stop))
+		assert.Same(t, breakpoint, stop.Breakpoint)
+		assert.NoError(t, stop.ConditionError)
+
+		x, err := debugger.Evaluate(
+			stop.Interpreter,
+			debugger.CurrentActivation(stop.Interpreter),
+			parseDebuggerExpression(t, "x"),
+		)
+		require.NoError(t, err)
+		utils.AssertValuesEqual(t, stop.Interpreter, interpreter.NewIntValueFromInt64(3), x)
+
+		callStack := debugger.CallStack()
+		require.Len(t, callStack, 1)
+		assert.Equal(t, utils.TestLocation, callStack[0].Location())
+		assert.Same(t, stop.Statement, callStack[0].Statement)
+
+		require.True(t, debugger.Continue())
+
+		result := <-results
+		utils.AssertValuesEqual(t, stop.Interpreter, interpreter.NewIntValueFromInt64(3), result)
+	})
+
+	t.Run("invalid condition", func(t *testing.T) {
+
+		t.Parallel()
+
+		debugger := interpreter.NewDebugger()
+
+		debugger.AddBreakpoint(
+			utils.TestLocation,
+			12,
+			parseDebuggerExpression(t, "unknown == 1"),
+		)
+
+		results := startDebuggerTest(t, debugger)
+
+		stop := <-debugger.Stops()
+
+		assert.Equal(t, 12, stopLine(stop))
+		assert.Error(t, stop.ConditionError)
+
+		debugger.ClearBreakpoints(utils.TestLocation)
+		assert.Empty(t, debugger.Breakpoints())
+
+		require.True(t, debugger.Continue())
+
+		<-results
+	})
+}
+
+func TestInterpretDebuggerStepping(t *testing.T) {
+
+	t.Parallel()
+
+	debugger := interpreter.NewDebugger()
+
+	breakpoint := debugger.AddBreakpoint(
+		utils.TestLocation,
+		11,
+		parseDebuggerExpression(t, "i == 1"),
+	)
+
+	results := startDebuggerTest(t, debugger)
+
+	stop := <-debugger.Stops()
+	require.Equal(t, 11, stopLine(stop))
+
+	// Step into the invoked function
+
+	stop = debugger.StepIn()
+	require.Equal(t, 3, stopLine(stop))
+	assert.Nil(t, stop.Breakpoint)
+
+	callStack := debugger.CallStack()
+	require.Len(t, callStack, 2)
+	assert.Equal(t, 3, callStack[0].Statement.StartPosition().Line)
+	assert.Equal(t, 11, callStack[1].Statement.StartPosition().Line)
+
+	sum, err := debugger.Evaluate(
+		stop.Interpreter,
+		callStack[0].Activation,
+		parseDebuggerExpression(t, "a + b"),
+	)
+	require.NoError(t, err)
+	utils.AssertValuesEqual(t, stop.Interpreter, interpreter.NewIntValueFromInt64(1), sum)
+
+	// Variables of the calling function are evaluated in the activation of its frame
+
+	i, err := debugger.Evaluate(
+		stop.Interpreter,
+		callStack[1].Activation,
+		parseDebuggerExpression(t, "i"),
+	)
+	require.NoError(t, err)
+	utils.AssertValuesEqual(t, stop.Interpreter, interpreter.NewIntValueFromInt64(1), i)
+
+	stop = debugger.StepOver()
+	require.Equal(t, 4, stopLine(stop))
+
+	// Step out of the invoked function
+
+	stop = debugger.StepOut()
+	require.Equal(t, 12, stopLine(stop))
+	require.Len(t, debugger.CallStack(), 1)
+
+	// Step over the invocation in the next loop iteration
+
+	stop = debugger.StepOver()
+	require.Equal(t, 11, stopLine(stop))
+
+	stop = debugger.StepOver()
+	require.Equal(t, 12, stopLine(stop))
+	require.Len(t, debugger.CallStack(), 1)
+
+	require.True(t, debugger.RemoveBreakpoint(breakpoint.ID))
+	require.True(t, debugger.Continue())
+
+	result := <-results
+	utils.AssertValuesEqual(t, stop.Interpreter, interpreter.NewIntValueFromInt64(3), result)
+}
+
+func TestInterpretDebuggerStepImmediatelyAfterStop(t *testing.T) {
+
+	t.Parallel()
+
+	debugger := interpreter.NewDebugger()
+
+	debugger.AddBreakpoint(utils.TestLocation, 8, nil)
+
+	results := startDebuggerTest(t, debugger)
+
+	stop := <-debugger.Stops()
+	require.Equal(t, 8, stopLine(stop))
+
+	// Resume immediately after each stop is received,
+	// i.e. possibly before the interpreter waits for the resumption
+
+	steps := 0
+
+	for {
+		require.True(t, debugger.ContinueStepIn())
+
+		select {
+		case stop = <-debugger.Stops():
+			steps++
+
+		case result := <-results:
+			utils.AssertValuesEqual(t, stop.Interpreter, interpreter.NewIntValueFromInt64(3), result)
+			assert.Greater(t, steps, 10)
+			return
+		}
+	}
+}
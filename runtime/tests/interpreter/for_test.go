@@ -219,3 +219,284 @@ func TestInterpretForStatementEmpty(t *testing.T) {
 		value,
 	)
 }
+
+func TestInterpretForStatementDictionary(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("keys", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+           fun test(): Int {
+               var sum = 0
+               for key in {1: "a", 2: "b", 3: "c"} {
+                   sum = sum + key
+               }
+               return sum
+           }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(6),
+			value,
+		)
+	})
+
+	t.Run("keys and values", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+           fun test(): Int {
+               var sum = 0
+               for key, value in {1: 10, 2: 20, 3: 30} {
+                   sum = sum + key * value
+               }
+               return sum
+           }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(140),
+			value,
+		)
+	})
+
+	t.Run("break", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+           fun test(): Int {
+               var count = 0
+               for key in {1: "a", 2: "b", 3: "c"} {
+                   count = count + 1
+                   break
+               }
+               return count
+           }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(1),
+			value,
+		)
+	})
+
+	t.Run("copy", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+           fun test(): Int {
+               let xs = {1: "a", 2: "b"}
+               for key in xs {
+                   xs[key + 10] = "c"
+               }
+               return xs.length
+           }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(4),
+			value,
+		)
+	})
+}
+
+func TestInterpretForStatementReference(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("array", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+           fun test(): Int {
+               let xs = [1, 2, 3]
+               var sum = 0
+               for index, x in &xs as &[Int] {
+                   sum = sum + index * x
+               }
+               return sum
+           }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(8),
+			value,
+		)
+	})
+
+	t.Run("resource array", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+           resource R {
+               var value: Int
+
+               init(value: Int) {
+                   self.value = value
+               }
+
+               fun increment() {
+                   self.value = self.value + 1
+               }
+           }
+
+           fun test(): Int {
+               let rs <- [<-create R(value: 1), <-create R(value: 2)]
+               for r in &rs as &[R] {
+                   r.increment()
+               }
+               let sum = rs[0].value + rs[1].value
+               destroy rs
+               return sum
+           }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(5),
+			value,
+		)
+	})
+
+	t.Run("resource dictionary", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+           resource R {
+               let value: Int
+
+               init(value: Int) {
+                   self.value = value
+               }
+           }
+
+           fun test(): Int {
+               let rs <- {1: <-create R(value: 10), 2: <-create R(value: 20)}
+               var sum = 0
+               for key, r in &rs as &{Int: R} {
+                   sum = sum + key * r.value
+               }
+               destroy rs
+               return sum
+           }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(50),
+			value,
+		)
+	})
+}
+
+func TestInterpretForStatementReferenceMutation(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("array", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+           fun test() {
+               let xs = [1, 2, 3]
+               let ref = &xs as &[Int]
+               for x in ref {
+                   ref.append(x)
+               }
+           }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &interpreter.ArrayMutatedDuringIterationError{})
+	})
+
+	t.Run("dictionary", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+           fun test() {
+               let xs = {1: "a", 2: "b"}
+               let ref = &xs as &{Int: String}
+               for key in ref {
+                   ref.remove(key: key)
+               }
+           }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &interpreter.DictionaryMutatedDuringIterationError{})
+	})
+
+	t.Run("after loop", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+           fun test(): Int {
+               let xs = [1, 2, 3]
+               let ref = &xs as &[Int]
+               for x in ref {}
+               ref.append(4)
+               return xs.length
+           }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(4),
+			value,
+		)
+	})
+}
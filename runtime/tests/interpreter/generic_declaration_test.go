@@ -0,0 +1,298 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/onflow/cadence/runtime/tests/utils"
+
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+func TestInterpretGenericFunctionDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("identity", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun identity<T>(_ value: T): T {
+              return value
+          }
+
+          fun test(): UInt8 {
+              return identity<UInt8>(1 as UInt8)
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.UInt8Value(1),
+			value,
+		)
+	})
+
+	t.Run("type argument in array expression", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun wrap<T>(_ value: T): [T] {
+              return [value]
+          }
+
+          fun test(): [String] {
+              return wrap("hello")
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		require.IsType(t, &interpreter.ArrayValue{}, value)
+		arrayValue := value.(*interpreter.ArrayValue)
+
+		assert.Equal(t,
+			interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeString,
+			},
+			arrayValue.Type,
+		)
+	})
+
+	t.Run("type argument in casting expression", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun cast<T>(_ value: AnyStruct): T? {
+              return value as? T
+          }
+
+          fun testInt(): Int? {
+              return cast<Int>(1)
+          }
+
+          fun testString(): String? {
+              return cast<String>(1)
+          }
+        `)
+
+		value, err := inter.Invoke("testInt")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewSomeValueNonCopying(
+				interpreter.NewIntValueFromInt64(1),
+			),
+			value,
+		)
+
+		value, err = inter.Invoke("testString")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NilValue{},
+			value,
+		)
+	})
+
+	t.Run("type argument in nested function", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun makeWrapper<T>(): ((T): [T]) {
+              return fun (value: T): [T] {
+                  return [value]
+              }
+          }
+
+          fun test(): [Bool] {
+              let wrap = makeWrapper<Bool>()
+              return wrap(true)
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		require.IsType(t, &interpreter.ArrayValue{}, value)
+		arrayValue := value.(*interpreter.ArrayValue)
+
+		assert.Equal(t,
+			interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeBool,
+			},
+			arrayValue.Type,
+		)
+	})
+}
+
+func TestInterpretGenericCompositeDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	const boxDeclaration = `
+      struct Box<T> {
+          let value: T
+
+          init(_ value: T) {
+              self.value = value
+          }
+
+          fun get(): T {
+              return self.value
+          }
+
+          fun wrapped(): [T] {
+              return [self.value]
+          }
+      }
+    `
+
+	t.Run("constructor and function", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t,
+			boxDeclaration+`
+              fun test(): Int {
+                  let box = Box<Int>(42)
+                  return box.get()
+              }
+            `,
+		)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(42),
+			value,
+		)
+	})
+
+	t.Run("static type", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t,
+			boxDeclaration+`
+              let box = Box("hello")
+            `,
+		)
+
+		value := inter.Globals["box"].GetValue()
+
+		require.IsType(t, &interpreter.CompositeValue{}, value)
+		compositeValue := value.(*interpreter.CompositeValue)
+
+		expectedType := interpreter.NewCompositeStaticType(TestLocation, "Box")
+		expectedType.TypeArguments = []interpreter.StaticType{
+			interpreter.PrimitiveStaticTypeString,
+		}
+
+		assert.Equal(t,
+			expectedType,
+			compositeValue.StaticType(),
+		)
+	})
+
+	t.Run("type argument in function", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t,
+			boxDeclaration+`
+              fun test(): [UInt8] {
+                  return Box<UInt8>(1 as UInt8).wrapped()
+              }
+            `,
+		)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		require.IsType(t, &interpreter.ArrayValue{}, value)
+		arrayValue := value.(*interpreter.ArrayValue)
+
+		assert.Equal(t,
+			interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeUInt8,
+			},
+			arrayValue.Type,
+		)
+	})
+
+	t.Run("dynamic casting", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t,
+			boxDeclaration+`
+              let box: AnyStruct = Box<Int>(1)
+
+              fun testInt(): Bool {
+                  return (box as? Box<Int>) != nil
+              }
+
+              fun testString(): Bool {
+                  return (box as? Box<String>) != nil
+              }
+            `,
+		)
+
+		value, err := inter.Invoke("testInt")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.BoolValue(true),
+			value,
+		)
+
+		value, err = inter.Invoke("testString")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.BoolValue(false),
+			value,
+		)
+	})
+}
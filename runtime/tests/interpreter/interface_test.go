@@ -0,0 +1,144 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/onflow/cadence/runtime/tests/utils"
+
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+func TestInterpretInterfaceDefaultFunction(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("inherited", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct interface I {
+              pub let x: Int
+
+              pub fun double(): Int {
+                  return self.x * 2
+              }
+          }
+
+          struct S: I {
+              pub let x: Int
+
+              init(x: Int) {
+                  self.x = x
+              }
+          }
+
+          fun test(): Int {
+              return S(x: 21).double()
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(42),
+			value,
+		)
+	})
+
+	t.Run("overridden", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct interface I {
+              fun test(): Int {
+                  return 1
+              }
+          }
+
+          struct S: I {
+              fun test(): Int {
+                  return 2
+              }
+          }
+
+          fun test(): Int {
+              return S().test()
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(2),
+			value,
+		)
+	})
+
+	t.Run("conditions", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct interface I {
+              fun test(x: Int): Int {
+                  pre {
+                      x > 0: "x must be positive"
+                  }
+                  post {
+                      result > x: "result must be larger than x"
+                  }
+                  return x + 1
+              }
+          }
+
+          struct S: I {}
+
+          fun test(x: Int): Int {
+              return S().test(x: x)
+          }
+        `)
+
+		value, err := inter.Invoke("test", interpreter.NewIntValueFromInt64(1))
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(2),
+			value,
+		)
+
+		_, err = inter.Invoke("test", interpreter.NewIntValueFromInt64(0))
+
+		var conditionErr interpreter.ConditionError
+		require.ErrorAs(t, err, &conditionErr)
+	})
+}
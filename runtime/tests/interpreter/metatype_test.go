@@ -228,7 +228,7 @@ func TestInterpretMetaTypeIdentifier(t *testing.T) {
 		AssertValuesEqual(
 			t,
 			inter,
-			interpreter.NewStringValue("[Int]"),
+			interpreter.NewUnmeteredStringValue("[Int]"),
 			inter.Globals["identifier"].GetValue(),
 		)
 	})
@@ -247,7 +247,7 @@ func TestInterpretMetaTypeIdentifier(t *testing.T) {
 		AssertValuesEqual(
 			t,
 			inter,
-			interpreter.NewStringValue("S.test.S"),
+			interpreter.NewUnmeteredStringValue("S.test.S"),
 			inter.Globals["identifier"].GetValue(),
 		)
 	})
@@ -290,7 +290,7 @@ func TestInterpretMetaTypeIdentifier(t *testing.T) {
 		AssertValuesEqual(
 			t,
 			inter,
-			interpreter.NewStringValue(""),
+			interpreter.NewUnmeteredStringValue(""),
 			inter.Globals["identifier"].GetValue(),
 		)
 	})
@@ -727,7 +727,7 @@ func TestInterpretGetType(t *testing.T) {
 			)
 			require.NoError(t, err)
 
-			storageMap := storage.GetStorageMap(storageAddress, storagePath.Domain.Identifier())
+			storageMap := storage.GetStorageMap(storageAddress, storagePath.Domain.Identifier(), true)
 			storageMap.WriteValue(
 				inter,
 				storagePath.Identifier,
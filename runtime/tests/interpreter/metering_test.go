@@ -19,13 +19,17 @@
 package interpreter_test
 
 import (
+	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
 	"github.com/onflow/cadence/runtime/interpreter"
 	"github.com/onflow/cadence/runtime/sema"
 	"github.com/onflow/cadence/runtime/tests/checker"
@@ -422,3 +426,379 @@ func TestInterpretFunctionInvocationHandler(t *testing.T) {
 		occurrences,
 	)
 }
+
+type testMemoryGauge struct {
+	meter map[common.MemoryKind]uint64
+	limit uint64
+	total uint64
+}
+
+func newTestMemoryGauge() *testMemoryGauge {
+	return &testMemoryGauge{
+		meter: map[common.MemoryKind]uint64{},
+	}
+}
+
+var memoryLimitExceededError = fmt.Errorf("memory limit exceeded")
+
+func (g *testMemoryGauge) MeterMemory(usage common.MemoryUsage) error {
+	g.total += usage.Amount
+	if g.limit > 0 && g.total > g.limit {
+		return memoryLimitExceededError
+	}
+	g.meter[usage.Kind] += usage.Amount
+	return nil
+}
+
+func TestInterpretMemoryMetering(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("string", func(t *testing.T) {
+
+		t.Parallel()
+
+		gauge := newTestMemoryGauge()
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              fun main(): String {
+                  return "hello".concat(" world")
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Options: []interpreter.Option{
+					interpreter.WithMemoryGauge(gauge),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("main")
+		require.NoError(t, err)
+
+		// "hello" (5 + 1), " world" (6 + 1), and "hello world" (11 + 1)
+		assert.Equal(t, uint64(25), gauge.meter[common.MemoryKindStringValue])
+	})
+
+	t.Run("string replaceAll", func(t *testing.T) {
+
+		t.Parallel()
+
+		gauge := newTestMemoryGauge()
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              fun main(): String {
+                  return "a,b,c".replaceAll(of: ",", with: ";;")
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Options: []interpreter.Option{
+					interpreter.WithMemoryGauge(gauge),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("main")
+		require.NoError(t, err)
+
+		// "a,b,c" (5 + 1), "," (1 + 1), ";;" (2 + 1), and "a;;b;;c" (7 + 1)
+		assert.Equal(t, uint64(19), gauge.meter[common.MemoryKindStringValue])
+	})
+
+	t.Run("string join", func(t *testing.T) {
+
+		t.Parallel()
+
+		gauge := newTestMemoryGauge()
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              fun main(): String {
+                  return String.join(["a", "bc"], separator: ", ")
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Options: []interpreter.Option{
+					interpreter.WithMemoryGauge(gauge),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("main")
+		require.NoError(t, err)
+
+		// "a" (1 + 1), "bc" (2 + 1), ", " (2 + 1), and "a, bc" (5 + 1)
+		assert.Equal(t, uint64(14), gauge.meter[common.MemoryKindStringValue])
+	})
+
+	t.Run("array", func(t *testing.T) {
+
+		t.Parallel()
+
+		gauge := newTestMemoryGauge()
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              fun main(): [Int] {
+                  let xs: [Int] = [1, 2, 3]
+                  xs.append(4)
+                  return xs
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Options: []interpreter.Option{
+					interpreter.WithMemoryGauge(gauge),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("main")
+		require.NoError(t, err)
+
+		assert.NotZero(t, gauge.meter[common.MemoryKindArrayValueBase])
+		assert.NotZero(t, gauge.meter[common.MemoryKindAtreeArrayDataSlab])
+		assert.GreaterOrEqual(t, gauge.meter[common.MemoryKindAtreeArrayElementOverhead], uint64(4))
+	})
+
+	t.Run("big integers", func(t *testing.T) {
+
+		t.Parallel()
+
+		gauge := newTestMemoryGauge()
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              fun main(): Int {
+                  let x = 1 << 800
+                  return x * x
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Options: []interpreter.Option{
+					interpreter.WithMemoryGauge(gauge),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("main")
+		require.NoError(t, err)
+
+		// 1 << 800: 1 + 101 bytes, rounded up to 104 bytes.
+		// x * x: 101 + 101 bytes, rounded up to 208 bytes
+		assert.Equal(t, uint64(312), gauge.meter[common.MemoryKindBigInt])
+	})
+
+	t.Run("big integer shift limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		gauge := newTestMemoryGauge()
+		gauge.limit = 10_000
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              fun main(): UInt256 {
+                  let x: UInt256 = 1
+                  return x << 100_000_000
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Options: []interpreter.Option{
+					interpreter.WithMemoryGauge(gauge),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("main")
+		require.Error(t, err)
+
+		var memoryErr errors.MemoryError
+		require.ErrorAs(t, err, &memoryErr)
+		require.ErrorIs(t, err, memoryLimitExceededError)
+	})
+
+	t.Run("big integer saturating function", func(t *testing.T) {
+
+		t.Parallel()
+
+		gauge := newTestMemoryGauge()
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              fun main(): Int128 {
+                  let x: Int128 = 1
+                  return x.saturatingMultiply(2)
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Options: []interpreter.Option{
+					interpreter.WithMemoryGauge(gauge),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("main")
+		require.NoError(t, err)
+
+		// 1 * 2: 1 + 1 bytes, rounded up to 8 bytes
+		assert.Equal(t, uint64(8), gauge.meter[common.MemoryKindBigInt])
+	})
+
+	t.Run("limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		gauge := newTestMemoryGauge()
+		gauge.limit = 10_000
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              fun main() {
+                  let xs: [String] = []
+                  while true {
+                      xs.append("hello world")
+                  }
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Options: []interpreter.Option{
+					interpreter.WithMemoryGauge(gauge),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("main")
+		require.Error(t, err)
+
+		var memoryErr errors.MemoryError
+		require.ErrorAs(t, err, &memoryErr)
+		require.ErrorIs(t, err, memoryLimitExceededError)
+	})
+}
+
+func TestInterpretContextCancellation(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("canceled before execution", func(t *testing.T) {
+
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              fun main() {
+                  while true {}
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Options: []interpreter.Option{
+					interpreter.WithContext(ctx),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("main")
+		require.Error(t, err)
+
+		var canceledErr interpreter.ExecutionCanceledError
+		require.ErrorAs(t, err, &canceledErr)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("canceled in loop iteration handler", func(t *testing.T) {
+
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		iterations := 0
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              fun main() {
+                  var i = 0
+                  while true {
+                      i = i + 1
+                  }
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Options: []interpreter.Option{
+					interpreter.WithContext(ctx),
+					interpreter.WithOnLoopIterationHandler(
+						func(_ *interpreter.Interpreter, _ int) {
+							iterations++
+							if iterations == 10 {
+								cancel()
+							}
+						},
+					),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("main")
+		require.Error(t, err)
+
+		var canceledErr interpreter.ExecutionCanceledError
+		require.ErrorAs(t, err, &canceledErr)
+		require.ErrorIs(t, err, context.Canceled)
+
+		assert.Equal(t, 10, iterations)
+	})
+
+	t.Run("deadline exceeded in statement handler", func(t *testing.T) {
+
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+
+		statements := 0
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              fun main() {
+                  true
+                  true
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Options: []interpreter.Option{
+					interpreter.WithContext(ctx),
+					interpreter.WithOnStatementHandler(
+						func(_ *interpreter.Interpreter, _ ast.Statement) {
+							statements++
+							<-ctx.Done()
+						},
+					),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("main")
+		require.Error(t, err)
+
+		var canceledErr interpreter.ExecutionCanceledError
+		require.ErrorAs(t, err, &canceledErr)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		assert.Equal(t, 1, statements)
+	})
+}
@@ -53,7 +53,7 @@ func TestInterpretRecursiveValueString(t *testing.T) {
 	require.Equal(t,
 		`{"mapRef": ...}`,
 		mapValue.(*interpreter.DictionaryValue).
-			GetKey(inter, interpreter.ReturnEmptyLocationRange, interpreter.NewStringValue("mapRef")).
+			GetKey(inter, interpreter.ReturnEmptyLocationRange, interpreter.NewUnmeteredStringValue("mapRef")).
 			String(),
 	)
 }
@@ -74,7 +74,7 @@ func TestInterpretStringFunction(t *testing.T) {
 	RequireValuesEqual(
 		t,
 		inter,
-		interpreter.NewStringValue(""),
+		interpreter.NewUnmeteredStringValue(""),
 		result,
 	)
 }
@@ -125,7 +125,7 @@ func TestInterpretStringEncodeHex(t *testing.T) {
 	RequireValuesEqual(
 		t,
 		inter,
-		interpreter.NewStringValue("010203cade"),
+		interpreter.NewUnmeteredStringValue("010203cade"),
 		result,
 	)
 }
@@ -201,7 +201,7 @@ func TestInterpretStringToLower(t *testing.T) {
 	require.NoError(t, err)
 
 	require.Equal(t,
-		interpreter.NewStringValue("flowers"),
+		interpreter.NewUnmeteredStringValue("flowers"),
 		result,
 	)
 }
@@ -301,7 +301,7 @@ func TestInterpretConvertCharacterToString(t *testing.T) {
 	require.NoError(t, err)
 
 	require.Equal(t,
-		interpreter.NewStringValue("x"),
+		interpreter.NewUnmeteredStringValue("x"),
 		result,
 	)
 }
@@ -341,3 +341,323 @@ func TestInterpretCompareCharacters(t *testing.T) {
 		inter.Globals["z"].GetValue(),
 	)
 }
+
+func TestInterpretStringToUpper(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      fun test(): String {
+          return "Flowers".toUpper()
+      }
+    `)
+
+	result, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	require.Equal(t,
+		interpreter.NewUnmeteredStringValue("FLOWERS"),
+		result,
+	)
+}
+
+func TestInterpretStringTrim(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      fun test(): [String] {
+          return [
+              "  Flowers \t\n".trim(),
+              "   ".trim(),
+              "".trim(),
+              // the combining accent belongs to the space character,
+              // which is hence not trimmed
+              " \u{301}Flowers".trim()
+          ]
+      }
+    `)
+
+	result, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	require.IsType(t, &interpreter.ArrayValue{}, result)
+
+	require.Equal(t,
+		[]interpreter.Value{
+			interpreter.NewUnmeteredStringValue("Flowers"),
+			interpreter.NewUnmeteredStringValue(""),
+			interpreter.NewUnmeteredStringValue(""),
+			interpreter.NewUnmeteredStringValue(" \u0301Flowers"),
+		},
+		arrayElements(inter, result.(*interpreter.ArrayValue)),
+	)
+}
+
+func TestInterpretStringSplit(t *testing.T) {
+
+	t.Parallel()
+
+	type test struct {
+		str       string
+		separator string
+		result    string
+	}
+
+	tests := []test{
+		{`"a,b,c"`, `","`, `["a", "b", "c"]`},
+		{`"a, b, c"`, `", "`, `["a", "b", "c"]`},
+		{`",a,,b,"`, `","`, `["", "a", "", "b", ""]`},
+		{`"abc"`, `","`, `["abc"]`},
+		{`""`, `","`, `[""]`},
+		{`"abc"`, `""`, `["a", "b", "c"]`},
+		{`""`, `""`, `[]`},
+		// the separator only matches whole characters (grapheme clusters)
+		{`"ae\u{301}b"`, `"e"`, `["ae\u{301}b"]`},
+		{`"ae\u{301}b"`, `"e\u{301}"`, `["a", "b"]`},
+		{`"ae\u{301}b"`, `""`, `["a", "e\u{301}", "b"]`},
+	}
+
+	for _, test := range tests {
+
+		test := test
+
+		t.Run(test.str+".split(separator: "+test.separator+")", func(t *testing.T) {
+
+			t.Parallel()
+
+			inter := parseCheckAndInterpret(t, `
+              fun test(): [String] {
+                  return `+test.str+`.split(separator: `+test.separator+`)
+              }
+            `)
+
+			result, err := inter.Invoke("test")
+			require.NoError(t, err)
+
+			expected := parseCheckAndInterpret(t, `
+              let expected: [String] = `+test.result+`
+            `).Globals["expected"].GetValue()
+
+			require.Equal(t,
+				expected.String(),
+				result.String(),
+			)
+		})
+	}
+}
+
+func TestInterpretStringReplaceAll(t *testing.T) {
+
+	t.Parallel()
+
+	type test struct {
+		str         string
+		original    string
+		replacement string
+		result      string
+	}
+
+	tests := []test{
+		{"abcb", "b", "x", "axcx"},
+		{"abcb", "bc", "", "ab"},
+		{"abc", "d", "x", "abc"},
+		{"aaa", "aa", "b", "ba"},
+		{"abc", "", "-", "-a-b-c-"},
+		{"", "", "-", "-"},
+		// only whole characters (grapheme clusters) are replaced
+		{"ae\u0301b", "e", "x", "ae\u0301b"},
+		{"ae\u0301b", "e\u0301", "x", "axb"},
+		{"ae\u0301b", "", "-", "-a-e\u0301-b-"},
+	}
+
+	for _, test := range tests {
+
+		test := test
+
+		t.Run("", func(t *testing.T) {
+
+			t.Parallel()
+
+			inter := parseCheckAndInterpret(t, `
+              fun test(str: String, original: String, replacement: String): String {
+                  return str.replaceAll(of: original, with: replacement)
+              }
+            `)
+
+			result, err := inter.Invoke(
+				"test",
+				interpreter.NewUnmeteredStringValue(test.str),
+				interpreter.NewUnmeteredStringValue(test.original),
+				interpreter.NewUnmeteredStringValue(test.replacement),
+			)
+			require.NoError(t, err)
+
+			require.Equal(t,
+				interpreter.NewUnmeteredStringValue(test.result),
+				result,
+			)
+		})
+	}
+}
+
+func TestInterpretStringContainsAndIndex(t *testing.T) {
+
+	t.Parallel()
+
+	type test struct {
+		str   string
+		other string
+		index int
+	}
+
+	tests := []test{
+		{"abc", "a", 0},
+		{"abc", "bc", 1},
+		{"abc", "c", 2},
+		{"abc", "", 0},
+		{"abc", "d", -1},
+		{"abc", "abcd", -1},
+		{"", "", 0},
+		{"", "a", -1},
+		// only whole characters (grapheme clusters) are matched,
+		// and the index is the index of the character
+		{"ae\u0301b", "e", -1},
+		{"ae\u0301b", "e\u0301", 1},
+		{"e\u0301e\u0301e", "e", 2},
+	}
+
+	for _, test := range tests {
+
+		test := test
+
+		t.Run("", func(t *testing.T) {
+
+			t.Parallel()
+
+			inter := parseCheckAndInterpret(t, `
+              fun contains(str: String, other: String): Bool {
+                  return str.contains(other)
+              }
+
+              fun index(str: String, other: String): Int? {
+                  return str.index(of: other)
+              }
+            `)
+
+			arguments := []interpreter.Value{
+				interpreter.NewUnmeteredStringValue(test.str),
+				interpreter.NewUnmeteredStringValue(test.other),
+			}
+
+			result, err := inter.Invoke("contains", arguments...)
+			require.NoError(t, err)
+
+			require.Equal(t,
+				interpreter.BoolValue(test.index >= 0),
+				result,
+			)
+
+			result, err = inter.Invoke("index", arguments...)
+			require.NoError(t, err)
+
+			var expected interpreter.Value = interpreter.NilValue{}
+			if test.index >= 0 {
+				expected = interpreter.NewSomeValueNonCopying(
+					interpreter.NewIntValueFromInt64(int64(test.index)),
+				)
+			}
+
+			AssertValuesEqual(t, inter, expected, result)
+		})
+	}
+}
+
+func TestInterpretStringJoin(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      fun test(): [String] {
+          return [
+              String.join(["a", "b", "c"], separator: ", "),
+              String.join(["a"], separator: ", "),
+              String.join([], separator: ", "),
+              String.join(["a", "b"], separator: "")
+          ]
+      }
+    `)
+
+	result, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	require.IsType(t, &interpreter.ArrayValue{}, result)
+
+	require.Equal(t,
+		[]interpreter.Value{
+			interpreter.NewUnmeteredStringValue("a, b, c"),
+			interpreter.NewUnmeteredStringValue("a"),
+			interpreter.NewUnmeteredStringValue(""),
+			interpreter.NewUnmeteredStringValue("ab"),
+		},
+		arrayElements(inter, result.(*interpreter.ArrayValue)),
+	)
+}
+
+func TestInterpretStringFunctionMetering(t *testing.T) {
+
+	t.Parallel()
+
+	computation := map[common.ComputationKind]uint{}
+
+	inter, err := parseCheckAndInterpretWithOptions(t,
+		`
+          fun test() {
+              let str = "a,b,c"
+              str.split(separator: ",")
+              str.replaceAll(of: ",", with: ";")
+              str.contains("b")
+              str.index(of: "b")
+              str.toUpper()
+              str.trim()
+              String.join(["a", "b"], separator: ",")
+              str.concat("d,e")
+              str.slice(from: 1, upTo: 3)
+              str.toLower()
+              "0a0b".decodeHex()
+              String.encodeHex([1, 2, 3])
+          }
+        `,
+		ParseCheckAndInterpretOptions{
+			Options: []interpreter.Option{
+				interpreter.WithOnMeterComputationFuncHandler(
+					func(compKind common.ComputationKind, intensity uint) {
+						computation[compKind] += intensity
+					},
+				),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+
+	for _, kind := range []common.ComputationKind{
+		common.ComputationKindStringSplit,
+		common.ComputationKindStringReplaceAll,
+		common.ComputationKindStringContains,
+		common.ComputationKindStringIndex,
+		common.ComputationKindStringToUpper,
+		common.ComputationKindStringTrim,
+		common.ComputationKindStringSlice,
+		common.ComputationKindStringToLower,
+	} {
+		require.Equal(t, uint(5), computation[kind], kind.String())
+	}
+
+	require.Equal(t, uint(3), computation[common.ComputationKindStringJoin])
+	require.Equal(t, uint(8), computation[common.ComputationKindStringConcat])
+	require.Equal(t, uint(4), computation[common.ComputationKindStringDecodeHex])
+	require.Equal(t, uint(3), computation[common.ComputationKindStringEncodeHex])
+}
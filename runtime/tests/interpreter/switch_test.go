@@ -100,10 +100,10 @@ func TestInterpretSwitchStatement(t *testing.T) {
 		require.NoError(t, err)
 
 		for argument, expected := range map[interpreter.Value]interpreter.Value{
-			interpreter.NewIntValueFromInt64(1): interpreter.NewStringValue("1"),
-			interpreter.NewIntValueFromInt64(2): interpreter.NewStringValue("2"),
-			interpreter.NewIntValueFromInt64(3): interpreter.NewStringValue("3"),
-			interpreter.NewIntValueFromInt64(4): interpreter.NewStringValue("3"),
+			interpreter.NewIntValueFromInt64(1): interpreter.NewUnmeteredStringValue("1"),
+			interpreter.NewIntValueFromInt64(2): interpreter.NewUnmeteredStringValue("2"),
+			interpreter.NewIntValueFromInt64(3): interpreter.NewUnmeteredStringValue("3"),
+			interpreter.NewIntValueFromInt64(4): interpreter.NewUnmeteredStringValue("3"),
 		} {
 
 			actual, err := inter.Invoke("test", argument)
@@ -141,10 +141,10 @@ func TestInterpretSwitchStatement(t *testing.T) {
 		require.NoError(t, err)
 
 		for argument, expected := range map[interpreter.Value]interpreter.Value{
-			interpreter.NewIntValueFromInt64(1): interpreter.NewStringValue("4"),
-			interpreter.NewIntValueFromInt64(2): interpreter.NewStringValue("2"),
-			interpreter.NewIntValueFromInt64(3): interpreter.NewStringValue("3"),
-			interpreter.NewIntValueFromInt64(4): interpreter.NewStringValue("3"),
+			interpreter.NewIntValueFromInt64(1): interpreter.NewUnmeteredStringValue("4"),
+			interpreter.NewIntValueFromInt64(2): interpreter.NewUnmeteredStringValue("2"),
+			interpreter.NewIntValueFromInt64(3): interpreter.NewUnmeteredStringValue("3"),
+			interpreter.NewIntValueFromInt64(4): interpreter.NewUnmeteredStringValue("3"),
 		} {
 
 			actual, err := inter.Invoke("test", argument)
@@ -173,16 +173,16 @@ func TestInterpretSwitchStatement(t *testing.T) {
 
 		for argument, expectedValues := range map[interpreter.Value][]interpreter.Value{
 			interpreter.NewIntValueFromInt64(1): {
-				interpreter.NewStringValue("1"),
+				interpreter.NewUnmeteredStringValue("1"),
 			},
 			interpreter.NewIntValueFromInt64(2): {
-				interpreter.NewStringValue("2"),
+				interpreter.NewUnmeteredStringValue("2"),
 			},
 			interpreter.NewIntValueFromInt64(3): {
-				interpreter.NewStringValue("3"),
+				interpreter.NewUnmeteredStringValue("3"),
 			},
 			interpreter.NewIntValueFromInt64(4): {
-				interpreter.NewStringValue("3"),
+				interpreter.NewUnmeteredStringValue("3"),
 			},
 		} {
 
@@ -242,7 +242,7 @@ func TestInterpretSwitchStatement(t *testing.T) {
 						interpreter.NewIntValueFromInt64(1),
 					),
 				},
-				interpreter.NewStringValue("1"),
+				interpreter.NewUnmeteredStringValue("1"),
 			},
 			{
 				[]interpreter.Value{
@@ -251,7 +251,7 @@ func TestInterpretSwitchStatement(t *testing.T) {
 						interpreter.NewIntValueFromInt64(1),
 					),
 				},
-				interpreter.NewStringValue("2"),
+				interpreter.NewUnmeteredStringValue("2"),
 			},
 			{
 				[]interpreter.Value{
@@ -262,7 +262,7 @@ func TestInterpretSwitchStatement(t *testing.T) {
 						interpreter.NewIntValueFromInt64(2),
 					),
 				},
-				interpreter.NewStringValue("3"),
+				interpreter.NewUnmeteredStringValue("3"),
 			},
 		} {
 			actual, err := inter.Invoke("test", testCase.arguments...)
@@ -22,6 +22,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math/big"
+	"strconv"
 	"unicode/utf8"
 
 	"github.com/onflow/cadence/fixedpoint"
@@ -1284,6 +1285,9 @@ type Capability struct {
 	Path       Path
 	Address    Address
 	BorrowType Type
+	// ID is the ID of the capability, if it was issued through a capability controller,
+	// or 0 if the capability is the capability of a link
+	ID uint64
 }
 
 func (Capability) isValue() {}
@@ -1297,6 +1301,14 @@ func (Capability) ToGoValue() interface{} {
 }
 
 func (v Capability) String() string {
+	if v.ID != 0 {
+		return format.ControllerCapability(
+			v.BorrowType.ID(),
+			v.Address.String(),
+			strconv.FormatUint(v.ID, 10),
+		)
+	}
+
 	return format.Capability(
 		v.BorrowType.ID(),
 		v.Address.String(),
@@ -111,7 +111,7 @@ func NewVM(wasm []byte) (VM, error) {
 
 		bytes := C.GoBytes(mem.Data(), C.int(length))
 
-		return interpreter.NewStringValue(string(bytes)), nil
+		return interpreter.NewUnmeteredStringValue(string(bytes)), nil
 	})
 
 	addFunc := wasmtime.WrapFunc(store, func(left, right interface{}) (interface{}, *wasmtime.Trap) {
@@ -125,7 +125,7 @@ func NewVM(wasm []byte) (VM, error) {
 			return nil, wasmtime.NewTrap(store, fmt.Sprintf("add: invalid right: %#+v", right))
 		}
 
-		return leftNumber.Plus(rightNumber), nil
+		return leftNumber.Plus(nil, rightNumber), nil
 	})
 
 	// NOTE: wasmtime currently does not support specifying imports by name,